@@ -24,21 +24,31 @@ import (
 	"github.com/memohai/memoh/internal/acl"
 	agentpkg "github.com/memohai/memoh/internal/agent"
 	agenttools "github.com/memohai/memoh/internal/agent/tools"
+	"github.com/memohai/memoh/internal/apikeys"
+	"github.com/memohai/memoh/internal/approval"
 	"github.com/memohai/memoh/internal/bind"
 	"github.com/memohai/memoh/internal/boot"
 	"github.com/memohai/memoh/internal/bots"
 	"github.com/memohai/memoh/internal/browsercontexts"
+	"github.com/memohai/memoh/internal/budget"
 	"github.com/memohai/memoh/internal/channel"
 	"github.com/memohai/memoh/internal/channel/adapters/discord"
 	"github.com/memohai/memoh/internal/channel/adapters/feishu"
+	"github.com/memohai/memoh/internal/channel/adapters/irc"
 	"github.com/memohai/memoh/internal/channel/adapters/local"
 	"github.com/memohai/memoh/internal/channel/adapters/matrix"
+	"github.com/memohai/memoh/internal/channel/adapters/mattermost"
 	"github.com/memohai/memoh/internal/channel/adapters/qq"
+	"github.com/memohai/memoh/internal/channel/adapters/signal"
+	"github.com/memohai/memoh/internal/channel/adapters/slack"
 	"github.com/memohai/memoh/internal/channel/adapters/telegram"
+	"github.com/memohai/memoh/internal/channel/adapters/webhook"
 	"github.com/memohai/memoh/internal/channel/adapters/wecom"
+	"github.com/memohai/memoh/internal/channel/adapters/xmpp"
 	"github.com/memohai/memoh/internal/channel/identities"
 	"github.com/memohai/memoh/internal/channel/inbound"
 	"github.com/memohai/memoh/internal/channel/route"
+	"github.com/memohai/memoh/internal/channel/trigger"
 	"github.com/memohai/memoh/internal/command"
 	"github.com/memohai/memoh/internal/compaction"
 	"github.com/memohai/memoh/internal/config"
@@ -47,10 +57,12 @@ import (
 	"github.com/memohai/memoh/internal/conversation/flow"
 	"github.com/memohai/memoh/internal/db"
 	dbsqlc "github.com/memohai/memoh/internal/db/sqlc"
+	"github.com/memohai/memoh/internal/document"
 	emailpkg "github.com/memohai/memoh/internal/email"
 	emailgeneric "github.com/memohai/memoh/internal/email/adapters/generic"
 	emailgmail "github.com/memohai/memoh/internal/email/adapters/gmail"
 	emailmailgun "github.com/memohai/memoh/internal/email/adapters/mailgun"
+	"github.com/memohai/memoh/internal/eventtrigger"
 	"github.com/memohai/memoh/internal/handlers"
 	"github.com/memohai/memoh/internal/healthcheck"
 	channelchecker "github.com/memohai/memoh/internal/healthcheck/checkers/channel"
@@ -70,6 +82,7 @@ import (
 	"github.com/memohai/memoh/internal/message/event"
 	"github.com/memohai/memoh/internal/messaging"
 	"github.com/memohai/memoh/internal/models"
+	"github.com/memohai/memoh/internal/netguard"
 	"github.com/memohai/memoh/internal/policy"
 	"github.com/memohai/memoh/internal/providers"
 	"github.com/memohai/memoh/internal/registry"
@@ -79,6 +92,8 @@ import (
 	sessionpkg "github.com/memohai/memoh/internal/session"
 	"github.com/memohai/memoh/internal/settings"
 	"github.com/memohai/memoh/internal/storage/providers/containerfs"
+	sttpkg "github.com/memohai/memoh/internal/stt"
+	sttopenai "github.com/memohai/memoh/internal/stt/adapter/openai"
 	ttspkg "github.com/memohai/memoh/internal/tts"
 	ttsedge "github.com/memohai/memoh/internal/tts/adapter/edge"
 	"github.com/memohai/memoh/internal/version"
@@ -178,6 +193,10 @@ func runServe() {
 			ttspkg.NewService,
 			provideTtsTempStore,
 
+			// stt infrastructure
+			provideSttRegistry,
+			sttpkg.NewService,
+
 			// email infrastructure
 			emailpkg.NewDBOAuthTokenStore,
 			provideEmailRegistry,
@@ -192,6 +211,7 @@ func runServe() {
 			provideSessionService,
 			provideMessageService,
 			provideMediaService,
+			provideDocumentService,
 
 			// channel infrastructure
 			local.NewRouteHub,
@@ -208,9 +228,16 @@ func runServe() {
 			provideHeartbeatSessionCreator,
 			provideScheduleSessionCreator,
 			schedule.NewService,
+			provideEventTriggerTriggerer,
+			provideEventTriggerSessionCreator,
+			eventtrigger.NewService,
 			provideHeartbeatTriggerer,
 			heartbeat.NewService,
 			compaction.NewService,
+			budget.NewService,
+			approval.NewService,
+			trigger.NewService,
+			apikeys.NewService,
 
 			// containerd handler & tool gateway
 			provideContainerdHandler,
@@ -232,14 +259,17 @@ func runServe() {
 			provideServerHandler(handlers.NewACLHandler),
 			provideServerHandler(handlers.NewBindHandler),
 			provideServerHandler(handlers.NewScheduleHandler),
+			provideServerHandler(handlers.NewEventTriggerHandler),
 			provideServerHandler(handlers.NewHeartbeatHandler),
 			provideServerHandler(handlers.NewCompactionHandler),
 			provideServerHandler(handlers.NewChannelHandler),
 			provideServerHandler(feishu.NewWebhookServerHandler),
+			provideServerHandler(webhook.NewWebhookServerHandler),
 			provideServerHandler(provideUsersHandler),
 			provideServerHandler(handlers.NewMemoryProvidersHandler),
 			provideServerHandler(handlers.NewTtsProvidersHandler),
 			provideServerHandler(handlers.NewBotTtsHandler),
+			provideServerHandler(handlers.NewSttProvidersHandler),
 			provideServerHandler(handlers.NewEmailProvidersHandler),
 			provideServerHandler(handlers.NewEmailBindingsHandler),
 			provideServerHandler(handlers.NewEmailOutboxHandler),
@@ -249,6 +279,11 @@ func runServe() {
 			provideServerHandler(handlers.NewMCPOAuthHandler),
 			provideOAuthService,
 			provideServerHandler(handlers.NewTokenUsageHandler),
+			provideServerHandler(handlers.NewTokenBudgetHandler),
+			provideServerHandler(handlers.NewToolApprovalHandler),
+			provideServerHandler(handlers.NewTriggerPolicyHandler),
+			provideServerHandler(handlers.NewAPIKeysHandler),
+			provideServerHandler(provideOpenAICompatHandler),
 			provideServerHandler(handlers.NewBrowserContextsHandler),
 			provideServerHandler(provideCLIHandler),
 			provideServerHandler(provideWebHandler),
@@ -257,9 +292,14 @@ func runServe() {
 		),
 		fx.Invoke(
 			injectToolProviders,
+			injectApprovalNotifier,
+			injectScheduleDelivery,
+			injectEventTriggerSources,
+			injectEgressHosts,
 			startRegistrySync,
 			startMemoryProviderBootstrap,
 			startScheduleService,
+			startEventTriggerService,
 			startHeartbeatService,
 			startChannelManager,
 			startEmailManager,
@@ -352,12 +392,12 @@ func provideMemoryLLM(modelsService *models.Service, queries *dbsqlc.Queries, lo
 	}
 }
 
-func provideMemoryProviderRegistry(log *slog.Logger, chatService *conversation.Service, accountService *accounts.Service, manager *workspace.Manager, queries *dbsqlc.Queries, cfg config.Config) *memprovider.Registry {
+func provideMemoryProviderRegistry(log *slog.Logger, chatService *conversation.Service, accountService *accounts.Service, manager *workspace.Manager, queries *dbsqlc.Queries, conn *pgxpool.Pool, cfg config.Config) *memprovider.Registry {
 	registry := memprovider.NewRegistry(log)
 	fileRuntime := handlers.NewBuiltinMemoryRuntime(manager)
 	fileStore := storefs.New(log, manager)
 	registry.RegisterFactory(string(memprovider.ProviderBuiltin), func(_ string, providerConfig map[string]any) (memprovider.Provider, error) {
-		runtime, err := membuiltin.NewBuiltinRuntimeFromConfig(log, providerConfig, fileRuntime, fileStore, queries, cfg)
+		runtime, err := membuiltin.NewBuiltinRuntimeFromConfig(log, providerConfig, fileRuntime, fileStore, queries, conn, cfg)
 		if err != nil {
 			return nil, err
 		}
@@ -393,6 +433,10 @@ func provideScheduleTriggerer(resolver *flow.Resolver) schedule.Triggerer {
 	return flow.NewScheduleGateway(resolver)
 }
 
+func provideEventTriggerTriggerer(resolver *flow.Resolver) eventtrigger.Triggerer {
+	return flow.NewEventTriggerGateway(resolver)
+}
+
 func provideHeartbeatTriggerer(resolver *flow.Resolver) heartbeat.Triggerer {
 	return flow.NewHeartbeatGateway(resolver)
 }
@@ -420,13 +464,37 @@ func provideScheduleSessionCreator(sessionService *sessionpkg.Service) schedule.
 	return &sessionCreatorAdapter{svc: sessionService}
 }
 
+func provideEventTriggerSessionCreator(sessionService *sessionpkg.Service) eventtrigger.SessionCreator {
+	return &sessionCreatorAdapter{svc: sessionService}
+}
+
+// mcpNotificationWatcher resolves a bot's MCP connection and watches its
+// notifications through the federation gateway.
+type mcpNotificationWatcher struct {
+	connections *mcp.ConnectionService
+	gateway     *handlers.MCPFederationGateway
+}
+
+func (w *mcpNotificationWatcher) WatchNotifications(ctx context.Context, botID, connectionID, resourceURI string, handle func(method string, params any)) error {
+	connection, err := w.connections.Get(ctx, botID, connectionID)
+	if err != nil {
+		return err
+	}
+	if !connection.Active {
+		return errors.New("mcp connection is inactive")
+	}
+	return w.gateway.WatchConnectionNotifications(ctx, connection, resourceURI, handle)
+}
+
 // ---------------------------------------------------------------------------
 // conversation flow
 // ---------------------------------------------------------------------------
 
-func provideAgent(log *slog.Logger, manager *workspace.Manager) *agentpkg.Agent {
+func provideAgent(log *slog.Logger, manager *workspace.Manager, aclService *acl.Service, approvalService *approval.Service) *agentpkg.Agent {
 	return agentpkg.New(agentpkg.Deps{
 		BridgeProvider: manager,
+		ToolPolicy:     aclService,
+		Approvals:      approvalService,
 		Logger:         log,
 	})
 }
@@ -443,7 +511,32 @@ func injectToolProviders(a *agentpkg.Agent, msgService *message.DBService, provi
 	}
 }
 
-func provideChatResolver(log *slog.Logger, a *agentpkg.Agent, modelsService *models.Service, queries *dbsqlc.Queries, chatService *conversation.Service, msgService *message.DBService, settingsService *settings.Service, mediaService *media.Service, containerdHandler *handlers.ContainerdHandler, memoryRegistry *memprovider.Registry, sessionService *sessionpkg.Service, eventHub *event.Hub, compactionService *compaction.Service) *flow.Resolver {
+// injectApprovalNotifier lets the approval service prompt owners through the
+// channel manager, which depends on the agent and cannot be injected directly.
+func injectApprovalNotifier(approvalService *approval.Service, channelManager *channel.Manager, registry *channel.Registry) {
+	approvalService.SetNotifier(channelManager, registry)
+}
+
+// injectScheduleDelivery lets schedules deliver final answers through the
+// channel manager without a dependency cycle.
+func injectScheduleDelivery(scheduleService *schedule.Service, channelManager *channel.Manager, registry *channel.Registry) {
+	scheduleService.SetDelivery(channelManager, registry)
+}
+
+// injectEventTriggerSources connects event triggers to the container
+// bridge, MCP connections and inbound email without a dependency cycle.
+func injectEventTriggerSources(eventTriggerService *eventtrigger.Service, manager *workspace.Manager, mcpConnService *mcp.ConnectionService, fedGateway *handlers.MCPFederationGateway, emailTrigger *emailpkg.Trigger) {
+	eventTriggerService.SetSources(manager, &mcpNotificationWatcher{connections: mcpConnService, gateway: fedGateway})
+	emailTrigger.SetEventDispatcher(eventTriggerService)
+}
+
+// injectEgressHosts keeps the bot's MCP servers and the browser gateway
+// reachable from containers under an allowlist network policy.
+func injectEgressHosts(manager *workspace.Manager, mcpConnService *mcp.ConnectionService, cfg config.Config) {
+	manager.SetEgressHostSources(mcpConnService, workspace.StaticEgressHosts{cfg.BrowserGateway.Host})
+}
+
+func provideChatResolver(log *slog.Logger, a *agentpkg.Agent, modelsService *models.Service, queries *dbsqlc.Queries, chatService *conversation.Service, msgService *message.DBService, settingsService *settings.Service, mediaService *media.Service, containerdHandler *handlers.ContainerdHandler, memoryRegistry *memprovider.Registry, sessionService *sessionpkg.Service, eventHub *event.Hub, compactionService *compaction.Service, budgetService *budget.Service) *flow.Resolver {
 	resolver := flow.NewResolver(log, modelsService, queries, chatService, msgService, settingsService, a, 120*time.Second)
 	resolver.SetMemoryRegistry(memoryRegistry)
 	resolver.SetSkillLoader(&skillLoaderAdapter{handler: containerdHandler})
@@ -451,6 +544,7 @@ func provideChatResolver(log *slog.Logger, a *agentpkg.Agent, modelsService *mod
 	resolver.SetSessionService(sessionService)
 	resolver.SetEventPublisher(eventHub)
 	resolver.SetCompactionService(compactionService)
+	resolver.SetBudgetService(budgetService)
 	return resolver
 }
 
@@ -458,8 +552,9 @@ func provideChatResolver(log *slog.Logger, a *agentpkg.Agent, modelsService *mod
 // channel providers
 // ---------------------------------------------------------------------------
 
-func provideChannelRegistry(log *slog.Logger, hub *local.RouteHub, mediaService *media.Service) *channel.Registry {
+func provideChannelRegistry(log *slog.Logger, hub *local.RouteHub, mediaService *media.Service, settingsService *settings.Service) *channel.Registry {
 	registry := channel.NewRegistry()
+	fetchPolicies := &settingsFetchPolicyResolver{settings: settingsService}
 
 	// Telegram
 	tgAdapter := telegram.NewTelegramAdapter(log)
@@ -477,6 +572,23 @@ func provideChannelRegistry(log *slog.Logger, hub *local.RouteHub, mediaService
 	matrixAdapter := matrix.NewMatrixAdapter(log)
 	matrixAdapter.SetAssetOpener(mediaService)
 	registry.MustRegister(matrixAdapter)
+	slackAdapter := slack.NewSlackAdapter(log)
+	slackAdapter.SetAssetOpener(mediaService)
+	slackAdapter.SetFetchPolicyResolver(fetchPolicies)
+	registry.MustRegister(slackAdapter)
+	mattermostAdapter := mattermost.NewMattermostAdapter(log)
+	mattermostAdapter.SetAssetOpener(mediaService)
+	mattermostAdapter.SetFetchPolicyResolver(fetchPolicies)
+	registry.MustRegister(mattermostAdapter)
+	registry.MustRegister(irc.NewIRCAdapter(log))
+	registry.MustRegister(xmpp.NewXMPPAdapter(log))
+	signalAdapter := signal.NewSignalAdapter(log)
+	signalAdapter.SetAssetOpener(mediaService)
+	signalAdapter.SetFetchPolicyResolver(fetchPolicies)
+	registry.MustRegister(signalAdapter)
+	webhookAdapter := webhook.NewWebhookAdapter(log)
+	webhookAdapter.SetAssetOpener(mediaService)
+	registry.MustRegister(webhookAdapter)
 
 	feishuAdapter := feishu.NewFeishuAdapter(log)
 	feishuAdapter.SetAssetOpener(mediaService)
@@ -498,10 +610,14 @@ func provideChannelRouter(
 	identityService *identities.Service,
 	botService *bots.Service,
 	aclService *acl.Service,
+	approvalService *approval.Service,
+	triggerService *trigger.Service,
 	policyService *policy.Service,
 	bindService *bind.Service,
 	mediaService *media.Service,
+	documentService *document.Service,
 	ttsService *ttspkg.Service,
+	sttService *sttpkg.Service,
 	settingsService *settings.Service,
 	scheduleService *schedule.Service,
 	mcpConnService *mcp.ConnectionService,
@@ -532,9 +648,14 @@ func provideChannelRouter(
 	processor := inbound.NewChannelInboundProcessor(log, registry, routeService, msgService, resolver, identityService, policyService, bindService, rc.JwtSecret, 5*time.Minute)
 	processor.SetSessionEnsurer(&sessionEnsurerAdapter{svc: sessionService})
 	processor.SetACLService(aclService)
+	processor.SetApprovalService(approvalService)
+	processor.SetTriggerPolicies(triggerService, resolver)
 	processor.SetMediaService(mediaService)
 	processor.SetStreamObserver(local.NewRouteHubBroadcaster(hub))
 	processor.SetTtsService(ttsService, &settingsTtsModelResolver{settings: settingsService})
+	processor.SetSttService(sttService, &settingsSttModelResolver{settings: settingsService})
+	processor.SetFetchPolicyResolver(&settingsFetchPolicyResolver{settings: settingsService})
+	processor.SetDocumentExtractor(documentService)
 	processor.SetCommandHandler(command.NewHandler(
 		log,
 		&command.BotMemberRoleAdapter{BotService: botService},
@@ -607,7 +728,7 @@ func provideToolGatewayService(log *slog.Logger, fedGateway *handlers.MCPFederat
 	return svc
 }
 
-func provideToolProviders(log *slog.Logger, cfg config.Config, channelManager *channel.Manager, registry *channel.Registry, routeService *route.DBService, scheduleService *schedule.Service, settingsService *settings.Service, searchProviderService *searchproviders.Service, manager *workspace.Manager, mediaService *media.Service, memoryRegistry *memprovider.Registry, emailService *emailpkg.Service, emailManager *emailpkg.Manager, fedGateway *handlers.MCPFederationGateway, mcpConnService *mcp.ConnectionService, modelsService *models.Service, browserContextService *browsercontexts.Service, queries *dbsqlc.Queries, ttsService *ttspkg.Service, sessionService *sessionpkg.Service) []agenttools.ToolProvider {
+func provideToolProviders(log *slog.Logger, cfg config.Config, channelManager *channel.Manager, registry *channel.Registry, routeService *route.DBService, scheduleService *schedule.Service, settingsService *settings.Service, searchProviderService *searchproviders.Service, manager *workspace.Manager, mediaService *media.Service, documentService *document.Service, memoryRegistry *memprovider.Registry, emailService *emailpkg.Service, emailManager *emailpkg.Manager, fedGateway *handlers.MCPFederationGateway, mcpConnService *mcp.ConnectionService, modelsService *models.Service, browserContextService *browsercontexts.Service, queries *dbsqlc.Queries, ttsService *ttspkg.Service, sessionService *sessionpkg.Service) []agenttools.ToolProvider {
 	var assetResolver messaging.AssetResolver
 	if mediaService != nil {
 		assetResolver = &mediaAssetResolverAdapter{media: mediaService}
@@ -621,8 +742,9 @@ func provideToolProviders(log *slog.Logger, cfg config.Config, channelManager *c
 		agenttools.NewWebProvider(log, settingsService, searchProviderService),
 		agenttools.NewContainerProvider(log, manager, config.DefaultDataMount),
 		agenttools.NewReadMediaProvider(log, manager, config.DefaultDataMount),
+		agenttools.NewReadDocumentProvider(log, manager, documentService, config.DefaultDataMount),
 		agenttools.NewEmailProvider(log, emailService, emailManager),
-		agenttools.NewWebFetchProvider(log),
+		agenttools.NewWebFetchProvider(log, settingsService),
 		agenttools.NewSpawnProvider(log, settingsService, modelsService, queries, sessionService),
 		agenttools.NewSkillProvider(log),
 		agenttools.NewBrowserProvider(log, settingsService, browserContextService, manager, cfg.BrowserGateway),
@@ -663,6 +785,10 @@ func provideMediaService(log *slog.Logger, manager *workspace.Manager) *media.Se
 	return media.NewService(log, provider)
 }
 
+func provideDocumentService(log *slog.Logger, mediaService *media.Service) *document.Service {
+	return document.NewService(log, mediaService)
+}
+
 func provideUsersHandler(log *slog.Logger, accountService *accounts.Service, identityService *identities.Service, botService *bots.Service, routeService *route.DBService, channelStore *channel.Store, channelLifecycle *channel.Lifecycle, channelManager *channel.Manager, registry *channel.Registry) *handlers.UsersHandler {
 	return handlers.NewUsersHandler(log, accountService, identityService, botService, routeService, channelStore, channelLifecycle, channelManager, registry)
 }
@@ -683,6 +809,12 @@ func provideWebHandler(channelManager *channel.Manager, channelStore *channel.St
 	return h
 }
 
+func provideOpenAICompatHandler(log *slog.Logger, botService *bots.Service, accountService *accounts.Service, sessionService *sessionpkg.Service, cfg config.Config, resolver *flow.Resolver) *handlers.OpenAICompatHandler {
+	h := handlers.NewOpenAICompatHandler(log, botService, accountService, sessionService, cfg)
+	h.SetResolver(resolver)
+	return h
+}
+
 // ---------------------------------------------------------------------------
 // email providers
 // ---------------------------------------------------------------------------
@@ -693,6 +825,12 @@ func provideTtsRegistry(log *slog.Logger) *ttspkg.Registry {
 	return reg
 }
 
+func provideSttRegistry(log *slog.Logger) *sttpkg.Registry {
+	reg := sttpkg.NewRegistry()
+	reg.Register(sttopenai.NewOpenAIAdapter(log))
+	return reg
+}
+
 func provideTtsTempStore() (*ttspkg.TempStore, error) {
 	return ttspkg.NewTempStore(os.TempDir())
 }
@@ -746,6 +884,34 @@ func (r *settingsTtsModelResolver) ResolveTtsModelID(ctx context.Context, botID
 	return s.TtsModelID, nil
 }
 
+// settingsSttModelResolver adapts settings.Service to the sttModelResolver
+// interface expected by ChannelInboundProcessor.
+type settingsSttModelResolver struct {
+	settings *settings.Service
+}
+
+func (r *settingsSttModelResolver) ResolveSttModelID(ctx context.Context, botID string) (string, error) {
+	s, err := r.settings.GetBot(ctx, botID)
+	if err != nil {
+		return "", err
+	}
+	return s.SttModelID, nil
+}
+
+// settingsFetchPolicyResolver adapts settings.Service to the
+// channel.FetchPolicyResolver interface used for attachment downloads.
+type settingsFetchPolicyResolver struct {
+	settings *settings.Service
+}
+
+func (r *settingsFetchPolicyResolver) ResolveFetchPolicy(ctx context.Context, botID string) (netguard.Policy, error) {
+	s, err := r.settings.GetBot(ctx, botID)
+	if err != nil {
+		return netguard.Policy{}, err
+	}
+	return s.FetchPolicy(), nil
+}
+
 func provideEmailRegistry(log *slog.Logger, tokenStore *emailpkg.DBOAuthTokenStore) *emailpkg.Registry {
 	reg := emailpkg.NewRegistry()
 	reg.Register(emailgeneric.New(log))
@@ -806,13 +972,14 @@ type serverParams struct {
 	Config            config.Config
 	ServerHandlers    []server.Handler `group:"server_handlers"`
 	ContainerdHandler *handlers.ContainerdHandler
+	APIKeys           *apikeys.Service
 }
 
 func provideServer(params serverParams) *server.Server {
 	allHandlers := make([]server.Handler, 0, len(params.ServerHandlers)+1)
 	allHandlers = append(allHandlers, params.ServerHandlers...)
 	allHandlers = append(allHandlers, params.ContainerdHandler)
-	return server.NewServer(params.Logger, params.RuntimeConfig.ServerAddr, params.Config.Auth.JWTSecret, allHandlers...)
+	return server.NewServer(params.Logger, params.RuntimeConfig.ServerAddr, params.Config.Auth.JWTSecret, params.APIKeys, allHandlers...)
 }
 
 // ---------------------------------------------------------------------------
@@ -862,6 +1029,18 @@ func startScheduleService(lc fx.Lifecycle, scheduleService *schedule.Service) {
 	})
 }
 
+func startEventTriggerService(lc fx.Lifecycle, eventTriggerService *eventtrigger.Service) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return eventTriggerService.Bootstrap(ctx)
+		},
+		OnStop: func(_ context.Context) error {
+			eventTriggerService.Stop()
+			return nil
+		},
+	})
+}
+
 func startHeartbeatService(lc fx.Lifecycle, heartbeatService *heartbeat.Service) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
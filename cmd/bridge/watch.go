@@ -0,0 +1,142 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/memohai/memoh/internal/workspace/bridgepb"
+)
+
+const (
+	watchDefaultInterval = 2 * time.Second
+	watchMinInterval     = 250 * time.Millisecond
+	// watchMaxEntries bounds a single snapshot so watching a huge tree
+	// cannot exhaust the container's memory.
+	watchMaxEntries = 10000
+)
+
+// fileState is what a watch compares between two polls.
+type fileState struct {
+	isDir   bool
+	size    int64
+	modTime time.Time
+}
+
+// Watch polls path and streams create, modify and delete events until the
+// client cancels. Polling keeps the bridge free of platform-specific
+// notification APIs and works on every filesystem a container may mount.
+func (*containerServer) Watch(req *pb.WatchRequest, stream pb.ContainerService_WatchServer) error {
+	root := req.GetPath()
+	if root == "" {
+		return status.Error(codes.InvalidArgument, "path is required")
+	}
+	root = resolvePath(root)
+	interval := time.Duration(req.GetIntervalMs()) * time.Millisecond
+	if interval <= 0 {
+		interval = watchDefaultInterval
+	}
+	interval = max(interval, watchMinInterval)
+
+	prev := snapshotTree(root, req.GetRecursive())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+		next := snapshotTree(root, req.GetRecursive())
+		for _, event := range diffSnapshots(prev, next) {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+		prev = next
+	}
+}
+
+// snapshotTree records the state of root and the entries below it. A
+// missing root yields an empty snapshot so its creation is reported later.
+func snapshotTree(root string, recursive bool) map[string]fileState {
+	out := map[string]fileState{}
+	info, err := os.Stat(root)
+	if err != nil {
+		return out
+	}
+	if !info.IsDir() {
+		out[root] = fileState{size: info.Size(), modTime: info.ModTime()}
+		return out
+	}
+	if !recursive {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			return out
+		}
+		for _, d := range entries {
+			if len(out) >= watchMaxEntries {
+				break
+			}
+			if entryInfo, err := d.Info(); err == nil {
+				out[filepath.Join(root, d.Name())] = stateOf(entryInfo)
+			}
+		}
+		return out
+	}
+	_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == root {
+			return nil
+		}
+		if len(out) >= watchMaxEntries {
+			return filepath.SkipAll
+		}
+		if entryInfo, err := d.Info(); err == nil {
+			out[p] = stateOf(entryInfo)
+		}
+		return nil
+	})
+	return out
+}
+
+func stateOf(info fs.FileInfo) fileState {
+	return fileState{isDir: info.IsDir(), size: info.Size(), modTime: info.ModTime()}
+}
+
+// diffSnapshots returns the events that turn prev into next, ordered by
+// path. Directories only report creation and deletion; their mtime changes
+// whenever a child does, which the child's own event already covers.
+func diffSnapshots(prev, next map[string]fileState) []*pb.WatchEvent {
+	var events []*pb.WatchEvent
+	for p, cur := range next {
+		old, ok := prev[p]
+		switch {
+		case !ok:
+			events = append(events, watchEvent(pb.WatchEvent_CREATE, p, cur))
+		case !cur.isDir && (cur.size != old.size || !cur.modTime.Equal(old.modTime)):
+			events = append(events, watchEvent(pb.WatchEvent_MODIFY, p, cur))
+		}
+	}
+	for p, old := range prev {
+		if _, ok := next[p]; !ok {
+			events = append(events, watchEvent(pb.WatchEvent_DELETE, p, old))
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].GetPath() < events[j].GetPath() })
+	return events
+}
+
+func watchEvent(op pb.WatchEvent_Op, path string, state fileState) *pb.WatchEvent {
+	return &pb.WatchEvent{
+		Op:      op,
+		Path:    path,
+		IsDir:   state.isDir,
+		Size:    state.size,
+		ModTime: state.modTime.Format(time.RFC3339),
+	}
+}
@@ -24,22 +24,32 @@ import (
 	"github.com/memohai/memoh/internal/acl"
 	agentpkg "github.com/memohai/memoh/internal/agent"
 	agenttools "github.com/memohai/memoh/internal/agent/tools"
+	"github.com/memohai/memoh/internal/apikeys"
+	"github.com/memohai/memoh/internal/approval"
 	"github.com/memohai/memoh/internal/auth"
 	"github.com/memohai/memoh/internal/bind"
 	"github.com/memohai/memoh/internal/boot"
 	"github.com/memohai/memoh/internal/bots"
 	"github.com/memohai/memoh/internal/browsercontexts"
+	"github.com/memohai/memoh/internal/budget"
 	"github.com/memohai/memoh/internal/channel"
 	"github.com/memohai/memoh/internal/channel/adapters/discord"
 	"github.com/memohai/memoh/internal/channel/adapters/feishu"
+	"github.com/memohai/memoh/internal/channel/adapters/irc"
 	"github.com/memohai/memoh/internal/channel/adapters/local"
 	"github.com/memohai/memoh/internal/channel/adapters/matrix"
+	"github.com/memohai/memoh/internal/channel/adapters/mattermost"
 	"github.com/memohai/memoh/internal/channel/adapters/qq"
+	"github.com/memohai/memoh/internal/channel/adapters/signal"
+	"github.com/memohai/memoh/internal/channel/adapters/slack"
 	"github.com/memohai/memoh/internal/channel/adapters/telegram"
+	"github.com/memohai/memoh/internal/channel/adapters/webhook"
 	"github.com/memohai/memoh/internal/channel/adapters/wecom"
+	"github.com/memohai/memoh/internal/channel/adapters/xmpp"
 	"github.com/memohai/memoh/internal/channel/identities"
 	"github.com/memohai/memoh/internal/channel/inbound"
 	"github.com/memohai/memoh/internal/channel/route"
+	"github.com/memohai/memoh/internal/channel/trigger"
 	"github.com/memohai/memoh/internal/command"
 	"github.com/memohai/memoh/internal/compaction"
 	"github.com/memohai/memoh/internal/config"
@@ -48,10 +58,12 @@ import (
 	"github.com/memohai/memoh/internal/conversation/flow"
 	"github.com/memohai/memoh/internal/db"
 	dbsqlc "github.com/memohai/memoh/internal/db/sqlc"
+	"github.com/memohai/memoh/internal/document"
 	emailpkg "github.com/memohai/memoh/internal/email"
 	emailgeneric "github.com/memohai/memoh/internal/email/adapters/generic"
 	emailgmail "github.com/memohai/memoh/internal/email/adapters/gmail"
 	emailmailgun "github.com/memohai/memoh/internal/email/adapters/mailgun"
+	"github.com/memohai/memoh/internal/eventtrigger"
 	"github.com/memohai/memoh/internal/handlers"
 	"github.com/memohai/memoh/internal/healthcheck"
 	channelchecker "github.com/memohai/memoh/internal/healthcheck/checkers/channel"
@@ -71,6 +83,7 @@ import (
 	"github.com/memohai/memoh/internal/message/event"
 	"github.com/memohai/memoh/internal/messaging"
 	"github.com/memohai/memoh/internal/models"
+	"github.com/memohai/memoh/internal/netguard"
 	"github.com/memohai/memoh/internal/policy"
 	"github.com/memohai/memoh/internal/providers"
 	"github.com/memohai/memoh/internal/registry"
@@ -80,6 +93,8 @@ import (
 	sessionpkg "github.com/memohai/memoh/internal/session"
 	"github.com/memohai/memoh/internal/settings"
 	"github.com/memohai/memoh/internal/storage/providers/containerfs"
+	sttpkg "github.com/memohai/memoh/internal/stt"
+	sttopenai "github.com/memohai/memoh/internal/stt/adapter/openai"
 	ttspkg "github.com/memohai/memoh/internal/tts"
 	ttsedge "github.com/memohai/memoh/internal/tts/adapter/edge"
 	"github.com/memohai/memoh/internal/version"
@@ -115,6 +130,8 @@ func runServe() {
 			provideTtsRegistry,
 			ttspkg.NewService,
 			provideTtsTempStore,
+			provideSttRegistry,
+			sttpkg.NewService,
 			provideEmailRegistry,
 			emailpkg.NewService,
 			emailpkg.NewOutboxService,
@@ -125,6 +142,7 @@ func runServe() {
 			provideSessionService,
 			provideMessageService,
 			provideMediaService,
+			provideDocumentService,
 			local.NewRouteHub,
 			provideChannelRegistry,
 			channel.NewStore,
@@ -138,9 +156,16 @@ func runServe() {
 			provideHeartbeatSessionCreator,
 			provideScheduleSessionCreator,
 			schedule.NewService,
+			provideEventTriggerTriggerer,
+			provideEventTriggerSessionCreator,
+			eventtrigger.NewService,
 			provideHeartbeatTriggerer,
 			heartbeat.NewService,
 			compaction.NewService,
+			budget.NewService,
+			approval.NewService,
+			trigger.NewService,
+			apikeys.NewService,
 			provideContainerdHandler,
 			provideFederationGateway,
 			provideToolGatewayService,
@@ -158,14 +183,17 @@ func runServe() {
 			provideServerHandler(handlers.NewACLHandler),
 			provideServerHandler(handlers.NewBindHandler),
 			provideServerHandler(handlers.NewScheduleHandler),
+			provideServerHandler(handlers.NewEventTriggerHandler),
 			provideServerHandler(handlers.NewHeartbeatHandler),
 			provideServerHandler(handlers.NewCompactionHandler),
 			provideServerHandler(handlers.NewChannelHandler),
 			provideServerHandler(feishu.NewWebhookServerHandler),
+			provideServerHandler(webhook.NewWebhookServerHandler),
 			provideServerHandler(provideUsersHandler),
 			provideServerHandler(handlers.NewMemoryProvidersHandler),
 			provideServerHandler(handlers.NewTtsProvidersHandler),
 			provideServerHandler(handlers.NewBotTtsHandler),
+			provideServerHandler(handlers.NewSttProvidersHandler),
 			provideServerHandler(handlers.NewEmailProvidersHandler),
 			provideServerHandler(handlers.NewEmailBindingsHandler),
 			provideServerHandler(handlers.NewEmailOutboxHandler),
@@ -176,6 +204,11 @@ func runServe() {
 			provideServerHandler(handlers.NewMCPOAuthHandler),
 			provideOAuthService,
 			provideServerHandler(handlers.NewTokenUsageHandler),
+			provideServerHandler(handlers.NewTokenBudgetHandler),
+			provideServerHandler(handlers.NewToolApprovalHandler),
+			provideServerHandler(handlers.NewTriggerPolicyHandler),
+			provideServerHandler(handlers.NewAPIKeysHandler),
+			provideServerHandler(provideOpenAICompatHandler),
 			provideServerHandler(handlers.NewBrowserContextsHandler),
 			provideServerHandler(provideCLIHandler),
 			provideServerHandler(provideWebHandler),
@@ -184,9 +217,14 @@ func runServe() {
 		),
 		fx.Invoke(
 			injectToolProviders,
+			injectApprovalNotifier,
+			injectScheduleDelivery,
+			injectEventTriggerSources,
+			injectEgressHosts,
 			startRegistrySync,
 			startMemoryProviderBootstrap,
 			startScheduleService,
+			startEventTriggerService,
 			startHeartbeatService,
 			startChannelManager,
 			startEmailManager,
@@ -249,12 +287,12 @@ func provideMemoryLLM(modelsService *models.Service, queries *dbsqlc.Queries, lo
 	return &lazyLLMClient{modelsService: modelsService, queries: queries, timeout: 30 * time.Second, logger: log}
 }
 
-func provideMemoryProviderRegistry(log *slog.Logger, chatService *conversation.Service, accountService *accounts.Service, manager *workspace.Manager, queries *dbsqlc.Queries, cfg config.Config) *memprovider.Registry {
+func provideMemoryProviderRegistry(log *slog.Logger, chatService *conversation.Service, accountService *accounts.Service, manager *workspace.Manager, queries *dbsqlc.Queries, conn *pgxpool.Pool, cfg config.Config) *memprovider.Registry {
 	registry := memprovider.NewRegistry(log)
 	builtinRuntime := handlers.NewBuiltinMemoryRuntime(manager)
 	fileStore := storefs.New(log, manager)
 	registry.RegisterFactory(string(memprovider.ProviderBuiltin), func(_ string, providerConfig map[string]any) (memprovider.Provider, error) {
-		runtime, err := membuiltin.NewBuiltinRuntimeFromConfig(log, providerConfig, builtinRuntime, fileStore, queries, cfg)
+		runtime, err := membuiltin.NewBuiltinRuntimeFromConfig(log, providerConfig, builtinRuntime, fileStore, queries, conn, cfg)
 		if err != nil {
 			return nil, err
 		}
@@ -321,6 +359,10 @@ func provideScheduleTriggerer(resolver *flow.Resolver) schedule.Triggerer {
 	return flow.NewScheduleGateway(resolver)
 }
 
+func provideEventTriggerTriggerer(resolver *flow.Resolver) eventtrigger.Triggerer {
+	return flow.NewEventTriggerGateway(resolver)
+}
+
 func provideHeartbeatTriggerer(resolver *flow.Resolver) heartbeat.Triggerer {
 	return flow.NewHeartbeatGateway(resolver)
 }
@@ -348,9 +390,33 @@ func provideScheduleSessionCreator(sessionService *sessionpkg.Service) schedule.
 	return &sessionCreatorAdapter{svc: sessionService}
 }
 
-func provideAgent(log *slog.Logger, manager *workspace.Manager) *agentpkg.Agent {
+func provideEventTriggerSessionCreator(sessionService *sessionpkg.Service) eventtrigger.SessionCreator {
+	return &sessionCreatorAdapter{svc: sessionService}
+}
+
+// mcpNotificationWatcher resolves a bot's MCP connection and watches its
+// notifications through the federation gateway.
+type mcpNotificationWatcher struct {
+	connections *mcp.ConnectionService
+	gateway     *handlers.MCPFederationGateway
+}
+
+func (w *mcpNotificationWatcher) WatchNotifications(ctx context.Context, botID, connectionID, resourceURI string, handle func(method string, params any)) error {
+	connection, err := w.connections.Get(ctx, botID, connectionID)
+	if err != nil {
+		return err
+	}
+	if !connection.Active {
+		return errors.New("mcp connection is inactive")
+	}
+	return w.gateway.WatchConnectionNotifications(ctx, connection, resourceURI, handle)
+}
+
+func provideAgent(log *slog.Logger, manager *workspace.Manager, aclService *acl.Service, approvalService *approval.Service) *agentpkg.Agent {
 	return agentpkg.New(agentpkg.Deps{
 		BridgeProvider: manager,
+		ToolPolicy:     aclService,
+		Approvals:      approvalService,
 		Logger:         log,
 	})
 }
@@ -367,7 +433,32 @@ func injectToolProviders(a *agentpkg.Agent, msgService *message.DBService, provi
 	}
 }
 
-func provideChatResolver(log *slog.Logger, a *agentpkg.Agent, modelsService *models.Service, queries *dbsqlc.Queries, chatService *conversation.Service, msgService *message.DBService, settingsService *settings.Service, mediaService *media.Service, containerdHandler *handlers.ContainerdHandler, memoryRegistry *memprovider.Registry, sessionService *sessionpkg.Service, eventHub *event.Hub, compactionService *compaction.Service) *flow.Resolver {
+// injectApprovalNotifier lets the approval service prompt owners through the
+// channel manager, which depends on the agent and cannot be injected directly.
+func injectApprovalNotifier(approvalService *approval.Service, channelManager *channel.Manager, registry *channel.Registry) {
+	approvalService.SetNotifier(channelManager, registry)
+}
+
+// injectScheduleDelivery lets schedules deliver final answers through the
+// channel manager without a dependency cycle.
+func injectScheduleDelivery(scheduleService *schedule.Service, channelManager *channel.Manager, registry *channel.Registry) {
+	scheduleService.SetDelivery(channelManager, registry)
+}
+
+// injectEventTriggerSources connects event triggers to the container
+// bridge, MCP connections and inbound email without a dependency cycle.
+func injectEventTriggerSources(eventTriggerService *eventtrigger.Service, manager *workspace.Manager, mcpConnService *mcp.ConnectionService, fedGateway *handlers.MCPFederationGateway, emailTrigger *emailpkg.Trigger) {
+	eventTriggerService.SetSources(manager, &mcpNotificationWatcher{connections: mcpConnService, gateway: fedGateway})
+	emailTrigger.SetEventDispatcher(eventTriggerService)
+}
+
+// injectEgressHosts keeps the bot's MCP servers and the browser gateway
+// reachable from containers under an allowlist network policy.
+func injectEgressHosts(manager *workspace.Manager, mcpConnService *mcp.ConnectionService, cfg config.Config) {
+	manager.SetEgressHostSources(mcpConnService, workspace.StaticEgressHosts{cfg.BrowserGateway.Host})
+}
+
+func provideChatResolver(log *slog.Logger, a *agentpkg.Agent, modelsService *models.Service, queries *dbsqlc.Queries, chatService *conversation.Service, msgService *message.DBService, settingsService *settings.Service, mediaService *media.Service, containerdHandler *handlers.ContainerdHandler, memoryRegistry *memprovider.Registry, sessionService *sessionpkg.Service, eventHub *event.Hub, compactionService *compaction.Service, budgetService *budget.Service) *flow.Resolver {
 	resolver := flow.NewResolver(log, modelsService, queries, chatService, msgService, settingsService, a, 120*time.Second)
 	resolver.SetMemoryRegistry(memoryRegistry)
 	resolver.SetSkillLoader(&skillLoaderAdapter{handler: containerdHandler})
@@ -375,11 +466,13 @@ func provideChatResolver(log *slog.Logger, a *agentpkg.Agent, modelsService *mod
 	resolver.SetSessionService(sessionService)
 	resolver.SetEventPublisher(eventHub)
 	resolver.SetCompactionService(compactionService)
+	resolver.SetBudgetService(budgetService)
 	return resolver
 }
 
-func provideChannelRegistry(log *slog.Logger, hub *local.RouteHub, mediaService *media.Service) *channel.Registry {
+func provideChannelRegistry(log *slog.Logger, hub *local.RouteHub, mediaService *media.Service, settingsService *settings.Service) *channel.Registry {
 	registry := channel.NewRegistry()
+	fetchPolicies := &settingsFetchPolicyResolver{settings: settingsService}
 	tgAdapter := telegram.NewTelegramAdapter(log)
 	tgAdapter.SetAssetOpener(mediaService)
 	registry.MustRegister(tgAdapter)
@@ -392,6 +485,23 @@ func provideChannelRegistry(log *slog.Logger, hub *local.RouteHub, mediaService
 	matrixAdapter := matrix.NewMatrixAdapter(log)
 	matrixAdapter.SetAssetOpener(mediaService)
 	registry.MustRegister(matrixAdapter)
+	slackAdapter := slack.NewSlackAdapter(log)
+	slackAdapter.SetAssetOpener(mediaService)
+	slackAdapter.SetFetchPolicyResolver(fetchPolicies)
+	registry.MustRegister(slackAdapter)
+	mattermostAdapter := mattermost.NewMattermostAdapter(log)
+	mattermostAdapter.SetAssetOpener(mediaService)
+	mattermostAdapter.SetFetchPolicyResolver(fetchPolicies)
+	registry.MustRegister(mattermostAdapter)
+	registry.MustRegister(irc.NewIRCAdapter(log))
+	registry.MustRegister(xmpp.NewXMPPAdapter(log))
+	signalAdapter := signal.NewSignalAdapter(log)
+	signalAdapter.SetAssetOpener(mediaService)
+	signalAdapter.SetFetchPolicyResolver(fetchPolicies)
+	registry.MustRegister(signalAdapter)
+	webhookAdapter := webhook.NewWebhookAdapter(log)
+	webhookAdapter.SetAssetOpener(mediaService)
+	registry.MustRegister(webhookAdapter)
 	feishuAdapter := feishu.NewFeishuAdapter(log)
 	feishuAdapter.SetAssetOpener(mediaService)
 	registry.MustRegister(feishuAdapter)
@@ -401,7 +511,7 @@ func provideChannelRegistry(log *slog.Logger, hub *local.RouteHub, mediaService
 	return registry
 }
 
-func provideChannelRouter(log *slog.Logger, registry *channel.Registry, hub *local.RouteHub, routeService *route.DBService, sessionService *sessionpkg.Service, msgService *message.DBService, resolver *flow.Resolver, identityService *identities.Service, botService *bots.Service, aclService *acl.Service, policyService *policy.Service, bindService *bind.Service, mediaService *media.Service, ttsService *ttspkg.Service, settingsService *settings.Service, scheduleService *schedule.Service, mcpConnService *mcp.ConnectionService, modelsService *models.Service, providersService *providers.Service, memProvService *memprovider.Service, searchProvService *searchproviders.Service, browserCtxService *browsercontexts.Service, emailService *emailpkg.Service, emailOutboxService *emailpkg.OutboxService, heartbeatService *heartbeat.Service, queries *dbsqlc.Queries, containerdHandler *handlers.ContainerdHandler, manager *workspace.Manager, rc *boot.RuntimeConfig) *inbound.ChannelInboundProcessor {
+func provideChannelRouter(log *slog.Logger, registry *channel.Registry, hub *local.RouteHub, routeService *route.DBService, sessionService *sessionpkg.Service, msgService *message.DBService, resolver *flow.Resolver, identityService *identities.Service, botService *bots.Service, aclService *acl.Service, approvalService *approval.Service, triggerService *trigger.Service, policyService *policy.Service, bindService *bind.Service, mediaService *media.Service, documentService *document.Service, ttsService *ttspkg.Service, sttService *sttpkg.Service, settingsService *settings.Service, scheduleService *schedule.Service, mcpConnService *mcp.ConnectionService, modelsService *models.Service, providersService *providers.Service, memProvService *memprovider.Service, searchProvService *searchproviders.Service, browserCtxService *browsercontexts.Service, emailService *emailpkg.Service, emailOutboxService *emailpkg.OutboxService, heartbeatService *heartbeat.Service, queries *dbsqlc.Queries, containerdHandler *handlers.ContainerdHandler, manager *workspace.Manager, rc *boot.RuntimeConfig) *inbound.ChannelInboundProcessor {
 	adapter, ok := registry.Get(qq.Type)
 	if !ok {
 		panic("qq adapter not registered")
@@ -415,9 +525,14 @@ func provideChannelRouter(log *slog.Logger, registry *channel.Registry, hub *loc
 	processor := inbound.NewChannelInboundProcessor(log, registry, routeService, msgService, resolver, identityService, policyService, bindService, rc.JwtSecret, 5*time.Minute)
 	processor.SetSessionEnsurer(&sessionEnsurerAdapter{svc: sessionService})
 	processor.SetACLService(aclService)
+	processor.SetApprovalService(approvalService)
+	processor.SetTriggerPolicies(triggerService, resolver)
 	processor.SetMediaService(mediaService)
 	processor.SetStreamObserver(local.NewRouteHubBroadcaster(hub))
 	processor.SetTtsService(ttsService, &settingsTtsModelResolver{settings: settingsService})
+	processor.SetSttService(sttService, &settingsSttModelResolver{settings: settingsService})
+	processor.SetFetchPolicyResolver(&settingsFetchPolicyResolver{settings: settingsService})
+	processor.SetDocumentExtractor(documentService)
 	processor.SetCommandHandler(command.NewHandler(
 		log,
 		&command.BotMemberRoleAdapter{BotService: botService},
@@ -486,7 +601,7 @@ func provideToolGatewayService(log *slog.Logger, fedGateway *handlers.MCPFederat
 	return svc
 }
 
-func provideToolProviders(log *slog.Logger, cfg config.Config, channelManager *channel.Manager, registry *channel.Registry, routeService *route.DBService, scheduleService *schedule.Service, settingsService *settings.Service, searchProviderService *searchproviders.Service, manager *workspace.Manager, mediaService *media.Service, memoryRegistry *memprovider.Registry, emailService *emailpkg.Service, emailManager *emailpkg.Manager, fedGateway *handlers.MCPFederationGateway, mcpConnService *mcp.ConnectionService, modelsService *models.Service, browserContextService *browsercontexts.Service, queries *dbsqlc.Queries, ttsService *ttspkg.Service, sessionService *sessionpkg.Service) []agenttools.ToolProvider {
+func provideToolProviders(log *slog.Logger, cfg config.Config, channelManager *channel.Manager, registry *channel.Registry, routeService *route.DBService, scheduleService *schedule.Service, settingsService *settings.Service, searchProviderService *searchproviders.Service, manager *workspace.Manager, mediaService *media.Service, documentService *document.Service, memoryRegistry *memprovider.Registry, emailService *emailpkg.Service, emailManager *emailpkg.Manager, fedGateway *handlers.MCPFederationGateway, mcpConnService *mcp.ConnectionService, modelsService *models.Service, browserContextService *browsercontexts.Service, queries *dbsqlc.Queries, ttsService *ttspkg.Service, sessionService *sessionpkg.Service) []agenttools.ToolProvider {
 	var assetResolver messaging.AssetResolver
 	if mediaService != nil {
 		assetResolver = &mediaAssetResolverAdapter{media: mediaService}
@@ -500,8 +615,9 @@ func provideToolProviders(log *slog.Logger, cfg config.Config, channelManager *c
 		agenttools.NewWebProvider(log, settingsService, searchProviderService),
 		agenttools.NewContainerProvider(log, manager, config.DefaultDataMount),
 		agenttools.NewReadMediaProvider(log, manager, config.DefaultDataMount),
+		agenttools.NewReadDocumentProvider(log, manager, documentService, config.DefaultDataMount),
 		agenttools.NewEmailProvider(log, emailService, emailManager),
-		agenttools.NewWebFetchProvider(log),
+		agenttools.NewWebFetchProvider(log, settingsService),
 		agenttools.NewSpawnProvider(log, settingsService, modelsService, queries, sessionService),
 		agenttools.NewSkillProvider(log),
 		agenttools.NewBrowserProvider(log, settingsService, browserContextService, manager, cfg.BrowserGateway),
@@ -545,6 +661,10 @@ func provideMediaService(log *slog.Logger, manager *workspace.Manager) *media.Se
 	return media.NewService(log, provider)
 }
 
+func provideDocumentService(log *slog.Logger, mediaService *media.Service) *document.Service {
+	return document.NewService(log, mediaService)
+}
+
 func provideUsersHandler(log *slog.Logger, accountService *accounts.Service, identityService *identities.Service, botService *bots.Service, routeService *route.DBService, channelStore *channel.Store, channelLifecycle *channel.Lifecycle, channelManager *channel.Manager, registry *channel.Registry) *handlers.UsersHandler {
 	return handlers.NewUsersHandler(log, accountService, identityService, botService, routeService, channelStore, channelLifecycle, channelManager, registry)
 }
@@ -565,6 +685,12 @@ func provideWebHandler(channelManager *channel.Manager, channelStore *channel.St
 	return h
 }
 
+func provideOpenAICompatHandler(log *slog.Logger, botService *bots.Service, accountService *accounts.Service, sessionService *sessionpkg.Service, cfg config.Config, resolver *flow.Resolver) *handlers.OpenAICompatHandler {
+	h := handlers.NewOpenAICompatHandler(log, botService, accountService, sessionService, cfg)
+	h.SetResolver(resolver)
+	return h
+}
+
 type serverParams struct {
 	fx.In
 	Logger            *slog.Logger
@@ -572,6 +698,7 @@ type serverParams struct {
 	Config            config.Config
 	ServerHandlers    []server.Handler `group:"server_handlers"`
 	ContainerdHandler *handlers.ContainerdHandler
+	APIKeys           *apikeys.Service
 }
 
 type memohServer struct {
@@ -594,8 +721,10 @@ var (
 		"/assets/",
 		"/api/docs",
 		"/channels/feishu/webhook/",
+		"/channels/webhook/",
 		"/email/mailgun/webhook/",
 		"/email/oauth/callback",
+		"/triggers/webhook/",
 	}
 	memohSPABackendPrefixes = []string{
 		"/api",
@@ -614,10 +743,12 @@ var (
 		"/message",
 		"/mcp",
 		"/schedule",
+		"/triggers",
 		"/bind",
 		"/preauth",
 		"/ping",
 		"/health",
+		"/v1",
 	}
 	memohAPIRewriteBypassExact = map[string]struct{}{
 		"/api/swagger.json": {},
@@ -664,9 +795,9 @@ func provideServer(params serverParams) *memohServer {
 			return nil
 		},
 	}))
-	e.Use(auth.JWTMiddleware(params.Config.Auth.JWTSecret, func(c echo.Context) bool {
+	e.Use(auth.Middleware(params.Config.Auth.JWTSecret, func(c echo.Context) bool {
 		return shouldSkipJWTForMemoh(c.Request().URL.Path)
-	}))
+	}, params.APIKeys))
 	for _, h := range allHandlers {
 		if h != nil {
 			h.Register(e)
@@ -679,6 +810,13 @@ func startScheduleService(lc fx.Lifecycle, scheduleService *schedule.Service) {
 	lc.Append(fx.Hook{OnStart: func(ctx context.Context) error { return scheduleService.Bootstrap(ctx) }})
 }
 
+func startEventTriggerService(lc fx.Lifecycle, eventTriggerService *eventtrigger.Service) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error { return eventTriggerService.Bootstrap(ctx) },
+		OnStop:  func(_ context.Context) error { eventTriggerService.Stop(); return nil },
+	})
+}
+
 func startHeartbeatService(lc fx.Lifecycle, heartbeatService *heartbeat.Service) {
 	lc.Append(fx.Hook{OnStart: func(ctx context.Context) error { return heartbeatService.Bootstrap(ctx) }})
 }
@@ -787,6 +925,12 @@ func provideTtsRegistry(log *slog.Logger) *ttspkg.Registry {
 	return reg
 }
 
+func provideSttRegistry(log *slog.Logger) *sttpkg.Registry {
+	reg := sttpkg.NewRegistry()
+	reg.Register(sttopenai.NewOpenAIAdapter(log))
+	return reg
+}
+
 func provideTtsTempStore() (*ttspkg.TempStore, error) {
 	return ttspkg.NewTempStore(os.TempDir())
 }
@@ -839,6 +983,34 @@ func (r *settingsTtsModelResolver) ResolveTtsModelID(ctx context.Context, botID
 	return s.TtsModelID, nil
 }
 
+// settingsSttModelResolver adapts settings.Service to the sttModelResolver
+// interface expected by ChannelInboundProcessor.
+type settingsSttModelResolver struct {
+	settings *settings.Service
+}
+
+func (r *settingsSttModelResolver) ResolveSttModelID(ctx context.Context, botID string) (string, error) {
+	s, err := r.settings.GetBot(ctx, botID)
+	if err != nil {
+		return "", err
+	}
+	return s.SttModelID, nil
+}
+
+// settingsFetchPolicyResolver adapts settings.Service to the
+// channel.FetchPolicyResolver interface used for attachment downloads.
+type settingsFetchPolicyResolver struct {
+	settings *settings.Service
+}
+
+func (r *settingsFetchPolicyResolver) ResolveFetchPolicy(ctx context.Context, botID string) (netguard.Policy, error) {
+	s, err := r.settings.GetBot(ctx, botID)
+	if err != nil {
+		return netguard.Policy{}, err
+	}
+	return s.FetchPolicy(), nil
+}
+
 func provideEmailRegistry(log *slog.Logger, tokenStore *emailpkg.DBOAuthTokenStore) *emailpkg.Registry {
 	reg := emailpkg.NewRegistry()
 	reg.Register(emailgeneric.New(log))
@@ -142,6 +142,30 @@ CREATE TABLE IF NOT EXISTS tts_models (
 
 CREATE INDEX IF NOT EXISTS idx_tts_models_provider_id ON tts_models(tts_provider_id);
 
+-- stt_providers: pluggable speech-to-text service backends
+CREATE TABLE IF NOT EXISTS stt_providers (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  provider TEXT NOT NULL,
+  config JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT stt_providers_name_unique UNIQUE (name)
+);
+
+-- stt_models: available models per STT provider with per-model configuration
+CREATE TABLE IF NOT EXISTS stt_models (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  model_id TEXT NOT NULL,
+  name TEXT,
+  stt_provider_id UUID NOT NULL REFERENCES stt_providers(id) ON DELETE CASCADE,
+  config JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stt_models_provider_id ON stt_models(stt_provider_id);
+
 CREATE TABLE IF NOT EXISTS browser_contexts (
   id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   name        TEXT NOT NULL DEFAULT '',
@@ -175,17 +199,40 @@ CREATE TABLE IF NOT EXISTS bots (
   compaction_model_id UUID REFERENCES models(id) ON DELETE SET NULL,
   title_model_id UUID REFERENCES models(id) ON DELETE SET NULL,
   tts_model_id UUID REFERENCES tts_models(id) ON DELETE SET NULL,
+  stt_model_id UUID REFERENCES stt_models(id) ON DELETE SET NULL,
   browser_context_id UUID REFERENCES browser_contexts(id) ON DELETE SET NULL,
+  memory_scope_policy TEXT NOT NULL DEFAULT 'private',
+  timezone TEXT NOT NULL DEFAULT '',
+  heartbeat_active_start TEXT NOT NULL DEFAULT '',
+  heartbeat_active_end TEXT NOT NULL DEFAULT '',
+  heartbeat_jitter INTEGER NOT NULL DEFAULT 0,
+  heartbeat_max_interval INTEGER NOT NULL DEFAULT 0,
+  fetch_allow_domains TEXT[] NOT NULL DEFAULT '{}',
+  fetch_deny_domains TEXT[] NOT NULL DEFAULT '{}',
   metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   CONSTRAINT bots_type_check CHECK (type IN ('personal', 'public')),
   CONSTRAINT bots_status_check CHECK (status IN ('creating', 'ready', 'deleting')),
-  CONSTRAINT bots_reasoning_effort_check CHECK (reasoning_effort IN ('low', 'medium', 'high'))
+  CONSTRAINT bots_reasoning_effort_check CHECK (reasoning_effort IN ('low', 'medium', 'high')),
+  CONSTRAINT bots_memory_scope_policy_check CHECK (memory_scope_policy IN ('shared', 'per_user', 'private'))
 );
 
 CREATE INDEX IF NOT EXISTS idx_bots_owner_user_id ON bots(owner_user_id);
 
+CREATE TABLE IF NOT EXISTS bot_model_fallbacks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  purpose TEXT NOT NULL,
+  model_id UUID NOT NULL REFERENCES models(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_model_fallbacks_purpose_check CHECK (purpose IN ('chat', 'heartbeat', 'title', 'compaction')),
+  CONSTRAINT bot_model_fallbacks_unique UNIQUE (bot_id, purpose, model_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_model_fallbacks_bot_purpose ON bot_model_fallbacks(bot_id, purpose, position);
+
 CREATE TABLE IF NOT EXISTS bot_acl_rules (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
@@ -198,10 +245,14 @@ CREATE TABLE IF NOT EXISTS bot_acl_rules (
   source_conversation_type TEXT,
   source_conversation_id TEXT,
   source_thread_id TEXT,
+  tool_pattern TEXT,
   created_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
-  CONSTRAINT bot_acl_rules_action_check CHECK (action IN ('chat.trigger')),
+  CONSTRAINT bot_acl_rules_action_check CHECK (action IN ('chat.trigger', 'tool.use')),
+  CONSTRAINT bot_acl_rules_tool_pattern_check CHECK (
+    (action = 'tool.use') = (tool_pattern IS NOT NULL)
+  ),
   CONSTRAINT bot_acl_rules_effect_check CHECK (effect IN ('allow', 'deny')),
   CONSTRAINT bot_acl_rules_subject_kind_check CHECK (subject_kind IN ('guest_all', 'user', 'channel_identity')),
   CONSTRAINT bot_acl_rules_source_conversation_type_check CHECK (
@@ -221,11 +272,13 @@ CREATE TABLE IF NOT EXISTS bot_acl_rules (
   ),
   CONSTRAINT bot_acl_rules_unique_user UNIQUE NULLS NOT DISTINCT (
     bot_id, action, effect, subject_kind, user_id,
-    source_channel, source_conversation_type, source_conversation_id, source_thread_id
+    source_channel, source_conversation_type, source_conversation_id, source_thread_id,
+    tool_pattern
   ),
   CONSTRAINT bot_acl_rules_unique_channel_identity UNIQUE NULLS NOT DISTINCT (
     bot_id, action, effect, subject_kind, channel_identity_id,
-    source_channel, source_conversation_type, source_conversation_id, source_thread_id
+    source_channel, source_conversation_type, source_conversation_id, source_thread_id,
+    tool_pattern
   )
 );
 
@@ -337,13 +390,31 @@ CREATE UNIQUE INDEX IF NOT EXISTS idx_bot_channel_routes_unique
   ON bot_channel_routes (bot_id, channel_type, external_conversation_id, COALESCE(external_thread_id, ''));
 CREATE INDEX IF NOT EXISTS idx_bot_channel_routes_bot ON bot_channel_routes(bot_id);
 
+-- bot_route_trigger_policies: per-route group trigger policy and unsolicited reply limits.
+CREATE TABLE IF NOT EXISTS bot_route_trigger_policies (
+  route_id UUID PRIMARY KEY REFERENCES bot_channel_routes(id) ON DELETE CASCADE,
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  mode TEXT NOT NULL DEFAULT 'mention',
+  keywords TEXT[] NOT NULL DEFAULT '{}',
+  patterns TEXT[] NOT NULL DEFAULT '{}',
+  listen_instructions TEXT NOT NULL DEFAULT '',
+  classifier_model_id UUID REFERENCES models(id) ON DELETE SET NULL,
+  max_unsolicited_per_hour INTEGER NOT NULL DEFAULT 3,
+  cooldown_seconds INTEGER NOT NULL DEFAULT 300,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_route_trigger_policies_mode_check CHECK (mode IN ('mention', 'keywords', 'listen')),
+  CONSTRAINT bot_route_trigger_policies_limits_check CHECK (max_unsolicited_per_hour >= 0 AND cooldown_seconds >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_route_trigger_policies_bot ON bot_route_trigger_policies(bot_id);
+
 -- bot_sessions: chat sessions within a bot, optionally linked to a channel route.
 CREATE TABLE IF NOT EXISTS bot_sessions (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
   route_id UUID REFERENCES bot_channel_routes(id) ON DELETE SET NULL,
   channel_type TEXT,
-  type TEXT NOT NULL DEFAULT 'chat' CHECK (type IN ('chat', 'heartbeat', 'schedule', 'subagent')),
+  type TEXT NOT NULL DEFAULT 'chat' CHECK (type IN ('chat', 'heartbeat', 'schedule', 'subagent', 'trigger')),
   title TEXT NOT NULL DEFAULT '',
   metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
   parent_session_id UUID REFERENCES bot_sessions(id) ON DELETE SET NULL,
@@ -376,6 +447,7 @@ CREATE TABLE IF NOT EXISTS bot_history_messages (
   metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
   usage JSONB,
   model_id UUID REFERENCES models(id) ON DELETE SET NULL,
+  cost_usd DOUBLE PRECISION,
   compact_id UUID,
   created_at TIMESTAMPTZ NOT NULL DEFAULT now()
 );
@@ -389,6 +461,95 @@ CREATE INDEX IF NOT EXISTS idx_bot_history_messages_session_source
 CREATE INDEX IF NOT EXISTS idx_bot_history_messages_session_reply
   ON bot_history_messages(session_id, source_reply_to_message_id);
 
+CREATE TABLE IF NOT EXISTS bot_token_budgets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  scope TEXT NOT NULL,
+  user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+  channel_identity_id UUID REFERENCES channel_identities(id) ON DELETE CASCADE,
+  period TEXT NOT NULL,
+  max_input_tokens BIGINT NOT NULL DEFAULT 0,
+  max_output_tokens BIGINT NOT NULL DEFAULT 0,
+  max_total_tokens BIGINT NOT NULL DEFAULT 0,
+  exhausted_message TEXT NOT NULL DEFAULT '',
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_token_budgets_scope_check CHECK (scope IN ('bot', 'user', 'channel_identity')),
+  CONSTRAINT bot_token_budgets_period_check CHECK (period IN ('daily', 'monthly')),
+  CONSTRAINT bot_token_budgets_subject_check CHECK (
+    (scope = 'bot' AND user_id IS NULL AND channel_identity_id IS NULL) OR
+    (scope = 'user' AND channel_identity_id IS NULL) OR
+    (scope = 'channel_identity' AND user_id IS NULL)
+  ),
+  CONSTRAINT bot_token_budgets_limits_check CHECK (
+    max_input_tokens >= 0 AND max_output_tokens >= 0 AND max_total_tokens >= 0
+  )
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_token_budgets_bot ON bot_token_budgets(bot_id);
+
+CREATE TABLE IF NOT EXISTS bot_token_usage (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  session_id UUID REFERENCES bot_sessions(id) ON DELETE SET NULL,
+  user_id UUID,
+  channel_identity_id UUID,
+  model_id UUID REFERENCES models(id) ON DELETE SET NULL,
+  input_tokens BIGINT NOT NULL DEFAULT 0,
+  output_tokens BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_token_usage_bot_created ON bot_token_usage(bot_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_bot_token_usage_user_created ON bot_token_usage(bot_id, user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_bot_token_usage_identity_created ON bot_token_usage(bot_id, channel_identity_id, created_at);
+
+CREATE TABLE IF NOT EXISTS user_api_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  token_prefix TEXT NOT NULL,
+  token_hash TEXT NOT NULL,
+  scopes TEXT[] NOT NULL DEFAULT '{}',
+  expires_at TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT user_api_keys_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_api_keys_user ON user_api_keys(user_id);
+
+CREATE TABLE IF NOT EXISTS bot_tool_approval_policies (
+  bot_id UUID PRIMARY KEY REFERENCES bots(id) ON DELETE CASCADE,
+  tool_patterns TEXT[] NOT NULL DEFAULT '{}',
+  timeout_seconds INTEGER NOT NULL DEFAULT 300,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_tool_approval_policies_timeout_check CHECK (timeout_seconds > 0)
+);
+
+CREATE TABLE IF NOT EXISTS tool_approvals (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  session_id UUID REFERENCES bot_sessions(id) ON DELETE SET NULL,
+  requester_channel_identity_id UUID REFERENCES channel_identities(id) ON DELETE SET NULL,
+  tool_name TEXT NOT NULL,
+  tool_call_id TEXT NOT NULL DEFAULT '',
+  input JSONB NOT NULL DEFAULT '{}'::jsonb,
+  platform TEXT NOT NULL DEFAULT '',
+  reply_target TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT 'pending',
+  decided_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  decision_note TEXT NOT NULL DEFAULT '',
+  expires_at TIMESTAMPTZ NOT NULL,
+  decided_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT tool_approvals_status_check CHECK (status IN ('pending', 'approved', 'rejected', 'expired'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tool_approvals_bot_created ON tool_approvals(bot_id, created_at DESC);
+
 CREATE TABLE IF NOT EXISTS containers (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
@@ -409,6 +570,26 @@ CREATE TABLE IF NOT EXISTS containers (
 
 CREATE INDEX IF NOT EXISTS idx_containers_bot_id ON containers(bot_id);
 
+CREATE TABLE IF NOT EXISTS bot_resource_limits (
+  bot_id UUID PRIMARY KEY REFERENCES bots(id) ON DELETE CASCADE,
+  cpu_shares INTEGER NOT NULL DEFAULT 0,
+  cpu_millicores INTEGER NOT NULL DEFAULT 0,
+  memory_mb INTEGER NOT NULL DEFAULT 0,
+  pids_limit INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_resource_limits_non_negative CHECK (
+    cpu_shares >= 0 AND cpu_millicores >= 0 AND memory_mb >= 0 AND pids_limit >= 0
+  )
+);
+
+CREATE TABLE IF NOT EXISTS bot_network_policies (
+  bot_id UUID PRIMARY KEY REFERENCES bots(id) ON DELETE CASCADE,
+  egress_mode TEXT NOT NULL DEFAULT 'full',
+  allow_list TEXT[] NOT NULL DEFAULT '{}',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_network_policies_mode_check CHECK (egress_mode IN ('full', 'allowlist', 'none'))
+);
+
 CREATE TABLE IF NOT EXISTS snapshots (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   container_id TEXT NOT NULL REFERENCES containers(container_id) ON DELETE CASCADE,
@@ -461,7 +642,11 @@ CREATE TABLE IF NOT EXISTS schedule (
   updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   enabled BOOLEAN NOT NULL DEFAULT true,
   command TEXT NOT NULL,
-  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  timezone TEXT NOT NULL DEFAULT '',
+  run_at TIMESTAMPTZ,
+  delivery_platform TEXT NOT NULL DEFAULT '',
+  delivery_target TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX IF NOT EXISTS idx_schedule_bot_id ON schedule(bot_id);
@@ -520,7 +705,11 @@ CREATE TABLE IF NOT EXISTS bot_heartbeat_logs (
   usage JSONB,
   model_id UUID REFERENCES models(id) ON DELETE SET NULL,
   started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
-  completed_at TIMESTAMPTZ
+  completed_at TIMESTAMPTZ,
+  consecutive_ok INTEGER NOT NULL DEFAULT 0,
+  requested_interval INTEGER,
+  next_run_at TIMESTAMPTZ,
+  next_run_reason TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX IF NOT EXISTS idx_heartbeat_logs_bot_started ON bot_heartbeat_logs(bot_id, started_at DESC);
@@ -560,6 +749,47 @@ CREATE TABLE IF NOT EXISTS schedule_logs (
 CREATE INDEX IF NOT EXISTS idx_schedule_logs_schedule ON schedule_logs(schedule_id, started_at DESC);
 CREATE INDEX IF NOT EXISTS idx_schedule_logs_bot ON schedule_logs(bot_id, started_at DESC);
 
+-- event_triggers: event-driven agent runs (file changes, inbound email, webhooks, MCP notifications).
+CREATE TABLE IF NOT EXISTS event_triggers (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  source TEXT NOT NULL CHECK (source IN ('file', 'email', 'webhook', 'mcp')),
+  config JSONB NOT NULL DEFAULT '{}'::jsonb,
+  command TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  secret TEXT NOT NULL DEFAULT '',
+  cooldown_seconds INTEGER NOT NULL DEFAULT 0,
+  current_calls INTEGER NOT NULL DEFAULT 0,
+  last_fired_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_triggers_bot_id ON event_triggers(bot_id);
+CREATE INDEX IF NOT EXISTS idx_event_triggers_enabled_source ON event_triggers(source) WHERE enabled;
+
+-- event_trigger_logs: structured execution records for event triggers.
+CREATE TABLE IF NOT EXISTS event_trigger_logs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  trigger_id UUID NOT NULL REFERENCES event_triggers(id) ON DELETE CASCADE,
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  session_id UUID REFERENCES bot_sessions(id) ON DELETE SET NULL,
+  source TEXT NOT NULL,
+  event JSONB NOT NULL DEFAULT '{}'::jsonb,
+  status TEXT NOT NULL DEFAULT 'ok' CHECK (status IN ('ok', 'error')),
+  result_text TEXT NOT NULL DEFAULT '',
+  error_message TEXT NOT NULL DEFAULT '',
+  usage JSONB,
+  model_id UUID REFERENCES models(id) ON DELETE SET NULL,
+  started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_trigger_logs_trigger ON event_trigger_logs(trigger_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_event_trigger_logs_bot ON event_trigger_logs(bot_id, started_at DESC);
+
 -- email_providers: pluggable email service backends
 CREATE TABLE IF NOT EXISTS email_providers (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -625,3 +855,30 @@ CREATE TABLE IF NOT EXISTS email_outbox (
 
 CREATE INDEX IF NOT EXISTS idx_email_outbox_provider_id ON email_outbox(provider_id);
 CREATE INDEX IF NOT EXISTS idx_email_outbox_bot_id ON email_outbox(bot_id, created_at DESC);
+
+-- memory_vectors: dense memory embeddings, only when pgvector is available
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') THEN
+    BEGIN
+      CREATE EXTENSION IF NOT EXISTS vector;
+    EXCEPTION WHEN insufficient_privilege THEN
+      RAISE NOTICE 'memory_vectors skipped: no permission to create the vector extension';
+    END;
+  END IF;
+  IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'vector') THEN
+    CREATE TABLE IF NOT EXISTS memory_vectors (
+      id UUID PRIMARY KEY,
+      bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+      model TEXT NOT NULL,
+      dimensions INTEGER NOT NULL,
+      embedding vector NOT NULL,
+      payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+      search_text tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(payload->>'memory', ''))) STORED,
+      updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+    CREATE INDEX IF NOT EXISTS idx_memory_vectors_bot_id ON memory_vectors(bot_id);
+    CREATE INDEX IF NOT EXISTS idx_memory_vectors_search_text ON memory_vectors USING gin(search_text);
+  END IF;
+END
+$$;
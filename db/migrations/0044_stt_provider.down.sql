@@ -0,0 +1,14 @@
+-- 0044_stt_provider (rollback)
+-- Remove STT provider/model tables and bots.stt_model_id.
+
+ALTER TABLE bots
+  DROP CONSTRAINT IF EXISTS bots_stt_model_id_fkey;
+
+ALTER TABLE bots
+  DROP COLUMN IF EXISTS stt_model_id;
+
+DROP INDEX IF EXISTS idx_stt_models_provider_id;
+
+DROP TABLE IF EXISTS stt_models;
+
+DROP TABLE IF EXISTS stt_providers;
@@ -0,0 +1,40 @@
+-- 0044_stt_provider
+-- Add STT provider/model tables and bots.stt_model_id for inbound voice transcription.
+
+CREATE TABLE IF NOT EXISTS stt_providers (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  provider TEXT NOT NULL,
+  config JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT stt_providers_name_unique UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS stt_models (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  model_id TEXT NOT NULL,
+  name TEXT,
+  stt_provider_id UUID NOT NULL REFERENCES stt_providers(id) ON DELETE CASCADE,
+  config JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stt_models_provider_id ON stt_models(stt_provider_id);
+
+ALTER TABLE bots
+  ADD COLUMN IF NOT EXISTS stt_model_id UUID;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1
+    FROM pg_constraint
+    WHERE conname = 'bots_stt_model_id_fkey'
+  ) THEN
+    ALTER TABLE bots
+      ADD CONSTRAINT bots_stt_model_id_fkey
+      FOREIGN KEY (stt_model_id) REFERENCES stt_models(id) ON DELETE SET NULL;
+  END IF;
+END $$;
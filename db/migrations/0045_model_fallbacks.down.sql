@@ -0,0 +1,6 @@
+-- 0045_model_fallbacks (rollback)
+-- Remove per-bot fallback model chains.
+
+DROP INDEX IF EXISTS idx_bot_model_fallbacks_bot_purpose;
+
+DROP TABLE IF EXISTS bot_model_fallbacks;
@@ -0,0 +1,15 @@
+-- 0045_model_fallbacks
+-- Add ordered per-bot fallback model chains for chat, heartbeat, title and compaction.
+
+CREATE TABLE IF NOT EXISTS bot_model_fallbacks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  purpose TEXT NOT NULL,
+  model_id UUID NOT NULL REFERENCES models(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_model_fallbacks_purpose_check CHECK (purpose IN ('chat', 'heartbeat', 'title', 'compaction')),
+  CONSTRAINT bot_model_fallbacks_unique UNIQUE (bot_id, purpose, model_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_model_fallbacks_bot_purpose ON bot_model_fallbacks(bot_id, purpose, position);
@@ -0,0 +1,10 @@
+-- 0046_token_budgets (rollback)
+-- Remove token budget rules and the usage ledger.
+
+DROP INDEX IF EXISTS idx_bot_token_usage_identity_created;
+DROP INDEX IF EXISTS idx_bot_token_usage_user_created;
+DROP INDEX IF EXISTS idx_bot_token_usage_bot_created;
+DROP TABLE IF EXISTS bot_token_usage;
+
+DROP INDEX IF EXISTS idx_bot_token_budgets_bot;
+DROP TABLE IF EXISTS bot_token_budgets;
@@ -0,0 +1,46 @@
+-- 0046_token_budgets
+-- Add per-bot token budget rules and a usage ledger used to enforce them.
+
+CREATE TABLE IF NOT EXISTS bot_token_budgets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  scope TEXT NOT NULL,
+  user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+  channel_identity_id UUID REFERENCES channel_identities(id) ON DELETE CASCADE,
+  period TEXT NOT NULL,
+  max_input_tokens BIGINT NOT NULL DEFAULT 0,
+  max_output_tokens BIGINT NOT NULL DEFAULT 0,
+  max_total_tokens BIGINT NOT NULL DEFAULT 0,
+  exhausted_message TEXT NOT NULL DEFAULT '',
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_token_budgets_scope_check CHECK (scope IN ('bot', 'user', 'channel_identity')),
+  CONSTRAINT bot_token_budgets_period_check CHECK (period IN ('daily', 'monthly')),
+  CONSTRAINT bot_token_budgets_subject_check CHECK (
+    (scope = 'bot' AND user_id IS NULL AND channel_identity_id IS NULL) OR
+    (scope = 'user' AND channel_identity_id IS NULL) OR
+    (scope = 'channel_identity' AND user_id IS NULL)
+  ),
+  CONSTRAINT bot_token_budgets_limits_check CHECK (
+    max_input_tokens >= 0 AND max_output_tokens >= 0 AND max_total_tokens >= 0
+  )
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_token_budgets_bot ON bot_token_budgets(bot_id);
+
+CREATE TABLE IF NOT EXISTS bot_token_usage (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  session_id UUID REFERENCES bot_sessions(id) ON DELETE SET NULL,
+  user_id UUID,
+  channel_identity_id UUID,
+  model_id UUID REFERENCES models(id) ON DELETE SET NULL,
+  input_tokens BIGINT NOT NULL DEFAULT 0,
+  output_tokens BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_token_usage_bot_created ON bot_token_usage(bot_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_bot_token_usage_user_created ON bot_token_usage(bot_id, user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_bot_token_usage_identity_created ON bot_token_usage(bot_id, channel_identity_id, created_at);
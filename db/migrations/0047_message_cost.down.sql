@@ -0,0 +1,5 @@
+-- 0047_message_cost (rollback)
+-- Remove per-message cost.
+
+ALTER TABLE bot_history_messages
+  DROP COLUMN IF EXISTS cost_usd;
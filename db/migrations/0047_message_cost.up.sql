@@ -0,0 +1,5 @@
+-- 0047_message_cost
+-- Record the USD cost of each message computed from model pricing at persist time.
+
+ALTER TABLE bot_history_messages
+  ADD COLUMN IF NOT EXISTS cost_usd DOUBLE PRECISION;
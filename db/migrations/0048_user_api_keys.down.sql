@@ -0,0 +1,4 @@
+-- 0048_user_api_keys (rollback)
+-- Remove personal access tokens.
+
+DROP TABLE IF EXISTS user_api_keys;
@@ -0,0 +1,24 @@
+-- 0049_acl_tool_rules (rollback)
+-- Remove tool-level ACL rules.
+
+DELETE FROM bot_acl_rules WHERE action = 'tool.use';
+
+ALTER TABLE bot_acl_rules
+  DROP CONSTRAINT IF EXISTS bot_acl_rules_action_check,
+  DROP CONSTRAINT IF EXISTS bot_acl_rules_tool_pattern_check,
+  DROP CONSTRAINT IF EXISTS bot_acl_rules_unique_user,
+  DROP CONSTRAINT IF EXISTS bot_acl_rules_unique_channel_identity;
+
+ALTER TABLE bot_acl_rules
+  ADD CONSTRAINT bot_acl_rules_action_check CHECK (action IN ('chat.trigger')),
+  ADD CONSTRAINT bot_acl_rules_unique_user UNIQUE NULLS NOT DISTINCT (
+    bot_id, action, effect, subject_kind, user_id,
+    source_channel, source_conversation_type, source_conversation_id, source_thread_id
+  ),
+  ADD CONSTRAINT bot_acl_rules_unique_channel_identity UNIQUE NULLS NOT DISTINCT (
+    bot_id, action, effect, subject_kind, channel_identity_id,
+    source_channel, source_conversation_type, source_conversation_id, source_thread_id
+  );
+
+ALTER TABLE bot_acl_rules
+  DROP COLUMN IF EXISTS tool_pattern;
@@ -0,0 +1,27 @@
+-- 0049_acl_tool_rules
+-- Allow ACL rules to restrict which tools the agent offers to a caller.
+
+ALTER TABLE bot_acl_rules
+  ADD COLUMN IF NOT EXISTS tool_pattern TEXT;
+
+ALTER TABLE bot_acl_rules
+  DROP CONSTRAINT IF EXISTS bot_acl_rules_action_check,
+  DROP CONSTRAINT IF EXISTS bot_acl_rules_tool_pattern_check,
+  DROP CONSTRAINT IF EXISTS bot_acl_rules_unique_user,
+  DROP CONSTRAINT IF EXISTS bot_acl_rules_unique_channel_identity;
+
+ALTER TABLE bot_acl_rules
+  ADD CONSTRAINT bot_acl_rules_action_check CHECK (action IN ('chat.trigger', 'tool.use')),
+  ADD CONSTRAINT bot_acl_rules_tool_pattern_check CHECK (
+    (action = 'tool.use') = (tool_pattern IS NOT NULL)
+  ),
+  ADD CONSTRAINT bot_acl_rules_unique_user UNIQUE NULLS NOT DISTINCT (
+    bot_id, action, effect, subject_kind, user_id,
+    source_channel, source_conversation_type, source_conversation_id, source_thread_id,
+    tool_pattern
+  ),
+  ADD CONSTRAINT bot_acl_rules_unique_channel_identity UNIQUE NULLS NOT DISTINCT (
+    bot_id, action, effect, subject_kind, channel_identity_id,
+    source_channel, source_conversation_type, source_conversation_id, source_thread_id,
+    tool_pattern
+  );
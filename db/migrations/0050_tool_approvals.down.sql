@@ -0,0 +1,5 @@
+-- 0050_tool_approvals (rollback)
+-- Remove tool approval policies and the approval audit log.
+
+DROP TABLE IF EXISTS tool_approvals;
+DROP TABLE IF EXISTS bot_tool_approval_policies;
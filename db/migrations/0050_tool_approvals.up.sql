@@ -0,0 +1,31 @@
+-- 0050_tool_approvals
+-- Add per-bot tool approval policies and an audit log of approval requests.
+
+CREATE TABLE IF NOT EXISTS bot_tool_approval_policies (
+  bot_id UUID PRIMARY KEY REFERENCES bots(id) ON DELETE CASCADE,
+  tool_patterns TEXT[] NOT NULL DEFAULT '{}',
+  timeout_seconds INTEGER NOT NULL DEFAULT 300,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_tool_approval_policies_timeout_check CHECK (timeout_seconds > 0)
+);
+
+CREATE TABLE IF NOT EXISTS tool_approvals (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  session_id UUID REFERENCES bot_sessions(id) ON DELETE SET NULL,
+  requester_channel_identity_id UUID REFERENCES channel_identities(id) ON DELETE SET NULL,
+  tool_name TEXT NOT NULL,
+  tool_call_id TEXT NOT NULL DEFAULT '',
+  input JSONB NOT NULL DEFAULT '{}'::jsonb,
+  platform TEXT NOT NULL DEFAULT '',
+  reply_target TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT 'pending',
+  decided_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  decision_note TEXT NOT NULL DEFAULT '',
+  expires_at TIMESTAMPTZ NOT NULL,
+  decided_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT tool_approvals_status_check CHECK (status IN ('pending', 'approved', 'rejected', 'expired'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tool_approvals_bot_created ON tool_approvals(bot_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- 0051_bot_resource_limits (rollback)
+-- Remove per-bot container resource profiles.
+
+DROP TABLE IF EXISTS bot_resource_limits;
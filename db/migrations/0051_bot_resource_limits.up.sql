@@ -0,0 +1,14 @@
+-- 0051_bot_resource_limits
+-- Add per-bot container resource profiles (CPU, memory and pids limits).
+
+CREATE TABLE IF NOT EXISTS bot_resource_limits (
+  bot_id UUID PRIMARY KEY REFERENCES bots(id) ON DELETE CASCADE,
+  cpu_shares INTEGER NOT NULL DEFAULT 0,
+  cpu_millicores INTEGER NOT NULL DEFAULT 0,
+  memory_mb INTEGER NOT NULL DEFAULT 0,
+  pids_limit INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_resource_limits_non_negative CHECK (
+    cpu_shares >= 0 AND cpu_millicores >= 0 AND memory_mb >= 0 AND pids_limit >= 0
+  )
+);
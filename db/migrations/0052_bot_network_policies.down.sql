@@ -0,0 +1,4 @@
+-- 0052_bot_network_policies (rollback)
+-- Remove per-bot container network egress policies.
+
+DROP TABLE IF EXISTS bot_network_policies;
@@ -0,0 +1,10 @@
+-- 0052_bot_network_policies
+-- Add per-bot container network egress policies (full, allowlist, none).
+
+CREATE TABLE IF NOT EXISTS bot_network_policies (
+  bot_id UUID PRIMARY KEY REFERENCES bots(id) ON DELETE CASCADE,
+  egress_mode TEXT NOT NULL DEFAULT 'full',
+  allow_list TEXT[] NOT NULL DEFAULT '{}',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_network_policies_mode_check CHECK (egress_mode IN ('full', 'allowlist', 'none'))
+);
@@ -0,0 +1,4 @@
+-- 0053_route_trigger_policies (rollback)
+-- Remove per-route group trigger policies.
+
+DROP TABLE IF EXISTS bot_route_trigger_policies;
@@ -0,0 +1,19 @@
+-- 0053_route_trigger_policies
+-- Add per-route group trigger policies (mention, keywords, listen) with limits on unsolicited replies.
+
+CREATE TABLE IF NOT EXISTS bot_route_trigger_policies (
+  route_id UUID PRIMARY KEY REFERENCES bot_channel_routes(id) ON DELETE CASCADE,
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  mode TEXT NOT NULL DEFAULT 'mention',
+  keywords TEXT[] NOT NULL DEFAULT '{}',
+  patterns TEXT[] NOT NULL DEFAULT '{}',
+  listen_instructions TEXT NOT NULL DEFAULT '',
+  classifier_model_id UUID REFERENCES models(id) ON DELETE SET NULL,
+  max_unsolicited_per_hour INTEGER NOT NULL DEFAULT 3,
+  cooldown_seconds INTEGER NOT NULL DEFAULT 300,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT bot_route_trigger_policies_mode_check CHECK (mode IN ('mention', 'keywords', 'listen')),
+  CONSTRAINT bot_route_trigger_policies_limits_check CHECK (max_unsolicited_per_hour >= 0 AND cooldown_seconds >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_route_trigger_policies_bot ON bot_route_trigger_policies(bot_id);
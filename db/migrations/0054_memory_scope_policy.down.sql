@@ -0,0 +1,4 @@
+-- 0054_memory_scope_policy (rollback)
+
+ALTER TABLE bots DROP CONSTRAINT IF EXISTS bots_memory_scope_policy_check;
+ALTER TABLE bots DROP COLUMN IF EXISTS memory_scope_policy;
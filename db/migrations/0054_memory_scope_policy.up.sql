@@ -0,0 +1,11 @@
+-- 0054_memory_scope_policy
+-- Add the per-bot policy deciding which memory scope new facts are stored in and which scopes are recalled.
+
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS memory_scope_policy TEXT NOT NULL DEFAULT 'private';
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'bots_memory_scope_policy_check') THEN
+    ALTER TABLE bots ADD CONSTRAINT bots_memory_scope_policy_check CHECK (memory_scope_policy IN ('shared', 'per_user', 'private'));
+  END IF;
+END$$;
@@ -0,0 +1,4 @@
+-- 0055_memory_vectors (rollback)
+-- Remove the pgvector memory table. The extension is left installed.
+
+DROP TABLE IF EXISTS memory_vectors;
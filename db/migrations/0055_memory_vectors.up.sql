@@ -0,0 +1,29 @@
+-- 0055_memory_vectors
+-- Add the pgvector memory table. It is only created when the database has the
+-- vector extension available; stock Postgres images do not ship it.
+
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') THEN
+    BEGIN
+      CREATE EXTENSION IF NOT EXISTS vector;
+    EXCEPTION WHEN insufficient_privilege THEN
+      RAISE NOTICE 'memory_vectors skipped: no permission to create the vector extension';
+    END;
+  END IF;
+  IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'vector') THEN
+    CREATE TABLE IF NOT EXISTS memory_vectors (
+      id UUID PRIMARY KEY,
+      bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+      model TEXT NOT NULL,
+      dimensions INTEGER NOT NULL,
+      embedding vector NOT NULL,
+      payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+      search_text tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(payload->>'memory', ''))) STORED,
+      updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+    CREATE INDEX IF NOT EXISTS idx_memory_vectors_bot_id ON memory_vectors(bot_id);
+    CREATE INDEX IF NOT EXISTS idx_memory_vectors_search_text ON memory_vectors USING gin(search_text);
+  END IF;
+END
+$$;
@@ -0,0 +1,6 @@
+-- 0056_schedule_timing_delivery (rollback)
+
+ALTER TABLE schedule DROP COLUMN IF EXISTS delivery_target;
+ALTER TABLE schedule DROP COLUMN IF EXISTS delivery_platform;
+ALTER TABLE schedule DROP COLUMN IF EXISTS run_at;
+ALTER TABLE schedule DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,7 @@
+-- 0056_schedule_timing_delivery
+-- Add per-schedule timezones, one-shot run times and an optional channel target that receives each run's final answer.
+
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS run_at TIMESTAMPTZ;
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS delivery_platform TEXT NOT NULL DEFAULT '';
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS delivery_target TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,12 @@
+-- 0057_heartbeat_pacing (rollback)
+
+ALTER TABLE bot_heartbeat_logs DROP COLUMN IF EXISTS next_run_reason;
+ALTER TABLE bot_heartbeat_logs DROP COLUMN IF EXISTS next_run_at;
+ALTER TABLE bot_heartbeat_logs DROP COLUMN IF EXISTS requested_interval;
+ALTER TABLE bot_heartbeat_logs DROP COLUMN IF EXISTS consecutive_ok;
+
+ALTER TABLE bots DROP COLUMN IF EXISTS heartbeat_max_interval;
+ALTER TABLE bots DROP COLUMN IF EXISTS heartbeat_jitter;
+ALTER TABLE bots DROP COLUMN IF EXISTS heartbeat_active_end;
+ALTER TABLE bots DROP COLUMN IF EXISTS heartbeat_active_start;
+ALTER TABLE bots DROP COLUMN IF EXISTS timezone;
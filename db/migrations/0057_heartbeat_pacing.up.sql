@@ -0,0 +1,13 @@
+-- 0057_heartbeat_pacing
+-- Add a bot timezone, heartbeat active hours, jitter and backoff ceiling, and record how each heartbeat paced the next one.
+
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS heartbeat_active_start TEXT NOT NULL DEFAULT '';
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS heartbeat_active_end TEXT NOT NULL DEFAULT '';
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS heartbeat_jitter INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS heartbeat_max_interval INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE bot_heartbeat_logs ADD COLUMN IF NOT EXISTS consecutive_ok INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE bot_heartbeat_logs ADD COLUMN IF NOT EXISTS requested_interval INTEGER;
+ALTER TABLE bot_heartbeat_logs ADD COLUMN IF NOT EXISTS next_run_at TIMESTAMPTZ;
+ALTER TABLE bot_heartbeat_logs ADD COLUMN IF NOT EXISTS next_run_reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,12 @@
+-- 0058_event_triggers (rollback)
+
+DELETE FROM bot_sessions WHERE type = 'trigger';
+
+ALTER TABLE bot_sessions
+  DROP CONSTRAINT IF EXISTS bot_sessions_type_check;
+
+ALTER TABLE bot_sessions
+  ADD CONSTRAINT bot_sessions_type_check CHECK (type IN ('chat', 'heartbeat', 'schedule', 'subagent'));
+
+DROP TABLE IF EXISTS event_trigger_logs;
+DROP TABLE IF EXISTS event_triggers;
@@ -0,0 +1,47 @@
+-- 0058_event_triggers
+-- Add event-driven triggers (file changes, inbound email, webhooks, MCP notifications) with their run logs and a trigger session type.
+
+CREATE TABLE IF NOT EXISTS event_triggers (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  source TEXT NOT NULL CHECK (source IN ('file', 'email', 'webhook', 'mcp')),
+  config JSONB NOT NULL DEFAULT '{}'::jsonb,
+  command TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  secret TEXT NOT NULL DEFAULT '',
+  cooldown_seconds INTEGER NOT NULL DEFAULT 0,
+  current_calls INTEGER NOT NULL DEFAULT 0,
+  last_fired_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_triggers_bot_id ON event_triggers(bot_id);
+CREATE INDEX IF NOT EXISTS idx_event_triggers_enabled_source ON event_triggers(source) WHERE enabled;
+
+CREATE TABLE IF NOT EXISTS event_trigger_logs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  trigger_id UUID NOT NULL REFERENCES event_triggers(id) ON DELETE CASCADE,
+  bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+  session_id UUID REFERENCES bot_sessions(id) ON DELETE SET NULL,
+  source TEXT NOT NULL,
+  event JSONB NOT NULL DEFAULT '{}'::jsonb,
+  status TEXT NOT NULL DEFAULT 'ok' CHECK (status IN ('ok', 'error')),
+  result_text TEXT NOT NULL DEFAULT '',
+  error_message TEXT NOT NULL DEFAULT '',
+  usage JSONB,
+  model_id UUID REFERENCES models(id) ON DELETE SET NULL,
+  started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_trigger_logs_trigger ON event_trigger_logs(trigger_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_event_trigger_logs_bot ON event_trigger_logs(bot_id, started_at DESC);
+
+ALTER TABLE bot_sessions
+  DROP CONSTRAINT IF EXISTS bot_sessions_type_check;
+
+ALTER TABLE bot_sessions
+  ADD CONSTRAINT bot_sessions_type_check CHECK (type IN ('chat', 'heartbeat', 'schedule', 'subagent', 'trigger'));
@@ -0,0 +1,4 @@
+-- 0059_fetch_policy (rollback)
+
+ALTER TABLE bots DROP COLUMN IF EXISTS fetch_deny_domains;
+ALTER TABLE bots DROP COLUMN IF EXISTS fetch_allow_domains;
@@ -0,0 +1,5 @@
+-- 0059_fetch_policy
+-- Add per-bot domain allow and deny lists for outbound fetches made by web_fetch and attachment downloads.
+
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS fetch_allow_domains TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS fetch_deny_domains TEXT[] NOT NULL DEFAULT '{}';
@@ -41,6 +41,11 @@ WHERE id = $1;
 DELETE FROM bots WHERE id = $1;
 
 -- name: ListHeartbeatEnabledBots :many
-SELECT id, owner_user_id, heartbeat_enabled, heartbeat_interval, heartbeat_prompt
+SELECT id, owner_user_id, heartbeat_enabled, heartbeat_interval, heartbeat_prompt, timezone, heartbeat_active_start, heartbeat_active_end, heartbeat_jitter, heartbeat_max_interval
 FROM bots
 WHERE heartbeat_enabled = true AND status = 'ready';
+
+-- name: GetBotHeartbeatConfig :one
+SELECT id, owner_user_id, status, heartbeat_enabled, heartbeat_interval, timezone, heartbeat_active_start, heartbeat_active_end, heartbeat_jitter, heartbeat_max_interval
+FROM bots
+WHERE id = $1;
@@ -50,3 +50,45 @@ WHERE bot_id = sqlc.arg(bot_id);
 
 -- name: ListAutoStartContainers :many
 SELECT * FROM containers WHERE auto_start = true ORDER BY updated_at DESC;
+
+-- name: GetBotResourceLimits :one
+SELECT bot_id, cpu_shares, cpu_millicores, memory_mb, pids_limit, updated_at
+FROM bot_resource_limits
+WHERE bot_id = sqlc.arg(bot_id);
+
+-- name: UpsertBotResourceLimits :one
+INSERT INTO bot_resource_limits (bot_id, cpu_shares, cpu_millicores, memory_mb, pids_limit)
+VALUES (
+  sqlc.arg(bot_id),
+  sqlc.arg(cpu_shares),
+  sqlc.arg(cpu_millicores),
+  sqlc.arg(memory_mb),
+  sqlc.arg(pids_limit)
+)
+ON CONFLICT (bot_id) DO UPDATE SET
+  cpu_shares = EXCLUDED.cpu_shares,
+  cpu_millicores = EXCLUDED.cpu_millicores,
+  memory_mb = EXCLUDED.memory_mb,
+  pids_limit = EXCLUDED.pids_limit,
+  updated_at = now()
+RETURNING bot_id, cpu_shares, cpu_millicores, memory_mb, pids_limit, updated_at;
+
+-- name: DeleteBotResourceLimits :exec
+DELETE FROM bot_resource_limits WHERE bot_id = sqlc.arg(bot_id);
+
+-- name: GetBotNetworkPolicy :one
+SELECT bot_id, egress_mode, allow_list, updated_at
+FROM bot_network_policies
+WHERE bot_id = sqlc.arg(bot_id);
+
+-- name: UpsertBotNetworkPolicy :one
+INSERT INTO bot_network_policies (bot_id, egress_mode, allow_list)
+VALUES (sqlc.arg(bot_id), sqlc.arg(egress_mode), sqlc.arg(allow_list)::text[])
+ON CONFLICT (bot_id) DO UPDATE SET
+  egress_mode = EXCLUDED.egress_mode,
+  allow_list = EXCLUDED.allow_list,
+  updated_at = now()
+RETURNING bot_id, egress_mode, allow_list, updated_at;
+
+-- name: DeleteBotNetworkPolicy :exec
+DELETE FROM bot_network_policies WHERE bot_id = sqlc.arg(bot_id);
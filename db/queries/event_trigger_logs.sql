@@ -0,0 +1,34 @@
+-- name: CreateEventTriggerLog :one
+INSERT INTO event_trigger_logs (trigger_id, bot_id, source, event, session_id, started_at)
+VALUES ($1, $2, $3, $4, sqlc.narg(session_id)::uuid, now())
+RETURNING id, trigger_id, bot_id, session_id, source, event, status, result_text, error_message, usage, started_at, completed_at;
+
+-- name: CompleteEventTriggerLog :one
+UPDATE event_trigger_logs
+SET status = $2,
+    result_text = $3,
+    error_message = $4,
+    usage = $5,
+    model_id = $6,
+    completed_at = now()
+WHERE id = $1
+RETURNING id, trigger_id, bot_id, session_id, source, event, status, result_text, error_message, usage, model_id, started_at, completed_at;
+
+-- name: ListEventTriggerLogsByBot :many
+SELECT id, trigger_id, bot_id, session_id, source, event, status, result_text, error_message, usage, started_at, completed_at
+FROM event_trigger_logs
+WHERE bot_id = $1
+  AND ($2::timestamptz IS NULL OR started_at < $2::timestamptz)
+ORDER BY started_at DESC
+LIMIT $3;
+
+-- name: ListEventTriggerLogsByTrigger :many
+SELECT id, trigger_id, bot_id, session_id, source, event, status, result_text, error_message, usage, started_at, completed_at
+FROM event_trigger_logs
+WHERE trigger_id = $1
+  AND ($2::timestamptz IS NULL OR started_at < $2::timestamptz)
+ORDER BY started_at DESC
+LIMIT $3;
+
+-- name: DeleteEventTriggerLogsByBot :exec
+DELETE FROM event_trigger_logs WHERE bot_id = $1;
@@ -0,0 +1,54 @@
+-- name: CreateEventTrigger :one
+INSERT INTO event_triggers (bot_id, name, description, source, config, command, enabled, secret, cooldown_seconds)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, bot_id, name, description, source, config, command, enabled, secret, cooldown_seconds, current_calls, last_fired_at, created_at, updated_at;
+
+-- name: GetEventTriggerByID :one
+SELECT id, bot_id, name, description, source, config, command, enabled, secret, cooldown_seconds, current_calls, last_fired_at, created_at, updated_at
+FROM event_triggers
+WHERE id = $1;
+
+-- name: ListEventTriggersByBot :many
+SELECT id, bot_id, name, description, source, config, command, enabled, secret, cooldown_seconds, current_calls, last_fired_at, created_at, updated_at
+FROM event_triggers
+WHERE bot_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListEnabledEventTriggersBySource :many
+SELECT id, bot_id, name, description, source, config, command, enabled, secret, cooldown_seconds, current_calls, last_fired_at, created_at, updated_at
+FROM event_triggers
+WHERE enabled = true
+  AND source = $1
+ORDER BY created_at DESC;
+
+-- name: ListEnabledEventTriggersByBotAndSource :many
+SELECT id, bot_id, name, description, source, config, command, enabled, secret, cooldown_seconds, current_calls, last_fired_at, created_at, updated_at
+FROM event_triggers
+WHERE bot_id = $1
+  AND source = $2
+  AND enabled = true
+ORDER BY created_at DESC;
+
+-- name: UpdateEventTrigger :one
+UPDATE event_triggers
+SET name = $2,
+    description = $3,
+    config = $4,
+    command = $5,
+    enabled = $6,
+    secret = $7,
+    cooldown_seconds = $8,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, bot_id, name, description, source, config, command, enabled, secret, cooldown_seconds, current_calls, last_fired_at, created_at, updated_at;
+
+-- name: MarkEventTriggerFired :one
+UPDATE event_triggers
+SET current_calls = current_calls + 1,
+    last_fired_at = now()
+WHERE id = $1
+RETURNING id, bot_id, name, description, source, config, command, enabled, secret, cooldown_seconds, current_calls, last_fired_at, created_at, updated_at;
+
+-- name: DeleteEventTrigger :exec
+DELETE FROM event_triggers
+WHERE id = $1;
@@ -10,12 +10,16 @@ SET status = $2,
     error_message = $4,
     usage = $5,
     model_id = $6,
+    consecutive_ok = $7,
+    requested_interval = $8,
+    next_run_at = $9,
+    next_run_reason = $10,
     completed_at = now()
 WHERE id = $1
-RETURNING id, bot_id, session_id, status, result_text, error_message, usage, model_id, started_at, completed_at;
+RETURNING id, bot_id, session_id, status, result_text, error_message, usage, model_id, started_at, completed_at, consecutive_ok, requested_interval, next_run_at, next_run_reason;
 
 -- name: ListHeartbeatLogsByBot :many
-SELECT id, bot_id, session_id, status, result_text, error_message, usage, started_at, completed_at
+SELECT id, bot_id, session_id, status, result_text, error_message, usage, started_at, completed_at, consecutive_ok, requested_interval, next_run_at, next_run_reason
 FROM bot_heartbeat_logs
 WHERE bot_id = $1
   AND ($2::timestamptz IS NULL OR started_at < $2::timestamptz)
@@ -25,3 +25,8 @@ DELETE FROM memory_providers WHERE id = $1;
 
 -- name: CountMemoryProvidersByDefault :one
 SELECT COUNT(*) FROM memory_providers WHERE is_default = true;
+
+-- name: GetVectorStoreStatus :one
+SELECT
+  EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') AS extension_available,
+  to_regclass('memory_vectors') IS NOT NULL AS table_exists;
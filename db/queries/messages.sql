@@ -10,7 +10,8 @@ INSERT INTO bot_history_messages (
   content,
   metadata,
   usage,
-  model_id
+  model_id,
+  cost_usd
 )
 VALUES (
   sqlc.arg(bot_id),
@@ -23,7 +24,8 @@ VALUES (
   sqlc.arg(content),
   sqlc.arg(metadata),
   sqlc.arg(usage),
-  sqlc.narg(model_id)::uuid
+  sqlc.narg(model_id)::uuid,
+  sqlc.narg(cost_usd)::double precision
 )
 RETURNING
   id,
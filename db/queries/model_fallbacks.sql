@@ -0,0 +1,22 @@
+-- name: ListBotModelFallbacks :many
+SELECT * FROM bot_model_fallbacks
+WHERE bot_id = sqlc.arg(bot_id)
+ORDER BY purpose, position, created_at;
+
+-- name: CreateBotModelFallback :one
+INSERT INTO bot_model_fallbacks (bot_id, purpose, model_id, position)
+VALUES (
+  sqlc.arg(bot_id),
+  sqlc.arg(purpose),
+  sqlc.arg(model_id),
+  sqlc.arg(position)
+)
+RETURNING *;
+
+-- name: DeleteBotModelFallbacksByPurpose :exec
+DELETE FROM bot_model_fallbacks
+WHERE bot_id = sqlc.arg(bot_id)
+  AND purpose = sqlc.arg(purpose);
+
+-- name: DeleteBotModelFallbacks :exec
+DELETE FROM bot_model_fallbacks WHERE bot_id = sqlc.arg(bot_id);
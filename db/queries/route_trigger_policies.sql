@@ -0,0 +1,53 @@
+-- name: GetRouteTriggerPolicy :one
+SELECT route_id, bot_id, mode, keywords, patterns, listen_instructions, classifier_model_id, max_unsolicited_per_hour, cooldown_seconds, updated_at
+FROM bot_route_trigger_policies
+WHERE route_id = sqlc.arg(route_id);
+
+-- name: ListRouteTriggerPoliciesByBot :many
+SELECT
+  p.route_id,
+  p.bot_id,
+  p.mode,
+  p.keywords,
+  p.patterns,
+  p.listen_instructions,
+  p.classifier_model_id,
+  p.max_unsolicited_per_hour,
+  p.cooldown_seconds,
+  p.updated_at,
+  r.channel_type AS platform,
+  r.external_conversation_id AS conversation_id,
+  r.conversation_type
+FROM bot_route_trigger_policies p
+JOIN bot_channel_routes r ON r.id = p.route_id
+WHERE p.bot_id = sqlc.arg(bot_id)
+ORDER BY p.updated_at DESC;
+
+-- name: UpsertRouteTriggerPolicy :one
+INSERT INTO bot_route_trigger_policies (
+  route_id, bot_id, mode, keywords, patterns, listen_instructions, classifier_model_id, max_unsolicited_per_hour, cooldown_seconds
+)
+VALUES (
+  sqlc.arg(route_id),
+  sqlc.arg(bot_id),
+  sqlc.arg(mode),
+  sqlc.arg(keywords)::text[],
+  sqlc.arg(patterns)::text[],
+  sqlc.arg(listen_instructions),
+  sqlc.narg(classifier_model_id)::uuid,
+  sqlc.arg(max_unsolicited_per_hour),
+  sqlc.arg(cooldown_seconds)
+)
+ON CONFLICT (route_id) DO UPDATE SET
+  mode = EXCLUDED.mode,
+  keywords = EXCLUDED.keywords,
+  patterns = EXCLUDED.patterns,
+  listen_instructions = EXCLUDED.listen_instructions,
+  classifier_model_id = EXCLUDED.classifier_model_id,
+  max_unsolicited_per_hour = EXCLUDED.max_unsolicited_per_hour,
+  cooldown_seconds = EXCLUDED.cooldown_seconds,
+  updated_at = now()
+RETURNING route_id, bot_id, mode, keywords, patterns, listen_instructions, classifier_model_id, max_unsolicited_per_hour, cooldown_seconds, updated_at;
+
+-- name: DeleteRouteTriggerPolicy :exec
+DELETE FROM bot_route_trigger_policies WHERE route_id = sqlc.arg(route_id);
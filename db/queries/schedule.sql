@@ -1,21 +1,21 @@
 -- name: CreateSchedule :one
-INSERT INTO schedule (name, description, pattern, max_calls, enabled, command, bot_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id;
+INSERT INTO schedule (name, description, pattern, max_calls, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target;
 
 -- name: GetScheduleByID :one
-SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 FROM schedule
 WHERE id = $1;
 
 -- name: ListSchedulesByBot :many
-SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 FROM schedule
 WHERE bot_id = $1
 ORDER BY created_at DESC;
 
 -- name: ListEnabledSchedules :many
-SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 FROM schedule
 WHERE enabled = true
 ORDER BY created_at DESC;
@@ -28,9 +28,13 @@ SET name = $2,
     max_calls = $5,
     enabled = $6,
     command = $7,
+    timezone = $8,
+    run_at = $9,
+    delivery_platform = $10,
+    delivery_target = $11,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id;
+RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target;
 
 -- name: DeleteSchedule :exec
 DELETE FROM schedule
@@ -45,5 +49,5 @@ SET current_calls = current_calls + 1,
     END,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id;
+RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target;
 
@@ -11,6 +11,14 @@ SELECT
   bots.heartbeat_prompt,
   bots.compaction_enabled,
   bots.compaction_threshold,
+  bots.memory_scope_policy,
+  bots.timezone,
+  bots.heartbeat_active_start,
+  bots.heartbeat_active_end,
+  bots.heartbeat_jitter,
+  bots.heartbeat_max_interval,
+  bots.fetch_allow_domains,
+  bots.fetch_deny_domains,
   chat_models.id AS chat_model_id,
   heartbeat_models.id AS heartbeat_model_id,
   compaction_models.id AS compaction_model_id,
@@ -18,6 +26,7 @@ SELECT
   search_providers.id AS search_provider_id,
   memory_providers.id AS memory_provider_id,
   tts_models.id AS tts_model_id,
+  stt_models.id AS stt_model_id,
   browser_contexts.id AS browser_context_id
 FROM bots
 LEFT JOIN models AS chat_models ON chat_models.id = bots.chat_model_id
@@ -27,6 +36,7 @@ LEFT JOIN models AS title_models ON title_models.id = bots.title_model_id
 LEFT JOIN search_providers ON search_providers.id = bots.search_provider_id
 LEFT JOIN memory_providers ON memory_providers.id = bots.memory_provider_id
 LEFT JOIN tts_models ON tts_models.id = bots.tts_model_id
+LEFT JOIN stt_models ON stt_models.id = bots.stt_model_id
 LEFT JOIN browser_contexts ON browser_contexts.id = bots.browser_context_id
 WHERE bots.id = $1;
 
@@ -43,6 +53,14 @@ WITH updated AS (
       heartbeat_prompt = sqlc.arg(heartbeat_prompt),
       compaction_enabled = sqlc.arg(compaction_enabled),
       compaction_threshold = sqlc.arg(compaction_threshold),
+      memory_scope_policy = sqlc.arg(memory_scope_policy),
+      timezone = sqlc.arg(timezone),
+      heartbeat_active_start = sqlc.arg(heartbeat_active_start),
+      heartbeat_active_end = sqlc.arg(heartbeat_active_end),
+      heartbeat_jitter = sqlc.arg(heartbeat_jitter),
+      heartbeat_max_interval = sqlc.arg(heartbeat_max_interval),
+      fetch_allow_domains = sqlc.arg(fetch_allow_domains),
+      fetch_deny_domains = sqlc.arg(fetch_deny_domains),
       chat_model_id = COALESCE(sqlc.narg(chat_model_id)::uuid, bots.chat_model_id),
       heartbeat_model_id = COALESCE(sqlc.narg(heartbeat_model_id)::uuid, bots.heartbeat_model_id),
       compaction_model_id = COALESCE(sqlc.narg(compaction_model_id)::uuid, bots.compaction_model_id),
@@ -50,10 +68,11 @@ WITH updated AS (
       search_provider_id = COALESCE(sqlc.narg(search_provider_id)::uuid, bots.search_provider_id),
       memory_provider_id = COALESCE(sqlc.narg(memory_provider_id)::uuid, bots.memory_provider_id),
       tts_model_id = COALESCE(sqlc.narg(tts_model_id)::uuid, bots.tts_model_id),
+      stt_model_id = COALESCE(sqlc.narg(stt_model_id)::uuid, bots.stt_model_id),
       browser_context_id = COALESCE(sqlc.narg(browser_context_id)::uuid, bots.browser_context_id),
       updated_at = now()
   WHERE bots.id = sqlc.arg(id)
-  RETURNING bots.id, bots.max_context_load_time, bots.max_context_tokens, bots.language, bots.reasoning_enabled, bots.reasoning_effort, bots.heartbeat_enabled, bots.heartbeat_interval, bots.heartbeat_prompt, bots.compaction_enabled, bots.compaction_threshold, bots.chat_model_id, bots.heartbeat_model_id, bots.compaction_model_id, bots.title_model_id, bots.search_provider_id, bots.memory_provider_id, bots.tts_model_id, bots.browser_context_id
+  RETURNING bots.id, bots.max_context_load_time, bots.max_context_tokens, bots.language, bots.reasoning_enabled, bots.reasoning_effort, bots.heartbeat_enabled, bots.heartbeat_interval, bots.heartbeat_prompt, bots.compaction_enabled, bots.compaction_threshold, bots.memory_scope_policy, bots.timezone, bots.heartbeat_active_start, bots.heartbeat_active_end, bots.heartbeat_jitter, bots.heartbeat_max_interval, bots.fetch_allow_domains, bots.fetch_deny_domains, bots.chat_model_id, bots.heartbeat_model_id, bots.compaction_model_id, bots.title_model_id, bots.search_provider_id, bots.memory_provider_id, bots.tts_model_id, bots.stt_model_id, bots.browser_context_id
 )
 SELECT
   updated.id AS bot_id,
@@ -67,6 +86,14 @@ SELECT
   updated.heartbeat_prompt,
   updated.compaction_enabled,
   updated.compaction_threshold,
+  updated.memory_scope_policy,
+  updated.timezone,
+  updated.heartbeat_active_start,
+  updated.heartbeat_active_end,
+  updated.heartbeat_jitter,
+  updated.heartbeat_max_interval,
+  updated.fetch_allow_domains,
+  updated.fetch_deny_domains,
   chat_models.id AS chat_model_id,
   heartbeat_models.id AS heartbeat_model_id,
   compaction_models.id AS compaction_model_id,
@@ -74,6 +101,7 @@ SELECT
   search_providers.id AS search_provider_id,
   memory_providers.id AS memory_provider_id,
   tts_models.id AS tts_model_id,
+  stt_models.id AS stt_model_id,
   browser_contexts.id AS browser_context_id
 FROM updated
 LEFT JOIN models AS chat_models ON chat_models.id = updated.chat_model_id
@@ -83,6 +111,7 @@ LEFT JOIN models AS title_models ON title_models.id = updated.title_model_id
 LEFT JOIN search_providers ON search_providers.id = updated.search_provider_id
 LEFT JOIN memory_providers ON memory_providers.id = updated.memory_provider_id
 LEFT JOIN tts_models ON tts_models.id = updated.tts_model_id
+LEFT JOIN stt_models ON stt_models.id = updated.stt_model_id
 LEFT JOIN browser_contexts ON browser_contexts.id = updated.browser_context_id;
 
 -- name: DeleteSettingsByBotID :exec
@@ -97,6 +126,14 @@ SET max_context_load_time = 1440,
     heartbeat_prompt = '',
     compaction_enabled = false,
     compaction_threshold = 100000,
+    memory_scope_policy = 'private',
+    timezone = '',
+    heartbeat_active_start = '',
+    heartbeat_active_end = '',
+    heartbeat_jitter = 0,
+    heartbeat_max_interval = 0,
+    fetch_allow_domains = '{}',
+    fetch_deny_domains = '{}',
     chat_model_id = NULL,
     heartbeat_model_id = NULL,
     compaction_model_id = NULL,
@@ -104,6 +141,7 @@ SET max_context_load_time = 1440,
     search_provider_id = NULL,
     memory_provider_id = NULL,
     tts_model_id = NULL,
+    stt_model_id = NULL,
     browser_context_id = NULL,
     updated_at = now()
 WHERE id = $1;
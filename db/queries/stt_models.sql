@@ -0,0 +1,51 @@
+-- name: CreateSttModel :one
+INSERT INTO stt_models (model_id, name, stt_provider_id, config)
+VALUES (
+  sqlc.arg(model_id),
+  sqlc.arg(name),
+  sqlc.arg(stt_provider_id),
+  sqlc.arg(config)
+)
+RETURNING *;
+
+-- name: GetSttModelByID :one
+SELECT * FROM stt_models WHERE id = sqlc.arg(id);
+
+-- name: GetSttModelWithProvider :one
+SELECT
+  sm.*,
+  sp.provider AS provider_type,
+  sp.config AS provider_config
+FROM stt_models sm
+JOIN stt_providers sp ON sp.id = sm.stt_provider_id
+WHERE sm.id = sqlc.arg(id);
+
+-- name: ListSttModels :many
+SELECT * FROM stt_models
+ORDER BY created_at DESC;
+
+-- name: ListSttModelsByProviderID :many
+SELECT * FROM stt_models
+WHERE stt_provider_id = sqlc.arg(stt_provider_id)
+ORDER BY created_at DESC;
+
+-- name: UpdateSttModel :one
+UPDATE stt_models
+SET
+  name = sqlc.arg(name),
+  config = sqlc.arg(config),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteSttModel :exec
+DELETE FROM stt_models WHERE id = sqlc.arg(id);
+
+-- name: DeleteSttModelsByProviderID :exec
+DELETE FROM stt_models WHERE stt_provider_id = sqlc.arg(stt_provider_id);
+
+-- name: GetSttModelByProviderAndModelID :one
+SELECT * FROM stt_models
+WHERE stt_provider_id = sqlc.arg(stt_provider_id)
+  AND model_id = sqlc.arg(model_id)
+LIMIT 1;
@@ -0,0 +1,36 @@
+-- name: CreateSttProvider :one
+INSERT INTO stt_providers (name, provider, config)
+VALUES (
+  sqlc.arg(name),
+  sqlc.arg(provider),
+  sqlc.arg(config)
+)
+RETURNING *;
+
+-- name: GetSttProviderByID :one
+SELECT * FROM stt_providers WHERE id = sqlc.arg(id);
+
+-- name: GetSttProviderByName :one
+SELECT * FROM stt_providers WHERE name = sqlc.arg(name);
+
+-- name: ListSttProviders :many
+SELECT * FROM stt_providers
+ORDER BY created_at DESC;
+
+-- name: ListSttProvidersByProvider :many
+SELECT * FROM stt_providers
+WHERE provider = sqlc.arg(provider)
+ORDER BY created_at DESC;
+
+-- name: UpdateSttProvider :one
+UPDATE stt_providers
+SET
+  name = sqlc.arg(name),
+  provider = sqlc.arg(provider),
+  config = sqlc.arg(config),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteSttProvider :exec
+DELETE FROM stt_providers WHERE id = sqlc.arg(id);
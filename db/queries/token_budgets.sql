@@ -0,0 +1,67 @@
+-- name: CreateTokenBudget :one
+INSERT INTO bot_token_budgets (
+  bot_id, scope, user_id, channel_identity_id, period,
+  max_input_tokens, max_output_tokens, max_total_tokens, exhausted_message, enabled
+)
+VALUES (
+  sqlc.arg(bot_id),
+  sqlc.arg(scope),
+  sqlc.narg(user_id)::uuid,
+  sqlc.narg(channel_identity_id)::uuid,
+  sqlc.arg(period),
+  sqlc.arg(max_input_tokens),
+  sqlc.arg(max_output_tokens),
+  sqlc.arg(max_total_tokens),
+  sqlc.arg(exhausted_message),
+  sqlc.arg(enabled)
+)
+RETURNING id, bot_id, scope, user_id, channel_identity_id, period, max_input_tokens, max_output_tokens, max_total_tokens, exhausted_message, enabled, created_at, updated_at;
+
+-- name: GetTokenBudgetByID :one
+SELECT id, bot_id, scope, user_id, channel_identity_id, period, max_input_tokens, max_output_tokens, max_total_tokens, exhausted_message, enabled, created_at, updated_at
+FROM bot_token_budgets
+WHERE id = $1;
+
+-- name: ListTokenBudgetsByBot :many
+SELECT id, bot_id, scope, user_id, channel_identity_id, period, max_input_tokens, max_output_tokens, max_total_tokens, exhausted_message, enabled, created_at, updated_at
+FROM bot_token_budgets
+WHERE bot_id = $1
+ORDER BY created_at;
+
+-- name: UpdateTokenBudget :one
+UPDATE bot_token_budgets
+SET period = sqlc.arg(period),
+    max_input_tokens = sqlc.arg(max_input_tokens),
+    max_output_tokens = sqlc.arg(max_output_tokens),
+    max_total_tokens = sqlc.arg(max_total_tokens),
+    exhausted_message = sqlc.arg(exhausted_message),
+    enabled = sqlc.arg(enabled),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING id, bot_id, scope, user_id, channel_identity_id, period, max_input_tokens, max_output_tokens, max_total_tokens, exhausted_message, enabled, created_at, updated_at;
+
+-- name: DeleteTokenBudget :exec
+DELETE FROM bot_token_budgets
+WHERE id = $1;
+
+-- name: RecordTokenUsage :exec
+INSERT INTO bot_token_usage (bot_id, session_id, user_id, channel_identity_id, model_id, input_tokens, output_tokens)
+VALUES (
+  sqlc.arg(bot_id),
+  sqlc.narg(session_id)::uuid,
+  sqlc.narg(user_id)::uuid,
+  sqlc.narg(channel_identity_id)::uuid,
+  sqlc.narg(model_id)::uuid,
+  sqlc.arg(input_tokens),
+  sqlc.arg(output_tokens)
+);
+
+-- name: SumTokenUsageSince :one
+SELECT
+  COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+  COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens
+FROM bot_token_usage
+WHERE bot_id = sqlc.arg(bot_id)
+  AND created_at >= sqlc.arg(since)
+  AND (sqlc.narg(user_id)::uuid IS NULL OR user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.narg(channel_identity_id)::uuid IS NULL OR channel_identity_id = sqlc.narg(channel_identity_id)::uuid);
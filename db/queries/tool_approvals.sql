@@ -0,0 +1,65 @@
+-- name: GetBotToolApprovalPolicy :one
+SELECT bot_id, tool_patterns, timeout_seconds, updated_at
+FROM bot_tool_approval_policies
+WHERE bot_id = $1;
+
+-- name: UpsertBotToolApprovalPolicy :one
+INSERT INTO bot_tool_approval_policies (bot_id, tool_patterns, timeout_seconds)
+VALUES (
+  sqlc.arg(bot_id),
+  sqlc.arg(tool_patterns)::text[],
+  sqlc.arg(timeout_seconds)
+)
+ON CONFLICT (bot_id) DO UPDATE SET
+  tool_patterns = EXCLUDED.tool_patterns,
+  timeout_seconds = EXCLUDED.timeout_seconds,
+  updated_at = now()
+RETURNING bot_id, tool_patterns, timeout_seconds, updated_at;
+
+-- name: CreateToolApproval :one
+INSERT INTO tool_approvals (
+  bot_id, session_id, requester_channel_identity_id, tool_name, tool_call_id,
+  input, platform, reply_target, expires_at
+)
+VALUES (
+  sqlc.arg(bot_id),
+  sqlc.narg(session_id)::uuid,
+  sqlc.narg(requester_channel_identity_id)::uuid,
+  sqlc.arg(tool_name),
+  sqlc.arg(tool_call_id),
+  sqlc.arg(input),
+  sqlc.arg(platform),
+  sqlc.arg(reply_target),
+  sqlc.arg(expires_at)
+)
+RETURNING id, bot_id, session_id, requester_channel_identity_id, tool_name, tool_call_id, input, platform, reply_target, status, decided_by_user_id, decision_note, expires_at, decided_at, created_at;
+
+-- name: GetToolApproval :one
+SELECT id, bot_id, session_id, requester_channel_identity_id, tool_name, tool_call_id, input, platform, reply_target, status, decided_by_user_id, decision_note, expires_at, decided_at, created_at
+FROM tool_approvals
+WHERE id = $1;
+
+-- name: ListPendingToolApprovals :many
+SELECT id, bot_id, session_id, requester_channel_identity_id, tool_name, tool_call_id, input, platform, reply_target, status, decided_by_user_id, decision_note, expires_at, decided_at, created_at
+FROM tool_approvals
+WHERE bot_id = $1
+  AND status = 'pending'
+ORDER BY created_at DESC;
+
+-- name: ListToolApprovals :many
+SELECT id, bot_id, session_id, requester_channel_identity_id, tool_name, tool_call_id, input, platform, reply_target, status, decided_by_user_id, decision_note, expires_at, decided_at, created_at
+FROM tool_approvals
+WHERE bot_id = sqlc.arg(bot_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(max_count);
+
+-- name: DecideToolApproval :one
+UPDATE tool_approvals
+SET status = sqlc.arg(status),
+    decided_by_user_id = sqlc.narg(decided_by_user_id)::uuid,
+    decision_note = sqlc.arg(decision_note),
+    decided_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'pending'
+RETURNING id, bot_id, session_id, requester_channel_identity_id, tool_name, tool_call_id, input, platform, reply_target, status, decided_by_user_id, decision_note, expires_at, decided_at, created_at;
@@ -0,0 +1,35 @@
+-- name: CreateUserAPIKey :one
+INSERT INTO user_api_keys (user_id, name, token_prefix, token_hash, scopes, expires_at)
+VALUES (
+  sqlc.arg(user_id),
+  sqlc.arg(name),
+  sqlc.arg(token_prefix),
+  sqlc.arg(token_hash),
+  sqlc.arg(scopes)::text[],
+  sqlc.narg(expires_at)::timestamptz
+)
+RETURNING id, user_id, name, token_prefix, token_hash, scopes, expires_at, last_used_at, revoked_at, created_at;
+
+-- name: GetUserAPIKeyByHash :one
+SELECT id, user_id, name, token_prefix, token_hash, scopes, expires_at, last_used_at, revoked_at, created_at
+FROM user_api_keys
+WHERE token_hash = $1;
+
+-- name: ListUserAPIKeys :many
+SELECT id, user_id, name, token_prefix, token_hash, scopes, expires_at, last_used_at, revoked_at, created_at
+FROM user_api_keys
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeUserAPIKey :execrows
+UPDATE user_api_keys
+SET revoked_at = now()
+WHERE id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id)
+  AND revoked_at IS NULL;
+
+-- name: TouchUserAPIKey :exec
+UPDATE user_api_keys
+SET last_used_at = now()
+WHERE id = $1
+  AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute');
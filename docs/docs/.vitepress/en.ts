@@ -72,6 +72,10 @@ export const en = [
         text: 'Scheduled Tasks',
         link: '/getting-started/schedule.md'
       },
+      {
+        text: 'Event Triggers',
+        link: '/getting-started/event-triggers.md'
+      },
       {
         text: 'Search Providers',
         link: '/getting-started/search-provider.md'
@@ -135,6 +139,30 @@ export const en = [
         text: 'Discord',
         link: '/channels/discord.md'
       },
+      {
+        text: 'Slack',
+        link: '/channels/slack.md'
+      },
+      {
+        text: 'Mattermost',
+        link: '/channels/mattermost.md'
+      },
+      {
+        text: 'IRC',
+        link: '/channels/irc.md'
+      },
+      {
+        text: 'XMPP',
+        link: '/channels/xmpp.md'
+      },
+      {
+        text: 'Signal',
+        link: '/channels/signal.md'
+      },
+      {
+        text: 'Webhook',
+        link: '/channels/webhook.md'
+      },
       {
         text: 'QQ',
         link: '/channels/qq.md'
@@ -360,9 +360,10 @@ func TestAddWhitelistEntryChannelIdentityForcesIdentityChannel(t *testing.T) {
 						*dest[8].(*pgtype.Text) = pgtype.Text{String: "group", Valid: true}
 						*dest[9].(*pgtype.Text) = pgtype.Text{String: "chat-1", Valid: true}
 						*dest[10].(*pgtype.Text) = pgtype.Text{}
-						*dest[11].(*pgtype.UUID) = createdByUUID
-						*dest[12].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: now, Valid: true}
+						*dest[11].(*pgtype.Text) = pgtype.Text{}
+						*dest[12].(*pgtype.UUID) = createdByUUID
 						*dest[13].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: now, Valid: true}
+						*dest[14].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: now, Valid: true}
 						return nil
 					},
 				}
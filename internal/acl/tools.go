@@ -0,0 +1,281 @@
+package acl
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/memohai/memoh/internal/db"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+var (
+	ErrInvalidToolPattern = errors.New("tool_pattern must be a tool name or glob")
+	ErrInvalidEffect      = errors.New("effect must be allow or deny")
+	ErrToolRuleNotFound   = errors.New("tool rule not found")
+)
+
+// ToolPolicy decides which tools are offered to one caller. The zero value
+// allows every tool.
+type ToolPolicy struct {
+	rules []toolRule
+}
+
+type toolRule struct {
+	pattern  string
+	effect   string
+	specific bool
+}
+
+// Allows reports whether the tool may be offered. Rules naming the caller
+// take precedence over rules for everyone; within those, an exact tool name
+// beats a glob, and deny beats allow. Tools no rule matches are allowed.
+func (p ToolPolicy) Allows(name string) bool {
+	best := -1
+	allowed := true
+	for _, rule := range p.rules {
+		exact := rule.pattern == name
+		if !exact {
+			if matched, err := path.Match(rule.pattern, name); err != nil || !matched {
+				continue
+			}
+		}
+		rank := 0
+		if rule.specific {
+			rank += 4
+		}
+		if exact {
+			rank += 2
+		}
+		if rule.effect == EffectDeny {
+			rank++
+		}
+		if rank > best {
+			best = rank
+			allowed = rule.effect == EffectAllow
+		}
+	}
+	return allowed
+}
+
+// ResolveToolPolicy collects the tool rules that apply to a caller. The bot
+// owner and runs without a caller identity (schedules, heartbeats) are not
+// restricted.
+func (s *Service) ResolveToolPolicy(ctx context.Context, req ToolAccessRequest) (ToolPolicy, error) {
+	if s == nil || s.queries == nil || s.bots == nil {
+		return ToolPolicy{}, errors.New("acl service not configured")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	channelIdentityID := strings.TrimSpace(req.ChannelIdentityID)
+	if userID == "" && channelIdentityID == "" {
+		return ToolPolicy{}, nil
+	}
+	pgBotID, err := db.ParseUUID(req.BotID)
+	if err != nil {
+		return ToolPolicy{}, err
+	}
+	rows, err := s.queries.ListBotACLToolRules(ctx, pgBotID)
+	if err != nil {
+		return ToolPolicy{}, err
+	}
+	if len(rows) == 0 {
+		return ToolPolicy{}, nil
+	}
+	if userID == "" {
+		if pgIdentityID, err := db.ParseUUID(channelIdentityID); err == nil {
+			if identity, err := s.queries.GetChannelIdentityByID(ctx, pgIdentityID); err == nil && identity.UserID.Valid {
+				userID = uuid.UUID(identity.UserID.Bytes).String()
+			}
+		}
+	}
+	bot, err := s.bots.Get(ctx, req.BotID)
+	if err != nil {
+		return ToolPolicy{}, err
+	}
+	if userID != "" && strings.TrimSpace(bot.OwnerUserID) == userID {
+		return ToolPolicy{}, nil
+	}
+
+	var policy ToolPolicy
+	for _, row := range rows {
+		var specific bool
+		switch row.SubjectKind {
+		case SubjectKindGuestAll:
+		case SubjectKindUser:
+			if userID == "" || !row.UserID.Valid || uuid.UUID(row.UserID.Bytes).String() != userID {
+				continue
+			}
+			specific = true
+		case SubjectKindChannelIdentity:
+			if channelIdentityID == "" || !row.ChannelIdentityID.Valid || uuid.UUID(row.ChannelIdentityID.Bytes).String() != channelIdentityID {
+				continue
+			}
+			specific = true
+		default:
+			continue
+		}
+		policy.rules = append(policy.rules, toolRule{
+			pattern:  strings.TrimSpace(row.ToolPattern.String),
+			effect:   row.Effect,
+			specific: specific,
+		})
+	}
+	return policy, nil
+}
+
+func (s *Service) ListToolRules(ctx context.Context, botID string) ([]Rule, error) {
+	if s == nil || s.queries == nil {
+		return nil, errors.New("acl queries not configured")
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListBotACLToolRules(ctx, pgBotID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Rule, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toolRuleToRule(row))
+	}
+	return items, nil
+}
+
+func (s *Service) AddToolRule(ctx context.Context, botID, createdByUserID string, req UpsertToolRuleRequest) (Rule, error) {
+	if s == nil || s.queries == nil {
+		return Rule{}, errors.New("acl queries not configured")
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return Rule{}, err
+	}
+	effect := strings.ToLower(strings.TrimSpace(req.Effect))
+	if effect != EffectAllow && effect != EffectDeny {
+		return Rule{}, ErrInvalidEffect
+	}
+	pattern, err := normalizeToolPattern(req.ToolPattern)
+	if err != nil {
+		return Rule{}, err
+	}
+	userID := strings.TrimSpace(req.UserID)
+	channelIdentityID := strings.TrimSpace(req.ChannelIdentityID)
+	if userID != "" && channelIdentityID != "" {
+		return Rule{}, ErrInvalidRuleSubject
+	}
+
+	var row sqlc.BotAclRule
+	if channelIdentityID != "" {
+		row, err = s.queries.UpsertBotACLToolChannelIdentityRule(ctx, sqlc.UpsertBotACLToolChannelIdentityRuleParams{
+			BotID:             pgBotID,
+			Effect:            effect,
+			ChannelIdentityID: optionalUUID(channelIdentityID),
+			CreatedByUserID:   optionalUUID(createdByUserID),
+			ToolPattern:       pattern,
+		})
+	} else {
+		subjectKind := SubjectKindGuestAll
+		if userID != "" {
+			subjectKind = SubjectKindUser
+		}
+		row, err = s.queries.UpsertBotACLToolUserRule(ctx, sqlc.UpsertBotACLToolUserRuleParams{
+			BotID:           pgBotID,
+			Effect:          effect,
+			SubjectKind:     subjectKind,
+			UserID:          optionalUUID(userID),
+			CreatedByUserID: optionalUUID(createdByUserID),
+			ToolPattern:     pattern,
+		})
+	}
+	if err != nil {
+		return Rule{}, err
+	}
+	rule := ruleFromWriteRow(
+		row.ID,
+		row.BotID,
+		row.Action,
+		row.Effect,
+		row.SubjectKind,
+		row.UserID,
+		row.ChannelIdentityID,
+		row.SourceChannel,
+		row.SourceConversationType,
+		row.SourceConversationID,
+		row.SourceThreadID,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+	rule.ToolPattern = strings.TrimSpace(row.ToolPattern.String)
+	return rule, nil
+}
+
+// DeleteToolRule removes a tool rule of botID. It returns ErrToolRuleNotFound
+// when ruleID is not a tool rule of that bot.
+func (s *Service) DeleteToolRule(ctx context.Context, botID, ruleID string) error {
+	if s == nil || s.queries == nil {
+		return errors.New("acl queries not configured")
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return err
+	}
+	pgRuleID, err := db.ParseUUID(ruleID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.queries.DeleteBotACLToolRule(ctx, sqlc.DeleteBotACLToolRuleParams{ID: pgRuleID, BotID: pgBotID})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrToolRuleNotFound
+	}
+	return nil
+}
+
+func normalizeToolPattern(pattern string) (string, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return "", ErrInvalidToolPattern
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return "", ErrInvalidToolPattern
+	}
+	return pattern, nil
+}
+
+func toolRuleToRule(row sqlc.ListBotACLToolRulesRow) Rule {
+	rule := Rule{
+		ID:                         uuid.UUID(row.ID.Bytes).String(),
+		BotID:                      uuid.UUID(row.BotID.Bytes).String(),
+		Action:                     row.Action,
+		Effect:                     row.Effect,
+		SubjectKind:                row.SubjectKind,
+		ToolPattern:                strings.TrimSpace(row.ToolPattern.String),
+		UserUsername:               strings.TrimSpace(row.UserUsername.String),
+		UserDisplayName:            strings.TrimSpace(row.UserDisplayName.String),
+		UserAvatarURL:              strings.TrimSpace(row.UserAvatarUrl.String),
+		ChannelType:                strings.TrimSpace(row.ChannelType.String),
+		ChannelSubjectID:           strings.TrimSpace(row.ChannelSubjectID.String),
+		ChannelIdentityDisplayName: strings.TrimSpace(row.ChannelIdentityDisplayName.String),
+		ChannelIdentityAvatarURL:   strings.TrimSpace(row.ChannelIdentityAvatarUrl.String),
+		LinkedUserUsername:         strings.TrimSpace(row.LinkedUserUsername.String),
+		LinkedUserDisplayName:      strings.TrimSpace(row.LinkedUserDisplayName.String),
+		LinkedUserAvatarURL:        strings.TrimSpace(row.LinkedUserAvatarUrl.String),
+		CreatedAt:                  timeFromPg(row.CreatedAt),
+		UpdatedAt:                  timeFromPg(row.UpdatedAt),
+	}
+	if row.UserID.Valid {
+		rule.UserID = uuid.UUID(row.UserID.Bytes).String()
+	}
+	if row.ChannelIdentityID.Valid {
+		rule.ChannelIdentityID = uuid.UUID(row.ChannelIdentityID.Bytes).String()
+	}
+	if row.LinkedUserID.Valid {
+		rule.LinkedUserID = uuid.UUID(row.LinkedUserID.Bytes).String()
+	}
+	return rule
+}
@@ -0,0 +1,179 @@
+package acl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/bots"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+func TestToolPolicyAllows(t *testing.T) {
+	policy := ToolPolicy{rules: []toolRule{
+		{pattern: "exec", effect: EffectDeny},
+		{pattern: "github_*", effect: EffectDeny},
+		{pattern: "github_search", effect: EffectAllow},
+		{pattern: "spawn", effect: EffectDeny},
+		{pattern: "spawn", effect: EffectAllow, specific: true},
+		{pattern: "send_*", effect: EffectAllow},
+		{pattern: "send_*", effect: EffectDeny},
+	}}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "exec", want: false},
+		{name: "read", want: true},
+		{name: "github_create_issue", want: false},
+		{name: "github_search", want: true},
+		{name: "spawn", want: true},
+		{name: "send_email", want: false},
+	}
+	for _, tt := range tests {
+		if got := policy.Allows(tt.name); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !(ToolPolicy{}).Allows("exec") {
+		t.Fatal("zero policy should allow every tool")
+	}
+}
+
+func TestResolveToolPolicy(t *testing.T) {
+	botUUID := pgtype.UUID{Bytes: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Valid: true}
+	ownerUUID := pgtype.UUID{Bytes: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Valid: true}
+	guestIdentityUUID := pgtype.UUID{Bytes: uuid.MustParse("55555555-5555-5555-5555-555555555555"), Valid: true}
+	ownerIdentityUUID := pgtype.UUID{Bytes: uuid.MustParse("66666666-6666-6666-6666-666666666666"), Valid: true}
+	trustedIdentityUUID := pgtype.UUID{Bytes: uuid.MustParse("77777777-7777-7777-7777-777777777777"), Valid: true}
+
+	type ruleRow struct {
+		effect            string
+		subjectKind       string
+		channelIdentityID pgtype.UUID
+		pattern           string
+	}
+	rules := []ruleRow{
+		{effect: EffectDeny, subjectKind: SubjectKindGuestAll, pattern: "exec"},
+		{effect: EffectAllow, subjectKind: SubjectKindChannelIdentity, channelIdentityID: trustedIdentityUUID, pattern: "exec"},
+	}
+	db := &fakeDBTX{
+		queryFunc: func(_ context.Context, sql string, _ ...any) (pgx.Rows, error) {
+			if !strings.Contains(sql, "ListBotACLToolRules") {
+				return &fakeRows{}, nil
+			}
+			scans := make([]func(dest ...any) error, 0, len(rules))
+			for _, rule := range rules {
+				scans = append(scans, func(dest ...any) error {
+					*dest[0].(*pgtype.UUID) = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+					*dest[1].(*pgtype.UUID) = botUUID
+					*dest[2].(*string) = ActionToolUse
+					*dest[3].(*string) = rule.effect
+					*dest[4].(*string) = rule.subjectKind
+					*dest[6].(*pgtype.UUID) = rule.channelIdentityID
+					*dest[7].(*pgtype.Text) = pgtype.Text{String: rule.pattern, Valid: true}
+					return nil
+				})
+			}
+			return &fakeRows{rows: scans}, nil
+		},
+		queryRowFunc: func(_ context.Context, sql string, args ...any) pgx.Row {
+			switch {
+			case strings.Contains(sql, "FROM bots"):
+				return makeBotRow(botUUID, ownerUUID)
+			case strings.Contains(sql, "FROM channel_identities"):
+				id := args[0].(pgtype.UUID)
+				return &fakeRow{scanFunc: func(dest ...any) error {
+					*dest[0].(*pgtype.UUID) = id
+					if id == ownerIdentityUUID {
+						*dest[1].(*pgtype.UUID) = ownerUUID
+					}
+					return nil
+				}}
+			default:
+				return &fakeRow{scanFunc: func(_ ...any) error { return pgx.ErrNoRows }}
+			}
+		},
+	}
+	queries := sqlc.New(db)
+	service := NewService(nil, queries, bots.NewService(nil, queries))
+
+	tests := []struct {
+		name              string
+		channelIdentityID string
+		wantExec          bool
+	}{
+		{name: "guest denied", channelIdentityID: guestIdentityUUID.String(), wantExec: false},
+		{name: "specific allow overrides everyone deny", channelIdentityID: trustedIdentityUUID.String(), wantExec: true},
+		{name: "owner via linked identity", channelIdentityID: ownerIdentityUUID.String(), wantExec: true},
+		{name: "no caller identity", wantExec: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := service.ResolveToolPolicy(context.Background(), ToolAccessRequest{
+				BotID:             botUUID.String(),
+				ChannelIdentityID: tt.channelIdentityID,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := policy.Allows("exec"); got != tt.wantExec {
+				t.Fatalf("Allows(exec) = %v, want %v", got, tt.wantExec)
+			}
+			if !policy.Allows("read") {
+				t.Fatal("unmatched tools should stay allowed")
+			}
+		})
+	}
+}
+
+func TestDeleteToolRuleIsScopedToBot(t *testing.T) {
+	const (
+		botID  = "11111111-1111-1111-1111-111111111111"
+		ruleID = "33333333-3333-3333-3333-333333333333"
+	)
+	var gotArgs []any
+	db := &fakeDBTX{
+		execFunc: func(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+			if !strings.Contains(sql, "DeleteBotACLToolRule") || !strings.Contains(sql, "action = 'tool.use'") {
+				t.Fatalf("unexpected query: %s", sql)
+			}
+			gotArgs = args
+			if args[1].(pgtype.UUID).String() != botID {
+				return pgconn.NewCommandTag("DELETE 0"), nil
+			}
+			return pgconn.NewCommandTag("DELETE 1"), nil
+		},
+	}
+	queries := sqlc.New(db)
+	service := NewService(nil, queries, bots.NewService(nil, queries))
+
+	if err := service.DeleteToolRule(context.Background(), botID, ruleID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(gotArgs) != 2 || gotArgs[0].(pgtype.UUID).String() != ruleID {
+		t.Fatalf("unexpected args: %v", gotArgs)
+	}
+	otherBot := "44444444-4444-4444-4444-444444444444"
+	if err := service.DeleteToolRule(context.Background(), otherBot, ruleID); !errors.Is(err, ErrToolRuleNotFound) {
+		t.Fatalf("expected ErrToolRuleNotFound, got %v", err)
+	}
+}
+
+func TestNormalizeToolPattern(t *testing.T) {
+	if got, err := normalizeToolPattern("  mcp_* "); err != nil || got != "mcp_*" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	for _, bad := range []string{"", "   ", "bad["} {
+		if _, err := normalizeToolPattern(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 const (
 	ActionChatTrigger = "chat.trigger"
+	ActionToolUse     = "tool.use"
 
 	EffectAllow = "allow"
 	EffectDeny  = "deny"
@@ -27,6 +28,7 @@ type Rule struct {
 	UserID                     string       `json:"user_id,omitempty"`
 	ChannelIdentityID          string       `json:"channel_identity_id,omitempty"`
 	SourceScope                *SourceScope `json:"source_scope,omitempty"`
+	ToolPattern                string       `json:"tool_pattern,omitempty"`
 	UserUsername               string       `json:"user_username,omitempty"`
 	UserDisplayName            string       `json:"user_display_name,omitempty"`
 	UserAvatarURL              string       `json:"user_avatar_url,omitempty"`
@@ -59,6 +61,23 @@ type UpsertRuleRequest struct {
 	SourceScope       *SourceScope `json:"source_scope,omitempty"`
 }
 
+// UpsertToolRuleRequest allows or denies tools matching ToolPattern, an exact
+// tool name or a glob such as "github_*". Leaving both subject fields empty
+// applies the rule to every caller.
+type UpsertToolRuleRequest struct {
+	Effect            string `json:"effect"`
+	ToolPattern       string `json:"tool_pattern"`
+	UserID            string `json:"user_id,omitempty"`
+	ChannelIdentityID string `json:"channel_identity_id,omitempty"`
+}
+
+// ToolAccessRequest identifies the caller a tool set is being built for.
+type ToolAccessRequest struct {
+	BotID             string
+	UserID            string
+	ChannelIdentityID string
+}
+
 type ChatTriggerRequest struct {
 	BotID             string
 	UserID            string
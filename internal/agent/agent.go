@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync/atomic"
 
 	sdk "github.com/memohai/twilight-ai/sdk"
 
+	"github.com/memohai/memoh/internal/acl"
 	"github.com/memohai/memoh/internal/agent/tools"
+	"github.com/memohai/memoh/internal/approval"
 	"github.com/memohai/memoh/internal/workspace/bridge"
 )
 
@@ -19,6 +22,8 @@ type Agent struct {
 	client         *sdk.Client
 	toolProviders  []tools.ToolProvider
 	bridgeProvider bridge.Provider
+	toolPolicy     ToolPolicyResolver
+	approvals      ToolApprover
 	logger         *slog.Logger
 }
 
@@ -31,6 +36,8 @@ func New(deps Deps) *Agent {
 	return &Agent{
 		client:         sdk.NewClient(),
 		bridgeProvider: deps.BridgeProvider,
+		toolPolicy:     deps.ToolPolicy,
+		approvals:      deps.Approvals,
 		logger:         logger.With(slog.String("service", "agent")),
 	}
 }
@@ -289,6 +296,8 @@ func (a *Agent) runGenerate(ctx context.Context, cfg RunConfig) (*GenerateResult
 	if toolLoopGuard != nil {
 		tools = wrapToolsWithLoopGuard(tools, toolLoopGuard, toolLoopAbortCallIDs)
 	}
+	var produced atomic.Bool
+	tools = wrapToolsWithOutputMarker(tools, &produced)
 
 	var prepareStep func(*sdk.GenerateParams) *sdk.GenerateParams
 	if readMediaState != nil {
@@ -297,6 +306,9 @@ func (a *Agent) runGenerate(ctx context.Context, cfg RunConfig) (*GenerateResult
 	opts := a.buildGenerateOptions(cfg, tools, prepareStep)
 	opts = append(opts,
 		sdk.WithOnStep(func(step *sdk.StepResult) *sdk.GenerateParams {
+			if isNonEmptyString(step.Text) || len(step.ToolCalls) > 0 {
+				produced.Store(true)
+			}
 			if cfg.LoopDetection.Enabled {
 				if len(toolLoopAbortCallIDs) > 0 {
 					return nil // stop
@@ -314,6 +326,9 @@ func (a *Agent) runGenerate(ctx context.Context, cfg RunConfig) (*GenerateResult
 
 	genResult, err := a.client.GenerateTextResult(ctx, opts...)
 	if err != nil {
+		if produced.Load() {
+			return nil, fmt.Errorf("generate: %w: %w", ErrPartialOutput, err)
+		}
 		return nil, fmt.Errorf("generate: %w", err)
 	}
 
@@ -418,7 +433,10 @@ func (a *Agent) assembleTools(ctx context.Context, cfg RunConfig) ([]sdk.Tool, e
 		BotID:              cfg.Identity.BotID,
 		ChatID:             cfg.Identity.ChatID,
 		SessionID:          cfg.Identity.SessionID,
+		UserID:             cfg.Identity.UserID,
 		ChannelIdentityID:  cfg.Identity.ChannelIdentityID,
+		ConversationID:     cfg.Identity.ConversationID,
+		ConversationType:   cfg.Identity.ConversationType,
 		SessionToken:       cfg.Identity.SessionToken,
 		CurrentPlatform:    cfg.Identity.CurrentPlatform,
 		ReplyTarget:        cfg.Identity.ReplyTarget,
@@ -436,9 +454,98 @@ func (a *Agent) assembleTools(ctx context.Context, cfg RunConfig) ([]sdk.Tool, e
 		}
 		allTools = append(allTools, providerTools...)
 	}
+	allowed, err := a.filterToolsByPolicy(ctx, cfg.Identity, allTools)
+	if err != nil {
+		return nil, err
+	}
+	return a.wrapToolsWithApproval(ctx, cfg.Identity, allowed)
+}
+
+// filterToolsByPolicy drops tools the caller's ACL tool rules deny, so the
+// model is never offered them.
+func (a *Agent) filterToolsByPolicy(ctx context.Context, identity SessionContext, allTools []sdk.Tool) ([]sdk.Tool, error) {
+	if a.toolPolicy == nil || len(allTools) == 0 {
+		return allTools, nil
+	}
+	policy, err := a.toolPolicy.ResolveToolPolicy(ctx, acl.ToolAccessRequest{
+		BotID:             identity.BotID,
+		UserID:            identity.UserID,
+		ChannelIdentityID: identity.ChannelIdentityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolve tool policy: %w", err)
+	}
+	allowed := allTools[:0]
+	for _, tool := range allTools {
+		if policy.Allows(tool.Name) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed, nil
+}
+
+// wrapToolsWithApproval makes tools listed in the bot's approval policy wait
+// for the owner's decision before running. Rejected or expired calls return
+// an error result to the model instead of running. A policy that cannot be
+// loaded fails the run rather than letting gated tools run unchecked.
+func (a *Agent) wrapToolsWithApproval(ctx context.Context, identity SessionContext, allTools []sdk.Tool) ([]sdk.Tool, error) {
+	if a.approvals == nil || len(allTools) == 0 {
+		return allTools, nil
+	}
+	policy, err := a.approvals.ApprovalPolicy(ctx, identity.BotID)
+	if err != nil {
+		return nil, fmt.Errorf("load approval policy: %w", err)
+	}
+	if len(policy.ToolPatterns) == 0 {
+		return allTools, nil
+	}
+	for i, tool := range allTools {
+		if !policy.Requires(tool.Name) {
+			continue
+		}
+		originalExecute := tool.Execute
+		toolName := tool.Name
+		allTools[i].Execute = func(execCtx *sdk.ToolExecContext, input any) (any, error) {
+			decision, err := a.approvals.Await(execCtx, approval.Request{
+				BotID:             identity.BotID,
+				SessionID:         identity.SessionID,
+				ChannelIdentityID: identity.ChannelIdentityID,
+				Platform:          identity.CurrentPlatform,
+				ReplyTarget:       identity.ReplyTarget,
+				ToolName:          toolName,
+				ToolCallID:        execCtx.ToolCallID,
+				Input:             input,
+				Timeout:           policy.Timeout(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("await approval: %w", err)
+			}
+			if !decision.Approved() {
+				return approvalDeniedResult(toolName, decision), nil
+			}
+			return originalExecute(execCtx, input)
+		}
+	}
 	return allTools, nil
 }
 
+func approvalDeniedResult(toolName string, decision approval.Decision) map[string]any {
+	text := fmt.Sprintf("The bot owner rejected the %s call.", toolName)
+	if decision.Status == approval.StatusExpired {
+		text = fmt.Sprintf("The %s call was not approved before the request expired.", toolName)
+	}
+	if note := strings.TrimSpace(decision.Note); note != "" && decision.Status == approval.StatusRejected {
+		text += " Reason: " + note
+	}
+	return map[string]any{
+		"isError": true,
+		"content": []map[string]any{{
+			"type": "text",
+			"text": text,
+		}},
+	}
+}
+
 func emitTagEvents(ch chan<- StreamEvent, events []TagEvent) {
 	for _, ev := range events {
 		switch ev.Tag {
@@ -476,6 +583,24 @@ func emitTagEvents(ch chan<- StreamEvent, events []TagEvent) {
 	}
 }
 
+// wrapToolsWithOutputMarker sets produced as soon as any tool starts
+// executing, so a later failure is known to have had side effects.
+func wrapToolsWithOutputMarker(tools []sdk.Tool, produced *atomic.Bool) []sdk.Tool {
+	wrapped := make([]sdk.Tool, len(tools))
+	for i, tool := range tools {
+		originalExecute := tool.Execute
+		wrapped[i] = tool
+		if originalExecute == nil {
+			continue
+		}
+		wrapped[i].Execute = func(ctx *sdk.ToolExecContext, input any) (any, error) {
+			produced.Store(true)
+			return originalExecute(ctx, input)
+		}
+	}
+	return wrapped
+}
+
 func wrapToolsWithLoopGuard(tools []sdk.Tool, guard *ToolLoopGuard, abortCallIDs map[string]struct{}) []sdk.Tool {
 	wrapped := make([]sdk.Tool, len(tools))
 	for i, tool := range tools {
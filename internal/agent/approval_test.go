@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+
+	"github.com/memohai/memoh/internal/approval"
+)
+
+type fakeToolApprover struct {
+	policy    approval.Policy
+	policyErr error
+	decision  approval.Decision
+	awaited   []string
+}
+
+func (f *fakeToolApprover) ApprovalPolicy(context.Context, string) (approval.Policy, error) {
+	return f.policy, f.policyErr
+}
+
+func (f *fakeToolApprover) Await(_ context.Context, req approval.Request) (approval.Decision, error) {
+	f.awaited = append(f.awaited, req.ToolName)
+	return f.decision, nil
+}
+
+func approvalTestTools(ran *[]string) []sdk.Tool {
+	tools := make([]sdk.Tool, 0, 2)
+	for _, name := range []string{"exec", "read"} {
+		tools = append(tools, sdk.Tool{
+			Name: name,
+			Execute: func(_ *sdk.ToolExecContext, _ any) (any, error) {
+				*ran = append(*ran, name)
+				return "ok", nil
+			},
+		})
+	}
+	return tools
+}
+
+func TestWrapToolsWithApprovalGatesMatchingTools(t *testing.T) {
+	approver := &fakeToolApprover{
+		policy:   approval.Policy{ToolPatterns: []string{"exec"}},
+		decision: approval.Decision{Status: approval.StatusRejected},
+	}
+	a := New(Deps{Approvals: approver})
+
+	var ran []string
+	tools, err := a.wrapToolsWithApproval(context.Background(), SessionContext{BotID: "bot-1"}, approvalTestTools(&ran))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	execCtx := &sdk.ToolExecContext{Context: context.Background()}
+	for _, tool := range tools {
+		if _, err := tool.Execute(execCtx, nil); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tool.Name, err)
+		}
+	}
+	if len(approver.awaited) != 1 || approver.awaited[0] != "exec" {
+		t.Fatalf("expected approval for exec only, got %v", approver.awaited)
+	}
+	if len(ran) != 1 || ran[0] != "read" {
+		t.Fatalf("expected only read to run, got %v", ran)
+	}
+}
+
+func TestWrapToolsWithApprovalFailsClosedOnPolicyError(t *testing.T) {
+	loadErr := errors.New("database unavailable")
+	a := New(Deps{Approvals: &fakeToolApprover{policyErr: loadErr}})
+
+	var ran []string
+	tools, err := a.wrapToolsWithApproval(context.Background(), SessionContext{BotID: "bot-1"}, approvalTestTools(&ran))
+	if !errors.Is(err, loadErr) {
+		t.Fatalf("expected policy load error, got %v", err)
+	}
+	if tools != nil {
+		t.Fatalf("expected no tools when the policy cannot be loaded, got %d", len(tools))
+	}
+}
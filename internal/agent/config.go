@@ -1,13 +1,29 @@
 package agent
 
 import (
+	"context"
 	"log/slog"
 
+	"github.com/memohai/memoh/internal/acl"
+	"github.com/memohai/memoh/internal/approval"
 	"github.com/memohai/memoh/internal/workspace/bridge"
 )
 
 // Deps holds all service dependencies for the Agent.
 type Deps struct {
 	BridgeProvider bridge.Provider
+	ToolPolicy     ToolPolicyResolver
+	Approvals      ToolApprover
 	Logger         *slog.Logger
 }
+
+// ToolPolicyResolver decides which tools may be offered to the caller of a run.
+type ToolPolicyResolver interface {
+	ResolveToolPolicy(ctx context.Context, req acl.ToolAccessRequest) (acl.ToolPolicy, error)
+}
+
+// ToolApprover holds gated tool calls until the bot owner approves them.
+type ToolApprover interface {
+	ApprovalPolicy(ctx context.Context, botID string) (approval.Policy, error)
+	Await(ctx context.Context, req approval.Request) (approval.Decision, error)
+}
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 //go:embed prompts/*.md
@@ -17,8 +18,10 @@ var (
 	systemHeartbeatTmpl string
 	systemScheduleTmpl  string
 	systemSubagentTmpl  string
+	systemTriggerTmpl   string
 	scheduleTmpl        string
 	heartbeatTmpl       string
+	triggerTmpl         string
 
 	includes map[string]string
 )
@@ -30,8 +33,10 @@ func init() {
 	systemHeartbeatTmpl = mustReadPrompt("prompts/system_heartbeat.md")
 	systemScheduleTmpl = mustReadPrompt("prompts/system_schedule.md")
 	systemSubagentTmpl = mustReadPrompt("prompts/system_subagent.md")
+	systemTriggerTmpl = mustReadPrompt("prompts/system_trigger.md")
 	scheduleTmpl = mustReadPrompt("prompts/schedule.md")
 	heartbeatTmpl = mustReadPrompt("prompts/heartbeat.md")
+	triggerTmpl = mustReadPrompt("prompts/trigger.md")
 
 	includes = map[string]string{
 		"_memory":        mustReadPrompt("prompts/_memory.md"),
@@ -45,6 +50,7 @@ func init() {
 	systemHeartbeatTmpl = resolveIncludes(systemHeartbeatTmpl)
 	systemScheduleTmpl = resolveIncludes(systemScheduleTmpl)
 	systemSubagentTmpl = resolveIncludes(systemSubagentTmpl)
+	systemTriggerTmpl = resolveIncludes(systemTriggerTmpl)
 }
 
 func mustReadPrompt(name string) string {
@@ -87,6 +93,8 @@ func selectSystemTemplate(sessionType string) string {
 		return systemScheduleTmpl
 	case "subagent":
 		return systemSubagentTmpl
+	case "trigger":
+		return systemTriggerTmpl
 	default:
 		return systemChatTmpl
 	}
@@ -103,6 +111,7 @@ func GenerateSystemPrompt(params SystemPromptParams) string {
 		basicTools = append(basicTools, "- `read_media`: view the media")
 	}
 	basicTools = append(basicTools,
+		"- `read_document`: read text and tables from PDF, DOCX, XLSX and HTML files",
 		"- `write`: write file content",
 		"- `list`: list directory entries",
 		"- `edit`: replace exact text in a file",
@@ -145,17 +154,50 @@ func GenerateSchedulePrompt(s Schedule) string {
 	if s.MaxCalls != nil {
 		maxCallsStr = strconv.Itoa(*s.MaxCalls)
 	}
+	timing := "cron-pattern: " + s.Pattern
+	if s.Timezone != "" {
+		timing += "\ntimezone: " + s.Timezone
+	}
+	if s.RunAt != nil {
+		runAt := s.RunAt.UTC()
+		if loc, err := time.LoadLocation(s.Timezone); err == nil {
+			runAt = s.RunAt.In(loc)
+		}
+		timing = "run-at: " + runAt.Format(time.RFC3339) + " (one-shot, will not run again)"
+	}
+	delivery := "Your final answer is logged but not sent. Use `send` if anyone should see the result."
+	if s.DeliveryPlatform != "" && s.DeliveryTarget != "" {
+		delivery = "Your final answer will be delivered automatically to " + s.DeliveryPlatform + " target " + s.DeliveryTarget + ". Reply with the message itself; do not also `send` it there."
+	}
 	return render(scheduleTmpl, map[string]string{
 		"name":        s.Name,
 		"description": s.Description,
 		"maxCalls":    maxCallsStr,
-		"pattern":     s.Pattern,
+		"timing":      timing,
+		"delivery":    delivery,
 		"command":     s.Command,
 	})
 }
 
+// GenerateTriggerPrompt builds the user message for an event trigger.
+func GenerateTriggerPrompt(t TriggerEvent) string {
+	details := strings.TrimSpace(t.Details)
+	if details != "" {
+		details = "## Event details\n\n```json\n" + details + "\n```"
+	}
+	return render(triggerTmpl, map[string]string{
+		"name":        t.Name,
+		"description": t.Description,
+		"source":      t.Source,
+		"summary":     t.Summary,
+		"details":     details,
+		"command":     t.Command,
+	})
+}
+
 // GenerateHeartbeatPrompt builds the user message for a heartbeat trigger.
-func GenerateHeartbeatPrompt(interval int, checklist string, lastHeartbeatAt string) string {
+// A non-empty timezone adds the bot's local time.
+func GenerateHeartbeatPrompt(interval int, checklist string, lastHeartbeatAt string, timezone string) string {
 	checklistSection := ""
 	if strings.TrimSpace(checklist) != "" {
 		checklistSection = "\n## HEARTBEAT.md (checklist)\n\n" + strings.TrimSpace(checklist) + "\n"
@@ -164,9 +206,16 @@ func GenerateHeartbeatPrompt(interval int, checklist string, lastHeartbeatAt str
 	if lastHB == "" {
 		lastHB = "never (first heartbeat)"
 	}
+	now := TimeNow().UTC()
+	timeNow := now.Format("2006-01-02T15:04:05Z")
+	if timezone = strings.TrimSpace(timezone); timezone != "" {
+		if loc, err := time.LoadLocation(timezone); err == nil {
+			timeNow += " (local " + now.In(loc).Format("Mon 2006-01-02 15:04") + " " + timezone + ")"
+		}
+	}
 	return render(heartbeatTmpl, map[string]string{
 		"interval":         strconv.Itoa(interval),
-		"timeNow":          TimeNow().UTC().Format("2006-01-02T15:04:05Z"),
+		"timeNow":          timeNow,
 		"lastHeartbeat":    lastHB,
 		"checklistSection": checklistSection,
 	})
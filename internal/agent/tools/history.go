@@ -58,8 +58,8 @@ func (p *HistoryProvider) Tools(_ context.Context, sess SessionContext) ([]sdk.T
 				"properties": map[string]any{
 					"type": map[string]any{
 						"type":        "string",
-						"description": "Filter by session type: chat, heartbeat, or schedule. Returns all types when omitted.",
-						"enum":        []string{"chat", "heartbeat", "schedule"},
+						"description": "Filter by session type: chat, heartbeat, schedule, or trigger. Returns all types when omitted.",
+						"enum":        []string{"chat", "heartbeat", "schedule", "trigger"},
 					},
 					"platform": map[string]any{
 						"type":        "string",
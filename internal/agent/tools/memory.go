@@ -8,6 +8,7 @@ import (
 
 	sdk "github.com/memohai/twilight-ai/sdk"
 
+	"github.com/memohai/memoh/internal/channel"
 	"github.com/memohai/memoh/internal/mcp"
 	memprovider "github.com/memohai/memoh/internal/memory/adapters"
 	"github.com/memohai/memoh/internal/settings"
@@ -39,11 +40,12 @@ func (p *MemoryProvider) Tools(ctx context.Context, session SessionContext) ([]s
 	if session.IsSubagent {
 		return nil, nil
 	}
-	provider := p.resolveProvider(ctx, session.BotID)
+	provider, policy := p.resolveProvider(ctx, session.BotID)
 	if provider == nil {
 		return nil, nil
 	}
 	mcpSession := toMCPSession(session)
+	mcpSession.MemoryScopePolicy = policy
 	descriptors, err := provider.ListTools(ctx, mcpSession)
 	if err != nil {
 		return nil, nil
@@ -70,27 +72,27 @@ func (p *MemoryProvider) Tools(ctx context.Context, session SessionContext) ([]s
 	return tools, nil
 }
 
-func (p *MemoryProvider) resolveProvider(ctx context.Context, botID string) memprovider.Provider {
+func (p *MemoryProvider) resolveProvider(ctx context.Context, botID string) (memprovider.Provider, string) {
 	if p.registry == nil || p.settings == nil {
-		return nil
+		return nil, ""
 	}
 	botID = strings.TrimSpace(botID)
 	if botID == "" {
-		return nil
+		return nil, ""
 	}
 	botSettings, err := p.settings.GetBot(ctx, botID)
 	if err != nil {
-		return nil
+		return nil, ""
 	}
 	providerID := strings.TrimSpace(botSettings.MemoryProviderID)
 	if providerID == "" {
-		return nil
+		return nil, ""
 	}
 	prov, err := p.registry.Get(providerID)
 	if err != nil {
-		return nil
+		return nil, ""
 	}
-	return prov
+	return prov, botSettings.MemoryScopePolicy
 }
 
 func toMCPSession(s SessionContext) mcp.ToolSessionContext {
@@ -102,6 +104,9 @@ func toMCPSession(s SessionContext) mcp.ToolSessionContext {
 		CurrentPlatform:   s.CurrentPlatform,
 		ReplyTarget:       s.ReplyTarget,
 		IsSubagent:        s.IsSubagent,
+		UserID:            s.UserID,
+		ConversationID:    s.ConversationID,
+		GroupConversation: !channel.IsPrivateConversationType(s.ConversationType),
 	}
 }
 
@@ -17,11 +17,15 @@ func NewMessageProvider(log *slog.Logger, sender messaging.Sender, reactor messa
 	if log == nil {
 		log = slog.Default()
 	}
+	// The channel registry passed as resolver also reports capabilities,
+	// which send_interactive uses to pick polls, buttons or plain text.
+	capabilities, _ := resolver.(messaging.CapabilityResolver)
 	return &MessageProvider{
 		exec: &messaging.Executor{
 			Sender:        sender,
 			Reactor:       reactor,
 			Resolver:      resolver,
+			Capabilities:  capabilities,
 			AssetResolver: assetResolver,
 			Logger:        log.With(slog.String("tool", "message")),
 		},
@@ -54,6 +58,45 @@ func (p *MessageProvider) Tools(_ context.Context, session SessionContext) ([]sd
 			Execute: func(ctx *sdk.ToolExecContext, input any) (any, error) {
 				return p.execSend(ctx.Context, sess, inputAsMap(input))
 			},
+		}, sdk.Tool{
+			Name: "send_interactive",
+			Description: "Ask the user to choose with buttons or a poll. Works in the current conversation. " +
+				"The choice arrives later as a new message starting with [Button clicked on message ...] or [Poll vote on message ...]. " +
+				"On platforms without buttons the options are sent as a numbered list.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"bot_id":   map[string]any{"type": "string", "description": "Bot ID, optional and defaults to current bot"},
+					"platform": map[string]any{"type": "string", "description": "Channel platform name. Defaults to current session platform."},
+					"target":   map[string]any{"type": "string", "description": "Channel target. Defaults to current session reply target."},
+					"text":     map[string]any{"type": "string", "description": "The question or prompt shown with the options"},
+					"kind":     map[string]any{"type": "string", "enum": []string{messaging.InteractiveButtons, messaging.InteractivePoll}, "description": "buttons (default) or poll. Polls fall back to buttons where native polls are unavailable."},
+					"options": map[string]any{
+						"type":        "array",
+						"description": "Up to 10 options. Each is a label string or an object {label, value, url}. value (max 64 bytes) is what comes back when clicked and defaults to the label; url makes a link button.",
+						"items": map[string]any{
+							"anyOf": []map[string]any{
+								{"type": "string"},
+								{
+									"type": "object",
+									"properties": map[string]any{
+										"label": map[string]any{"type": "string"},
+										"value": map[string]any{"type": "string"},
+										"url":   map[string]any{"type": "string"},
+									},
+									"required": []string{"label"},
+								},
+							},
+						},
+					},
+					"multiple": map[string]any{"type": "boolean", "description": "Allow choosing several poll options. Default false."},
+					"reply_to": map[string]any{"type": "string", "description": "Message ID to reply to"},
+				},
+				"required": []string{"text", "options"},
+			},
+			Execute: func(ctx *sdk.ToolExecContext, input any) (any, error) {
+				return p.execSendInteractive(ctx.Context, sess, inputAsMap(input))
+			},
 		})
 	}
 	if p.exec.CanReact() {
@@ -91,6 +134,17 @@ func (p *MessageProvider) execSend(ctx context.Context, session SessionContext,
 	}, nil
 }
 
+func (p *MessageProvider) execSendInteractive(ctx context.Context, session SessionContext, args map[string]any) (any, error) {
+	result, err := p.exec.SendInteractive(ctx, toMessagingSession(session), args)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"ok": true, "bot_id": result.BotID, "platform": result.Platform, "target": result.Target, "rendered": result.Rendered,
+		"instruction": "The options are shown to the user. Do not repeat them in your reply; the user's choice will arrive as a new message.",
+	}, nil
+}
+
 func (p *MessageProvider) execReact(ctx context.Context, session SessionContext, args map[string]any) (any, error) {
 	result, err := p.exec.React(ctx, toMessagingSession(session), args)
 	if err != nil {
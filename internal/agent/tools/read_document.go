@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+
+	"github.com/memohai/memoh/internal/document"
+	"github.com/memohai/memoh/internal/workspace/bridge"
+)
+
+const (
+	ReadDocumentToolName        = "read_document"
+	defaultReadDocumentRoot     = "/data"
+	defaultReadDocumentMaxChars = 20000
+)
+
+// mediaAssetPathPattern matches container paths of persisted media assets,
+// whose extraction may already be cached.
+var mediaAssetPathPattern = regexp.MustCompile(`^/data/media/[0-9a-f]{2}/([0-9a-f]{64})\.[A-Za-z0-9]+$`)
+
+// documentExtractor returns the cached or fresh extraction of a media asset.
+type documentExtractor interface {
+	ExtractAsset(ctx context.Context, botID, contentHash, name string) (document.Document, error)
+}
+
+type ReadDocumentProvider struct {
+	clients   bridge.Provider
+	documents documentExtractor
+	rootDir   string
+	logger    *slog.Logger
+}
+
+func NewReadDocumentProvider(log *slog.Logger, clients bridge.Provider, documents documentExtractor, rootDir string) *ReadDocumentProvider {
+	if log == nil {
+		log = slog.Default()
+	}
+	root := strings.TrimSpace(rootDir)
+	if root == "" {
+		root = defaultReadDocumentRoot
+	}
+	return &ReadDocumentProvider{
+		clients:   clients,
+		documents: documents,
+		rootDir:   path.Clean(root),
+		logger:    log.With(slog.String("tool", ReadDocumentToolName)),
+	}
+}
+
+func (p *ReadDocumentProvider) Tools(_ context.Context, session SessionContext) ([]sdk.Tool, error) {
+	if p == nil || p.clients == nil {
+		return nil, nil
+	}
+	root := p.rootDir
+	sess := session
+	return []sdk.Tool{
+		{
+			Name: ReadDocumentToolName,
+			Description: fmt.Sprintf(
+				"Read the text of a PDF, DOCX, XLSX or HTML file under %s. Tables and spreadsheet sheets are returned as Markdown tables; each sheet counts as a page. Returns up to %d characters per call; use next_page to continue.",
+				root, defaultReadDocumentMaxChars,
+			),
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"path": map[string]any{
+						"type":        "string",
+						"description": fmt.Sprintf("Document path under %s. Relative paths are resolved under %s.", root, root),
+					},
+					"pages": map[string]any{
+						"type":        "string",
+						"description": "Pages or sheets to read, e.g. \"3\", \"2-5\", \"4-\" or \"1,3,5-7\". Default: from the first page.",
+					},
+				},
+				"required": []string{"path"},
+			},
+			Execute: func(ctx *sdk.ToolExecContext, input any) (any, error) {
+				return p.execReadDocument(ctx.Context, sess, inputAsMap(input))
+			},
+		},
+	}, nil
+}
+
+func (p *ReadDocumentProvider) execReadDocument(ctx context.Context, session SessionContext, args map[string]any) (any, error) {
+	botID := strings.TrimSpace(session.BotID)
+	if botID == "" {
+		return nil, errors.New("bot_id is required")
+	}
+	resolvedPath, err := p.resolvePath(StringArg(args, "path"))
+	if err != nil {
+		return nil, err
+	}
+	doc, err := p.load(ctx, botID, resolvedPath)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := document.ParsePageRange(StringArg(args, "pages"), doc.TotalPages())
+	if err != nil {
+		return nil, err
+	}
+	rendered := doc.Render(pages, defaultReadDocumentMaxChars)
+	result := map[string]any{
+		"path":        resolvedPath,
+		"format":      string(doc.Format),
+		"total_pages": doc.TotalPages(),
+		"pages":       rendered.Pages,
+		"content":     rendered.Text,
+	}
+	if doc.Title != "" {
+		result["title"] = doc.Title
+	}
+	if rendered.NextPage > 0 {
+		result["next_page"] = rendered.NextPage
+	}
+	if rendered.Truncated || doc.Truncated {
+		result["truncated"] = true
+	}
+	return result, nil
+}
+
+// load returns the cached extraction for media assets and otherwise reads
+// and extracts the file from the container.
+func (p *ReadDocumentProvider) load(ctx context.Context, botID, filePath string) (document.Document, error) {
+	if m := mediaAssetPathPattern.FindStringSubmatch(filePath); m != nil && p.documents != nil {
+		doc, err := p.documents.ExtractAsset(ctx, botID, m[1], path.Base(filePath))
+		if err == nil {
+			return doc, nil
+		}
+		if errors.Is(err, document.ErrUnsupportedFormat) || errors.Is(err, document.ErrTooLarge) {
+			return document.Document{}, err
+		}
+		p.logger.Debug("media extraction unavailable, reading file directly",
+			slog.String("path", filePath), slog.Any("error", err))
+	}
+
+	format := document.DetectFormat("", filePath)
+	if format == "" {
+		return document.Document{}, errors.New("read_document supports PDF, DOCX, XLSX and HTML files")
+	}
+	client, err := p.clients.MCPClient(ctx, botID)
+	if err != nil {
+		return document.Document{}, fmt.Errorf("container not reachable: %w", err)
+	}
+	reader, err := client.ReadRaw(ctx, filePath)
+	if err != nil {
+		return document.Document{}, err
+	}
+	defer func() { _ = reader.Close() }()
+	data, err := io.ReadAll(io.LimitReader(reader, document.MaxInputBytes+1))
+	if err != nil {
+		return document.Document{}, err
+	}
+	if int64(len(data)) > document.MaxInputBytes {
+		return document.Document{}, fmt.Errorf("%w: max %d bytes", document.ErrTooLarge, document.MaxInputBytes)
+	}
+	return document.Extract(data, format)
+}
+
+func (p *ReadDocumentProvider) resolvePath(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", errors.New("path is required")
+	}
+	resolved := trimmed
+	if !strings.HasPrefix(resolved, "/") {
+		resolved = path.Join(p.rootDir, resolved)
+	}
+	resolved = path.Clean(resolved)
+	if resolved == p.rootDir || !strings.HasPrefix(resolved, p.rootDir+"/") {
+		return "", fmt.Errorf("path must be under %s", p.rootDir)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+
+	"github.com/memohai/memoh/internal/document"
+)
+
+type fakeDocumentExtractor struct {
+	doc     document.Document
+	gotHash string
+}
+
+func (f *fakeDocumentExtractor) ExtractAsset(_ context.Context, _, contentHash, _ string) (document.Document, error) {
+	f.gotHash = contentHash
+	return f.doc, nil
+}
+
+func execReadDocument(t *testing.T, provider *ReadDocumentProvider, args map[string]any) map[string]any {
+	t.Helper()
+	tools, err := provider.Tools(context.Background(), SessionContext{BotID: "bot-1"})
+	if err != nil {
+		t.Fatalf("Tools returned error: %v", err)
+	}
+	tool, ok := findToolByName(tools, ReadDocumentToolName)
+	if !ok {
+		t.Fatalf("expected %q tool", ReadDocumentToolName)
+	}
+	output, err := tool.Execute(&sdk.ToolExecContext{
+		Context:    context.Background(),
+		ToolCallID: "call-1",
+		ToolName:   ReadDocumentToolName,
+	}, args)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	result, ok := output.(map[string]any)
+	if !ok {
+		t.Fatalf("expected map result, got %T", output)
+	}
+	return result
+}
+
+func TestReadDocumentProviderReadsContainerFile(t *testing.T) {
+	t.Parallel()
+
+	page := []byte(`<html><body><h1>Notes</h1><p>Quarterly numbers are attached.</p></body></html>`)
+	provider := NewReadDocumentProvider(nil, newReadMediaBridgeProvider(t, map[string][]byte{
+		"/data/docs/notes.html": page,
+	}), nil, "/data")
+
+	result := execReadDocument(t, provider, map[string]any{"path": "docs/notes.html"})
+	if result["format"] != "html" || result["total_pages"] != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if content, _ := result["content"].(string); !strings.Contains(content, "Quarterly numbers") {
+		t.Fatalf("unexpected content: %q", content)
+	}
+}
+
+func TestReadDocumentProviderUsesMediaExtraction(t *testing.T) {
+	t.Parallel()
+
+	pages := make([]document.Page, 3)
+	for i := range pages {
+		pages[i] = document.Page{Number: i + 1, Text: strings.Repeat(string(rune('a'+i)), defaultReadDocumentMaxChars/2)}
+	}
+	extractor := &fakeDocumentExtractor{doc: document.Document{Format: document.FormatPDF, Pages: pages}}
+	provider := NewReadDocumentProvider(nil, newReadMediaBridgeProvider(t, nil), extractor, "/data")
+
+	hash := strings.Repeat("ab", 32)
+	result := execReadDocument(t, provider, map[string]any{"path": "/data/media/ab/" + hash + ".pdf", "pages": "2-"})
+	if extractor.gotHash != hash {
+		t.Fatalf("expected extraction for %q, got %q", hash, extractor.gotHash)
+	}
+	if !reflect.DeepEqual(result["pages"], []int{2}) || result["next_page"] != 3 || result["total_pages"] != 3 {
+		t.Fatalf("unexpected paging: pages=%v next=%v total=%v", result["pages"], result["next_page"], result["total_pages"])
+	}
+}
+
+func TestReadDocumentProviderRejectsPathsOutsideRoot(t *testing.T) {
+	t.Parallel()
+
+	provider := NewReadDocumentProvider(nil, newReadMediaBridgeProvider(t, nil), nil, "/data")
+	tools, err := provider.Tools(context.Background(), SessionContext{BotID: "bot-1"})
+	if err != nil {
+		t.Fatalf("Tools returned error: %v", err)
+	}
+	tool, _ := findToolByName(tools, ReadDocumentToolName)
+	for _, p := range []string{"/etc/report.pdf", "../report.pdf", "notes.txt"} {
+		if _, err := tool.Execute(&sdk.ToolExecContext{Context: context.Background()}, map[string]any{"path": p}); err == nil {
+			t.Errorf("path %q: expected an error", p)
+		}
+	}
+}
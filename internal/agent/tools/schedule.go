@@ -86,16 +86,16 @@ func (p *ScheduleProvider) Tools(_ context.Context, session SessionContext) ([]s
 			},
 		},
 		{
-			Name: "create_schedule", Description: "Create a new schedule",
+			Name: "create_schedule", Description: "Create a new schedule. Give a cron pattern for a recurring schedule, or run_at / in for a one-shot reminder that fires once and then disables itself",
 			Parameters: map[string]any{
 				"type": "object",
-				"properties": map[string]any{
+				"properties": mergeProperties(map[string]any{
 					"name": map[string]any{"type": "string"}, "description": map[string]any{"type": "string"},
-					"pattern": map[string]any{"type": "string"}, "command": map[string]any{"type": "string"},
+					"pattern": map[string]any{"type": "string", "description": "Cron pattern for a recurring schedule"}, "command": map[string]any{"type": "string"},
 					"max_calls": map[string]any{"type": []string{"integer", "null"}, "description": "Optional max calls, null means unlimited"},
 					"enabled":   map[string]any{"type": "boolean"},
-				},
-				"required": []string{"name", "description", "pattern", "command"},
+				}, scheduleTimingProperties),
+				"required": []string{"name", "description", "command"},
 			},
 			Execute: func(ctx *sdk.ToolExecContext, input any) (any, error) {
 				args := inputAsMap(input)
@@ -107,10 +107,24 @@ func (p *ScheduleProvider) Tools(_ context.Context, session SessionContext) ([]s
 				description := StringArg(args, "description")
 				pattern := StringArg(args, "pattern")
 				command := StringArg(args, "command")
-				if name == "" || description == "" || pattern == "" || command == "" {
-					return nil, errors.New("name, description, pattern, command are required")
+				if name == "" || description == "" || command == "" {
+					return nil, errors.New("name, description, command are required")
 				}
 				req := sched.CreateRequest{Name: name, Description: description, Pattern: pattern, Command: command}
+				req.Timezone = StringArg(args, "timezone")
+				if raw := StringArg(args, "run_at"); raw != "" {
+					runAt, err := sched.ParseRunAt(raw, req.Timezone)
+					if err != nil {
+						return nil, err
+					}
+					req.RunAt = &runAt
+				}
+				req.In = StringArg(args, "in")
+				platform, target, err := scheduleDeliveryArgs(args, sess)
+				if err != nil {
+					return nil, err
+				}
+				req.DeliveryPlatform, req.DeliveryTarget = platform, target
 				maxCalls, err := parseNullableIntArg(args, "max_calls")
 				if err != nil {
 					return nil, err
@@ -132,13 +146,14 @@ func (p *ScheduleProvider) Tools(_ context.Context, session SessionContext) ([]s
 			Name: "update_schedule", Description: "Update an existing schedule",
 			Parameters: map[string]any{
 				"type": "object",
-				"properties": map[string]any{
+				"properties": mergeProperties(map[string]any{
 					"id": map[string]any{"type": "string"}, "name": map[string]any{"type": "string"},
-					"description": map[string]any{"type": "string"}, "pattern": map[string]any{"type": "string"},
-					"command":   map[string]any{"type": "string"},
-					"max_calls": map[string]any{"type": []string{"integer", "null"}},
-					"enabled":   map[string]any{"type": "boolean"},
-				},
+					"description": map[string]any{"type": "string"}, "pattern": map[string]any{"type": "string", "description": "Cron pattern; makes the schedule recurring"},
+					"command":        map[string]any{"type": "string"},
+					"max_calls":      map[string]any{"type": []string{"integer", "null"}},
+					"enabled":        map[string]any{"type": "boolean"},
+					"clear_delivery": map[string]any{"type": "boolean", "description": "Stop delivering final answers automatically"},
+				}, scheduleTimingProperties),
 				"required": []string{"id"},
 			},
 			Execute: func(ctx *sdk.ToolExecContext, input any) (any, error) {
@@ -169,6 +184,39 @@ func (p *ScheduleProvider) Tools(_ context.Context, session SessionContext) ([]s
 				if v := StringArg(args, "command"); v != "" {
 					req.Command = &v
 				}
+				if v := StringArg(args, "timezone"); v != "" {
+					req.Timezone = &v
+				}
+				if raw := StringArg(args, "run_at"); raw != "" {
+					timezone := ""
+					if req.Timezone != nil {
+						timezone = *req.Timezone
+					} else if existing, err := p.service.Get(ctx.Context, id); err == nil {
+						timezone = existing.Timezone
+					}
+					runAt, err := sched.ParseRunAt(raw, timezone)
+					if err != nil {
+						return nil, err
+					}
+					req.RunAt = &runAt
+				}
+				if v := StringArg(args, "in"); v != "" {
+					req.In = &v
+				}
+				if clearDelivery, ok, err := BoolArg(args, "clear_delivery"); err != nil {
+					return nil, err
+				} else if ok && clearDelivery {
+					empty := ""
+					req.DeliveryPlatform, req.DeliveryTarget = &empty, &empty
+				} else {
+					platform, target, err := scheduleDeliveryArgs(args, sess)
+					if err != nil {
+						return nil, err
+					}
+					if platform != "" || target != "" {
+						req.DeliveryPlatform, req.DeliveryTarget = &platform, &target
+					}
+				}
 				if enabled, ok, err := BoolArg(args, "enabled"); err != nil {
 					return nil, err
 				} else if ok {
@@ -241,6 +289,54 @@ func parseNullableIntArg(arguments map[string]any, key string) (sched.NullableIn
 	return req, nil
 }
 
+// scheduleTimingProperties are the timing and delivery parameters shared by
+// create_schedule and update_schedule.
+var scheduleTimingProperties = map[string]any{
+	"timezone": map[string]any{"type": "string", "description": "IANA timezone such as Asia/Shanghai for the pattern and for run_at without an offset. Defaults to the server zone for patterns and UTC for run_at"},
+	"run_at":   map[string]any{"type": "string", "description": "One-shot run time, RFC 3339 or \"YYYY-MM-DD HH:MM\" in timezone"},
+	"in":       map[string]any{"type": "string", "description": "One-shot delay from now as a duration such as 30m or 2h"},
+	"deliver_to_current": map[string]any{
+		"type":        "boolean",
+		"description": "Send each run's final answer to the current conversation",
+	},
+	"delivery_platform": map[string]any{"type": "string", "description": "Platform that receives each run's final answer; use with delivery_target"},
+	"delivery_target":   map[string]any{"type": "string", "description": "Channel target (chat or user id) that receives each run's final answer"},
+}
+
+// scheduleDeliveryArgs resolves the delivery target from explicit arguments
+// or, with deliver_to_current, from the conversation the tool was called in.
+func scheduleDeliveryArgs(args map[string]any, session SessionContext) (string, string, error) {
+	platform := StringArg(args, "delivery_platform")
+	target := StringArg(args, "delivery_target")
+	current, ok, err := BoolArg(args, "deliver_to_current")
+	if err != nil {
+		return "", "", err
+	}
+	if !ok || !current {
+		return platform, target, nil
+	}
+	if platform != "" || target != "" {
+		return "", "", errors.New("deliver_to_current cannot be combined with delivery_platform or delivery_target")
+	}
+	platform = strings.TrimSpace(session.CurrentPlatform)
+	target = strings.TrimSpace(session.ReplyTarget)
+	if platform == "" || target == "" {
+		return "", "", errors.New("deliver_to_current requires a conversation with a reply target")
+	}
+	return platform, target, nil
+}
+
+func mergeProperties(base, extra map[string]any) map[string]any {
+	out := make(map[string]any, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
 func emptyObjectSchema() map[string]any {
 	return map[string]any{"type": "object", "properties": map[string]any{}}
 }
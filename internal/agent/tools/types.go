@@ -21,7 +21,10 @@ type SessionContext struct {
 	BotID              string
 	ChatID             string
 	SessionID          string
+	UserID             string
 	ChannelIdentityID  string
+	ConversationID     string
+	ConversationType   string
 	SessionToken       string //nolint:gosec // carries session credential material at runtime
 	CurrentPlatform    string
 	ReplyTarget        string
@@ -26,10 +26,14 @@ import (
 
 	"github.com/memohai/memoh/internal/channel"
 	"github.com/memohai/memoh/internal/db/sqlc"
+	"github.com/memohai/memoh/internal/netguard"
 	"github.com/memohai/memoh/internal/searchproviders"
 	"github.com/memohai/memoh/internal/settings"
 )
 
+// searchMaxBodyBytes bounds a search provider response.
+const searchMaxBodyBytes = 5 << 20
+
 type WebProvider struct {
 	logger          *slog.Logger
 	settings        *settings.Service
@@ -151,7 +155,7 @@ func callBraveSearch(ctx context.Context, configJSON []byte, query string, count
 	params.Set("count", strconv.Itoa(count))
 	reqURL.RawQuery = params.Encode()
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -196,7 +200,7 @@ func callBingSearch(ctx context.Context, configJSON []byte, query string, count
 	params.Set("count", strconv.Itoa(count))
 	reqURL.RawQuery = params.Encode()
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	req.Header.Set("Accept", "application/json")
 	if apiKey := stringValue(cfg["api_key"]); apiKey != "" {
@@ -248,7 +252,7 @@ func callGoogleSearch(ctx context.Context, configJSON []byte, query string, coun
 	}
 	reqURL.RawQuery = params.Encode()
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	req.Header.Set("Accept", "application/json")
 	resp, err := client.Do(req) //nolint:gosec
@@ -284,7 +288,7 @@ func callTavilySearch(ctx context.Context, configJSON []byte, query string, coun
 	}
 	payload, _ := json.Marshal(map[string]any{"query": query, "max_results": count})
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -340,7 +344,7 @@ func callSogouSearch(ctx context.Context, configJSON []byte, query string, count
 	signature := hex.EncodeToString(hmacSHA256(secretSigning, []byte(stringToSign)))
 	authorization := fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", secretID, credentialScope, signedHeaders, signature)
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+"/", bytes.NewReader(payload))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", authorization)
@@ -408,7 +412,7 @@ func callSerperSearch(ctx context.Context, configJSON []byte, query string, coun
 	}
 	payload, _ := json.Marshal(map[string]any{"q": query})
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -464,7 +468,7 @@ func callSearXNGSearch(ctx context.Context, configJSON []byte, query string, cou
 	}
 	reqURL.RawQuery = params.Encode()
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	req.Header.Set("Accept", "application/json")
 	resp, err := client.Do(req) //nolint:gosec
@@ -508,7 +512,7 @@ func callJinaSearch(ctx context.Context, configJSON []byte, query string, count
 	}
 	payload, _ := json.Marshal(map[string]any{"q": query, "count": count})
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -545,7 +549,7 @@ func callExaSearch(ctx context.Context, configJSON []byte, query string, count i
 	}
 	payload, _ := json.Marshal(map[string]any{"query": query, "numResults": count, "contents": map[string]any{"text": true, "highlights": true}, "type": "auto"})
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -581,7 +585,7 @@ func callBochaSearch(ctx context.Context, configJSON []byte, query string, count
 	}
 	payload, _ := json.Marshal(map[string]any{"query": query, "summary": true, "freshness": "noLimit", "count": count})
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -616,7 +620,7 @@ func callDuckDuckGoSearch(ctx context.Context, configJSON []byte, query string,
 	cfg := parseSearchConfig(configJSON)
 	endpoint := firstNonEmpty(stringValue(cfg["base_url"]), "https://html.duckduckgo.com/html/")
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	form := url.Values{}
 	form.Set("q", query)
 	form.Set("b", "")
@@ -674,7 +678,7 @@ func callYandexSearch(ctx context.Context, configJSON []byte, query string, coun
 		"groupSpec": map[string]any{"groupMode": "GROUP_MODE_DEEP", "groupsOnPage": count, "docsInGroup": 1},
 	})
 	timeout := parseSearchTimeout(configJSON, 15*time.Second)
-	client := &http.Client{Timeout: timeout}
+	client := searchClient(timeout)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Api-Key "+apiKey)
@@ -706,6 +710,17 @@ func callYandexSearch(ctx context.Context, configJSON []byte, query string, coun
 
 // ---- helpers ----
 
+// searchClient returns the HTTP client for search provider APIs. Provider
+// endpoints are set by the operator and may be self-hosted on a private
+// network, so only redirects and response size are restricted.
+func searchClient(timeout time.Duration) *http.Client {
+	return netguard.Policy{
+		AllowPrivate: true,
+		MaxBodyBytes: searchMaxBodyBytes,
+		Timeout:      timeout,
+	}.Client()
+}
+
 func buildSearchResults[T any](query string, items []T, mapper func(T) map[string]any) map[string]any {
 	results := make([]map[string]any, 0, len(items))
 	for _, item := range items {
@@ -15,34 +15,43 @@ import (
 	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
 	readability "github.com/go-shiori/go-readability"
 	sdk "github.com/memohai/twilight-ai/sdk"
+
+	"github.com/memohai/memoh/internal/document"
+	"github.com/memohai/memoh/internal/netguard"
+	"github.com/memohai/memoh/internal/settings"
 )
 
 const (
 	webFetchMaxTextContent = 10000
 	webFetchTimeout        = 30 * time.Second
-	webFetchUserAgent      = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"
+	webFetchMaxBodyBytes   = 5 << 20
+	// webFetchMaxDocumentBytes is the larger limit for PDF, DOCX and XLSX
+	// responses, which are often bigger than the text they contain.
+	webFetchMaxDocumentBytes = 20 << 20
+	webFetchUserAgent        = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"
 )
 
 type WebFetchProvider struct {
-	logger *slog.Logger
-	client *http.Client
+	logger   *slog.Logger
+	settings *settings.Service
 }
 
-func NewWebFetchProvider(log *slog.Logger) *WebFetchProvider {
+func NewWebFetchProvider(log *slog.Logger, settingsSvc *settings.Service) *WebFetchProvider {
 	if log == nil {
 		log = slog.Default()
 	}
 	return &WebFetchProvider{
-		logger: log.With(slog.String("tool", "webfetch")),
-		client: &http.Client{Timeout: webFetchTimeout},
+		logger:   log.With(slog.String("tool", "webfetch")),
+		settings: settingsSvc,
 	}
 }
 
-func (p *WebFetchProvider) Tools(_ context.Context, _ SessionContext) ([]sdk.Tool, error) {
+func (p *WebFetchProvider) Tools(_ context.Context, session SessionContext) ([]sdk.Tool, error) {
+	sess := session
 	return []sdk.Tool{
 		{
 			Name:        "web_fetch",
-			Description: "Fetch a URL and convert the response to readable content. Supports HTML (converts to Markdown), JSON, XML, and plain text formats.",
+			Description: "Fetch a URL and convert the response to readable content. Supports HTML (converts to Markdown), JSON, XML, plain text, and PDF, DOCX and XLSX documents (extracts text and tables).",
 			Parameters: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -55,6 +64,10 @@ func (p *WebFetchProvider) Tools(_ context.Context, _ SessionContext) ([]sdk.Too
 						"enum":        []string{"auto", "markdown", "json", "xml", "text"},
 						"description": "Output format (default: auto - detects from content type)",
 					},
+					"pages": map[string]any{
+						"type":        "string",
+						"description": "For PDF, DOCX and XLSX documents: pages or sheets to return, e.g. \"3\", \"2-5\" or \"1,4-\" (default: from the first page)",
+					},
 				},
 				"required": []string{"url"},
 			},
@@ -68,20 +81,24 @@ func (p *WebFetchProvider) Tools(_ context.Context, _ SessionContext) ([]sdk.Too
 				if format == "" {
 					format = "auto"
 				}
-				return p.callWebFetch(ctx.Context, rawURL, format)
+				return p.callWebFetch(ctx.Context, sess.BotID, rawURL, format, strings.TrimSpace(StringArg(args, "pages")))
 			},
 		},
 	}, nil
 }
 
-func (p *WebFetchProvider) callWebFetch(ctx context.Context, rawURL, format string) (any, error) {
+func (p *WebFetchProvider) callWebFetch(ctx context.Context, botID, rawURL, format, pages string) (any, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("invalid url: %w", err)
 	}
 	req.Header.Set("User-Agent", webFetchUserAgent)
 
-	resp, err := p.client.Do(req) //nolint:gosec // intentionally fetches user-specified URLs
+	policy, err := p.fetchPolicy(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := policy.Client().Do(req) //nolint:gosec // destination is checked by the bot's fetch policy
 	if err != nil {
 		return nil, err
 	}
@@ -97,10 +114,27 @@ func (p *WebFetchProvider) callWebFetch(ctx context.Context, rawURL, format stri
 		detected = detectWebFetchFormat(contentType)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	var docFormat document.Format
+	if format == "auto" {
+		switch f := document.DetectFormat(contentType, resp.Request.URL.Path); f {
+		case document.FormatPDF, document.FormatDOCX, document.FormatXLSX:
+			docFormat = f
+		}
+	}
+	maxBytes := int64(webFetchMaxBodyBytes)
+	if docFormat != "" {
+		maxBytes = webFetchMaxDocumentBytes
+	}
+	if resp.ContentLength > maxBytes {
+		return nil, fmt.Errorf("%w: max %d bytes", netguard.ErrBodyTooLarge, maxBytes)
+	}
+	body, err := io.ReadAll(netguard.LimitBody(resp.Body, maxBytes))
 	if err != nil {
 		return nil, err
 	}
+	if docFormat != "" {
+		return processDocument(rawURL, contentType, body, docFormat, pages)
+	}
 
 	switch detected {
 	case "json":
@@ -114,6 +148,23 @@ func (p *WebFetchProvider) callWebFetch(ctx context.Context, rawURL, format stri
 	}
 }
 
+// fetchPolicy returns the bot's outbound fetch policy with the web_fetch
+// time limit applied. Its body limit is the document limit; callWebFetch
+// lowers it once the response is known not to be a document.
+func (p *WebFetchProvider) fetchPolicy(ctx context.Context, botID string) (netguard.Policy, error) {
+	var policy netguard.Policy
+	if p.settings != nil && strings.TrimSpace(botID) != "" {
+		botSettings, err := p.settings.GetBot(ctx, botID)
+		if err != nil {
+			return netguard.Policy{}, err
+		}
+		policy = botSettings.FetchPolicy()
+	}
+	policy.MaxBodyBytes = webFetchMaxDocumentBytes
+	policy.Timeout = webFetchTimeout
+	return policy, nil
+}
+
 func detectWebFetchFormat(contentType string) string {
 	ct := strings.ToLower(contentType)
 	switch {
@@ -128,6 +179,29 @@ func detectWebFetchFormat(contentType string) string {
 	}
 }
 
+func processDocument(fetchedURL, contentType string, body []byte, format document.Format, pages string) (any, error) {
+	doc, err := document.Extract(body, format)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := document.ParsePageRange(pages, doc.TotalPages())
+	if err != nil {
+		return nil, err
+	}
+	rendered := doc.Render(selected, defaultReadDocumentMaxChars)
+	result := map[string]any{
+		"success": true, "url": fetchedURL, "format": string(format), "contentType": contentType,
+		"title": doc.Title, "content": rendered.Text, "pages": rendered.Pages, "totalPages": doc.TotalPages(),
+	}
+	if rendered.NextPage > 0 {
+		result["nextPage"] = rendered.NextPage
+	}
+	if rendered.Truncated || doc.Truncated {
+		result["truncated"] = true
+	}
+	return result, nil
+}
+
 func (*WebFetchProvider) processJSON(fetchedURL, contentType string, body []byte) (any, error) {
 	var data any
 	if err := json.Unmarshal(body, &data); err != nil {
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	sdk "github.com/memohai/twilight-ai/sdk"
@@ -12,11 +13,14 @@ type SessionContext struct {
 	BotID             string
 	ChatID            string
 	SessionID         string
+	UserID            string
 	ChannelIdentityID string
 	CurrentPlatform   string
 	ReplyTarget       string
 	SessionToken      string //nolint:gosec // carries session credential material at runtime
 	IsSubagent        bool
+	ConversationID    string
+	ConversationType  string
 }
 
 // SkillEntry represents a skill loaded from the bot container.
@@ -35,6 +39,26 @@ type Schedule struct {
 	Pattern     string `json:"pattern"`
 	MaxCalls    *int   `json:"maxCalls,omitempty"`
 	Command     string `json:"command"`
+	Timezone    string `json:"timezone,omitempty"`
+	// RunAt is set for one-shot schedules.
+	RunAt *time.Time `json:"runAt,omitempty"`
+	// DeliveryPlatform and DeliveryTarget name where the final answer is
+	// delivered automatically, if anywhere.
+	DeliveryPlatform string `json:"deliveryPlatform,omitempty"`
+	DeliveryTarget   string `json:"deliveryTarget,omitempty"`
+}
+
+// TriggerEvent describes an event that fired an event trigger.
+type TriggerEvent struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Source      string `json:"source"`
+	Command     string `json:"command"`
+	// Summary is a one-line description of the event; Details is its JSON
+	// payload, such as the changed files or the email headers.
+	Summary string `json:"summary"`
+	Details string `json:"details,omitempty"`
 }
 
 // LoopDetectionConfig controls loop detection behavior.
@@ -56,6 +80,11 @@ type RunConfig struct {
 	LoopDetection      LoopDetectionConfig
 }
 
+// ErrPartialOutput marks a non-streaming generation that failed after it had
+// already run a tool or produced text. Retrying it on another model would
+// repeat the tool calls and their side effects.
+var ErrPartialOutput = errors.New("generation failed after producing output")
+
 // GenerateResult holds the result of a non-streaming agent invocation.
 type GenerateResult struct {
 	Messages    []sdk.Message
@@ -0,0 +1,203 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/auth"
+	"github.com/memohai/memoh/internal/db"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+// tokenBytes is the amount of randomness in a generated token.
+const tokenBytes = 24
+
+// prefixLength is how much of the token is kept in clear for display.
+const prefixLength = len(auth.APIKeyPrefix) + 8
+
+type Service struct {
+	queries *sqlc.Queries
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+func NewService(log *slog.Logger, queries *sqlc.Queries) *Service {
+	return &Service{
+		queries: queries,
+		logger:  log.With(slog.String("service", "apikeys")),
+		now:     time.Now,
+	}
+}
+
+// Create issues a new key for the user and returns its plaintext token.
+func (s *Service) Create(ctx context.Context, userID string, req CreateRequest) (CreateResponse, error) {
+	pgUserID, err := db.ParseUUID(userID)
+	if err != nil {
+		return CreateResponse{}, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return CreateResponse{}, ErrNameRequired
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return CreateResponse{}, err
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > MaxExpiresInDays {
+		return CreateResponse{}, ErrInvalidExpiry
+	}
+	var expiresAt pgtype.Timestamptz
+	if req.ExpiresInDays > 0 {
+		expiresAt = pgtype.Timestamptz{Time: s.now().UTC().AddDate(0, 0, req.ExpiresInDays), Valid: true}
+	}
+	token, err := generateToken()
+	if err != nil {
+		return CreateResponse{}, err
+	}
+	row, err := s.queries.CreateUserAPIKey(ctx, sqlc.CreateUserAPIKeyParams{
+		UserID:      pgUserID,
+		Name:        name,
+		TokenPrefix: token[:prefixLength],
+		TokenHash:   hashToken(token),
+		Scopes:      scopes,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return CreateResponse{}, fmt.Errorf("create api key: %w", err)
+	}
+	return CreateResponse{APIKey: toAPIKey(row), Token: token}, nil
+}
+
+func (s *Service) List(ctx context.Context, userID string) ([]APIKey, error) {
+	pgUserID, err := db.ParseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListUserAPIKeys(ctx, pgUserID)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	items := make([]APIKey, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toAPIKey(row))
+	}
+	return items, nil
+}
+
+// Revoke disables a key owned by the user. Revoked keys stay listed.
+func (s *Service) Revoke(ctx context.Context, userID, id string) error {
+	pgUserID, err := db.ParseUUID(userID)
+	if err != nil {
+		return err
+	}
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	affected, err := s.queries.RevokeUserAPIKey(ctx, sqlc.RevokeUserAPIKeyParams{ID: pgID, UserID: pgUserID})
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AuthenticateAPIKey implements auth.APIKeyAuthenticator.
+func (s *Service) AuthenticateAPIKey(ctx context.Context, token string) (auth.APIKeyPrincipal, error) {
+	row, err := s.queries.GetUserAPIKeyByHash(ctx, hashToken(strings.TrimSpace(token)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return auth.APIKeyPrincipal{}, auth.ErrInvalidAPIKey
+		}
+		return auth.APIKeyPrincipal{}, fmt.Errorf("get api key: %w", err)
+	}
+	if !usable(row, s.now()) {
+		return auth.APIKeyPrincipal{}, auth.ErrInvalidAPIKey
+	}
+	if err := s.queries.TouchUserAPIKey(ctx, row.ID); err != nil {
+		s.logger.Warn("touch api key failed", slog.Any("error", err))
+	}
+	return auth.APIKeyPrincipal{
+		KeyID:  row.ID.String(),
+		UserID: row.UserID.String(),
+		Scopes: row.Scopes,
+	}, nil
+}
+
+func usable(row sqlc.UserApiKey, now time.Time) bool {
+	if row.RevokedAt.Valid {
+		return false
+	}
+	return !row.ExpiresAt.Valid || now.Before(row.ExpiresAt.Time)
+}
+
+func normalizeScopes(scopes []string) ([]string, error) {
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || slices.Contains(out, scope) {
+			continue
+		}
+		if !auth.ValidScope(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+		out = append(out, scope)
+	}
+	if len(out) == 0 {
+		return nil, ErrNoScopes
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate api key: %w", err)
+	}
+	return auth.APIKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func optionalTime(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	t := value.Time
+	return &t
+}
+
+func toAPIKey(row sqlc.UserApiKey) APIKey {
+	scopes := row.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return APIKey{
+		ID:         row.ID.String(),
+		UserID:     row.UserID.String(),
+		Name:       row.Name,
+		Prefix:     row.TokenPrefix,
+		Scopes:     scopes,
+		ExpiresAt:  optionalTime(row.ExpiresAt),
+		LastUsedAt: optionalTime(row.LastUsedAt),
+		RevokedAt:  optionalTime(row.RevokedAt),
+		CreatedAt:  db.TimeFromPg(row.CreatedAt),
+	}
+}
@@ -0,0 +1,58 @@
+package apikeys
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/auth"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+func TestNormalizeScopes(t *testing.T) {
+	got, err := normalizeScopes([]string{" messages:write ", "bots:read", "bots:read", ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, ",") != "bots:read,messages:write" {
+		t.Fatalf("unexpected scopes: %v", got)
+	}
+	if _, err := normalizeScopes([]string{"root"}); !errors.Is(err, ErrInvalidScope) {
+		t.Fatalf("expected ErrInvalidScope, got %v", err)
+	}
+	if _, err := normalizeScopes(nil); !errors.Is(err, ErrNoScopes) {
+		t.Fatalf("expected ErrNoScopes, got %v", err)
+	}
+}
+
+func TestGenerateToken(t *testing.T) {
+	a, err := generateToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := generateToken()
+	if !strings.HasPrefix(a, auth.APIKeyPrefix) || a == b {
+		t.Fatalf("tokens should be prefixed and unique: %q %q", a, b)
+	}
+	if hashToken(a) == a || hashToken(a) != hashToken(a) || len(hashToken(a)) != 64 {
+		t.Fatal("hash should be a stable sha256 hex digest")
+	}
+}
+
+func TestUsable(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	if !usable(sqlc.UserApiKey{}, now) {
+		t.Fatal("keys without expiry should be usable")
+	}
+	expired := sqlc.UserApiKey{ExpiresAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}}
+	if usable(expired, now) {
+		t.Fatal("expired keys should be rejected")
+	}
+	revoked := sqlc.UserApiKey{RevokedAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}}
+	if usable(revoked, now) {
+		t.Fatal("revoked keys should be rejected")
+	}
+}
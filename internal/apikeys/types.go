@@ -0,0 +1,54 @@
+package apikeys
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxExpiresInDays bounds the lifetime that can be requested for a key.
+const MaxExpiresInDays = 3650
+
+var (
+	ErrNameRequired  = errors.New("name is required")
+	ErrNoScopes      = errors.New("at least one scope is required")
+	ErrInvalidScope  = errors.New("unknown scope")
+	ErrInvalidExpiry = errors.New("expires_in_days must be between 0 and 3650")
+	ErrNotFound      = errors.New("api key not found")
+)
+
+// APIKey is a personal access token as shown to its owner. The token itself
+// is only returned once, on creation.
+type APIKey struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateRequest creates a key. ExpiresInDays of zero creates a key that
+// never expires.
+type CreateRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty"`
+}
+
+// CreateResponse carries the new key and its plaintext token.
+type CreateResponse struct {
+	APIKey
+	Token string `json:"token"`
+}
+
+type ListResponse struct {
+	Items []APIKey `json:"items"`
+}
+
+// ScopesResponse lists the scopes that can be granted.
+type ScopesResponse struct {
+	Scopes []string `json:"scopes"`
+}
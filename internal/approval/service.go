@@ -0,0 +1,467 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/bots"
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/db"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+// maxPromptInputLen bounds the tool input echoed in approval prompts.
+const maxPromptInputLen = 500
+
+// Sender delivers approval prompts through a channel adapter.
+type Sender interface {
+	Send(ctx context.Context, botID string, channelType channel.ChannelType, req channel.SendRequest) error
+}
+
+// ChannelResolver maps platform names to channel types and capabilities.
+type ChannelResolver interface {
+	ParseChannelType(raw string) (channel.ChannelType, error)
+	GetCapabilities(channelType channel.ChannelType) (channel.ChannelCapabilities, bool)
+}
+
+// Service stores approval policies and holds gated tool calls until the bot
+// owner decides on them. Waiting calls live in memory, so a decision must
+// reach the same process that is running the call.
+type Service struct {
+	queries  *sqlc.Queries
+	bots     *bots.Service
+	sender   Sender
+	channels ChannelResolver
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	waiters map[string]chan Decision
+}
+
+func NewService(log *slog.Logger, queries *sqlc.Queries, botService *bots.Service) *Service {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Service{
+		queries: queries,
+		bots:    botService,
+		logger:  log.With(slog.String("service", "approval")),
+		now:     time.Now,
+		waiters: make(map[string]chan Decision),
+	}
+}
+
+// SetNotifier configures how approval prompts reach the originating channel.
+// This allows breaking dependency cycles in the DI graph.
+func (s *Service) SetNotifier(sender Sender, channels ChannelResolver) {
+	s.sender = sender
+	s.channels = channels
+}
+
+// ApprovalPolicy returns the bot's policy, or an empty one if none is stored.
+func (s *Service) ApprovalPolicy(ctx context.Context, botID string) (Policy, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return Policy{}, err
+	}
+	row, err := s.queries.GetBotToolApprovalPolicy(ctx, pgBotID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Policy{ToolPatterns: []string{}, TimeoutSeconds: DefaultTimeoutSeconds}, nil
+		}
+		return Policy{}, err
+	}
+	return toPolicy(row), nil
+}
+
+func (s *Service) UpdatePolicy(ctx context.Context, botID string, req UpdatePolicyRequest) (Policy, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return Policy{}, err
+	}
+	patterns := make([]string, 0, len(req.ToolPatterns))
+	seen := make(map[string]struct{}, len(req.ToolPatterns))
+	for _, raw := range req.ToolPatterns {
+		pattern := strings.TrimSpace(raw)
+		if pattern == "" {
+			return Policy{}, ErrInvalidToolPattern
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return Policy{}, ErrInvalidToolPattern
+		}
+		if _, ok := seen[pattern]; ok {
+			continue
+		}
+		seen[pattern] = struct{}{}
+		patterns = append(patterns, pattern)
+	}
+	current, err := s.ApprovalPolicy(ctx, botID)
+	if err != nil {
+		return Policy{}, err
+	}
+	timeout := current.TimeoutSeconds
+	if req.TimeoutSeconds != nil {
+		timeout = *req.TimeoutSeconds
+		if timeout <= 0 || timeout > MaxTimeoutSeconds {
+			return Policy{}, ErrInvalidTimeout
+		}
+	}
+	row, err := s.queries.UpsertBotToolApprovalPolicy(ctx, sqlc.UpsertBotToolApprovalPolicyParams{
+		BotID:          pgBotID,
+		ToolPatterns:   patterns,
+		TimeoutSeconds: int32(timeout), //nolint:gosec // bounded by MaxTimeoutSeconds
+	})
+	if err != nil {
+		return Policy{}, err
+	}
+	return toPolicy(row), nil
+}
+
+// List returns the bot's approval audit log, newest first. An empty status
+// lists every request.
+func (s *Service) List(ctx context.Context, botID, status string, limit int) ([]Approval, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return nil, err
+	}
+	var pgStatus pgtype.Text
+	if status = strings.TrimSpace(status); status != "" {
+		pgStatus = pgtype.Text{String: status, Valid: true}
+	}
+	rows, err := s.queries.ListToolApprovals(ctx, sqlc.ListToolApprovalsParams{
+		BotID:    pgBotID,
+		Status:   pgStatus,
+		MaxCount: int32(limit), //nolint:gosec // bounded by callers
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Approval, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toApproval(row))
+	}
+	return items, nil
+}
+
+// Await records an approval request, prompts the owner in the originating
+// channel and blocks until the request is decided, times out, or ctx ends.
+func (s *Service) Await(ctx context.Context, req Request) (Decision, error) {
+	pgBotID, err := db.ParseUUID(req.BotID)
+	if err != nil {
+		return Decision{}, err
+	}
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeoutSeconds * time.Second
+	}
+	row, err := s.queries.CreateToolApproval(ctx, sqlc.CreateToolApprovalParams{
+		BotID:                      pgBotID,
+		SessionID:                  optionalUUID(req.SessionID),
+		RequesterChannelIdentityID: optionalUUID(req.ChannelIdentityID),
+		ToolName:                   req.ToolName,
+		ToolCallID:                 req.ToolCallID,
+		Input:                      marshalInput(req.Input),
+		Platform:                   strings.TrimSpace(req.Platform),
+		ReplyTarget:                strings.TrimSpace(req.ReplyTarget),
+		ExpiresAt:                  pgtype.Timestamptz{Time: s.now().Add(timeout), Valid: true},
+	})
+	if err != nil {
+		return Decision{}, fmt.Errorf("create approval: %w", err)
+	}
+	record := toApproval(row)
+
+	waiter := make(chan Decision, 1)
+	s.mu.Lock()
+	s.waiters[record.ID] = waiter
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.waiters, record.ID)
+		s.mu.Unlock()
+	}()
+
+	s.notify(ctx, record, timeout)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case decision := <-waiter:
+		return decision, nil
+	case <-timer.C:
+		return s.expire(context.WithoutCancel(ctx), record.ID, waiter, "timed out")
+	case <-ctx.Done():
+		if _, err := s.expire(context.WithoutCancel(ctx), record.ID, waiter, "run cancelled"); err != nil {
+			s.logger.Warn("expire approval failed", slog.String("approval_id", record.ID), slog.Any("error", err))
+		}
+		return Decision{}, ctx.Err()
+	}
+}
+
+// Decide approves or rejects a pending request on behalf of userID, who must
+// own the bot. ref is the approval ID or an unambiguous prefix of a pending
+// one.
+func (s *Service) Decide(ctx context.Context, botID, ref, userID string, req DecideRequest) (Approval, error) {
+	bot, err := s.bots.Get(ctx, botID)
+	if err != nil {
+		return Approval{}, err
+	}
+	userID = strings.TrimSpace(userID)
+	if userID == "" || strings.TrimSpace(bot.OwnerUserID) != userID {
+		return Approval{}, ErrNotOwner
+	}
+	id, err := s.resolveID(ctx, botID, ref)
+	if err != nil {
+		return Approval{}, err
+	}
+	key := id.String()
+	s.mu.Lock()
+	waiter, waiting := s.waiters[key]
+	s.mu.Unlock()
+	if !waiting {
+		// The run that asked has ended or this process restarted; nothing
+		// would act on the decision.
+		if _, err := s.queries.DecideToolApproval(ctx, sqlc.DecideToolApprovalParams{
+			Status:       StatusExpired,
+			DecisionNote: "no longer waiting",
+			ID:           id,
+		}); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return Approval{}, err
+		}
+		return Approval{}, ErrNotPending
+	}
+	status := StatusRejected
+	if req.Approve {
+		status = StatusApproved
+	}
+	note := strings.TrimSpace(req.Note)
+	row, err := s.queries.DecideToolApproval(ctx, sqlc.DecideToolApprovalParams{
+		Status:          status,
+		DecidedByUserID: optionalUUID(userID),
+		DecisionNote:    note,
+		ID:              id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Approval{}, ErrNotPending
+		}
+		return Approval{}, err
+	}
+	select {
+	case waiter <- Decision{ApprovalID: key, Status: status, Note: note}:
+	default:
+	}
+	return toApproval(row), nil
+}
+
+func (s *Service) expire(ctx context.Context, id string, waiter <-chan Decision, note string) (Decision, error) {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return Decision{}, err
+	}
+	_, err = s.queries.DecideToolApproval(ctx, sqlc.DecideToolApprovalParams{
+		Status:       StatusExpired,
+		DecisionNote: note,
+		ID:           pgID,
+	})
+	if err == nil {
+		return Decision{ApprovalID: id, Status: StatusExpired, Note: note}, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return Decision{}, err
+	}
+	// A decision landed just before the deadline.
+	select {
+	case decision := <-waiter:
+		return decision, nil
+	default:
+	}
+	row, err := s.queries.GetToolApproval(ctx, pgID)
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{ApprovalID: id, Status: row.Status, Note: row.DecisionNote}, nil
+}
+
+func (s *Service) resolveID(ctx context.Context, botID, ref string) (pgtype.UUID, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if ref == "" {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	if id, err := db.ParseUUID(ref); err == nil {
+		row, err := s.queries.GetToolApproval(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return pgtype.UUID{}, ErrNotFound
+			}
+			return pgtype.UUID{}, err
+		}
+		if row.BotID.String() != strings.ToLower(strings.TrimSpace(botID)) {
+			return pgtype.UUID{}, ErrNotFound
+		}
+		return id, nil
+	}
+	if len(ref) < MinIDPrefixLength {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	rows, err := s.queries.ListPendingToolApprovals(ctx, pgBotID)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	var match pgtype.UUID
+	for _, row := range rows {
+		if !strings.HasPrefix(row.ID.String(), ref) {
+			continue
+		}
+		if match.Valid {
+			return pgtype.UUID{}, ErrAmbiguousID
+		}
+		match = row.ID
+	}
+	if !match.Valid {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	return match, nil
+}
+
+// notify prompts the owner in the conversation the tool call came from,
+// with approve/reject buttons when the adapter supports them.
+func (s *Service) notify(ctx context.Context, record Approval, timeout time.Duration) {
+	if s.sender == nil || s.channels == nil || record.Platform == "" || record.ReplyTarget == "" {
+		return
+	}
+	channelType, err := s.channels.ParseChannelType(record.Platform)
+	if err != nil {
+		s.logger.Warn("approval prompt skipped", slog.String("platform", record.Platform), slog.Any("error", err))
+		return
+	}
+	caps, _ := s.channels.GetCapabilities(channelType)
+	msg := channel.Message{Text: promptText(record, timeout, caps.Buttons)}
+	if caps.Buttons {
+		msg.Actions = []channel.Action{
+			{Type: "button", Label: "Approve", Value: "/approve " + record.ID},
+			{Type: "button", Label: "Reject", Value: "/reject " + record.ID},
+		}
+	}
+	if err := s.sender.Send(ctx, record.BotID, channelType, channel.SendRequest{
+		Target:  record.ReplyTarget,
+		Message: msg,
+	}); err != nil {
+		s.logger.Warn("approval prompt failed",
+			slog.String("approval_id", record.ID),
+			slog.String("platform", record.Platform),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func promptText(record Approval, timeout time.Duration, buttons bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Approval needed: the bot wants to run %s.", record.ToolName)
+	if len(record.Input) > 0 {
+		input, _ := json.Marshal(record.Input)
+		text := string(input)
+		if len(text) > maxPromptInputLen {
+			cut := maxPromptInputLen
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+			text = text[:cut] + "…"
+		}
+		fmt.Fprintf(&b, "\nInput: %s", text)
+	}
+	shortID := ShortID(record.ID)
+	if buttons {
+		fmt.Fprintf(&b, "\nOnly the bot owner can decide. Expires in %s.", timeout)
+	} else {
+		fmt.Fprintf(&b, "\nThe bot owner can reply /approve %s or /reject %s within %s.", shortID, shortID, timeout)
+	}
+	return b.String()
+}
+
+// ShortID returns the prefix of an approval ID shown in chat prompts.
+func ShortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func marshalInput(input any) []byte {
+	if input == nil {
+		return []byte(`{}`)
+	}
+	data, err := json.Marshal(input)
+	if err != nil || len(data) == 0 || data[0] != '{' {
+		wrapped, wrapErr := json.Marshal(map[string]any{"value": input})
+		if wrapErr != nil {
+			return []byte(`{}`)
+		}
+		return wrapped
+	}
+	return data
+}
+
+func optionalUUID(value string) pgtype.UUID {
+	id, err := db.ParseUUID(strings.TrimSpace(value))
+	if err != nil {
+		return pgtype.UUID{}
+	}
+	return id
+}
+
+func formatUUID(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.String()
+}
+
+func toPolicy(row sqlc.BotToolApprovalPolicy) Policy {
+	patterns := row.ToolPatterns
+	if patterns == nil {
+		patterns = []string{}
+	}
+	return Policy{ToolPatterns: patterns, TimeoutSeconds: int(row.TimeoutSeconds)}
+}
+
+func toApproval(row sqlc.ToolApproval) Approval {
+	item := Approval{
+		ID:                         formatUUID(row.ID),
+		BotID:                      formatUUID(row.BotID),
+		SessionID:                  formatUUID(row.SessionID),
+		RequesterChannelIdentityID: formatUUID(row.RequesterChannelIdentityID),
+		ToolName:                   row.ToolName,
+		ToolCallID:                 row.ToolCallID,
+		Platform:                   row.Platform,
+		ReplyTarget:                row.ReplyTarget,
+		Status:                     row.Status,
+		DecidedByUserID:            formatUUID(row.DecidedByUserID),
+		DecisionNote:               row.DecisionNote,
+		ExpiresAt:                  row.ExpiresAt.Time,
+		CreatedAt:                  row.CreatedAt.Time,
+	}
+	if len(row.Input) > 0 {
+		_ = json.Unmarshal(row.Input, &item.Input)
+	}
+	if row.DecidedAt.Valid {
+		decidedAt := row.DecidedAt.Time
+		item.DecidedAt = &decidedAt
+	}
+	return item
+}
@@ -0,0 +1,373 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/bots"
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+var (
+	testBotID   = pgtype.UUID{Bytes: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Valid: true}
+	testOwnerID = pgtype.UUID{Bytes: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Valid: true}
+	testGuestID = "33333333-3333-3333-3333-333333333333"
+)
+
+// fakeStore keeps approval rows in memory and answers the queries the
+// service issues.
+type fakeStore struct {
+	mu      sync.Mutex
+	records map[pgtype.UUID]*sqlc.ToolApproval
+}
+
+func (f *fakeStore) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeStore) Query(_ context.Context, sql string, _ ...any) (pgx.Rows, error) {
+	if !strings.Contains(sql, "ListPendingToolApprovals") {
+		return &fakeRows{}, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rows := &fakeRows{}
+	for _, record := range f.records {
+		if record.Status != StatusPending {
+			continue
+		}
+		snapshot := *record
+		rows.rows = append(rows.rows, func(dest ...any) error { return scanApproval(snapshot, dest) })
+	}
+	return rows, nil
+}
+
+func (f *fakeStore) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case strings.Contains(sql, "CreateToolApproval"):
+		record := &sqlc.ToolApproval{
+			ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			BotID:       args[0].(pgtype.UUID),
+			ToolName:    args[3].(string),
+			ToolCallID:  args[4].(string),
+			Input:       args[5].([]byte),
+			Platform:    args[6].(string),
+			ReplyTarget: args[7].(string),
+			Status:      StatusPending,
+			ExpiresAt:   args[8].(pgtype.Timestamptz),
+		}
+		f.records[record.ID] = record
+		return rowOf(*record)
+	case strings.Contains(sql, "DecideToolApproval"):
+		record, ok := f.records[args[3].(pgtype.UUID)]
+		if !ok || record.Status != StatusPending {
+			return &fakeRow{err: pgx.ErrNoRows}
+		}
+		record.Status = args[0].(string)
+		record.DecidedByUserID = args[1].(pgtype.UUID)
+		record.DecisionNote = args[2].(string)
+		record.DecidedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		return rowOf(*record)
+	case strings.Contains(sql, "GetToolApproval"):
+		record, ok := f.records[args[0].(pgtype.UUID)]
+		if !ok {
+			return &fakeRow{err: pgx.ErrNoRows}
+		}
+		return rowOf(*record)
+	case strings.Contains(sql, "FROM bots"):
+		return botRow()
+	default:
+		return &fakeRow{err: pgx.ErrNoRows}
+	}
+}
+
+type fakeRow struct {
+	scan func(dest ...any) error
+	err  error
+}
+
+func (r *fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.scan(dest...)
+}
+
+type fakeRows struct {
+	rows []func(dest ...any) error
+	idx  int
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) Values() ([]any, error)                       { return nil, nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	if r.idx >= len(r.rows) {
+		return false
+	}
+	r.idx++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return r.rows[r.idx-1](dest...)
+}
+
+func rowOf(record sqlc.ToolApproval) *fakeRow {
+	return &fakeRow{scan: func(dest ...any) error { return scanApproval(record, dest) }}
+}
+
+func scanApproval(record sqlc.ToolApproval, dest []any) error {
+	*dest[0].(*pgtype.UUID) = record.ID
+	*dest[1].(*pgtype.UUID) = record.BotID
+	*dest[2].(*pgtype.UUID) = record.SessionID
+	*dest[3].(*pgtype.UUID) = record.RequesterChannelIdentityID
+	*dest[4].(*string) = record.ToolName
+	*dest[5].(*string) = record.ToolCallID
+	*dest[6].(*[]byte) = record.Input
+	*dest[7].(*string) = record.Platform
+	*dest[8].(*string) = record.ReplyTarget
+	*dest[9].(*string) = record.Status
+	*dest[10].(*pgtype.UUID) = record.DecidedByUserID
+	*dest[11].(*string) = record.DecisionNote
+	*dest[12].(*pgtype.Timestamptz) = record.ExpiresAt
+	*dest[13].(*pgtype.Timestamptz) = record.DecidedAt
+	*dest[14].(*pgtype.Timestamptz) = record.CreatedAt
+	return nil
+}
+
+func botRow() *fakeRow {
+	return &fakeRow{scan: func(dest ...any) error {
+		*dest[0].(*pgtype.UUID) = testBotID
+		*dest[1].(*pgtype.UUID) = testOwnerID
+		*dest[2].(*pgtype.Text) = pgtype.Text{String: "bot", Valid: true}
+		*dest[3].(*pgtype.Text) = pgtype.Text{}
+		*dest[4].(*bool) = true
+		*dest[5].(*string) = bots.BotStatusReady
+		*dest[6].(*int32) = 30
+		*dest[7].(*int32) = 0
+		*dest[8].(*string) = ""
+		*dest[9].(*bool) = false
+		*dest[10].(*string) = "medium"
+		*dest[11].(*pgtype.UUID) = pgtype.UUID{}
+		*dest[12].(*pgtype.UUID) = pgtype.UUID{}
+		*dest[13].(*pgtype.UUID) = pgtype.UUID{}
+		*dest[14].(*bool) = false
+		*dest[15].(*int32) = 30
+		*dest[16].(*string) = ""
+		*dest[17].(*bool) = false
+		*dest[18].(*int32) = 100000
+		*dest[19].(*pgtype.UUID) = pgtype.UUID{}
+		*dest[20].(*[]byte) = []byte(`{}`)
+		*dest[21].(*pgtype.Timestamptz) = pgtype.Timestamptz{}
+		*dest[22].(*pgtype.Timestamptz) = pgtype.Timestamptz{}
+		return nil
+	}}
+}
+
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []channel.SendRequest
+}
+
+func (f *fakeSender) Send(_ context.Context, _ string, _ channel.ChannelType, req channel.SendRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, req)
+	return nil
+}
+
+func (f *fakeSender) messages() []channel.SendRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]channel.SendRequest(nil), f.sent...)
+}
+
+type fakeChannels struct {
+	buttons bool
+}
+
+func (f fakeChannels) ParseChannelType(raw string) (channel.ChannelType, error) {
+	return channel.ChannelType(raw), nil
+}
+
+func (f fakeChannels) GetCapabilities(channel.ChannelType) (channel.ChannelCapabilities, bool) {
+	return channel.ChannelCapabilities{Buttons: f.buttons}, true
+}
+
+func newTestService(buttons bool) (*Service, *fakeSender) {
+	store := &fakeStore{records: make(map[pgtype.UUID]*sqlc.ToolApproval)}
+	queries := sqlc.New(store)
+	service := NewService(nil, queries, bots.NewService(nil, queries))
+	sender := &fakeSender{}
+	service.SetNotifier(sender, fakeChannels{buttons: buttons})
+	return service, sender
+}
+
+func testRequest(timeout time.Duration) Request {
+	return Request{
+		BotID:       testBotID.String(),
+		Platform:    "telegram",
+		ReplyTarget: "chat-1",
+		ToolName:    "exec",
+		ToolCallID:  "call-1",
+		Input:       map[string]any{"command": "rm -rf /tmp/x"},
+		Timeout:     timeout,
+	}
+}
+
+// waitForPending blocks until an Await call has registered its waiter and
+// returns the approval ID.
+func waitForPending(t *testing.T, service *Service) string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		service.mu.Lock()
+		for id := range service.waiters {
+			service.mu.Unlock()
+			return id
+		}
+		service.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("approval request was never registered")
+	return ""
+}
+
+func TestPolicyRequires(t *testing.T) {
+	policy := Policy{ToolPatterns: []string{"exec", "github_*"}}
+	for name, want := range map[string]bool{
+		"exec":                true,
+		"read":                false,
+		"github_create_issue": true,
+		"executor":            false,
+	} {
+		if got := policy.Requires(name); got != want {
+			t.Errorf("Requires(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if (Policy{}).Timeout() != DefaultTimeoutSeconds*time.Second {
+		t.Fatal("zero policy should use the default timeout")
+	}
+}
+
+func TestAwaitApprovedByOwnerWithPrefix(t *testing.T) {
+	service, sender := newTestService(false)
+
+	result := make(chan Decision, 1)
+	go func() {
+		decision, err := service.Await(context.Background(), testRequest(time.Minute))
+		if err != nil {
+			t.Errorf("Await: %v", err)
+		}
+		result <- decision
+	}()
+	id := waitForPending(t, service)
+
+	sent := sender.messages()
+	if len(sent) != 1 || sent[0].Target != "chat-1" {
+		t.Fatalf("expected one prompt to chat-1, got %+v", sent)
+	}
+	if !strings.Contains(sent[0].Message.Text, "/approve "+ShortID(id)) || len(sent[0].Message.Actions) != 0 {
+		t.Fatalf("expected text instructions without buttons, got %+v", sent[0].Message)
+	}
+
+	if _, err := service.Decide(context.Background(), testBotID.String(), ShortID(id), testGuestID, DecideRequest{Approve: true}); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("expected ErrNotOwner for a guest, got %v", err)
+	}
+	if _, err := service.Decide(context.Background(), testBotID.String(), id[:3], testOwnerID.String(), DecideRequest{Approve: true}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a short prefix, got %v", err)
+	}
+	decided, err := service.Decide(context.Background(), testBotID.String(), ShortID(id), testOwnerID.String(), DecideRequest{Approve: true, Note: "ok"})
+	if err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+	if decided.Status != StatusApproved || decided.DecidedByUserID != testOwnerID.String() {
+		t.Fatalf("unexpected audit record: %+v", decided)
+	}
+
+	select {
+	case decision := <-result:
+		if !decision.Approved() || decision.ApprovalID != id {
+			t.Fatalf("unexpected decision: %+v", decision)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Await did not return after the decision")
+	}
+
+	if _, err := service.Decide(context.Background(), testBotID.String(), id, testOwnerID.String(), DecideRequest{}); !errors.Is(err, ErrNotPending) {
+		t.Fatalf("expected ErrNotPending for a decided approval, got %v", err)
+	}
+}
+
+func TestAwaitExpires(t *testing.T) {
+	service, sender := newTestService(true)
+
+	decision, err := service.Await(context.Background(), testRequest(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Await: %v", err)
+	}
+	if decision.Status != StatusExpired || decision.Approved() {
+		t.Fatalf("expected expired decision, got %+v", decision)
+	}
+	sent := sender.messages()
+	if len(sent) != 1 || len(sent[0].Message.Actions) != 2 {
+		t.Fatalf("expected a prompt with approve/reject buttons, got %+v", sent)
+	}
+	if sent[0].Message.Actions[1].Value != "/reject "+decision.ApprovalID {
+		t.Fatalf("unexpected reject action: %+v", sent[0].Message.Actions[1])
+	}
+}
+
+func TestUpdatePolicyValidation(t *testing.T) {
+	service, _ := newTestService(false)
+	ctx := context.Background()
+	if _, err := service.UpdatePolicy(ctx, testBotID.String(), UpdatePolicyRequest{ToolPatterns: []string{"bad["}}); !errors.Is(err, ErrInvalidToolPattern) {
+		t.Fatalf("expected ErrInvalidToolPattern, got %v", err)
+	}
+	zero := 0
+	if _, err := service.UpdatePolicy(ctx, testBotID.String(), UpdatePolicyRequest{ToolPatterns: []string{"exec"}, TimeoutSeconds: &zero}); !errors.Is(err, ErrInvalidTimeout) {
+		t.Fatalf("expected ErrInvalidTimeout, got %v", err)
+	}
+}
+
+func TestMarshalInputWrapsNonObjects(t *testing.T) {
+	if got := string(marshalInput(nil)); got != `{}` {
+		t.Fatalf("nil input = %s", got)
+	}
+	if got := string(marshalInput("ls")); got != `{"value":"ls"}` {
+		t.Fatalf("string input = %s", got)
+	}
+}
+
+func TestPromptTextTruncatesOnRuneBoundary(t *testing.T) {
+	// `{"command":"` is 12 bytes, so a 3-byte rune straddles the byte limit.
+	record := Approval{ID: "abcdef123456", ToolName: "exec", Input: map[string]any{"command": strings.Repeat("界", maxPromptInputLen)}}
+	text := promptText(record, time.Minute, false)
+	if !utf8.ValidString(text) {
+		t.Fatalf("prompt is not valid UTF-8: %q", text)
+	}
+	line := strings.SplitN(text, "\n", 3)[1]
+	input := strings.TrimSuffix(strings.TrimPrefix(line, "Input: "), "…")
+	if len(input) > maxPromptInputLen || len(input) < maxPromptInputLen-utf8.UTFMax {
+		t.Fatalf("unexpected truncated length %d", len(input))
+	}
+}
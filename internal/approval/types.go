@@ -0,0 +1,119 @@
+package approval
+
+import (
+	"errors"
+	"path"
+	"time"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+	StatusExpired  = "expired"
+
+	DefaultTimeoutSeconds = 300
+	MaxTimeoutSeconds     = 24 * 60 * 60
+
+	// MinIDPrefixLength is the shortest approval ID prefix accepted by
+	// /approve and /reject.
+	MinIDPrefixLength = 6
+)
+
+var (
+	ErrInvalidToolPattern = errors.New("tool patterns must be tool names or globs")
+	ErrInvalidTimeout     = errors.New("timeout_seconds must be between 1 and 86400")
+	ErrNotFound           = errors.New("approval not found")
+	ErrAmbiguousID        = errors.New("approval id prefix matches more than one pending request")
+	ErrNotPending         = errors.New("approval is no longer pending")
+	ErrNotOwner           = errors.New("only the bot owner can decide approvals")
+)
+
+// Policy lists the tools that need owner approval before they run. Patterns
+// are exact tool names or globs such as "github_*".
+type Policy struct {
+	ToolPatterns   []string `json:"tool_patterns"`
+	TimeoutSeconds int      `json:"timeout_seconds"`
+}
+
+// Requires reports whether calls to the tool must be approved.
+func (p Policy) Requires(toolName string) bool {
+	for _, pattern := range p.ToolPatterns {
+		if pattern == toolName {
+			return true
+		}
+		if matched, err := path.Match(pattern, toolName); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Timeout returns how long a call waits for a decision.
+func (p Policy) Timeout() time.Duration {
+	if p.TimeoutSeconds <= 0 {
+		return DefaultTimeoutSeconds * time.Second
+	}
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
+
+// UpdatePolicyRequest replaces a bot's approval policy. A nil timeout keeps
+// the current one.
+type UpdatePolicyRequest struct {
+	ToolPatterns   []string `json:"tool_patterns"`
+	TimeoutSeconds *int     `json:"timeout_seconds,omitempty"`
+}
+
+// Approval is the audit record of one approval request.
+type Approval struct {
+	ID                         string         `json:"id"`
+	BotID                      string         `json:"bot_id"`
+	SessionID                  string         `json:"session_id,omitempty"`
+	RequesterChannelIdentityID string         `json:"requester_channel_identity_id,omitempty"`
+	ToolName                   string         `json:"tool_name"`
+	ToolCallID                 string         `json:"tool_call_id,omitempty"`
+	Input                      map[string]any `json:"input,omitempty"`
+	Platform                   string         `json:"platform,omitempty"`
+	ReplyTarget                string         `json:"reply_target,omitempty"`
+	Status                     string         `json:"status"`
+	DecidedByUserID            string         `json:"decided_by_user_id,omitempty"`
+	DecisionNote               string         `json:"decision_note,omitempty"`
+	ExpiresAt                  time.Time      `json:"expires_at"`
+	DecidedAt                  *time.Time     `json:"decided_at,omitempty"`
+	CreatedAt                  time.Time      `json:"created_at"`
+}
+
+type ListResponse struct {
+	Items []Approval `json:"items"`
+}
+
+// DecideRequest approves or rejects a pending request.
+type DecideRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note,omitempty"`
+}
+
+// Request asks the owner to approve one tool call.
+type Request struct {
+	BotID             string
+	SessionID         string
+	ChannelIdentityID string
+	Platform          string
+	ReplyTarget       string
+	ToolName          string
+	ToolCallID        string
+	Input             any
+	Timeout           time.Duration
+}
+
+// Decision is the outcome of a Request.
+type Decision struct {
+	ApprovalID string
+	Status     string
+	Note       string
+}
+
+// Approved reports whether the tool call may run.
+func (d Decision) Approved() bool {
+	return d.Status == StatusApproved
+}
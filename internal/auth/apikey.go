@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// APIKeyPrefix marks bearer tokens that are personal access tokens rather
+// than JWTs.
+const APIKeyPrefix = "memoh_pat_"
+
+const (
+	apiKeyTokenType = "api_key"
+	claimAPIKeyID   = "api_key_id"
+	claimScopes     = "scopes"
+)
+
+// ErrInvalidAPIKey is returned by authenticators for unknown, revoked, or
+// expired keys.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// APIKeyPrincipal is the identity behind a valid API key.
+type APIKeyPrincipal struct {
+	KeyID  string
+	UserID string
+	Scopes []string
+}
+
+// APIKeyAuthenticator validates API keys presented as bearer tokens.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, token string) (APIKeyPrincipal, error)
+}
+
+// Middleware authenticates requests with either a login JWT or, when keys is
+// set, a personal access token. API key requests are checked against the
+// scope required by the route and then carry synthesized JWT claims so that
+// handlers resolve the user the same way for both.
+func Middleware(secret string, skipper middleware.Skipper, keys APIKeyAuthenticator) echo.MiddlewareFunc {
+	jwtMiddleware := JWTMiddleware(secret, skipper)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		jwtNext := jwtMiddleware(next)
+		return func(c echo.Context) error {
+			if keys == nil || (skipper != nil && skipper(c)) {
+				return jwtNext(c)
+			}
+			raw := bearerToken(c)
+			if !strings.HasPrefix(raw, APIKeyPrefix) {
+				return jwtNext(c)
+			}
+			principal, err := keys.AuthenticateAPIKey(c.Request().Context(), raw)
+			if err != nil {
+				if errors.Is(err, ErrInvalidAPIKey) {
+					return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired api key")
+				}
+				return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			required, allowed := RequiredScope(c.Request().Method, c.Request().URL.Path)
+			if !allowed {
+				return echo.NewHTTPError(http.StatusForbidden, "api keys cannot access this endpoint")
+			}
+			if !HasScope(principal.Scopes, required) {
+				return echo.NewHTTPError(http.StatusForbidden, "api key is missing scope "+required)
+			}
+			c.Set("user", apiKeyToken(principal))
+			return next(c)
+		}
+	}
+}
+
+// APIKeyFromContext reports the API key used to authenticate the request.
+// It returns false for JWT-authenticated requests.
+func APIKeyFromContext(c echo.Context) (APIKeyPrincipal, bool) {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok || token == nil || !token.Valid {
+		return APIKeyPrincipal{}, false
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claimString(claims, claimType) != apiKeyTokenType {
+		return APIKeyPrincipal{}, false
+	}
+	principal := APIKeyPrincipal{
+		KeyID:  claimString(claims, claimAPIKeyID),
+		UserID: claimString(claims, claimUserID),
+	}
+	if scopes, ok := claims[claimScopes].([]string); ok {
+		principal.Scopes = scopes
+	}
+	return principal, true
+}
+
+func apiKeyToken(p APIKeyPrincipal) *jwt.Token {
+	return &jwt.Token{
+		Valid: true,
+		Claims: jwt.MapClaims{
+			claimSubject:  p.UserID,
+			claimUserID:   p.UserID,
+			claimType:     apiKeyTokenType,
+			claimAPIKeyID: p.KeyID,
+			claimScopes:   p.Scopes,
+		},
+	}
+}
+
+// bearerToken mirrors the JWT token lookup: the Authorization bearer header
+// first, then the token query parameter used by WebSocket clients.
+func bearerToken(c echo.Context) string {
+	if header := strings.TrimSpace(c.Request().Header.Get(echo.HeaderAuthorization)); header != "" {
+		if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+			return strings.TrimSpace(header[7:])
+		}
+		return ""
+	}
+	return strings.TrimSpace(c.QueryParam("token"))
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAPIKeys map[string]APIKeyPrincipal
+
+func (f fakeAPIKeys) AuthenticateAPIKey(_ context.Context, token string) (APIKeyPrincipal, error) {
+	p, ok := f[token]
+	if !ok {
+		return APIKeyPrincipal{}, ErrInvalidAPIKey
+	}
+	return p, nil
+}
+
+func TestRequiredScope(t *testing.T) {
+	cases := []struct {
+		method, path string
+		scope        string
+		allowed      bool
+	}{
+		{http.MethodGet, "/bots", ScopeBotsRead, true},
+		{http.MethodPut, "/bots/b1/settings", ScopeBotsWrite, true},
+		{http.MethodGet, "/bots/b1/messages", ScopeMessagesRead, true},
+		{http.MethodPost, "/bots/b1/web/messages", ScopeMessagesWrite, true},
+		{http.MethodPost, "/bots/b1/channel/telegram/send", ScopeMessagesWrite, true},
+		{http.MethodPost, "/v1/chat/completions", ScopeMessagesWrite, true},
+		{http.MethodGet, "/v1/models", ScopeBotsRead, true},
+		{http.MethodGet, "/bots/b1/container/fs/list", ScopeContainersRead, true},
+		{http.MethodPost, "/bots/b1/container/fs/write", ScopeContainersWrite, true},
+		{http.MethodGet, "/bots/b1/container/terminal/ws", ScopeContainersExec, true},
+		{http.MethodPost, "/bots/b1/mcp-stdio", ScopeContainersExec, true},
+		{http.MethodGet, "/bots/b1/mcp", ScopeBotsRead, true},
+		{http.MethodPost, "/bots/b1/mcp", ScopeContainersExec, true},
+		{http.MethodPost, "/bots/b1/mcp/m1/probe", ScopeContainersExec, true},
+		{http.MethodPut, "/bots/b1/mcp/import", ScopeContainersExec, true},
+		{http.MethodPost, "/bots/b1/mcp-ops/batch-delete", ScopeContainersExec, true},
+		{http.MethodPost, "/bots/b1/mcp-ops/import", ScopeContainersExec, true},
+		{http.MethodGet, "/bots/b1/tool-approvals", ScopeBotsRead, true},
+		{http.MethodPost, "/bots/b1/tool-approvals/a1/decision", "", false},
+		{http.MethodPut, "/bots/b1/tool-approvals/policy", "", false},
+		{http.MethodGet, "/bots/b1/whitelist", ScopeBotsRead, true},
+		{http.MethodPut, "/bots/b1/whitelist", "", false},
+		{http.MethodDelete, "/bots/b1/blacklist/r1", "", false},
+		{http.MethodPut, "/bots/b1/tool_rules", "", false},
+		{http.MethodDelete, "/bots/b1/tool_rules/r1", "", false},
+		{http.MethodGet, "/bots/b1/token-budgets", ScopeBotsRead, true},
+		{http.MethodPost, "/bots/b1/token-budgets", "", false},
+		{http.MethodPut, "/bots/b1/token-budgets/t1", "", false},
+		{http.MethodPut, "/bots/b1/owner", "", false},
+		{http.MethodPost, "/bots/b1/unknown", "", false},
+		{http.MethodGet, "/models", ScopeBotsRead, true},
+		{http.MethodPost, "/models", "", false},
+		{http.MethodGet, "/providers", "", false},
+		{http.MethodPost, "/providers/p1/test", "", false},
+		{http.MethodGet, "/search-providers", "", false},
+		{http.MethodGet, "/users", "", false},
+		{http.MethodGet, "/users/me", "", false},
+		{http.MethodPut, "/users/u1", "", false},
+		{http.MethodPost, "/users/me/api-keys", "", false},
+		{http.MethodPut, "/users/me/password", "", false},
+		{http.MethodPut, "/users/u1/password", "", false},
+		{http.MethodPost, "/auth/refresh", "", false},
+		{http.MethodGet, "/v1/chat/completions", "", false},
+		{http.MethodGet, "/unknown", "", false},
+		{http.MethodGet, "/", "", false},
+	}
+	for _, tc := range cases {
+		scope, allowed := RequiredScope(tc.method, tc.path)
+		assert.Equal(t, tc.allowed, allowed, "%s %s", tc.method, tc.path)
+		assert.Equal(t, tc.scope, scope, "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	assert.True(t, HasScope([]string{ScopeBotsWrite}, ScopeBotsRead))
+	assert.False(t, HasScope([]string{ScopeBotsRead}, ScopeBotsWrite))
+	assert.False(t, HasScope([]string{ScopeContainersWrite}, ScopeContainersExec))
+}
+
+func TestMiddleware(t *testing.T) {
+	secret := "test-secret"
+	keys := fakeAPIKeys{
+		APIKeyPrefix + "good": {KeyID: "k1", UserID: "user-1", Scopes: []string{ScopeBotsRead}},
+	}
+	e := echo.New()
+	e.Use(Middleware(secret, nil, keys))
+	e.GET("/bots", func(c echo.Context) error {
+		userID, err := UserIDFromContext(c)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, userID)
+	})
+	e.POST("/bots", func(c echo.Context) error { return c.NoContent(http.StatusCreated) })
+
+	do := func(method, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/bots", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodGet, APIKeyPrefix+"good")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user-1", rec.Body.String())
+
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, APIKeyPrefix+"good").Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, APIKeyPrefix+"bad").Code)
+
+	jwtToken, _, err := GenerateToken("user-2", secret, time.Minute)
+	require.NoError(t, err)
+	rec = do(http.MethodGet, jwtToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user-2", rec.Body.String())
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// API key scopes.
+const (
+	ScopeBotsRead        = "bots:read"
+	ScopeBotsWrite       = "bots:write"
+	ScopeMessagesRead    = "messages:read"
+	ScopeMessagesWrite   = "messages:write"
+	ScopeContainersRead  = "containers:read"
+	ScopeContainersWrite = "containers:write"
+	ScopeContainersExec  = "containers:exec"
+)
+
+// Scopes lists every scope an API key may be granted.
+var Scopes = []string{
+	ScopeBotsRead,
+	ScopeBotsWrite,
+	ScopeMessagesRead,
+	ScopeMessagesWrite,
+	ScopeContainersRead,
+	ScopeContainersWrite,
+	ScopeContainersExec,
+}
+
+// ValidScope reports whether scope is a known API key scope.
+func ValidScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+// HasScope reports whether granted satisfies required. A write scope also
+// grants the matching read scope.
+func HasScope(granted []string, required string) bool {
+	if slices.Contains(granted, required) {
+		return true
+	}
+	if resource, ok := strings.CutSuffix(required, ":read"); ok {
+		return slices.Contains(granted, resource+":write")
+	}
+	return false
+}
+
+// routeScopes holds the scopes an API key needs to read and to modify a
+// resource. An empty scope means the operation is JWT-only.
+type routeScopes struct {
+	read, write string
+}
+
+var (
+	botsScopes     = routeScopes{read: ScopeBotsRead, write: ScopeBotsWrite}
+	messagesScopes = routeScopes{read: ScopeMessagesRead, write: ScopeMessagesWrite}
+	// botsAdminScopes covers access control, budgets and approvals: a key
+	// may inspect them, but only a logged-in user can change them.
+	botsAdminScopes = routeScopes{read: ScopeBotsRead}
+)
+
+// botResourceScopes maps /bots/:id/<resource> to its scopes. Resources that
+// are not listed never accept API keys.
+var botResourceScopes = map[string]routeScopes{
+	"settings":         botsScopes,
+	"checks":           botsScopes,
+	"channel":          botsScopes,
+	"heartbeat":        botsScopes,
+	"schedule":         botsScopes,
+	"memory":           botsScopes,
+	"compaction":       botsScopes,
+	"email-bindings":   botsScopes,
+	"email-outbox":     botsScopes,
+	"event-triggers":   botsScopes,
+	"trigger-policies": botsScopes,
+	"token-usage":      botsScopes,
+	"tts":              botsScopes,
+	"messages":         messagesScopes,
+	"media":            messagesScopes,
+	"sessions":         messagesScopes,
+	"web":              messagesScopes,
+	"cli":              messagesScopes,
+	"mcp":              {read: ScopeBotsRead, write: ScopeContainersExec},
+	"mcp-ops":          {read: ScopeBotsRead, write: ScopeContainersExec},
+	"mcp-stdio":        {read: ScopeContainersExec, write: ScopeContainersExec},
+	"tools":            {read: ScopeContainersExec, write: ScopeContainersExec},
+	"container":        {read: ScopeContainersRead, write: ScopeContainersWrite},
+	"access":           botsAdminScopes,
+	"whitelist":        botsAdminScopes,
+	"blacklist":        botsAdminScopes,
+	"tool_rules":       botsAdminScopes,
+	"token-budgets":    botsAdminScopes,
+	"tool-approvals":   botsAdminScopes,
+}
+
+// topLevelScopes maps the first path segment of instance-wide resources to
+// their scopes. Users, auth, API keys and provider credentials are absent on
+// purpose, so a leaked key cannot mint new keys or read provider secrets.
+var topLevelScopes = map[string]routeScopes{
+	"bots":             botsScopes,
+	"models":           {read: ScopeBotsRead},
+	"channels":         {read: ScopeBotsRead},
+	"browser-contexts": {read: ScopeBotsRead},
+}
+
+// RequiredScope returns the scope an API key needs for a request. allowed is
+// false for endpoints that never accept API keys, including every route the
+// mapping does not know about.
+func RequiredScope(method, path string) (scope string, allowed bool) {
+	path = strings.Trim(path, "/")
+	segments := strings.Split(path, "/")
+	read := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+
+	switch {
+	case path == "v1/models" && read:
+		return ScopeBotsRead, true
+	case path == "v1/chat/completions" && !read:
+		return ScopeMessagesWrite, true
+	}
+
+	scopes, ok := topLevelScopes[segments[0]]
+	if ok && segments[0] == "bots" && len(segments) >= 3 {
+		scopes, ok = botResourceScopes[segments[2]]
+		switch {
+		case !ok:
+		case segments[2] == "container" && len(segments) >= 4 && segments[3] == "terminal":
+			return ScopeContainersExec, true
+		case segments[2] == "channel" && len(segments) >= 5 && strings.HasPrefix(segments[4], "send"):
+			return ScopeMessagesWrite, true
+		}
+	}
+	if !ok {
+		return "", false
+	}
+	scope = scopes.write
+	if read {
+		scope = scopes.read
+	}
+	return scope, scope != ""
+}
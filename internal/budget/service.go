@@ -0,0 +1,351 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/db"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+type Service struct {
+	queries *sqlc.Queries
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+func NewService(log *slog.Logger, queries *sqlc.Queries) *Service {
+	return &Service{
+		queries: queries,
+		logger:  log.With(slog.String("service", "budget")),
+		now:     time.Now,
+	}
+}
+
+func (s *Service) Create(ctx context.Context, botID string, req CreateRequest) (Rule, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return Rule{}, err
+	}
+	scope := strings.TrimSpace(req.Scope)
+	userID := strings.TrimSpace(req.UserID)
+	channelIdentityID := strings.TrimSpace(req.ChannelIdentityID)
+	switch scope {
+	case ScopeBot:
+		if userID != "" || channelIdentityID != "" {
+			return Rule{}, ErrInvalidSubject
+		}
+	case ScopeUser:
+		if channelIdentityID != "" {
+			return Rule{}, ErrInvalidSubject
+		}
+	case ScopeChannelIdentity:
+		if userID != "" {
+			return Rule{}, ErrInvalidSubject
+		}
+	default:
+		return Rule{}, ErrInvalidScope
+	}
+	pgUserID, err := optionalUUID(userID)
+	if err != nil {
+		return Rule{}, err
+	}
+	pgChannelIdentityID, err := optionalUUID(channelIdentityID)
+	if err != nil {
+		return Rule{}, err
+	}
+	period := strings.TrimSpace(req.Period)
+	if err := validateLimits(period, req.MaxInputTokens, req.MaxOutputTokens, req.MaxTotalTokens); err != nil {
+		return Rule{}, err
+	}
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	row, err := s.queries.CreateTokenBudget(ctx, sqlc.CreateTokenBudgetParams{
+		BotID:             pgBotID,
+		Scope:             scope,
+		UserID:            pgUserID,
+		ChannelIdentityID: pgChannelIdentityID,
+		Period:            period,
+		MaxInputTokens:    req.MaxInputTokens,
+		MaxOutputTokens:   req.MaxOutputTokens,
+		MaxTotalTokens:    req.MaxTotalTokens,
+		ExhaustedMessage:  strings.TrimSpace(req.ExhaustedMessage),
+		Enabled:           enabled,
+	})
+	if err != nil {
+		return Rule{}, fmt.Errorf("create token budget: %w", err)
+	}
+	return toRule(row), nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (Rule, error) {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return Rule{}, err
+	}
+	row, err := s.queries.GetTokenBudgetByID(ctx, pgID)
+	if err != nil {
+		return Rule{}, fmt.Errorf("get token budget: %w", err)
+	}
+	return toRule(row), nil
+}
+
+func (s *Service) List(ctx context.Context, botID string) ([]Rule, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListTokenBudgetsByBot(ctx, pgBotID)
+	if err != nil {
+		return nil, fmt.Errorf("list token budgets: %w", err)
+	}
+	items := make([]Rule, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toRule(row))
+	}
+	return items, nil
+}
+
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (Rule, error) {
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return Rule{}, err
+	}
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return Rule{}, err
+	}
+	if req.Period != nil {
+		current.Period = strings.TrimSpace(*req.Period)
+	}
+	if req.MaxInputTokens != nil {
+		current.MaxInputTokens = *req.MaxInputTokens
+	}
+	if req.MaxOutputTokens != nil {
+		current.MaxOutputTokens = *req.MaxOutputTokens
+	}
+	if req.MaxTotalTokens != nil {
+		current.MaxTotalTokens = *req.MaxTotalTokens
+	}
+	if req.ExhaustedMessage != nil {
+		current.ExhaustedMessage = strings.TrimSpace(*req.ExhaustedMessage)
+	}
+	if req.Enabled != nil {
+		current.Enabled = *req.Enabled
+	}
+	if err := validateLimits(current.Period, current.MaxInputTokens, current.MaxOutputTokens, current.MaxTotalTokens); err != nil {
+		return Rule{}, err
+	}
+	row, err := s.queries.UpdateTokenBudget(ctx, sqlc.UpdateTokenBudgetParams{
+		ID:               pgID,
+		Period:           current.Period,
+		MaxInputTokens:   current.MaxInputTokens,
+		MaxOutputTokens:  current.MaxOutputTokens,
+		MaxTotalTokens:   current.MaxTotalTokens,
+		ExhaustedMessage: current.ExhaustedMessage,
+		Enabled:          current.Enabled,
+	})
+	if err != nil {
+		return Rule{}, fmt.Errorf("update token budget: %w", err)
+	}
+	return toRule(row), nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.queries.DeleteTokenBudget(ctx, pgID); err != nil {
+		return fmt.Errorf("delete token budget: %w", err)
+	}
+	return nil
+}
+
+// Allowances reports the remaining allowance of every enabled rule that
+// applies to the subject.
+func (s *Service) Allowances(ctx context.Context, botID string, subject Subject) ([]Allowance, error) {
+	rules, err := s.List(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	items := make([]Allowance, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		userID, channelIdentityID, ok := rule.subjectFilter(subject)
+		if !ok {
+			continue
+		}
+		start, _ := periodBounds(rule.Period, now)
+		row, err := s.queries.SumTokenUsageSince(ctx, sqlc.SumTokenUsageSinceParams{
+			BotID:             pgBotID,
+			Since:             pgtype.Timestamptz{Time: start, Valid: true},
+			UserID:            db.ParseUUIDOrEmpty(userID),
+			ChannelIdentityID: db.ParseUUIDOrEmpty(channelIdentityID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sum token usage: %w", err)
+		}
+		items = append(items, evaluate(rule, Usage{InputTokens: row.InputTokens, OutputTokens: row.OutputTokens}, now))
+	}
+	return items, nil
+}
+
+// Check returns an *ExhaustedError when any applicable rule is exhausted.
+func (s *Service) Check(ctx context.Context, botID string, subject Subject) error {
+	items, err := s.Allowances(ctx, botID, subject)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.Exhausted {
+			return &ExhaustedError{Allowance: item}
+		}
+	}
+	return nil
+}
+
+// Record appends one turn's token consumption to the usage ledger.
+func (s *Service) Record(ctx context.Context, record UsageRecord) error {
+	if record.Usage.InputTokens <= 0 && record.Usage.OutputTokens <= 0 {
+		return nil
+	}
+	pgBotID, err := db.ParseUUID(record.BotID)
+	if err != nil {
+		return err
+	}
+	return s.queries.RecordTokenUsage(ctx, sqlc.RecordTokenUsageParams{
+		BotID:             pgBotID,
+		SessionID:         db.ParseUUIDOrEmpty(record.SessionID),
+		UserID:            db.ParseUUIDOrEmpty(record.UserID),
+		ChannelIdentityID: db.ParseUUIDOrEmpty(record.ChannelIdentityID),
+		ModelID:           db.ParseUUIDOrEmpty(record.ModelID),
+		InputTokens:       record.Usage.InputTokens,
+		OutputTokens:      record.Usage.OutputTokens,
+	})
+}
+
+// subjectFilter reports whether the rule applies to the subject and which
+// user or channel identity its usage should be summed over.
+func (r Rule) subjectFilter(subject Subject) (userID, channelIdentityID string, ok bool) {
+	switch r.Scope {
+	case ScopeBot:
+		return "", "", true
+	case ScopeUser:
+		id := strings.TrimSpace(subject.UserID)
+		if id == "" || (r.UserID != "" && r.UserID != id) {
+			return "", "", false
+		}
+		return id, "", true
+	case ScopeChannelIdentity:
+		id := strings.TrimSpace(subject.ChannelIdentityID)
+		if id == "" || (r.ChannelIdentityID != "" && r.ChannelIdentityID != id) {
+			return "", "", false
+		}
+		return "", id, true
+	default:
+		return "", "", false
+	}
+}
+
+// periodBounds returns the UTC start of the current period and the time it resets.
+func periodBounds(period string, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	if period == PeriodMonthly {
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, 1)
+}
+
+func evaluate(rule Rule, used Usage, now time.Time) Allowance {
+	start, resets := periodBounds(rule.Period, now)
+	a := Allowance{Rule: rule, PeriodStart: start, ResetsAt: resets, Used: used}
+	a.RemainingInputTokens, a.Exhausted = remaining(rule.MaxInputTokens, used.InputTokens, a.Exhausted)
+	a.RemainingOutputTokens, a.Exhausted = remaining(rule.MaxOutputTokens, used.OutputTokens, a.Exhausted)
+	a.RemainingTotalTokens, a.Exhausted = remaining(rule.MaxTotalTokens, used.Total(), a.Exhausted)
+	return a
+}
+
+func remaining(limit, used int64, exhausted bool) (*int64, bool) {
+	if limit <= 0 {
+		return nil, exhausted
+	}
+	left := limit - used
+	if left <= 0 {
+		left = 0
+		exhausted = true
+	}
+	return &left, exhausted
+}
+
+func validateLimits(period string, limits ...int64) error {
+	if period != PeriodDaily && period != PeriodMonthly {
+		return ErrInvalidPeriod
+	}
+	anySet := false
+	for _, limit := range limits {
+		if limit < 0 {
+			return ErrInvalidLimit
+		}
+		if limit > 0 {
+			anySet = true
+		}
+	}
+	if !anySet {
+		return ErrNoLimit
+	}
+	return nil
+}
+
+func optionalUUID(value string) (pgtype.UUID, error) {
+	if value == "" {
+		return pgtype.UUID{}, nil
+	}
+	id, err := db.ParseUUID(value)
+	if err != nil {
+		return pgtype.UUID{}, errors.Join(ErrInvalidSubject, err)
+	}
+	return id, nil
+}
+
+func formatUUID(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.String()
+}
+
+func toRule(row sqlc.BotTokenBudget) Rule {
+	return Rule{
+		ID:                formatUUID(row.ID),
+		BotID:             formatUUID(row.BotID),
+		Scope:             row.Scope,
+		UserID:            formatUUID(row.UserID),
+		ChannelIdentityID: formatUUID(row.ChannelIdentityID),
+		Period:            row.Period,
+		MaxInputTokens:    row.MaxInputTokens,
+		MaxOutputTokens:   row.MaxOutputTokens,
+		MaxTotalTokens:    row.MaxTotalTokens,
+		ExhaustedMessage:  row.ExhaustedMessage,
+		Enabled:           row.Enabled,
+		CreatedAt:         db.TimeFromPg(row.CreatedAt),
+		UpdatedAt:         db.TimeFromPg(row.UpdatedAt),
+	}
+}
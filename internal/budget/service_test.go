@@ -0,0 +1,111 @@
+package budget
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPeriodBounds(t *testing.T) {
+	now := time.Date(2026, 3, 31, 18, 30, 0, 0, time.FixedZone("UTC+8", 8*3600))
+
+	start, resets := periodBounds(PeriodDaily, now)
+	if want := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Fatalf("daily start = %v, want %v", start, want)
+	}
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC); !resets.Equal(want) {
+		t.Fatalf("daily reset = %v, want %v", resets, want)
+	}
+
+	start, resets = periodBounds(PeriodMonthly, now)
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Fatalf("monthly start = %v, want %v", start, want)
+	}
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC); !resets.Equal(want) {
+		t.Fatalf("monthly reset = %v, want %v", resets, want)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	rule := Rule{Period: PeriodDaily, MaxInputTokens: 1000, MaxTotalTokens: 1500}
+
+	a := evaluate(rule, Usage{InputTokens: 400, OutputTokens: 300}, now)
+	if a.Exhausted {
+		t.Fatal("expected allowance to remain")
+	}
+	if a.RemainingInputTokens == nil || *a.RemainingInputTokens != 600 {
+		t.Fatalf("unexpected remaining input: %v", a.RemainingInputTokens)
+	}
+	if a.RemainingOutputTokens != nil {
+		t.Fatal("uncapped output should have no remaining value")
+	}
+	if a.RemainingTotalTokens == nil || *a.RemainingTotalTokens != 800 {
+		t.Fatalf("unexpected remaining total: %v", a.RemainingTotalTokens)
+	}
+
+	a = evaluate(rule, Usage{InputTokens: 900, OutputTokens: 700}, now)
+	if !a.Exhausted {
+		t.Fatal("expected total cap to exhaust the budget")
+	}
+	if *a.RemainingTotalTokens != 0 {
+		t.Fatalf("remaining total should clamp to zero, got %d", *a.RemainingTotalTokens)
+	}
+}
+
+func TestRuleSubjectFilter(t *testing.T) {
+	subject := Subject{UserID: "u1", ChannelIdentityID: "c1"}
+
+	cases := []struct {
+		name     string
+		rule     Rule
+		ok       bool
+		user     string
+		identity string
+	}{
+		{name: "bot", rule: Rule{Scope: ScopeBot}, ok: true},
+		{name: "each user", rule: Rule{Scope: ScopeUser}, ok: true, user: "u1"},
+		{name: "matching user", rule: Rule{Scope: ScopeUser, UserID: "u1"}, ok: true, user: "u1"},
+		{name: "other user", rule: Rule{Scope: ScopeUser, UserID: "u2"}},
+		{name: "each identity", rule: Rule{Scope: ScopeChannelIdentity}, ok: true, identity: "c1"},
+		{name: "other identity", rule: Rule{Scope: ScopeChannelIdentity, ChannelIdentityID: "c2"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, identity, ok := tc.rule.subjectFilter(subject)
+			if ok != tc.ok || user != tc.user || identity != tc.identity {
+				t.Fatalf("got (%q, %q, %v), want (%q, %q, %v)", user, identity, ok, tc.user, tc.identity, tc.ok)
+			}
+		})
+	}
+
+	if _, _, ok := (Rule{Scope: ScopeUser}).subjectFilter(Subject{}); ok {
+		t.Fatal("user rule should not apply without a user")
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	if err := validateLimits("weekly", 10); !errors.Is(err, ErrInvalidPeriod) {
+		t.Fatalf("expected ErrInvalidPeriod, got %v", err)
+	}
+	if err := validateLimits(PeriodDaily, 0, 0, 0); !errors.Is(err, ErrNoLimit) {
+		t.Fatalf("expected ErrNoLimit, got %v", err)
+	}
+	if err := validateLimits(PeriodDaily, 10, -1, 0); !errors.Is(err, ErrInvalidLimit) {
+		t.Fatalf("expected ErrInvalidLimit, got %v", err)
+	}
+	if err := validateLimits(PeriodMonthly, 0, 0, 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExhaustedErrorMessage(t *testing.T) {
+	err := &ExhaustedError{}
+	if err.Message() != DefaultExhaustedMessage {
+		t.Fatalf("expected default message, got %q", err.Message())
+	}
+	err.Allowance.Rule.ExhaustedMessage = "Out of credit"
+	if err.Message() != "Out of credit" {
+		t.Fatalf("expected custom message, got %q", err.Message())
+	}
+}
@@ -0,0 +1,132 @@
+package budget
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ScopeBot             = "bot"
+	ScopeUser            = "user"
+	ScopeChannelIdentity = "channel_identity"
+
+	PeriodDaily   = "daily"
+	PeriodMonthly = "monthly"
+
+	// DefaultExhaustedMessage is replied when a rule has no custom message.
+	DefaultExhaustedMessage = "The token budget for this conversation has been used up. Please try again after it resets."
+)
+
+var (
+	ErrInvalidScope   = errors.New("scope must be one of bot, user, channel_identity")
+	ErrInvalidPeriod  = errors.New("period must be daily or monthly")
+	ErrInvalidSubject = errors.New("subject does not match scope")
+	ErrInvalidLimit   = errors.New("token limits must not be negative")
+	ErrNoLimit        = errors.New("at least one token limit is required")
+)
+
+// Rule caps input, output, and/or total tokens over a rolling calendar
+// period. A limit of zero means that dimension is not capped. User and
+// channel identity rules without a subject apply to each subject separately.
+type Rule struct {
+	ID                string    `json:"id"`
+	BotID             string    `json:"bot_id"`
+	Scope             string    `json:"scope"`
+	UserID            string    `json:"user_id,omitempty"`
+	ChannelIdentityID string    `json:"channel_identity_id,omitempty"`
+	Period            string    `json:"period"`
+	MaxInputTokens    int64     `json:"max_input_tokens"`
+	MaxOutputTokens   int64     `json:"max_output_tokens"`
+	MaxTotalTokens    int64     `json:"max_total_tokens"`
+	ExhaustedMessage  string    `json:"exhausted_message"`
+	Enabled           bool      `json:"enabled"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type CreateRequest struct {
+	Scope             string `json:"scope"`
+	UserID            string `json:"user_id,omitempty"`
+	ChannelIdentityID string `json:"channel_identity_id,omitempty"`
+	Period            string `json:"period"`
+	MaxInputTokens    int64  `json:"max_input_tokens"`
+	MaxOutputTokens   int64  `json:"max_output_tokens"`
+	MaxTotalTokens    int64  `json:"max_total_tokens"`
+	ExhaustedMessage  string `json:"exhausted_message"`
+	Enabled           *bool  `json:"enabled,omitempty"`
+}
+
+type UpdateRequest struct {
+	Period           *string `json:"period,omitempty"`
+	MaxInputTokens   *int64  `json:"max_input_tokens,omitempty"`
+	MaxOutputTokens  *int64  `json:"max_output_tokens,omitempty"`
+	MaxTotalTokens   *int64  `json:"max_total_tokens,omitempty"`
+	ExhaustedMessage *string `json:"exhausted_message,omitempty"`
+	Enabled          *bool   `json:"enabled,omitempty"`
+}
+
+type ListResponse struct {
+	Items []Rule `json:"items"`
+}
+
+// Subject identifies who a turn is billed to.
+type Subject struct {
+	UserID            string
+	ChannelIdentityID string
+}
+
+// Usage is a token count pair.
+type Usage struct {
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+}
+
+// Total returns input plus output tokens.
+func (u Usage) Total() int64 {
+	return u.InputTokens + u.OutputTokens
+}
+
+// Allowance reports consumption against one rule in its current period.
+// Remaining values are nil for dimensions the rule does not cap.
+type Allowance struct {
+	Rule                  Rule      `json:"rule"`
+	PeriodStart           time.Time `json:"period_start"`
+	ResetsAt              time.Time `json:"resets_at"`
+	Used                  Usage     `json:"used"`
+	RemainingInputTokens  *int64    `json:"remaining_input_tokens,omitempty"`
+	RemainingOutputTokens *int64    `json:"remaining_output_tokens,omitempty"`
+	RemainingTotalTokens  *int64    `json:"remaining_total_tokens,omitempty"`
+	Exhausted             bool      `json:"exhausted"`
+}
+
+type AllowanceResponse struct {
+	Items     []Allowance `json:"items"`
+	Exhausted bool        `json:"exhausted"`
+}
+
+// UsageRecord is one turn's token consumption written to the ledger.
+type UsageRecord struct {
+	BotID             string
+	SessionID         string
+	UserID            string
+	ChannelIdentityID string
+	ModelID           string
+	Usage             Usage
+}
+
+// ExhaustedError is returned by Check when a budget rule is exhausted.
+type ExhaustedError struct {
+	Allowance Allowance
+}
+
+func (e *ExhaustedError) Error() string {
+	return "token budget exhausted (" + e.Allowance.Rule.Scope + ", " + e.Allowance.Rule.Period + ")"
+}
+
+// Message returns the reply to send when the budget is exhausted.
+func (e *ExhaustedError) Message() string {
+	if e.Allowance.Rule.ExhaustedMessage != "" {
+		return e.Allowance.Rule.ExhaustedMessage
+	}
+	return DefaultExhaustedMessage
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrSendQueueFull is returned when a SendQueue cannot take more items.
+var ErrSendQueueFull = errors.New("send queue full")
+
+// SendQueue writes outbound items through a single goroutine, pacing them
+// with a token bucket so line-based protocols (IRC, XMPP) stay under server
+// flood limits. Enqueue never blocks on the network.
+type SendQueue struct {
+	limiter *rate.Limiter
+	items   chan string
+	mu      sync.Mutex // keeps one Enqueue call's items together
+}
+
+// NewSendQueue creates a queue that lets burst items out at once and then one
+// item per interval, holding at most size pending items.
+func NewSendQueue(burst int, interval time.Duration, size int) *SendQueue {
+	if burst <= 0 {
+		burst = 1
+	}
+	if size <= 0 {
+		size = 256
+	}
+	limit := rate.Inf
+	if interval > 0 {
+		limit = rate.Every(interval)
+	}
+	return &SendQueue{
+		limiter: rate.NewLimiter(limit, burst),
+		items:   make(chan string, size),
+	}
+}
+
+// Enqueue adds items in order. Either all of them are queued or none are.
+func (q *SendQueue) Enqueue(items ...string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(items) > cap(q.items)-len(q.items) {
+		return ErrSendQueueFull
+	}
+	for _, item := range items {
+		select {
+		case q.items <- item:
+		default:
+			return ErrSendQueueFull
+		}
+	}
+	return nil
+}
+
+// Run writes queued items until ctx ends or write fails, and returns the
+// write error.
+func (q *SendQueue) Run(ctx context.Context, write func(string) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case item := <-q.items:
+			if err := q.limiter.Wait(ctx); err != nil {
+				return nil
+			}
+			if err := write(item); err != nil {
+				return err
+			}
+		}
+	}
+}
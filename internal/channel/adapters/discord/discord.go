@@ -77,6 +77,8 @@ func (*DiscordAdapter) Descriptor() channel.Descriptor {
 			Streaming:      true,
 			BlockStreaming: true,
 			Reactions:      true,
+			Buttons:        true,
+			Polls:          true,
 		},
 		ConfigSchema: channel.ConfigSchema{
 			Version: 1,
@@ -239,14 +241,27 @@ func (a *DiscordAdapter) Connect(ctx context.Context, cfg channel.ChannelConfig,
 			)
 		}
 
-		go func() {
-			if err := handler(ctx, cfg, msg); err != nil && a.logger != nil {
-				a.logger.Error("handle inbound failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
-			}
-		}()
+		a.dispatchInbound(ctx, cfg, handler, msg)
+	})
+
+	removeInteraction := session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if ctx.Err() != nil {
+			return
+		}
+		a.handleDiscordInteraction(ctx, s, cfg, handler, i)
+	})
+	removePollVote := session.AddHandler(func(s *discordgo.Session, vote *discordgo.MessagePollVoteAdd) {
+		if ctx.Err() != nil {
+			return
+		}
+		a.handleDiscordPollVote(ctx, s, cfg, handler, vote)
 	})
 
-	a.swapHandlerRemover(discordCfg.BotToken, remove)
+	a.swapHandlerRemover(discordCfg.BotToken, func() {
+		remove()
+		removeInteraction()
+		removePollVote()
+	})
 
 	if err := session.Open(); err != nil {
 		return nil, fmt.Errorf("discord open connection: %w", err)
@@ -323,8 +338,18 @@ func (a *DiscordAdapter) sendDiscordMessage(ctx context.Context, session *discor
 		}
 	}
 
-	// Validate: must have content or files
-	if messageSend.Content == "" && len(messageSend.Files) == 0 {
+	if len(msg.Message.Actions) > 0 {
+		messageSend.Components = buildDiscordComponents(msg.Message.Actions)
+		if messageSend.Content == "" && len(messageSend.Components) > 0 {
+			messageSend.Content = "\u200b"
+		}
+	}
+	if msg.Message.Poll != nil {
+		messageSend.Poll = buildDiscordPoll(*msg.Message.Poll)
+	}
+
+	// Validate: must have content, files or a poll
+	if messageSend.Content == "" && len(messageSend.Files) == 0 && messageSend.Poll == nil {
 		return errors.New("cannot send empty message: no content and no valid attachments")
 	}
 
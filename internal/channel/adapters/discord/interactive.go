@@ -0,0 +1,245 @@
+package discord
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const (
+	discordButtonsPerRow     = 5
+	discordMaxButtonRows     = 5
+	discordCustomIDMaxLength = 100
+	discordButtonLabelMax    = 80
+	discordPollAnswerMax     = 55
+	discordPollQuestionMax   = 300
+	discordPollDurationHours = 24
+)
+
+// buildDiscordComponents renders actions as button rows. Custom IDs carry the
+// button index, which keeps them unique, followed by the value.
+func buildDiscordComponents(actions []channel.Action) []discordgo.MessageComponent {
+	var (
+		rows    []discordgo.MessageComponent
+		current []discordgo.MessageComponent
+	)
+	flush := func() {
+		if len(current) > 0 {
+			rows = append(rows, discordgo.ActionsRow{Components: current})
+			current = nil
+		}
+	}
+	for i, action := range actions {
+		label := strings.TrimSpace(action.Label)
+		value := strings.TrimSpace(action.Value)
+		if label == "" {
+			label = value
+		}
+		if label == "" {
+			continue
+		}
+		button := discordgo.Button{Label: truncateRunes(label, discordButtonLabelMax)}
+		if link := strings.TrimSpace(action.URL); link != "" {
+			button.Style = discordgo.LinkButton
+			button.URL = link
+		} else {
+			if value == "" {
+				value = label
+			}
+			button.Style = discordgo.PrimaryButton
+			button.CustomID = truncateRunes(strconv.Itoa(i)+":"+value, discordCustomIDMaxLength)
+		}
+		current = append(current, button)
+		if len(current) == discordButtonsPerRow {
+			flush()
+		}
+		if len(rows) == discordMaxButtonRows {
+			break
+		}
+	}
+	flush()
+	return rows
+}
+
+func buildDiscordPoll(poll channel.Poll) *discordgo.Poll {
+	answers := make([]discordgo.PollAnswer, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		answers = append(answers, discordgo.PollAnswer{Media: &discordgo.PollMedia{Text: truncateRunes(option, discordPollAnswerMax)}})
+	}
+	return &discordgo.Poll{
+		Question:         discordgo.PollMedia{Text: truncateRunes(strings.TrimSpace(poll.Question), discordPollQuestionMax)},
+		Answers:          answers,
+		AllowMultiselect: poll.MultipleAnswers,
+		Duration:         discordPollDurationHours,
+	}
+}
+
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return string([]rune(text)[:limit])
+}
+
+// handleDiscordInteraction acknowledges a button click without changing the
+// message and forwards it as an interaction on that message.
+func (a *DiscordAdapter) handleDiscordInteraction(ctx context.Context, s *discordgo.Session, cfg channel.ChannelConfig, handler channel.InboundHandler, i *discordgo.InteractionCreate) {
+	if i == nil || i.Interaction == nil || i.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	}); err != nil && a.logger != nil {
+		a.logger.Warn("acknowledge interaction failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+	}
+	msg, ok := buildDiscordComponentMessage(i.Interaction)
+	if !ok {
+		return
+	}
+	msg.BotID = cfg.BotID
+	a.dispatchInbound(ctx, cfg, handler, msg)
+}
+
+func buildDiscordComponentMessage(i *discordgo.Interaction) (channel.InboundMessage, bool) {
+	if i.Message == nil {
+		return channel.InboundMessage{}, false
+	}
+	user := i.User
+	if i.Member != nil && i.Member.User != nil {
+		user = i.Member.User
+	}
+	if user == nil || user.Bot {
+		return channel.InboundMessage{}, false
+	}
+	customID := i.MessageComponentData().CustomID
+	_, value, found := strings.Cut(customID, ":")
+	if !found || strings.TrimSpace(value) == "" {
+		return channel.InboundMessage{}, false
+	}
+	msg := buildDiscordInteractionMessage(user, i.ChannelID, i.GuildID, channel.Interaction{
+		Type:      channel.InteractionButton,
+		MessageID: i.Message.ID,
+		Value:     value,
+		Label:     findDiscordButtonLabel(i.Message.Components, customID),
+	})
+	msg.Message.ID = "interaction:" + i.ID
+	msg.Message.Text = value
+	msg.Metadata["raw_text"] = value
+	return msg, true
+}
+
+func findDiscordButtonLabel(components []discordgo.MessageComponent, customID string) string {
+	for _, component := range components {
+		switch c := component.(type) {
+		case *discordgo.ActionsRow:
+			if label := findDiscordButtonLabel(c.Components, customID); label != "" {
+				return label
+			}
+		case *discordgo.Button:
+			if c.CustomID == customID {
+				return c.Label
+			}
+		}
+	}
+	return ""
+}
+
+// handleDiscordPollVote forwards a vote on a poll the bot sent. Discord
+// reports each selected answer separately, so a multi-select vote arrives as
+// several interactions.
+func (a *DiscordAdapter) handleDiscordPollVote(ctx context.Context, s *discordgo.Session, cfg channel.ChannelConfig, handler channel.InboundHandler, vote *discordgo.MessagePollVoteAdd) {
+	if vote == nil || s.State == nil || s.State.User == nil || vote.UserID == s.State.User.ID {
+		return
+	}
+	pollMsg, err := s.ChannelMessage(vote.ChannelID, vote.MessageID)
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Warn("fetch poll message failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+		return
+	}
+	if pollMsg.Poll == nil || pollMsg.Author == nil || pollMsg.Author.ID != s.State.User.ID {
+		return
+	}
+	user, err := s.User(vote.UserID)
+	if err != nil || user.Bot {
+		return
+	}
+	msg, ok := buildDiscordPollVoteMessage(user, vote, pollMsg.Poll)
+	if !ok {
+		return
+	}
+	msg.BotID = cfg.BotID
+	a.dispatchInbound(ctx, cfg, handler, msg)
+}
+
+func buildDiscordPollVoteMessage(user *discordgo.User, vote *discordgo.MessagePollVoteAdd, poll *discordgo.Poll) (channel.InboundMessage, bool) {
+	answer := ""
+	for _, item := range poll.Answers {
+		if item.AnswerID == vote.AnswerID && item.Media != nil {
+			answer = item.Media.Text
+		}
+	}
+	if answer == "" {
+		return channel.InboundMessage{}, false
+	}
+	msg := buildDiscordInteractionMessage(user, vote.ChannelID, vote.GuildID, channel.Interaction{
+		Type:      channel.InteractionPoll,
+		MessageID: vote.MessageID,
+		Options:   []string{answer},
+	})
+	msg.Message.ID = "poll:" + vote.MessageID + ":" + user.ID + ":" + strconv.Itoa(vote.AnswerID)
+	msg.Message.Text = answer
+	return msg, true
+}
+
+func buildDiscordInteractionMessage(user *discordgo.User, channelID, guildID string, interaction channel.Interaction) channel.InboundMessage {
+	chatType := channel.ConversationTypePrivate
+	if guildID != "" {
+		chatType = channel.ConversationTypeGroup
+	}
+	return channel.InboundMessage{
+		Channel: Type,
+		Message: channel.Message{
+			Format: channel.MessageFormatPlain,
+			Reply:  &channel.ReplyRef{Target: channelID, MessageID: interaction.MessageID},
+		},
+		ReplyTarget: channelID,
+		Sender: channel.Identity{
+			SubjectID:   user.ID,
+			DisplayName: user.Username,
+			Attributes: map[string]string{
+				"user_id":  user.ID,
+				"username": user.Username,
+			},
+		},
+		Conversation: channel.Conversation{
+			ID:   channelID,
+			Type: chatType,
+		},
+		ReceivedAt:  time.Now().UTC(),
+		Source:      "discord",
+		Interaction: &interaction,
+		Metadata: map[string]any{
+			"guild_id": guildID,
+			// Clicks and votes answer the bot's own message.
+			"is_mentioned":    true,
+			"is_reply_to_bot": true,
+		},
+	}
+}
+
+func (a *DiscordAdapter) dispatchInbound(ctx context.Context, cfg channel.ChannelConfig, handler channel.InboundHandler, msg channel.InboundMessage) {
+	go func() {
+		if err := handler(ctx, cfg, msg); err != nil && a.logger != nil {
+			a.logger.Error("handle inbound failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+	}()
+}
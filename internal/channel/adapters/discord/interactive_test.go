@@ -0,0 +1,82 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+func TestBuildDiscordComponents(t *testing.T) {
+	actions := make([]channel.Action, 0, 7)
+	for _, label := range []string{"A", "B", "C", "D", "E", "F"} {
+		actions = append(actions, channel.Action{Label: label, Value: "v" + label})
+	}
+	actions = append(actions, channel.Action{Label: "Docs", URL: "https://example.com"})
+
+	rows := buildDiscordComponents(actions)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	first := rows[0].(discordgo.ActionsRow).Components[0].(discordgo.Button)
+	if first.CustomID != "0:vA" || first.Style != discordgo.PrimaryButton {
+		t.Fatalf("unexpected first button: %#v", first)
+	}
+	link := rows[1].(discordgo.ActionsRow).Components[1].(discordgo.Button)
+	if link.URL != "https://example.com" || link.CustomID != "" || link.Style != discordgo.LinkButton {
+		t.Fatalf("unexpected link button: %#v", link)
+	}
+}
+
+func TestBuildDiscordComponentMessage(t *testing.T) {
+	interaction := &discordgo.Interaction{
+		ID:        "i1",
+		Type:      discordgo.InteractionMessageComponent,
+		ChannelID: "c1",
+		GuildID:   "g1",
+		Member:    &discordgo.Member{User: &discordgo.User{ID: "u1", Username: "alice"}},
+		Message: &discordgo.Message{
+			ID: "m1",
+			Components: []discordgo.MessageComponent{
+				&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+					&discordgo.Button{Label: "Approve", CustomID: "0:/approve 1"},
+				}},
+			},
+		},
+		Data: discordgo.MessageComponentInteractionData{CustomID: "0:/approve 1"},
+	}
+	msg, ok := buildDiscordComponentMessage(interaction)
+	if !ok {
+		t.Fatal("expected component message")
+	}
+	if msg.Message.Text != "/approve 1" || msg.ReplyTarget != "c1" || msg.Conversation.Type != channel.ConversationTypeGroup {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if msg.Interaction == nil || msg.Interaction.MessageID != "m1" || msg.Interaction.Label != "Approve" || msg.Interaction.Value != "/approve 1" {
+		t.Fatalf("unexpected interaction: %#v", msg.Interaction)
+	}
+}
+
+func TestBuildDiscordPollVoteMessage(t *testing.T) {
+	poll := &discordgo.Poll{Answers: []discordgo.PollAnswer{
+		{AnswerID: 1, Media: &discordgo.PollMedia{Text: "Pizza"}},
+		{AnswerID: 2, Media: &discordgo.PollMedia{Text: "Sushi"}},
+	}}
+	vote := &discordgo.MessagePollVoteAdd{UserID: "u1", ChannelID: "c1", MessageID: "m1", AnswerID: 2}
+	msg, ok := buildDiscordPollVoteMessage(&discordgo.User{ID: "u1"}, vote, poll)
+	if !ok {
+		t.Fatal("expected poll vote message")
+	}
+	if msg.Message.Text != "Sushi" || msg.Conversation.Type != channel.ConversationTypePrivate {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if msg.Interaction == nil || msg.Interaction.Type != channel.InteractionPoll || msg.Interaction.MessageID != "m1" {
+		t.Fatalf("unexpected interaction: %#v", msg.Interaction)
+	}
+
+	vote.AnswerID = 9
+	if _, ok := buildDiscordPollVoteMessage(&discordgo.User{ID: "u1"}, vote, poll); ok {
+		t.Fatal("expected unknown answer to be ignored")
+	}
+}
@@ -16,6 +16,7 @@ import (
 	lark "github.com/larksuite/oapi-sdk-go/v3"
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher"
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher/callback"
 	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
 	larkws "github.com/larksuite/oapi-sdk-go/v3/ws"
 
@@ -138,6 +139,7 @@ func (*FeishuAdapter) Descriptor() channel.Descriptor {
 			Reply:          true,
 			Streaming:      true,
 			BlockStreaming: true,
+			Buttons:        true,
 		},
 		ConfigSchema: channel.ConfigSchema{
 			Version: 2,
@@ -455,6 +457,23 @@ func (a *FeishuAdapter) Connect(ctx context.Context, cfg channel.ChannelConfig,
 			}()
 			return nil
 		})
+		eventDispatcher.OnP2CardActionTrigger(func(_ context.Context, event *callback.CardActionTriggerEvent) (*callback.CardActionTriggerResponse, error) {
+			if connCtx.Err() != nil {
+				return nil, nil
+			}
+			msg, ok := extractFeishuCardAction(event)
+			if !ok {
+				return nil, nil
+			}
+			msg.BotID = cfg.BotID
+			// Reply to the callback right away; the agent answers in the chat.
+			go func() {
+				if err := handler(connCtx, cfg, msg); err != nil && a.logger != nil {
+					a.logger.Error("handle card action failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+				}
+			}()
+			return nil, nil
+		})
 		eventDispatcher.OnP2MessageReadV1(func(_ context.Context, _ *larkim.P2MessageReadV1) error {
 			return nil
 		})
@@ -535,13 +554,22 @@ func (a *FeishuAdapter) Send(ctx context.Context, cfg channel.ChannelConfig, msg
 				return err
 			}
 		}
-		return nil
+		if len(msg.Message.Actions) == 0 {
+			return nil
+		}
 	}
 
 	var msgType string
 	var content string
 
-	if len(msg.Message.Parts) > 1 {
+	if len(msg.Message.Actions) > 0 {
+		msgType = larkim.MsgTypeInteractive
+		cardContent, cardErr := buildFeishuActionCardContent(msg.Message.PlainText(), msg.Message.Actions, receiveType)
+		if cardErr != nil {
+			return fmt.Errorf("failed to build card content: %w", cardErr)
+		}
+		content = cardContent
+	} else if len(msg.Message.Parts) > 1 {
 		msgType = larkim.MsgTypePost
 		postContent, postErr := a.buildPostContent(msg.Message)
 		if postErr != nil {
@@ -0,0 +1,159 @@
+package feishu
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher/callback"
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+// Keys of the value map attached to card buttons. Card callbacks carry no
+// chat type, so it is stored on the button when the card is sent.
+const (
+	feishuCardValueKey    = "memoh_value"
+	feishuCardLabelKey    = "memoh_label"
+	feishuCardChatTypeKey = "chat_type"
+)
+
+// buildFeishuActionCardContent renders text and actions as an interactive
+// card with one button per action.
+func buildFeishuActionCardContent(text string, actions []channel.Action, receiveType string) (string, error) {
+	chatType := "p2p"
+	if receiveType == larkim.ReceiveIdTypeChatId {
+		chatType = "group"
+	}
+	buttons := make([]map[string]any, 0, len(actions))
+	for _, action := range actions {
+		label := strings.TrimSpace(action.Label)
+		value := strings.TrimSpace(action.Value)
+		if label == "" {
+			label = value
+		}
+		if label == "" {
+			continue
+		}
+		button := map[string]any{
+			"tag":  "button",
+			"text": map[string]any{"tag": "plain_text", "content": label},
+			"type": "default",
+		}
+		if link := strings.TrimSpace(action.URL); link != "" {
+			button["url"] = link
+		} else {
+			if value == "" {
+				value = label
+			}
+			button["type"] = "primary"
+			button["value"] = map[string]any{
+				feishuCardValueKey:    value,
+				feishuCardLabelKey:    label,
+				feishuCardChatTypeKey: chatType,
+			}
+		}
+		buttons = append(buttons, button)
+	}
+	elements := make([]map[string]any, 0, 2)
+	if body := strings.TrimSpace(text); body != "" {
+		elements = append(elements, map[string]any{
+			"tag":  "div",
+			"text": map[string]any{"tag": "lark_md", "content": processFeishuCardMarkdown(body)},
+		})
+	}
+	if len(buttons) > 0 {
+		elements = append(elements, map[string]any{"tag": "action", "actions": buttons})
+	}
+	card := map[string]any{
+		"config":   map[string]any{"wide_screen_mode": true, "enable_forward": true},
+		"elements": elements,
+	}
+	data, err := json.Marshal(card)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// extractFeishuCardAction turns a card button click into an interaction on
+// the message that carried the card.
+func extractFeishuCardAction(event *callback.CardActionTriggerEvent) (channel.InboundMessage, bool) {
+	if event == nil || event.Event == nil || event.Event.Action == nil || event.Event.Operator == nil || event.Event.Context == nil {
+		return channel.InboundMessage{}, false
+	}
+	values := event.Event.Action.Value
+	value := strings.TrimSpace(stringValue(values[feishuCardValueKey]))
+	if value == "" {
+		return channel.InboundMessage{}, false
+	}
+	label := strings.TrimSpace(stringValue(values[feishuCardLabelKey]))
+	chatTypeRaw := strings.TrimSpace(stringValue(values[feishuCardChatTypeKey]))
+	chatType := normalizeFeishuConversationType(chatTypeRaw)
+
+	operator := event.Event.Operator
+	openID := strings.TrimSpace(operator.OpenID)
+	userID := ""
+	if operator.UserID != nil {
+		userID = strings.TrimSpace(*operator.UserID)
+	}
+	subjectID := openID
+	if subjectID == "" {
+		subjectID = userID
+	}
+	if subjectID == "" {
+		return channel.InboundMessage{}, false
+	}
+	attrs := map[string]string{}
+	if userID != "" {
+		attrs["user_id"] = userID
+	}
+	if openID != "" {
+		attrs["open_id"] = openID
+	}
+
+	chatID := strings.TrimSpace(event.Event.Context.OpenChatID)
+	messageID := strings.TrimSpace(event.Event.Context.OpenMessageID)
+	replyTo := subjectID
+	if chatID != "" && chatType != channel.ConversationTypePrivate {
+		replyTo = "chat_id:" + chatID
+	}
+	msgID := ""
+	if event.EventV2Base != nil && event.EventV2Base.Header != nil {
+		msgID = "card_action:" + event.EventV2Base.Header.EventID
+	}
+	return channel.InboundMessage{
+		Channel: Type,
+		Message: channel.Message{
+			ID:     msgID,
+			Format: channel.MessageFormatPlain,
+			Text:   value,
+			Reply:  &channel.ReplyRef{MessageID: messageID},
+		},
+		ReplyTarget: replyTo,
+		Sender: channel.Identity{
+			SubjectID:  subjectID,
+			Attributes: attrs,
+		},
+		Conversation: channel.Conversation{
+			ID:   chatID,
+			Type: chatType,
+		},
+		ReceivedAt: time.Now().UTC(),
+		Source:     "feishu",
+		Interaction: &channel.Interaction{
+			Type:      channel.InteractionButton,
+			MessageID: messageID,
+			Value:     value,
+			Label:     label,
+		},
+		Metadata: map[string]any{
+			// Clicks answer the bot's own card.
+			"is_mentioned":    true,
+			"is_reply_to_bot": true,
+			"raw_chat_type":   chatTypeRaw,
+			"raw_text":        value,
+		},
+	}, true
+}
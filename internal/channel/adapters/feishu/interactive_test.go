@@ -0,0 +1,74 @@
+package feishu
+
+import (
+	"encoding/json"
+	"testing"
+
+	larkevent "github.com/larksuite/oapi-sdk-go/v3/event"
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher/callback"
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+func TestBuildFeishuActionCardContent(t *testing.T) {
+	content, err := buildFeishuActionCardContent("Approve?", []channel.Action{
+		{Label: "Approve", Value: "/approve 1"},
+		{Label: "Docs", URL: "https://example.com"},
+	}, larkim.ReceiveIdTypeChatId)
+	if err != nil {
+		t.Fatalf("build card: %v", err)
+	}
+	var card struct {
+		Elements []struct {
+			Tag     string `json:"tag"`
+			Actions []struct {
+				URL   string         `json:"url"`
+				Value map[string]any `json:"value"`
+			} `json:"actions"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal([]byte(content), &card); err != nil {
+		t.Fatalf("unmarshal card: %v", err)
+	}
+	if len(card.Elements) != 2 || card.Elements[1].Tag != "action" || len(card.Elements[1].Actions) != 2 {
+		t.Fatalf("unexpected card: %s", content)
+	}
+	value := card.Elements[1].Actions[0].Value
+	if value[feishuCardValueKey] != "/approve 1" || value[feishuCardChatTypeKey] != "group" {
+		t.Fatalf("unexpected button value: %v", value)
+	}
+	if link := card.Elements[1].Actions[1]; link.URL != "https://example.com" || link.Value != nil {
+		t.Fatalf("unexpected link button: %+v", link)
+	}
+}
+
+func TestExtractFeishuCardAction(t *testing.T) {
+	event := &callback.CardActionTriggerEvent{
+		EventV2Base: &larkevent.EventV2Base{Header: &larkevent.EventHeader{EventID: "ev1"}},
+		Event: &callback.CardActionTriggerRequest{
+			Operator: &callback.Operator{OpenID: "ou_1"},
+			Action: &callback.CallBackAction{Value: map[string]any{
+				feishuCardValueKey:    "/approve 1",
+				feishuCardLabelKey:    "Approve",
+				feishuCardChatTypeKey: "group",
+			}},
+			Context: &callback.Context{OpenMessageID: "om_1", OpenChatID: "oc_1"},
+		},
+	}
+	msg, ok := extractFeishuCardAction(event)
+	if !ok {
+		t.Fatal("expected card action message")
+	}
+	if msg.Message.Text != "/approve 1" || msg.Message.ID != "card_action:ev1" || msg.ReplyTarget != "chat_id:oc_1" {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+	if msg.Interaction == nil || msg.Interaction.MessageID != "om_1" || msg.Interaction.Label != "Approve" {
+		t.Fatalf("unexpected interaction: %+v", msg.Interaction)
+	}
+
+	event.Event.Action.Value = map[string]any{"other": "x"}
+	if _, ok := extractFeishuCardAction(event); ok {
+		t.Fatal("expected foreign card actions to be ignored")
+	}
+}
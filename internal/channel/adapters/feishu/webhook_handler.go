@@ -12,6 +12,7 @@ import (
 	"github.com/labstack/echo/v4"
 	larkevent "github.com/larksuite/oapi-sdk-go/v3/event"
 	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher"
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher/callback"
 	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
 
 	"github.com/memohai/memoh/internal/channel"
@@ -121,6 +122,21 @@ func (h *WebhookHandler) Handle(c echo.Context) error {
 		msg.BotID = cfg.BotID
 		return h.manager.HandleInbound(reqCtx, cfg, msg)
 	})
+	eventDispatcher.OnP2CardActionTrigger(func(_ context.Context, event *callback.CardActionTriggerEvent) (*callback.CardActionTriggerResponse, error) {
+		msg, ok := extractFeishuCardAction(event)
+		if !ok {
+			return nil, nil
+		}
+		msg.BotID = cfg.BotID
+		// Feishu expects the callback answered within 3 seconds, so the click
+		// is handled after the response is written.
+		go func() {
+			if err := h.manager.HandleInbound(context.WithoutCancel(reqCtx), cfg, msg); err != nil {
+				h.logger.Error("handle card action failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+			}
+		}()
+		return nil, nil
+	})
 
 	resp := eventDispatcher.Handle(c.Request().Context(), &larkevent.EventReq{
 		Header:     c.Request().Header,
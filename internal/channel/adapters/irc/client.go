@@ -0,0 +1,351 @@
+package irc
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/channel/adapters/common"
+)
+
+const (
+	ircDialTimeout   = 15 * time.Second
+	ircWriteTimeout  = 10 * time.Second
+	ircPingInterval  = 90 * time.Second
+	ircReadTimeout   = 4 * time.Minute
+	ircMaxLineLength = 8192 // IRCv3 tags may push lines past 512 bytes
+)
+
+var ircReconnectBackoffs = []time.Duration{time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 30 * time.Second}
+
+// ircMessage is one parsed protocol line:
+// [@tags] [:prefix] COMMAND [params...] [:trailing].
+type ircMessage struct {
+	Tags    map[string]string
+	Prefix  string
+	Command string
+	Params  []string
+}
+
+func parseMessage(line string) ircMessage {
+	var msg ircMessage
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "@") {
+		raw, rest, _ := strings.Cut(line[1:], " ")
+		msg.Tags = make(map[string]string)
+		for _, tag := range strings.Split(raw, ";") {
+			key, value, _ := strings.Cut(tag, "=")
+			msg.Tags[key] = value
+		}
+		line = strings.TrimLeft(rest, " ")
+	}
+	if strings.HasPrefix(line, ":") {
+		msg.Prefix, line, _ = strings.Cut(line[1:], " ")
+		line = strings.TrimLeft(line, " ")
+	}
+	for line != "" {
+		if strings.HasPrefix(line, ":") {
+			msg.Params = append(msg.Params, line[1:])
+			break
+		}
+		var param string
+		param, line, _ = strings.Cut(line, " ")
+		line = strings.TrimLeft(line, " ")
+		if msg.Command == "" {
+			msg.Command = strings.ToUpper(param)
+			continue
+		}
+		msg.Params = append(msg.Params, param)
+	}
+	return msg
+}
+
+// param returns the i-th parameter or "".
+func (m ircMessage) param(i int) string {
+	if i < len(m.Params) {
+		return m.Params[i]
+	}
+	return ""
+}
+
+// nick returns the nick part of a nick!user@host prefix.
+func (m ircMessage) nick() string {
+	nick, _, _ := strings.Cut(m.Prefix, "!")
+	return nick
+}
+
+// ircSession owns one config's server connection. The send queue outlives
+// individual connections, so lines queued during a reconnect go out once the
+// client is registered again.
+type ircSession struct {
+	logger  *slog.Logger
+	cfg     channel.ChannelConfig
+	parsed  Config
+	handler channel.InboundHandler
+	queue   *common.SendQueue
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	nick    string
+	counter uint64
+}
+
+func newIRCSession(logger *slog.Logger, cfg channel.ChannelConfig, parsed Config, handler channel.InboundHandler) *ircSession {
+	return &ircSession{
+		logger:  logger,
+		cfg:     cfg,
+		parsed:  parsed,
+		handler: handler,
+		queue:   common.NewSendQueue(ircSendBurst, ircSendInterval, ircSendQueueLen),
+		done:    make(chan struct{}),
+		nick:    parsed.Nick,
+	}
+}
+
+func (s *ircSession) stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	<-s.done
+}
+
+func (s *ircSession) currentNick() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nick
+}
+
+func (s *ircSession) setNick(nick string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nick = nick
+}
+
+func (s *ircSession) sendText(target, text string) error {
+	lines := privmsgLines(target, text)
+	if len(lines) == 0 {
+		return nil
+	}
+	if err := s.queue.Enqueue(lines...); err != nil {
+		return fmt.Errorf("irc send to %s: %w", target, err)
+	}
+	return nil
+}
+
+// run keeps the connection up until ctx ends, reconnecting with backoff.
+func (s *ircSession) run(ctx context.Context) {
+	attempt := 0
+	for ctx.Err() == nil {
+		healthy, err := s.connectOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if healthy {
+			attempt = 0
+		}
+		delay := ircReconnectBackoffs[min(attempt, len(ircReconnectBackoffs)-1)]
+		attempt++
+		if s.logger != nil {
+			s.logger.Warn("reconnect", slog.String("config_id", s.cfg.ID), slog.Duration("delay", delay), slog.Any("error", err))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+type lineWriter struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (w *lineWriter) writeLine(line string) error {
+	line = strings.NewReplacer("\r", "", "\n", "", "\x00", "").Replace(line)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.conn.SetWriteDeadline(time.Now().Add(ircWriteTimeout)); err != nil {
+		return err
+	}
+	_, err := w.conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+func (s *ircSession) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: ircDialTimeout, KeepAlive: 30 * time.Second}
+	if !s.parsed.TLS {
+		return dialer.DialContext(ctx, "tcp", s.parsed.Server)
+	}
+	host, _, _ := net.SplitHostPort(s.parsed.Server)
+	tlsDialer := &tls.Dialer{
+		NetDialer: dialer,
+		Config:    &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12},
+	}
+	return tlsDialer.DialContext(ctx, "tcp", s.parsed.Server)
+}
+
+// connectOnce runs a single connection. healthy reports whether registration
+// completed, which resets the reconnect backoff.
+func (s *ircSession) connectOnce(ctx context.Context) (healthy bool, err error) {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return false, err
+	}
+	connCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		_ = conn.Close()
+		wg.Wait()
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-connCtx.Done()
+		_ = conn.Close()
+	}()
+
+	w := &lineWriter{conn: conn}
+	s.setNick(s.parsed.Nick)
+	if s.parsed.SASLUsername != "" {
+		if err := w.writeLine("CAP REQ :sasl"); err != nil {
+			return false, err
+		}
+	}
+	if s.parsed.Password != "" {
+		if err := w.writeLine("PASS :" + s.parsed.Password); err != nil {
+			return false, err
+		}
+	}
+	if err := w.writeLine("NICK " + s.parsed.Nick); err != nil {
+		return false, err
+	}
+	if err := w.writeLine("USER " + s.parsed.Username + " 0 * :" + s.parsed.RealName); err != nil {
+		return false, err
+	}
+
+	reader := bufio.NewReaderSize(conn, ircMaxLineLength)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(ircReadTimeout)); err != nil {
+			return healthy, err
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return healthy, err
+		}
+		msg := parseMessage(line)
+		switch msg.Command {
+		case "PING":
+			err = w.writeLine("PONG :" + msg.param(0))
+		case "CAP":
+			err = s.handleCap(w, msg)
+		case "AUTHENTICATE":
+			if msg.param(0) == "+" {
+				payload := "\x00" + s.parsed.SASLUsername + "\x00" + s.parsed.SASLPassword
+				err = w.writeLine("AUTHENTICATE " + base64.StdEncoding.EncodeToString([]byte(payload)))
+			}
+		case "903": // RPL_SASLSUCCESS
+			err = w.writeLine("CAP END")
+		case "902", "904", "905", "906", "908":
+			return false, fmt.Errorf("irc sasl authentication failed: %s", lastParam(msg))
+		case "001": // RPL_WELCOME
+			healthy = true
+			if nick := msg.param(0); nick != "" {
+				s.setNick(nick)
+			}
+			if s.logger != nil {
+				s.logger.Info("registered", slog.String("config_id", s.cfg.ID), slog.String("nick", s.currentNick()))
+			}
+			for _, name := range s.parsed.Channels {
+				if err = w.writeLine("JOIN " + name); err != nil {
+					break
+				}
+			}
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if err := s.queue.Run(connCtx, w.writeLine); err != nil {
+					cancel()
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				s.keepalive(connCtx, w)
+			}()
+		case "432", "433", "436": // nick rejected or in use
+			if healthy {
+				break
+			}
+			nick := s.currentNick() + "_"
+			s.setNick(nick)
+			err = w.writeLine("NICK " + nick)
+		case "NICK":
+			if strings.EqualFold(msg.nick(), s.currentNick()) {
+				s.setNick(msg.param(0))
+			}
+		case "PRIVMSG":
+			s.handlePrivmsg(ctx, msg)
+		case "403", "405", "471", "473", "474", "475", "477":
+			if s.logger != nil {
+				s.logger.Warn("join failed", slog.String("config_id", s.cfg.ID), slog.String("channel", msg.param(1)), slog.String("reason", lastParam(msg)))
+			}
+		case "465": // ERR_YOUREBANNEDCREEP
+			return healthy, fmt.Errorf("irc banned: %s", lastParam(msg))
+		case "ERROR":
+			return healthy, fmt.Errorf("irc server error: %s", lastParam(msg))
+		}
+		if err != nil {
+			return healthy, err
+		}
+	}
+}
+
+func (s *ircSession) handleCap(w *lineWriter, msg ircMessage) error {
+	switch strings.ToUpper(msg.param(1)) {
+	case "ACK":
+		if strings.Contains(" "+strings.ToLower(lastParam(msg))+" ", " sasl ") {
+			return w.writeLine("AUTHENTICATE PLAIN")
+		}
+	case "NAK":
+		return errors.New("irc server does not support sasl")
+	}
+	return nil
+}
+
+// keepalive pings the server so a dead connection is noticed by the read
+// deadline even on a quiet network.
+func (s *ircSession) keepalive(ctx context.Context, w *lineWriter) {
+	ticker := time.NewTicker(ircPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.writeLine("PING :memoh"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func lastParam(msg ircMessage) string {
+	if len(msg.Params) == 0 {
+		return ""
+	}
+	return msg.Params[len(msg.Params)-1]
+}
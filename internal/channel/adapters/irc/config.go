@@ -0,0 +1,230 @@
+package irc
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const (
+	defaultTLSPort   = "6697"
+	defaultPlainPort = "6667"
+)
+
+type Config struct {
+	Server       string // host:port
+	TLS          bool
+	Nick         string
+	Username     string
+	RealName     string
+	Password     string //nolint:gosec // intentional: operator-supplied IRC server password in channel config
+	SASLUsername string
+	SASLPassword string //nolint:gosec // intentional: operator-supplied SASL password in channel config
+	Channels     []string
+}
+
+type UserConfig struct {
+	Nick    string
+	Channel string
+}
+
+func normalizeConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{
+		"server":   cfg.Server,
+		"tls":      cfg.TLS,
+		"nick":     cfg.Nick,
+		"username": cfg.Username,
+		"realName": cfg.RealName,
+		"channels": strings.Join(cfg.Channels, ","),
+	}
+	if cfg.Password != "" {
+		out["password"] = cfg.Password
+	}
+	if cfg.SASLUsername != "" {
+		out["saslUsername"] = cfg.SASLUsername
+		out["saslPassword"] = cfg.SASLPassword
+	}
+	return out, nil
+}
+
+func normalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if cfg.Nick != "" {
+		out["nick"] = cfg.Nick
+	}
+	if cfg.Channel != "" {
+		out["channel"] = cfg.Channel
+	}
+	return out, nil
+}
+
+func resolveTarget(raw map[string]any) (string, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Channel != "" {
+		return cfg.Channel, nil
+	}
+	return cfg.Nick, nil
+}
+
+func matchBinding(raw map[string]any, criteria channel.BindingCriteria) bool {
+	cfg, err := parseUserConfig(raw)
+	if err != nil || cfg.Nick == "" {
+		return false
+	}
+	if value := criteria.Attribute("nick"); value != "" && strings.EqualFold(value, cfg.Nick) {
+		return true
+	}
+	return criteria.SubjectID != "" && strings.EqualFold(criteria.SubjectID, cfg.Nick)
+}
+
+func buildUserConfig(identity channel.Identity) map[string]any {
+	nick := identity.Attribute("nick")
+	if nick == "" {
+		nick = strings.TrimSpace(identity.SubjectID)
+	}
+	if nick == "" {
+		return map[string]any{}
+	}
+	return map[string]any{"nick": nick}
+}
+
+func parseConfig(raw map[string]any) (Config, error) {
+	server := strings.TrimSpace(channel.ReadString(raw, "server", "host"))
+	nick := strings.TrimSpace(channel.ReadString(raw, "nick", "nickname"))
+	if server == "" {
+		return Config{}, errors.New("irc server is required")
+	}
+	if nick == "" {
+		return Config{}, errors.New("irc nick is required")
+	}
+	if strings.ContainsAny(nick, " ,*?!@#:") {
+		return Config{}, errors.New("irc nick contains invalid characters")
+	}
+	useTLS := readBool(raw, true, "tls", "useTls", "use_tls")
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		port := defaultTLSPort
+		if !useTLS {
+			port = defaultPlainPort
+		}
+		server = net.JoinHostPort(server, port)
+	}
+	username := strings.TrimSpace(channel.ReadString(raw, "username", "user"))
+	if username == "" {
+		username = nick
+	}
+	realName := strings.TrimSpace(channel.ReadString(raw, "realName", "real_name"))
+	if realName == "" {
+		realName = nick
+	}
+	saslUsername := strings.TrimSpace(channel.ReadString(raw, "saslUsername", "sasl_username"))
+	saslPassword := channel.ReadString(raw, "saslPassword", "sasl_password")
+	if saslUsername != "" && saslPassword == "" {
+		return Config{}, errors.New("irc saslPassword is required with saslUsername")
+	}
+	return Config{
+		Server:       server,
+		TLS:          useTLS,
+		Nick:         nick,
+		Username:     username,
+		RealName:     realName,
+		Password:     channel.ReadString(raw, "password", "serverPassword", "server_password"),
+		SASLUsername: saslUsername,
+		SASLPassword: saslPassword,
+		Channels:     parseChannels(channel.ReadString(raw, "channels")),
+	}, nil
+}
+
+func parseUserConfig(raw map[string]any) (UserConfig, error) {
+	nick := strings.TrimSpace(channel.ReadString(raw, "nick"))
+	channelName := normalizeTarget(channel.ReadString(raw, "channel"))
+	if nick == "" && channelName == "" {
+		return UserConfig{}, errors.New("irc user config requires nick or channel")
+	}
+	if channelName != "" && !isChannelName(channelName) {
+		return UserConfig{}, errors.New("irc channel must start with #, &, + or !")
+	}
+	return UserConfig{Nick: nick, Channel: channelName}, nil
+}
+
+// parseChannels splits a comma or whitespace separated channel list, adding
+// a leading # where it is missing.
+func parseChannels(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+	channels := make([]string, 0, len(fields))
+	seen := map[string]bool{}
+	for _, field := range fields {
+		name := strings.TrimSpace(field)
+		if name == "" {
+			continue
+		}
+		if !isChannelName(name) {
+			name = "#" + name
+		}
+		if key := strings.ToLower(name); !seen[key] {
+			seen[key] = true
+			channels = append(channels, name)
+		}
+	}
+	return channels
+}
+
+// normalizeTarget strips an optional irc: prefix. A target is a channel
+// (#name) or a nick.
+func normalizeTarget(raw string) string {
+	value := strings.TrimSpace(raw)
+	if len(value) > len("irc:") && strings.EqualFold(value[:len("irc:")], "irc:") {
+		value = strings.TrimSpace(value[len("irc:"):])
+	}
+	return value
+}
+
+func validateTarget(target string) error {
+	value := normalizeTarget(target)
+	if value == "" {
+		return errors.New("irc target is required")
+	}
+	if strings.ContainsAny(value, " ,\r\n") {
+		return errors.New("irc target must be a channel or nick")
+	}
+	return nil
+}
+
+func isChannelName(name string) bool {
+	return name != "" && strings.ContainsRune("#&+!", rune(name[0]))
+}
+
+func readBool(raw map[string]any, fallback bool, keys ...string) bool {
+	for _, key := range keys {
+		value, ok := raw[key]
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case bool:
+			return v
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true", "1", "yes", "on":
+				return true
+			case "false", "0", "no", "off":
+				return false
+			}
+		}
+	}
+	return fallback
+}
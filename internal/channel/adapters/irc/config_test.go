@@ -0,0 +1,66 @@
+package irc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := parseConfig(map[string]any{
+		"server":   " irc.libera.chat ",
+		"nick":     "memoh",
+		"channels": "#memoh, general #Memoh",
+	})
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v", err)
+	}
+	if cfg.Server != "irc.libera.chat:6697" || !cfg.TLS {
+		t.Fatalf("unexpected server: %+v", cfg)
+	}
+	if cfg.Username != "memoh" || cfg.RealName != "memoh" {
+		t.Fatalf("expected username and real name to default to nick: %+v", cfg)
+	}
+	if want := []string{"#memoh", "#general"}; !reflect.DeepEqual(cfg.Channels, want) {
+		t.Fatalf("channels = %v, want %v", cfg.Channels, want)
+	}
+
+	cfg, err = parseConfig(map[string]any{"server": "localhost", "nick": "bot", "tls": "false"})
+	if err != nil || cfg.Server != "localhost:6667" || cfg.TLS {
+		t.Fatalf("plain config = %+v (%v)", cfg, err)
+	}
+	for _, raw := range []map[string]any{
+		{"nick": "memoh"},
+		{"server": "irc.libera.chat"},
+		{"server": "irc.libera.chat", "nick": "bad nick"},
+		{"server": "irc.libera.chat", "nick": "memoh", "saslUsername": "memoh"},
+	} {
+		if _, err := parseConfig(raw); err == nil {
+			t.Errorf("expected error for %v", raw)
+		}
+	}
+}
+
+func TestTargetsAndBinding(t *testing.T) {
+	if got := normalizeTarget(" IRC:#memoh "); got != "#memoh" {
+		t.Fatalf("normalizeTarget = %q", got)
+	}
+	if err := validateTarget("#a b"); err == nil {
+		t.Fatal("expected targets with spaces to be rejected")
+	}
+	target, err := resolveTarget(map[string]any{"nick": "alice", "channel": "#memoh"})
+	if err != nil || target != "#memoh" {
+		t.Fatalf("resolveTarget = %q (%v)", target, err)
+	}
+	if _, err := parseUserConfig(map[string]any{"channel": "memoh"}); err == nil {
+		t.Fatal("expected channel without prefix to be rejected")
+	}
+	raw := map[string]any{"nick": "Alice"}
+	if !matchBinding(raw, channel.BindingCriteria{SubjectID: "alice"}) {
+		t.Fatal("expected case-insensitive nick match")
+	}
+	if matchBinding(raw, channel.BindingCriteria{SubjectID: "bob"}) {
+		t.Fatal("expected other nicks not to match")
+	}
+}
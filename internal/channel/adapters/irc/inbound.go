@@ -0,0 +1,217 @@
+package irc
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/channel/adapters/common"
+)
+
+const ctcpDelim = "\x01"
+
+func (s *ircSession) handlePrivmsg(ctx context.Context, raw ircMessage) {
+	msg, ok := s.buildInboundMessage(raw)
+	if !ok {
+		return
+	}
+	if s.logger != nil {
+		s.logger.Info("inbound received",
+			slog.String("config_id", s.cfg.ID),
+			slog.String("chat_type", msg.Conversation.Type),
+			slog.String("nick", msg.Sender.SubjectID),
+			slog.String("text", common.SummarizeText(msg.Message.Text)),
+		)
+	}
+	go func() {
+		if err := s.handler(ctx, s.cfg, msg); err != nil && s.logger != nil {
+			s.logger.Error("handle inbound failed", slog.String("config_id", s.cfg.ID), slog.Any("error", err))
+		}
+	}()
+}
+
+// buildInboundMessage maps a PRIVMSG to a channel or to the bot. CTCP
+// requests other than ACTION and the bot's own echoed lines are skipped.
+func (s *ircSession) buildInboundMessage(raw ircMessage) (channel.InboundMessage, bool) {
+	sender := raw.nick()
+	target := raw.param(0)
+	text := raw.param(1)
+	selfNick := s.currentNick()
+	if sender == "" || target == "" || strings.EqualFold(sender, selfNick) {
+		return channel.InboundMessage{}, false
+	}
+	isAction := false
+	if strings.HasPrefix(text, ctcpDelim) {
+		command, rest, _ := strings.Cut(strings.Trim(text, ctcpDelim), " ")
+		if !strings.EqualFold(command, "ACTION") {
+			return channel.InboundMessage{}, false
+		}
+		text, isAction = rest, true
+	}
+	text = strings.TrimSpace(stripFormatting(text))
+	if text == "" {
+		return channel.InboundMessage{}, false
+	}
+
+	isMentioned := false
+	if rest, ok := stripAddress(text, selfNick); ok {
+		text, isMentioned = rest, true
+	} else {
+		isMentioned = containsNick(text, selfNick)
+	}
+	if text == "" {
+		return channel.InboundMessage{}, false
+	}
+
+	conversation := channel.Conversation{
+		ID:   sender,
+		Type: channel.ConversationTypePrivate,
+		Name: sender,
+	}
+	replyTarget := sender
+	if isChannelName(target) {
+		conversation = channel.Conversation{
+			ID:   strings.ToLower(target),
+			Type: channel.ConversationTypeGroup,
+			Name: target,
+		}
+		replyTarget = target
+	}
+	user, host := splitUserHost(raw.Prefix)
+	return channel.InboundMessage{
+		Channel: Type,
+		Message: channel.Message{
+			ID:     s.messageID(raw),
+			Format: channel.MessageFormatPlain,
+			Text:   text,
+		},
+		BotID:       s.cfg.BotID,
+		ReplyTarget: replyTarget,
+		Sender: channel.Identity{
+			SubjectID:   sender,
+			DisplayName: sender,
+			Attributes: map[string]string{
+				"nick": sender,
+				"user": user,
+				"host": host,
+			},
+		},
+		Conversation: conversation,
+		ReceivedAt:   time.Now().UTC(),
+		Source:       "irc",
+		Metadata: map[string]any{
+			"is_mentioned": isMentioned,
+			"is_action":    isAction,
+			"raw_text":     raw.param(1),
+		},
+	}, true
+}
+
+// messageID uses the IRCv3 msgid tag when the server sends one. Plain IRC
+// has no message IDs, so a local sequence number stands in.
+func (s *ircSession) messageID(raw ircMessage) string {
+	if id := strings.TrimSpace(raw.Tags["msgid"]); id != "" {
+		return id
+	}
+	s.mu.Lock()
+	s.counter++
+	n := s.counter
+	s.mu.Unlock()
+	return strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatUint(n, 10)
+}
+
+func splitUserHost(prefix string) (string, string) {
+	_, userHost, ok := strings.Cut(prefix, "!")
+	if !ok {
+		return "", ""
+	}
+	user, host, _ := strings.Cut(userHost, "@")
+	return user, host
+}
+
+// stripAddress removes a leading "nick:" or "nick," used to address the bot.
+func stripAddress(text, nick string) (string, bool) {
+	if nick == "" || len(text) <= len(nick) || !strings.EqualFold(text[:len(nick)], nick) {
+		return "", false
+	}
+	switch text[len(nick)] {
+	case ':', ',':
+		return strings.TrimSpace(text[len(nick)+1:]), true
+	}
+	return "", false
+}
+
+// containsNick reports whether nick appears in text as a whole word.
+func containsNick(text, nick string) bool {
+	if nick == "" {
+		return false
+	}
+	lowerText, lowerNick := strings.ToLower(text), strings.ToLower(nick)
+	for offset := 0; offset < len(lowerText); {
+		idx := strings.Index(lowerText[offset:], lowerNick)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(lowerNick)
+		if (start == 0 || !isNickChar(lowerText[start-1])) && (end == len(lowerText) || !isNickChar(lowerText[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+func isNickChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("[]\\`_^{|}-", c) >= 0
+}
+
+// stripFormatting removes mIRC formatting: bold, italics, underline,
+// strikethrough, monospace, reverse, reset and colour codes with their
+// arguments.
+func stripFormatting(text string) string {
+	if !strings.ContainsAny(text, "\x02\x03\x04\x0f\x11\x16\x1d\x1e\x1f") {
+		return text
+	}
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case 0x02, 0x0f, 0x11, 0x16, 0x1d, 0x1e, 0x1f:
+		case 0x03:
+			i = skipColor(text, i, isDigit, 2)
+		case 0x04:
+			i = skipColor(text, i, isHexDigit, 6)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// skipColor skips "fg[,bg]" after a colour code at i and returns the index of
+// the last byte consumed.
+func skipColor(text string, i int, valid func(byte) bool, width int) int {
+	j := i + 1
+	for n := 0; n < width && j < len(text) && valid(text[j]); n++ {
+		j++
+	}
+	if j > i+1 && j+1 < len(text) && text[j] == ',' && valid(text[j+1]) {
+		j++
+		for n := 0; n < width && j < len(text) && valid(text[j]); n++ {
+			j++
+		}
+	}
+	return j - 1
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
@@ -0,0 +1,358 @@
+package irc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const Type channel.ChannelType = "irc"
+
+const (
+	// ircTextChunkLimit keeps a chunk within a few protocol lines; each line
+	// is split again by bytes before it is sent.
+	ircTextChunkLimit = 400
+	// ircLineBudget is the payload size a PRIVMSG line may use. Servers cap a
+	// relayed line at 512 bytes including the sender prefix, which we cannot
+	// see, so the target length is subtracted from a conservative budget.
+	ircLineBudget    = 400
+	ircMinLineBudget = 100
+)
+
+// Flood control: most networks allow a short burst and then about one line
+// per second before disconnecting a client for excess flood.
+var (
+	ircSendBurst    = 4
+	ircSendInterval = time.Second
+	ircSendQueueLen = 512
+)
+
+type IRCAdapter struct {
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*ircSession // keyed by config ID
+}
+
+func NewIRCAdapter(log *slog.Logger) *IRCAdapter {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &IRCAdapter{
+		logger:   log.With(slog.String("adapter", "irc")),
+		sessions: make(map[string]*ircSession),
+	}
+}
+
+func (*IRCAdapter) Type() channel.ChannelType {
+	return Type
+}
+
+func (*IRCAdapter) Descriptor() channel.Descriptor {
+	return channel.Descriptor{
+		Type:        Type,
+		DisplayName: "IRC",
+		Capabilities: channel.ChannelCapabilities{
+			Text:           true,
+			BlockStreaming: true,
+			ChatTypes:      []string{"direct", "group"},
+		},
+		OutboundPolicy: channel.OutboundPolicy{
+			TextChunkLimit: ircTextChunkLimit,
+			ChunkerMode:    channel.ChunkerModeText,
+		},
+		ConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"server": {
+					Type:        channel.FieldString,
+					Required:    true,
+					Title:       "Server",
+					Description: "host:port of the IRC server; the port defaults to 6697 with TLS and 6667 without",
+					Example:     "irc.libera.chat:6697",
+				},
+				"tls": {
+					Type:        channel.FieldBool,
+					Title:       "TLS",
+					Description: "Connect over TLS (default true)",
+				},
+				"nick": {
+					Type:     channel.FieldString,
+					Required: true,
+					Title:    "Nick",
+					Example:  "memoh",
+				},
+				"username": {Type: channel.FieldString, Title: "Username"},
+				"realName": {Type: channel.FieldString, Title: "Real Name"},
+				"password": {
+					Type:        channel.FieldSecret,
+					Title:       "Server Password",
+					Description: "Sent with PASS before registration",
+				},
+				"saslUsername": {
+					Type:        channel.FieldString,
+					Title:       "SASL Username",
+					Description: "Account name for SASL PLAIN authentication",
+				},
+				"saslPassword": {Type: channel.FieldSecret, Title: "SASL Password"},
+				"channels": {
+					Type:        channel.FieldString,
+					Title:       "Channels",
+					Description: "Comma separated channels to join",
+					Example:     "#memoh,#general",
+				},
+			},
+		},
+		UserConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"nick":    {Type: channel.FieldString, Title: "Nick"},
+				"channel": {Type: channel.FieldString, Title: "Channel"},
+			},
+		},
+		TargetSpec: channel.TargetSpec{
+			Format: "#channel | nick",
+			Hints: []channel.TargetHint{
+				{Label: "Channel", Example: "#memoh"},
+				{Label: "Nick", Example: "alice"},
+			},
+		},
+	}
+}
+
+func (*IRCAdapter) NormalizeConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeConfig(raw)
+}
+
+func (*IRCAdapter) NormalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeUserConfig(raw)
+}
+
+func (*IRCAdapter) NormalizeTarget(raw string) string {
+	return normalizeTarget(raw)
+}
+
+func (*IRCAdapter) ResolveTarget(userConfig map[string]any) (string, error) {
+	return resolveTarget(userConfig)
+}
+
+func (*IRCAdapter) MatchBinding(config map[string]any, criteria channel.BindingCriteria) bool {
+	return matchBinding(config, criteria)
+}
+
+func (*IRCAdapter) BuildUserConfig(identity channel.Identity) map[string]any {
+	return buildUserConfig(identity)
+}
+
+func (a *IRCAdapter) Connect(ctx context.Context, cfg channel.ChannelConfig, handler channel.InboundHandler) (channel.Connection, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	channel.SetIMErrorSecrets("irc:"+cfg.ID, parsed.Password, parsed.SASLPassword)
+	if a.logger != nil {
+		a.logger.Info("start", slog.String("config_id", cfg.ID), slog.String("server", parsed.Server), slog.String("nick", parsed.Nick))
+	}
+	session := newIRCSession(a.logger, cfg, parsed, handler)
+	a.mu.Lock()
+	if previous := a.sessions[cfg.ID]; previous != nil {
+		previous.stop()
+	}
+	a.sessions[cfg.ID] = session
+	a.mu.Unlock()
+
+	connCtx, cancel := context.WithCancel(ctx)
+	session.cancel = cancel
+	go func() {
+		defer close(session.done)
+		session.run(connCtx)
+	}()
+	return channel.NewConnection(cfg, func(context.Context) error {
+		if a.logger != nil {
+			a.logger.Info("stop", slog.String("config_id", cfg.ID))
+		}
+		a.mu.Lock()
+		if a.sessions[cfg.ID] == session {
+			delete(a.sessions, cfg.ID)
+		}
+		a.mu.Unlock()
+		session.stop()
+		return nil
+	}), nil
+}
+
+func (a *IRCAdapter) session(configID string) (*ircSession, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	session := a.sessions[configID]
+	if session == nil {
+		return nil, errors.New("irc connection is not running")
+	}
+	return session, nil
+}
+
+// Send queues the message as PRIVMSG lines. Lines go out in the background at
+// the flood-control pace, so Send returns before they reach the server.
+func (a *IRCAdapter) Send(_ context.Context, cfg channel.ChannelConfig, msg channel.OutboundMessage) error {
+	if msg.Message.IsEmpty() {
+		return errors.New("message is required")
+	}
+	if err := validateTarget(msg.Target); err != nil {
+		return err
+	}
+	session, err := a.session(cfg.ID)
+	if err != nil {
+		return err
+	}
+	return session.sendText(normalizeTarget(msg.Target), messageText(msg.Message))
+}
+
+// OpenStream buffers a reply and sends it once it is final; IRC messages
+// cannot be edited.
+func (a *IRCAdapter) OpenStream(_ context.Context, cfg channel.ChannelConfig, target string, _ channel.StreamOptions) (channel.OutboundStream, error) {
+	if err := validateTarget(target); err != nil {
+		return nil, err
+	}
+	session, err := a.session(cfg.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &ircOutboundStream{session: session, target: normalizeTarget(target)}, nil
+}
+
+// messageText flattens a message for a text-only channel, listing attachment
+// URLs after the text.
+func messageText(msg channel.Message) string {
+	lines := []string{strings.TrimSpace(msg.PlainText())}
+	for _, att := range msg.Attachments {
+		if url := strings.TrimSpace(att.URL); url != "" {
+			lines = append(lines, url)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+type ircOutboundStream struct {
+	session *ircSession
+	target  string
+
+	closed atomic.Bool
+	mu     sync.Mutex
+	buffer strings.Builder
+}
+
+func (s *ircOutboundStream) Push(ctx context.Context, event channel.StreamEvent) error {
+	if s.closed.Load() {
+		return errors.New("irc stream is closed")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	switch event.Type {
+	case channel.StreamEventDelta:
+		if event.Phase == channel.StreamPhaseReasoning || event.Delta == "" {
+			return nil
+		}
+		s.mu.Lock()
+		s.buffer.WriteString(event.Delta)
+		s.mu.Unlock()
+		return nil
+	case channel.StreamEventToolCallStart:
+		// Text before a tool call is its own message.
+		return s.flush()
+	case channel.StreamEventError:
+		errText := channel.RedactIMErrorText(strings.TrimSpace(event.Error))
+		if errText == "" {
+			return nil
+		}
+		return s.session.sendText(s.target, "Error: "+errText)
+	case channel.StreamEventFinal:
+		if event.Final == nil {
+			return errors.New("irc stream final payload is required")
+		}
+		text := messageText(event.Final.Message)
+		s.mu.Lock()
+		if text == "" {
+			text = strings.TrimSpace(s.buffer.String())
+		}
+		s.buffer.Reset()
+		s.mu.Unlock()
+		if text == "" {
+			return nil
+		}
+		return s.session.sendText(s.target, text)
+	default:
+		return nil
+	}
+}
+
+func (s *ircOutboundStream) flush() error {
+	s.mu.Lock()
+	text := strings.TrimSpace(s.buffer.String())
+	s.buffer.Reset()
+	s.mu.Unlock()
+	if text == "" {
+		return nil
+	}
+	return s.session.sendText(s.target, text)
+}
+
+func (s *ircOutboundStream) Close(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.closed.Store(true)
+	return nil
+}
+
+// privmsgLines turns text into PRIVMSG commands: one per line, with long
+// lines split on UTF-8 boundaries so no line exceeds the byte budget.
+func privmsgLines(target, text string) []string {
+	budget := max(ircLineBudget-len(target), ircMinLineBudget)
+	var out []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r", ""), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		for _, part := range splitBytes(line, budget) {
+			out = append(out, "PRIVMSG "+target+" :"+part)
+		}
+	}
+	return out
+}
+
+// splitBytes splits s into pieces of at most limit bytes, preferring to break
+// after a space and never inside a UTF-8 sequence.
+func splitBytes(s string, limit int) []string {
+	var parts []string
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if space := strings.LastIndexByte(s[:cut], ' '); space > limit/2 {
+			cut = space + 1
+		}
+		if cut == 0 {
+			_, size := utf8.DecodeRuneInString(s)
+			cut = size
+		}
+		parts = append(parts, strings.TrimRight(s[:cut], " "))
+		s = s[cut:]
+	}
+	if s != "" {
+		parts = append(parts, s)
+	}
+	return parts
+}
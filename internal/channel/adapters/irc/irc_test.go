@@ -0,0 +1,240 @@
+package irc
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+func TestParseMessage(t *testing.T) {
+	msg := parseMessage("@msgid=abc;time=x :alice!al@example.org PRIVMSG #memoh :hello there\r\n")
+	if msg.Tags["msgid"] != "abc" || msg.Prefix != "alice!al@example.org" || msg.Command != "PRIVMSG" {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+	if want := []string{"#memoh", "hello there"}; !reflect.DeepEqual(msg.Params, want) {
+		t.Fatalf("params = %q, want %q", msg.Params, want)
+	}
+	if msg.nick() != "alice" {
+		t.Fatalf("nick = %q", msg.nick())
+	}
+	if ping := parseMessage("PING :irc.example.org"); ping.Command != "PING" || ping.param(0) != "irc.example.org" {
+		t.Fatalf("unexpected ping: %+v", ping)
+	}
+}
+
+func TestStripFormattingAndMentions(t *testing.T) {
+	if got := stripFormatting("\x02bold\x02 \x0304,12red\x03 \x1ditalic\x0f"); got != "bold red italic" {
+		t.Fatalf("stripFormatting = %q", got)
+	}
+	if got := stripFormatting("\x0399 balloons"); got != " balloons" {
+		t.Fatalf("stripFormatting colour without comma = %q", got)
+	}
+	if !containsNick("hey Memoh, are you there?", "memoh") {
+		t.Fatal("expected nick mention to be detected")
+	}
+	if containsNick("memohbot is another bot", "memoh") {
+		t.Fatal("expected nick inside a longer word not to match")
+	}
+	if rest, ok := stripAddress("memoh: ping", "Memoh"); !ok || rest != "ping" {
+		t.Fatalf("stripAddress = %q, %v", rest, ok)
+	}
+}
+
+func TestPrivmsgLinesSplitsByBytes(t *testing.T) {
+	long := strings.Repeat("你好", 100) // 600 bytes
+	lines := privmsgLines("#memoh", "first\n\nsecond\r\n"+long)
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d: %q", len(lines), lines)
+	}
+	if lines[0] != "PRIVMSG #memoh :first" || lines[1] != "PRIVMSG #memoh :second" {
+		t.Fatalf("unexpected lines: %q", lines[:2])
+	}
+	var joined strings.Builder
+	for _, line := range lines[2:] {
+		payload := strings.TrimPrefix(line, "PRIVMSG #memoh :")
+		if len(payload) > ircLineBudget-len("#memoh") {
+			t.Fatalf("line exceeds budget: %d bytes", len(payload))
+		}
+		joined.WriteString(payload)
+	}
+	if joined.String() != long {
+		t.Fatal("split lines do not reassemble the original text")
+	}
+}
+
+// fakeIRCd is a local stand-in for an IRC server that records the lines the
+// client writes.
+type fakeIRCd struct {
+	t        *testing.T
+	listener net.Listener
+	conn     net.Conn
+	lines    chan string
+}
+
+func newFakeIRCd(t *testing.T) *fakeIRCd {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	return &fakeIRCd{t: t, listener: listener, lines: make(chan string, 64)}
+}
+
+func (f *fakeIRCd) accept() {
+	f.t.Helper()
+	conn, err := f.listener.Accept()
+	if err != nil {
+		f.t.Fatalf("accept: %v", err)
+	}
+	f.conn = conn
+	f.t.Cleanup(func() { _ = conn.Close() })
+	go func() {
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			f.lines <- strings.TrimRight(scanner.Text(), "\r")
+		}
+	}()
+}
+
+func (f *fakeIRCd) send(line string) {
+	f.t.Helper()
+	if _, err := f.conn.Write([]byte(line + "\r\n")); err != nil {
+		f.t.Fatalf("write: %v", err)
+	}
+}
+
+func (f *fakeIRCd) expect(want string) {
+	f.t.Helper()
+	select {
+	case got := <-f.lines:
+		if got != want {
+			f.t.Fatalf("client sent %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		f.t.Fatalf("timed out waiting for %q", want)
+	}
+}
+
+func TestAdapterAgainstFakeServer(t *testing.T) {
+	server := newFakeIRCd(t)
+	adapter := NewIRCAdapter(slog.New(slog.DiscardHandler))
+	cfg := channel.ChannelConfig{
+		ID:          "cfg-1",
+		BotID:       "bot-1",
+		ChannelType: Type,
+		Credentials: map[string]any{
+			"server":       server.listener.Addr().String(),
+			"tls":          false,
+			"nick":         "memoh",
+			"saslUsername": "memoh",
+			"saslPassword": "secret",
+			"channels":     "#memoh",
+		},
+	}
+	inbound := make(chan channel.InboundMessage, 4)
+	conn, err := adapter.Connect(context.Background(), cfg, func(_ context.Context, _ channel.ChannelConfig, msg channel.InboundMessage) error {
+		inbound <- msg
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Connect returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Stop(context.Background()) })
+
+	server.accept()
+	server.expect("CAP REQ :sasl")
+	server.expect("NICK memoh")
+	server.expect("USER memoh 0 * :memoh")
+	server.send(":irc.test CAP * ACK :sasl")
+	server.expect("AUTHENTICATE PLAIN")
+	server.send("AUTHENTICATE +")
+	server.expect("AUTHENTICATE " + base64.StdEncoding.EncodeToString([]byte("\x00memoh\x00secret")))
+	server.send(":irc.test 903 memoh :SASL authentication successful")
+	server.expect("CAP END")
+	server.send(":irc.test 433 * memoh :Nickname is already in use")
+	server.expect("NICK memoh_")
+	server.send(":irc.test 001 memoh_ :Welcome")
+	server.expect("JOIN #memoh")
+	server.send("PING :irc.test")
+	server.expect("PONG :irc.test")
+
+	server.send(":alice!al@example.org PRIVMSG #Memoh :memoh_: what is \x02up\x02?")
+	server.send(":bob!bo@example.org PRIVMSG #memoh :just chatting")
+	server.send(":alice!al@example.org PRIVMSG memoh_ :\x01ACTION waves\x01")
+	var got []channel.InboundMessage
+	for range 3 {
+		select {
+		case msg := <-inbound:
+			got = append(got, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for inbound messages, got %d", len(got))
+		}
+	}
+	byText := map[string]channel.InboundMessage{}
+	for _, msg := range got {
+		byText[msg.Message.Text] = msg
+	}
+	mention, ok := byText["what is up?"]
+	if !ok {
+		t.Fatalf("addressed message missing: %+v", got)
+	}
+	if mention.Conversation.ID != "#memoh" || mention.Conversation.Type != channel.ConversationTypeGroup || mention.ReplyTarget != "#Memoh" {
+		t.Fatalf("unexpected conversation: %+v reply=%q", mention.Conversation, mention.ReplyTarget)
+	}
+	if mention.Metadata["is_mentioned"] != true || mention.Sender.SubjectID != "alice" {
+		t.Fatalf("unexpected mention metadata: %+v", mention)
+	}
+	if byText["just chatting"].Metadata["is_mentioned"] != false {
+		t.Fatal("expected unaddressed message not to be a mention")
+	}
+	action := byText["waves"]
+	if action.Conversation.Type != channel.ConversationTypePrivate || action.ReplyTarget != "alice" || action.Metadata["is_action"] != true {
+		t.Fatalf("unexpected private action: %+v", action)
+	}
+
+	if err := adapter.Send(context.Background(), cfg, channel.OutboundMessage{
+		Target:  "#memoh",
+		Message: channel.Message{Text: "line one\nline two"},
+	}); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	server.expect("PRIVMSG #memoh :line one")
+	server.expect("PRIVMSG #memoh :line two")
+
+	stream, err := adapter.OpenStream(context.Background(), cfg, "alice", channel.StreamOptions{})
+	if err != nil {
+		t.Fatalf("OpenStream returned error: %v", err)
+	}
+	for _, delta := range []string{"streamed ", "reply"} {
+		if err := stream.Push(context.Background(), channel.StreamEvent{Type: channel.StreamEventDelta, Delta: delta}); err != nil {
+			t.Fatalf("Push delta: %v", err)
+		}
+	}
+	if err := stream.Push(context.Background(), channel.StreamEvent{Type: channel.StreamEventFinal, Final: &channel.StreamFinalizePayload{}}); err != nil {
+		t.Fatalf("Push final: %v", err)
+	}
+	server.expect("PRIVMSG alice :streamed reply")
+	if err := stream.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestSendWithoutConnection(t *testing.T) {
+	adapter := NewIRCAdapter(slog.New(slog.DiscardHandler))
+	err := adapter.Send(context.Background(), channel.ChannelConfig{ID: "missing"}, channel.OutboundMessage{
+		Target:  "#memoh",
+		Message: channel.Message{Text: "hi"},
+	})
+	if err == nil {
+		t.Fatal("expected Send to fail without a running connection")
+	}
+}
@@ -0,0 +1,171 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/textutil"
+)
+
+const (
+	mattermostDefaultTimeout = 30 * time.Second
+	mattermostMaxRetryAfter  = 30 * time.Second
+)
+
+// apiError is a REST API error response.
+type apiError struct {
+	Path       string
+	StatusCode int
+	ID         string
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("mattermost %s: HTTP %d: %s (%s)", e.Path, e.StatusCode, e.Message, e.ID)
+	}
+	return fmt.Sprintf("mattermost %s: HTTP %d: %s", e.Path, e.StatusCode, e.Message)
+}
+
+// call invokes a REST API v4 endpoint with a JSON body. A rate-limited call is
+// retried once after the delay the server asks for.
+func (a *MattermostAdapter) call(ctx context.Context, cfg Config, method, path string, body any, out any) error {
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("mattermost %s: %w", path, err)
+		}
+		payload = data
+	}
+	return a.do(ctx, cfg, method, path, "application/json", payload, out)
+}
+
+// upload posts one file to /files as multipart form data and returns its
+// file ID.
+func (a *MattermostAdapter) upload(ctx context.Context, cfg Config, channelID, name string, data []byte) (string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.WriteField("channel_id", channelID); err != nil {
+		return "", err
+	}
+	part, err := writer.CreateFormFile("files", name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	var resp struct {
+		FileInfos []struct {
+			ID string `json:"id"`
+		} `json:"file_infos"`
+	}
+	if err := a.do(ctx, cfg, http.MethodPost, "/files", writer.FormDataContentType(), buf.Bytes(), &resp); err != nil {
+		return "", err
+	}
+	if len(resp.FileInfos) == 0 || resp.FileInfos[0].ID == "" {
+		return "", errors.New("mattermost /files: no file info returned")
+	}
+	return resp.FileInfos[0].ID, nil
+}
+
+func (a *MattermostAdapter) do(ctx context.Context, cfg Config, method, path, contentType string, payload []byte, out any) error {
+	for attempt := 0; ; attempt++ {
+		data, status, retryAfter, err := a.request(ctx, cfg, method, path, contentType, payload)
+		if err != nil {
+			return fmt.Errorf("mattermost %s: %w", path, err)
+		}
+		if status == http.StatusTooManyRequests && attempt == 0 {
+			if !sleepContext(ctx, retryAfter) {
+				return ctx.Err()
+			}
+			continue
+		}
+		if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			apiErr := &apiError{Path: path, StatusCode: status}
+			var resp struct {
+				ID      string `json:"id"`
+				Message string `json:"message"`
+			}
+			if json.Unmarshal(data, &resp) == nil && resp.Message != "" {
+				apiErr.ID, apiErr.Message = resp.ID, resp.Message
+			} else {
+				apiErr.Message = textutil.TruncateRunes(strings.TrimSpace(string(data)), 300)
+			}
+			return apiErr
+		}
+		if out == nil || len(data) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("mattermost %s: decode response: %w", path, err)
+		}
+		return nil
+	}
+}
+
+func (a *MattermostAdapter) request(ctx context.Context, cfg Config, method, path, contentType string, payload []byte) ([]byte, int, time.Duration, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, cfg.ServerURL+"/api/v4"+path, body)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := a.httpClient.Do(req) //nolint:gosec // G704: URL is the configured Mattermost server
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, resp.StatusCode, 0, err
+	}
+	return data, resp.StatusCode, parseRetryAfter(resp.Header), nil
+}
+
+// parseRetryAfter reads Retry-After, falling back to Mattermost's
+// X-Ratelimit-Reset (seconds until the limit resets).
+func parseRetryAfter(header http.Header) time.Duration {
+	for _, key := range []string{"Retry-After", "X-Ratelimit-Reset"} {
+		seconds, err := strconv.Atoi(strings.TrimSpace(header.Get(key)))
+		if err != nil || seconds <= 0 {
+			continue
+		}
+		delay := time.Duration(seconds) * time.Second
+		if delay > mattermostMaxRetryAfter {
+			return mattermostMaxRetryAfter
+		}
+		return delay
+	}
+	return time.Second
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
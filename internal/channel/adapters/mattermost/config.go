@@ -0,0 +1,182 @@
+package mattermost
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const userTargetPrefix = "user:"
+
+type Config struct {
+	ServerURL string
+	Token     string //nolint:gosec // intentional: operator-supplied Mattermost bot access token in channel config
+}
+
+type UserConfig struct {
+	UserID    string
+	ChannelID string
+	Username  string
+}
+
+func normalizeConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"serverUrl": cfg.ServerURL,
+		"token":     cfg.Token,
+	}, nil
+}
+
+func normalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if cfg.UserID != "" {
+		out["user_id"] = cfg.UserID
+	}
+	if cfg.ChannelID != "" {
+		out["channel_id"] = cfg.ChannelID
+	}
+	if cfg.Username != "" {
+		out["username"] = cfg.Username
+	}
+	return out, nil
+}
+
+func resolveTarget(raw map[string]any) (string, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return "", err
+	}
+	if cfg.ChannelID != "" {
+		return cfg.ChannelID, nil
+	}
+	return userTargetPrefix + cfg.UserID, nil
+}
+
+func matchBinding(raw map[string]any, criteria channel.BindingCriteria) bool {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return false
+	}
+	if value := criteria.Attribute("user_id"); value != "" && value == cfg.UserID {
+		return true
+	}
+	if value := criteria.Attribute("username"); value != "" && strings.EqualFold(value, cfg.Username) {
+		return true
+	}
+	return criteria.SubjectID != "" && criteria.SubjectID == cfg.UserID
+}
+
+func buildUserConfig(identity channel.Identity) map[string]any {
+	out := map[string]any{}
+	userID := identity.Attribute("user_id")
+	if userID == "" {
+		userID = strings.TrimSpace(identity.SubjectID)
+	}
+	if userID != "" {
+		out["user_id"] = userID
+	}
+	if value := identity.Attribute("username"); value != "" {
+		out["username"] = value
+	}
+	return out
+}
+
+func parseConfig(raw map[string]any) (Config, error) {
+	serverURL := strings.TrimRight(strings.TrimSpace(channel.ReadString(raw, "serverUrl", "server_url", "url")), "/")
+	token := strings.TrimSpace(channel.ReadString(raw, "token", "accessToken", "access_token"))
+	if serverURL == "" {
+		return Config{}, errors.New("mattermost serverUrl is required")
+	}
+	parsed, err := url.Parse(serverURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return Config{}, errors.New("mattermost serverUrl must be an http or https URL")
+	}
+	if token == "" {
+		return Config{}, errors.New("mattermost token is required")
+	}
+	return Config{ServerURL: serverURL, Token: token}, nil
+}
+
+func parseUserConfig(raw map[string]any) (UserConfig, error) {
+	userID := strings.TrimSpace(channel.ReadString(raw, "userId", "user_id"))
+	channelID := strings.TrimSpace(channel.ReadString(raw, "channelId", "channel_id"))
+	username := strings.TrimPrefix(strings.TrimSpace(channel.ReadString(raw, "username")), "@")
+	if userID == "" && channelID == "" {
+		return UserConfig{}, errors.New("mattermost user config requires user_id or channel_id")
+	}
+	return UserConfig{UserID: userID, ChannelID: channelID, Username: username}, nil
+}
+
+// normalizeTarget strips optional prefixes. Mattermost channel and user IDs
+// look alike, so a bare ID is a channel and users are addressed as
+// "user:<id>" or "@username". A channel may be followed by ":<root_id>" to
+// post into a thread.
+func normalizeTarget(raw string) string {
+	value := strings.TrimSpace(raw)
+	for _, prefix := range []string{"mattermost:", "channel:"} {
+		if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+			value = strings.TrimSpace(value[len(prefix):])
+			break
+		}
+	}
+	if len(value) > len(userTargetPrefix) && strings.EqualFold(value[:len(userTargetPrefix)], userTargetPrefix) {
+		return userTargetPrefix + strings.TrimSpace(value[len(userTargetPrefix):])
+	}
+	return value
+}
+
+// splitTarget returns the conversation and optional thread root of a
+// normalized target. User targets keep their "user:" or "@" marker.
+func splitTarget(target string) (string, string) {
+	target = normalizeTarget(target)
+	prefix := ""
+	if strings.HasPrefix(target, userTargetPrefix) {
+		prefix, target = userTargetPrefix, target[len(userTargetPrefix):]
+	}
+	conversation, root, _ := strings.Cut(target, ":")
+	return prefix + strings.TrimSpace(conversation), strings.TrimSpace(root)
+}
+
+func joinTarget(conversation, rootID string) string {
+	if rootID == "" {
+		return conversation
+	}
+	return conversation + ":" + rootID
+}
+
+func validateTarget(target string) error {
+	conversation, _ := splitTarget(target)
+	switch {
+	case conversation == "", conversation == userTargetPrefix, conversation == "@":
+		return errors.New("mattermost target is required")
+	case strings.HasPrefix(conversation, userTargetPrefix), strings.HasPrefix(conversation, "@"):
+		return nil
+	case isMattermostID(conversation):
+		return nil
+	default:
+		return errors.New("mattermost target must be a channel id, user:<id> or @username")
+	}
+}
+
+// isMattermostID reports whether value looks like a Mattermost ID: 26
+// lowercase base32 characters.
+func isMattermostID(value string) bool {
+	if len(value) != 26 {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package mattermost
+
+import (
+	"testing"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := parseConfig(map[string]any{
+		"server_url": " https://chat.example.com/ ",
+		"token":      " abc ",
+	})
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v", err)
+	}
+	if cfg.ServerURL != "https://chat.example.com" || cfg.Token != "abc" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	for _, raw := range []map[string]any{
+		{"token": "abc"},
+		{"serverUrl": "chat.example.com", "token": "abc"},
+		{"serverUrl": "https://chat.example.com"},
+	} {
+		if _, err := parseConfig(raw); err == nil {
+			t.Errorf("expected error for %v", raw)
+		}
+	}
+}
+
+func TestNormalizeTarget(t *testing.T) {
+	cases := map[string]string{
+		testChannelID:                    testChannelID,
+		" mattermost:" + testChannelID:   testChannelID,
+		"channel:" + testChannelID:       testChannelID,
+		"USER:" + testUserID:             "user:" + testUserID,
+		"@alice":                         "@alice",
+		testChannelID + ":" + testRootID: testChannelID + ":" + testRootID,
+	}
+	for input, want := range cases {
+		if got := normalizeTarget(input); got != want {
+			t.Errorf("normalizeTarget(%q) = %q, want %q", input, got, want)
+		}
+	}
+	conversation, root := splitTarget("channel:" + testChannelID + ":" + testRootID)
+	if conversation != testChannelID || root != testRootID {
+		t.Fatalf("splitTarget = %q, %q", conversation, root)
+	}
+	conversation, root = splitTarget("user:" + testUserID)
+	if conversation != "user:"+testUserID || root != "" {
+		t.Fatalf("splitTarget(user) = %q, %q", conversation, root)
+	}
+	if err := validateTarget("town-square"); err == nil {
+		t.Fatal("expected channel names to be rejected")
+	}
+}
+
+func TestResolveTargetAndBinding(t *testing.T) {
+	target, err := resolveTarget(map[string]any{"user_id": testUserID})
+	if err != nil || target != "user:"+testUserID {
+		t.Fatalf("resolveTarget = %q (%v)", target, err)
+	}
+	raw := map[string]any{"user_id": testUserID, "username": "@Alice"}
+	if !matchBinding(raw, channel.BindingCriteria{Attributes: map[string]string{"username": "alice"}}) {
+		t.Fatal("expected case-insensitive username match")
+	}
+	if matchBinding(raw, channel.BindingCriteria{SubjectID: testBotID}) {
+		t.Fatal("expected other users not to match")
+	}
+}
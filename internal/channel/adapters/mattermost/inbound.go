@@ -0,0 +1,225 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/channel/adapters/common"
+)
+
+// postedEvent is the data of a "posted" websocket event. The post and the
+// mention list arrive as JSON-encoded strings.
+type postedEvent struct {
+	ChannelType        string `json:"channel_type"`
+	ChannelName        string `json:"channel_name"`
+	ChannelDisplayName string `json:"channel_display_name"`
+	SenderName         string `json:"sender_name"`
+	Mentions           string `json:"mentions"`
+	Post               string `json:"post"`
+}
+
+func (a *MattermostAdapter) handlePosted(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, data json.RawMessage, handler channel.InboundHandler) {
+	var event postedEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		if a.logger != nil {
+			a.logger.Warn("decode posted event failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+		return
+	}
+	var post mattermostPost
+	if err := json.Unmarshal([]byte(event.Post), &post); err != nil {
+		if a.logger != nil {
+			a.logger.Warn("decode post failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+		return
+	}
+	msg, ok := a.buildInboundMessage(ctx, cfg, parsed, selfID, event, post)
+	if !ok {
+		return
+	}
+	a.dispatch(ctx, cfg, msg, handler)
+}
+
+// buildInboundMessage maps a post by a person. System posts (joins, header
+// changes), posts from bots and the bot's own posts are skipped.
+func (a *MattermostAdapter) buildInboundMessage(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, event postedEvent, post mattermostPost) (channel.InboundMessage, bool) {
+	if post.ID == "" || post.ChannelID == "" || post.UserID == "" || post.UserID == selfID || post.Type != "" {
+		return channel.InboundMessage{}, false
+	}
+	if fromBot, _ := post.Props["from_bot"].(string); fromBot == "true" {
+		return channel.InboundMessage{}, false
+	}
+	text := strings.TrimSpace(post.Message)
+	attachments := collectAttachments(post.Metadata.Files)
+	if text == "" && len(attachments) == 0 {
+		return channel.InboundMessage{}, false
+	}
+	if a.isDuplicateInbound(cfg.ID, post.ID) {
+		return channel.InboundMessage{}, false
+	}
+
+	conversation := channel.Conversation{
+		ID:   post.ChannelID,
+		Type: conversationType(event.ChannelType),
+		Name: strings.TrimSpace(event.ChannelDisplayName),
+	}
+	message := channel.Message{
+		ID:          post.ID,
+		Format:      channel.MessageFormatPlain,
+		Text:        text,
+		Attachments: attachments,
+	}
+	replyTarget := post.ChannelID
+	isReplyToBot := false
+	if post.RootID != "" {
+		conversation.Type = channel.ConversationTypeThread
+		conversation.ThreadID = post.RootID
+		message.Thread = &channel.ThreadRef{ID: post.RootID}
+		replyTarget = joinTarget(post.ChannelID, post.RootID)
+		isReplyToBot = a.postAuthor(ctx, parsed, post.RootID) == selfID
+	}
+	var mentions []string
+	_ = json.Unmarshal([]byte(event.Mentions), &mentions)
+	username := strings.TrimPrefix(strings.TrimSpace(event.SenderName), "@")
+	if username == "" {
+		username = a.userName(ctx, parsed, post.UserID)
+	}
+
+	return channel.InboundMessage{
+		Channel:     Type,
+		Message:     message,
+		BotID:       cfg.BotID,
+		ReplyTarget: replyTarget,
+		Sender: channel.Identity{
+			SubjectID:   post.UserID,
+			DisplayName: username,
+			Attributes: map[string]string{
+				"user_id":  post.UserID,
+				"username": username,
+			},
+		},
+		Conversation: conversation,
+		ReceivedAt:   postTime(post.CreateAt),
+		Source:       "mattermost",
+		Metadata: map[string]any{
+			"is_mentioned":    slices.Contains(mentions, selfID),
+			"is_reply_to_bot": isReplyToBot,
+			"raw_text":        text,
+		},
+	}, true
+}
+
+func (a *MattermostAdapter) dispatch(ctx context.Context, cfg channel.ChannelConfig, msg channel.InboundMessage, handler channel.InboundHandler) {
+	if a.logger != nil {
+		a.logger.Info("inbound received",
+			slog.String("config_id", cfg.ID),
+			slog.String("chat_type", msg.Conversation.Type),
+			slog.String("user_id", msg.Sender.SubjectID),
+			slog.String("username", msg.Sender.DisplayName),
+			slog.String("text", common.SummarizeText(msg.Message.Text)),
+		)
+	}
+	go func() {
+		if err := handler(ctx, cfg, msg); err != nil && a.logger != nil {
+			a.logger.Error("handle inbound failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+	}()
+}
+
+// isDuplicateInbound guards against a post being delivered twice, e.g. around
+// a websocket reconnect.
+func (a *MattermostAdapter) isDuplicateInbound(configID, postID string) bool {
+	now := time.Now().UTC()
+	expireBefore := now.Add(-inboundDedupTTL)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, seenAt := range a.seenMessages {
+		if seenAt.Before(expireBefore) {
+			delete(a.seenMessages, key)
+		}
+	}
+	key := configID + ":" + postID
+	if _, ok := a.seenMessages[key]; ok {
+		return true
+	}
+	a.seenMessages[key] = now
+	return false
+}
+
+// postAuthor returns the author of a post, or "" when it cannot be read.
+func (a *MattermostAdapter) postAuthor(ctx context.Context, cfg Config, postID string) string {
+	var post mattermostPost
+	if err := a.call(ctx, cfg, http.MethodGet, "/posts/"+url.PathEscape(postID), nil, &post); err != nil {
+		return ""
+	}
+	return post.UserID
+}
+
+// userName returns a user's username, falling back to the ID when the lookup
+// fails.
+func (a *MattermostAdapter) userName(ctx context.Context, cfg Config, userID string) string {
+	key := cfg.ServerURL + ":" + cfg.Token + ":" + userID
+	a.mu.Lock()
+	name, ok := a.userNames[key]
+	a.mu.Unlock()
+	if ok {
+		return name
+	}
+	user, err := a.user(ctx, cfg, userID)
+	if err != nil || user.Username == "" {
+		return userID
+	}
+	a.mu.Lock()
+	a.userNames[key] = user.Username
+	a.mu.Unlock()
+	return user.Username
+}
+
+// conversationType maps Mattermost channel types: D is a direct message,
+// O/P are public/private channels and G is a group message.
+func conversationType(channelType string) string {
+	if channelType == "D" {
+		return channel.ConversationTypePrivate
+	}
+	return channel.ConversationTypeGroup
+}
+
+func collectAttachments(files []mattermostFile) []channel.Attachment {
+	if len(files) == 0 {
+		return nil
+	}
+	attachments := make([]channel.Attachment, 0, len(files))
+	for _, file := range files {
+		if file.ID == "" {
+			continue
+		}
+		// Files need the bot token, so the file ID travels as the platform
+		// key and is fetched through ResolveAttachment.
+		attachments = append(attachments, channel.Attachment{
+			Type:           channel.InferAttachmentType(channel.AttachmentFile, file.MimeType, file.Name),
+			PlatformKey:    file.ID,
+			SourcePlatform: Type.String(),
+			Name:           file.Name,
+			Size:           file.Size,
+			Mime:           file.MimeType,
+			Width:          file.Width,
+			Height:         file.Height,
+		})
+	}
+	return attachments
+}
+
+func postTime(createAt int64) time.Time {
+	if createAt <= 0 {
+		return time.Now().UTC()
+	}
+	return time.UnixMilli(createAt).UTC()
+}
@@ -0,0 +1,604 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/media"
+)
+
+const Type channel.ChannelType = "mattermost"
+
+const (
+	inboundDedupTTL = 10 * time.Minute
+	// mattermostPostMaxLen stays under the server's default MaxPostSize of
+	// 16383 characters.
+	mattermostPostMaxLen      = 16000
+	mattermostMaxFilesPerPost = 5
+	mattermostMaxDownloadSize = media.MaxAssetBytes
+)
+
+// assetOpener reads stored asset bytes by content hash.
+type assetOpener interface {
+	Open(ctx context.Context, botID, contentHash string) (io.ReadCloser, media.Asset, error)
+}
+
+type MattermostAdapter struct {
+	logger     *slog.Logger
+	httpClient *http.Client
+	assets     assetOpener
+	policies   channel.FetchPolicyResolver
+
+	mu           sync.Mutex
+	seenMessages map[string]time.Time // keyed by configID:postID
+	selfUsers    map[string]string    // server url:token -> bot user id
+	dmChannels   map[string]string    // server url:token:user -> DM channel id
+	userNames    map[string]string    // server url:token:user id -> username
+}
+
+func NewMattermostAdapter(log *slog.Logger) *MattermostAdapter {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &MattermostAdapter{
+		logger:       log.With(slog.String("adapter", "mattermost")),
+		httpClient:   &http.Client{Timeout: mattermostDefaultTimeout},
+		seenMessages: make(map[string]time.Time),
+		selfUsers:    make(map[string]string),
+		dmChannels:   make(map[string]string),
+		userNames:    make(map[string]string),
+	}
+}
+
+// SetAssetOpener configures the asset opener for reading stored attachments by content hash.
+func (a *MattermostAdapter) SetAssetOpener(opener assetOpener) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.assets = opener
+}
+
+// SetFetchPolicyResolver configures the per-bot policy that agent-supplied
+// attachment URLs are fetched under.
+func (a *MattermostAdapter) SetFetchPolicyResolver(resolver channel.FetchPolicyResolver) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.policies = resolver
+}
+
+func (*MattermostAdapter) Type() channel.ChannelType {
+	return Type
+}
+
+func (*MattermostAdapter) Descriptor() channel.Descriptor {
+	return channel.Descriptor{
+		Type:        Type,
+		DisplayName: "Mattermost",
+		Capabilities: channel.ChannelCapabilities{
+			Text:           true,
+			Markdown:       true,
+			Attachments:    true,
+			Media:          true,
+			Reactions:      true,
+			Reply:          true,
+			Threads:        true,
+			Streaming:      true,
+			BlockStreaming: true,
+			Edit:           true,
+			Unsend:         true,
+			ChatTypes:      []string{"direct", "group", "thread"},
+		},
+		OutboundPolicy: channel.OutboundPolicy{
+			TextChunkLimit: mattermostPostMaxLen,
+			ChunkerMode:    channel.ChunkerModeMarkdown,
+			MediaOrder:     channel.OutboundOrderTextFirst,
+		},
+		ConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"serverUrl": {
+					Type:        channel.FieldString,
+					Required:    true,
+					Title:       "Server URL",
+					Description: "Base URL of the Mattermost server",
+					Example:     "https://chat.example.com",
+				},
+				"token": {
+					Type:        channel.FieldSecret,
+					Required:    true,
+					Title:       "Access Token",
+					Description: "Bot account access token",
+				},
+			},
+		},
+		UserConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"user_id":    {Type: channel.FieldString, Title: "User ID"},
+				"channel_id": {Type: channel.FieldString, Title: "Channel ID"},
+				"username":   {Type: channel.FieldString, Title: "Username"},
+			},
+		},
+		TargetSpec: channel.TargetSpec{
+			Format: "channel_id[:root_id] | user:user_id | @username",
+			Hints: []channel.TargetHint{
+				{Label: "Channel ID", Example: "4xp9fdt77pncbef59f4k1qe83o"},
+				{Label: "Thread", Example: "4xp9fdt77pncbef59f4k1qe83o:ri3b4ku8k3d9xmwf6ffxmbkm7e"},
+				{Label: "User", Example: "user:9m3dwgcmj7n7ux3k8yhzd5wnor"},
+				{Label: "Username", Example: "@alice"},
+			},
+		},
+	}
+}
+
+func (*MattermostAdapter) NormalizeConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeConfig(raw)
+}
+
+func (*MattermostAdapter) NormalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeUserConfig(raw)
+}
+
+func (*MattermostAdapter) NormalizeTarget(raw string) string {
+	return normalizeTarget(raw)
+}
+
+func (*MattermostAdapter) ResolveTarget(userConfig map[string]any) (string, error) {
+	return resolveTarget(userConfig)
+}
+
+func (*MattermostAdapter) MatchBinding(config map[string]any, criteria channel.BindingCriteria) bool {
+	return matchBinding(config, criteria)
+}
+
+func (*MattermostAdapter) BuildUserConfig(identity channel.Identity) map[string]any {
+	return buildUserConfig(identity)
+}
+
+type mattermostPost struct {
+	ID        string         `json:"id"`
+	CreateAt  int64          `json:"create_at"`
+	UserID    string         `json:"user_id"`
+	ChannelID string         `json:"channel_id"`
+	RootID    string         `json:"root_id"`
+	Message   string         `json:"message"`
+	Type      string         `json:"type"`
+	FileIDs   []string       `json:"file_ids"`
+	Props     map[string]any `json:"props"`
+	Metadata  struct {
+		Files []mattermostFile `json:"files"`
+	} `json:"metadata"`
+}
+
+type mattermostFile struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+type mattermostUser struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Nickname  string `json:"nickname"`
+	IsBot     bool   `json:"is_bot"`
+}
+
+func (u mattermostUser) displayName() string {
+	if name := strings.TrimSpace(u.Nickname); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		return name
+	}
+	if u.Username != "" {
+		return u.Username
+	}
+	return u.ID
+}
+
+func (a *MattermostAdapter) Send(ctx context.Context, cfg channel.ChannelConfig, msg channel.OutboundMessage) error {
+	if msg.Message.IsEmpty() {
+		return errors.New("message is required")
+	}
+	if err := validateTarget(msg.Target); err != nil {
+		return err
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	channel.SetIMErrorSecrets("mattermost:"+cfg.ID, parsed.Token)
+	conversation, rootID, err := a.resolveConversation(ctx, parsed, msg.Target)
+	if err != nil {
+		return err
+	}
+	rootID = messageRootID(msg.Message, rootID)
+	fileIDs, err := a.uploadAttachments(ctx, parsed, cfg.BotID, conversation, msg.Message.Attachments)
+	if err != nil {
+		return err
+	}
+	text := strings.TrimSpace(msg.Message.PlainText())
+	// A post carries at most five files; the text rides on the first post.
+	for len(fileIDs) > mattermostMaxFilesPerPost {
+		if _, err := a.createPost(ctx, parsed, conversation, rootID, text, fileIDs[:mattermostMaxFilesPerPost]); err != nil {
+			return err
+		}
+		text, fileIDs = "", fileIDs[mattermostMaxFilesPerPost:]
+	}
+	if text == "" && len(fileIDs) == 0 {
+		return nil
+	}
+	_, err = a.createPost(ctx, parsed, conversation, rootID, text, fileIDs)
+	return err
+}
+
+// messageRootID picks the thread a message goes to: an explicit thread on the
+// message wins over the one carried by the target.
+func messageRootID(msg channel.Message, targetRoot string) string {
+	if msg.Thread != nil && strings.TrimSpace(msg.Thread.ID) != "" {
+		return strings.TrimSpace(msg.Thread.ID)
+	}
+	return targetRoot
+}
+
+func (a *MattermostAdapter) createPost(ctx context.Context, cfg Config, channelID, rootID, text string, fileIDs []string) (mattermostPost, error) {
+	body := map[string]any{
+		"channel_id": channelID,
+		"message":    text,
+	}
+	if rootID != "" {
+		body["root_id"] = rootID
+	}
+	if len(fileIDs) > 0 {
+		body["file_ids"] = fileIDs
+	}
+	var post mattermostPost
+	if err := a.call(ctx, cfg, http.MethodPost, "/posts", body, &post); err != nil {
+		return mattermostPost{}, err
+	}
+	return post, nil
+}
+
+func (a *MattermostAdapter) patchPost(ctx context.Context, cfg Config, postID, text string) error {
+	if postID == "" {
+		return errors.New("mattermost post id is required")
+	}
+	return a.call(ctx, cfg, http.MethodPut, "/posts/"+url.PathEscape(postID)+"/patch", map[string]any{"message": text}, nil)
+}
+
+// OpenStream streams a reply by creating a post and patching it as text
+// arrives. Mattermost has no quoted replies, so opts.Reply is not used;
+// thread replies come from the target.
+func (a *MattermostAdapter) OpenStream(_ context.Context, cfg channel.ChannelConfig, target string, _ channel.StreamOptions) (channel.OutboundStream, error) {
+	if err := validateTarget(target); err != nil {
+		return nil, err
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	return &mattermostOutboundStream{
+		adapter: a,
+		cfg:     parsed,
+		botID:   cfg.BotID,
+		target:  normalizeTarget(target),
+	}, nil
+}
+
+func (a *MattermostAdapter) Update(ctx context.Context, cfg channel.ChannelConfig, _ string, messageID string, msg channel.Message) error {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	return a.patchPost(ctx, parsed, strings.TrimSpace(messageID), strings.TrimSpace(msg.PlainText()))
+}
+
+func (a *MattermostAdapter) Unsend(ctx context.Context, cfg channel.ChannelConfig, _ string, messageID string) error {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	postID := strings.TrimSpace(messageID)
+	if postID == "" {
+		return errors.New("mattermost post id is required")
+	}
+	return a.call(ctx, parsed, http.MethodDelete, "/posts/"+url.PathEscape(postID), nil, nil)
+}
+
+func (a *MattermostAdapter) React(ctx context.Context, cfg channel.ChannelConfig, _ string, messageID string, emoji string) error {
+	parsed, selfID, name, err := a.reactionArgs(ctx, cfg, messageID, emoji)
+	if err != nil {
+		return err
+	}
+	return a.call(ctx, parsed, http.MethodPost, "/reactions", map[string]any{
+		"user_id":    selfID,
+		"post_id":    strings.TrimSpace(messageID),
+		"emoji_name": name,
+	}, nil)
+}
+
+func (a *MattermostAdapter) Unreact(ctx context.Context, cfg channel.ChannelConfig, _ string, messageID string, emoji string) error {
+	parsed, selfID, name, err := a.reactionArgs(ctx, cfg, messageID, emoji)
+	if err != nil {
+		return err
+	}
+	path := "/users/" + url.PathEscape(selfID) + "/posts/" + url.PathEscape(strings.TrimSpace(messageID)) + "/reactions/" + url.PathEscape(name)
+	err = a.call(ctx, parsed, http.MethodDelete, path, nil, nil)
+	// Removing a reaction that is not there is not worth failing over.
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func (a *MattermostAdapter) reactionArgs(ctx context.Context, cfg channel.ChannelConfig, messageID, emoji string) (Config, string, string, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return Config{}, "", "", err
+	}
+	if strings.TrimSpace(messageID) == "" {
+		return Config{}, "", "", errors.New("mattermost post id is required")
+	}
+	name := reactionName(emoji)
+	if name == "" {
+		return Config{}, "", "", errors.New("mattermost reaction emoji is required")
+	}
+	selfID, err := a.selfID(ctx, parsed)
+	if err != nil {
+		return Config{}, "", "", err
+	}
+	return parsed, selfID, name, nil
+}
+
+// mattermostReactionNames maps the unicode emoji agents usually react with to
+// Mattermost's emoji names; anything else is passed through as a name.
+var mattermostReactionNames = map[string]string{
+	"👍":  "+1",
+	"👎":  "-1",
+	"❤️": "heart",
+	"❤":  "heart",
+	"😂":  "joy",
+	"😄":  "smile",
+	"😊":  "blush",
+	"🎉":  "tada",
+	"🔥":  "fire",
+	"👀":  "eyes",
+	"✅":  "white_check_mark",
+	"❌":  "x",
+	"🤔":  "thinking_face",
+	"🙏":  "pray",
+	"👌":  "ok_hand",
+	"👏":  "clap",
+	"🚀":  "rocket",
+	"💯":  "100",
+	"😢":  "cry",
+	"😮":  "open_mouth",
+	"⏳":  "hourglass_flowing_sand",
+	"✍️": "writing_hand",
+}
+
+func reactionName(emoji string) string {
+	value := strings.TrimSpace(emoji)
+	if name, ok := mattermostReactionNames[value]; ok {
+		return name
+	}
+	return strings.Trim(value, ":")
+}
+
+func (a *MattermostAdapter) DiscoverSelf(ctx context.Context, credentials map[string]any) (map[string]any, string, error) {
+	cfg, err := parseConfig(credentials)
+	if err != nil {
+		return nil, "", err
+	}
+	me, err := a.me(ctx, cfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("mattermost discover self: %w", err)
+	}
+	return map[string]any{
+		"user_id":  me.ID,
+		"username": me.Username,
+		"name":     me.displayName(),
+	}, me.ID, nil
+}
+
+func (a *MattermostAdapter) me(ctx context.Context, cfg Config) (mattermostUser, error) {
+	var user mattermostUser
+	if err := a.call(ctx, cfg, http.MethodGet, "/users/me", nil, &user); err != nil {
+		return mattermostUser{}, err
+	}
+	if strings.TrimSpace(user.ID) == "" {
+		return mattermostUser{}, errors.New("mattermost /users/me returned no id")
+	}
+	a.mu.Lock()
+	a.selfUsers[cfg.ServerURL+":"+cfg.Token] = user.ID
+	a.mu.Unlock()
+	return user, nil
+}
+
+func (a *MattermostAdapter) selfID(ctx context.Context, cfg Config) (string, error) {
+	a.mu.Lock()
+	id, ok := a.selfUsers[cfg.ServerURL+":"+cfg.Token]
+	a.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	user, err := a.me(ctx, cfg)
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
+func (a *MattermostAdapter) user(ctx context.Context, cfg Config, userID string) (mattermostUser, error) {
+	var user mattermostUser
+	if err := a.call(ctx, cfg, http.MethodGet, "/users/"+url.PathEscape(userID), nil, &user); err != nil {
+		return mattermostUser{}, err
+	}
+	return user, nil
+}
+
+// resolveConversation turns a target into a channel ID and thread root.
+// User targets are opened as direct channels with the bot.
+func (a *MattermostAdapter) resolveConversation(ctx context.Context, cfg Config, target string) (string, string, error) {
+	if err := validateTarget(target); err != nil {
+		return "", "", err
+	}
+	conversation, rootID := splitTarget(target)
+	var userID, username string
+	switch {
+	case strings.HasPrefix(conversation, userTargetPrefix):
+		userID = strings.TrimPrefix(conversation, userTargetPrefix)
+	case strings.HasPrefix(conversation, "@"):
+		username = strings.TrimPrefix(conversation, "@")
+	default:
+		return conversation, rootID, nil
+	}
+	key := cfg.ServerURL + ":" + cfg.Token + ":" + conversation
+	a.mu.Lock()
+	dm, ok := a.dmChannels[key]
+	a.mu.Unlock()
+	if ok {
+		return dm, rootID, nil
+	}
+	if username != "" {
+		var user mattermostUser
+		if err := a.call(ctx, cfg, http.MethodGet, "/users/username/"+url.PathEscape(username), nil, &user); err != nil {
+			return "", "", err
+		}
+		userID = user.ID
+	}
+	selfID, err := a.selfID(ctx, cfg)
+	if err != nil {
+		return "", "", err
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := a.call(ctx, cfg, http.MethodPost, "/channels/direct", []string{selfID, userID}, &created); err != nil {
+		return "", "", err
+	}
+	if created.ID == "" {
+		return "", "", errors.New("mattermost /channels/direct returned no channel")
+	}
+	a.mu.Lock()
+	a.dmChannels[key] = created.ID
+	a.mu.Unlock()
+	return created.ID, rootID, nil
+}
+
+func (a *MattermostAdapter) uploadAttachments(ctx context.Context, cfg Config, botID, channelID string, attachments []channel.Attachment) ([]string, error) {
+	fileIDs := make([]string, 0, len(attachments))
+	for _, att := range attachments {
+		data, name, err := a.loadAttachment(ctx, botID, att)
+		if err != nil {
+			return nil, err
+		}
+		fileID, err := a.upload(ctx, cfg, channelID, name, data)
+		if err != nil {
+			return nil, err
+		}
+		fileIDs = append(fileIDs, fileID)
+	}
+	return fileIDs, nil
+}
+
+// loadAttachment reads an outbound attachment through the shared attachment
+// source, so agent-supplied URLs are checked against the bot's fetch policy.
+func (a *MattermostAdapter) loadAttachment(ctx context.Context, botID string, att channel.Attachment) ([]byte, string, error) {
+	name := strings.TrimSpace(att.Name)
+	if name == "" {
+		name = "attachment" + mimeExtension(att.Mime)
+	}
+	a.mu.Lock()
+	source := channel.AttachmentSource{Assets: a.assets, Policies: a.policies, MaxBytes: mattermostMaxDownloadSize}
+	a.mu.Unlock()
+	data, err := source.Load(ctx, botID, att)
+	if err != nil {
+		return nil, "", fmt.Errorf("mattermost attachment: %w", err)
+	}
+	return data, name, nil
+}
+
+// download fetches a file from the configured Mattermost server with the
+// bot token. It must not be used for agent-supplied URLs.
+func (a *MattermostAdapter) download(ctx context.Context, link, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := a.httpClient.Do(req) //nolint:gosec // G704: URL is a file URL on the configured Mattermost server
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, mattermostMaxDownloadSize))
+}
+
+// ResolveAttachment downloads an inbound Mattermost file. Files are only
+// readable with the bot token, so inbound attachments carry the file ID as
+// the platform key.
+func (a *MattermostAdapter) ResolveAttachment(ctx context.Context, cfg channel.ChannelConfig, attachment channel.Attachment) (channel.AttachmentPayload, error) {
+	fileID := strings.TrimSpace(attachment.PlatformKey)
+	if fileID == "" {
+		return channel.AttachmentPayload{}, errors.New("mattermost attachment requires platform_key")
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return channel.AttachmentPayload{}, err
+	}
+	data, err := a.download(ctx, parsed.ServerURL+"/api/v4/files/"+url.PathEscape(fileID), parsed.Token)
+	if err != nil {
+		return channel.AttachmentPayload{}, fmt.Errorf("download mattermost file: %w", err)
+	}
+	return channel.AttachmentPayload{
+		Reader: io.NopCloser(bytes.NewReader(data)),
+		Mime:   strings.TrimSpace(attachment.Mime),
+		Name:   strings.TrimSpace(attachment.Name),
+		Size:   int64(len(data)),
+	}, nil
+}
+
+// mimeExtension returns file extension for common mime types.
+func mimeExtension(mime string) string {
+	switch mime {
+	case "image/jpeg", "image/jpg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "video/mp4":
+		return ".mp4"
+	case "audio/mpeg", "audio/mp3":
+		return ".mp3"
+	case "audio/ogg":
+		return ".ogg"
+	case "application/pdf":
+		return ".pdf"
+	case "text/plain":
+		return ".txt"
+	default:
+		return ""
+	}
+}
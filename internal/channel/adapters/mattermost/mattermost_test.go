@@ -0,0 +1,380 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const (
+	testBotID     = "botuserid0000000000000000a"
+	testUserID    = "aliceid00000000000000000aa"
+	testChannelID = "townsquare0000000000000000"
+	testRootID    = "rootpost000000000000000000"
+)
+
+type fakeCall struct {
+	Method string
+	Path   string
+	Token  string
+	Body   any
+}
+
+// fakeMattermost is a local stand-in for the REST API and the event
+// websocket.
+type fakeMattermost struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	calls    []fakeCall
+	events   chan any
+	upgrader websocket.Upgrader
+}
+
+func newFakeMattermost(t *testing.T) *fakeMattermost {
+	t.Helper()
+	f := &fakeMattermost{events: make(chan any, 16)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/websocket", f.handleWebsocket)
+	mux.HandleFunc("/api/v4/", f.handleAPI)
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeMattermost) config() channel.ChannelConfig {
+	return channel.ChannelConfig{
+		ID:          "cfg-1",
+		BotID:       "bot-1",
+		ChannelType: Type,
+		Credentials: map[string]any{
+			"serverUrl": f.server.URL + "/",
+			"token":     "mm-token",
+		},
+	}
+}
+
+func (f *fakeMattermost) callsTo(method, path string) []fakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []fakeCall
+	for _, call := range f.calls {
+		if call.Method == method && call.Path == path {
+			out = append(out, call)
+		}
+	}
+	return out
+}
+
+func (f *fakeMattermost) handleAPI(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v4")
+	var body any
+	switch {
+	case strings.HasPrefix(r.Header.Get("Content-Type"), "application/json"):
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	case strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"):
+		if err := r.ParseMultipartForm(1 << 20); err == nil {
+			body = map[string]any{"channel_id": r.FormValue("channel_id")}
+		}
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	f.mu.Lock()
+	f.calls = append(f.calls, fakeCall{Method: r.Method, Path: path, Token: token, Body: body})
+	f.mu.Unlock()
+	if token != "mm-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"id":"api.context.session_expired.app_error","message":"Invalid or expired session","status_code":401}`)
+		return
+	}
+
+	var resp any = map[string]any{"status": "OK"}
+	switch {
+	case path == "/users/me":
+		resp = map[string]any{"id": testBotID, "username": "memoh", "nickname": "Memoh"}
+	case path == "/users/"+testUserID:
+		resp = map[string]any{"id": testUserID, "username": "alice"}
+	case path == "/users/username/alice":
+		resp = map[string]any{"id": testUserID, "username": "alice"}
+	case path == "/channels/direct":
+		resp = map[string]any{"id": "directchannel0000000000000"}
+	case path == "/posts" && r.Method == http.MethodPost:
+		resp = map[string]any{"id": "newpost0000000000000000000"}
+	case path == "/posts/"+testRootID:
+		resp = map[string]any{"id": testRootID, "user_id": testBotID}
+	case path == "/posts/missing":
+		w.WriteHeader(http.StatusNotFound)
+		resp = map[string]any{"id": "app.post.get.app_error", "message": "Unable to get the post.", "status_code": 404}
+	case path == "/files":
+		resp = map[string]any{"file_infos": []any{map[string]any{"id": "uploaded000000000000000000"}}}
+	case path == "/files/file1":
+		_, _ = io.WriteString(w, "file-bytes")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeMattermost) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer mm-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	conn, err := f.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.WriteJSON(map[string]any{"event": "hello", "data": map[string]any{"server_version": "9.0"}, "seq": 0})
+	for {
+		select {
+		case event := <-f.events:
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func postedEventFrame(t *testing.T, channelType string, post map[string]any, mentions []string) map[string]any {
+	t.Helper()
+	postJSON, err := json.Marshal(post)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mentionsJSON, err := json.Marshal(mentions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]any{
+		"event": "posted",
+		"data": map[string]any{
+			"channel_type":         channelType,
+			"channel_display_name": "Town Square",
+			"sender_name":          "@alice",
+			"mentions":             string(mentionsJSON),
+			"post":                 string(postJSON),
+		},
+	}
+}
+
+func newTestAdapter() *MattermostAdapter {
+	return NewMattermostAdapter(slog.New(slog.DiscardHandler))
+}
+
+func TestSendPostsThreadReply(t *testing.T) {
+	f := newFakeMattermost(t)
+	err := newTestAdapter().Send(context.Background(), f.config(), channel.OutboundMessage{
+		Target: testChannelID + ":" + testRootID,
+		Message: channel.Message{
+			Text:        "see **attached**",
+			Attachments: []channel.Attachment{{Base64: "data:text/plain;base64,aGk=", Name: "hi.txt"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if uploads := f.callsTo(http.MethodPost, "/files"); len(uploads) != 1 || uploads[0].Body.(map[string]any)["channel_id"] != testChannelID {
+		t.Fatalf("expected one upload to the channel, got %v", uploads)
+	}
+	posts := f.callsTo(http.MethodPost, "/posts")
+	if len(posts) != 1 {
+		t.Fatalf("expected one post, got %d", len(posts))
+	}
+	body := posts[0].Body.(map[string]any)
+	if body["channel_id"] != testChannelID || body["root_id"] != testRootID || body["message"] != "see **attached**" {
+		t.Fatalf("unexpected post: %v", body)
+	}
+	if files, _ := body["file_ids"].([]any); len(files) != 1 || files[0] != "uploaded000000000000000000" {
+		t.Fatalf("expected the uploaded file on the post, got %v", body["file_ids"])
+	}
+}
+
+func TestSendToUserOpensDirectChannel(t *testing.T) {
+	f := newFakeMattermost(t)
+	adapter := newTestAdapter()
+	for _, target := range []string{"user:" + testUserID, "user:" + testUserID, "@alice"} {
+		if err := adapter.Send(context.Background(), f.config(), channel.OutboundMessage{Target: target, Message: channel.Message{Text: "hi"}}); err != nil {
+			t.Fatalf("Send(%s): %v", target, err)
+		}
+	}
+	directs := f.callsTo(http.MethodPost, "/channels/direct")
+	if len(directs) != 2 {
+		t.Fatalf("expected one cached direct channel per target form, got %d", len(directs))
+	}
+	members, _ := directs[0].Body.([]any)
+	if len(members) != 2 || members[0] != testBotID || members[1] != testUserID {
+		t.Fatalf("unexpected direct channel members: %v", directs[0].Body)
+	}
+	for _, post := range f.callsTo(http.MethodPost, "/posts") {
+		if post.Body.(map[string]any)["channel_id"] != "directchannel0000000000000" {
+			t.Fatalf("expected direct channel, got %v", post.Body)
+		}
+	}
+}
+
+func TestSendSurfacesAPIErrors(t *testing.T) {
+	f := newFakeMattermost(t)
+	cfg := f.config()
+	cfg.Credentials["token"] = "wrong"
+	err := newTestAdapter().Send(context.Background(), cfg, channel.OutboundMessage{Target: testChannelID, Message: channel.Message{Text: "hi"}})
+	if err == nil || !strings.Contains(err.Error(), "Invalid or expired session") {
+		t.Fatalf("expected session error, got %v", err)
+	}
+}
+
+func TestStreamPostsThenPatches(t *testing.T) {
+	f := newFakeMattermost(t)
+	stream, err := newTestAdapter().OpenStream(context.Background(), f.config(), testChannelID, channel.StreamOptions{})
+	if err != nil {
+		t.Fatalf("OpenStream: %v", err)
+	}
+	ctx := context.Background()
+	for _, delta := range []string{"Hel", "lo"} {
+		if err := stream.Push(ctx, channel.StreamEvent{Type: channel.StreamEventDelta, Delta: delta}); err != nil {
+			t.Fatalf("Push delta: %v", err)
+		}
+	}
+	if err := stream.Push(ctx, channel.StreamEvent{Type: channel.StreamEventFinal, Final: &channel.StreamFinalizePayload{
+		Message: channel.Message{Text: "Hello world"},
+	}}); err != nil {
+		t.Fatalf("Push final: %v", err)
+	}
+	posts := f.callsTo(http.MethodPost, "/posts")
+	if len(posts) != 1 || posts[0].Body.(map[string]any)["message"] != "Hel" {
+		t.Fatalf("expected the first delta to be posted, got %v", posts)
+	}
+	patches := f.callsTo(http.MethodPut, "/posts/newpost0000000000000000000/patch")
+	if len(patches) != 1 || patches[0].Body.(map[string]any)["message"] != "Hello world" {
+		t.Fatalf("expected throttled deltas and one final patch, got %v", patches)
+	}
+}
+
+func TestReactAndUnreact(t *testing.T) {
+	f := newFakeMattermost(t)
+	adapter := newTestAdapter()
+	if err := adapter.React(context.Background(), f.config(), testChannelID, "post1", "👍"); err != nil {
+		t.Fatalf("React: %v", err)
+	}
+	calls := f.callsTo(http.MethodPost, "/reactions")
+	if len(calls) != 1 {
+		t.Fatalf("expected one reaction, got %v", calls)
+	}
+	body := calls[0].Body.(map[string]any)
+	if body["user_id"] != testBotID || body["post_id"] != "post1" || body["emoji_name"] != "+1" {
+		t.Fatalf("unexpected reaction: %v", body)
+	}
+	if err := adapter.Unreact(context.Background(), f.config(), testChannelID, "post1", ":tada:"); err != nil {
+		t.Fatalf("Unreact: %v", err)
+	}
+	if calls := f.callsTo(http.MethodDelete, "/users/"+testBotID+"/posts/post1/reactions/tada"); len(calls) != 1 {
+		t.Fatalf("expected reaction removal, got %v", calls)
+	}
+}
+
+func TestDiscoverSelf(t *testing.T) {
+	f := newFakeMattermost(t)
+	identity, externalID, err := newTestAdapter().DiscoverSelf(context.Background(), f.config().Credentials)
+	if err != nil {
+		t.Fatalf("DiscoverSelf: %v", err)
+	}
+	if externalID != testBotID || identity["username"] != "memoh" || identity["name"] != "Memoh" {
+		t.Fatalf("unexpected identity: %v (%s)", identity, externalID)
+	}
+}
+
+func TestResolveAttachmentUsesToken(t *testing.T) {
+	f := newFakeMattermost(t)
+	payload, err := newTestAdapter().ResolveAttachment(context.Background(), f.config(), channel.Attachment{
+		PlatformKey: "file1",
+		Name:        "report.pdf",
+		Mime:        "application/pdf",
+	})
+	if err != nil {
+		t.Fatalf("ResolveAttachment: %v", err)
+	}
+	defer func() { _ = payload.Reader.Close() }()
+	data, _ := io.ReadAll(payload.Reader)
+	if string(data) != "file-bytes" || payload.Name != "report.pdf" {
+		t.Fatalf("unexpected payload: %q %q", data, payload.Name)
+	}
+}
+
+func TestConnectDeliversThreadPosts(t *testing.T) {
+	f := newFakeMattermost(t)
+	received := make(chan channel.InboundMessage, 4)
+	conn, err := newTestAdapter().Connect(context.Background(), f.config(), func(_ context.Context, _ channel.ChannelConfig, msg channel.InboundMessage) error {
+		received <- msg
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() { _ = conn.Stop(context.Background()) }()
+
+	threadPost := map[string]any{
+		"id": "post1", "channel_id": testChannelID, "root_id": testRootID, "user_id": testUserID,
+		"message": "@memoh summarize", "create_at": 1700000000000,
+		"metadata": map[string]any{"files": []any{map[string]any{"id": "file1", "name": "a.png", "mime_type": "image/png", "size": 3}}},
+	}
+	f.events <- postedEventFrame(t, "O", threadPost, []string{testBotID})
+	f.events <- postedEventFrame(t, "O", threadPost, []string{testBotID})
+	f.events <- postedEventFrame(t, "O", map[string]any{"id": "post2", "channel_id": testChannelID, "user_id": testBotID, "message": "my own echo"}, nil)
+	f.events <- postedEventFrame(t, "O", map[string]any{"id": "post3", "channel_id": testChannelID, "user_id": testUserID, "type": "system_join_channel", "message": "alice joined"}, nil)
+	f.events <- postedEventFrame(t, "D", map[string]any{"id": "post4", "channel_id": "directchannel0000000000000", "user_id": testUserID, "message": "hello"}, nil)
+
+	var msgs []channel.InboundMessage
+	for len(msgs) < 2 {
+		select {
+		case msg := <-received:
+			msgs = append(msgs, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for inbound messages, got %d", len(msgs))
+		}
+	}
+	select {
+	case extra := <-received:
+		t.Fatalf("unexpected extra inbound message: %+v", extra)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	var threadMsg, directMsg channel.InboundMessage
+	for _, msg := range msgs {
+		if msg.Message.ID == "post1" {
+			threadMsg = msg
+		} else {
+			directMsg = msg
+		}
+	}
+	if threadMsg.Conversation.Type != channel.ConversationTypeThread || threadMsg.Conversation.ThreadID != testRootID ||
+		threadMsg.Message.Thread == nil || threadMsg.ReplyTarget != testChannelID+":"+testRootID {
+		t.Fatalf("thread not mapped: %+v", threadMsg)
+	}
+	if threadMsg.Metadata["is_mentioned"] != true || threadMsg.Metadata["is_reply_to_bot"] != true {
+		t.Fatalf("unexpected metadata: %v", threadMsg.Metadata)
+	}
+	if threadMsg.Sender.SubjectID != testUserID || threadMsg.Sender.DisplayName != "alice" || threadMsg.BotID != "bot-1" {
+		t.Fatalf("unexpected sender: %+v", threadMsg.Sender)
+	}
+	if len(threadMsg.Message.Attachments) != 1 {
+		t.Fatalf("expected one attachment, got %+v", threadMsg.Message.Attachments)
+	}
+	att := threadMsg.Message.Attachments[0]
+	if att.PlatformKey != "file1" || att.URL != "" || att.Type != channel.AttachmentImage {
+		t.Fatalf("attachment should be resolved through the adapter: %+v", att)
+	}
+	if directMsg.Conversation.Type != channel.ConversationTypePrivate || directMsg.ReplyTarget != "directchannel0000000000000" {
+		t.Fatalf("unexpected direct message: %+v", directMsg)
+	}
+}
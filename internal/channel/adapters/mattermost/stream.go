@@ -0,0 +1,205 @@
+package mattermost
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+// mattermostEditThrottle spaces out intermediate post patches so a long reply
+// does not run into the server's per-user rate limit.
+const mattermostEditThrottle = time.Second
+
+type mattermostOutboundStream struct {
+	adapter *MattermostAdapter
+	cfg     Config
+	botID   string
+	target  string
+
+	closed atomic.Bool
+	mu     sync.Mutex
+
+	channelID    string
+	rootID       string
+	postID       string
+	buffer       strings.Builder
+	lastText     string
+	lastEditedAt time.Time
+}
+
+func (s *mattermostOutboundStream) Push(ctx context.Context, event channel.StreamEvent) error {
+	if s == nil || s.adapter == nil {
+		return errors.New("mattermost stream not configured")
+	}
+	if s.closed.Load() {
+		return errors.New("mattermost stream is closed")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	switch event.Type {
+	case channel.StreamEventDelta:
+		if event.Phase == channel.StreamPhaseReasoning || event.Delta == "" {
+			return nil
+		}
+		s.mu.Lock()
+		s.buffer.WriteString(event.Delta)
+		text := s.buffer.String()
+		s.mu.Unlock()
+		return s.upsertText(ctx, text, false)
+	case channel.StreamEventPhaseEnd:
+		if event.Phase != channel.StreamPhaseText {
+			return nil
+		}
+		s.mu.Lock()
+		text := s.buffer.String()
+		s.mu.Unlock()
+		return s.upsertText(ctx, text, true)
+	case channel.StreamEventToolCallStart:
+		s.resetMessageState()
+		return nil
+	case channel.StreamEventError:
+		errText := channel.RedactIMErrorText(strings.TrimSpace(event.Error))
+		if errText == "" {
+			return nil
+		}
+		return s.upsertText(ctx, "Error: "+errText, true)
+	case channel.StreamEventAttachment:
+		return s.pushAttachments(ctx, event.Attachments)
+	case channel.StreamEventFinal:
+		if event.Final == nil {
+			return errors.New("mattermost stream final payload is required")
+		}
+		msg := event.Final.Message
+		text := strings.TrimSpace(msg.PlainText())
+		if text == "" {
+			s.mu.Lock()
+			text = s.buffer.String()
+			s.mu.Unlock()
+		}
+		if err := s.upsertText(ctx, text, true); err != nil {
+			return err
+		}
+		if err := s.pushAttachments(ctx, msg.Attachments); err != nil {
+			return err
+		}
+		s.resetMessageState()
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (s *mattermostOutboundStream) Close(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.closed.Store(true)
+	return nil
+}
+
+// upsertText creates the reply post on the first chunk and patches it as more
+// text arrives. Intermediate patches are throttled; forced ones always go out.
+func (s *mattermostOutboundStream) upsertText(ctx context.Context, text string, force bool) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	channelID, rootID, err := s.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	postID := s.postID
+	lastText := s.lastText
+	lastEditedAt := s.lastEditedAt
+	s.mu.Unlock()
+
+	if postID == "" {
+		post, err := s.adapter.createPost(ctx, s.cfg, channelID, rootID, text, nil)
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.postID = post.ID
+		s.lastText = text
+		s.lastEditedAt = time.Now()
+		s.mu.Unlock()
+		return nil
+	}
+	if text == lastText {
+		return nil
+	}
+	if !force && time.Since(lastEditedAt) < mattermostEditThrottle {
+		return nil
+	}
+	if err := s.adapter.patchPost(ctx, s.cfg, postID, text); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.lastText = text
+	s.lastEditedAt = time.Now()
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *mattermostOutboundStream) resolve(ctx context.Context) (string, string, error) {
+	s.mu.Lock()
+	channelID, rootID := s.channelID, s.rootID
+	s.mu.Unlock()
+	if channelID != "" {
+		return channelID, rootID, nil
+	}
+	channelID, rootID, err := s.adapter.resolveConversation(ctx, s.cfg, s.target)
+	if err != nil {
+		return "", "", err
+	}
+	s.mu.Lock()
+	s.channelID, s.rootID = channelID, rootID
+	s.mu.Unlock()
+	return channelID, rootID, nil
+}
+
+func (s *mattermostOutboundStream) resetMessageState() {
+	s.mu.Lock()
+	s.postID = ""
+	s.buffer.Reset()
+	s.lastText = ""
+	s.lastEditedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *mattermostOutboundStream) pushAttachments(ctx context.Context, attachments []channel.Attachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	channelID, rootID, err := s.resolve(ctx)
+	if err != nil {
+		return err
+	}
+	fileIDs, err := s.adapter.uploadAttachments(ctx, s.cfg, s.botID, channelID, attachments)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(fileIDs); start += mattermostMaxFilesPerPost {
+		end := min(start+mattermostMaxFilesPerPost, len(fileIDs))
+		if _, err := s.adapter.createPost(ctx, s.cfg, channelID, rootID, "", fileIDs[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+// websocketEvent is a server event frame. Replies to our own requests carry
+// seq_reply instead of an event name and are ignored.
+type websocketEvent struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+	Seq   int64           `json:"seq"`
+}
+
+var websocketReconnectBackoffs = []time.Duration{time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 20 * time.Second}
+
+func (a *MattermostAdapter) Connect(ctx context.Context, cfg channel.ChannelConfig, handler channel.InboundHandler) (channel.Connection, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	channel.SetIMErrorSecrets("mattermost:"+cfg.ID, parsed.Token)
+	me, err := a.me(ctx, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("mattermost token check failed: %w", err)
+	}
+	if a.logger != nil {
+		a.logger.Info("start", slog.String("config_id", cfg.ID), slog.String("server", parsed.ServerURL), slog.String("user_id", me.ID))
+	}
+	connCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.runWebsocket(connCtx, cfg, parsed, me.ID, handler)
+	}()
+	return channel.NewConnection(cfg, func(context.Context) error {
+		if a.logger != nil {
+			a.logger.Info("stop", slog.String("config_id", cfg.ID))
+		}
+		cancel()
+		<-done
+		return nil
+	}), nil
+}
+
+// runWebsocket keeps the event websocket open until ctx ends, reconnecting
+// with backoff.
+func (a *MattermostAdapter) runWebsocket(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, handler channel.InboundHandler) {
+	attempt := 0
+	for ctx.Err() == nil {
+		healthy, err := a.websocketOnce(ctx, cfg, parsed, selfID, handler)
+		if ctx.Err() != nil {
+			return
+		}
+		if healthy {
+			attempt = 0
+		}
+		delay := websocketReconnectBackoffs[min(attempt, len(websocketReconnectBackoffs)-1)]
+		attempt++
+		if a.logger != nil {
+			a.logger.Warn("websocket reconnect", slog.String("config_id", cfg.ID), slog.Duration("delay", delay), slog.Any("error", err))
+		}
+		if !sleepContext(ctx, delay) {
+			return
+		}
+	}
+}
+
+// websocketOnce runs a single connection. healthy reports whether the server
+// said hello, i.e. the connection worked before it ended.
+func (a *MattermostAdapter) websocketOnce(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, handler channel.InboundHandler) (bool, error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+parsed.Token)
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, websocketURL(parsed.ServerURL), header)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return false, fmt.Errorf("dial websocket: %w", err)
+	}
+	var closeOnce sync.Once
+	closeConn := func() { closeOnce.Do(func() { _ = conn.Close() }) }
+	defer closeConn()
+	stop := context.AfterFunc(ctx, closeConn)
+	defer stop()
+
+	healthy := false
+	for {
+		var event websocketEvent
+		if err := conn.ReadJSON(&event); err != nil {
+			if ctx.Err() != nil {
+				return healthy, nil
+			}
+			return healthy, fmt.Errorf("read websocket event: %w", err)
+		}
+		switch event.Event {
+		case "hello":
+			healthy = true
+		case "posted":
+			a.handlePosted(ctx, cfg, parsed, selfID, event.Data, handler)
+		}
+	}
+}
+
+func websocketURL(serverURL string) string {
+	switch {
+	case strings.HasPrefix(serverURL, "https://"):
+		serverURL = "wss://" + strings.TrimPrefix(serverURL, "https://")
+	case strings.HasPrefix(serverURL, "http://"):
+		serverURL = "ws://" + strings.TrimPrefix(serverURL, "http://")
+	}
+	return serverURL + "/api/v4/websocket"
+}
@@ -0,0 +1,232 @@
+package signal
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const groupPrefix = "group:"
+
+var (
+	phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{5,14}$`)
+	uuidPattern  = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+type Config struct {
+	BaseURL string // signal-cli HTTP daemon, e.g. http://127.0.0.1:8080
+	Account string // the bot's registered phone number
+}
+
+type UserConfig struct {
+	Number string
+	UUID   string
+}
+
+func normalizeConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"baseUrl": cfg.BaseURL,
+		"account": cfg.Account,
+	}, nil
+}
+
+func normalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if cfg.Number != "" {
+		out["number"] = cfg.Number
+	}
+	if cfg.UUID != "" {
+		out["uuid"] = cfg.UUID
+	}
+	return out, nil
+}
+
+// resolveTarget prefers the UUID: it is stable and works when the contact
+// hides their phone number.
+func resolveTarget(raw map[string]any) (string, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return "", err
+	}
+	if cfg.UUID != "" {
+		return cfg.UUID, nil
+	}
+	return cfg.Number, nil
+}
+
+func matchBinding(raw map[string]any, criteria channel.BindingCriteria) bool {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return false
+	}
+	candidates := []string{
+		criteria.SubjectID,
+		criteria.Attribute("uuid"),
+		criteria.Attribute("number"),
+	}
+	for _, candidate := range candidates {
+		value := normalizeTarget(candidate)
+		if value == "" {
+			continue
+		}
+		if (cfg.UUID != "" && value == cfg.UUID) || (cfg.Number != "" && value == cfg.Number) {
+			return true
+		}
+	}
+	return false
+}
+
+func buildUserConfig(identity channel.Identity) map[string]any {
+	out := map[string]any{}
+	if number := normalizeNumber(identity.Attribute("number")); number != "" {
+		out["number"] = number
+	}
+	if uuid := normalizeUUID(identity.Attribute("uuid")); uuid != "" {
+		out["uuid"] = uuid
+	}
+	if len(out) == 0 {
+		switch subject := normalizeTarget(identity.SubjectID); {
+		case uuidPattern.MatchString(subject):
+			out["uuid"] = subject
+		case phonePattern.MatchString(subject):
+			out["number"] = subject
+		}
+	}
+	return out
+}
+
+func parseConfig(raw map[string]any) (Config, error) {
+	baseURL := strings.TrimRight(strings.TrimSpace(channel.ReadString(raw, "baseUrl", "base_url", "url")), "/")
+	account := normalizeNumber(channel.ReadString(raw, "account", "number", "phoneNumber", "phone_number"))
+	if baseURL == "" {
+		return Config{}, errors.New("signal baseUrl is required")
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return Config{}, errors.New("signal baseUrl must be an http(s) URL")
+	}
+	if account == "" {
+		return Config{}, errors.New("signal account is required")
+	}
+	if !phonePattern.MatchString(account) {
+		return Config{}, errors.New("signal account must be a phone number in international format, e.g. +15551234567")
+	}
+	return Config{BaseURL: baseURL, Account: account}, nil
+}
+
+func parseUserConfig(raw map[string]any) (UserConfig, error) {
+	number := normalizeNumber(channel.ReadString(raw, "number", "phone"))
+	uuid := normalizeUUID(channel.ReadString(raw, "uuid"))
+	if number == "" && uuid == "" {
+		return UserConfig{}, errors.New("signal user config requires number or uuid")
+	}
+	if number != "" && !phonePattern.MatchString(number) {
+		return UserConfig{}, errors.New("signal number must be in international format")
+	}
+	if uuid != "" && !uuidPattern.MatchString(uuid) {
+		return UserConfig{}, errors.New("signal uuid is invalid")
+	}
+	return UserConfig{Number: number, UUID: uuid}, nil
+}
+
+// normalizeTarget strips an optional signal: prefix. Targets are a phone
+// number (+15551234567), an account UUID (optionally prefixed uuid:) or
+// group:<group id>.
+func normalizeTarget(raw string) string {
+	value := strings.TrimSpace(raw)
+	value = trimPrefixFold(value, "signal:")
+	if id, ok := cutPrefixFold(value, groupPrefix); ok {
+		return groupPrefix + strings.TrimSpace(id)
+	}
+	if id, ok := cutPrefixFold(value, "uuid:"); ok {
+		return normalizeUUID(id)
+	}
+	if strings.HasPrefix(value, "+") {
+		return normalizeNumber(value)
+	}
+	if uuid := normalizeUUID(value); uuidPattern.MatchString(uuid) {
+		return uuid
+	}
+	return value
+}
+
+func validateTarget(target string) error {
+	value := normalizeTarget(target)
+	switch {
+	case value == "":
+		return errors.New("signal target is required")
+	case strings.HasPrefix(value, groupPrefix):
+		if strings.TrimPrefix(value, groupPrefix) == "" {
+			return errors.New("signal group id is required")
+		}
+		return nil
+	case phonePattern.MatchString(value), uuidPattern.MatchString(value):
+		return nil
+	default:
+		return errors.New("signal target must be a phone number, uuid or group:<id>")
+	}
+}
+
+// recipientParams returns the JSON-RPC parameters addressing a target.
+func recipientParams(target string) map[string]any {
+	value := normalizeTarget(target)
+	if id, ok := strings.CutPrefix(value, groupPrefix); ok {
+		return map[string]any{"groupId": id}
+	}
+	return map[string]any{"recipient": []string{value}}
+}
+
+func isGroupTarget(target string) bool {
+	return strings.HasPrefix(normalizeTarget(target), groupPrefix)
+}
+
+// normalizeNumber removes the separators people type into phone numbers.
+func normalizeNumber(raw string) string {
+	return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(strings.TrimSpace(raw))
+}
+
+func normalizeUUID(raw string) string {
+	return strings.ToLower(strings.TrimSpace(raw))
+}
+
+// Signal identifies a message by its author and sent timestamp, so message
+// IDs are "author:timestamp".
+func formatMessageID(author string, timestamp int64) string {
+	return author + ":" + strconv.FormatInt(timestamp, 10)
+}
+
+func parseMessageID(id string) (string, int64, error) {
+	author, ts, ok := strings.Cut(strings.TrimSpace(id), ":")
+	if !ok || author == "" {
+		return "", 0, errors.New("signal message id must be author:timestamp")
+	}
+	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || timestamp <= 0 {
+		return "", 0, errors.New("signal message id has an invalid timestamp")
+	}
+	return author, timestamp, nil
+}
+
+func cutPrefixFold(value, prefix string) (string, bool) {
+	if len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+		return value[len(prefix):], true
+	}
+	return value, false
+}
+
+func trimPrefixFold(value, prefix string) string {
+	rest, _ := cutPrefixFold(value, prefix)
+	return strings.TrimSpace(rest)
+}
@@ -0,0 +1,330 @@
+package signal
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/channel/adapters/common"
+)
+
+// mentionPlaceholder marks where Signal inserts a mention in message text.
+const mentionPlaceholder = "\uFFFC"
+
+var eventReconnectBackoffs = []time.Duration{time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 30 * time.Second}
+
+type envelope struct {
+	Source       string       `json:"source"`
+	SourceNumber string       `json:"sourceNumber"`
+	SourceUUID   string       `json:"sourceUuid"`
+	SourceName   string       `json:"sourceName"`
+	Timestamp    int64        `json:"timestamp"`
+	DataMessage  *dataMessage `json:"dataMessage"`
+}
+
+type dataMessage struct {
+	Timestamp   int64              `json:"timestamp"`
+	Message     string             `json:"message"`
+	GroupInfo   *groupInfo         `json:"groupInfo"`
+	Attachments []signalAttachment `json:"attachments"`
+	Mentions    []signalMention    `json:"mentions"`
+	Quote       *signalQuote       `json:"quote"`
+}
+
+type groupInfo struct {
+	GroupID   string `json:"groupId"`
+	GroupName string `json:"groupName"`
+}
+
+type signalAttachment struct {
+	ID          string `json:"id"`
+	ContentType string `json:"contentType"`
+	Filename    string `json:"filename"`
+	Size        int64  `json:"size"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	Caption     string `json:"caption"`
+}
+
+type signalMention struct {
+	Name   string `json:"name"`
+	Number string `json:"number"`
+	UUID   string `json:"uuid"`
+	Start  int    `json:"start"`
+	Length int    `json:"length"`
+}
+
+type signalQuote struct {
+	ID           int64  `json:"id"`
+	Author       string `json:"author"`
+	AuthorNumber string `json:"authorNumber"`
+	AuthorUUID   string `json:"authorUuid"`
+	Text         string `json:"text"`
+}
+
+func (a *SignalAdapter) Connect(ctx context.Context, cfg channel.ChannelConfig, handler channel.InboundHandler) (channel.Connection, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	if a.logger != nil {
+		a.logger.Info("start", slog.String("config_id", cfg.ID), slog.String("base_url", parsed.BaseURL))
+	}
+	connCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.runEvents(connCtx, cfg, parsed, handler)
+	}()
+	return channel.NewConnection(cfg, func(context.Context) error {
+		if a.logger != nil {
+			a.logger.Info("stop", slog.String("config_id", cfg.ID))
+		}
+		cancel()
+		<-done
+		return nil
+	}), nil
+}
+
+// runEvents keeps the event stream open until ctx ends, reconnecting with
+// backoff.
+func (a *SignalAdapter) runEvents(ctx context.Context, cfg channel.ChannelConfig, parsed Config, handler channel.InboundHandler) {
+	attempt := 0
+	for ctx.Err() == nil {
+		healthy := false
+		err := a.readEvents(ctx, parsed, func() { healthy = true }, func(event receiveEvent) {
+			a.handleEvent(ctx, cfg, parsed, event, handler)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if healthy {
+			attempt = 0
+		}
+		delay := eventReconnectBackoffs[min(attempt, len(eventReconnectBackoffs)-1)]
+		attempt++
+		if a.logger != nil {
+			a.logger.Warn("event stream reconnect", slog.String("config_id", cfg.ID), slog.Duration("delay", delay), slog.Any("error", err))
+		}
+		if !sleepContext(ctx, delay) {
+			return
+		}
+	}
+}
+
+func (a *SignalAdapter) handleEvent(ctx context.Context, cfg channel.ChannelConfig, parsed Config, event receiveEvent, handler channel.InboundHandler) {
+	if event.Account != "" && event.Account != parsed.Account {
+		return
+	}
+	msg, ok := a.buildInboundMessage(ctx, cfg, parsed, event.Envelope)
+	if !ok {
+		return
+	}
+	if a.logger != nil {
+		a.logger.Info("inbound received",
+			slog.String("config_id", cfg.ID),
+			slog.String("chat_type", msg.Conversation.Type),
+			slog.String("sender", msg.Sender.SubjectID),
+			slog.String("text", common.SummarizeText(msg.Message.Text)),
+		)
+	}
+	go func() {
+		if err := handler(ctx, cfg, msg); err != nil && a.logger != nil {
+			a.logger.Error("handle inbound failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+	}()
+}
+
+// buildInboundMessage maps a data message from a contact or group member.
+// Receipts, typing notices, reactions and the account's own synced messages
+// carry no data message text and are skipped.
+func (a *SignalAdapter) buildInboundMessage(ctx context.Context, cfg channel.ChannelConfig, parsed Config, env envelope) (channel.InboundMessage, bool) {
+	data := env.DataMessage
+	if data == nil {
+		return channel.InboundMessage{}, false
+	}
+	number := normalizeNumber(firstNonEmpty(env.SourceNumber, numberOrEmpty(env.Source)))
+	uuid := normalizeUUID(firstNonEmpty(env.SourceUUID, uuidOrEmpty(env.Source)))
+	author := firstNonEmpty(uuid, number)
+	if author == "" {
+		return channel.InboundMessage{}, false
+	}
+	selfUUID := a.selfUUID(ctx, parsed)
+	isSelf := func(number, uuid string) bool {
+		return (number != "" && normalizeNumber(number) == parsed.Account) || (uuid != "" && selfUUID != "" && normalizeUUID(uuid) == selfUUID)
+	}
+	if isSelf(number, uuid) {
+		return channel.InboundMessage{}, false
+	}
+	timestamp := data.Timestamp
+	if timestamp == 0 {
+		timestamp = env.Timestamp
+	}
+
+	text, isMentioned := replaceMentions(data.Message, data.Mentions, isSelf)
+	text = strings.TrimSpace(text)
+	conversation := channel.Conversation{
+		ID:   author,
+		Type: channel.ConversationTypePrivate,
+		Name: strings.TrimSpace(env.SourceName),
+	}
+	replyTarget := author
+	if data.GroupInfo != nil && data.GroupInfo.GroupID != "" {
+		conversation = channel.Conversation{
+			ID:   groupPrefix + data.GroupInfo.GroupID,
+			Type: channel.ConversationTypeGroup,
+			Name: strings.TrimSpace(data.GroupInfo.GroupName),
+		}
+		replyTarget = groupPrefix + data.GroupInfo.GroupID
+	}
+	attachments := collectAttachments(data.Attachments, replyTarget)
+	if text == "" && len(attachments) == 0 {
+		return channel.InboundMessage{}, false
+	}
+	messageID := formatMessageID(author, timestamp)
+	if a.isDuplicateInbound(cfg.ID, messageID) {
+		return channel.InboundMessage{}, false
+	}
+
+	message := channel.Message{
+		ID:          messageID,
+		Format:      channel.MessageFormatPlain,
+		Text:        text,
+		Attachments: attachments,
+	}
+	isReplyToBot := false
+	if quote := data.Quote; quote != nil && quote.ID > 0 {
+		quoteAuthor := normalizeUUID(firstNonEmpty(quote.AuthorUUID, uuidOrEmpty(quote.Author)))
+		if quoteAuthor == "" {
+			quoteAuthor = normalizeNumber(firstNonEmpty(quote.AuthorNumber, quote.Author))
+		}
+		message.Reply = &channel.ReplyRef{Target: replyTarget, MessageID: formatMessageID(quoteAuthor, quote.ID)}
+		isReplyToBot = isSelf(firstNonEmpty(quote.AuthorNumber, numberOrEmpty(quote.Author)), firstNonEmpty(quote.AuthorUUID, uuidOrEmpty(quote.Author)))
+	}
+
+	attributes := map[string]string{}
+	if number != "" {
+		attributes["number"] = number
+	}
+	if uuid != "" {
+		attributes["uuid"] = uuid
+	}
+	displayName := strings.TrimSpace(env.SourceName)
+	if displayName == "" {
+		displayName = firstNonEmpty(number, uuid)
+	}
+	return channel.InboundMessage{
+		Channel:     Type,
+		Message:     message,
+		BotID:       cfg.BotID,
+		ReplyTarget: replyTarget,
+		Sender: channel.Identity{
+			SubjectID:   author,
+			DisplayName: displayName,
+			Attributes:  attributes,
+		},
+		Conversation: conversation,
+		ReceivedAt:   messageTime(timestamp),
+		Source:       "signal",
+		Metadata: map[string]any{
+			"is_mentioned":    isMentioned,
+			"is_reply_to_bot": isReplyToBot,
+			"raw_text":        data.Message,
+		},
+	}, true
+}
+
+// replaceMentions swaps the placeholders Signal puts in mention positions for
+// @name, in order, and reports whether the account itself was mentioned.
+func replaceMentions(text string, mentions []signalMention, isSelf func(number, uuid string) bool) (string, bool) {
+	mentioned := false
+	for _, mention := range mentions {
+		if isSelf(mention.Number, mention.UUID) {
+			mentioned = true
+		}
+		name := firstNonEmpty(strings.TrimSpace(mention.Name), mention.Number, mention.UUID)
+		text = strings.Replace(text, mentionPlaceholder, "@"+name, 1)
+	}
+	return text, mentioned
+}
+
+// isDuplicateInbound guards against a message being delivered twice, e.g.
+// around an event stream reconnect.
+func (a *SignalAdapter) isDuplicateInbound(configID, messageID string) bool {
+	now := time.Now().UTC()
+	expireBefore := now.Add(-inboundDedupTTL)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, seenAt := range a.seenMessages {
+		if seenAt.Before(expireBefore) {
+			delete(a.seenMessages, key)
+		}
+	}
+	key := configID + ":" + messageID
+	if _, ok := a.seenMessages[key]; ok {
+		return true
+	}
+	a.seenMessages[key] = now
+	return false
+}
+
+func collectAttachments(items []signalAttachment, target string) []channel.Attachment {
+	if len(items) == 0 {
+		return nil
+	}
+	attachments := make([]channel.Attachment, 0, len(items))
+	for _, item := range items {
+		if item.ID == "" {
+			continue
+		}
+		// Attachment files live with signal-cli, so the ID travels as the
+		// platform key and is fetched through ResolveAttachment.
+		attachments = append(attachments, channel.Attachment{
+			Type:           channel.InferAttachmentType(channel.AttachmentFile, item.ContentType, item.Filename),
+			PlatformKey:    item.ID,
+			SourcePlatform: Type.String(),
+			Name:           item.Filename,
+			Size:           item.Size,
+			Mime:           item.ContentType,
+			Width:          item.Width,
+			Height:         item.Height,
+			Caption:        item.Caption,
+			Metadata:       map[string]any{"signal_target": target},
+		})
+	}
+	return attachments
+}
+
+func messageTime(timestamp int64) time.Time {
+	if timestamp <= 0 {
+		return time.Now().UTC()
+	}
+	return time.UnixMilli(timestamp).UTC()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Older signal-cli versions only fill "source", which is a number or a UUID.
+func numberOrEmpty(source string) string {
+	if strings.HasPrefix(strings.TrimSpace(source), "+") {
+		return source
+	}
+	return ""
+}
+
+func uuidOrEmpty(source string) string {
+	if uuid := normalizeUUID(source); uuidPattern.MatchString(uuid) {
+		return uuid
+	}
+	return ""
+}
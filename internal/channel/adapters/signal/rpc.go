@@ -0,0 +1,175 @@
+package signal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/memohai/memoh/internal/textutil"
+)
+
+const (
+	signalRPCTimeout = 60 * time.Second // sends with attachments can be slow
+	// signalMaxResponseBytes bounds JSON-RPC responses; getAttachment returns
+	// the file base64-encoded.
+	signalMaxResponseBytes = 160 << 20
+	signalMaxEventBytes    = 16 << 20
+)
+
+var rpcRequestID atomic.Int64
+
+type rpcRequest struct {
+	JSONRPC string         `json:"jsonrpc"`
+	Method  string         `json:"method"`
+	Params  map[string]any `json:"params,omitempty"`
+	ID      int64          `json:"id"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// rpcError is a JSON-RPC error returned by signal-cli.
+type rpcError struct {
+	Method  string `json:"-"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("signal %s: %s (code %d)", e.Method, e.Message, e.Code)
+}
+
+// call invokes a signal-cli JSON-RPC method. The account is always passed so
+// the same code works with single- and multi-account daemons.
+func (a *SignalAdapter) call(ctx context.Context, cfg Config, method string, params map[string]any, out any) error {
+	if params == nil {
+		params = map[string]any{}
+	}
+	params["account"] = cfg.Account
+	payload, err := json.Marshal(rpcRequest{JSONRPC: "2.0", Method: method, Params: params, ID: rpcRequestID.Add(1)})
+	if err != nil {
+		return fmt.Errorf("signal %s: %w", method, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, signalRPCTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.BaseURL+"/api/v1/rpc", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("signal %s: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.httpClient.Do(req) //nolint:gosec // G704: URL is the configured signal-cli daemon
+	if err != nil {
+		return fmt.Errorf("signal %s: %w", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, signalMaxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("signal %s: %w", method, err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("signal %s: HTTP %d: %s", method, resp.StatusCode, textutil.TruncateRunes(strings.TrimSpace(string(data)), 300))
+	}
+	var decoded rpcResponse
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("signal %s: decode response: %w", method, err)
+	}
+	if decoded.Error != nil {
+		decoded.Error.Method = method
+		return decoded.Error
+	}
+	if out == nil || len(decoded.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(decoded.Result, out); err != nil {
+		return fmt.Errorf("signal %s: decode result: %w", method, err)
+	}
+	return nil
+}
+
+// receiveEvent is the payload of a "receive" server-sent event.
+type receiveEvent struct {
+	Account  string   `json:"account"`
+	Envelope envelope `json:"envelope"`
+}
+
+// readEvents streams /api/v1/events until ctx ends or the connection drops,
+// calling onEvent for each receive event. connected is called once the
+// daemon accepts the stream.
+func (a *SignalAdapter) readEvents(ctx context.Context, cfg Config, connected func(), onEvent func(receiveEvent)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.BaseURL+"/api/v1/events?account="+url.QueryEscape(cfg.Account), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := a.streamClient.Do(req) //nolint:gosec // G704: URL is the configured signal-cli daemon
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("signal events: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	connected()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), signalMaxEventBytes)
+	var (
+		eventName string
+		data      strings.Builder
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 && (eventName == "" || eventName == "receive") {
+				var event receiveEvent
+				if err := json.Unmarshal([]byte(data.String()), &event); err == nil {
+					onEvent(event)
+				} else if a.logger != nil {
+					a.logger.Warn("decode event failed", slog.Any("error", err))
+				}
+			}
+			eventName = ""
+			data.Reset()
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventName = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return errors.New("signal event stream closed")
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
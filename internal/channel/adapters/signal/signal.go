@@ -0,0 +1,455 @@
+package signal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/media"
+)
+
+const Type channel.ChannelType = "signal"
+
+const (
+	inboundDedupTTL = 10 * time.Minute
+	// signalTextChunkLimit matches the length Signal clients send inline;
+	// longer bodies are turned into a text attachment by the apps.
+	signalTextChunkLimit = 2000
+	signalMaxDownload    = media.MaxAssetBytes
+	// Signal clients hide a typing indicator after about 15 seconds, so it is
+	// refreshed while a reply is being generated, up to typingMaxDuration.
+	typingRefreshInterval = 10 * time.Second
+	typingMaxDuration     = 5 * time.Minute
+)
+
+// assetOpener reads stored asset bytes by content hash.
+type assetOpener interface {
+	Open(ctx context.Context, botID, contentHash string) (io.ReadCloser, media.Asset, error)
+}
+
+type SignalAdapter struct {
+	logger       *slog.Logger
+	httpClient   *http.Client
+	streamClient *http.Client // no timeout: the event stream stays open
+	assets       assetOpener
+	policies     channel.FetchPolicyResolver
+
+	mu           sync.Mutex
+	seenMessages map[string]time.Time          // keyed by configID:author:timestamp
+	selfUUIDs    map[string]string             // account -> account UUID
+	typing       map[string]context.CancelFunc // handle token -> typing refresher
+}
+
+func NewSignalAdapter(log *slog.Logger) *SignalAdapter {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &SignalAdapter{
+		logger:       log.With(slog.String("adapter", "signal")),
+		httpClient:   &http.Client{Timeout: signalRPCTimeout},
+		streamClient: &http.Client{},
+		seenMessages: make(map[string]time.Time),
+		selfUUIDs:    make(map[string]string),
+		typing:       make(map[string]context.CancelFunc),
+	}
+}
+
+// SetAssetOpener configures the asset opener for reading stored attachments by content hash.
+func (a *SignalAdapter) SetAssetOpener(opener assetOpener) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.assets = opener
+}
+
+// SetFetchPolicyResolver configures the per-bot policy that agent-supplied
+// attachment URLs are fetched under.
+func (a *SignalAdapter) SetFetchPolicyResolver(resolver channel.FetchPolicyResolver) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.policies = resolver
+}
+
+func (*SignalAdapter) Type() channel.ChannelType {
+	return Type
+}
+
+func (*SignalAdapter) Descriptor() channel.Descriptor {
+	return channel.Descriptor{
+		Type:        Type,
+		DisplayName: "Signal",
+		Capabilities: channel.ChannelCapabilities{
+			Text:           true,
+			Attachments:    true,
+			Media:          true,
+			Reactions:      true,
+			Reply:          true,
+			BlockStreaming: true,
+			ChatTypes:      []string{"direct", "group"},
+		},
+		OutboundPolicy: channel.OutboundPolicy{
+			TextChunkLimit: signalTextChunkLimit,
+			ChunkerMode:    channel.ChunkerModeText,
+		},
+		ConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"baseUrl": {
+					Type:        channel.FieldString,
+					Required:    true,
+					Title:       "signal-cli URL",
+					Description: "HTTP address of the signal-cli daemon started with --http",
+					Example:     "http://127.0.0.1:8080",
+				},
+				"account": {
+					Type:        channel.FieldString,
+					Required:    true,
+					Title:       "Account",
+					Description: "Phone number registered with signal-cli",
+					Example:     "+15551234567",
+				},
+			},
+		},
+		UserConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"number": {Type: channel.FieldString, Title: "Phone Number"},
+				"uuid":   {Type: channel.FieldString, Title: "UUID"},
+			},
+		},
+		TargetSpec: channel.TargetSpec{
+			Format: "+number | uuid | group:group_id",
+			Hints: []channel.TargetHint{
+				{Label: "Phone Number", Example: "+15551234567"},
+				{Label: "UUID", Example: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"},
+				{Label: "Group", Example: "group:EkF0aGVuYSBHcm91cCBJRA=="},
+			},
+		},
+	}
+}
+
+func (*SignalAdapter) NormalizeConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeConfig(raw)
+}
+
+func (*SignalAdapter) NormalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeUserConfig(raw)
+}
+
+func (*SignalAdapter) NormalizeTarget(raw string) string {
+	return normalizeTarget(raw)
+}
+
+func (*SignalAdapter) ResolveTarget(userConfig map[string]any) (string, error) {
+	return resolveTarget(userConfig)
+}
+
+func (*SignalAdapter) MatchBinding(config map[string]any, criteria channel.BindingCriteria) bool {
+	return matchBinding(config, criteria)
+}
+
+func (*SignalAdapter) BuildUserConfig(identity channel.Identity) map[string]any {
+	return buildUserConfig(identity)
+}
+
+type userStatus struct {
+	Number       string `json:"number"`
+	UUID         string `json:"uuid"`
+	IsRegistered bool   `json:"isRegistered"`
+}
+
+// selfUUID returns the account's UUID, looked up once through
+// getUserStatus. Mentions and quotes may name the bot by UUID only.
+func (a *SignalAdapter) selfUUID(ctx context.Context, cfg Config) string {
+	a.mu.Lock()
+	uuid, ok := a.selfUUIDs[cfg.Account]
+	a.mu.Unlock()
+	if ok {
+		return uuid
+	}
+	var statuses []userStatus
+	if err := a.call(ctx, cfg, "getUserStatus", map[string]any{"recipient": []string{cfg.Account}}, &statuses); err != nil {
+		return ""
+	}
+	for _, status := range statuses {
+		if status.UUID != "" {
+			uuid = normalizeUUID(status.UUID)
+			break
+		}
+	}
+	a.mu.Lock()
+	a.selfUUIDs[cfg.Account] = uuid
+	a.mu.Unlock()
+	return uuid
+}
+
+func (a *SignalAdapter) DiscoverSelf(ctx context.Context, credentials map[string]any) (map[string]any, string, error) {
+	parsed, err := parseConfig(credentials)
+	if err != nil {
+		return nil, "", err
+	}
+	identity := map[string]any{"number": parsed.Account}
+	externalID := parsed.Account
+	if uuid := a.selfUUID(ctx, parsed); uuid != "" {
+		identity["uuid"] = uuid
+		externalID = uuid
+	}
+	return identity, externalID, nil
+}
+
+func (a *SignalAdapter) Send(ctx context.Context, cfg channel.ChannelConfig, msg channel.OutboundMessage) error {
+	if msg.Message.IsEmpty() {
+		return errors.New("message is required")
+	}
+	if err := validateTarget(msg.Target); err != nil {
+		return err
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	_, err = a.sendMessage(ctx, parsed, cfg.BotID, msg.Target, strings.TrimSpace(msg.Message.PlainText()), msg.Message.Attachments, msg.Message.Reply)
+	return err
+}
+
+// sendMessage sends text and attachments in one Signal message, quoting the
+// replied-to message when reply names one. It returns the sent timestamp.
+func (a *SignalAdapter) sendMessage(ctx context.Context, cfg Config, botID, target, text string, attachments []channel.Attachment, reply *channel.ReplyRef) (int64, error) {
+	params := recipientParams(target)
+	if text != "" {
+		params["message"] = text
+	}
+	if len(attachments) > 0 {
+		encoded := make([]string, 0, len(attachments))
+		for _, att := range attachments {
+			dataURI, err := a.attachmentDataURI(ctx, botID, att)
+			if err != nil {
+				return 0, err
+			}
+			encoded = append(encoded, dataURI)
+		}
+		params["attachments"] = encoded
+	}
+	if text == "" && len(attachments) == 0 {
+		return 0, nil
+	}
+	if reply != nil {
+		if author, timestamp, err := parseMessageID(reply.MessageID); err == nil {
+			params["quoteAuthor"] = author
+			params["quoteTimestamp"] = timestamp
+		}
+	}
+	var result struct {
+		Timestamp int64 `json:"timestamp"`
+	}
+	if err := a.call(ctx, cfg, "send", params, &result); err != nil {
+		return 0, err
+	}
+	return result.Timestamp, nil
+}
+
+// OpenStream buffers a reply and sends it once it is final. In groups the
+// reply quotes the message it answers so it is clear who is addressed.
+func (a *SignalAdapter) OpenStream(_ context.Context, cfg channel.ChannelConfig, target string, opts channel.StreamOptions) (channel.OutboundStream, error) {
+	if err := validateTarget(target); err != nil {
+		return nil, err
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	reply := opts.Reply
+	if reply == nil && isGroupTarget(target) && strings.TrimSpace(opts.SourceMessageID) != "" {
+		reply = &channel.ReplyRef{Target: normalizeTarget(target), MessageID: strings.TrimSpace(opts.SourceMessageID)}
+	}
+	return &signalOutboundStream{
+		adapter: a,
+		cfg:     parsed,
+		botID:   cfg.BotID,
+		target:  normalizeTarget(target),
+		reply:   reply,
+	}, nil
+}
+
+func (a *SignalAdapter) React(ctx context.Context, cfg channel.ChannelConfig, target string, messageID string, emoji string) error {
+	return a.sendReaction(ctx, cfg, target, messageID, emoji, false)
+}
+
+func (a *SignalAdapter) Unreact(ctx context.Context, cfg channel.ChannelConfig, target string, messageID string, emoji string) error {
+	return a.sendReaction(ctx, cfg, target, messageID, emoji, true)
+}
+
+func (a *SignalAdapter) sendReaction(ctx context.Context, cfg channel.ChannelConfig, target, messageID, emoji string, remove bool) error {
+	if err := validateTarget(target); err != nil {
+		return err
+	}
+	emoji = strings.TrimSpace(emoji)
+	if emoji == "" {
+		return errors.New("signal reaction emoji is required")
+	}
+	author, timestamp, err := parseMessageID(messageID)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	params := recipientParams(target)
+	params["emoji"] = emoji
+	params["targetAuthor"] = author
+	params["targetTimestamp"] = timestamp
+	if remove {
+		params["remove"] = true
+	}
+	return a.call(ctx, parsed, "sendReaction", params, nil)
+}
+
+// ProcessingStarted shows a typing indicator in the chat and keeps it alive
+// until processing ends.
+func (a *SignalAdapter) ProcessingStarted(ctx context.Context, cfg channel.ChannelConfig, _ channel.InboundMessage, info channel.ProcessingStatusInfo) (channel.ProcessingStatusHandle, error) {
+	target := normalizeTarget(info.ReplyTarget)
+	if validateTarget(target) != nil {
+		return channel.ProcessingStatusHandle{}, nil
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return channel.ProcessingStatusHandle{}, err
+	}
+	if err := a.sendTyping(ctx, parsed, target, false); err != nil {
+		return channel.ProcessingStatusHandle{}, err
+	}
+	token := cfg.ID + "|" + target + "|" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	typingCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), typingMaxDuration)
+	a.mu.Lock()
+	a.typing[token] = cancel
+	a.mu.Unlock()
+	go func() {
+		defer a.stopTypingRefresh(token)
+		ticker := time.NewTicker(typingRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-typingCtx.Done():
+				return
+			case <-ticker.C:
+				_ = a.sendTyping(typingCtx, parsed, target, false)
+			}
+		}
+	}()
+	return channel.ProcessingStatusHandle{Token: token}, nil
+}
+
+func (a *SignalAdapter) ProcessingCompleted(ctx context.Context, cfg channel.ChannelConfig, _ channel.InboundMessage, info channel.ProcessingStatusInfo, handle channel.ProcessingStatusHandle) error {
+	return a.finishTyping(ctx, cfg, info, handle)
+}
+
+func (a *SignalAdapter) ProcessingFailed(ctx context.Context, cfg channel.ChannelConfig, _ channel.InboundMessage, info channel.ProcessingStatusInfo, handle channel.ProcessingStatusHandle, _ error) error {
+	return a.finishTyping(ctx, cfg, info, handle)
+}
+
+func (a *SignalAdapter) finishTyping(ctx context.Context, cfg channel.ChannelConfig, info channel.ProcessingStatusInfo, handle channel.ProcessingStatusHandle) error {
+	if handle.Token == "" {
+		return nil
+	}
+	a.stopTypingRefresh(handle.Token)
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	return a.sendTyping(ctx, parsed, normalizeTarget(info.ReplyTarget), true)
+}
+
+func (a *SignalAdapter) stopTypingRefresh(token string) {
+	a.mu.Lock()
+	cancel := a.typing[token]
+	delete(a.typing, token)
+	a.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+func (a *SignalAdapter) sendTyping(ctx context.Context, cfg Config, target string, stop bool) error {
+	params := recipientParams(target)
+	if stop {
+		params["stop"] = true
+	}
+	return a.call(ctx, cfg, "sendTyping", params, nil)
+}
+
+// ResolveAttachment fetches an inbound attachment from signal-cli. Inbound
+// attachments carry the signal-cli attachment ID as the platform key.
+func (a *SignalAdapter) ResolveAttachment(ctx context.Context, cfg channel.ChannelConfig, attachment channel.Attachment) (channel.AttachmentPayload, error) {
+	id := strings.TrimSpace(attachment.PlatformKey)
+	if id == "" {
+		return channel.AttachmentPayload{}, errors.New("signal attachment requires platform_key")
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return channel.AttachmentPayload{}, err
+	}
+	params := map[string]any{"id": id}
+	if target, _ := attachment.Metadata["signal_target"].(string); target != "" {
+		for key, value := range recipientParams(target) {
+			params[key] = value
+		}
+	}
+	var result struct {
+		Data string `json:"data"`
+	}
+	if err := a.call(ctx, parsed, "getAttachment", params, &result); err != nil {
+		return channel.AttachmentPayload{}, err
+	}
+	data, err := base64.StdEncoding.DecodeString(result.Data)
+	if err != nil {
+		return channel.AttachmentPayload{}, fmt.Errorf("signal getAttachment: decode data: %w", err)
+	}
+	return channel.AttachmentPayload{
+		Reader: io.NopCloser(bytes.NewReader(data)),
+		Mime:   strings.TrimSpace(attachment.Mime),
+		Name:   strings.TrimSpace(attachment.Name),
+		Size:   int64(len(data)),
+	}, nil
+}
+
+// attachmentDataURI encodes an outbound attachment the way signal-cli
+// accepts inline files: data:<mime>;filename=<name>;base64,<data>.
+func (a *SignalAdapter) attachmentDataURI(ctx context.Context, botID string, att channel.Attachment) (string, error) {
+	data, err := a.loadAttachment(ctx, botID, att)
+	if err != nil {
+		return "", err
+	}
+	mime := strings.TrimSpace(att.Mime)
+	if mime == "" {
+		mime = http.DetectContentType(data)
+		mime, _, _ = strings.Cut(mime, ";")
+	}
+	uri := "data:" + mime
+	if name := strings.TrimSpace(att.Name); name != "" {
+		uri += ";filename=" + strings.NewReplacer(";", "_", ",", "_").Replace(name)
+	}
+	return uri + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// loadAttachment reads an outbound attachment through the shared attachment
+// source, so agent-supplied URLs are checked against the bot's fetch policy.
+func (a *SignalAdapter) loadAttachment(ctx context.Context, botID string, att channel.Attachment) ([]byte, error) {
+	a.mu.Lock()
+	source := channel.AttachmentSource{Assets: a.assets, Policies: a.policies, MaxBytes: signalMaxDownload}
+	a.mu.Unlock()
+	data, err := source.Load(ctx, botID, att)
+	if err != nil {
+		return nil, fmt.Errorf("signal attachment: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,380 @@
+package signal
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const (
+	testAccount  = "+15550000001"
+	testSelfUUID = "00000000-0000-4000-8000-000000000001"
+	testUserUUID = "aaaaaaaa-bbbb-4ccc-8ddd-eeeeeeeeeeee"
+	testGroupID  = "R3JvdXBJZA=="
+)
+
+type rpcCall struct {
+	Method string
+	Params map[string]any
+}
+
+// fakeSignalCLI is a local stand-in for the signal-cli HTTP daemon.
+type fakeSignalCLI struct {
+	server *httptest.Server
+	mu     sync.Mutex
+	calls  []rpcCall
+	events chan string
+}
+
+func newFakeSignalCLI(t *testing.T) *fakeSignalCLI {
+	t.Helper()
+	f := &fakeSignalCLI{events: make(chan string, 16)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/rpc", f.handleRPC)
+	mux.HandleFunc("/api/v1/events", f.handleEvents)
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeSignalCLI) config() channel.ChannelConfig {
+	return channel.ChannelConfig{
+		ID:          "cfg-1",
+		BotID:       "bot-1",
+		ChannelType: Type,
+		Credentials: map[string]any{
+			"baseUrl": f.server.URL + "/",
+			"account": "+1 555 000 0001",
+		},
+	}
+}
+
+func (f *fakeSignalCLI) callsTo(method string) []rpcCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []rpcCall
+	for _, call := range f.calls {
+		if call.Method == method {
+			out = append(out, call)
+		}
+	}
+	return out
+}
+
+func (f *fakeSignalCLI) handleRPC(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string         `json:"method"`
+		Params map[string]any `json:"params"`
+		ID     int64          `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.calls = append(f.calls, rpcCall{Method: req.Method, Params: req.Params})
+	f.mu.Unlock()
+	var result any = map[string]any{}
+	switch req.Method {
+	case "getUserStatus":
+		result = []map[string]any{{"number": testAccount, "uuid": testSelfUUID, "isRegistered": true}}
+	case "send":
+		result = map[string]any{"timestamp": 1700000000999}
+	case "getAttachment":
+		result = map[string]any{"data": base64.StdEncoding.EncodeToString([]byte("image-bytes"))}
+	case "sendReaction":
+		if req.Params["targetTimestamp"] == nil {
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "error": map[string]any{"code": -32602, "message": "missing target"}})
+			return
+		}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+func (f *fakeSignalCLI) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("account") != testAccount {
+		http.Error(w, "unknown account", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-f.events:
+			_, _ = fmt.Fprintf(w, "event:receive\ndata:%s\n\n", event)
+			flusher.Flush()
+		}
+	}
+}
+
+func (f *fakeSignalCLI) push(t *testing.T, envelope map[string]any) {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"account": testAccount, "envelope": envelope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.events <- string(data)
+}
+
+func TestParseConfigAndTargets(t *testing.T) {
+	cfg, err := parseConfig(map[string]any{"baseUrl": "http://127.0.0.1:8080/", "account": "+1 (555) 000-0001"})
+	if err != nil || cfg.BaseURL != "http://127.0.0.1:8080" || cfg.Account != testAccount {
+		t.Fatalf("parseConfig = %+v (%v)", cfg, err)
+	}
+	for _, raw := range []map[string]any{
+		{"account": testAccount},
+		{"baseUrl": "127.0.0.1:8080", "account": testAccount},
+		{"baseUrl": "http://127.0.0.1:8080", "account": "15550000001"},
+	} {
+		if _, err := parseConfig(raw); err == nil {
+			t.Errorf("expected error for %v", raw)
+		}
+	}
+	cases := map[string]string{
+		"signal:+1 555 000 0001":                testAccount,
+		"UUID:" + strings.ToUpper(testUserUUID): testUserUUID,
+		"group:" + testGroupID:                  "group:" + testGroupID,
+		" signal:GROUP:" + testGroupID + " ":    "group:" + testGroupID,
+		strings.ToUpper(testUserUUID):           testUserUUID,
+	}
+	for input, want := range cases {
+		if got := normalizeTarget(input); got != want {
+			t.Errorf("normalizeTarget(%q) = %q, want %q", input, got, want)
+		}
+		if err := validateTarget(input); err != nil {
+			t.Errorf("validateTarget(%q) returned %v", input, err)
+		}
+	}
+	if err := validateTarget("alice"); err == nil {
+		t.Fatal("expected plain names to be rejected")
+	}
+	if got := recipientParams("group:" + testGroupID); got["groupId"] != testGroupID {
+		t.Fatalf("recipientParams(group) = %v", got)
+	}
+}
+
+func TestBindingByNumberOrUUID(t *testing.T) {
+	raw := map[string]any{"number": testAccount, "uuid": testUserUUID}
+	if !matchBinding(raw, channel.BindingCriteria{SubjectID: strings.ToUpper(testUserUUID)}) {
+		t.Fatal("expected uuid match")
+	}
+	if !matchBinding(raw, channel.BindingCriteria{Attributes: map[string]string{"number": "+1 555 000 0001"}}) {
+		t.Fatal("expected number match")
+	}
+	if matchBinding(raw, channel.BindingCriteria{SubjectID: "+15559999999"}) {
+		t.Fatal("expected other numbers not to match")
+	}
+	target, err := resolveTarget(raw)
+	if err != nil || target != testUserUUID {
+		t.Fatalf("resolveTarget = %q (%v)", target, err)
+	}
+	built := buildUserConfig(channel.Identity{SubjectID: testUserUUID, Attributes: map[string]string{"number": testAccount}})
+	if built["number"] != testAccount {
+		t.Fatalf("buildUserConfig = %v", built)
+	}
+}
+
+func TestInboundMessagesFromEventStream(t *testing.T) {
+	fake := newFakeSignalCLI(t)
+	adapter := NewSignalAdapter(slog.New(slog.DiscardHandler))
+	inbound := make(chan channel.InboundMessage, 4)
+	conn, err := adapter.Connect(context.Background(), fake.config(), func(_ context.Context, _ channel.ChannelConfig, msg channel.InboundMessage) error {
+		inbound <- msg
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Connect returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Stop(context.Background()) })
+
+	groupMessage := map[string]any{
+		"sourceNumber": "+15550000002",
+		"sourceUuid":   testUserUUID,
+		"sourceName":   "Alice",
+		"timestamp":    1700000000001,
+		"dataMessage": map[string]any{
+			"timestamp": 1700000000001,
+			"message":   "￼ can you check this?",
+			"groupInfo": map[string]any{"groupId": testGroupID, "groupName": "Family"},
+			"mentions":  []map[string]any{{"name": "Memoh", "uuid": testSelfUUID, "start": 0, "length": 1}},
+			"quote":     map[string]any{"id": 1700000000000, "authorNumber": testAccount, "authorUuid": testSelfUUID, "text": "earlier"},
+			"attachments": []map[string]any{{
+				"id": "att-1", "contentType": "image/jpeg", "filename": "photo.jpg", "size": 11,
+			}},
+		},
+	}
+	fake.push(t, map[string]any{"sourceNumber": testAccount, "sourceUuid": testSelfUUID, "timestamp": 1, "dataMessage": map[string]any{"timestamp": 1, "message": "own message"}})
+	fake.push(t, map[string]any{"sourceNumber": "+15550000002", "timestamp": 2, "typingMessage": map[string]any{"action": "STARTED"}})
+	fake.push(t, groupMessage)
+	fake.push(t, groupMessage) // redelivered
+	fake.push(t, map[string]any{
+		"sourceNumber": "+15550000003",
+		"timestamp":    1700000000002,
+		"dataMessage":  map[string]any{"timestamp": 1700000000002, "message": "hi in private"},
+	})
+
+	var got []channel.InboundMessage
+	for range 2 {
+		select {
+		case msg := <-inbound:
+			got = append(got, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for inbound messages, got %d", len(got))
+		}
+	}
+	select {
+	case extra := <-inbound:
+		t.Fatalf("unexpected extra message: %+v", extra)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	group, direct := got[0], got[1]
+	if group.Conversation.Type != channel.ConversationTypeGroup {
+		group, direct = direct, group
+	}
+	if group.Message.Text != "@Memoh can you check this?" || group.Message.ID != testUserUUID+":1700000000001" {
+		t.Fatalf("unexpected group message: %+v", group.Message)
+	}
+	if group.ReplyTarget != "group:"+testGroupID || group.Conversation.Name != "Family" || group.Sender.Attributes["number"] != "+15550000002" {
+		t.Fatalf("unexpected group routing: %+v", group)
+	}
+	if group.Metadata["is_mentioned"] != true || group.Metadata["is_reply_to_bot"] != true {
+		t.Fatalf("unexpected group metadata: %+v", group.Metadata)
+	}
+	if group.Message.Reply == nil || group.Message.Reply.MessageID != testSelfUUID+":1700000000000" {
+		t.Fatalf("unexpected reply ref: %+v", group.Message.Reply)
+	}
+	if len(group.Message.Attachments) != 1 || group.Message.Attachments[0].PlatformKey != "att-1" || group.Message.Attachments[0].Type != channel.AttachmentImage {
+		t.Fatalf("unexpected attachments: %+v", group.Message.Attachments)
+	}
+	if direct.Conversation.Type != channel.ConversationTypePrivate || direct.ReplyTarget != "+15550000003" || direct.Metadata["is_mentioned"] != false {
+		t.Fatalf("unexpected direct message: %+v", direct)
+	}
+
+	payload, err := adapter.ResolveAttachment(context.Background(), fake.config(), group.Message.Attachments[0])
+	if err != nil {
+		t.Fatalf("ResolveAttachment returned error: %v", err)
+	}
+	data, _ := io.ReadAll(payload.Reader)
+	_ = payload.Reader.Close()
+	if string(data) != "image-bytes" {
+		t.Fatalf("unexpected attachment data %q", data)
+	}
+	calls := fake.callsTo("getAttachment")
+	if len(calls) != 1 || calls[0].Params["id"] != "att-1" || calls[0].Params["groupId"] != testGroupID {
+		t.Fatalf("unexpected getAttachment calls: %+v", calls)
+	}
+}
+
+func TestSendReactAndTyping(t *testing.T) {
+	fake := newFakeSignalCLI(t)
+	adapter := NewSignalAdapter(slog.New(slog.DiscardHandler))
+	cfg := fake.config()
+	ctx := context.Background()
+
+	err := adapter.Send(ctx, cfg, channel.OutboundMessage{
+		Target: "group:" + testGroupID,
+		Message: channel.Message{
+			Text:        "here you go",
+			Attachments: []channel.Attachment{{Type: channel.AttachmentFile, Name: "note.txt", Mime: "text/plain", Base64: "data:text/plain;base64," + base64.StdEncoding.EncodeToString([]byte("hello"))}},
+			Reply:       &channel.ReplyRef{MessageID: testUserUUID + ":1700000000001"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	sends := fake.callsTo("send")
+	if len(sends) != 1 {
+		t.Fatalf("expected one send, got %d", len(sends))
+	}
+	params := sends[0].Params
+	if params["account"] != testAccount || params["groupId"] != testGroupID || params["message"] != "here you go" {
+		t.Fatalf("unexpected send params: %v", params)
+	}
+	if params["quoteAuthor"] != testUserUUID || params["quoteTimestamp"] != float64(1700000000001) {
+		t.Fatalf("unexpected quote params: %v", params)
+	}
+	attachments, _ := params["attachments"].([]any)
+	if len(attachments) != 1 || attachments[0] != "data:text/plain;filename=note.txt;base64,aGVsbG8=" {
+		t.Fatalf("unexpected attachments: %v", params["attachments"])
+	}
+
+	if err := adapter.React(ctx, cfg, testUserUUID, testUserUUID+":1700000000001", "👍"); err != nil {
+		t.Fatalf("React returned error: %v", err)
+	}
+	if err := adapter.Unreact(ctx, cfg, testUserUUID, testUserUUID+":1700000000001", "👍"); err != nil {
+		t.Fatalf("Unreact returned error: %v", err)
+	}
+	reactions := fake.callsTo("sendReaction")
+	if len(reactions) != 2 || reactions[0].Params["targetAuthor"] != testUserUUID || reactions[0].Params["remove"] != nil || reactions[1].Params["remove"] != true {
+		t.Fatalf("unexpected reactions: %+v", reactions)
+	}
+	recipients, _ := reactions[0].Params["recipient"].([]any)
+	if len(recipients) != 1 || recipients[0] != testUserUUID {
+		t.Fatalf("unexpected reaction recipient: %v", reactions[0].Params)
+	}
+	if err := adapter.React(ctx, cfg, testUserUUID, "not-a-message-id", "👍"); err == nil {
+		t.Fatal("expected malformed message id to be rejected")
+	}
+
+	info := channel.ProcessingStatusInfo{ReplyTarget: "+15550000003"}
+	handle, err := adapter.ProcessingStarted(ctx, cfg, channel.InboundMessage{}, info)
+	if err != nil || handle.Token == "" {
+		t.Fatalf("ProcessingStarted = %+v (%v)", handle, err)
+	}
+	if err := adapter.ProcessingCompleted(ctx, cfg, channel.InboundMessage{}, info, handle); err != nil {
+		t.Fatalf("ProcessingCompleted returned error: %v", err)
+	}
+	typing := fake.callsTo("sendTyping")
+	if len(typing) != 2 || typing[0].Params["stop"] != nil || typing[1].Params["stop"] != true {
+		t.Fatalf("unexpected typing calls: %+v", typing)
+	}
+	adapter.mu.Lock()
+	pending := len(adapter.typing)
+	adapter.mu.Unlock()
+	if pending != 0 {
+		t.Fatalf("expected typing refresher to stop, %d pending", pending)
+	}
+}
+
+func TestStreamQuotesOnlyFirstGroupMessage(t *testing.T) {
+	fake := newFakeSignalCLI(t)
+	adapter := NewSignalAdapter(slog.New(slog.DiscardHandler))
+	ctx := context.Background()
+	stream, err := adapter.OpenStream(ctx, fake.config(), "group:"+testGroupID, channel.StreamOptions{SourceMessageID: testUserUUID + ":1700000000001"})
+	if err != nil {
+		t.Fatalf("OpenStream returned error: %v", err)
+	}
+	for _, event := range []channel.StreamEvent{
+		{Type: channel.StreamEventDelta, Delta: "Looking it up"},
+		{Type: channel.StreamEventToolCallStart},
+		{Type: channel.StreamEventDelta, Delta: "Found it."},
+		{Type: channel.StreamEventFinal, Final: &channel.StreamFinalizePayload{}},
+	} {
+		if err := stream.Push(ctx, event); err != nil {
+			t.Fatalf("Push %s: %v", event.Type, err)
+		}
+	}
+	sends := fake.callsTo("send")
+	if len(sends) != 2 || sends[0].Params["message"] != "Looking it up" || sends[1].Params["message"] != "Found it." {
+		t.Fatalf("unexpected sends: %+v", sends)
+	}
+	if sends[0].Params["quoteAuthor"] != testUserUUID || sends[1].Params["quoteAuthor"] != nil {
+		t.Fatalf("expected only the first message to quote: %+v", sends)
+	}
+}
@@ -0,0 +1,112 @@
+package signal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+// signalOutboundStream collects a streamed reply and sends it as one message
+// when it is final; Signal edits are limited, so text is not patched in
+// place.
+type signalOutboundStream struct {
+	adapter *SignalAdapter
+	cfg     Config
+	botID   string
+	target  string
+	reply   *channel.ReplyRef
+
+	closed atomic.Bool
+	mu     sync.Mutex
+	buffer strings.Builder
+	quoted bool // only the first message of a reply quotes the source
+}
+
+func (s *signalOutboundStream) Push(ctx context.Context, event channel.StreamEvent) error {
+	if s == nil || s.adapter == nil {
+		return errors.New("signal stream not configured")
+	}
+	if s.closed.Load() {
+		return errors.New("signal stream is closed")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	switch event.Type {
+	case channel.StreamEventDelta:
+		if event.Phase == channel.StreamPhaseReasoning || event.Delta == "" {
+			return nil
+		}
+		s.mu.Lock()
+		s.buffer.WriteString(event.Delta)
+		s.mu.Unlock()
+		return nil
+	case channel.StreamEventToolCallStart:
+		// Text before a tool call is its own message.
+		return s.send(ctx, s.takeBuffer(), nil)
+	case channel.StreamEventError:
+		errText := channel.RedactIMErrorText(strings.TrimSpace(event.Error))
+		if errText == "" {
+			return nil
+		}
+		return s.send(ctx, "Error: "+errText, nil)
+	case channel.StreamEventAttachment:
+		return s.send(ctx, "", event.Attachments)
+	case channel.StreamEventFinal:
+		if event.Final == nil {
+			return errors.New("signal stream final payload is required")
+		}
+		msg := event.Final.Message
+		buffered := s.takeBuffer()
+		text := strings.TrimSpace(msg.PlainText())
+		if text == "" {
+			text = buffered
+		}
+		return s.send(ctx, text, msg.Attachments)
+	default:
+		return nil
+	}
+}
+
+func (s *signalOutboundStream) takeBuffer() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	text := strings.TrimSpace(s.buffer.String())
+	s.buffer.Reset()
+	return text
+}
+
+func (s *signalOutboundStream) send(ctx context.Context, text string, attachments []channel.Attachment) error {
+	if text == "" && len(attachments) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	reply := s.reply
+	if s.quoted {
+		reply = nil
+	}
+	s.quoted = true
+	s.mu.Unlock()
+	_, err := s.adapter.sendMessage(ctx, s.cfg, s.botID, s.target, text, attachments, reply)
+	return err
+}
+
+func (s *signalOutboundStream) Close(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.closed.Store(true)
+	return nil
+}
@@ -0,0 +1,139 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/textutil"
+)
+
+const (
+	slackDefaultTimeout = 30 * time.Second
+	slackMaxRetryAfter  = 30 * time.Second
+)
+
+// apiError is a Web API response with "ok": false.
+type apiError struct {
+	Method string
+	Code   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("slack %s: %s", e.Method, e.Code)
+}
+
+type apiResponse struct {
+	OK               bool   `json:"ok"`
+	Error            string `json:"error"`
+	ResponseMetadata struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"response_metadata"`
+}
+
+// call invokes a Web API method. url.Values bodies are sent form-encoded,
+// anything else as JSON. A rate-limited call is retried once after the
+// Retry-After delay Slack asks for.
+func (a *SlackAdapter) call(ctx context.Context, cfg Config, token, method string, body any, out any) error {
+	payload, contentType, err := encodeAPIBody(body)
+	if err != nil {
+		return fmt.Errorf("slack %s: %w", method, err)
+	}
+	for attempt := 0; ; attempt++ {
+		data, status, retryAfter, err := a.post(ctx, cfg.APIBaseURL+"/"+method, token, contentType, payload)
+		if err != nil {
+			return fmt.Errorf("slack %s: %w", method, err)
+		}
+		if status == http.StatusTooManyRequests && attempt == 0 {
+			if !sleepContext(ctx, retryAfter) {
+				return ctx.Err()
+			}
+			continue
+		}
+		if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			return fmt.Errorf("slack %s: HTTP %d: %s", method, status, textutil.TruncateRunes(strings.TrimSpace(string(data)), 300))
+		}
+		var resp apiResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return fmt.Errorf("slack %s: invalid response: %w", method, err)
+		}
+		if !resp.OK {
+			code := strings.TrimSpace(resp.Error)
+			if code == "" {
+				code = "unknown_error"
+			}
+			return &apiError{Method: method, Code: code}
+		}
+		if out == nil {
+			return nil
+		}
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("slack %s: decode response: %w", method, err)
+		}
+		return nil
+	}
+}
+
+func (a *SlackAdapter) post(ctx context.Context, endpoint, token, contentType string, payload []byte) ([]byte, int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", contentType)
+	resp, err := a.httpClient.Do(req) //nolint:gosec // G704: endpoint is the configured Slack API base URL
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, resp.StatusCode, 0, err
+	}
+	return data, resp.StatusCode, parseRetryAfter(resp.Header.Get("Retry-After")), nil
+}
+
+func encodeAPIBody(body any) ([]byte, string, error) {
+	switch v := body.(type) {
+	case nil:
+		return nil, "application/x-www-form-urlencoded", nil
+	case url.Values:
+		return []byte(v.Encode()), "application/x-www-form-urlencoded", nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, "application/json; charset=utf-8", nil
+	}
+}
+
+func parseRetryAfter(raw string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || seconds <= 0 {
+		return time.Second
+	}
+	delay := time.Duration(seconds) * time.Second
+	if delay > slackMaxRetryAfter {
+		return slackMaxRetryAfter
+	}
+	return delay
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
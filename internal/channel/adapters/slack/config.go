@@ -0,0 +1,175 @@
+package slack
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const defaultAPIBaseURL = "https://slack.com/api"
+
+type Config struct {
+	BotToken   string //nolint:gosec // intentional: operator-supplied Slack bot token in channel config
+	AppToken   string //nolint:gosec // intentional: operator-supplied Slack app-level token for Socket Mode
+	APIBaseURL string
+}
+
+type UserConfig struct {
+	UserID    string
+	ChannelID string
+	Username  string
+}
+
+func normalizeConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{
+		"botToken": cfg.BotToken,
+		"appToken": cfg.AppToken,
+	}
+	if cfg.APIBaseURL != defaultAPIBaseURL {
+		out["apiBaseUrl"] = cfg.APIBaseURL
+	}
+	return out, nil
+}
+
+func normalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if cfg.UserID != "" {
+		out["user_id"] = cfg.UserID
+	}
+	if cfg.ChannelID != "" {
+		out["channel_id"] = cfg.ChannelID
+	}
+	if cfg.Username != "" {
+		out["username"] = cfg.Username
+	}
+	return out, nil
+}
+
+func resolveTarget(raw map[string]any) (string, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return "", err
+	}
+	if cfg.ChannelID != "" {
+		return cfg.ChannelID, nil
+	}
+	return cfg.UserID, nil
+}
+
+func matchBinding(raw map[string]any, criteria channel.BindingCriteria) bool {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return false
+	}
+	if value := criteria.Attribute("user_id"); value != "" && value == cfg.UserID {
+		return true
+	}
+	if value := criteria.Attribute("username"); value != "" && strings.EqualFold(value, cfg.Username) {
+		return true
+	}
+	return criteria.SubjectID != "" && criteria.SubjectID == cfg.UserID
+}
+
+func buildUserConfig(identity channel.Identity) map[string]any {
+	out := map[string]any{}
+	userID := identity.Attribute("user_id")
+	if userID == "" {
+		userID = strings.TrimSpace(identity.SubjectID)
+	}
+	if userID != "" {
+		out["user_id"] = userID
+	}
+	if value := identity.Attribute("username"); value != "" {
+		out["username"] = value
+	}
+	return out
+}
+
+func parseConfig(raw map[string]any) (Config, error) {
+	botToken := strings.TrimSpace(channel.ReadString(raw, "botToken", "bot_token"))
+	appToken := strings.TrimSpace(channel.ReadString(raw, "appToken", "app_token"))
+	if botToken == "" {
+		return Config{}, errors.New("slack botToken is required")
+	}
+	if !strings.HasPrefix(botToken, "xoxb-") {
+		return Config{}, errors.New("slack botToken must be a bot token (xoxb-...)")
+	}
+	if appToken == "" {
+		return Config{}, errors.New("slack appToken is required for Socket Mode")
+	}
+	if !strings.HasPrefix(appToken, "xapp-") {
+		return Config{}, errors.New("slack appToken must be an app-level token (xapp-...)")
+	}
+	baseURL := strings.TrimRight(strings.TrimSpace(channel.ReadString(raw, "apiBaseUrl", "api_base_url")), "/")
+	if baseURL == "" {
+		baseURL = defaultAPIBaseURL
+	}
+	return Config{BotToken: botToken, AppToken: appToken, APIBaseURL: baseURL}, nil
+}
+
+func parseUserConfig(raw map[string]any) (UserConfig, error) {
+	userID := strings.TrimSpace(channel.ReadString(raw, "userId", "user_id"))
+	channelID := strings.TrimSpace(channel.ReadString(raw, "channelId", "channel_id"))
+	username := strings.TrimSpace(channel.ReadString(raw, "username"))
+	if userID == "" && channelID == "" {
+		return UserConfig{}, errors.New("slack user config requires user_id or channel_id")
+	}
+	return UserConfig{UserID: userID, ChannelID: channelID, Username: username}, nil
+}
+
+// normalizeTarget strips optional prefixes and mention syntax. A target is a
+// channel, DM or user ID, optionally followed by ":<thread_ts>" to post into
+// a thread.
+func normalizeTarget(raw string) string {
+	value := strings.TrimSpace(raw)
+	for _, prefix := range []string{"slack:", "channel:", "user:"} {
+		if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+			value = strings.TrimSpace(value[len(prefix):])
+			break
+		}
+	}
+	if strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">") {
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		value = strings.TrimLeft(value, "@#")
+		if idx := strings.Index(value, "|"); idx >= 0 {
+			value = value[:idx]
+		}
+	}
+	return value
+}
+
+// splitTarget returns the conversation and optional thread timestamp of a
+// normalized target.
+func splitTarget(target string) (string, string) {
+	conversation, thread, _ := strings.Cut(normalizeTarget(target), ":")
+	return strings.TrimSpace(conversation), strings.TrimSpace(thread)
+}
+
+func joinTarget(conversation, threadTS string) string {
+	if threadTS == "" {
+		return conversation
+	}
+	return conversation + ":" + threadTS
+}
+
+func validateTarget(target string) error {
+	conversation, _ := splitTarget(target)
+	if conversation == "" {
+		return errors.New("slack target is required")
+	}
+	switch conversation[0] {
+	case 'C', 'G', 'D', 'U', 'W':
+		return nil
+	default:
+		return errors.New("slack target must be a channel, DM or user id")
+	}
+}
@@ -0,0 +1,82 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := parseConfig(map[string]any{
+		"botToken":   " xoxb-1 ",
+		"app_token":  "xapp-1",
+		"apiBaseUrl": "http://127.0.0.1:9000/api/",
+	})
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v", err)
+	}
+	if cfg.BotToken != "xoxb-1" || cfg.AppToken != "xapp-1" {
+		t.Fatalf("unexpected tokens: %+v", cfg)
+	}
+	if cfg.APIBaseURL != "http://127.0.0.1:9000/api" {
+		t.Fatalf("unexpected api base url: %q", cfg.APIBaseURL)
+	}
+
+	cfg, err = parseConfig(map[string]any{"botToken": "xoxb-1", "appToken": "xapp-1"})
+	if err != nil || cfg.APIBaseURL != defaultAPIBaseURL {
+		t.Fatalf("expected default api base url, got %q (%v)", cfg.APIBaseURL, err)
+	}
+}
+
+func TestParseConfigRejectsWrongTokens(t *testing.T) {
+	for _, raw := range []map[string]any{
+		{"appToken": "xapp-1"},
+		{"botToken": "xoxp-1", "appToken": "xapp-1"},
+		{"botToken": "xoxb-1"},
+		{"botToken": "xoxb-1", "appToken": "xoxb-2"},
+	} {
+		if _, err := parseConfig(raw); err == nil {
+			t.Errorf("expected error for %v", raw)
+		}
+	}
+}
+
+func TestNormalizeTarget(t *testing.T) {
+	cases := map[string]string{
+		"C0123":                "C0123",
+		" slack:C0123 ":        "C0123",
+		"channel:C0123:17.001": "C0123:17.001",
+		"user:U0123":           "U0123",
+		"<@U0123>":             "U0123",
+		"<#C0123|general>":     "C0123",
+	}
+	for input, want := range cases {
+		if got := normalizeTarget(input); got != want {
+			t.Errorf("normalizeTarget(%q) = %q, want %q", input, got, want)
+		}
+	}
+	conversation, thread := splitTarget("channel:C0123:1712345678.000100")
+	if conversation != "C0123" || thread != "1712345678.000100" {
+		t.Fatalf("splitTarget = %q, %q", conversation, thread)
+	}
+	if err := validateTarget("general"); err == nil {
+		t.Fatal("expected channel names to be rejected")
+	}
+}
+
+func TestMatchBinding(t *testing.T) {
+	raw := map[string]any{"user_id": "U0123", "username": "Alice"}
+	if !matchBinding(raw, channel.BindingCriteria{Attributes: map[string]string{"user_id": "U0123"}}) {
+		t.Fatal("expected user id match")
+	}
+	if !matchBinding(raw, channel.BindingCriteria{Attributes: map[string]string{"username": "alice"}}) {
+		t.Fatal("expected case-insensitive username match")
+	}
+	if matchBinding(raw, channel.BindingCriteria{SubjectID: "U9999"}) {
+		t.Fatal("expected other users not to match")
+	}
+	built := buildUserConfig(channel.Identity{SubjectID: "U0123", Attributes: map[string]string{"username": "alice"}})
+	if built["user_id"] != "U0123" || built["username"] != "alice" {
+		t.Fatalf("unexpected user config: %v", built)
+	}
+}
@@ -0,0 +1,283 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const (
+	defaultDirectoryLimit = 50
+	maxDirectoryLimit     = 200
+	// directoryMaxPages bounds how far a filtered listing pages through a
+	// large workspace.
+	directoryMaxPages = 10
+)
+
+func directoryLimit(n int) int {
+	if n <= 0 {
+		return defaultDirectoryLimit
+	}
+	if n > maxDirectoryLimit {
+		return maxDirectoryLimit
+	}
+	return n
+}
+
+type slackConversation struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsChannel  bool   `json:"is_channel"`
+	IsGroup    bool   `json:"is_group"`
+	IsIM       bool   `json:"is_im"`
+	IsMPIM     bool   `json:"is_mpim"`
+	IsPrivate  bool   `json:"is_private"`
+	IsArchived bool   `json:"is_archived"`
+	IsMember   bool   `json:"is_member"`
+	NumMembers int    `json:"num_members"`
+	Topic      struct {
+		Value string `json:"value"`
+	} `json:"topic"`
+}
+
+// ListPeers returns workspace members, skipping bots and deactivated users.
+func (a *SlackAdapter) ListPeers(ctx context.Context, cfg channel.ChannelConfig, query channel.DirectoryQuery) ([]channel.DirectoryEntry, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	limit := directoryLimit(query.Limit)
+	entries := make([]channel.DirectoryEntry, 0, limit)
+	cursor := ""
+	for page := 0; page < directoryMaxPages && len(entries) < limit; page++ {
+		var resp struct {
+			apiResponse
+			Members []slackUser `json:"members"`
+		}
+		params := url.Values{"limit": {strconv.Itoa(maxDirectoryLimit)}}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		if err := a.call(ctx, parsed, parsed.BotToken, "users.list", params, &resp); err != nil {
+			return nil, err
+		}
+		for _, user := range resp.Members {
+			if user.Deleted || user.IsBot || user.ID == "USLACKBOT" {
+				continue
+			}
+			entry := userEntry(user)
+			if !matchesDirectoryQuery(entry, query.Query) {
+				continue
+			}
+			entries = append(entries, entry)
+			if len(entries) == limit {
+				break
+			}
+		}
+		cursor = resp.ResponseMetadata.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+	return entries, nil
+}
+
+// ListGroups returns the channels the bot is a member of.
+func (a *SlackAdapter) ListGroups(ctx context.Context, cfg channel.ChannelConfig, query channel.DirectoryQuery) ([]channel.DirectoryEntry, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	limit := directoryLimit(query.Limit)
+	entries := make([]channel.DirectoryEntry, 0, limit)
+	cursor := ""
+	for page := 0; page < directoryMaxPages && len(entries) < limit; page++ {
+		var resp struct {
+			apiResponse
+			Channels []slackConversation `json:"channels"`
+		}
+		params := url.Values{
+			"limit":            {strconv.Itoa(maxDirectoryLimit)},
+			"types":            {"public_channel,private_channel,mpim"},
+			"exclude_archived": {"true"},
+		}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		if err := a.call(ctx, parsed, parsed.BotToken, "users.conversations", params, &resp); err != nil {
+			return nil, err
+		}
+		for _, conv := range resp.Channels {
+			entry := conversationEntry(conv)
+			if !matchesDirectoryQuery(entry, query.Query) {
+				continue
+			}
+			entries = append(entries, entry)
+			if len(entries) == limit {
+				break
+			}
+		}
+		cursor = resp.ResponseMetadata.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+	return entries, nil
+}
+
+// ListGroupMembers returns the members of a channel, looking each one up for
+// a display name.
+func (a *SlackAdapter) ListGroupMembers(ctx context.Context, cfg channel.ChannelConfig, groupID string, query channel.DirectoryQuery) ([]channel.DirectoryEntry, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	groupID, _ = splitTarget(groupID)
+	if groupID == "" {
+		return nil, errors.New("slack group id is required")
+	}
+	limit := directoryLimit(query.Limit)
+	var resp struct {
+		Members []string `json:"members"`
+	}
+	if err := a.call(ctx, parsed, parsed.BotToken, "conversations.members", url.Values{
+		"channel": {groupID},
+		"limit":   {strconv.Itoa(maxDirectoryLimit)},
+	}, &resp); err != nil {
+		return nil, err
+	}
+	entries := make([]channel.DirectoryEntry, 0, min(limit, len(resp.Members)))
+	for _, memberID := range resp.Members {
+		entry := channel.DirectoryEntry{Kind: channel.DirectoryEntryUser, ID: memberID, Name: memberID}
+		if user, err := a.userInfo(ctx, parsed, memberID); err == nil {
+			if user.IsBot {
+				continue
+			}
+			entry = userEntry(user)
+		}
+		if !matchesDirectoryQuery(entry, query.Query) {
+			continue
+		}
+		entries = append(entries, entry)
+		if len(entries) == limit {
+			break
+		}
+	}
+	return entries, nil
+}
+
+// ResolveEntry resolves a user by ID, mention or email, or a channel by ID or
+// #name.
+func (a *SlackAdapter) ResolveEntry(ctx context.Context, cfg channel.ChannelConfig, input string, kind channel.DirectoryEntryKind) (channel.DirectoryEntry, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return channel.DirectoryEntry{}, err
+	}
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return channel.DirectoryEntry{}, errors.New("slack resolve entry: input is required")
+	}
+	switch kind {
+	case channel.DirectoryEntryUser:
+		return a.resolveUser(ctx, parsed, input)
+	case channel.DirectoryEntryGroup:
+		return a.resolveGroup(ctx, cfg, parsed, input)
+	default:
+		return channel.DirectoryEntry{}, fmt.Errorf("slack resolve entry: unsupported kind %q", kind)
+	}
+}
+
+func (a *SlackAdapter) resolveUser(ctx context.Context, cfg Config, input string) (channel.DirectoryEntry, error) {
+	if strings.Contains(input, "@") && !strings.HasPrefix(input, "<@") && !strings.HasPrefix(input, "@") {
+		var resp struct {
+			User slackUser `json:"user"`
+		}
+		if err := a.call(ctx, cfg, cfg.BotToken, "users.lookupByEmail", url.Values{"email": {input}}, &resp); err != nil {
+			return channel.DirectoryEntry{}, err
+		}
+		return userEntry(resp.User), nil
+	}
+	userID := normalizeTarget(input)
+	user, err := a.userInfo(ctx, cfg, userID)
+	if err != nil {
+		return channel.DirectoryEntry{}, err
+	}
+	return userEntry(user), nil
+}
+
+func (a *SlackAdapter) resolveGroup(ctx context.Context, cfg channel.ChannelConfig, parsed Config, input string) (channel.DirectoryEntry, error) {
+	if name, ok := strings.CutPrefix(input, "#"); ok {
+		groups, err := a.ListGroups(ctx, cfg, channel.DirectoryQuery{Query: name, Limit: maxDirectoryLimit})
+		if err != nil {
+			return channel.DirectoryEntry{}, err
+		}
+		for _, group := range groups {
+			if strings.EqualFold(group.Handle, "#"+name) {
+				return group, nil
+			}
+		}
+		return channel.DirectoryEntry{}, fmt.Errorf("slack resolve entry: channel %q not found", input)
+	}
+	channelID, _ := splitTarget(input)
+	var resp struct {
+		Channel slackConversation `json:"channel"`
+	}
+	if err := a.call(ctx, parsed, parsed.BotToken, "conversations.info", url.Values{"channel": {channelID}}, &resp); err != nil {
+		return channel.DirectoryEntry{}, err
+	}
+	return conversationEntry(resp.Channel), nil
+}
+
+func userEntry(user slackUser) channel.DirectoryEntry {
+	entry := channel.DirectoryEntry{
+		Kind:      channel.DirectoryEntryUser,
+		ID:        user.ID,
+		Name:      user.displayName(),
+		AvatarURL: user.Profile.Image192,
+	}
+	if user.Name != "" {
+		entry.Handle = "@" + user.Name
+	}
+	return entry
+}
+
+func conversationEntry(conv slackConversation) channel.DirectoryEntry {
+	entry := channel.DirectoryEntry{
+		Kind: channel.DirectoryEntryGroup,
+		ID:   conv.ID,
+		Name: conv.Name,
+		Metadata: map[string]any{
+			"is_private": conv.IsPrivate || conv.IsGroup || conv.IsMPIM,
+		},
+	}
+	if conv.Name != "" {
+		entry.Handle = "#" + conv.Name
+	} else {
+		entry.Name = conv.ID
+	}
+	if conv.NumMembers > 0 {
+		entry.Metadata["member_count"] = conv.NumMembers
+	}
+	if topic := strings.TrimSpace(conv.Topic.Value); topic != "" {
+		entry.Metadata["topic"] = topic
+	}
+	return entry
+}
+
+func matchesDirectoryQuery(entry channel.DirectoryEntry, query string) bool {
+	query = strings.ToLower(strings.TrimSpace(strings.TrimLeft(query, "@#")))
+	if query == "" {
+		return true
+	}
+	for _, value := range []string{entry.ID, entry.Name, entry.Handle} {
+		if strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,408 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/channel/adapters/common"
+)
+
+type eventCallback struct {
+	Type   string     `json:"type"`
+	TeamID string     `json:"team_id"`
+	Event  slackEvent `json:"event"`
+}
+
+type slackEvent struct {
+	Type         string      `json:"type"`
+	Subtype      string      `json:"subtype"`
+	Channel      string      `json:"channel"`
+	ChannelType  string      `json:"channel_type"`
+	User         string      `json:"user"`
+	BotID        string      `json:"bot_id"`
+	Text         string      `json:"text"`
+	TS           string      `json:"ts"`
+	ThreadTS     string      `json:"thread_ts"`
+	ParentUserID string      `json:"parent_user_id"`
+	Files        []slackFile `json:"files"`
+}
+
+type slackFile struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Title              string `json:"title"`
+	Mimetype           string `json:"mimetype"`
+	Size               int64  `json:"size"`
+	URLPrivate         string `json:"url_private"`
+	URLPrivateDownload string `json:"url_private_download"`
+	OriginalW          int    `json:"original_w"`
+	OriginalH          int    `json:"original_h"`
+}
+
+type blockActionsPayload struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"user"`
+	Channel struct {
+		ID string `json:"id"`
+	} `json:"channel"`
+	Container struct {
+		MessageTS string `json:"message_ts"`
+		ChannelID string `json:"channel_id"`
+		ThreadTS  string `json:"thread_ts"`
+	} `json:"container"`
+	Message struct {
+		TS       string `json:"ts"`
+		ThreadTS string `json:"thread_ts"`
+	} `json:"message"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+		ActionTS string `json:"action_ts"`
+		Text     struct {
+			Text string `json:"text"`
+		} `json:"text"`
+	} `json:"actions"`
+}
+
+var (
+	slackUserMentionPattern    = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|([^>]*))?>`)
+	slackChannelMentionPattern = regexp.MustCompile(`<#([CG][A-Z0-9]+)(?:\|([^>]*))?>`)
+	slackSpecialMentionPattern = regexp.MustCompile(`<!([a-z]+)(?:\^[^|>]*)?(?:\|([^>]*))?>`)
+	slackLinkPattern           = regexp.MustCompile(`<((?:https?|mailto):[^|>]+)(?:\|([^>]*))?>`)
+)
+
+// inboundSubtypes are the message subtypes that carry something a person
+// said; edits, joins and other housekeeping are skipped.
+var inboundSubtypes = map[string]bool{
+	"":                 true,
+	"file_share":       true,
+	"thread_broadcast": true,
+}
+
+func (a *SlackAdapter) handleEventsAPI(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, payload json.RawMessage, handler channel.InboundHandler) {
+	var callback eventCallback
+	if err := json.Unmarshal(payload, &callback); err != nil {
+		if a.logger != nil {
+			a.logger.Warn("decode slack event failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+		return
+	}
+	if callback.Type != "event_callback" {
+		return
+	}
+	msg, ok := a.buildInboundMessage(ctx, cfg, parsed, selfID, callback.Event)
+	if !ok {
+		return
+	}
+	a.dispatch(ctx, cfg, msg, handler)
+}
+
+// buildInboundMessage maps a message or app_mention event. A mention in a
+// channel arrives as both, so events are deduplicated by channel and ts.
+func (a *SlackAdapter) buildInboundMessage(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, event slackEvent) (channel.InboundMessage, bool) {
+	if event.Type != "message" && event.Type != "app_mention" {
+		return channel.InboundMessage{}, false
+	}
+	if !inboundSubtypes[event.Subtype] || event.BotID != "" || event.User == "" || event.User == selfID {
+		return channel.InboundMessage{}, false
+	}
+	rawText := strings.TrimSpace(event.Text)
+	attachments := collectAttachments(event.Files)
+	if rawText == "" && len(attachments) == 0 {
+		return channel.InboundMessage{}, false
+	}
+	if a.isDuplicateInbound(cfg.ID, event.Channel, event.TS) {
+		return channel.InboundMessage{}, false
+	}
+
+	conversation := channel.Conversation{ID: event.Channel, Type: conversationType(event.ChannelType, event.Channel)}
+	message := channel.Message{
+		ID:          event.TS,
+		Format:      channel.MessageFormatPlain,
+		Text:        plainText(rawText),
+		Attachments: attachments,
+	}
+	replyTarget := event.Channel
+	if event.ThreadTS != "" && event.ThreadTS != event.TS {
+		conversation.Type = channel.ConversationTypeThread
+		conversation.ThreadID = event.ThreadTS
+		message.Thread = &channel.ThreadRef{ID: event.ThreadTS}
+		replyTarget = joinTarget(event.Channel, event.ThreadTS)
+	}
+	isMentioned := event.Type == "app_mention" || (selfID != "" && strings.Contains(rawText, "<@"+selfID))
+	username := a.userName(ctx, parsed, event.User)
+
+	return channel.InboundMessage{
+		Channel:     Type,
+		Message:     message,
+		BotID:       cfg.BotID,
+		ReplyTarget: replyTarget,
+		Sender: channel.Identity{
+			SubjectID:   event.User,
+			DisplayName: username,
+			Attributes: map[string]string{
+				"user_id":  event.User,
+				"username": username,
+			},
+		},
+		Conversation: conversation,
+		ReceivedAt:   timestampTime(event.TS),
+		Source:       "slack",
+		Metadata: map[string]any{
+			"is_mentioned":    isMentioned,
+			"is_reply_to_bot": selfID != "" && event.ParentUserID == selfID,
+			"raw_text":        rawText,
+		},
+	}, true
+}
+
+// handleInteractive turns a click on one of our Block Kit buttons into an
+// inbound message carrying the button's value, as if the user had typed it.
+func (a *SlackAdapter) handleInteractive(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, payload json.RawMessage, handler channel.InboundHandler) {
+	var interaction blockActionsPayload
+	if err := json.Unmarshal(payload, &interaction); err != nil {
+		if a.logger != nil {
+			a.logger.Warn("decode slack interaction failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+		return
+	}
+	msg, ok := a.buildActionMessage(ctx, cfg, parsed, selfID, interaction)
+	if !ok {
+		return
+	}
+	a.dispatch(ctx, cfg, msg, handler)
+}
+
+func (a *SlackAdapter) buildActionMessage(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, interaction blockActionsPayload) (channel.InboundMessage, bool) {
+	if interaction.Type != "block_actions" || interaction.User.ID == "" || interaction.User.ID == selfID {
+		return channel.InboundMessage{}, false
+	}
+	channelID := interaction.Channel.ID
+	if channelID == "" {
+		channelID = interaction.Container.ChannelID
+	}
+	if channelID == "" {
+		return channel.InboundMessage{}, false
+	}
+	var value, label, actionTS string
+	for _, action := range interaction.Actions {
+		if strings.HasPrefix(action.ActionID, slackActionIDPrefix) && strings.TrimSpace(action.Value) != "" {
+			value, label, actionTS = strings.TrimSpace(action.Value), strings.TrimSpace(action.Text.Text), action.ActionTS
+			break
+		}
+	}
+	if value == "" {
+		return channel.InboundMessage{}, false
+	}
+	if a.isDuplicateInbound(cfg.ID, channelID, "action:"+actionTS) {
+		return channel.InboundMessage{}, false
+	}
+	threadTS := interaction.Message.ThreadTS
+	if threadTS == "" {
+		threadTS = interaction.Container.ThreadTS
+	}
+	conversation := channel.Conversation{ID: channelID, Type: conversationType("", channelID)}
+	message := channel.Message{ID: actionTS, Format: channel.MessageFormatPlain, Text: value}
+	replyTarget := channelID
+	if threadTS != "" {
+		conversation.Type = channel.ConversationTypeThread
+		conversation.ThreadID = threadTS
+		message.Thread = &channel.ThreadRef{ID: threadTS}
+		replyTarget = joinTarget(channelID, threadTS)
+	}
+	username := strings.TrimSpace(interaction.User.Username)
+	if username == "" {
+		username = a.userName(ctx, parsed, interaction.User.ID)
+	}
+	return channel.InboundMessage{
+		Channel:     Type,
+		Message:     message,
+		BotID:       cfg.BotID,
+		ReplyTarget: replyTarget,
+		Sender: channel.Identity{
+			SubjectID:   interaction.User.ID,
+			DisplayName: username,
+			Attributes: map[string]string{
+				"user_id":  interaction.User.ID,
+				"username": username,
+			},
+		},
+		Conversation: conversation,
+		ReceivedAt:   time.Now().UTC(),
+		Source:       "slack",
+		Interaction: &channel.Interaction{
+			Type:      channel.InteractionButton,
+			MessageID: interaction.Container.MessageTS,
+			Value:     value,
+			Label:     label,
+		},
+		Metadata: map[string]any{
+			// A button click is addressed to the bot that posted it.
+			"is_mentioned":      true,
+			"is_reply_to_bot":   true,
+			"raw_text":          value,
+			"action_message_ts": interaction.Container.MessageTS,
+		},
+	}, true
+}
+
+func (a *SlackAdapter) dispatch(ctx context.Context, cfg channel.ChannelConfig, msg channel.InboundMessage, handler channel.InboundHandler) {
+	if a.logger != nil {
+		a.logger.Info("inbound received",
+			slog.String("config_id", cfg.ID),
+			slog.String("chat_type", msg.Conversation.Type),
+			slog.String("user_id", msg.Sender.SubjectID),
+			slog.String("username", msg.Sender.DisplayName),
+			slog.String("text", common.SummarizeText(msg.Message.Text)),
+		)
+	}
+	go func() {
+		if err := handler(ctx, cfg, msg); err != nil && a.logger != nil {
+			a.logger.Error("handle inbound failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		}
+	}()
+}
+
+func (a *SlackAdapter) isDuplicateInbound(configID, channelID, ts string) bool {
+	if strings.TrimSpace(ts) == "" {
+		return false
+	}
+	now := time.Now().UTC()
+	expireBefore := now.Add(-inboundDedupTTL)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, seenAt := range a.seenMessages {
+		if seenAt.Before(expireBefore) {
+			delete(a.seenMessages, key)
+		}
+	}
+	key := configID + ":" + channelID + ":" + ts
+	if _, ok := a.seenMessages[key]; ok {
+		return true
+	}
+	a.seenMessages[key] = now
+	return false
+}
+
+// userName returns a user's display name, falling back to the ID when the
+// lookup fails (e.g. the app lacks users:read).
+func (a *SlackAdapter) userName(ctx context.Context, cfg Config, userID string) string {
+	key := cfg.BotToken + ":" + userID
+	a.mu.Lock()
+	name, ok := a.userNames[key]
+	a.mu.Unlock()
+	if ok {
+		return name
+	}
+	user, err := a.userInfo(ctx, cfg, userID)
+	if err != nil {
+		return userID
+	}
+	name = user.displayName()
+	a.mu.Lock()
+	a.userNames[key] = name
+	a.mu.Unlock()
+	return name
+}
+
+func conversationType(channelType, channelID string) string {
+	switch channelType {
+	case "im":
+		return channel.ConversationTypePrivate
+	case "channel", "group", "mpim":
+		return channel.ConversationTypeGroup
+	}
+	if strings.HasPrefix(channelID, "D") {
+		return channel.ConversationTypePrivate
+	}
+	return channel.ConversationTypeGroup
+}
+
+func collectAttachments(files []slackFile) []channel.Attachment {
+	if len(files) == 0 {
+		return nil
+	}
+	attachments := make([]channel.Attachment, 0, len(files))
+	for _, file := range files {
+		link := file.URLPrivateDownload
+		if link == "" {
+			link = file.URLPrivate
+		}
+		if link == "" {
+			continue
+		}
+		name := file.Name
+		if name == "" {
+			name = file.Title
+		}
+		// The URL needs the bot token, so it travels as the platform key and
+		// is fetched through ResolveAttachment.
+		attachments = append(attachments, channel.Attachment{
+			Type:           channel.InferAttachmentType(channel.AttachmentFile, file.Mimetype, name),
+			PlatformKey:    link,
+			SourcePlatform: Type.String(),
+			Name:           name,
+			Size:           file.Size,
+			Mime:           file.Mimetype,
+			Width:          file.OriginalW,
+			Height:         file.OriginalH,
+			Metadata:       map[string]any{"file_id": file.ID},
+		})
+	}
+	return attachments
+}
+
+// plainText renders Slack's message markup as readable text: mentions keep
+// their labels, links show their target and entities are unescaped.
+func plainText(text string) string {
+	text = slackUserMentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := slackUserMentionPattern.FindStringSubmatch(match)
+		if m[2] != "" {
+			return "@" + m[2]
+		}
+		return "@" + m[1]
+	})
+	text = slackChannelMentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := slackChannelMentionPattern.FindStringSubmatch(match)
+		if m[2] != "" {
+			return "#" + m[2]
+		}
+		return "#" + m[1]
+	})
+	text = slackSpecialMentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := slackSpecialMentionPattern.FindStringSubmatch(match)
+		if m[2] != "" {
+			return m[2]
+		}
+		return "@" + m[1]
+	})
+	text = slackLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := slackLinkPattern.FindStringSubmatch(match)
+		target := strings.TrimPrefix(m[1], "mailto:")
+		if m[2] == "" || m[2] == target || m[2] == m[1] {
+			return target
+		}
+		return m[2] + " (" + target + ")"
+	})
+	return strings.TrimSpace(html.UnescapeString(text))
+}
+
+func timestampTime(ts string) time.Time {
+	seconds, err := strconv.ParseFloat(ts, 64)
+	if err != nil || seconds <= 0 {
+		return time.Now().UTC()
+	}
+	return time.UnixMilli(int64(seconds * 1000)).UTC()
+}
@@ -0,0 +1,107 @@
+package slack
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeadingPattern    = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`)
+	mdBulletPattern     = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+	mdImagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdLinkPattern       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdBoldPattern       = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdItalicPattern     = regexp.MustCompile(`(^|[^*\w])\*(\S(?:[^*]*?\S)?)\*([^*\w]|$)`)
+	mdStrikePattern     = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mdInlineCodePattern = regexp.MustCompile("`[^`\n]+`")
+)
+
+// boldMarker stands in for Slack's bold asterisks while italics are rewritten.
+const boldMarker = "\x00"
+
+// markdownToMrkdwn converts common Markdown to Slack mrkdwn. Code spans and
+// fences are left untouched apart from the &, < and > escaping Slack requires
+// everywhere.
+func markdownToMrkdwn(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			lines[i] = escapeMrkdwn(strings.TrimSpace(line))
+			continue
+		}
+		if inFence {
+			lines[i] = escapeMrkdwn(line)
+			continue
+		}
+		lines[i] = convertMarkdownLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func convertMarkdownLine(line string) string {
+	if rest, ok := strings.CutPrefix(strings.TrimLeft(line, " "), ">"); ok {
+		return ">" + convertMarkdownLine(rest)
+	}
+	if m := mdHeadingPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+		return "*" + convertInline(m[1]) + "*"
+	}
+	if m := mdBulletPattern.FindStringSubmatch(line); m != nil {
+		return m[1] + "• " + convertInline(line[len(m[0]):])
+	}
+	return convertInline(line)
+}
+
+// convertInline rewrites inline formatting outside of code spans.
+func convertInline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range mdInlineCodePattern.FindAllStringIndex(text, -1) {
+		b.WriteString(convertInlineSegment(text[last:loc[0]]))
+		b.WriteString(escapeMrkdwn(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(convertInlineSegment(text[last:]))
+	return b.String()
+}
+
+func convertInlineSegment(text string) string {
+	if text == "" {
+		return ""
+	}
+	text = escapeMrkdwn(text)
+	text = mdImagePattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := mdImagePattern.FindStringSubmatch(match)
+		return slackLink(m[2], m[1])
+	})
+	text = mdLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := mdLinkPattern.FindStringSubmatch(match)
+		return slackLink(m[2], m[1])
+	})
+	text = mdBoldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := mdBoldPattern.FindStringSubmatch(match)
+		inner := m[1]
+		if inner == "" {
+			inner = m[2]
+		}
+		return boldMarker + inner + boldMarker
+	})
+	text = mdItalicPattern.ReplaceAllString(text, "${1}_${2}_${3}")
+	text = mdStrikePattern.ReplaceAllString(text, "~${1}~")
+	return strings.ReplaceAll(text, boldMarker, "*")
+}
+
+func slackLink(target, label string) string {
+	label = strings.TrimSpace(label)
+	if label == "" || label == target {
+		return "<" + target + ">"
+	}
+	return "<" + target + "|" + strings.ReplaceAll(label, "|", "¦") + ">"
+}
+
+func escapeMrkdwn(text string) string {
+	text = strings.ReplaceAll(text, "&", "&amp;")
+	text = strings.ReplaceAll(text, "<", "&lt;")
+	return strings.ReplaceAll(text, ">", "&gt;")
+}
@@ -0,0 +1,35 @@
+package slack
+
+import "testing"
+
+func TestMarkdownToMrkdwn(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"bold", "a **bold** and __also__ word", "a *bold* and *also* word"},
+		{"italic", "an *italic* word", "an _italic_ word"},
+		{"strike", "~~gone~~", "~gone~"},
+		{"link", "see [the docs](https://example.com/a?b=1&c=2)", "see <https://example.com/a?b=1&amp;c=2|the docs>"},
+		{"heading", "## Title", "*Title*"},
+		{"bullet", "- one\n* two", "• one\n• two"},
+		{"quote", "> quoted **text**", "> quoted *text*"},
+		{"escape", "1 < 2 & 3 > 2", "1 &lt; 2 &amp; 3 &gt; 2"},
+		{"inline code", "run `a **b**` now", "run `a **b**` now"},
+		{"fence", "```\n**x** <y>\n```", "```\n**x** &lt;y&gt;\n```"},
+	}
+	for _, tc := range cases {
+		if got := markdownToMrkdwn(tc.in); got != tc.want {
+			t.Errorf("%s: markdownToMrkdwn(%q) = %q, want %q", tc.name, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	in := "<@U01|alice> see <#C01|general> and <https://example.com|the site> &amp; <!here>"
+	want := "@alice see #general and the site (https://example.com) & @here"
+	if got := plainText(in); got != want {
+		t.Fatalf("plainText = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,704 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/media"
+	"github.com/memohai/memoh/internal/textutil"
+)
+
+const Type channel.ChannelType = "slack"
+
+const (
+	inboundDedupTTL      = 10 * time.Minute
+	slackSectionMaxLen   = 3000
+	slackButtonLabelMax  = 75
+	slackButtonValueMax  = 2000
+	slackMaxButtons      = 25
+	slackActionIDPrefix  = "memoh_action_"
+	slackMaxDownloadSize = media.MaxAssetBytes
+)
+
+// assetOpener reads stored asset bytes by content hash.
+type assetOpener interface {
+	Open(ctx context.Context, botID, contentHash string) (io.ReadCloser, media.Asset, error)
+}
+
+type SlackAdapter struct {
+	logger     *slog.Logger
+	httpClient *http.Client
+	assets     assetOpener
+	policies   channel.FetchPolicyResolver
+
+	mu           sync.Mutex
+	seenMessages map[string]time.Time // keyed by configID:channel:ts
+	selfUsers    map[string]string    // bot token -> bot user id
+	dmChannels   map[string]string    // bot token:user id -> DM channel id
+	userNames    map[string]string    // bot token:user id -> display name
+}
+
+func NewSlackAdapter(log *slog.Logger) *SlackAdapter {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &SlackAdapter{
+		logger:       log.With(slog.String("adapter", "slack")),
+		httpClient:   &http.Client{Timeout: slackDefaultTimeout},
+		seenMessages: make(map[string]time.Time),
+		selfUsers:    make(map[string]string),
+		dmChannels:   make(map[string]string),
+		userNames:    make(map[string]string),
+	}
+}
+
+// SetAssetOpener configures the asset opener for reading stored attachments by content hash.
+func (a *SlackAdapter) SetAssetOpener(opener assetOpener) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.assets = opener
+}
+
+// SetFetchPolicyResolver configures the per-bot policy that agent-supplied
+// attachment URLs are fetched under.
+func (a *SlackAdapter) SetFetchPolicyResolver(resolver channel.FetchPolicyResolver) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.policies = resolver
+}
+
+func (*SlackAdapter) Type() channel.ChannelType {
+	return Type
+}
+
+func (*SlackAdapter) Descriptor() channel.Descriptor {
+	return channel.Descriptor{
+		Type:        Type,
+		DisplayName: "Slack",
+		Capabilities: channel.ChannelCapabilities{
+			Text:           true,
+			Markdown:       true,
+			Attachments:    true,
+			Media:          true,
+			Reactions:      true,
+			Buttons:        true,
+			Reply:          true,
+			Threads:        true,
+			Streaming:      true,
+			BlockStreaming: true,
+			Edit:           true,
+			Unsend:         true,
+			ChatTypes:      []string{"direct", "group", "thread"},
+		},
+		OutboundPolicy: channel.OutboundPolicy{
+			TextChunkLimit: slackSectionMaxLen,
+			ChunkerMode:    channel.ChunkerModeMarkdown,
+			MediaOrder:     channel.OutboundOrderTextFirst,
+		},
+		ConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"botToken": {
+					Type:        channel.FieldSecret,
+					Required:    true,
+					Title:       "Bot Token",
+					Description: "Bot User OAuth Token, starts with xoxb-",
+				},
+				"appToken": {
+					Type:        channel.FieldSecret,
+					Required:    true,
+					Title:       "App Token",
+					Description: "App-level token with the connections:write scope for Socket Mode, starts with xapp-",
+				},
+				"apiBaseUrl": {
+					Type:        channel.FieldString,
+					Title:       "API Base URL",
+					Description: "Override the Slack Web API base URL",
+					Example:     defaultAPIBaseURL,
+				},
+			},
+		},
+		UserConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"user_id":    {Type: channel.FieldString, Title: "User ID"},
+				"channel_id": {Type: channel.FieldString, Title: "Channel ID"},
+				"username":   {Type: channel.FieldString, Title: "Username"},
+			},
+		},
+		TargetSpec: channel.TargetSpec{
+			Format: "channel_id[:thread_ts] | user_id",
+			Hints: []channel.TargetHint{
+				{Label: "Channel ID", Example: "C0123456789"},
+				{Label: "Thread", Example: "C0123456789:1712345678.000100"},
+				{Label: "User ID", Example: "U0123456789"},
+			},
+		},
+	}
+}
+
+func (*SlackAdapter) NormalizeConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeConfig(raw)
+}
+
+func (*SlackAdapter) NormalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeUserConfig(raw)
+}
+
+func (*SlackAdapter) NormalizeTarget(raw string) string {
+	return normalizeTarget(raw)
+}
+
+func (*SlackAdapter) ResolveTarget(userConfig map[string]any) (string, error) {
+	return resolveTarget(userConfig)
+}
+
+func (*SlackAdapter) MatchBinding(config map[string]any, criteria channel.BindingCriteria) bool {
+	return matchBinding(config, criteria)
+}
+
+func (*SlackAdapter) BuildUserConfig(identity channel.Identity) map[string]any {
+	return buildUserConfig(identity)
+}
+
+type slackPostResponse struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+func (a *SlackAdapter) Send(ctx context.Context, cfg channel.ChannelConfig, msg channel.OutboundMessage) error {
+	if msg.Message.IsEmpty() {
+		return errors.New("message is required")
+	}
+	if err := validateTarget(msg.Target); err != nil {
+		return err
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	channel.SetIMErrorSecrets("slack:"+cfg.ID, parsed.BotToken, parsed.AppToken)
+	conversation, threadTS, err := a.resolveConversation(ctx, parsed, msg.Target)
+	if err != nil {
+		return err
+	}
+	threadTS = messageThreadTS(msg.Message, threadTS)
+	if text := strings.TrimSpace(msg.Message.PlainText()); text != "" || len(msg.Message.Actions) > 0 {
+		if _, err := a.postMessage(ctx, parsed, conversation, threadTS, msg.Message); err != nil {
+			return err
+		}
+	}
+	for _, att := range msg.Message.Attachments {
+		if err := a.uploadAttachment(ctx, parsed, cfg.BotID, conversation, threadTS, att); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// messageThreadTS picks the thread a message goes to: an explicit thread on
+// the message wins over the one carried by the target.
+func messageThreadTS(msg channel.Message, targetThread string) string {
+	if msg.Thread != nil && strings.TrimSpace(msg.Thread.ID) != "" {
+		return strings.TrimSpace(msg.Thread.ID)
+	}
+	return targetThread
+}
+
+func (a *SlackAdapter) postMessage(ctx context.Context, cfg Config, conversation, threadTS string, msg channel.Message) (string, error) {
+	body := buildMessagePayload(msg)
+	body["channel"] = conversation
+	if threadTS != "" {
+		body["thread_ts"] = threadTS
+	}
+	var resp slackPostResponse
+	if err := a.call(ctx, cfg, cfg.BotToken, "chat.postMessage", body, &resp); err != nil {
+		return "", err
+	}
+	return resp.TS, nil
+}
+
+// buildMessagePayload renders the text as mrkdwn and, when the message has
+// actions, as Block Kit sections followed by an actions block of buttons.
+// The top-level text stays as the notification fallback.
+func buildMessagePayload(msg channel.Message) map[string]any {
+	text := strings.TrimSpace(msg.PlainText())
+	if msg.Format == channel.MessageFormatMarkdown {
+		text = markdownToMrkdwn(text)
+	}
+	body := map[string]any{"text": text}
+	buttons := buildButtons(msg.Actions)
+	if len(buttons) == 0 {
+		return body
+	}
+	blocks := make([]map[string]any, 0, 2)
+	for _, chunk := range splitRunes(text, slackSectionMaxLen) {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": chunk},
+		})
+	}
+	blocks = append(blocks, map[string]any{"type": "actions", "elements": buttons})
+	if text == "" {
+		body["text"] = buttonFallbackText(msg.Actions)
+	}
+	body["blocks"] = blocks
+	return body
+}
+
+func buildButtons(actions []channel.Action) []map[string]any {
+	buttons := make([]map[string]any, 0, len(actions))
+	for i, action := range actions {
+		if len(buttons) == slackMaxButtons {
+			break
+		}
+		label := strings.TrimSpace(action.Label)
+		if label == "" {
+			label = strings.TrimSpace(action.Value)
+		}
+		if label == "" {
+			continue
+		}
+		button := map[string]any{
+			"type":      "button",
+			"action_id": slackActionIDPrefix + strconv.Itoa(i),
+			"text": map[string]any{
+				"type": "plain_text",
+				"text": textutil.TruncateRunesWithSuffix(label, slackButtonLabelMax, "…"),
+			},
+		}
+		if link := strings.TrimSpace(action.URL); link != "" {
+			button["url"] = link
+		}
+		if value := strings.TrimSpace(action.Value); value != "" {
+			button["value"] = textutil.TruncateRunes(value, slackButtonValueMax)
+		}
+		buttons = append(buttons, button)
+	}
+	return buttons
+}
+
+func buttonFallbackText(actions []channel.Action) string {
+	labels := make([]string, 0, len(actions))
+	for _, action := range actions {
+		if label := strings.TrimSpace(action.Label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return strings.Join(labels, " | ")
+}
+
+// OpenStream streams a reply by posting it once and editing it with
+// chat.update. Slack has no quoted replies, so opts.Reply is not used; thread
+// replies come from the target.
+func (a *SlackAdapter) OpenStream(_ context.Context, cfg channel.ChannelConfig, target string, _ channel.StreamOptions) (channel.OutboundStream, error) {
+	if err := validateTarget(target); err != nil {
+		return nil, err
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	return &slackOutboundStream{
+		adapter: a,
+		cfg:     parsed,
+		botID:   cfg.BotID,
+		target:  normalizeTarget(target),
+	}, nil
+}
+
+func (a *SlackAdapter) Update(ctx context.Context, cfg channel.ChannelConfig, target string, messageID string, msg channel.Message) error {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	conversation, _, err := a.resolveConversation(ctx, parsed, target)
+	if err != nil {
+		return err
+	}
+	return a.updateMessage(ctx, parsed, conversation, strings.TrimSpace(messageID), msg)
+}
+
+func (a *SlackAdapter) updateMessage(ctx context.Context, cfg Config, conversation, ts string, msg channel.Message) error {
+	if ts == "" {
+		return errors.New("slack message ts is required")
+	}
+	body := buildMessagePayload(msg)
+	body["channel"] = conversation
+	body["ts"] = ts
+	if _, ok := body["blocks"]; !ok {
+		// chat.update keeps the previous blocks unless told otherwise.
+		body["blocks"] = []map[string]any{}
+	}
+	return a.call(ctx, cfg, cfg.BotToken, "chat.update", body, nil)
+}
+
+func (a *SlackAdapter) Unsend(ctx context.Context, cfg channel.ChannelConfig, target string, messageID string) error {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	conversation, _, err := a.resolveConversation(ctx, parsed, target)
+	if err != nil {
+		return err
+	}
+	return a.call(ctx, parsed, parsed.BotToken, "chat.delete", map[string]any{
+		"channel": conversation,
+		"ts":      strings.TrimSpace(messageID),
+	}, nil)
+}
+
+func (a *SlackAdapter) React(ctx context.Context, cfg channel.ChannelConfig, target string, messageID string, emoji string) error {
+	return a.reaction(ctx, cfg, "reactions.add", target, messageID, emoji)
+}
+
+func (a *SlackAdapter) Unreact(ctx context.Context, cfg channel.ChannelConfig, target string, messageID string, emoji string) error {
+	return a.reaction(ctx, cfg, "reactions.remove", target, messageID, emoji)
+}
+
+func (a *SlackAdapter) reaction(ctx context.Context, cfg channel.ChannelConfig, method, target, messageID, emoji string) error {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	name := reactionName(emoji)
+	if name == "" {
+		return errors.New("slack reaction emoji is required")
+	}
+	conversation, _, err := a.resolveConversation(ctx, parsed, target)
+	if err != nil {
+		return err
+	}
+	err = a.call(ctx, parsed, parsed.BotToken, method, map[string]any{
+		"channel":   conversation,
+		"timestamp": strings.TrimSpace(messageID),
+		"name":      name,
+	}, nil)
+	// Adding a reaction twice or removing a missing one is not worth failing over.
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && (apiErr.Code == "already_reacted" || apiErr.Code == "no_reaction") {
+		return nil
+	}
+	return err
+}
+
+// slackReactionNames maps the unicode emoji agents usually react with to
+// Slack's short names; anything else is passed through as a name.
+var slackReactionNames = map[string]string{
+	"👍":  "+1",
+	"👎":  "-1",
+	"❤️": "heart",
+	"❤":  "heart",
+	"😂":  "joy",
+	"😄":  "smile",
+	"😊":  "blush",
+	"🎉":  "tada",
+	"🔥":  "fire",
+	"👀":  "eyes",
+	"✅":  "white_check_mark",
+	"❌":  "x",
+	"🤔":  "thinking_face",
+	"🙏":  "pray",
+	"👌":  "ok_hand",
+	"👏":  "clap",
+	"🚀":  "rocket",
+	"💯":  "100",
+	"😢":  "cry",
+	"😮":  "open_mouth",
+	"⏳":  "hourglass_flowing_sand",
+	"✍️": "writing_hand",
+}
+
+func reactionName(emoji string) string {
+	value := strings.TrimSpace(emoji)
+	if name, ok := slackReactionNames[value]; ok {
+		return name
+	}
+	return strings.Trim(value, ":")
+}
+
+type slackAuthTestResponse struct {
+	UserID string `json:"user_id"`
+	User   string `json:"user"`
+	BotID  string `json:"bot_id"`
+	TeamID string `json:"team_id"`
+	Team   string `json:"team"`
+}
+
+type slackUser struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	RealName string `json:"real_name"`
+	Deleted  bool   `json:"deleted"`
+	IsBot    bool   `json:"is_bot"`
+	Profile  struct {
+		DisplayName string `json:"display_name"`
+		RealName    string `json:"real_name"`
+		Email       string `json:"email"`
+		Image192    string `json:"image_192"`
+	} `json:"profile"`
+}
+
+func (u slackUser) displayName() string {
+	for _, name := range []string{u.Profile.DisplayName, u.Profile.RealName, u.RealName, u.Name} {
+		if name = strings.TrimSpace(name); name != "" {
+			return name
+		}
+	}
+	return u.ID
+}
+
+func (a *SlackAdapter) DiscoverSelf(ctx context.Context, credentials map[string]any) (map[string]any, string, error) {
+	cfg, err := parseConfig(credentials)
+	if err != nil {
+		return nil, "", err
+	}
+	auth, err := a.authTest(ctx, cfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("slack discover self: %w", err)
+	}
+	identity := map[string]any{
+		"user_id":  auth.UserID,
+		"username": auth.User,
+		"team_id":  auth.TeamID,
+	}
+	// Best effort: a missing users:read scope only costs the display name.
+	if user, err := a.userInfo(ctx, cfg, auth.UserID); err == nil {
+		identity["name"] = user.displayName()
+		if user.Profile.Image192 != "" {
+			identity["avatar_url"] = user.Profile.Image192
+		}
+	}
+	return identity, auth.UserID, nil
+}
+
+func (a *SlackAdapter) authTest(ctx context.Context, cfg Config) (slackAuthTestResponse, error) {
+	var resp slackAuthTestResponse
+	if err := a.call(ctx, cfg, cfg.BotToken, "auth.test", nil, &resp); err != nil {
+		return slackAuthTestResponse{}, err
+	}
+	if strings.TrimSpace(resp.UserID) == "" {
+		return slackAuthTestResponse{}, errors.New("slack auth.test returned no user_id")
+	}
+	a.mu.Lock()
+	a.selfUsers[cfg.BotToken] = resp.UserID
+	a.mu.Unlock()
+	return resp, nil
+}
+
+func (a *SlackAdapter) userInfo(ctx context.Context, cfg Config, userID string) (slackUser, error) {
+	var resp struct {
+		User slackUser `json:"user"`
+	}
+	if err := a.call(ctx, cfg, cfg.BotToken, "users.info", url.Values{"user": {userID}}, &resp); err != nil {
+		return slackUser{}, err
+	}
+	return resp.User, nil
+}
+
+// resolveConversation turns a target into a conversation ID and thread ts.
+// User IDs are opened as DMs, since file uploads and edits need the DM's
+// channel ID rather than the user's.
+func (a *SlackAdapter) resolveConversation(ctx context.Context, cfg Config, target string) (string, string, error) {
+	if err := validateTarget(target); err != nil {
+		return "", "", err
+	}
+	conversation, threadTS := splitTarget(target)
+	if conversation[0] != 'U' && conversation[0] != 'W' {
+		return conversation, threadTS, nil
+	}
+	key := cfg.BotToken + ":" + conversation
+	a.mu.Lock()
+	dm, ok := a.dmChannels[key]
+	a.mu.Unlock()
+	if ok {
+		return dm, threadTS, nil
+	}
+	var resp struct {
+		Channel struct {
+			ID string `json:"id"`
+		} `json:"channel"`
+	}
+	if err := a.call(ctx, cfg, cfg.BotToken, "conversations.open", map[string]any{"users": conversation}, &resp); err != nil {
+		return "", "", err
+	}
+	if resp.Channel.ID == "" {
+		return "", "", errors.New("slack conversations.open returned no channel")
+	}
+	a.mu.Lock()
+	a.dmChannels[key] = resp.Channel.ID
+	a.mu.Unlock()
+	return resp.Channel.ID, threadTS, nil
+}
+
+// uploadAttachment uploads a file with the external upload flow: reserve an
+// upload URL, post the bytes there, then share the file into the
+// conversation.
+func (a *SlackAdapter) uploadAttachment(ctx context.Context, cfg Config, botID, conversation, threadTS string, att channel.Attachment) error {
+	data, name, err := a.loadAttachment(ctx, botID, att)
+	if err != nil {
+		return err
+	}
+	var reserved struct {
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	if err := a.call(ctx, cfg, cfg.BotToken, "files.getUploadURLExternal", url.Values{
+		"filename": {name},
+		"length":   {strconv.Itoa(len(data))},
+	}, &reserved); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reserved.UploadURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("slack upload: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := a.httpClient.Do(req) //nolint:gosec // G704: upload URL is issued by the Slack API
+	if err != nil {
+		return fmt.Errorf("slack upload: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("slack upload: HTTP %d", resp.StatusCode)
+	}
+	file := map[string]any{"id": reserved.FileID, "title": name}
+	complete := map[string]any{
+		"files":      []map[string]any{file},
+		"channel_id": conversation,
+	}
+	if threadTS != "" {
+		complete["thread_ts"] = threadTS
+	}
+	if caption := strings.TrimSpace(att.Caption); caption != "" {
+		complete["initial_comment"] = caption
+	}
+	return a.call(ctx, cfg, cfg.BotToken, "files.completeUploadExternal", complete, nil)
+}
+
+// loadAttachment reads an outbound attachment through the shared attachment
+// source, so agent-supplied URLs are checked against the bot's fetch policy.
+func (a *SlackAdapter) loadAttachment(ctx context.Context, botID string, att channel.Attachment) ([]byte, string, error) {
+	name := strings.TrimSpace(att.Name)
+	if name == "" {
+		name = "attachment" + mimeExtension(att.Mime)
+	}
+	a.mu.Lock()
+	source := channel.AttachmentSource{Assets: a.assets, Policies: a.policies, MaxBytes: slackMaxDownloadSize}
+	a.mu.Unlock()
+	data, err := source.Load(ctx, botID, att)
+	if err != nil {
+		return nil, "", fmt.Errorf("slack attachment: %w", err)
+	}
+	return data, name, nil
+}
+
+// download fetches a Slack-issued file URL with the bot token. It must not
+// be used for agent-supplied URLs.
+func (a *SlackAdapter) download(ctx context.Context, link, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := a.httpClient.Do(req) //nolint:gosec // G704: URL is a file URL issued by the Slack API
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, slackMaxDownloadSize))
+}
+
+// ResolveAttachment downloads an inbound Slack file. Slack file URLs need the
+// bot token, so inbound attachments carry them as platform keys rather than
+// plain URLs.
+func (a *SlackAdapter) ResolveAttachment(ctx context.Context, cfg channel.ChannelConfig, attachment channel.Attachment) (channel.AttachmentPayload, error) {
+	link := strings.TrimSpace(attachment.PlatformKey)
+	if link == "" {
+		link = strings.TrimSpace(attachment.URL)
+	}
+	if link == "" {
+		return channel.AttachmentPayload{}, errors.New("slack attachment requires platform_key or url")
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return channel.AttachmentPayload{}, err
+	}
+	data, err := a.download(ctx, link, parsed.BotToken)
+	if err != nil {
+		return channel.AttachmentPayload{}, fmt.Errorf("download slack file: %w", err)
+	}
+	return channel.AttachmentPayload{
+		Reader: io.NopCloser(bytes.NewReader(data)),
+		Mime:   strings.TrimSpace(attachment.Mime),
+		Name:   strings.TrimSpace(attachment.Name),
+		Size:   int64(len(data)),
+	}, nil
+}
+
+// mimeExtension returns file extension for common mime types.
+func mimeExtension(mime string) string {
+	switch mime {
+	case "image/jpeg", "image/jpg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "video/mp4":
+		return ".mp4"
+	case "audio/mpeg", "audio/mp3":
+		return ".mp3"
+	case "audio/ogg":
+		return ".ogg"
+	case "application/pdf":
+		return ".pdf"
+	case "text/plain":
+		return ".txt"
+	default:
+		return ""
+	}
+}
+
+// splitRunes splits text into chunks of at most limit runes, preferring line
+// breaks.
+func splitRunes(text string, limit int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	var chunks []string
+	runes := []rune(text)
+	for len(runes) > limit {
+		cut := limit
+		for i := limit - 1; i > limit/2; i-- {
+			if runes[i] == '\n' {
+				cut = i
+				break
+			}
+		}
+		chunks = append(chunks, strings.TrimSpace(string(runes[:cut])))
+		runes = runes[cut:]
+	}
+	if rest := strings.TrimSpace(string(runes)); rest != "" {
+		chunks = append(chunks, rest)
+	}
+	return chunks
+}
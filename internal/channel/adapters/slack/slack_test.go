@@ -0,0 +1,402 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+type fakeCall struct {
+	Method string
+	Token  string
+	Body   map[string]any
+}
+
+// fakeSlack is a local stand-in for the Web API and the Socket Mode
+// websocket.
+type fakeSlack struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	calls    []fakeCall
+	replies  map[string]func(body map[string]any) any
+	frames   chan any
+	acks     chan string
+	upgrader websocket.Upgrader
+}
+
+func newFakeSlack(t *testing.T) *fakeSlack {
+	t.Helper()
+	f := &fakeSlack{
+		replies: map[string]func(map[string]any) any{},
+		frames:  make(chan any, 16),
+		acks:    make(chan string, 16),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/", f.handleAPI)
+	mux.HandleFunc("/socket", f.handleSocket)
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = io.WriteString(w, "file-bytes")
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeSlack) config() channel.ChannelConfig {
+	return channel.ChannelConfig{
+		ID:          "cfg-1",
+		BotID:       "bot-1",
+		ChannelType: Type,
+		Credentials: map[string]any{
+			"botToken":   "xoxb-test",
+			"appToken":   "xapp-test",
+			"apiBaseUrl": f.server.URL + "/api",
+		},
+	}
+}
+
+func (f *fakeSlack) reply(method string, fn func(body map[string]any) any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies[method] = fn
+}
+
+func (f *fakeSlack) callsTo(method string) []fakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []fakeCall
+	for _, call := range f.calls {
+		if call.Method == method {
+			out = append(out, call)
+		}
+	}
+	return out
+}
+
+func (f *fakeSlack) handleAPI(w http.ResponseWriter, r *http.Request) {
+	method := strings.TrimPrefix(r.URL.Path, "/api/")
+	body := map[string]any{}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	} else if err := r.ParseForm(); err == nil {
+		for key := range r.PostForm {
+			body[key] = r.PostForm.Get(key)
+		}
+	}
+	f.mu.Lock()
+	f.calls = append(f.calls, fakeCall{Method: method, Token: strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), Body: body})
+	fn := f.replies[method]
+	f.mu.Unlock()
+
+	var resp any = map[string]any{"ok": true}
+	switch {
+	case fn != nil:
+		resp = fn(body)
+	case method == "auth.test":
+		resp = map[string]any{"ok": true, "user_id": "UBOT", "user": "memoh", "team_id": "T1", "team": "Acme"}
+	case method == "users.info":
+		resp = map[string]any{"ok": true, "user": map[string]any{"id": body["user"], "name": "alice", "profile": map[string]any{"display_name": "Alice"}}}
+	case method == "chat.postMessage":
+		resp = map[string]any{"ok": true, "channel": body["channel"], "ts": "200.000100"}
+	case method == "apps.connections.open":
+		resp = map[string]any{"ok": true, "url": "ws" + strings.TrimPrefix(f.server.URL, "http") + "/socket"}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeSlack) handleSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := f.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	go func() {
+		for {
+			var ack socketAck
+			if err := conn.ReadJSON(&ack); err != nil {
+				return
+			}
+			f.acks <- ack.EnvelopeID
+		}
+	}()
+	_ = conn.WriteJSON(map[string]any{"type": "hello"})
+	for {
+		select {
+		case frame := <-f.frames:
+			if err := conn.WriteJSON(frame); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func newTestAdapter() *SlackAdapter {
+	return NewSlackAdapter(slog.New(slog.DiscardHandler))
+}
+
+func TestSendPostsThreadReplyWithButtons(t *testing.T) {
+	f := newFakeSlack(t)
+	adapter := newTestAdapter()
+
+	err := adapter.Send(context.Background(), f.config(), channel.OutboundMessage{
+		Target: "C01:100.000200",
+		Message: channel.Message{
+			Text:   "Approve **this**?",
+			Format: channel.MessageFormatMarkdown,
+			Actions: []channel.Action{
+				{Type: "button", Label: "Approve", Value: "/approve 1"},
+				{Type: "button", Label: "Docs", URL: "https://example.com"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	posts := f.callsTo("chat.postMessage")
+	if len(posts) != 1 {
+		t.Fatalf("expected one post, got %d", len(posts))
+	}
+	body := posts[0].Body
+	if posts[0].Token != "xoxb-test" || body["channel"] != "C01" || body["thread_ts"] != "100.000200" {
+		t.Fatalf("unexpected post: token=%q body=%v", posts[0].Token, body)
+	}
+	if body["text"] != "Approve *this*?" {
+		t.Fatalf("expected mrkdwn fallback text, got %v", body["text"])
+	}
+	blocks, _ := body["blocks"].([]any)
+	if len(blocks) != 2 {
+		t.Fatalf("expected section and actions blocks, got %v", body["blocks"])
+	}
+	actions := blocks[1].(map[string]any)
+	elements := actions["elements"].([]any)
+	first := elements[0].(map[string]any)
+	second := elements[1].(map[string]any)
+	if actions["type"] != "actions" || first["value"] != "/approve 1" || second["url"] != "https://example.com" {
+		t.Fatalf("unexpected actions block: %v", actions)
+	}
+}
+
+func TestSendToUserOpensDirectMessage(t *testing.T) {
+	f := newFakeSlack(t)
+	f.reply("conversations.open", func(map[string]any) any {
+		return map[string]any{"ok": true, "channel": map[string]any{"id": "D01"}}
+	})
+	adapter := newTestAdapter()
+	for range 2 {
+		if err := adapter.Send(context.Background(), f.config(), channel.OutboundMessage{Target: "U01", Message: channel.Message{Text: "hi"}}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	if opens := f.callsTo("conversations.open"); len(opens) != 1 || opens[0].Body["users"] != "U01" {
+		t.Fatalf("expected one cached conversations.open, got %v", opens)
+	}
+	for _, post := range f.callsTo("chat.postMessage") {
+		if post.Body["channel"] != "D01" {
+			t.Fatalf("expected DM channel, got %v", post.Body["channel"])
+		}
+	}
+}
+
+func TestSendSurfacesAPIErrors(t *testing.T) {
+	f := newFakeSlack(t)
+	f.reply("chat.postMessage", func(map[string]any) any {
+		return map[string]any{"ok": false, "error": "channel_not_found"}
+	})
+	err := newTestAdapter().Send(context.Background(), f.config(), channel.OutboundMessage{Target: "C404", Message: channel.Message{Text: "hi"}})
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Fatalf("expected channel_not_found, got %v", err)
+	}
+}
+
+func TestStreamPostsThenUpdates(t *testing.T) {
+	f := newFakeSlack(t)
+	adapter := newTestAdapter()
+	stream, err := adapter.OpenStream(context.Background(), f.config(), "C01:100.000200", channel.StreamOptions{})
+	if err != nil {
+		t.Fatalf("OpenStream: %v", err)
+	}
+	ctx := context.Background()
+	for _, delta := range []string{"Hel", "lo"} {
+		if err := stream.Push(ctx, channel.StreamEvent{Type: channel.StreamEventDelta, Delta: delta}); err != nil {
+			t.Fatalf("Push delta: %v", err)
+		}
+	}
+	if err := stream.Push(ctx, channel.StreamEvent{Type: channel.StreamEventFinal, Final: &channel.StreamFinalizePayload{
+		Message: channel.Message{Text: "Hello world"},
+	}}); err != nil {
+		t.Fatalf("Push final: %v", err)
+	}
+	posts := f.callsTo("chat.postMessage")
+	if len(posts) != 1 || posts[0].Body["text"] != "Hel" || posts[0].Body["thread_ts"] != "100.000200" {
+		t.Fatalf("expected the first delta to be posted in the thread, got %v", posts)
+	}
+	updates := f.callsTo("chat.update")
+	if len(updates) != 1 {
+		t.Fatalf("expected throttled deltas and one final update, got %d", len(updates))
+	}
+	if updates[0].Body["ts"] != "200.000100" || updates[0].Body["text"] != "Hello world" {
+		t.Fatalf("unexpected update: %v", updates[0].Body)
+	}
+}
+
+func TestReactMapsUnicodeAndIgnoresDuplicates(t *testing.T) {
+	f := newFakeSlack(t)
+	f.reply("reactions.add", func(map[string]any) any {
+		return map[string]any{"ok": false, "error": "already_reacted"}
+	})
+	if err := newTestAdapter().React(context.Background(), f.config(), "C01", "100.1", "👍"); err != nil {
+		t.Fatalf("React: %v", err)
+	}
+	calls := f.callsTo("reactions.add")
+	if len(calls) != 1 || calls[0].Body["name"] != "+1" || calls[0].Body["timestamp"] != "100.1" {
+		t.Fatalf("unexpected reactions.add: %v", calls)
+	}
+	if got := reactionName(":tada:"); got != "tada" {
+		t.Fatalf("reactionName(:tada:) = %q", got)
+	}
+}
+
+func TestDiscoverSelf(t *testing.T) {
+	f := newFakeSlack(t)
+	identity, externalID, err := newTestAdapter().DiscoverSelf(context.Background(), f.config().Credentials)
+	if err != nil {
+		t.Fatalf("DiscoverSelf: %v", err)
+	}
+	if externalID != "UBOT" || identity["username"] != "memoh" || identity["name"] != "Alice" {
+		t.Fatalf("unexpected identity: %v (%s)", identity, externalID)
+	}
+}
+
+func TestResolveAttachmentUsesBotToken(t *testing.T) {
+	f := newFakeSlack(t)
+	payload, err := newTestAdapter().ResolveAttachment(context.Background(), f.config(), channel.Attachment{
+		PlatformKey: f.server.URL + "/files/F01/report.pdf",
+		Name:        "report.pdf",
+		Mime:        "application/pdf",
+	})
+	if err != nil {
+		t.Fatalf("ResolveAttachment: %v", err)
+	}
+	defer func() { _ = payload.Reader.Close() }()
+	data, _ := io.ReadAll(payload.Reader)
+	if string(data) != "file-bytes" || payload.Mime != "application/pdf" {
+		t.Fatalf("unexpected payload: %q %q", data, payload.Mime)
+	}
+}
+
+func TestConnectDeliversThreadMessagesAndButtonClicks(t *testing.T) {
+	f := newFakeSlack(t)
+	adapter := newTestAdapter()
+	received := make(chan channel.InboundMessage, 4)
+	conn, err := adapter.Connect(context.Background(), f.config(), func(_ context.Context, _ channel.ChannelConfig, msg channel.InboundMessage) error {
+		received <- msg
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() { _ = conn.Stop(context.Background()) }()
+
+	message := map[string]any{
+		"type": "message", "channel": "C01", "channel_type": "channel", "user": "U01",
+		"text": "<@UBOT> summarize &amp; reply", "ts": "101.000300", "thread_ts": "100.000200", "parent_user_id": "UBOT",
+		"files": []any{map[string]any{"id": "F01", "name": "a.png", "mimetype": "image/png", "url_private_download": "https://files.slack.com/a.png"}},
+	}
+	mention := map[string]any{}
+	for k, v := range message {
+		mention[k] = v
+	}
+	mention["type"] = "app_mention"
+	f.frames <- map[string]any{"type": "events_api", "envelope_id": "e1", "payload": map[string]any{"type": "event_callback", "event": message}}
+	f.frames <- map[string]any{"type": "events_api", "envelope_id": "e2", "payload": map[string]any{"type": "event_callback", "event": mention}}
+	f.frames <- map[string]any{"type": "events_api", "envelope_id": "e3", "payload": map[string]any{"type": "event_callback", "event": map[string]any{
+		"type": "message", "channel": "C01", "user": "UBOT", "text": "my own echo", "ts": "102.0",
+	}}}
+	f.frames <- map[string]any{"type": "interactive", "envelope_id": "e4", "payload": map[string]any{
+		"type":      "block_actions",
+		"user":      map[string]any{"id": "U01", "username": "alice"},
+		"channel":   map[string]any{"id": "D01"},
+		"container": map[string]any{"message_ts": "200.000100", "channel_id": "D01"},
+		"message":   map[string]any{"ts": "200.000100"},
+		"actions":   []any{map[string]any{"action_id": slackActionIDPrefix + "0", "value": "/approve 1", "action_ts": "300.1", "text": map[string]any{"type": "plain_text", "text": "Approve"}}},
+	}}
+
+	for _, want := range []string{"e1", "e2", "e3", "e4"} {
+		select {
+		case got := <-f.acks:
+			if got != want {
+				t.Fatalf("ack = %q, want %q", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for ack %s", want)
+		}
+	}
+
+	var msgs []channel.InboundMessage
+	for len(msgs) < 2 {
+		select {
+		case msg := <-received:
+			msgs = append(msgs, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for inbound messages, got %d", len(msgs))
+		}
+	}
+	select {
+	case extra := <-received:
+		t.Fatalf("unexpected extra inbound message: %+v", extra)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	var threadMsg, clickMsg channel.InboundMessage
+	for _, msg := range msgs {
+		if msg.Message.Text == "/approve 1" {
+			clickMsg = msg
+		} else {
+			threadMsg = msg
+		}
+	}
+	if threadMsg.Message.Text != "@UBOT summarize & reply" {
+		t.Fatalf("unexpected text: %q", threadMsg.Message.Text)
+	}
+	if threadMsg.Conversation.Type != channel.ConversationTypeThread || threadMsg.Conversation.ThreadID != "100.000200" ||
+		threadMsg.Message.Thread == nil || threadMsg.ReplyTarget != "C01:100.000200" {
+		t.Fatalf("thread not mapped: %+v", threadMsg)
+	}
+	if threadMsg.Metadata["is_mentioned"] != true || threadMsg.Metadata["is_reply_to_bot"] != true {
+		t.Fatalf("unexpected metadata: %v", threadMsg.Metadata)
+	}
+	if threadMsg.Sender.DisplayName != "Alice" || threadMsg.BotID != "bot-1" {
+		t.Fatalf("unexpected sender: %+v", threadMsg.Sender)
+	}
+	if len(threadMsg.Message.Attachments) != 1 {
+		t.Fatalf("expected one attachment, got %+v", threadMsg.Message.Attachments)
+	}
+	att := threadMsg.Message.Attachments[0]
+	if att.URL != "" || att.PlatformKey != "https://files.slack.com/a.png" || att.Type != channel.AttachmentImage {
+		t.Fatalf("attachment should be resolved through the adapter: %+v", att)
+	}
+	if clickMsg.Conversation.Type != channel.ConversationTypePrivate || clickMsg.ReplyTarget != "D01" || clickMsg.Sender.SubjectID != "U01" {
+		t.Fatalf("unexpected button click message: %+v", clickMsg)
+	}
+	if clickMsg.Interaction == nil || clickMsg.Interaction.MessageID != "200.000100" || clickMsg.Interaction.Label != "Approve" {
+		t.Fatalf("unexpected button click interaction: %+v", clickMsg.Interaction)
+	}
+}
@@ -0,0 +1,141 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+// socketEnvelope is a Socket Mode frame. Every frame with an envelope ID has
+// to be acknowledged, or Slack redelivers it.
+type socketEnvelope struct {
+	Type       string          `json:"type"`
+	EnvelopeID string          `json:"envelope_id"`
+	Payload    json.RawMessage `json:"payload"`
+	Reason     string          `json:"reason"`
+}
+
+type socketAck struct {
+	EnvelopeID string `json:"envelope_id"`
+}
+
+var socketReconnectBackoffs = []time.Duration{time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 20 * time.Second}
+
+func (a *SlackAdapter) Connect(ctx context.Context, cfg channel.ChannelConfig, handler channel.InboundHandler) (channel.Connection, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	channel.SetIMErrorSecrets("slack:"+cfg.ID, parsed.BotToken, parsed.AppToken)
+	auth, err := a.authTest(ctx, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("slack bot token check failed: %w", err)
+	}
+	if a.logger != nil {
+		a.logger.Info("start", slog.String("config_id", cfg.ID), slog.String("team", auth.Team), slog.String("user_id", auth.UserID))
+	}
+	connCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.runSocket(connCtx, cfg, parsed, auth.UserID, handler)
+	}()
+	return channel.NewConnection(cfg, func(context.Context) error {
+		if a.logger != nil {
+			a.logger.Info("stop", slog.String("config_id", cfg.ID))
+		}
+		cancel()
+		<-done
+		return nil
+	}), nil
+}
+
+// runSocket keeps a Socket Mode connection open until ctx ends, reconnecting
+// with backoff. Slack rotates connections every few hours with a
+// "disconnect" frame, which reconnects right away.
+func (a *SlackAdapter) runSocket(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, handler channel.InboundHandler) {
+	attempt := 0
+	for ctx.Err() == nil {
+		healthy, err := a.socketOnce(ctx, cfg, parsed, selfID, handler)
+		if ctx.Err() != nil {
+			return
+		}
+		if healthy {
+			attempt = 0
+		}
+		if err == nil {
+			continue
+		}
+		delay := socketReconnectBackoffs[min(attempt, len(socketReconnectBackoffs)-1)]
+		attempt++
+		if a.logger != nil {
+			a.logger.Warn("socket mode reconnect", slog.String("config_id", cfg.ID), slog.Duration("delay", delay), slog.Any("error", err))
+		}
+		if !sleepContext(ctx, delay) {
+			return
+		}
+	}
+}
+
+// socketOnce runs a single connection. healthy reports whether Slack said
+// hello, i.e. the connection worked before it ended.
+func (a *SlackAdapter) socketOnce(ctx context.Context, cfg channel.ChannelConfig, parsed Config, selfID string, handler channel.InboundHandler) (bool, error) {
+	var opened struct {
+		URL string `json:"url"`
+	}
+	if err := a.call(ctx, parsed, parsed.AppToken, "apps.connections.open", nil, &opened); err != nil {
+		return false, err
+	}
+	if opened.URL == "" {
+		return false, errors.New("slack apps.connections.open returned no url")
+	}
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, opened.URL, nil)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return false, fmt.Errorf("dial socket mode: %w", err)
+	}
+	var closeOnce sync.Once
+	closeConn := func() { closeOnce.Do(func() { _ = conn.Close() }) }
+	defer closeConn()
+	stop := context.AfterFunc(ctx, closeConn)
+	defer stop()
+
+	healthy := false
+	for {
+		var envelope socketEnvelope
+		if err := conn.ReadJSON(&envelope); err != nil {
+			if ctx.Err() != nil {
+				return healthy, nil
+			}
+			return healthy, fmt.Errorf("read socket mode frame: %w", err)
+		}
+		if envelope.EnvelopeID != "" {
+			if err := conn.WriteJSON(socketAck{EnvelopeID: envelope.EnvelopeID}); err != nil {
+				return healthy, fmt.Errorf("ack socket mode envelope: %w", err)
+			}
+		}
+		switch envelope.Type {
+		case "hello":
+			healthy = true
+		case "disconnect":
+			if a.logger != nil {
+				a.logger.Info("socket mode disconnect requested", slog.String("config_id", cfg.ID), slog.String("reason", envelope.Reason))
+			}
+			return healthy, nil
+		case "events_api":
+			a.handleEventsAPI(ctx, cfg, parsed, selfID, envelope.Payload, handler)
+		case "interactive":
+			a.handleInteractive(ctx, cfg, parsed, selfID, envelope.Payload, handler)
+		}
+	}
+}
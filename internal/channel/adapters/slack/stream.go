@@ -0,0 +1,215 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+// slackEditThrottle keeps chat.update calls under Slack's per-channel rate
+// limit of roughly one write per second.
+const slackEditThrottle = 1500 * time.Millisecond
+
+type slackOutboundStream struct {
+	adapter *SlackAdapter
+	cfg     Config
+	botID   string
+	target  string
+
+	closed atomic.Bool
+	mu     sync.Mutex
+
+	conversation string
+	threadTS     string
+	messageTS    string
+	buffer       strings.Builder
+	lastText     string
+	lastEditedAt time.Time
+}
+
+func (s *slackOutboundStream) Push(ctx context.Context, event channel.StreamEvent) error {
+	if s == nil || s.adapter == nil {
+		return errors.New("slack stream not configured")
+	}
+	if s.closed.Load() {
+		return errors.New("slack stream is closed")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	switch event.Type {
+	case channel.StreamEventStatus,
+		channel.StreamEventPhaseStart,
+		channel.StreamEventToolCallEnd,
+		channel.StreamEventAgentStart,
+		channel.StreamEventAgentEnd,
+		channel.StreamEventProcessingStarted,
+		channel.StreamEventProcessingCompleted,
+		channel.StreamEventProcessingFailed:
+		return nil
+	case channel.StreamEventDelta:
+		if event.Phase == channel.StreamPhaseReasoning || event.Delta == "" {
+			return nil
+		}
+		s.mu.Lock()
+		s.buffer.WriteString(event.Delta)
+		text := s.buffer.String()
+		s.mu.Unlock()
+		return s.upsertText(ctx, channel.Message{Text: text, Format: channel.MessageFormatMarkdown}, false)
+	case channel.StreamEventPhaseEnd:
+		if event.Phase != channel.StreamPhaseText {
+			return nil
+		}
+		s.mu.Lock()
+		text := s.buffer.String()
+		s.mu.Unlock()
+		return s.upsertText(ctx, channel.Message{Text: text, Format: channel.MessageFormatMarkdown}, true)
+	case channel.StreamEventToolCallStart:
+		s.resetMessageState()
+		return nil
+	case channel.StreamEventError:
+		errText := channel.RedactIMErrorText(strings.TrimSpace(event.Error))
+		if errText == "" {
+			return nil
+		}
+		return s.upsertText(ctx, channel.Message{Text: "Error: " + errText}, true)
+	case channel.StreamEventAttachment:
+		return s.pushAttachments(ctx, event.Attachments)
+	case channel.StreamEventFinal:
+		if event.Final == nil {
+			return errors.New("slack stream final payload is required")
+		}
+		msg := event.Final.Message
+		if strings.TrimSpace(msg.PlainText()) == "" {
+			s.mu.Lock()
+			msg.Text = s.buffer.String()
+			s.mu.Unlock()
+			msg.Parts = nil
+			if msg.Format == "" {
+				msg.Format = channel.MessageFormatMarkdown
+			}
+		}
+		if err := s.upsertText(ctx, msg, true); err != nil {
+			return err
+		}
+		if err := s.pushAttachments(ctx, msg.Attachments); err != nil {
+			return err
+		}
+		s.resetMessageState()
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (s *slackOutboundStream) Close(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.closed.Store(true)
+	return nil
+}
+
+// upsertText posts the first chunk of a reply and edits it in place as more
+// text arrives. Intermediate edits are throttled; forced ones always go out.
+func (s *slackOutboundStream) upsertText(ctx context.Context, msg channel.Message, force bool) error {
+	text := strings.TrimSpace(msg.PlainText())
+	if text == "" && len(msg.Actions) == 0 {
+		return nil
+	}
+	msg.Attachments = nil
+	msg.Text = text
+	msg.Parts = nil
+	conversation, threadTS, err := s.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	messageTS := s.messageTS
+	lastText := s.lastText
+	lastEditedAt := s.lastEditedAt
+	s.mu.Unlock()
+
+	if messageTS == "" {
+		ts, err := s.adapter.postMessage(ctx, s.cfg, conversation, threadTS, msg)
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.messageTS = ts
+		s.lastText = text
+		s.lastEditedAt = time.Now()
+		s.mu.Unlock()
+		return nil
+	}
+	if text == lastText && len(msg.Actions) == 0 {
+		return nil
+	}
+	if !force && time.Since(lastEditedAt) < slackEditThrottle {
+		return nil
+	}
+	if err := s.adapter.updateMessage(ctx, s.cfg, conversation, messageTS, msg); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.lastText = text
+	s.lastEditedAt = time.Now()
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *slackOutboundStream) resolve(ctx context.Context) (string, string, error) {
+	s.mu.Lock()
+	conversation, threadTS := s.conversation, s.threadTS
+	s.mu.Unlock()
+	if conversation != "" {
+		return conversation, threadTS, nil
+	}
+	conversation, threadTS, err := s.adapter.resolveConversation(ctx, s.cfg, s.target)
+	if err != nil {
+		return "", "", err
+	}
+	s.mu.Lock()
+	s.conversation, s.threadTS = conversation, threadTS
+	s.mu.Unlock()
+	return conversation, threadTS, nil
+}
+
+func (s *slackOutboundStream) resetMessageState() {
+	s.mu.Lock()
+	s.messageTS = ""
+	s.buffer.Reset()
+	s.lastText = ""
+	s.lastEditedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *slackOutboundStream) pushAttachments(ctx context.Context, attachments []channel.Attachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	conversation, threadTS, err := s.resolve(ctx)
+	if err != nil {
+		return err
+	}
+	for _, att := range attachments {
+		if err := s.adapter.uploadAttachment(ctx, s.cfg, s.botID, conversation, threadTS, att); err != nil {
+			return err
+		}
+	}
+	return nil
+}
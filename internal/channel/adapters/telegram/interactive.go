@@ -0,0 +1,271 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const (
+	telegramCallbackDataMaxBytes = 64
+	// telegramPollTTL bounds how long votes on a sent poll are forwarded.
+	telegramPollTTL = 7 * 24 * time.Hour
+)
+
+// telegramPoll remembers a poll the bot sent. Poll answers only carry the
+// poll ID, so the chat, message and options are looked up here.
+type telegramPoll struct {
+	chatID    int64
+	chatType  string
+	chatTitle string
+	messageID int
+	options   []string
+	sentAt    time.Time
+}
+
+// buildTelegramInlineKeyboard renders actions as one button per row.
+func buildTelegramInlineKeyboard(actions []channel.Action) *tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(actions))
+	for _, action := range actions {
+		label := strings.TrimSpace(action.Label)
+		value := strings.TrimSpace(action.Value)
+		if label == "" {
+			label = value
+		}
+		if label == "" {
+			continue
+		}
+		if link := strings.TrimSpace(action.URL); link != "" {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonURL(label, link)))
+			continue
+		}
+		if value == "" {
+			value = label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(label, truncateUTF8Bytes(value, telegramCallbackDataMaxBytes))))
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return &markup
+}
+
+func truncateUTF8Bytes(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut]
+}
+
+func buttonFallbackText(actions []channel.Action) string {
+	labels := make([]string, 0, len(actions))
+	for _, action := range actions {
+		if label := strings.TrimSpace(action.Label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return strings.Join(labels, " / ")
+}
+
+func sendTelegramButtons(bot *tgbotapi.BotAPI, target, text string, replyTo int, parseMode string, markup *tgbotapi.InlineKeyboardMarkup) error {
+	chatID, channelUsername, err := parseTelegramTarget(target)
+	if err != nil {
+		return err
+	}
+	text = truncateTelegramText(sanitizeTelegramText(text))
+	var message tgbotapi.MessageConfig
+	if channelUsername != "" {
+		message = tgbotapi.NewMessageToChannel(channelUsername, text)
+	} else {
+		message = tgbotapi.NewMessage(chatID, text)
+	}
+	message.ParseMode = parseMode
+	message.ReplyMarkup = markup
+	if replyTo > 0 {
+		message.ReplyToMessageID = replyTo
+	}
+	_, err = bot.Send(message)
+	return err
+}
+
+// sendTelegramPoll sends a non-anonymous poll, so that votes are reported
+// back as poll_answer updates, and remembers it for those updates.
+func (a *TelegramAdapter) sendTelegramPoll(bot *tgbotapi.BotAPI, target string, poll channel.Poll, replyTo int) error {
+	chatID, channelUsername, err := parseTelegramTarget(target)
+	if err != nil {
+		return err
+	}
+	if len(poll.Options) < 2 {
+		return errors.New("telegram poll needs at least 2 options")
+	}
+	config := tgbotapi.NewPoll(chatID, strings.TrimSpace(poll.Question), poll.Options...)
+	config.ChannelUsername = channelUsername
+	config.IsAnonymous = false
+	config.AllowsMultipleAnswers = poll.MultipleAnswers
+	if replyTo > 0 {
+		config.ReplyToMessageID = replyTo
+	}
+	sent, err := bot.Send(config)
+	if err != nil {
+		return err
+	}
+	if sent.Poll == nil || sent.Chat == nil {
+		return nil
+	}
+	a.rememberPoll(sent.Poll.ID, telegramPoll{
+		chatID:    sent.Chat.ID,
+		chatType:  sent.Chat.Type,
+		chatTitle: sent.Chat.Title,
+		messageID: sent.MessageID,
+		options:   append([]string(nil), poll.Options...),
+		sentAt:    time.Now(),
+	})
+	return nil
+}
+
+func (a *TelegramAdapter) rememberPoll(pollID string, poll telegramPoll) {
+	expireBefore := time.Now().Add(-telegramPollTTL)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for id, item := range a.polls {
+		if item.sentAt.Before(expireBefore) {
+			delete(a.polls, id)
+		}
+	}
+	a.polls[pollID] = poll
+}
+
+func (a *TelegramAdapter) lookupPoll(pollID string) (telegramPoll, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	poll, ok := a.polls[pollID]
+	return poll, ok
+}
+
+// handleTelegramCallback acknowledges an inline keyboard click and forwards
+// it as an interaction on the message that carried the keyboard.
+func (a *TelegramAdapter) handleTelegramCallback(ctx context.Context, bot *tgbotapi.BotAPI, cfg channel.ChannelConfig, handler channel.InboundHandler, query *tgbotapi.CallbackQuery) {
+	if _, err := bot.Request(tgbotapi.NewCallback(query.ID, "")); err != nil && a.logger != nil {
+		a.logger.Warn("answer callback query failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+	}
+	msg, ok := buildTelegramCallbackMessage(query)
+	if !ok {
+		return
+	}
+	a.dispatchInbound(ctx, cfg, handler, msg)
+}
+
+func buildTelegramCallbackMessage(query *tgbotapi.CallbackQuery) (channel.InboundMessage, bool) {
+	if query == nil || query.From == nil || query.From.IsBot || query.Message == nil || query.Message.Chat == nil {
+		return channel.InboundMessage{}, false
+	}
+	value := strings.TrimSpace(query.Data)
+	if value == "" {
+		return channel.InboundMessage{}, false
+	}
+	label := ""
+	if markup := query.Message.ReplyMarkup; markup != nil {
+		for _, row := range markup.InlineKeyboard {
+			for _, button := range row {
+				if button.CallbackData != nil && *button.CallbackData == query.Data {
+					label = button.Text
+				}
+			}
+		}
+	}
+	chat := query.Message.Chat
+	originID := strconv.Itoa(query.Message.MessageID)
+	msg := buildTelegramInteractionMessage(query.From, chat.ID, chat.Type, chat.Title, channel.Interaction{
+		Type:      channel.InteractionButton,
+		MessageID: originID,
+		Value:     value,
+		Label:     label,
+	})
+	msg.Message.ID = "callback:" + query.ID
+	msg.Message.Text = value
+	msg.Metadata["raw_text"] = value
+	return msg, true
+}
+
+// handleTelegramPollAnswer forwards a vote on a poll the bot sent.
+func (a *TelegramAdapter) handleTelegramPollAnswer(ctx context.Context, cfg channel.ChannelConfig, handler channel.InboundHandler, answer *tgbotapi.PollAnswer) {
+	poll, ok := a.lookupPoll(answer.PollID)
+	if !ok {
+		return
+	}
+	msg, ok := buildTelegramPollAnswerMessage(poll, answer)
+	if !ok {
+		return
+	}
+	a.dispatchInbound(ctx, cfg, handler, msg)
+}
+
+func buildTelegramPollAnswerMessage(poll telegramPoll, answer *tgbotapi.PollAnswer) (channel.InboundMessage, bool) {
+	if answer == nil || answer.User.ID == 0 || answer.User.IsBot {
+		return channel.InboundMessage{}, false
+	}
+	chosen := make([]string, 0, len(answer.OptionIDs))
+	for _, id := range answer.OptionIDs {
+		if id >= 0 && id < len(poll.options) {
+			chosen = append(chosen, poll.options[id])
+		}
+	}
+	user := answer.User
+	msg := buildTelegramInteractionMessage(&user, poll.chatID, poll.chatType, poll.chatTitle, channel.Interaction{
+		Type:      channel.InteractionPoll,
+		MessageID: strconv.Itoa(poll.messageID),
+		Options:   chosen,
+	})
+	msg.Message.ID = "poll:" + answer.PollID + ":" + strconv.FormatInt(user.ID, 10)
+	msg.Message.Text = strings.Join(chosen, "\n")
+	return msg, true
+}
+
+func buildTelegramInteractionMessage(from *tgbotapi.User, chatID int64, chatType, chatTitle string, interaction channel.Interaction) channel.InboundMessage {
+	chatIDText := strconv.FormatInt(chatID, 10)
+	// Resolve the sender as for a message the user typed in this chat.
+	subjectID, displayName, attrs := resolveTelegramSender(&tgbotapi.Message{
+		From: from,
+		Chat: &tgbotapi.Chat{ID: chatID, Type: chatType},
+	})
+	return channel.InboundMessage{
+		Channel: Type,
+		Message: channel.Message{
+			Format: channel.MessageFormatPlain,
+			Reply:  &channel.ReplyRef{Target: chatIDText, MessageID: interaction.MessageID},
+		},
+		ReplyTarget: chatIDText,
+		Sender: channel.Identity{
+			SubjectID:   subjectID,
+			DisplayName: displayName,
+			Attributes:  attrs,
+		},
+		Conversation: channel.Conversation{
+			ID:   chatIDText,
+			Type: normalizeTelegramConversationType(chatType),
+			Name: strings.TrimSpace(chatTitle),
+		},
+		ReceivedAt:  time.Now().UTC(),
+		Source:      "telegram",
+		Interaction: &interaction,
+		Metadata: map[string]any{
+			// Clicks and votes answer the bot's own message.
+			"is_mentioned":    true,
+			"is_reply_to_bot": true,
+			"raw_chat_type":   strings.TrimSpace(chatType),
+		},
+	}
+}
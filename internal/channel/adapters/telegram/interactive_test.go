@@ -0,0 +1,101 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+func TestBuildTelegramInlineKeyboard(t *testing.T) {
+	t.Parallel()
+
+	markup := buildTelegramInlineKeyboard([]channel.Action{
+		{Label: "Approve", Value: "/approve 1"},
+		{Label: "Docs", URL: "https://example.com"},
+		{Label: "Long", Value: strings.Repeat("é", 40)},
+		{},
+	})
+	if markup == nil || len(markup.InlineKeyboard) != 3 {
+		t.Fatalf("unexpected keyboard: %#v", markup)
+	}
+	first := markup.InlineKeyboard[0][0]
+	if first.Text != "Approve" || first.CallbackData == nil || *first.CallbackData != "/approve 1" {
+		t.Fatalf("unexpected data button: %#v", first)
+	}
+	link := markup.InlineKeyboard[1][0]
+	if link.URL == nil || *link.URL != "https://example.com" || link.CallbackData != nil {
+		t.Fatalf("unexpected url button: %#v", link)
+	}
+	long := *markup.InlineKeyboard[2][0].CallbackData
+	if len(long) > telegramCallbackDataMaxBytes || len(long) != 64 {
+		t.Fatalf("callback data not truncated on rune boundary: %d bytes", len(long))
+	}
+	if buildTelegramInlineKeyboard(nil) != nil {
+		t.Fatalf("expected nil keyboard without actions")
+	}
+}
+
+func TestBuildTelegramCallbackMessage(t *testing.T) {
+	t.Parallel()
+
+	data := "/approve 1"
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.InlineKeyboardButton{Text: "Approve", CallbackData: &data},
+	))
+	query := &tgbotapi.CallbackQuery{
+		ID:   "cb-1",
+		From: &tgbotapi.User{ID: 42, UserName: "alice"},
+		Message: &tgbotapi.Message{
+			MessageID:   7,
+			Chat:        &tgbotapi.Chat{ID: -100, Type: "supergroup", Title: "Team"},
+			ReplyMarkup: &markup,
+		},
+		Data: data,
+	}
+	msg, ok := buildTelegramCallbackMessage(query)
+	if !ok {
+		t.Fatalf("expected callback message")
+	}
+	if msg.Message.Text != data || msg.Metadata["raw_text"] != data || msg.ReplyTarget != "-100" {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if msg.Interaction == nil || msg.Interaction.Type != channel.InteractionButton || msg.Interaction.MessageID != "7" || msg.Interaction.Label != "Approve" {
+		t.Fatalf("unexpected interaction: %#v", msg.Interaction)
+	}
+	if msg.Sender.SubjectID != "42" || msg.Conversation.Type != channel.ConversationTypeGroup {
+		t.Fatalf("unexpected sender or conversation: %#v %#v", msg.Sender, msg.Conversation)
+	}
+
+	query.From.IsBot = true
+	if _, ok := buildTelegramCallbackMessage(query); ok {
+		t.Fatalf("expected bot clicks to be ignored")
+	}
+}
+
+func TestBuildTelegramPollAnswerMessage(t *testing.T) {
+	t.Parallel()
+
+	poll := telegramPoll{chatID: 100, chatType: "private", messageID: 9, options: []string{"Pizza", "Sushi", "Salad"}}
+	msg, ok := buildTelegramPollAnswerMessage(poll, &tgbotapi.PollAnswer{
+		PollID:    "p1",
+		User:      tgbotapi.User{ID: 42, FirstName: "Alice"},
+		OptionIDs: []int{0, 2, 5},
+	})
+	if !ok {
+		t.Fatalf("expected poll answer message")
+	}
+	if msg.Message.Text != "Pizza\nSalad" || msg.Message.ID != "poll:p1:42" {
+		t.Fatalf("unexpected message: %#v", msg.Message)
+	}
+	if msg.Interaction == nil || msg.Interaction.Type != channel.InteractionPoll || msg.Interaction.MessageID != "9" || len(msg.Interaction.Options) != 2 {
+		t.Fatalf("unexpected interaction: %#v", msg.Interaction)
+	}
+
+	retracted, ok := buildTelegramPollAnswerMessage(poll, &tgbotapi.PollAnswer{PollID: "p1", User: tgbotapi.User{ID: 42}})
+	if !ok || retracted.Message.Text != "" || len(retracted.Interaction.Options) != 0 {
+		t.Fatalf("unexpected retraction: %#v", retracted)
+	}
+}
@@ -50,6 +50,7 @@ type TelegramAdapter struct {
 	mu            sync.RWMutex
 	bots          map[string]*tgbotapi.BotAPI // keyed by bot token
 	fileEndpoints map[string]string           // token → file endpoint format string
+	polls         map[string]telegramPoll     // poll ID → poll sent by the bot
 	assets        assetOpener
 }
 
@@ -62,6 +63,7 @@ func NewTelegramAdapter(log *slog.Logger) *TelegramAdapter {
 		logger:        log.With(slog.String("adapter", "telegram")),
 		bots:          make(map[string]*tgbotapi.BotAPI),
 		fileEndpoints: make(map[string]string),
+		polls:         make(map[string]telegramPoll),
 	}
 	initTelegramBotLogger(adapter.logger)
 	return adapter
@@ -157,6 +159,8 @@ func (*TelegramAdapter) Descriptor() channel.Descriptor {
 			Reply:          true,
 			Attachments:    true,
 			Media:          true,
+			Buttons:        true,
+			Polls:          true,
 			Streaming:      true,
 			BlockStreaming: true,
 		},
@@ -341,6 +345,14 @@ func (a *TelegramAdapter) Connect(ctx context.Context, cfg channel.ChannelConfig
 					}
 					return
 				}
+				if update.CallbackQuery != nil {
+					a.handleTelegramCallback(connCtx, bot, cfg, handler, update.CallbackQuery)
+					continue
+				}
+				if update.PollAnswer != nil {
+					a.handleTelegramPollAnswer(connCtx, cfg, handler, update.PollAnswer)
+					continue
+				}
 				if update.Message == nil {
 					continue
 				}
@@ -608,8 +620,22 @@ func (a *TelegramAdapter) Send(ctx context.Context, cfg channel.ChannelConfig, m
 	text := strings.TrimSpace(msg.Message.PlainText())
 	text, parseMode := formatTelegramOutput(text, msg.Message.Format)
 	replyTo := parseReplyToMessageID(msg.Message.Reply)
+	if poll := msg.Message.Poll; poll != nil {
+		if err := a.sendTelegramPoll(bot, to, *poll, replyTo); err != nil {
+			return err
+		}
+		if text == "" && len(msg.Message.Attachments) == 0 && len(msg.Message.Actions) == 0 {
+			return nil
+		}
+	}
+	keyboard := buildTelegramInlineKeyboard(msg.Message.Actions)
+	if keyboard != nil && text == "" {
+		text = buttonFallbackText(msg.Message.Actions)
+		parseMode = ""
+	}
 	if len(msg.Message.Attachments) > 0 {
-		usedCaption := false
+		// Buttons go on a text message after the attachments.
+		usedCaption := keyboard != nil
 		for i, att := range msg.Message.Attachments {
 			caption := ""
 			if !usedCaption && text != "" {
@@ -627,11 +653,17 @@ func (a *TelegramAdapter) Send(ctx context.Context, cfg channel.ChannelConfig, m
 				return err
 			}
 		}
+		if keyboard != nil {
+			return sendTelegramButtons(bot, to, text, 0, parseMode, keyboard)
+		}
 		if text != "" && !usedCaption {
 			return sendTelegramText(bot, to, text, replyTo, parseMode)
 		}
 		return nil
 	}
+	if keyboard != nil {
+		return sendTelegramButtons(bot, to, text, replyTo, parseMode, keyboard)
+	}
 	return sendTelegramText(bot, to, text, replyTo, parseMode)
 }
 
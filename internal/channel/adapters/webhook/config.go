@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const (
+	defaultWaitTimeoutSeconds = 60
+	maxWaitTimeoutSeconds     = 300
+)
+
+// Config holds the webhook credentials for a bot.
+type Config struct {
+	Secret             string
+	CallbackURL        string
+	WaitTimeoutSeconds int
+}
+
+// UserConfig binds a channel identity to a webhook conversation or sender.
+type UserConfig struct {
+	ConversationID string
+	SenderID       string
+}
+
+func normalizeConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{
+		"secret":             cfg.Secret,
+		"waitTimeoutSeconds": cfg.WaitTimeoutSeconds,
+	}
+	if cfg.CallbackURL != "" {
+		out["callbackUrl"] = cfg.CallbackURL
+	}
+	return out, nil
+}
+
+func normalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if cfg.ConversationID != "" {
+		out["conversation_id"] = cfg.ConversationID
+	}
+	if cfg.SenderID != "" {
+		out["sender_id"] = cfg.SenderID
+	}
+	return out, nil
+}
+
+func resolveTarget(raw map[string]any) (string, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return "", err
+	}
+	if cfg.ConversationID != "" {
+		return cfg.ConversationID, nil
+	}
+	return cfg.SenderID, nil
+}
+
+func matchBinding(raw map[string]any, criteria channel.BindingCriteria) bool {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return false
+	}
+	if value := strings.TrimSpace(criteria.Attribute("sender_id")); value != "" && value == cfg.SenderID {
+		return true
+	}
+	if value := strings.TrimSpace(criteria.SubjectID); value != "" && value == cfg.SenderID {
+		return true
+	}
+	if value := strings.TrimSpace(criteria.Attribute("conversation_id")); value != "" && value == cfg.ConversationID {
+		return true
+	}
+	return false
+}
+
+func buildUserConfig(identity channel.Identity) map[string]any {
+	senderID := strings.TrimSpace(identity.Attribute("sender_id"))
+	if senderID == "" {
+		senderID = strings.TrimSpace(identity.SubjectID)
+	}
+	if senderID == "" {
+		return map[string]any{}
+	}
+	return map[string]any{"sender_id": senderID}
+}
+
+func parseConfig(raw map[string]any) (Config, error) {
+	secret := strings.TrimSpace(channel.ReadString(raw, "secret", "signingSecret", "signing_secret"))
+	if secret == "" {
+		return Config{}, errors.New("webhook secret is required")
+	}
+	callbackURL := strings.TrimSpace(channel.ReadString(raw, "callbackUrl", "callback_url"))
+	if callbackURL != "" {
+		parsed, err := url.Parse(callbackURL)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return Config{}, errors.New("webhook callbackUrl must be an http or https URL")
+		}
+	}
+	timeout := readInt(raw, defaultWaitTimeoutSeconds, "waitTimeoutSeconds", "wait_timeout_seconds")
+	if timeout <= 0 {
+		timeout = defaultWaitTimeoutSeconds
+	}
+	if timeout > maxWaitTimeoutSeconds {
+		timeout = maxWaitTimeoutSeconds
+	}
+	return Config{
+		Secret:             secret,
+		CallbackURL:        callbackURL,
+		WaitTimeoutSeconds: timeout,
+	}, nil
+}
+
+func parseUserConfig(raw map[string]any) (UserConfig, error) {
+	conversationID := normalizeTarget(channel.ReadString(raw, "conversationId", "conversation_id"))
+	senderID := strings.TrimSpace(channel.ReadString(raw, "senderId", "sender_id"))
+	if conversationID == "" && senderID == "" {
+		return UserConfig{}, errors.New("webhook user config requires conversation_id or sender_id")
+	}
+	return UserConfig{ConversationID: conversationID, SenderID: senderID}, nil
+}
+
+// normalizeTarget strips an optional webhook: prefix. Any other non-empty
+// string is a valid conversation ID.
+func normalizeTarget(raw string) string {
+	value := strings.TrimSpace(raw)
+	if strings.HasPrefix(strings.ToLower(value), "webhook:") {
+		value = strings.TrimSpace(value[len("webhook:"):])
+	}
+	return value
+}
+
+func readInt(raw map[string]any, fallback int, keys ...string) int {
+	for _, key := range keys {
+		value, ok := raw[key]
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case int:
+			return v
+		case int64:
+			return int(v)
+		case float64:
+			return int(v)
+		case string:
+			parsed, err := strconv.Atoi(strings.TrimSpace(v))
+			if err == nil {
+				return parsed
+			}
+		}
+	}
+	return fallback
+}
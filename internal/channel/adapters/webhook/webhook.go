@@ -0,0 +1,510 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/media"
+)
+
+const Type channel.ChannelType = "webhook"
+
+const (
+	// inboundDedupTTL is how long a message ID is remembered for idempotency.
+	// Seen IDs live in memory only, so a restart forgets them.
+	inboundDedupTTL = 24 * time.Hour
+	// inboundDedupSweepInterval bounds how often expired IDs are swept.
+	inboundDedupSweepInterval = 10 * time.Minute
+	callbackTimeout           = 30 * time.Second
+	// textChunkLimit is large enough that replies are never split into
+	// several streams; callers get each reply as one payload.
+	textChunkLimit = 1 << 16
+)
+
+// assetOpener reads stored asset bytes by content hash.
+type assetOpener interface {
+	Open(ctx context.Context, botID, contentHash string) (io.ReadCloser, media.Asset, error)
+}
+
+// WebhookAdapter exchanges messages with an external system over plain HTTP:
+// inbound POSTs arrive through WebhookHandler, and replies are either handed
+// back to a waiting request or POSTed to the configured callback URL.
+type WebhookAdapter struct {
+	logger     *slog.Logger
+	httpClient *http.Client
+	assets     assetOpener
+	now        func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]time.Time    // keyed by configID:messageID
+	lastSweep time.Time               // last time expired seen IDs were dropped
+	waiters   map[string]*replyWaiter // keyed by configID:messageID
+}
+
+func NewWebhookAdapter(log *slog.Logger) *WebhookAdapter {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &WebhookAdapter{
+		logger:     log.With(slog.String("adapter", "webhook")),
+		httpClient: &http.Client{Timeout: callbackTimeout},
+		now:        time.Now,
+		seen:       make(map[string]time.Time),
+		waiters:    make(map[string]*replyWaiter),
+	}
+}
+
+// SetAssetOpener configures the asset opener for reading stored attachments by content hash.
+func (a *WebhookAdapter) SetAssetOpener(opener assetOpener) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.assets = opener
+}
+
+func (*WebhookAdapter) Type() channel.ChannelType {
+	return Type
+}
+
+func (*WebhookAdapter) Descriptor() channel.Descriptor {
+	return channel.Descriptor{
+		Type:        Type,
+		DisplayName: "Webhook",
+		Capabilities: channel.ChannelCapabilities{
+			Text:           true,
+			Markdown:       true,
+			Attachments:    true,
+			Media:          true,
+			Buttons:        true,
+			Reply:          true,
+			BlockStreaming: true,
+			ChatTypes:      []string{channel.ConversationTypePrivate, channel.ConversationTypeGroup},
+		},
+		OutboundPolicy: channel.OutboundPolicy{
+			TextChunkLimit: textChunkLimit,
+			ChunkerMode:    channel.ChunkerModeMarkdown,
+			MediaOrder:     channel.OutboundOrderTextFirst,
+		},
+		ConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"secret": {
+					Type:        channel.FieldSecret,
+					Required:    true,
+					Title:       "Signing Secret",
+					Description: "Shared secret used to sign inbound requests and outbound callbacks with HMAC-SHA256",
+				},
+				"callbackUrl": {
+					Type:        channel.FieldString,
+					Title:       "Callback URL",
+					Description: "Replies are POSTed here as signed JSON unless the inbound request waits for them",
+					Example:     "https://example.com/memoh/callback",
+				},
+				"waitTimeoutSeconds": {
+					Type:        channel.FieldNumber,
+					Title:       "Wait Timeout (seconds)",
+					Description: "How long a waiting inbound request is held open for replies (max 300)",
+					Example:     defaultWaitTimeoutSeconds,
+				},
+			},
+		},
+		UserConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"conversation_id": {Type: channel.FieldString, Title: "Conversation ID"},
+				"sender_id":       {Type: channel.FieldString, Title: "Sender ID"},
+			},
+		},
+		TargetSpec: channel.TargetSpec{
+			Format: "conversation_id",
+			Hints: []channel.TargetHint{
+				{Label: "Conversation ID", Example: "support-ticket-42"},
+			},
+		},
+	}
+}
+
+func (*WebhookAdapter) NormalizeConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeConfig(raw)
+}
+
+func (*WebhookAdapter) NormalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeUserConfig(raw)
+}
+
+func (*WebhookAdapter) NormalizeTarget(raw string) string {
+	return normalizeTarget(raw)
+}
+
+func (*WebhookAdapter) ResolveTarget(userConfig map[string]any) (string, error) {
+	return resolveTarget(userConfig)
+}
+
+func (*WebhookAdapter) MatchBinding(config map[string]any, criteria channel.BindingCriteria) bool {
+	return matchBinding(config, criteria)
+}
+
+func (*WebhookAdapter) BuildUserConfig(identity channel.Identity) map[string]any {
+	return buildUserConfig(identity)
+}
+
+// Connect only validates the config: inbound messages arrive over HTTP
+// through WebhookHandler, so there is nothing to hold open.
+func (a *WebhookAdapter) Connect(_ context.Context, cfg channel.ChannelConfig, _ channel.InboundHandler) (channel.Connection, error) {
+	if _, err := parseConfig(cfg.Credentials); err != nil {
+		return nil, err
+	}
+	a.logger.Info("webhook channel ready", slog.String("config_id", cfg.ID))
+	return channel.NewConnection(cfg, func(context.Context) error { return nil }), nil
+}
+
+func (a *WebhookAdapter) Send(ctx context.Context, cfg channel.ChannelConfig, msg channel.OutboundMessage) error {
+	if msg.Message.IsEmpty() {
+		return errors.New("message is required")
+	}
+	target := normalizeTarget(msg.Target)
+	if target == "" {
+		return errors.New("webhook target is required")
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+	inReplyTo := ""
+	if msg.Message.Reply != nil {
+		inReplyTo = strings.TrimSpace(msg.Message.Reply.MessageID)
+	}
+	reply, err := a.buildReply(ctx, cfg.BotID, msg.Message)
+	if err != nil {
+		return err
+	}
+	return a.deliver(ctx, cfg, parsed, target, inReplyTo, reply)
+}
+
+// OpenStream buffers a streamed reply and delivers it as a whole once it is
+// final, either to the request waiting on the source message or to the
+// callback URL.
+func (a *WebhookAdapter) OpenStream(_ context.Context, cfg channel.ChannelConfig, target string, opts channel.StreamOptions) (channel.OutboundStream, error) {
+	target = normalizeTarget(target)
+	if target == "" {
+		return nil, errors.New("webhook target is required")
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	return &webhookOutboundStream{
+		adapter:   a,
+		cfg:       cfg,
+		parsed:    parsed,
+		target:    target,
+		inReplyTo: strings.TrimSpace(opts.SourceMessageID),
+	}, nil
+}
+
+// replyPayload is one reply as seen by the external system.
+type replyPayload struct {
+	Type        string              `json:"type"`
+	Text        string              `json:"text,omitempty"`
+	Format      string              `json:"format,omitempty"`
+	Attachments []attachmentPayload `json:"attachments,omitempty"`
+	Actions     []channel.Action    `json:"actions,omitempty"`
+	Error       string              `json:"error,omitempty"`
+}
+
+type attachmentPayload struct {
+	Type   string `json:"type,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Base64 string `json:"base64,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Mime   string `json:"mime,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+// callbackPayload is the signed body POSTed to the callback URL.
+type callbackPayload struct {
+	ConfigID  string       `json:"config_id"`
+	BotID     string       `json:"bot_id"`
+	Target    string       `json:"target"`
+	InReplyTo string       `json:"in_reply_to,omitempty"`
+	Message   replyPayload `json:"message"`
+	SentAt    time.Time    `json:"sent_at"`
+}
+
+func (a *WebhookAdapter) buildReply(ctx context.Context, botID string, msg channel.Message) (replyPayload, error) {
+	reply := replyPayload{
+		Type:    "message",
+		Text:    strings.TrimSpace(msg.PlainText()),
+		Format:  string(msg.Format),
+		Actions: msg.Actions,
+	}
+	for _, att := range msg.Attachments {
+		payload, err := a.buildAttachment(ctx, botID, att)
+		if err != nil {
+			return replyPayload{}, err
+		}
+		reply.Attachments = append(reply.Attachments, payload)
+	}
+	return reply, nil
+}
+
+// buildAttachment exposes an attachment as a public URL when it has one and
+// inlines it as a data URL otherwise.
+func (a *WebhookAdapter) buildAttachment(ctx context.Context, botID string, att channel.Attachment) (attachmentPayload, error) {
+	payload := attachmentPayload{
+		Type: string(att.Type),
+		Name: att.Name,
+		Mime: att.Mime,
+		Size: att.Size,
+	}
+	if ref := strings.TrimSpace(att.URL); strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		payload.URL = ref
+		return payload, nil
+	}
+	if data := strings.TrimSpace(att.Base64); data != "" {
+		payload.Base64 = data
+		return payload, nil
+	}
+	if ref := strings.TrimSpace(att.URL); strings.HasPrefix(ref, "data:") {
+		payload.Base64 = ref
+		return payload, nil
+	}
+	hash := strings.TrimSpace(att.ContentHash)
+	a.mu.Lock()
+	opener := a.assets
+	a.mu.Unlock()
+	if hash == "" || opener == nil {
+		return attachmentPayload{}, errors.New("webhook attachment has no url, data or stored asset")
+	}
+	reader, asset, err := opener.Open(ctx, botID, hash)
+	if err != nil {
+		return attachmentPayload{}, fmt.Errorf("open attachment asset: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+	data, err := media.ReadAllWithLimit(reader, media.MaxAssetBytes)
+	if err != nil {
+		return attachmentPayload{}, fmt.Errorf("read attachment asset: %w", err)
+	}
+	mime := strings.TrimSpace(payload.Mime)
+	if mime == "" {
+		mime = strings.TrimSpace(asset.Mime)
+	}
+	if mime == "" {
+		mime = "application/octet-stream"
+	}
+	payload.Mime = mime
+	payload.Size = int64(len(data))
+	payload.Base64 = "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data)
+	return payload, nil
+}
+
+// deliver hands a reply to the request waiting on inReplyTo when there is
+// one, and POSTs it to the callback URL otherwise.
+func (a *WebhookAdapter) deliver(ctx context.Context, cfg channel.ChannelConfig, parsed Config, target, inReplyTo string, reply replyPayload) error {
+	if inReplyTo != "" {
+		if waiter := a.waiter(cfg.ID, inReplyTo); waiter != nil {
+			waiter.add(reply)
+			return nil
+		}
+	}
+	if parsed.CallbackURL == "" {
+		return errors.New("webhook has no callback url and no request is waiting for the reply")
+	}
+	body, err := json.Marshal(callbackPayload{
+		ConfigID:  cfg.ID,
+		BotID:     cfg.BotID,
+		Target:    target,
+		InReplyTo: inReplyTo,
+		Message:   reply,
+		SentAt:    a.now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, parsed.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(a.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(timestampHeader, timestamp)
+	req.Header.Set(signatureHeader, sign(parsed.Secret, timestamp, body))
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook callback: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook callback: status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
+
+// markSeen records an inbound message ID and reports whether it was new.
+// Expired IDs are swept at most once per inboundDedupSweepInterval so that a
+// busy endpoint does not walk the whole map on every request. The state is
+// in memory only: after a restart a redelivered message is accepted again.
+func (a *WebhookAdapter) markSeen(configID, messageID string) bool {
+	key := configID + ":" + messageID
+	now := a.now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if now.Sub(a.lastSweep) >= inboundDedupSweepInterval {
+		for k, seenAt := range a.seen {
+			if now.Sub(seenAt) > inboundDedupTTL {
+				delete(a.seen, k)
+			}
+		}
+		a.lastSweep = now
+	}
+	if seenAt, ok := a.seen[key]; ok && now.Sub(seenAt) <= inboundDedupTTL {
+		return false
+	}
+	a.seen[key] = now
+	return true
+}
+
+// forget drops a message ID so that a request that failed before being
+// queued can be retried.
+func (a *WebhookAdapter) forget(configID, messageID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.seen, configID+":"+messageID)
+}
+
+// replyWaiter collects the replies to one inbound message for a request that
+// waits for them.
+type replyWaiter struct {
+	mu      sync.Mutex
+	replies []replyPayload
+	done    chan struct{}
+	once    sync.Once
+}
+
+func (w *replyWaiter) add(reply replyPayload) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.replies = append(w.replies, reply)
+}
+
+func (w *replyWaiter) finish() {
+	w.once.Do(func() { close(w.done) })
+}
+
+func (w *replyWaiter) collected() []replyPayload {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]replyPayload(nil), w.replies...)
+}
+
+func (a *WebhookAdapter) addWaiter(configID, messageID string) *replyWaiter {
+	waiter := &replyWaiter{done: make(chan struct{})}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.waiters[configID+":"+messageID] = waiter
+	return waiter
+}
+
+func (a *WebhookAdapter) removeWaiter(configID, messageID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.waiters, configID+":"+messageID)
+}
+
+func (a *WebhookAdapter) waiter(configID, messageID string) *replyWaiter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.waiters[configID+":"+messageID]
+}
+
+type webhookOutboundStream struct {
+	adapter   *WebhookAdapter
+	cfg       channel.ChannelConfig
+	parsed    Config
+	target    string
+	inReplyTo string
+
+	mu     sync.Mutex
+	closed bool
+	buffer strings.Builder
+}
+
+func (s *webhookOutboundStream) Push(ctx context.Context, event channel.StreamEvent) error {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return errors.New("webhook stream is closed")
+	}
+	switch event.Type {
+	case channel.StreamEventDelta:
+		if event.Phase == channel.StreamPhaseReasoning || event.Delta == "" {
+			return nil
+		}
+		s.mu.Lock()
+		s.buffer.WriteString(event.Delta)
+		s.mu.Unlock()
+		return nil
+	case channel.StreamEventToolCallStart:
+		s.mu.Lock()
+		s.buffer.Reset()
+		s.mu.Unlock()
+		return nil
+	case channel.StreamEventError:
+		errText := channel.RedactIMErrorText(strings.TrimSpace(event.Error))
+		if errText == "" {
+			return nil
+		}
+		return s.adapter.deliver(ctx, s.cfg, s.parsed, s.target, s.inReplyTo, replyPayload{Type: "error", Error: errText})
+	case channel.StreamEventFinal:
+		if event.Final == nil {
+			return errors.New("webhook stream final payload is required")
+		}
+		msg := event.Final.Message
+		s.mu.Lock()
+		if strings.TrimSpace(msg.PlainText()) == "" {
+			msg.Text = s.buffer.String()
+			msg.Parts = nil
+			if msg.Format == "" {
+				msg.Format = channel.MessageFormatMarkdown
+			}
+		}
+		s.buffer.Reset()
+		s.mu.Unlock()
+		if msg.IsEmpty() {
+			return nil
+		}
+		reply, err := s.adapter.buildReply(ctx, s.cfg.BotID, msg)
+		if err != nil {
+			return err
+		}
+		return s.adapter.deliver(ctx, s.cfg, s.parsed, s.target, s.inReplyTo, reply)
+	default:
+		return nil
+	}
+}
+
+// Close ends the reply and releases the request waiting on it, if any.
+func (s *webhookOutboundStream) Close(context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	if s.inReplyTo != "" {
+		if waiter := s.adapter.waiter(s.cfg.ID, s.inReplyTo); waiter != nil {
+			waiter.finish()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const (
+	timestampHeader = "X-Memoh-Timestamp"
+	signatureHeader = "X-Memoh-Signature"
+	signaturePrefix = "sha256="
+	// signatureTolerance bounds the clock skew accepted on signed requests,
+	// which also limits how long a captured request can be replayed.
+	signatureTolerance = 5 * time.Minute
+
+	webhookMaxBodyBytes int64 = 8 << 20 // 8 MiB, room for inline attachments
+	defaultConversation       = "default"
+	defaultSenderID           = "webhook"
+)
+
+type webhookConfigStore interface {
+	ListConfigsByType(ctx context.Context, channelType channel.ChannelType) ([]channel.ChannelConfig, error)
+}
+
+type webhookInboundManager interface {
+	HandleInbound(ctx context.Context, cfg channel.ChannelConfig, msg channel.InboundMessage) error
+}
+
+// inboundPayload is the JSON body accepted on the inbound webhook.
+type inboundPayload struct {
+	MessageID        string              `json:"message_id"`
+	Text             string              `json:"text"`
+	ConversationID   string              `json:"conversation_id"`
+	ConversationType string              `json:"conversation_type"`
+	ConversationName string              `json:"conversation_name"`
+	Sender           inboundSender       `json:"sender"`
+	Attachments      []attachmentPayload `json:"attachments"`
+	Metadata         map[string]any      `json:"metadata"`
+	Wait             bool                `json:"wait"`
+}
+
+type inboundSender struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WebhookHandler receives signed inbound messages for webhook channels.
+type WebhookHandler struct {
+	logger  *slog.Logger
+	store   webhookConfigStore
+	manager webhookInboundManager
+	adapter *WebhookAdapter
+}
+
+// NewWebhookHandler creates the public inbound handler. The adapter must be
+// the instance registered with the channel registry so that replies reach
+// waiting requests.
+func NewWebhookHandler(log *slog.Logger, store webhookConfigStore, manager webhookInboundManager, adapter *WebhookAdapter) *WebhookHandler {
+	if log == nil {
+		log = slog.Default()
+	}
+	if adapter == nil {
+		adapter = NewWebhookAdapter(log)
+	}
+	return &WebhookHandler{
+		logger:  log.With(slog.String("handler", "channel_webhook")),
+		store:   store,
+		manager: manager,
+		adapter: adapter,
+	}
+}
+
+// NewWebhookServerHandler is a DI-friendly constructor for fx/dig, using concrete
+// channel types as parameters.
+func NewWebhookServerHandler(log *slog.Logger, store *channel.Store, manager *channel.Manager, registry *channel.Registry) *WebhookHandler {
+	var adapter *WebhookAdapter
+	if registry != nil {
+		if registered, ok := registry.Get(Type); ok {
+			adapter, _ = registered.(*WebhookAdapter)
+		}
+	}
+	return NewWebhookHandler(log, store, manager, adapter)
+}
+
+// Register registers the inbound webhook route.
+func (h *WebhookHandler) Register(e *echo.Echo) {
+	e.POST("/channels/webhook/:config_id", h.Handle)
+}
+
+// Handle verifies and queues one inbound message. With wait=true (in the body
+// or the query string) the response carries the bot's replies; otherwise it
+// returns 202 and replies go to the callback URL.
+func (h *WebhookHandler) Handle(c echo.Context) error {
+	if h.store == nil || h.manager == nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "webhook dependencies not configured")
+	}
+	configID := strings.TrimSpace(c.Param("config_id"))
+	if configID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "config id is required")
+	}
+	ctx := c.Request().Context()
+	cfg, err := h.findConfigByID(ctx, configID)
+	if err != nil {
+		return err
+	}
+	if cfg.Disabled {
+		return echo.NewHTTPError(http.StatusForbidden, "channel config is disabled")
+	}
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, webhookMaxBodyBytes+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("read body: %v", err))
+	}
+	if int64(len(body)) > webhookMaxBodyBytes {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("payload too large: max %d bytes", webhookMaxBodyBytes))
+	}
+	if err := verifySignature(parsed.Secret, c.Request().Header.Get(timestampHeader), c.Request().Header.Get(signatureHeader), body, h.adapter.now()); err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	var payload inboundPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid payload: %v", err))
+	}
+	msg, err := buildInboundMessage(cfg, payload, h.adapter.now())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	messageID := msg.Message.ID
+	if !h.adapter.markSeen(cfg.ID, messageID) {
+		return c.JSON(http.StatusOK, map[string]any{"status": "duplicate", "message_id": messageID})
+	}
+
+	wait := payload.Wait
+	if raw := strings.TrimSpace(c.QueryParam("wait")); raw != "" {
+		wait, _ = strconv.ParseBool(raw)
+	}
+	var waiter *replyWaiter
+	if wait {
+		waiter = h.adapter.addWaiter(cfg.ID, messageID)
+		defer h.adapter.removeWaiter(cfg.ID, messageID)
+	}
+	if err := h.manager.HandleInbound(context.WithoutCancel(ctx), cfg, msg); err != nil {
+		h.adapter.forget(cfg.ID, messageID)
+		h.logger.Warn("queue inbound message failed", slog.String("config_id", cfg.ID), slog.Any("error", err))
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	}
+	if waiter == nil {
+		return c.JSON(http.StatusAccepted, map[string]any{"status": "accepted", "message_id": messageID})
+	}
+
+	timer := time.NewTimer(time.Duration(parsed.WaitTimeoutSeconds) * time.Second)
+	defer timer.Stop()
+	select {
+	case <-waiter.done:
+		return c.JSON(http.StatusOK, map[string]any{
+			"status":     "completed",
+			"message_id": messageID,
+			"replies":    waiter.collected(),
+		})
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return c.JSON(http.StatusAccepted, map[string]any{
+		"status":     "timeout",
+		"message_id": messageID,
+		"replies":    waiter.collected(),
+	})
+}
+
+func buildInboundMessage(cfg channel.ChannelConfig, payload inboundPayload, now time.Time) (channel.InboundMessage, error) {
+	messageID := strings.TrimSpace(payload.MessageID)
+	if messageID == "" {
+		return channel.InboundMessage{}, errors.New("message_id is required")
+	}
+	text := strings.TrimSpace(payload.Text)
+	attachments := make([]channel.Attachment, 0, len(payload.Attachments))
+	for _, item := range payload.Attachments {
+		att := channel.Attachment{
+			URL:    strings.TrimSpace(item.URL),
+			Base64: strings.TrimSpace(item.Base64),
+			Name:   strings.TrimSpace(item.Name),
+			Mime:   strings.TrimSpace(item.Mime),
+			Size:   item.Size,
+		}
+		if att.URL == "" && att.Base64 == "" {
+			return channel.InboundMessage{}, errors.New("attachment url or base64 is required")
+		}
+		att.Type = channel.InferAttachmentType(channel.AttachmentType(strings.TrimSpace(item.Type)), att.Mime, att.Name)
+		attachments = append(attachments, att)
+	}
+	if text == "" && len(attachments) == 0 {
+		return channel.InboundMessage{}, errors.New("text or attachments are required")
+	}
+	conversationID := normalizeTarget(payload.ConversationID)
+	if conversationID == "" {
+		conversationID = defaultConversation
+	}
+	senderID := strings.TrimSpace(payload.Sender.ID)
+	if senderID == "" {
+		senderID = defaultSenderID
+	}
+	senderName := strings.TrimSpace(payload.Sender.Name)
+	if senderName == "" {
+		senderName = senderID
+	}
+	metadata := make(map[string]any, len(payload.Metadata)+1)
+	for key, value := range payload.Metadata {
+		metadata[key] = value
+	}
+	metadata["webhook_message_id"] = messageID
+
+	return channel.InboundMessage{
+		Channel: Type,
+		Message: channel.Message{
+			ID:          messageID,
+			Format:      channel.MessageFormatPlain,
+			Text:        text,
+			Attachments: attachments,
+		},
+		BotID:       cfg.BotID,
+		ReplyTarget: conversationID,
+		Sender: channel.Identity{
+			SubjectID:   senderID,
+			DisplayName: senderName,
+			Attributes: map[string]string{
+				"sender_id":       senderID,
+				"conversation_id": conversationID,
+			},
+		},
+		Conversation: channel.Conversation{
+			ID:   conversationID,
+			Type: channel.NormalizeConversationType(payload.ConversationType),
+			Name: strings.TrimSpace(payload.ConversationName),
+		},
+		ReceivedAt: now,
+		Source:     "webhook",
+		Metadata:   metadata,
+	}, nil
+}
+
+func (h *WebhookHandler) findConfigByID(ctx context.Context, configID string) (channel.ChannelConfig, error) {
+	items, err := h.store.ListConfigsByType(ctx, Type)
+	if err != nil {
+		return channel.ChannelConfig{}, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	for _, item := range items {
+		if strings.TrimSpace(item.ID) == configID {
+			return item, nil
+		}
+	}
+	return channel.ChannelConfig{}, echo.NewHTTPError(http.StatusNotFound, "channel config not found")
+}
+
+// sign returns the signature header value for body sent at timestamp:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifySignature(secret, timestamp, signature string, body []byte, now time.Time) error {
+	timestamp = strings.TrimSpace(timestamp)
+	signature = strings.TrimSpace(signature)
+	if timestamp == "" || signature == "" {
+		return errors.New("missing webhook signature")
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid webhook timestamp")
+	}
+	skew := now.Sub(time.Unix(unix, 0))
+	if skew > signatureTolerance || skew < -signatureTolerance {
+		return errors.New("webhook timestamp outside tolerance")
+	}
+	if !hmac.Equal([]byte(sign(secret, timestamp, body)), []byte(signature)) {
+		return errors.New("webhook signature verification failed")
+	}
+	return nil
+}
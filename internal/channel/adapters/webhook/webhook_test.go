@@ -0,0 +1,266 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const testSecret = "test-secret"
+
+type fakeConfigStore struct {
+	configs []channel.ChannelConfig
+}
+
+func (s *fakeConfigStore) ListConfigsByType(context.Context, channel.ChannelType) ([]channel.ChannelConfig, error) {
+	return s.configs, nil
+}
+
+// fakeManager answers each inbound message by streaming a reply through the
+// adapter, the way the inbound processor does.
+type fakeManager struct {
+	adapter *WebhookAdapter
+	reply   string
+
+	mu       sync.Mutex
+	messages []channel.InboundMessage
+}
+
+func (m *fakeManager) HandleInbound(ctx context.Context, cfg channel.ChannelConfig, msg channel.InboundMessage) error {
+	m.mu.Lock()
+	m.messages = append(m.messages, msg)
+	m.mu.Unlock()
+	go func() {
+		stream, err := m.adapter.OpenStream(ctx, cfg, msg.ReplyTarget, channel.StreamOptions{SourceMessageID: msg.Message.ID})
+		if err != nil {
+			return
+		}
+		defer func() { _ = stream.Close(ctx) }()
+		_ = stream.Push(ctx, channel.StreamEvent{Type: channel.StreamEventDelta, Delta: m.reply})
+		_ = stream.Push(ctx, channel.StreamEvent{Type: channel.StreamEventFinal, Final: &channel.StreamFinalizePayload{}})
+	}()
+	return nil
+}
+
+func (m *fakeManager) received() []channel.InboundMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]channel.InboundMessage(nil), m.messages...)
+}
+
+func newTestHandler(credentials map[string]any) (*WebhookHandler, *fakeManager) {
+	adapter := NewWebhookAdapter(nil)
+	manager := &fakeManager{adapter: adapter, reply: "hello back"}
+	store := &fakeConfigStore{configs: []channel.ChannelConfig{{
+		ID:          "cfg-1",
+		BotID:       "bot-1",
+		ChannelType: Type,
+		Credentials: credentials,
+	}}}
+	return NewWebhookHandler(nil, store, manager, adapter), manager
+}
+
+func signedRequest(t *testing.T, target string, payload any, secret string) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(timestampHeader, timestamp)
+	req.Header.Set(signatureHeader, sign(secret, timestamp, body))
+	return req
+}
+
+func serve(h *WebhookHandler, req *http.Request) *httptest.ResponseRecorder {
+	e := echo.New()
+	h.Register(e)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte(`{"message_id":"1"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := sign(testSecret, timestamp, body)
+	if !strings.HasPrefix(signature, "sha256=") {
+		t.Fatalf("unexpected signature format: %q", signature)
+	}
+	if err := verifySignature(testSecret, timestamp, signature, body, now); err != nil {
+		t.Fatalf("expected valid signature: %v", err)
+	}
+	if err := verifySignature("other", timestamp, signature, body, now); err == nil {
+		t.Fatal("expected wrong secret to fail")
+	}
+	if err := verifySignature(testSecret, timestamp, signature, []byte(`{"message_id":"2"}`), now); err == nil {
+		t.Fatal("expected tampered body to fail")
+	}
+	if err := verifySignature(testSecret, timestamp, signature, body, now.Add(10*time.Minute)); err == nil {
+		t.Fatal("expected stale timestamp to fail")
+	}
+	if err := verifySignature(testSecret, "", "", body, now); err == nil {
+		t.Fatal("expected missing headers to fail")
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := parseConfig(map[string]any{"secret": " s ", "callback_url": "https://example.com/cb", "waitTimeoutSeconds": float64(900)})
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v", err)
+	}
+	if cfg.Secret != "s" || cfg.CallbackURL != "https://example.com/cb" || cfg.WaitTimeoutSeconds != maxWaitTimeoutSeconds {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if _, err := parseConfig(map[string]any{}); err == nil {
+		t.Fatal("expected missing secret to fail")
+	}
+	if _, err := parseConfig(map[string]any{"secret": "s", "callbackUrl": "ftp://example.com"}); err == nil {
+		t.Fatal("expected non-http callback url to fail")
+	}
+}
+
+func TestHandleRejectsBadSignature(t *testing.T) {
+	h, manager := newTestHandler(map[string]any{"secret": testSecret})
+	req := signedRequest(t, "/channels/webhook/cfg-1", map[string]any{"message_id": "m1", "text": "hi"}, "wrong")
+	rec := serve(h, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(manager.received()) != 0 {
+		t.Fatal("expected no inbound message")
+	}
+}
+
+func TestHandleWaitReturnsReplies(t *testing.T) {
+	h, manager := newTestHandler(map[string]any{"secret": testSecret})
+	payload := map[string]any{
+		"message_id":      "m1",
+		"text":            "hi",
+		"conversation_id": "ticket-42",
+		"sender":          map[string]any{"id": "u1", "name": "Alice"},
+	}
+	rec := serve(h, signedRequest(t, "/channels/webhook/cfg-1?wait=true", payload, testSecret))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Status  string         `json:"status"`
+		Replies []replyPayload `json:"replies"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Status != "completed" || len(resp.Replies) != 1 || resp.Replies[0].Text != "hello back" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	msgs := manager.received()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 inbound message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if msg.ReplyTarget != "ticket-42" || msg.Sender.SubjectID != "u1" || msg.Sender.DisplayName != "Alice" || msg.BotID != "bot-1" {
+		t.Fatalf("unexpected inbound message: %+v", msg)
+	}
+	if msg.Conversation.Type != channel.ConversationTypePrivate {
+		t.Fatalf("unexpected conversation type: %q", msg.Conversation.Type)
+	}
+
+	rec = serve(h, signedRequest(t, "/channels/webhook/cfg-1?wait=true", payload, testSecret))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"duplicate"`) {
+		t.Fatalf("expected duplicate response, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(manager.received()) != 1 {
+		t.Fatal("expected duplicate not to be dispatched")
+	}
+}
+
+func TestHandleDeliversSignedCallback(t *testing.T) {
+	received := make(chan callbackPayload, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := verifySignature(testSecret, r.Header.Get(timestampHeader), r.Header.Get(signatureHeader), body, time.Now()); err != nil {
+			t.Errorf("callback signature: %v", err)
+		}
+		var payload callbackPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("decode callback: %v", err)
+		}
+		received <- payload
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer callback.Close()
+
+	h, _ := newTestHandler(map[string]any{"secret": testSecret, "callbackUrl": callback.URL})
+	rec := serve(h, signedRequest(t, "/channels/webhook/cfg-1", map[string]any{"message_id": "m1", "text": "hi"}, testSecret))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	select {
+	case payload := <-received:
+		if payload.ConfigID != "cfg-1" || payload.Target != defaultConversation || payload.InReplyTo != "m1" {
+			t.Fatalf("unexpected callback: %+v", payload)
+		}
+		if payload.Message.Type != "message" || payload.Message.Text != "hello back" {
+			t.Fatalf("unexpected callback message: %+v", payload.Message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("callback not delivered")
+	}
+}
+
+func TestSendWithoutCallbackFails(t *testing.T) {
+	adapter := NewWebhookAdapter(nil)
+	err := adapter.Send(context.Background(), channel.ChannelConfig{ID: "cfg-1", Credentials: map[string]any{"secret": testSecret}}, channel.OutboundMessage{
+		Target:  "ticket-42",
+		Message: channel.Message{Text: "hi"},
+	})
+	if err == nil {
+		t.Fatal("expected error without callback url or waiting request")
+	}
+}
+
+func TestMarkSeenExpiresAndSweepsPeriodically(t *testing.T) {
+	adapter := NewWebhookAdapter(nil)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	adapter.now = func() time.Time { return now }
+
+	if !adapter.markSeen("cfg-1", "m1") {
+		t.Fatal("first delivery should be new")
+	}
+	if adapter.markSeen("cfg-1", "m1") {
+		t.Fatal("redelivery should be a duplicate")
+	}
+
+	// Past the TTL but within the sweep interval: the entry is stale and
+	// no longer counts, even though it has not been swept yet.
+	adapter.lastSweep = now.Add(inboundDedupTTL)
+	now = now.Add(inboundDedupTTL + time.Minute)
+	if !adapter.markSeen("cfg-1", "m2") || len(adapter.seen) != 2 {
+		t.Fatalf("expected no sweep within the interval, have %d entries", len(adapter.seen))
+	}
+	if !adapter.markSeen("cfg-1", "m1") {
+		t.Fatal("expired id should be accepted again")
+	}
+
+	now = now.Add(inboundDedupTTL + inboundDedupSweepInterval)
+	adapter.markSeen("cfg-1", "m3")
+	if len(adapter.seen) != 1 {
+		t.Fatalf("expected expired ids to be swept, have %d entries", len(adapter.seen))
+	}
+}
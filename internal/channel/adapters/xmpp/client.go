@@ -0,0 +1,539 @@
+package xmpp
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/channel/adapters/common"
+)
+
+const (
+	nsStreams = "http://etherx.jabber.org/streams"
+	nsClient  = "jabber:client"
+	nsTLS     = "urn:ietf:params:xml:ns:xmpp-tls"
+	nsSASL    = "urn:ietf:params:xml:ns:xmpp-sasl"
+	nsBind    = "urn:ietf:params:xml:ns:xmpp-bind"
+	nsPing    = "urn:xmpp:ping"
+	nsMUC     = "http://jabber.org/protocol/muc"
+	nsStanzas = "urn:ietf:params:xml:ns:xmpp-stanzas"
+)
+
+const (
+	xmppDialTimeout  = 15 * time.Second
+	xmppWriteTimeout = 10 * time.Second
+	xmppPingInterval = 60 * time.Second
+	xmppReadTimeout  = 3 * time.Minute
+)
+
+var xmppReconnectBackoffs = []time.Duration{time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 30 * time.Second}
+
+type streamFeatures struct {
+	StartTLS   *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-tls starttls"`
+	Mechanisms *struct {
+		Mechanism []string `xml:"mechanism"`
+	} `xml:"urn:ietf:params:xml:ns:xmpp-sasl mechanisms"`
+	Bind *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-bind bind"`
+}
+
+type stanzaError struct {
+	Type  string `xml:"type,attr"`
+	Inner string `xml:",innerxml"`
+}
+
+type xmppMessage struct {
+	ID       string    `xml:"id,attr"`
+	From     string    `xml:"from,attr"`
+	To       string    `xml:"to,attr"`
+	Type     string    `xml:"type,attr"`
+	Body     string    `xml:"body"`
+	Subject  *string   `xml:"subject"`
+	Delay    *struct{} `xml:"urn:xmpp:delay delay"`
+	StanzaID []struct {
+		ID string `xml:"id,attr"`
+		By string `xml:"by,attr"`
+	} `xml:"urn:xmpp:sid:0 stanza-id"`
+	Error *stanzaError `xml:"error"`
+}
+
+type xmppIQ struct {
+	ID   string `xml:"id,attr"`
+	Type string `xml:"type,attr"`
+	From string `xml:"from,attr"`
+	Bind *struct {
+		JID string `xml:"jid"`
+	} `xml:"urn:ietf:params:xml:ns:xmpp-bind bind"`
+	Ping  *struct{}    `xml:"urn:xmpp:ping ping"`
+	Error *stanzaError `xml:"error"`
+}
+
+type xmppPresence struct {
+	From  string       `xml:"from,attr"`
+	Type  string       `xml:"type,attr"`
+	Error *stanzaError `xml:"error"`
+}
+
+// xmppSession owns one config's server connection. The send queue outlives
+// individual connections, so stanzas queued during a reconnect go out once
+// the session is bound again.
+type xmppSession struct {
+	logger  *slog.Logger
+	cfg     channel.ChannelConfig
+	parsed  Config
+	handler channel.InboundHandler
+	queue   *common.SendQueue
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	rooms   map[string]bool // bare room JIDs, configured or seen in groupchat
+	counter uint64
+}
+
+func newXMPPSession(logger *slog.Logger, cfg channel.ChannelConfig, parsed Config, handler channel.InboundHandler) *xmppSession {
+	rooms := make(map[string]bool, len(parsed.Rooms))
+	for _, room := range parsed.Rooms {
+		rooms[room] = true
+	}
+	return &xmppSession{
+		logger:  logger,
+		cfg:     cfg,
+		parsed:  parsed,
+		handler: handler,
+		queue:   common.NewSendQueue(xmppSendBurst, xmppSendInterval, xmppSendQueueLen),
+		done:    make(chan struct{}),
+		rooms:   rooms,
+	}
+}
+
+func (s *xmppSession) stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	<-s.done
+}
+
+func (s *xmppSession) isRoom(jid string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rooms[bareJID(jid)]
+}
+
+func (s *xmppSession) rememberRoom(jid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rooms[bareJID(jid)] = true
+}
+
+func (s *xmppSession) nextID(prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counter++
+	return prefix + "-" + strconv.FormatUint(s.counter, 10)
+}
+
+// sendText queues a message stanza. A bare room JID gets a groupchat
+// message; users and room occupants (room/nick) get a chat message.
+func (s *xmppSession) sendText(target, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	msgType := "chat"
+	if _, _, resource := splitJID(target); resource == "" && s.isRoom(target) {
+		msgType = "groupchat"
+	}
+	stanza := "<message to='" + escape(target) + "' type='" + msgType + "' id='" + s.nextID("msg") + "'><body>" + escape(text) + "</body></message>"
+	if err := s.queue.Enqueue(stanza); err != nil {
+		return fmt.Errorf("xmpp send to %s: %w", target, err)
+	}
+	return nil
+}
+
+// run keeps the connection up until ctx ends, reconnecting with backoff.
+func (s *xmppSession) run(ctx context.Context) {
+	attempt := 0
+	for ctx.Err() == nil {
+		healthy, err := s.connectOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if healthy {
+			attempt = 0
+		}
+		delay := xmppReconnectBackoffs[min(attempt, len(xmppReconnectBackoffs)-1)]
+		attempt++
+		if s.logger != nil {
+			s.logger.Warn("reconnect", slog.String("config_id", s.cfg.ID), slog.Duration("delay", delay), slog.Any("error", err))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+type stanzaWriter struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (w *stanzaWriter) write(data string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.conn.SetWriteDeadline(time.Now().Add(xmppWriteTimeout)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.conn, data)
+	return err
+}
+
+func (s *xmppSession) dial(ctx context.Context) (net.Conn, error) {
+	_, domain, _ := splitJID(s.parsed.JID)
+	address := s.parsed.Server
+	if address == "" {
+		address = lookupServer(ctx, domain, s.parsed.TLSMode)
+	}
+	dialer := &net.Dialer{Timeout: xmppDialTimeout, KeepAlive: 30 * time.Second}
+	if s.parsed.TLSMode != TLSModeDirect {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+	tlsDialer := &tls.Dialer{
+		NetDialer: dialer,
+		Config:    &tls.Config{ServerName: domain, MinVersion: tls.VersionTLS12},
+	}
+	return tlsDialer.DialContext(ctx, "tcp", address)
+}
+
+// lookupServer resolves the client SRV record of domain (RFC 6120 3.2.1,
+// XEP-0368 for direct TLS), falling back to the domain on the default port.
+func lookupServer(ctx context.Context, domain, mode string) string {
+	service := "xmpp-client"
+	if mode == TLSModeDirect {
+		service = "xmpps-client"
+	}
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, service, "tcp", domain)
+	if err == nil && len(records) > 0 && records[0].Target != "." {
+		return net.JoinHostPort(strings.TrimSuffix(records[0].Target, "."), strconv.Itoa(int(records[0].Port)))
+	}
+	return net.JoinHostPort(domain, defaultPort(mode))
+}
+
+// connectOnce runs a single connection. healthy reports whether the session
+// was bound, which resets the reconnect backoff.
+func (s *xmppSession) connectOnce(ctx context.Context) (healthy bool, err error) {
+	rawConn, err := s.dial(ctx)
+	if err != nil {
+		return false, err
+	}
+	connCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		_ = rawConn.Close()
+		wg.Wait()
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-connCtx.Done()
+		_ = rawConn.Close()
+	}()
+
+	conn, dec, fullJID, err := s.negotiate(ctx, rawConn)
+	if err != nil {
+		return false, err
+	}
+	w := &stanzaWriter{conn: conn}
+	if err := w.write("<presence/>"); err != nil {
+		return false, err
+	}
+	for _, room := range s.parsed.Rooms {
+		join := "<presence to='" + escape(room+"/"+s.parsed.RoomNick) + "'><x xmlns='" + nsMUC + "'><history maxstanzas='0'/></x></presence>"
+		if err := w.write(join); err != nil {
+			return false, err
+		}
+	}
+	if s.logger != nil {
+		s.logger.Info("session bound", slog.String("config_id", s.cfg.ID), slog.String("jid", fullJID))
+	}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := s.queue.Run(connCtx, w.write); err != nil {
+			cancel()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		s.keepalive(connCtx, w)
+	}()
+
+	for {
+		start, err := readElement(conn, dec)
+		if err != nil {
+			return true, err
+		}
+		switch start.Name.Local {
+		case "message":
+			var msg xmppMessage
+			if err := dec.DecodeElement(&msg, &start); err != nil {
+				return true, err
+			}
+			s.handleMessage(ctx, msg)
+		case "iq":
+			var iq xmppIQ
+			if err := dec.DecodeElement(&iq, &start); err != nil {
+				return true, err
+			}
+			if err := s.handleIQ(w, iq); err != nil {
+				return true, err
+			}
+		case "presence":
+			var presence xmppPresence
+			if err := dec.DecodeElement(&presence, &start); err != nil {
+				return true, err
+			}
+			if presence.Type == "error" && s.logger != nil {
+				s.logger.Warn("presence error", slog.String("config_id", s.cfg.ID), slog.String("from", presence.From), slog.String("error", errorCondition(presence.Error)))
+			}
+		case "error":
+			if start.Name.Space == nsStreams {
+				var streamErr stanzaError
+				_ = dec.DecodeElement(&streamErr, &start)
+				return true, fmt.Errorf("xmpp stream error: %s", errorCondition(&streamErr))
+			}
+			if err := dec.Skip(); err != nil {
+				return true, err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return true, err
+			}
+		}
+	}
+}
+
+// negotiate runs STARTTLS, SASL PLAIN and resource binding, returning the
+// connection and decoder to use for the bound stream.
+func (s *xmppSession) negotiate(ctx context.Context, conn net.Conn) (net.Conn, *xml.Decoder, string, error) {
+	local, domain, _ := splitJID(s.parsed.JID)
+	dec, features, err := openStream(conn, domain)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if s.parsed.TLSMode == TLSModeStartTLS {
+		if features.StartTLS == nil {
+			return nil, nil, "", errors.New("xmpp server does not offer STARTTLS")
+		}
+		if err := writeRaw(conn, "<starttls xmlns='"+nsTLS+"'/>"); err != nil {
+			return nil, nil, "", err
+		}
+		start, err := readElement(conn, dec)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if start.Name.Local != "proceed" {
+			return nil, nil, "", errors.New("xmpp STARTTLS was refused")
+		}
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: domain, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, nil, "", fmt.Errorf("xmpp tls handshake: %w", err)
+		}
+		conn = tlsConn
+		if dec, features, err = openStream(conn, domain); err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	if features.Mechanisms == nil || !slices.Contains(features.Mechanisms.Mechanism, "PLAIN") {
+		return nil, nil, "", errors.New("xmpp server does not offer SASL PLAIN")
+	}
+	credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + local + "\x00" + s.parsed.Password))
+	if err := writeRaw(conn, "<auth xmlns='"+nsSASL+"' mechanism='PLAIN'>"+credentials+"</auth>"); err != nil {
+		return nil, nil, "", err
+	}
+	start, err := readElement(conn, dec)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if start.Name.Local != "success" {
+		var failure stanzaError
+		_ = dec.DecodeElement(&failure, &start)
+		return nil, nil, "", fmt.Errorf("xmpp authentication failed: %s", errorCondition(&failure))
+	}
+	if err := dec.Skip(); err != nil {
+		return nil, nil, "", err
+	}
+
+	if dec, features, err = openStream(conn, domain); err != nil {
+		return nil, nil, "", err
+	}
+	if features.Bind == nil {
+		return nil, nil, "", errors.New("xmpp server does not offer resource binding")
+	}
+	bindID := s.nextID("bind")
+	bind := "<iq type='set' id='" + bindID + "'><bind xmlns='" + nsBind + "'><resource>" + escape(s.parsed.Resource) + "</resource></bind></iq>"
+	if err := writeRaw(conn, bind); err != nil {
+		return nil, nil, "", err
+	}
+	for {
+		start, err := readElement(conn, dec)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if start.Name.Local != "iq" {
+			if err := dec.Skip(); err != nil {
+				return nil, nil, "", err
+			}
+			continue
+		}
+		var iq xmppIQ
+		if err := dec.DecodeElement(&iq, &start); err != nil {
+			return nil, nil, "", err
+		}
+		if iq.ID != bindID {
+			continue
+		}
+		if iq.Type != "result" || iq.Bind == nil {
+			return nil, nil, "", fmt.Errorf("xmpp resource binding failed: %s", errorCondition(iq.Error))
+		}
+		return conn, dec, iq.Bind.JID, nil
+	}
+}
+
+// openStream sends a stream header and reads the server's header and
+// features. Each restart (after STARTTLS and SASL) needs a fresh decoder.
+func openStream(conn net.Conn, domain string) (*xml.Decoder, streamFeatures, error) {
+	header := "<stream:stream to='" + escape(domain) + "' version='1.0' xmlns='" + nsClient + "' xmlns:stream='" + nsStreams + "'>"
+	if err := writeRaw(conn, header); err != nil {
+		return nil, streamFeatures{}, err
+	}
+	dec := xml.NewDecoder(conn)
+	start, err := readElement(conn, dec)
+	if err != nil {
+		return nil, streamFeatures{}, err
+	}
+	if start.Name.Space != nsStreams || start.Name.Local != "stream" {
+		return nil, streamFeatures{}, fmt.Errorf("xmpp unexpected element <%s>", start.Name.Local)
+	}
+	start, err = readElement(conn, dec)
+	if err != nil {
+		return nil, streamFeatures{}, err
+	}
+	if start.Name.Space != nsStreams || start.Name.Local != "features" {
+		var streamErr stanzaError
+		_ = dec.DecodeElement(&streamErr, &start)
+		return nil, streamFeatures{}, fmt.Errorf("xmpp expected stream features, got <%s>: %s", start.Name.Local, errorCondition(&streamErr))
+	}
+	var features streamFeatures
+	if err := dec.DecodeElement(&features, &start); err != nil {
+		return nil, streamFeatures{}, err
+	}
+	return dec, features, nil
+}
+
+// readElement returns the next start element, skipping whitespace and
+// failing when the server closes the stream.
+func readElement(conn net.Conn, dec *xml.Decoder) (xml.StartElement, error) {
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(xmppReadTimeout)); err != nil {
+			return xml.StartElement{}, err
+		}
+		token, err := dec.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			return t, nil
+		case xml.EndElement:
+			if t.Name.Space == nsStreams && t.Name.Local == "stream" {
+				return xml.StartElement{}, errors.New("xmpp stream closed by server")
+			}
+		}
+	}
+}
+
+func writeRaw(conn net.Conn, data string) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(xmppWriteTimeout)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(conn, data)
+	return err
+}
+
+// handleIQ answers pings (XEP-0199) and rejects other requests, as RFC 6120
+// requires a reply to every get or set.
+func (s *xmppSession) handleIQ(w *stanzaWriter, iq xmppIQ) error {
+	if iq.Type != "get" && iq.Type != "set" {
+		return nil
+	}
+	to := ""
+	if iq.From != "" {
+		to = " to='" + escape(iq.From) + "'"
+	}
+	if iq.Ping != nil {
+		return w.write("<iq type='result' id='" + escape(iq.ID) + "'" + to + "/>")
+	}
+	return w.write("<iq type='error' id='" + escape(iq.ID) + "'" + to + "><error type='cancel'><service-unavailable xmlns='" + nsStanzas + "'/></error></iq>")
+}
+
+// keepalive pings the server so a dead connection is noticed by the read
+// deadline even when nothing else is received.
+func (s *xmppSession) keepalive(ctx context.Context, w *stanzaWriter) {
+	_, domain, _ := splitJID(s.parsed.JID)
+	ticker := time.NewTicker(xmppPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ping := "<iq type='get' id='" + s.nextID("ping") + "' to='" + escape(domain) + "'><ping xmlns='" + nsPing + "'/></iq>"
+			if err := w.write(ping); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// errorCondition extracts the defined condition name from an error element,
+// e.g. "not-authorized".
+func errorCondition(e *stanzaError) string {
+	if e == nil {
+		return "unknown error"
+	}
+	dec := xml.NewDecoder(strings.NewReader(e.Inner))
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return "unknown error"
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local != "text" {
+			return start.Name.Local
+		}
+	}
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,228 @@
+package xmpp
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+// TLS modes: STARTTLS on the client port, TLS from the first byte (XEP-0368),
+// or no TLS at all, which is only meant for local test servers.
+const (
+	TLSModeStartTLS = "starttls"
+	TLSModeDirect   = "direct"
+	TLSModePlain    = "plain"
+)
+
+type Config struct {
+	JID      string // bare JID of the bot account
+	Password string //nolint:gosec // intentional: operator-supplied XMPP account password in channel config
+	Server   string // optional host:port; resolved from DNS SRV or the JID domain when empty
+	TLSMode  string
+	Rooms    []string
+	RoomNick string
+	Resource string
+}
+
+type UserConfig struct {
+	JID  string
+	Room string
+}
+
+func normalizeConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{
+		"jid":      cfg.JID,
+		"password": cfg.Password,
+		"tlsMode":  cfg.TLSMode,
+		"rooms":    strings.Join(cfg.Rooms, ","),
+		"roomNick": cfg.RoomNick,
+		"resource": cfg.Resource,
+	}
+	if cfg.Server != "" {
+		out["server"] = cfg.Server
+	}
+	return out, nil
+}
+
+func normalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if cfg.JID != "" {
+		out["jid"] = cfg.JID
+	}
+	if cfg.Room != "" {
+		out["room"] = cfg.Room
+	}
+	return out, nil
+}
+
+func resolveTarget(raw map[string]any) (string, error) {
+	cfg, err := parseUserConfig(raw)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Room != "" {
+		return cfg.Room, nil
+	}
+	return cfg.JID, nil
+}
+
+func matchBinding(raw map[string]any, criteria channel.BindingCriteria) bool {
+	cfg, err := parseUserConfig(raw)
+	if err != nil || cfg.JID == "" {
+		return false
+	}
+	if value := criteria.Attribute("jid"); value != "" && strings.EqualFold(bareJID(value), cfg.JID) {
+		return true
+	}
+	return criteria.SubjectID != "" && strings.EqualFold(bareJID(criteria.SubjectID), cfg.JID)
+}
+
+func buildUserConfig(identity channel.Identity) map[string]any {
+	jid := identity.Attribute("jid")
+	if jid == "" {
+		jid = identity.SubjectID
+	}
+	jid = bareJID(jid)
+	if jid == "" {
+		return map[string]any{}
+	}
+	return map[string]any{"jid": jid}
+}
+
+func parseConfig(raw map[string]any) (Config, error) {
+	jid := bareJID(channel.ReadString(raw, "jid", "username"))
+	password := channel.ReadString(raw, "password")
+	if jid == "" {
+		return Config{}, errors.New("xmpp jid is required")
+	}
+	local, domain, _ := splitJID(jid)
+	if local == "" || domain == "" {
+		return Config{}, errors.New("xmpp jid must look like user@domain")
+	}
+	if password == "" {
+		return Config{}, errors.New("xmpp password is required")
+	}
+	mode := strings.ToLower(strings.TrimSpace(channel.ReadString(raw, "tlsMode", "tls_mode")))
+	switch mode {
+	case "":
+		mode = TLSModeStartTLS
+	case TLSModeStartTLS, TLSModeDirect, TLSModePlain:
+	default:
+		return Config{}, errors.New("xmpp tlsMode must be starttls, direct or plain")
+	}
+	server := strings.TrimSpace(channel.ReadString(raw, "server", "host"))
+	if server != "" {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, defaultPort(mode))
+		}
+	}
+	roomNick := strings.TrimSpace(channel.ReadString(raw, "roomNick", "room_nick", "nick"))
+	if roomNick == "" {
+		roomNick = local
+	}
+	resource := strings.TrimSpace(channel.ReadString(raw, "resource"))
+	if resource == "" {
+		resource = "memoh"
+	}
+	rooms, err := parseRooms(channel.ReadString(raw, "rooms"))
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{
+		JID:      jid,
+		Password: password,
+		Server:   server,
+		TLSMode:  mode,
+		Rooms:    rooms,
+		RoomNick: roomNick,
+		Resource: resource,
+	}, nil
+}
+
+func parseUserConfig(raw map[string]any) (UserConfig, error) {
+	jid := bareJID(channel.ReadString(raw, "jid"))
+	room := bareJID(channel.ReadString(raw, "room"))
+	if jid == "" && room == "" {
+		return UserConfig{}, errors.New("xmpp user config requires jid or room")
+	}
+	return UserConfig{JID: jid, Room: room}, nil
+}
+
+func parseRooms(raw string) ([]string, error) {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+	rooms := make([]string, 0, len(fields))
+	seen := map[string]bool{}
+	for _, field := range fields {
+		room := bareJID(field)
+		if local, domain, _ := splitJID(room); local == "" || domain == "" {
+			return nil, errors.New("xmpp rooms must look like room@conference.domain")
+		}
+		if !seen[room] {
+			seen[room] = true
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+func defaultPort(mode string) string {
+	if mode == TLSModeDirect {
+		return "5223"
+	}
+	return "5222"
+}
+
+// normalizeTarget strips an optional xmpp: prefix. Targets are bare JIDs of
+// users or rooms, or room/nick for a private message to a room occupant; the
+// adapter knows which JIDs are rooms.
+func normalizeTarget(raw string) string {
+	value := strings.TrimSpace(raw)
+	if len(value) > len("xmpp:") && strings.EqualFold(value[:len("xmpp:")], "xmpp:") {
+		value = strings.TrimSpace(value[len("xmpp:"):])
+	}
+	return value
+}
+
+func validateTarget(target string) error {
+	local, domain, _ := splitJID(normalizeTarget(target))
+	if local == "" || domain == "" {
+		return errors.New("xmpp target must be a jid like user@domain")
+	}
+	return nil
+}
+
+// splitJID splits local@domain/resource. The local part and domain are
+// case-insensitive and returned in lower case; the resource keeps its case.
+func splitJID(jid string) (local, domain, resource string) {
+	jid = strings.TrimSpace(jid)
+	bare, resource, _ := strings.Cut(jid, "/")
+	if at := strings.LastIndex(bare, "@"); at >= 0 {
+		local, domain = bare[:at], bare[at+1:]
+	} else {
+		domain = bare
+	}
+	return strings.ToLower(local), strings.ToLower(domain), resource
+}
+
+func bareJID(jid string) string {
+	local, domain, _ := splitJID(jid)
+	if domain == "" {
+		return ""
+	}
+	if local == "" {
+		return domain
+	}
+	return local + "@" + domain
+}
@@ -0,0 +1,181 @@
+package xmpp
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/channel/adapters/common"
+)
+
+func (s *xmppSession) handleMessage(ctx context.Context, raw xmppMessage) {
+	msg, ok := s.buildInboundMessage(raw)
+	if !ok {
+		return
+	}
+	if s.logger != nil {
+		s.logger.Info("inbound received",
+			slog.String("config_id", s.cfg.ID),
+			slog.String("chat_type", msg.Conversation.Type),
+			slog.String("jid", msg.Sender.SubjectID),
+			slog.String("text", common.SummarizeText(msg.Message.Text)),
+		)
+	}
+	go func() {
+		if err := s.handler(ctx, s.cfg, msg); err != nil && s.logger != nil {
+			s.logger.Error("handle inbound failed", slog.String("config_id", s.cfg.ID), slog.Any("error", err))
+		}
+	}()
+}
+
+// buildInboundMessage maps a chat or groupchat message. Errors, room history
+// (delayed delivery), subject changes and the bot's own room echoes are
+// skipped.
+func (s *xmppSession) buildInboundMessage(raw xmppMessage) (channel.InboundMessage, bool) {
+	if raw.Type == "error" || raw.Error != nil || raw.Delay != nil || raw.From == "" {
+		return channel.InboundMessage{}, false
+	}
+	text := strings.TrimSpace(raw.Body)
+	if text == "" {
+		return channel.InboundMessage{}, false
+	}
+	from := bareJID(raw.From)
+	_, _, resource := splitJID(raw.From)
+
+	var (
+		conversation channel.Conversation
+		sender       channel.Identity
+		replyTarget  string
+		isMentioned  bool
+	)
+	switch raw.Type {
+	case "groupchat":
+		if resource == "" || resource == s.parsed.RoomNick || raw.Subject != nil {
+			return channel.InboundMessage{}, false
+		}
+		s.rememberRoom(from)
+		conversation = channel.Conversation{ID: from, Type: channel.ConversationTypeGroup, Name: from}
+		// Room occupants are only known by nick unless the room is
+		// non-anonymous, so the occupant JID identifies the sender.
+		sender = channel.Identity{
+			SubjectID:   from + "/" + resource,
+			DisplayName: resource,
+			Attributes:  map[string]string{"nick": resource, "room": from},
+		}
+		replyTarget = from
+		if rest, ok := stripAddress(text, s.parsed.RoomNick); ok {
+			text, isMentioned = rest, true
+		} else {
+			isMentioned = containsWord(text, s.parsed.RoomNick)
+		}
+		if text == "" {
+			return channel.InboundMessage{}, false
+		}
+	default:
+		if s.isRoom(from) {
+			// A private message from a room occupant must be answered
+			// through the room, addressed to the occupant JID.
+			if resource == "" {
+				return channel.InboundMessage{}, false
+			}
+			conversation = channel.Conversation{ID: raw.From, Type: channel.ConversationTypePrivate, Name: resource}
+			sender = channel.Identity{
+				SubjectID:   raw.From,
+				DisplayName: resource,
+				Attributes:  map[string]string{"nick": resource, "room": from},
+			}
+			replyTarget = raw.From
+			break
+		}
+		local, _, _ := splitJID(from)
+		conversation = channel.Conversation{ID: from, Type: channel.ConversationTypePrivate, Name: from}
+		sender = channel.Identity{
+			SubjectID:   from,
+			DisplayName: local,
+			Attributes:  map[string]string{"jid": from},
+		}
+		replyTarget = from
+	}
+
+	return channel.InboundMessage{
+		Channel: Type,
+		Message: channel.Message{
+			ID:     s.messageID(raw),
+			Format: channel.MessageFormatPlain,
+			Text:   text,
+		},
+		BotID:        s.cfg.BotID,
+		ReplyTarget:  replyTarget,
+		Sender:       sender,
+		Conversation: conversation,
+		ReceivedAt:   time.Now().UTC(),
+		Source:       "xmpp",
+		Metadata: map[string]any{
+			"is_mentioned": isMentioned,
+			"raw_text":     raw.Body,
+		},
+	}, true
+}
+
+// messageID prefers the server-assigned stanza ID (XEP-0359), then the
+// sender's id attribute.
+func (s *xmppSession) messageID(raw xmppMessage) string {
+	for _, sid := range raw.StanzaID {
+		if sid.ID != "" {
+			return sid.ID
+		}
+	}
+	if id := strings.TrimSpace(raw.ID); id != "" {
+		return id
+	}
+	return s.nextID("in")
+}
+
+// stripAddress removes a leading "nick:" or "nick," used to address the bot.
+func stripAddress(text, nick string) (string, bool) {
+	if nick == "" || len(text) <= len(nick) || !strings.EqualFold(text[:len(nick)], nick) {
+		return "", false
+	}
+	switch text[len(nick)] {
+	case ':', ',':
+		return strings.TrimSpace(text[len(nick)+1:]), true
+	}
+	return "", false
+}
+
+// containsWord reports whether word appears in text, case-insensitively and
+// not as part of a longer word.
+func containsWord(text, word string) bool {
+	if word == "" {
+		return false
+	}
+	lowerText, lowerWord := strings.ToLower(text), strings.ToLower(word)
+	for offset := 0; offset < len(lowerText); {
+		idx := strings.Index(lowerText[offset:], lowerWord)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(lowerWord)
+		before, after := ' ', ' '
+		if start > 0 {
+			before, _ = utf8.DecodeLastRuneInString(lowerText[:start])
+		}
+		if end < len(lowerText) {
+			after, _ = utf8.DecodeRuneInString(lowerText[end:])
+		}
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
@@ -0,0 +1,307 @@
+package xmpp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+const Type channel.ChannelType = "xmpp"
+
+// xmppTextChunkLimit keeps message bodies well under the stanza size limits
+// servers commonly enforce (e.g. 256 KiB in Prosody) and readable in clients.
+const xmppTextChunkLimit = 3000
+
+// Flood control: servers throttle clients that send faster than their
+// per-connection rate limit, which delays or drops stanzas.
+var (
+	xmppSendBurst    = 5
+	xmppSendInterval = 500 * time.Millisecond
+	xmppSendQueueLen = 256
+)
+
+type XMPPAdapter struct {
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*xmppSession // keyed by config ID
+}
+
+func NewXMPPAdapter(log *slog.Logger) *XMPPAdapter {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &XMPPAdapter{
+		logger:   log.With(slog.String("adapter", "xmpp")),
+		sessions: make(map[string]*xmppSession),
+	}
+}
+
+func (*XMPPAdapter) Type() channel.ChannelType {
+	return Type
+}
+
+func (*XMPPAdapter) Descriptor() channel.Descriptor {
+	return channel.Descriptor{
+		Type:        Type,
+		DisplayName: "XMPP",
+		Capabilities: channel.ChannelCapabilities{
+			Text:           true,
+			BlockStreaming: true,
+			ChatTypes:      []string{"direct", "group"},
+		},
+		OutboundPolicy: channel.OutboundPolicy{
+			TextChunkLimit: xmppTextChunkLimit,
+			ChunkerMode:    channel.ChunkerModeText,
+		},
+		ConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"jid": {
+					Type:     channel.FieldString,
+					Required: true,
+					Title:    "JID",
+					Example:  "memoh@example.org",
+				},
+				"password": {
+					Type:     channel.FieldSecret,
+					Required: true,
+					Title:    "Password",
+				},
+				"server": {
+					Type:        channel.FieldString,
+					Title:       "Server",
+					Description: "host:port to connect to; looked up from DNS SRV records of the JID domain when empty",
+					Example:     "xmpp.example.org:5222",
+				},
+				"tlsMode": {
+					Type:        channel.FieldEnum,
+					Title:       "TLS Mode",
+					Description: "starttls (default), direct TLS, or plain for local test servers only",
+					Enum:        []string{TLSModeStartTLS, TLSModeDirect, TLSModePlain},
+				},
+				"rooms": {
+					Type:        channel.FieldString,
+					Title:       "Rooms",
+					Description: "Comma separated multi-user chat rooms to join",
+					Example:     "team@conference.example.org",
+				},
+				"roomNick": {
+					Type:        channel.FieldString,
+					Title:       "Room Nick",
+					Description: "Nickname used in rooms; defaults to the JID local part",
+				},
+			},
+		},
+		UserConfigSchema: channel.ConfigSchema{
+			Version: 1,
+			Fields: map[string]channel.FieldSchema{
+				"jid":  {Type: channel.FieldString, Title: "JID"},
+				"room": {Type: channel.FieldString, Title: "Room"},
+			},
+		},
+		TargetSpec: channel.TargetSpec{
+			Format: "user@domain | room@conference.domain",
+			Hints: []channel.TargetHint{
+				{Label: "User", Example: "alice@example.org"},
+				{Label: "Room", Example: "team@conference.example.org"},
+			},
+		},
+	}
+}
+
+func (*XMPPAdapter) NormalizeConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeConfig(raw)
+}
+
+func (*XMPPAdapter) NormalizeUserConfig(raw map[string]any) (map[string]any, error) {
+	return normalizeUserConfig(raw)
+}
+
+func (*XMPPAdapter) NormalizeTarget(raw string) string {
+	return normalizeTarget(raw)
+}
+
+func (*XMPPAdapter) ResolveTarget(userConfig map[string]any) (string, error) {
+	return resolveTarget(userConfig)
+}
+
+func (*XMPPAdapter) MatchBinding(config map[string]any, criteria channel.BindingCriteria) bool {
+	return matchBinding(config, criteria)
+}
+
+func (*XMPPAdapter) BuildUserConfig(identity channel.Identity) map[string]any {
+	return buildUserConfig(identity)
+}
+
+func (a *XMPPAdapter) Connect(ctx context.Context, cfg channel.ChannelConfig, handler channel.InboundHandler) (channel.Connection, error) {
+	parsed, err := parseConfig(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	channel.SetIMErrorSecrets("xmpp:"+cfg.ID, parsed.Password)
+	if a.logger != nil {
+		a.logger.Info("start", slog.String("config_id", cfg.ID), slog.String("jid", parsed.JID), slog.Int("rooms", len(parsed.Rooms)))
+	}
+	session := newXMPPSession(a.logger, cfg, parsed, handler)
+	a.mu.Lock()
+	if previous := a.sessions[cfg.ID]; previous != nil {
+		previous.stop()
+	}
+	a.sessions[cfg.ID] = session
+	a.mu.Unlock()
+
+	connCtx, cancel := context.WithCancel(ctx)
+	session.cancel = cancel
+	go func() {
+		defer close(session.done)
+		session.run(connCtx)
+	}()
+	return channel.NewConnection(cfg, func(context.Context) error {
+		if a.logger != nil {
+			a.logger.Info("stop", slog.String("config_id", cfg.ID))
+		}
+		a.mu.Lock()
+		if a.sessions[cfg.ID] == session {
+			delete(a.sessions, cfg.ID)
+		}
+		a.mu.Unlock()
+		session.stop()
+		return nil
+	}), nil
+}
+
+func (a *XMPPAdapter) session(configID string) (*xmppSession, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	session := a.sessions[configID]
+	if session == nil {
+		return nil, errors.New("xmpp connection is not running")
+	}
+	return session, nil
+}
+
+// Send queues the message as a stanza. Stanzas go out in the background at
+// the flood-control pace, so Send returns before they reach the server.
+func (a *XMPPAdapter) Send(_ context.Context, cfg channel.ChannelConfig, msg channel.OutboundMessage) error {
+	if msg.Message.IsEmpty() {
+		return errors.New("message is required")
+	}
+	if err := validateTarget(msg.Target); err != nil {
+		return err
+	}
+	session, err := a.session(cfg.ID)
+	if err != nil {
+		return err
+	}
+	return session.sendText(normalizeTarget(msg.Target), messageText(msg.Message))
+}
+
+// OpenStream buffers a reply and sends it once it is final. Message
+// correction (XEP-0308) is not widely supported in rooms, so replies are not
+// edited in place.
+func (a *XMPPAdapter) OpenStream(_ context.Context, cfg channel.ChannelConfig, target string, _ channel.StreamOptions) (channel.OutboundStream, error) {
+	if err := validateTarget(target); err != nil {
+		return nil, err
+	}
+	session, err := a.session(cfg.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &xmppOutboundStream{session: session, target: normalizeTarget(target)}, nil
+}
+
+// messageText flattens a message for a text-only channel, listing attachment
+// URLs after the text.
+func messageText(msg channel.Message) string {
+	lines := []string{strings.TrimSpace(msg.PlainText())}
+	for _, att := range msg.Attachments {
+		if url := strings.TrimSpace(att.URL); url != "" {
+			lines = append(lines, url)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+type xmppOutboundStream struct {
+	session *xmppSession
+	target  string
+
+	closed atomic.Bool
+	mu     sync.Mutex
+	buffer strings.Builder
+}
+
+func (s *xmppOutboundStream) Push(ctx context.Context, event channel.StreamEvent) error {
+	if s.closed.Load() {
+		return errors.New("xmpp stream is closed")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	switch event.Type {
+	case channel.StreamEventDelta:
+		if event.Phase == channel.StreamPhaseReasoning || event.Delta == "" {
+			return nil
+		}
+		s.mu.Lock()
+		s.buffer.WriteString(event.Delta)
+		s.mu.Unlock()
+		return nil
+	case channel.StreamEventToolCallStart:
+		// Text before a tool call is its own message.
+		return s.flush()
+	case channel.StreamEventError:
+		errText := channel.RedactIMErrorText(strings.TrimSpace(event.Error))
+		if errText == "" {
+			return nil
+		}
+		return s.session.sendText(s.target, "Error: "+errText)
+	case channel.StreamEventFinal:
+		if event.Final == nil {
+			return errors.New("xmpp stream final payload is required")
+		}
+		text := messageText(event.Final.Message)
+		s.mu.Lock()
+		if text == "" {
+			text = strings.TrimSpace(s.buffer.String())
+		}
+		s.buffer.Reset()
+		s.mu.Unlock()
+		if text == "" {
+			return nil
+		}
+		return s.session.sendText(s.target, text)
+	default:
+		return nil
+	}
+}
+
+func (s *xmppOutboundStream) flush() error {
+	s.mu.Lock()
+	text := strings.TrimSpace(s.buffer.String())
+	s.buffer.Reset()
+	s.mu.Unlock()
+	if text == "" {
+		return nil
+	}
+	return s.session.sendText(s.target, text)
+}
+
+func (s *xmppOutboundStream) Close(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.closed.Store(true)
+	return nil
+}
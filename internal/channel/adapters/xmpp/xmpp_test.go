@@ -0,0 +1,279 @@
+package xmpp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := parseConfig(map[string]any{
+		"jid":      "Memoh@Example.org/desktop",
+		"password": "secret",
+		"server":   "localhost",
+		"rooms":    "team@conference.example.org, Team@conference.example.org",
+	})
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v", err)
+	}
+	if cfg.JID != "memoh@example.org" || cfg.Server != "localhost:5222" || cfg.TLSMode != TLSModeStartTLS {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg.RoomNick != "memoh" || cfg.Resource != "memoh" || len(cfg.Rooms) != 1 {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+	for _, raw := range []map[string]any{
+		{"password": "secret"},
+		{"jid": "example.org", "password": "secret"},
+		{"jid": "memoh@example.org"},
+		{"jid": "memoh@example.org", "password": "secret", "tlsMode": "ssl"},
+		{"jid": "memoh@example.org", "password": "secret", "rooms": "team"},
+	} {
+		if _, err := parseConfig(raw); err == nil {
+			t.Errorf("expected error for %v", raw)
+		}
+	}
+}
+
+func TestTargetsAndBinding(t *testing.T) {
+	if got := normalizeTarget(" xmpp:alice@example.org "); got != "alice@example.org" {
+		t.Fatalf("normalizeTarget = %q", got)
+	}
+	if err := validateTarget("example.org"); err == nil {
+		t.Fatal("expected domain-only target to be rejected")
+	}
+	target, err := resolveTarget(map[string]any{"jid": "Alice@Example.org/phone"})
+	if err != nil || target != "alice@example.org" {
+		t.Fatalf("resolveTarget = %q (%v)", target, err)
+	}
+	raw := map[string]any{"jid": "alice@example.org"}
+	if !matchBinding(raw, channel.BindingCriteria{SubjectID: "alice@example.org/phone"}) {
+		t.Fatal("expected bare JID match")
+	}
+	if matchBinding(raw, channel.BindingCriteria{SubjectID: "bob@example.org"}) {
+		t.Fatal("expected other JIDs not to match")
+	}
+	if !containsWord("hi Memoh!", "memoh") || containsWord("memohbot", "memoh") {
+		t.Fatal("unexpected containsWord result")
+	}
+}
+
+// fakeXMPPServer is a local stand-in for a plain-mode XMPP server.
+type fakeXMPPServer struct {
+	t        *testing.T
+	listener net.Listener
+	conn     net.Conn
+	dec      *xml.Decoder
+}
+
+type rawElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+func (e rawElement) attr(name string) string {
+	for _, attr := range e.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func newFakeXMPPServer(t *testing.T) *fakeXMPPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	return &fakeXMPPServer{t: t, listener: listener}
+}
+
+func (f *fakeXMPPServer) accept() {
+	f.t.Helper()
+	conn, err := f.listener.Accept()
+	if err != nil {
+		f.t.Fatalf("accept: %v", err)
+	}
+	f.conn = conn
+	f.t.Cleanup(func() { _ = conn.Close() })
+}
+
+func (f *fakeXMPPServer) send(data string) {
+	f.t.Helper()
+	if _, err := io.WriteString(f.conn, data); err != nil {
+		f.t.Fatalf("write: %v", err)
+	}
+}
+
+// openStream reads the client's stream header and answers with features.
+func (f *fakeXMPPServer) openStream(features string) {
+	f.t.Helper()
+	f.dec = xml.NewDecoder(f.conn)
+	start := f.next()
+	if start.Name.Local != "stream" {
+		f.t.Fatalf("expected stream header, got <%s>", start.Name.Local)
+	}
+	f.send("<stream:stream xmlns='jabber:client' xmlns:stream='http://etherx.jabber.org/streams' id='s1' from='example.org' version='1.0'>")
+	f.send("<stream:features>" + features + "</stream:features>")
+}
+
+func (f *fakeXMPPServer) next() xml.StartElement {
+	f.t.Helper()
+	_ = f.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		token, err := f.dec.Token()
+		if err != nil {
+			f.t.Fatalf("read: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start
+		}
+	}
+}
+
+func (f *fakeXMPPServer) expect(local string) rawElement {
+	f.t.Helper()
+	start := f.next()
+	var el rawElement
+	if err := f.dec.DecodeElement(&el, &start); err != nil {
+		f.t.Fatalf("decode <%s>: %v", start.Name.Local, err)
+	}
+	if el.XMLName.Local != local {
+		f.t.Fatalf("client sent <%s>, want <%s>", el.XMLName.Local, local)
+	}
+	return el
+}
+
+func TestAdapterAgainstFakeServer(t *testing.T) {
+	server := newFakeXMPPServer(t)
+	adapter := NewXMPPAdapter(slog.New(slog.DiscardHandler))
+	cfg := channel.ChannelConfig{
+		ID:          "cfg-1",
+		BotID:       "bot-1",
+		ChannelType: Type,
+		Credentials: map[string]any{
+			"jid":      "memoh@example.org",
+			"password": "secret",
+			"server":   server.listener.Addr().String(),
+			"tlsMode":  TLSModePlain,
+			"rooms":    "team@conference.example.org",
+		},
+	}
+	inbound := make(chan channel.InboundMessage, 4)
+	conn, err := adapter.Connect(context.Background(), cfg, func(_ context.Context, _ channel.ChannelConfig, msg channel.InboundMessage) error {
+		inbound <- msg
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Connect returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Stop(context.Background()) })
+
+	server.accept()
+	server.openStream("<mechanisms xmlns='urn:ietf:params:xml:ns:xmpp-sasl'><mechanism>SCRAM-SHA-1</mechanism><mechanism>PLAIN</mechanism></mechanisms>")
+	auth := server.expect("auth")
+	if auth.attr("mechanism") != "PLAIN" || auth.Inner != base64.StdEncoding.EncodeToString([]byte("\x00memoh\x00secret")) {
+		t.Fatalf("unexpected auth: %+v", auth)
+	}
+	server.send("<success xmlns='urn:ietf:params:xml:ns:xmpp-sasl'/>")
+	server.openStream("<bind xmlns='urn:ietf:params:xml:ns:xmpp-bind'/>")
+	bind := server.expect("iq")
+	if !strings.Contains(bind.Inner, "<resource>memoh</resource>") {
+		t.Fatalf("unexpected bind request: %s", bind.Inner)
+	}
+	server.send("<iq type='result' id='" + bind.attr("id") + "'><bind xmlns='urn:ietf:params:xml:ns:xmpp-bind'><jid>memoh@example.org/memoh</jid></bind></iq>")
+	if presence := server.expect("presence"); presence.attr("to") != "" {
+		t.Fatalf("expected initial presence, got %+v", presence)
+	}
+	join := server.expect("presence")
+	if join.attr("to") != "team@conference.example.org/memoh" || !strings.Contains(join.Inner, "maxstanzas='0'") && !strings.Contains(join.Inner, `maxstanzas="0"`) {
+		t.Fatalf("unexpected room join: %+v", join)
+	}
+
+	server.send("<iq type='get' id='p1' from='example.org'><ping xmlns='urn:xmpp:ping'/></iq>")
+	if pong := server.expect("iq"); pong.attr("type") != "result" || pong.attr("id") != "p1" {
+		t.Fatalf("unexpected ping reply: %+v", pong)
+	}
+
+	server.send("<message type='groupchat' from='team@conference.example.org/alice' id='h1'><body>old history</body><delay xmlns='urn:xmpp:delay' stamp='2024-01-01T00:00:00Z'/></message>")
+	server.send("<message type='groupchat' from='team@conference.example.org/memoh' id='e1'><body>own echo</body></message>")
+	server.send("<message type='groupchat' from='team@conference.example.org/alice' id='m1'><body>memoh: hello &amp; welcome</body><stanza-id xmlns='urn:xmpp:sid:0' id='sid-1' by='team@conference.example.org'/></message>")
+	server.send("<message type='chat' from='bob@example.org/phone' id='m2'><body>private question</body></message>")
+	got := map[string]channel.InboundMessage{}
+	for range 2 {
+		select {
+		case msg := <-inbound:
+			got[msg.Message.Text] = msg
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for inbound messages, got %d", len(got))
+		}
+	}
+	room, ok := got["hello & welcome"]
+	if !ok {
+		t.Fatalf("room message missing: %+v", got)
+	}
+	if room.Message.ID != "sid-1" || room.Conversation.Type != channel.ConversationTypeGroup || room.ReplyTarget != "team@conference.example.org" {
+		t.Fatalf("unexpected room message: %+v", room)
+	}
+	if room.Metadata["is_mentioned"] != true || room.Sender.DisplayName != "alice" {
+		t.Fatalf("unexpected room sender or mention: %+v", room)
+	}
+	direct, ok := got["private question"]
+	if !ok || direct.Conversation.Type != channel.ConversationTypePrivate || direct.ReplyTarget != "bob@example.org" || direct.Sender.SubjectID != "bob@example.org" {
+		t.Fatalf("unexpected direct message: %+v", direct)
+	}
+
+	if err := adapter.Send(context.Background(), cfg, channel.OutboundMessage{
+		Target:  "team@conference.example.org",
+		Message: channel.Message{Text: "hi <all>"},
+	}); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	msg := server.expect("message")
+	if msg.attr("type") != "groupchat" || msg.attr("to") != "team@conference.example.org" || !strings.Contains(msg.Inner, "hi &lt;all&gt;") {
+		t.Fatalf("unexpected room send: %+v", msg)
+	}
+
+	stream, err := adapter.OpenStream(context.Background(), cfg, "bob@example.org", channel.StreamOptions{})
+	if err != nil {
+		t.Fatalf("OpenStream returned error: %v", err)
+	}
+	if err := stream.Push(context.Background(), channel.StreamEvent{Type: channel.StreamEventDelta, Delta: "line one\nline two"}); err != nil {
+		t.Fatalf("Push delta: %v", err)
+	}
+	if err := stream.Push(context.Background(), channel.StreamEvent{Type: channel.StreamEventFinal, Final: &channel.StreamFinalizePayload{}}); err != nil {
+		t.Fatalf("Push final: %v", err)
+	}
+	msg = server.expect("message")
+	var body struct {
+		Body string `xml:"body"`
+	}
+	if err := xml.Unmarshal([]byte("<m>"+msg.Inner+"</m>"), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if msg.attr("type") != "chat" || body.Body != "line one\nline two" {
+		t.Fatalf("unexpected direct send: %+v (%q)", msg, body.Body)
+	}
+}
+
+func TestSendWithoutConnection(t *testing.T) {
+	adapter := NewXMPPAdapter(slog.New(slog.DiscardHandler))
+	err := adapter.Send(context.Background(), channel.ChannelConfig{ID: "missing"}, channel.OutboundMessage{
+		Target:  "alice@example.org",
+		Message: channel.Message{Text: "hi"},
+	})
+	if err == nil {
+		t.Fatal("expected Send to fail without a running connection")
+	}
+}
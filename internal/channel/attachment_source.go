@@ -0,0 +1,110 @@
+package channel
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/media"
+	"github.com/memohai/memoh/internal/netguard"
+)
+
+const attachmentFetchTimeout = 30 * time.Second
+
+// ErrAttachmentNoContent is returned when an outbound attachment has no
+// stored asset, data URL or remote URL to read from.
+var ErrAttachmentNoContent = errors.New("attachment has no readable content")
+
+// AttachmentAssetOpener reads stored media assets by content hash.
+type AttachmentAssetOpener interface {
+	Open(ctx context.Context, botID, contentHash string) (io.ReadCloser, media.Asset, error)
+}
+
+// FetchPolicyResolver returns the outbound fetch policy that applies to a
+// bot's remote attachment downloads.
+type FetchPolicyResolver interface {
+	ResolveFetchPolicy(ctx context.Context, botID string) (netguard.Policy, error)
+}
+
+// AttachmentSource loads the bytes of outbound attachments for adapters that
+// upload files themselves rather than passing URLs to the platform.
+type AttachmentSource struct {
+	// Assets reads attachments stored in the media store. Optional.
+	Assets AttachmentAssetOpener
+	// Policies resolves the bot's fetch policy for remote URLs. Without it
+	// the default policy applies, which refuses private destinations.
+	Policies FetchPolicyResolver
+	// MaxBytes caps the size of a loaded attachment; 0 means unlimited.
+	MaxBytes int64
+}
+
+// Load reads an outbound attachment from the media store, an inline data URL
+// or a remote URL, in that order. Remote URLs are agent-supplied, so they are
+// only fetched under the bot's fetch policy.
+func (s AttachmentSource) Load(ctx context.Context, botID string, att Attachment) ([]byte, error) {
+	if bid, ok := att.Metadata["bot_id"].(string); ok && strings.TrimSpace(bid) != "" {
+		botID = strings.TrimSpace(bid)
+	}
+	if att.ContentHash != "" && botID != "" && s.Assets != nil {
+		if rc, _, err := s.Assets.Open(ctx, botID, att.ContentHash); err == nil {
+			data, readErr := io.ReadAll(s.limit(rc))
+			_ = rc.Close()
+			if readErr == nil && len(data) > 0 {
+				return data, nil
+			}
+		}
+	}
+	for _, raw := range []string{att.Base64, att.URL} {
+		raw = strings.TrimSpace(raw)
+		if !strings.HasPrefix(strings.ToLower(raw), "data:") {
+			continue
+		}
+		if _, payload, ok := strings.Cut(raw, ","); ok {
+			if data, err := base64.StdEncoding.DecodeString(payload); err == nil {
+				return data, nil
+			}
+		}
+	}
+	if link := strings.TrimSpace(att.URL); strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+		return s.download(ctx, botID, link)
+	}
+	return nil, ErrAttachmentNoContent
+}
+
+func (s AttachmentSource) download(ctx context.Context, botID, link string) ([]byte, error) {
+	var policy netguard.Policy
+	if s.Policies != nil {
+		resolved, err := s.Policies.ResolveFetchPolicy(ctx, botID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve fetch policy: %w", err)
+		}
+		policy = resolved
+	}
+	policy.MaxBodyBytes = s.MaxBytes
+	policy.Timeout = attachmentFetchTimeout
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := policy.Client().Do(req) //nolint:gosec // G704: destination is checked by the bot's fetch policy
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s AttachmentSource) limit(r io.ReadCloser) io.Reader {
+	if s.MaxBytes <= 0 {
+		return r
+	}
+	return netguard.LimitBody(r, s.MaxBytes)
+}
@@ -0,0 +1,60 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/memohai/memoh/internal/netguard"
+)
+
+type staticFetchPolicy struct {
+	policy netguard.Policy
+	err    error
+}
+
+func (s staticFetchPolicy) ResolveFetchPolicy(context.Context, string) (netguard.Policy, error) {
+	return s.policy, s.err
+}
+
+func TestAttachmentSourceLoad(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("remote"))
+	}))
+	defer server.Close()
+
+	data, err := AttachmentSource{}.Load(context.Background(), "bot-1", Attachment{Base64: "data:text/plain;base64,aGk="})
+	if err != nil || string(data) != "hi" {
+		t.Fatalf("data URL: got %q, %v", data, err)
+	}
+
+	if _, err := (AttachmentSource{}).Load(context.Background(), "bot-1", Attachment{URL: server.URL}); !errors.Is(err, netguard.ErrBlockedAddress) {
+		t.Fatalf("default policy should refuse loopback, got %v", err)
+	}
+
+	loadErr := errors.New("settings unavailable")
+	failing := AttachmentSource{Policies: staticFetchPolicy{err: loadErr}}
+	if _, err := failing.Load(context.Background(), "bot-1", Attachment{URL: server.URL}); !errors.Is(err, loadErr) {
+		t.Fatalf("policy errors must fail the download, got %v", err)
+	}
+
+	allowed := AttachmentSource{Policies: staticFetchPolicy{policy: netguard.Policy{AllowPrivate: true}}}
+	data, err = allowed.Load(context.Background(), "bot-1", Attachment{URL: server.URL})
+	if err != nil || string(data) != "remote" {
+		t.Fatalf("allowed download: got %q, %v", data, err)
+	}
+
+	capped := allowed
+	capped.MaxBytes = 3
+	if _, err := capped.Load(context.Background(), "bot-1", Attachment{URL: server.URL}); !errors.Is(err, netguard.ErrBodyTooLarge) {
+		t.Fatalf("expected body limit error, got %v", err)
+	}
+
+	if _, err := (AttachmentSource{}).Load(context.Background(), "bot-1", Attachment{}); !errors.Is(err, ErrAttachmentNoContent) {
+		t.Fatalf("expected no content error, got %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Routing keys that configure inbound debouncing on a channel config.
+const (
+	// RoutingInboundDebounceMs is the quiet period in milliseconds to wait
+	// for follow-up messages before starting a turn. Zero disables it.
+	RoutingInboundDebounceMs = "inbound_debounce_ms"
+	// RoutingInterruptOnNewMessage cancels an in-flight turn when the same
+	// sender writes again; the interrupted messages join the next turn.
+	RoutingInterruptOnNewMessage = "interrupt_on_new_message"
+)
+
+const (
+	maxInboundDebounce      = time.Minute
+	maxInboundBatchMessages = 20
+	// inboundMaxWaitFactor caps how long a steady stream of messages can
+	// postpone a turn, as a multiple of the quiet period.
+	inboundMaxWaitFactor = 4
+)
+
+// InboundDebounceSettings controls how consecutive messages are coalesced.
+type InboundDebounceSettings struct {
+	Quiet     time.Duration
+	Interrupt bool
+}
+
+// Enabled reports whether messages need to pass through the debouncer.
+func (s InboundDebounceSettings) Enabled() bool {
+	return s.Quiet > 0 || s.Interrupt
+}
+
+// InboundDebounceSettingsFromConfig reads debounce settings from the routing
+// map of a channel config.
+func InboundDebounceSettingsFromConfig(cfg ChannelConfig) InboundDebounceSettings {
+	var settings InboundDebounceSettings
+	if raw := strings.TrimSpace(ReadString(cfg.Routing, RoutingInboundDebounceMs)); raw != "" {
+		if ms, err := strconv.ParseFloat(raw, 64); err == nil && ms > 0 {
+			settings.Quiet = min(time.Duration(ms)*time.Millisecond, maxInboundDebounce)
+		}
+	}
+	if raw := strings.TrimSpace(ReadString(cfg.Routing, RoutingInterruptOnNewMessage)); raw != "" {
+		settings.Interrupt, _ = strconv.ParseBool(raw)
+	}
+	return settings
+}
+
+// inboundRoute is the debounce state of one sender in one conversation.
+type inboundRoute struct {
+	cfg       ChannelConfig
+	settings  InboundDebounceSettings
+	pending   []InboundMessage
+	firstAt   time.Time
+	timer     *time.Timer
+	ready     bool
+	running   bool
+	cancel    context.CancelFunc
+	inflight  []InboundMessage
+	interrupt bool
+}
+
+// inboundDebouncer queues inbound messages per route, merges bursts into
+// one turn after a quiet period, and runs at most one turn per route at a
+// time.
+type inboundDebouncer struct {
+	mu       sync.Mutex
+	routes   map[string]*inboundRoute
+	dispatch func(ctx context.Context, cfg ChannelConfig, msg InboundMessage, done func(error)) error
+	logger   *slog.Logger
+}
+
+func newInboundDebouncer(log *slog.Logger, dispatch func(ctx context.Context, cfg ChannelConfig, msg InboundMessage, done func(error)) error) *inboundDebouncer {
+	return &inboundDebouncer{
+		routes:   map[string]*inboundRoute{},
+		dispatch: dispatch,
+		logger:   log,
+	}
+}
+
+// shouldDebounce reports whether a message may wait for follow-ups. Slash
+// commands and button clicks always run immediately.
+func shouldDebounce(msg InboundMessage) bool {
+	if msg.Interaction != nil {
+		return false
+	}
+	text := strings.TrimSpace(msg.Message.Text)
+	if raw, ok := msg.Metadata["raw_text"].(string); ok && strings.TrimSpace(raw) != "" {
+		text = strings.TrimSpace(raw)
+	}
+	return !strings.HasPrefix(text, "/")
+}
+
+// inboundRouteKey identifies a sender in a conversation thread. Group
+// messages from different people are never merged so each turn keeps a
+// single author.
+func inboundRouteKey(cfg ChannelConfig, msg InboundMessage) string {
+	threadID := strings.TrimSpace(msg.Conversation.ThreadID)
+	return strings.Join([]string{
+		cfg.ID,
+		msg.Channel.String(),
+		strings.TrimSpace(msg.Conversation.ID),
+		threadID,
+		strings.TrimSpace(msg.Sender.SubjectID),
+	}, "\x00")
+}
+
+// Add queues a message for its route and (re)starts the quiet period.
+func (d *inboundDebouncer) Add(ctx context.Context, cfg ChannelConfig, msg InboundMessage, settings InboundDebounceSettings) {
+	key := inboundRouteKey(cfg, msg)
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	r := d.routes[key]
+	if r == nil {
+		r = &inboundRoute{}
+		d.routes[key] = r
+	}
+	r.cfg = cfg
+	r.settings = settings
+	if len(r.pending) == 0 {
+		r.firstAt = now
+	}
+	r.pending = append(r.pending, msg)
+	if settings.Interrupt && r.running && r.cancel != nil && !r.interrupt {
+		r.interrupt = true
+		r.cancel()
+		if d.logger != nil {
+			d.logger.Info("inbound turn interrupted by new message",
+				slog.String("channel", msg.Channel.String()),
+				slog.String("conversation_id", msg.Conversation.ID))
+		}
+	}
+
+	wait := settings.Quiet
+	if deadline := r.firstAt.Add(settings.Quiet * inboundMaxWaitFactor); now.Add(wait).After(deadline) {
+		wait = max(deadline.Sub(now), 0)
+	}
+	if len(r.pending) >= maxInboundBatchMessages {
+		wait = 0
+	}
+	r.ready = false
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.timer = time.AfterFunc(wait, func() { d.fire(ctx, key, r) })
+}
+
+func (d *inboundDebouncer) fire(ctx context.Context, key string, r *inboundRoute) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.routes[key] != r || len(r.pending) == 0 {
+		return
+	}
+	r.timer = nil
+	if r.running {
+		// Start as soon as the current turn finishes.
+		r.ready = true
+		return
+	}
+	d.startLocked(ctx, key, r)
+}
+
+func (d *inboundDebouncer) startLocked(ctx context.Context, key string, r *inboundRoute) {
+	batch := r.pending
+	r.pending = nil
+	r.ready = false
+	r.running = true
+	r.inflight = batch
+	turnCtx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	cfg := r.cfg
+	msg := mergeInboundMessages(batch)
+	go func() {
+		err := d.dispatch(turnCtx, cfg, msg, func(err error) { d.finish(ctx, key, r, err) })
+		if err != nil {
+			if d.logger != nil {
+				d.logger.Error("inbound dispatch failed", slog.String("channel", msg.Channel.String()), slog.Any("error", err))
+			}
+			d.finish(ctx, key, r, err)
+		}
+	}()
+}
+
+// finish records the end of a turn and starts the next one when messages
+// are waiting. Messages of an interrupted turn are retried with the ones
+// that interrupted it.
+func (d *inboundDebouncer) finish(ctx context.Context, key string, r *inboundRoute, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.interrupt && errors.Is(err, context.Canceled) {
+		r.pending = append(r.inflight, r.pending...)
+	}
+	r.running = false
+	r.cancel = nil
+	r.inflight = nil
+	r.interrupt = false
+	switch {
+	case len(r.pending) == 0:
+		if d.routes[key] == r {
+			delete(d.routes, key)
+		}
+	case r.ready || r.timer == nil:
+		d.startLocked(ctx, key, r)
+	}
+}
+
+// mergeInboundMessages combines a burst of messages into one. The latest
+// message supplies the ID, reply target and reply reference; texts and
+// attachments are concatenated in order.
+func mergeInboundMessages(msgs []InboundMessage) InboundMessage {
+	if len(msgs) == 1 {
+		return msgs[0]
+	}
+	merged := msgs[len(msgs)-1]
+	texts := make([]string, 0, len(msgs))
+	rawTexts := make([]string, 0, len(msgs))
+	attachments := make([]Attachment, 0)
+	metadata := make(map[string]any, len(merged.Metadata))
+	for k, v := range merged.Metadata {
+		metadata[k] = v
+	}
+	for _, msg := range msgs {
+		if text := strings.TrimSpace(msg.Message.Text); text != "" {
+			texts = append(texts, text)
+		}
+		if raw, ok := msg.Metadata["raw_text"].(string); ok && strings.TrimSpace(raw) != "" {
+			rawTexts = append(rawTexts, strings.TrimSpace(raw))
+		}
+		attachments = append(attachments, msg.Message.Attachments...)
+		for _, key := range []string{"is_mentioned", "is_reply_to_bot"} {
+			if value, ok := msg.Metadata[key].(bool); ok && value {
+				metadata[key] = true
+			}
+		}
+		if merged.Message.Reply == nil && msg.Message.Reply != nil {
+			merged.Message.Reply = msg.Message.Reply
+		}
+	}
+	merged.Message.Text = strings.Join(texts, "\n")
+	merged.Message.Attachments = attachments
+	if len(rawTexts) > 0 {
+		metadata["raw_text"] = strings.Join(rawTexts, "\n")
+	}
+	metadata["coalesced_messages"] = len(msgs)
+	merged.Metadata = metadata
+	return merged
+}
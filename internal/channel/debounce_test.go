@@ -0,0 +1,158 @@
+package channel
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingInboundProcessor struct {
+	mu    sync.Mutex
+	msgs  []InboundMessage
+	calls chan InboundMessage
+	block bool
+}
+
+func newRecordingInboundProcessor() *recordingInboundProcessor {
+	return &recordingInboundProcessor{calls: make(chan InboundMessage, 16)}
+}
+
+func (p *recordingInboundProcessor) HandleInbound(ctx context.Context, _ ChannelConfig, msg InboundMessage, _ StreamReplySender) error {
+	p.mu.Lock()
+	p.msgs = append(p.msgs, msg)
+	block := p.block
+	p.block = false
+	p.mu.Unlock()
+	p.calls <- msg
+	if block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (p *recordingInboundProcessor) next(t *testing.T) InboundMessage {
+	t.Helper()
+	select {
+	case msg := <-p.calls:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for inbound turn")
+		return InboundMessage{}
+	}
+}
+
+func (p *recordingInboundProcessor) expectNone(t *testing.T, wait time.Duration) {
+	t.Helper()
+	select {
+	case msg := <-p.calls:
+		t.Fatalf("unexpected inbound turn: %q", msg.Message.Text)
+	case <-time.After(wait):
+	}
+}
+
+func debounceTestMessage(id, text string) InboundMessage {
+	return InboundMessage{
+		Channel:      ChannelType("test"),
+		Message:      Message{ID: id, Text: text},
+		ReplyTarget:  "target",
+		Sender:       Identity{SubjectID: "user-1"},
+		Conversation: Conversation{ID: "chat-1", Type: ConversationTypePrivate},
+	}
+}
+
+func TestInboundDebounceSettingsFromConfig(t *testing.T) {
+	settings := InboundDebounceSettingsFromConfig(ChannelConfig{Routing: map[string]any{
+		RoutingInboundDebounceMs:     float64(1500),
+		RoutingInterruptOnNewMessage: true,
+	}})
+	if settings.Quiet != 1500*time.Millisecond || !settings.Interrupt {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+	settings = InboundDebounceSettingsFromConfig(ChannelConfig{Routing: map[string]any{RoutingInboundDebounceMs: "600000"}})
+	if settings.Quiet != maxInboundDebounce {
+		t.Fatalf("expected quiet period to be capped, got %s", settings.Quiet)
+	}
+	if InboundDebounceSettingsFromConfig(ChannelConfig{}).Enabled() {
+		t.Fatal("expected debouncing to be off by default")
+	}
+}
+
+func TestMergeInboundMessages(t *testing.T) {
+	first := debounceTestMessage("m1", "hey")
+	first.Message.Attachments = []Attachment{{Type: AttachmentImage, URL: "https://example.com/a.png"}}
+	first.Metadata = map[string]any{"is_mentioned": true, "raw_text": "hey"}
+	second := debounceTestMessage("m2", "  ")
+	third := debounceTestMessage("m3", "can you check the logs?")
+	third.Metadata = map[string]any{"raw_text": "can you check the logs?"}
+
+	merged := mergeInboundMessages([]InboundMessage{first, second, third})
+	if merged.Message.ID != "m3" {
+		t.Fatalf("expected latest message ID, got %q", merged.Message.ID)
+	}
+	if merged.Message.Text != "hey\ncan you check the logs?" {
+		t.Fatalf("unexpected merged text: %q", merged.Message.Text)
+	}
+	if len(merged.Message.Attachments) != 1 {
+		t.Fatalf("expected attachments to be kept, got %d", len(merged.Message.Attachments))
+	}
+	if merged.Metadata["is_mentioned"] != true || merged.Metadata["raw_text"] != "hey\ncan you check the logs?" {
+		t.Fatalf("unexpected merged metadata: %+v", merged.Metadata)
+	}
+	if merged.Metadata["coalesced_messages"] != 3 {
+		t.Fatalf("expected coalesced count, got %v", merged.Metadata["coalesced_messages"])
+	}
+}
+
+func TestManagerDebouncesInboundBursts(t *testing.T) {
+	processor := newRecordingInboundProcessor()
+	m := NewManager(slog.Default(), NewRegistry(), &fakeConfigStore{}, processor)
+	cfg := ChannelConfig{ID: "cfg-1", BotID: "bot-1", ChannelType: ChannelType("test"), Routing: map[string]any{
+		RoutingInboundDebounceMs: float64(50),
+	}}
+	ctx := context.Background()
+
+	for i, text := range []string{"one", "two", "three"} {
+		if err := m.HandleInbound(ctx, cfg, debounceTestMessage(string(rune('a'+i)), text)); err != nil {
+			t.Fatalf("handle inbound: %v", err)
+		}
+	}
+	if got := processor.next(t); got.Message.Text != "one\ntwo\nthree" {
+		t.Fatalf("expected merged turn, got %q", got.Message.Text)
+	}
+	processor.expectNone(t, 150*time.Millisecond)
+
+	if err := m.HandleInbound(ctx, cfg, debounceTestMessage("cmd", "/new")); err != nil {
+		t.Fatalf("handle inbound: %v", err)
+	}
+	if got := processor.next(t); got.Message.Text != "/new" {
+		t.Fatalf("expected command to bypass debouncing, got %q", got.Message.Text)
+	}
+}
+
+func TestManagerInterruptsInFlightTurn(t *testing.T) {
+	processor := newRecordingInboundProcessor()
+	processor.block = true
+	m := NewManager(slog.Default(), NewRegistry(), &fakeConfigStore{}, processor)
+	cfg := ChannelConfig{ID: "cfg-1", BotID: "bot-1", ChannelType: ChannelType("test"), Routing: map[string]any{
+		RoutingInboundDebounceMs:     float64(20),
+		RoutingInterruptOnNewMessage: true,
+	}}
+	ctx := context.Background()
+
+	if err := m.HandleInbound(ctx, cfg, debounceTestMessage("m1", "deploy staging")); err != nil {
+		t.Fatalf("handle inbound: %v", err)
+	}
+	if got := processor.next(t); got.Message.Text != "deploy staging" {
+		t.Fatalf("unexpected first turn: %q", got.Message.Text)
+	}
+	if err := m.HandleInbound(ctx, cfg, debounceTestMessage("m2", "actually production")); err != nil {
+		t.Fatalf("handle inbound: %v", err)
+	}
+	got := processor.next(t)
+	if got.Message.Text != "deploy staging\nactually production" || got.Message.ID != "m2" {
+		t.Fatalf("expected interrupted messages to join the next turn, got %q (%s)", got.Message.Text, got.Message.ID)
+	}
+}
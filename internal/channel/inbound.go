@@ -7,11 +7,15 @@ import (
 )
 
 type inboundTask struct {
-	cfg ChannelConfig
-	msg InboundMessage
+	ctx  context.Context
+	cfg  ChannelConfig
+	msg  InboundMessage
+	done func(error)
 }
 
 // HandleInbound enqueues an inbound message for asynchronous processing by the worker pool.
+// When the channel config enables debouncing, the message first waits for
+// follow-ups from the same sender and is merged with them into one turn.
 func (m *Manager) HandleInbound(ctx context.Context, cfg ChannelConfig, msg InboundMessage) error {
 	if m.processor == nil {
 		return errors.New("inbound processor not configured")
@@ -20,10 +24,14 @@ func (m *Manager) HandleInbound(ctx context.Context, cfg ChannelConfig, msg Inbo
 	if m.inboundCtx != nil && m.inboundCtx.Err() != nil {
 		return errors.New("inbound dispatcher stopped")
 	}
-	task := inboundTask{
-		cfg: cfg,
-		msg: msg,
+	if settings := InboundDebounceSettingsFromConfig(cfg); settings.Enabled() && shouldDebounce(msg) {
+		m.debouncer.Add(m.inboundCtx, cfg, msg, settings)
+		return nil
 	}
+	return m.enqueueInbound(inboundTask{cfg: cfg, msg: msg})
+}
+
+func (m *Manager) enqueueInbound(task inboundTask) error {
 	select {
 	case m.inboundQueue <- task:
 		return nil
@@ -38,7 +46,7 @@ func (m *Manager) handleInbound(ctx context.Context, cfg ChannelConfig, msg Inbo
 	}
 	sender := m.newReplySender(cfg, msg.Channel)
 	if err := m.processor.HandleInbound(ctx, cfg, msg, sender); err != nil {
-		if m.logger != nil {
+		if m.logger != nil && !errors.Is(err, context.Canceled) {
 			m.logger.Error("inbound processing failed", slog.String("channel", msg.Channel.String()), slog.Any("error", err))
 		}
 		return err
@@ -46,6 +54,11 @@ func (m *Manager) handleInbound(ctx context.Context, cfg ChannelConfig, msg Inbo
 	return nil
 }
 
+// dispatchDebounced hands a debounced turn to the worker pool.
+func (m *Manager) dispatchDebounced(ctx context.Context, cfg ChannelConfig, msg InboundMessage, done func(error)) error {
+	return m.enqueueInbound(inboundTask{ctx: ctx, cfg: cfg, msg: msg, done: done})
+}
+
 func (m *Manager) startInboundWorkers(ctx context.Context) {
 	m.inboundOnce.Do(func() {
 		workerCtx := context.WithoutCancel(ctx)
@@ -63,10 +76,16 @@ func (m *Manager) runInboundWorker(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case task := <-m.inboundQueue:
-			if err := m.handleInbound(ctx, task.cfg, task.msg); err != nil {
-				if m.logger != nil {
-					m.logger.Error("inbound processing failed", slog.String("channel", task.msg.Channel.String()), slog.Any("error", err))
-				}
+			taskCtx := ctx
+			if task.ctx != nil {
+				taskCtx = task.ctx
+			}
+			err := m.handleInbound(taskCtx, task.cfg, task.msg)
+			if err != nil && m.logger != nil && !errors.Is(err, context.Canceled) {
+				m.logger.Error("inbound processing failed", slog.String("channel", task.msg.Channel.String()), slog.Any("error", err))
+			}
+			if task.done != nil {
+				task.done(err)
 			}
 		}
 	}
@@ -17,15 +17,20 @@ import (
 	"unicode"
 
 	"github.com/memohai/memoh/internal/acl"
+	"github.com/memohai/memoh/internal/approval"
 	"github.com/memohai/memoh/internal/attachment"
 	"github.com/memohai/memoh/internal/auth"
 	"github.com/memohai/memoh/internal/channel"
 	"github.com/memohai/memoh/internal/channel/route"
+	"github.com/memohai/memoh/internal/channel/trigger"
 	"github.com/memohai/memoh/internal/command"
 	"github.com/memohai/memoh/internal/conversation"
 	"github.com/memohai/memoh/internal/conversation/flow"
+	"github.com/memohai/memoh/internal/document"
 	"github.com/memohai/memoh/internal/media"
 	messagepkg "github.com/memohai/memoh/internal/message"
+	"github.com/memohai/memoh/internal/netguard"
+	"github.com/memohai/memoh/internal/stt"
 )
 
 var base64Std = base64.StdEncoding
@@ -51,6 +56,10 @@ type chatACL interface {
 	CanPerformChatTrigger(ctx context.Context, req acl.ChatTriggerRequest) (bool, error)
 }
 
+type toolApprovalDecider interface {
+	Decide(ctx context.Context, botID, ref, userID string, req approval.DecideRequest) (approval.Approval, error)
+}
+
 type mediaIngestor interface {
 	Ingest(ctx context.Context, input media.IngestInput) (media.Asset, error)
 	// GetByStorageKey resolves an asset by reading its sidecar JSON.
@@ -59,6 +68,8 @@ type mediaIngestor interface {
 	AccessPath(asset media.Asset) string
 	// IngestContainerFile reads a file from /data/ and ingests it into media store.
 	IngestContainerFile(ctx context.Context, botID, containerPath string) (media.Asset, error)
+	// Open returns a reader for a persisted asset by content hash.
+	Open(ctx context.Context, botID, contentHash string) (io.ReadCloser, media.Asset, error)
 }
 
 // ttsSynthesizer synthesizes text to speech audio.
@@ -71,6 +82,21 @@ type ttsModelResolver interface {
 	ResolveTtsModelID(ctx context.Context, botID string) (string, error)
 }
 
+// sttTranscriber transcribes speech audio to text.
+type sttTranscriber interface {
+	Transcribe(ctx context.Context, modelID string, audio stt.AudioInput, overrideCfg map[string]any) (stt.TranscriptionResult, error)
+}
+
+// sttModelResolver looks up the STT model ID configured for a bot.
+type sttModelResolver interface {
+	ResolveSttModelID(ctx context.Context, botID string) (string, error)
+}
+
+// documentExtractor extracts the text of persisted document attachments.
+type documentExtractor interface {
+	ExtractAsset(ctx context.Context, botID, contentHash, name string) (document.Document, error)
+}
+
 // SessionEnsurer resolves or creates an active session for a route.
 type SessionEnsurer interface {
 	EnsureActiveSession(ctx context.Context, botID, routeID, channelType string) (SessionResult, error)
@@ -102,7 +128,16 @@ type ChannelInboundProcessor struct {
 	observer         channel.StreamObserver
 	ttsService       ttsSynthesizer
 	ttsModelResolver ttsModelResolver
+	sttService       sttTranscriber
+	sttModelResolver sttModelResolver
 	sessionEnsurer   SessionEnsurer
+	approvals        toolApprovalDecider
+	fetchPolicies    channel.FetchPolicyResolver
+	documents        documentExtractor
+
+	triggerPolicies   triggerPolicyStore
+	passiveClassifier passiveClassifier
+	unsolicited       *trigger.Limiter
 }
 
 // NewChannelInboundProcessor creates a processor with channel identity-based resolution.
@@ -189,6 +224,35 @@ func (p *ChannelInboundProcessor) SetTtsService(synth ttsSynthesizer, modelResol
 	p.ttsModelResolver = modelResolver
 }
 
+// SetSttService configures the STT transcriber and settings reader used to
+// turn inbound voice and audio attachments into text for the agent.
+func (p *ChannelInboundProcessor) SetSttService(transcriber sttTranscriber, modelResolver sttModelResolver) {
+	if p == nil {
+		return
+	}
+	p.sttService = transcriber
+	p.sttModelResolver = modelResolver
+}
+
+// SetFetchPolicyResolver configures the per-bot policy that inbound
+// attachment downloads are checked against. Without it downloads use the
+// default policy, which refuses private destinations.
+func (p *ChannelInboundProcessor) SetFetchPolicyResolver(resolver channel.FetchPolicyResolver) {
+	if p == nil {
+		return
+	}
+	p.fetchPolicies = resolver
+}
+
+// SetDocumentExtractor configures extraction of PDF, Word, Excel and HTML
+// attachments so the agent can read them with the read_document tool.
+func (p *ChannelInboundProcessor) SetDocumentExtractor(extractor documentExtractor) {
+	if p == nil {
+		return
+	}
+	p.documents = extractor
+}
+
 // SetSessionEnsurer configures the session ensurer for auto-creating sessions on routes.
 func (p *ChannelInboundProcessor) SetSessionEnsurer(ensurer SessionEnsurer) {
 	if p == nil {
@@ -206,6 +270,15 @@ func (p *ChannelInboundProcessor) SetCommandHandler(handler *command.Handler) {
 	p.commandHandler = handler
 }
 
+// SetApprovalService configures the service that /approve and /reject
+// decide pending tool call approvals with.
+func (p *ChannelInboundProcessor) SetApprovalService(service toolApprovalDecider) {
+	if p == nil {
+		return
+	}
+	p.approvals = service
+}
+
 // HandleInbound processes an inbound channel message through identity resolution and chat gateway.
 func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel.ChannelConfig, msg channel.InboundMessage, sender channel.StreamReplySender) error {
 	if p.runner == nil {
@@ -225,7 +298,7 @@ func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel
 			slog.String("conversation_id", strings.TrimSpace(msg.Conversation.ID)),
 		)
 	}
-	if strings.TrimSpace(msg.Message.PlainText()) == "" && len(msg.Message.Attachments) == 0 {
+	if strings.TrimSpace(msg.Message.PlainText()) == "" && len(msg.Message.Attachments) == 0 && msg.Interaction == nil {
 		if p.logger != nil {
 			p.logger.Debug("inbound dropped empty", slog.String("channel", msg.Channel.String()))
 		}
@@ -270,6 +343,10 @@ func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel
 		return p.handleNewSessionCommand(ctx, cfg, msg, sender, identity)
 	}
 
+	if approve, ref, note, ok := parseApprovalCommand(cmdText); ok && isDirectedAtBot(msg) {
+		return p.handleApprovalCommand(ctx, msg, sender, identity, approve, ref, note)
+	}
+
 	if p.commandHandler != nil && p.commandHandler.IsCommand(cmdText) && isDirectedAtBot(msg) {
 		reply, err := p.commandHandler.Execute(ctx, strings.TrimSpace(identity.BotID), strings.TrimSpace(identity.ChannelIdentityID), cmdText)
 		if err != nil {
@@ -282,8 +359,13 @@ func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel
 	}
 
 	resolvedAttachments := p.ingestInboundAttachments(ctx, cfg, msg, strings.TrimSpace(identity.BotID), msg.Message.Attachments)
+	resolvedAttachments = p.transcribeInboundAudio(ctx, strings.TrimSpace(identity.BotID), resolvedAttachments)
+	resolvedAttachments = p.extractInboundDocuments(ctx, strings.TrimSpace(identity.BotID), resolvedAttachments)
 	attachments := mapChannelToChatAttachments(resolvedAttachments)
 	text = buildInboundQuery(msg.Message, attachments)
+	if msg.Interaction != nil {
+		text = formatInteraction(*msg.Interaction)
+	}
 	threadID := extractThreadID(msg)
 
 	// Resolve or create the route via channel_routes.
@@ -327,6 +409,9 @@ func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel
 		activeChatID = strings.TrimSpace(resolved.ChatID)
 	}
 	shouldTrigger := shouldTriggerAssistantResponse(msg) || identity.ForceReply
+	if !shouldTrigger {
+		shouldTrigger = p.matchTriggerPolicy(ctx, identity, msg, resolved.RouteID, text)
+	}
 	if shouldTrigger && p.acl != nil {
 		allowed, err := p.acl.CanPerformChatTrigger(ctx, acl.ChatTriggerRequest{
 			BotID:             identity.BotID,
@@ -436,7 +521,7 @@ func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel
 		UserID:            identity.UserID,
 		Query:             text,
 		ReplyTarget:       strings.TrimSpace(msg.ReplyTarget),
-		SourceMessageID:   strings.TrimSpace(msg.Message.ID),
+		SourceMessageID:   platformSourceMessageID(msg),
 	}
 	statusNotifier := p.resolveProcessingStatusNotifier(msg.Channel)
 	statusHandle := channel.ProcessingStatusHandle{}
@@ -458,7 +543,7 @@ func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel
 		}
 		return err
 	}
-	sourceMessageID := strings.TrimSpace(msg.Message.ID)
+	sourceMessageID := platformSourceMessageID(msg)
 	replyRef := &channel.ReplyRef{Target: target}
 	if sourceMessageID != "" {
 		replyRef.MessageID = sourceMessageID
@@ -527,7 +612,7 @@ func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel
 		DisplayName:             identity.DisplayName,
 		RouteID:                 resolved.RouteID,
 		ChatToken:               chatToken,
-		ExternalMessageID:       sourceMessageID,
+		ExternalMessageID:       strings.TrimSpace(msg.Message.ID),
 		ReplyTarget:             target,
 		ConversationType:        msg.Conversation.Type,
 		ConversationName:        msg.Conversation.Name,
@@ -601,6 +686,19 @@ func (p *ChannelInboundProcessor) HandleInbound(ctx context.Context, cfg channel
 		}
 	}
 
+	if streamErr != nil && errors.Is(ctx.Err(), context.Canceled) {
+		// The turn was interrupted by a newer message, which answers in
+		// its place; report nothing to the chat.
+		if p.logger != nil {
+			p.logger.Info(
+				"chat gateway stream interrupted",
+				slog.String("channel", msg.Channel.String()),
+				slog.String("channel_identity_id", identity.ChannelIdentityID),
+				slog.String("user_id", identity.UserID),
+			)
+		}
+		return ctx.Err()
+	}
 	if streamErr != nil {
 		if p.logger != nil {
 			p.logger.Error(
@@ -1082,32 +1180,177 @@ func mapStreamChunkToChannelEvents(chunk conversation.StreamChunk) ([]channel.St
 
 func buildInboundQuery(message channel.Message, attachments []conversation.ChatAttachment) string {
 	text := strings.TrimSpace(message.PlainText())
+	transcripts := collectAttachmentTranscripts(attachments)
+	documents := collectAttachmentDocuments(attachments)
 	if text != "" {
+		if len(transcripts) > 0 {
+			text += "\n" + formatTranscripts(transcripts)
+		}
+		if len(documents) > 0 {
+			text += "\n" + formatDocuments(documents)
+		}
 		return text
 	}
 	if len(message.Attachments) == 0 {
 		return ""
 	}
+	if len(transcripts) > 0 && len(transcripts) == len(message.Attachments) {
+		return formatTranscripts(transcripts)
+	}
 	count := len(message.Attachments)
 	fallback := fmt.Sprintf("[User sent %d attachments]", count)
 	if count == 1 {
 		fallback = "[User sent 1 attachment]"
 	}
 	refs := collectContainerAttachmentRefs(attachments)
-	if len(refs) == 0 {
+	if len(refs) == 0 && len(transcripts) == 0 {
 		return fallback
 	}
 	var sb strings.Builder
 	sb.WriteString(fallback)
-	sb.WriteString("\n[Attachment refs: container paths]\n")
-	for _, ref := range refs {
+	if len(refs) > 0 {
+		sb.WriteString("\n[Attachment refs: container paths]\n")
+		for _, ref := range refs {
+			sb.WriteString("- ")
+			sb.WriteString(ref)
+			sb.WriteByte('\n')
+		}
+	}
+	if len(transcripts) > 0 {
+		sb.WriteByte('\n')
+		sb.WriteString(formatTranscripts(transcripts))
+	}
+	if len(documents) > 0 {
+		sb.WriteByte('\n')
+		sb.WriteString(formatDocuments(documents))
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// collectAttachmentTranscripts returns the speech-to-text transcripts stored
+// on attachment metadata by transcribeInboundAudio, in attachment order.
+func collectAttachmentTranscripts(attachments []conversation.ChatAttachment) []string {
+	if len(attachments) == 0 {
+		return nil
+	}
+	transcripts := make([]string, 0, len(attachments))
+	for _, att := range attachments {
+		if att.Metadata == nil {
+			continue
+		}
+		transcript, _ := att.Metadata[attachmentTranscriptKey].(string)
+		if transcript = strings.TrimSpace(transcript); transcript != "" {
+			transcripts = append(transcripts, transcript)
+		}
+	}
+	return transcripts
+}
+
+func formatTranscripts(transcripts []string) string {
+	var sb strings.Builder
+	sb.WriteString("[Voice message transcript]\n")
+	for i, transcript := range transcripts {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(transcript)
+	}
+	return sb.String()
+}
+
+// collectAttachmentDocuments describes the documents extracted by
+// extractInboundDocuments, one line per attachment.
+func collectAttachmentDocuments(attachments []conversation.ChatAttachment) []string {
+	if len(attachments) == 0 {
+		return nil
+	}
+	documents := make([]string, 0, len(attachments))
+	for _, att := range attachments {
+		format, _ := att.Metadata[attachmentDocumentFormatKey].(string)
+		ref := strings.TrimSpace(att.Path)
+		if format == "" || ref == "" {
+			continue
+		}
+		line := fmt.Sprintf("%s (%s", ref, format)
+		if pages := metadataInt(att.Metadata[attachmentDocumentPagesKey]); pages > 0 {
+			unit := "pages"
+			if format == string(document.FormatXLSX) {
+				unit = "sheets"
+			}
+			line += fmt.Sprintf(", %d %s", pages, unit)
+		}
+		if title, _ := att.Metadata[attachmentDocumentTitleKey].(string); strings.TrimSpace(title) != "" {
+			line += fmt.Sprintf(", title %q", strings.TrimSpace(title))
+		}
+		documents = append(documents, line+")")
+	}
+	return documents
+}
+
+func formatDocuments(documents []string) string {
+	var sb strings.Builder
+	sb.WriteString("[Documents: read with the read_document tool]\n")
+	for _, doc := range documents {
 		sb.WriteString("- ")
-		sb.WriteString(ref)
+		sb.WriteString(doc)
 		sb.WriteByte('\n')
 	}
 	return strings.TrimSpace(sb.String())
 }
 
+// metadataInt reads an integer stored on metadata, which becomes a float
+// after a JSON round trip.
+func metadataInt(value any) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// platformSourceMessageID returns the platform message that replies,
+// reactions and processing status should target. Interaction events carry a
+// synthetic ID, so they target the message that was clicked or voted on.
+func platformSourceMessageID(msg channel.InboundMessage) string {
+	if msg.Interaction != nil {
+		if id := strings.TrimSpace(msg.Interaction.MessageID); id != "" {
+			return id
+		}
+	}
+	return strings.TrimSpace(msg.Message.ID)
+}
+
+// formatInteraction describes a button click or poll vote for the model,
+// naming the bot message it answers so the choice can be tied back to it.
+func formatInteraction(interaction channel.Interaction) string {
+	on := ""
+	if id := strings.TrimSpace(interaction.MessageID); id != "" {
+		on = " on message " + id
+	}
+	switch interaction.Type {
+	case channel.InteractionPoll:
+		if len(interaction.Options) == 0 {
+			return "[Poll vote retracted" + on + "]"
+		}
+		return "[Poll vote" + on + "]\n" + strings.Join(interaction.Options, "\n")
+	default:
+		label := strings.TrimSpace(interaction.Label)
+		value := strings.TrimSpace(interaction.Value)
+		switch {
+		case label == "" || label == value:
+			return fmt.Sprintf("[Button clicked%s]\n%s", on, value)
+		case value == "":
+			return fmt.Sprintf("[Button clicked%s]\n%s", on, label)
+		default:
+			return fmt.Sprintf("[Button clicked%s]\n%s (value: %s)", on, label, value)
+		}
+	}
+}
+
 func collectContainerAttachmentRefs(attachments []conversation.ChatAttachment) []string {
 	if len(attachments) == 0 {
 		return nil
@@ -1463,7 +1706,7 @@ func (p *ChannelInboundProcessor) ingestInboundAttachments(
 			result = append(result, item)
 			continue
 		}
-		payload, err := p.loadInboundAttachmentPayload(ctx, cfg, msg, item)
+		payload, err := p.loadInboundAttachmentPayload(ctx, cfg, msg, botID, item)
 		if err != nil {
 			if p.logger != nil {
 				p.logger.Warn(
@@ -1550,6 +1793,149 @@ func (p *ChannelInboundProcessor) ingestInboundAttachments(
 	return result
 }
 
+// attachmentTranscriptKey is the attachment metadata key holding the
+// speech-to-text transcript of an audio or voice attachment.
+const attachmentTranscriptKey = "transcript"
+
+// transcribeInboundAudio transcribes persisted audio and voice attachments
+// with the bot's configured STT model. Failures are logged and the
+// attachment is passed through unchanged so the message is still delivered.
+func (p *ChannelInboundProcessor) transcribeInboundAudio(ctx context.Context, botID string, attachments []channel.Attachment) []channel.Attachment {
+	if len(attachments) == 0 || p == nil || p.sttService == nil || p.sttModelResolver == nil || p.mediaService == nil || botID == "" {
+		return attachments
+	}
+	hasAudio := false
+	for _, att := range attachments {
+		if isTranscribableAttachment(att) {
+			hasAudio = true
+			break
+		}
+	}
+	if !hasAudio {
+		return attachments
+	}
+	modelID, err := p.sttModelResolver.ResolveSttModelID(ctx, botID)
+	if err != nil {
+		if p.logger != nil {
+			p.logger.Warn("resolve stt model failed", slog.String("bot_id", botID), slog.Any("error", err))
+		}
+		return attachments
+	}
+	if strings.TrimSpace(modelID) == "" {
+		return attachments
+	}
+	for i, att := range attachments {
+		if !isTranscribableAttachment(att) {
+			continue
+		}
+		transcript, err := p.transcribeAttachment(ctx, botID, modelID, att)
+		if err != nil {
+			if p.logger != nil {
+				p.logger.Warn(
+					"inbound audio transcription failed",
+					slog.Any("error", err),
+					slog.String("bot_id", botID),
+					slog.String("content_hash", att.ContentHash),
+				)
+			}
+			continue
+		}
+		if transcript == "" {
+			continue
+		}
+		metadata := make(map[string]any, len(att.Metadata)+1)
+		for k, v := range att.Metadata {
+			metadata[k] = v
+		}
+		metadata[attachmentTranscriptKey] = transcript
+		attachments[i].Metadata = metadata
+	}
+	return attachments
+}
+
+func (p *ChannelInboundProcessor) transcribeAttachment(ctx context.Context, botID, modelID string, att channel.Attachment) (string, error) {
+	if att.Size > stt.MaxAudioBytes {
+		return "", fmt.Errorf("audio too large: %d bytes", att.Size)
+	}
+	reader, asset, err := p.mediaService.Open(ctx, botID, strings.TrimSpace(att.ContentHash))
+	if err != nil {
+		return "", fmt.Errorf("open audio: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+	data, err := io.ReadAll(io.LimitReader(reader, stt.MaxAudioBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("read audio: %w", err)
+	}
+	if len(data) > stt.MaxAudioBytes {
+		return "", fmt.Errorf("audio too large: more than %d bytes", stt.MaxAudioBytes)
+	}
+	mime := attachment.NormalizeMime(att.Mime)
+	if mime == "" {
+		mime = attachment.NormalizeMime(asset.Mime)
+	}
+	result, err := p.sttService.Transcribe(ctx, modelID, stt.AudioInput{
+		Data:     data,
+		Filename: strings.TrimSpace(att.Name),
+		Mime:     mime,
+	}, nil)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result.Text), nil
+}
+
+// Attachment metadata keys describing an extracted document.
+const (
+	attachmentDocumentFormatKey = "document_format"
+	attachmentDocumentPagesKey  = "document_pages"
+	attachmentDocumentTitleKey  = "document_title"
+)
+
+// extractInboundDocuments extracts the text of persisted document
+// attachments and records the format and page count on their metadata.
+// The extraction is cached next to the asset for the read_document tool.
+// Failures are logged and the attachment is passed through unchanged.
+func (p *ChannelInboundProcessor) extractInboundDocuments(ctx context.Context, botID string, attachments []channel.Attachment) []channel.Attachment {
+	if len(attachments) == 0 || p == nil || p.documents == nil || botID == "" {
+		return attachments
+	}
+	for i, att := range attachments {
+		if strings.TrimSpace(att.ContentHash) == "" || document.DetectFormat(att.Mime, att.Name) == "" {
+			continue
+		}
+		doc, err := p.documents.ExtractAsset(ctx, botID, strings.TrimSpace(att.ContentHash), strings.TrimSpace(att.Name))
+		if err != nil {
+			if p.logger != nil {
+				p.logger.Warn(
+					"inbound document extraction failed",
+					slog.Any("error", err),
+					slog.String("bot_id", botID),
+					slog.String("content_hash", att.ContentHash),
+				)
+			}
+			continue
+		}
+		metadata := make(map[string]any, len(att.Metadata)+3)
+		for k, v := range att.Metadata {
+			metadata[k] = v
+		}
+		metadata[attachmentDocumentFormatKey] = string(doc.Format)
+		metadata[attachmentDocumentPagesKey] = doc.TotalPages()
+		if doc.Title != "" {
+			metadata[attachmentDocumentTitleKey] = doc.Title
+		}
+		attachments[i].Metadata = metadata
+	}
+	return attachments
+}
+
+func isTranscribableAttachment(att channel.Attachment) bool {
+	if strings.TrimSpace(att.ContentHash) == "" {
+		return false
+	}
+	return att.Type == channel.AttachmentVoice || att.Type == channel.AttachmentAudio
+}
+
 type inboundAttachmentPayload struct {
 	reader io.ReadCloser
 	mime   string
@@ -1561,11 +1947,16 @@ func (p *ChannelInboundProcessor) loadInboundAttachmentPayload(
 	ctx context.Context,
 	cfg channel.ChannelConfig,
 	msg channel.InboundMessage,
+	botID string,
 	att channel.Attachment,
 ) (inboundAttachmentPayload, error) {
 	rawURL := strings.TrimSpace(att.URL)
 	if rawURL != "" {
-		payload, err := openInboundAttachmentURL(ctx, rawURL)
+		policy, err := p.fetchPolicy(ctx, botID)
+		if err != nil {
+			return inboundAttachmentPayload{}, err
+		}
+		payload, err := openInboundAttachmentURL(ctx, policy, rawURL)
 		if err == nil {
 			if strings.TrimSpace(att.Mime) != "" {
 				payload.mime = strings.TrimSpace(att.Mime)
@@ -1627,25 +2018,39 @@ func (p *ChannelInboundProcessor) loadInboundAttachmentPayload(
 	}, nil
 }
 
-func openInboundAttachmentURL(ctx context.Context, rawURL string) (inboundAttachmentPayload, error) {
+// fetchPolicy returns the bot's fetch policy, or the default policy when no
+// resolver is configured. A policy that cannot be loaded fails the download
+// rather than falling back to a less restrictive one.
+func (p *ChannelInboundProcessor) fetchPolicy(ctx context.Context, botID string) (netguard.Policy, error) {
+	if p.fetchPolicies == nil {
+		return netguard.Policy{}, nil
+	}
+	policy, err := p.fetchPolicies.ResolveFetchPolicy(ctx, botID)
+	if err != nil {
+		return netguard.Policy{}, fmt.Errorf("resolve fetch policy: %w", err)
+	}
+	return policy, nil
+}
+
+func openInboundAttachmentURL(ctx context.Context, policy netguard.Policy, rawURL string) (inboundAttachmentPayload, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
 	if err != nil {
 		return inboundAttachmentPayload{}, fmt.Errorf("build request: %w", err)
 	}
-	client := &http.Client{Timeout: 20 * time.Second}
-	resp, err := client.Do(req) //nolint:gosec // G704: URL is an attachment URL provided by the inbound channel adapter
+	maxBytes := media.MaxAssetBytes
+	policy.MaxBodyBytes = maxBytes
+	policy.Timeout = 20 * time.Second
+	resp, err := policy.Client().Do(req) //nolint:gosec // G704: destination is checked by the bot's fetch policy
 	if err != nil {
+		if errors.Is(err, netguard.ErrBodyTooLarge) {
+			return inboundAttachmentPayload{}, fmt.Errorf("%w: max %d bytes", media.ErrAssetTooLarge, maxBytes)
+		}
 		return inboundAttachmentPayload{}, fmt.Errorf("download attachment: %w", err)
 	}
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		_ = resp.Body.Close()
 		return inboundAttachmentPayload{}, fmt.Errorf("download attachment status: %d", resp.StatusCode)
 	}
-	maxBytes := media.MaxAssetBytes
-	if resp.ContentLength > maxBytes {
-		_ = resp.Body.Close()
-		return inboundAttachmentPayload{}, fmt.Errorf("%w: max %d bytes", media.ErrAssetTooLarge, maxBytes)
-	}
 	mime := strings.TrimSpace(resp.Header.Get("Content-Type"))
 	if idx := strings.Index(mime, ";"); idx >= 0 {
 		mime = strings.TrimSpace(mime[:idx])
@@ -2259,6 +2664,78 @@ func isNewSessionCommand(cmdText string) bool {
 	return parsed.Resource == "new"
 }
 
+// parseApprovalCommand recognises "/approve <id> [note]" and
+// "/reject <id> [reason]".
+func parseApprovalCommand(cmdText string) (approve bool, ref, note string, ok bool) {
+	extracted := command.ExtractCommandText(cmdText)
+	if extracted == "" {
+		return false, "", "", false
+	}
+	parsed, err := command.Parse(extracted)
+	if err != nil {
+		return false, "", "", false
+	}
+	switch parsed.Resource {
+	case "approve":
+		approve = true
+	case "reject":
+	default:
+		return false, "", "", false
+	}
+	return approve, parsed.Action, strings.TrimSpace(strings.Join(parsed.Args, " ")), true
+}
+
+// handleApprovalCommand decides a pending tool call approval on behalf of
+// the sender, who must be the bot owner.
+func (p *ChannelInboundProcessor) handleApprovalCommand(
+	ctx context.Context,
+	msg channel.InboundMessage,
+	sender channel.StreamReplySender,
+	identity InboundIdentity,
+	approve bool,
+	ref string,
+	note string,
+) error {
+	target := strings.TrimSpace(msg.ReplyTarget)
+	if target == "" {
+		return errors.New("reply target missing for approval command")
+	}
+	var reply string
+	switch {
+	case p.approvals == nil:
+		reply = "Error: tool approvals are not configured."
+	case strings.TrimSpace(ref) == "":
+		reply = "Usage: /approve <id> [note] or /reject <id> [reason]"
+	default:
+		decided, err := p.approvals.Decide(ctx, identity.BotID, ref, identity.UserID, approval.DecideRequest{
+			Approve: approve,
+			Note:    note,
+		})
+		switch {
+		case err == nil && decided.Status == approval.StatusApproved:
+			reply = fmt.Sprintf("Approved %s (%s).", decided.ToolName, approval.ShortID(decided.ID))
+		case err == nil:
+			reply = fmt.Sprintf("Rejected %s (%s).", decided.ToolName, approval.ShortID(decided.ID))
+		default:
+			if p.logger != nil && !isApprovalUserError(err) {
+				p.logger.Warn("decide tool approval failed", slog.Any("error", err))
+			}
+			reply = "Error: " + err.Error()
+		}
+	}
+	return sender.Send(ctx, channel.OutboundMessage{
+		Target:  target,
+		Message: channel.Message{Text: reply},
+	})
+}
+
+func isApprovalUserError(err error) bool {
+	return errors.Is(err, approval.ErrNotFound) ||
+		errors.Is(err, approval.ErrAmbiguousID) ||
+		errors.Is(err, approval.ErrNotPending) ||
+		errors.Is(err, approval.ErrNotOwner)
+}
+
 // handleNewSessionCommand resolves the route for the current message and
 // creates a brand-new active session, effectively starting a fresh
 // conversation in the same IM thread/chat.
@@ -6,21 +6,28 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/memohai/memoh/internal/acl"
 	"github.com/memohai/memoh/internal/channel"
 	"github.com/memohai/memoh/internal/channel/identities"
 	"github.com/memohai/memoh/internal/channel/route"
+	"github.com/memohai/memoh/internal/channel/trigger"
 	"github.com/memohai/memoh/internal/conversation"
+	"github.com/memohai/memoh/internal/conversation/flow"
+	"github.com/memohai/memoh/internal/document"
 	"github.com/memohai/memoh/internal/media"
 	messagepkg "github.com/memohai/memoh/internal/message"
+	"github.com/memohai/memoh/internal/netguard"
 	"github.com/memohai/memoh/internal/schedule"
+	"github.com/memohai/memoh/internal/stt"
 )
 
 type fakeChatGateway struct {
@@ -242,6 +249,52 @@ func (*fakeMediaIngestor) AccessPath(asset media.Asset) string {
 	return "/data/media/" + asset.StorageKey
 }
 
+func (f *fakeMediaIngestor) Open(_ context.Context, _, contentHash string) (io.ReadCloser, media.Asset, error) {
+	if len(f.payloads) == 0 {
+		return nil, media.Asset{}, errors.New("asset not found")
+	}
+	payload := f.payloads[len(f.payloads)-1]
+	return io.NopCloser(bytes.NewReader(payload)), media.Asset{ContentHash: contentHash, Mime: f.nextMime}, nil
+}
+
+type fakeSttTranscriber struct {
+	text     string
+	err      error
+	calls    int
+	gotModel string
+	gotAudio stt.AudioInput
+}
+
+func (f *fakeSttTranscriber) Transcribe(_ context.Context, modelID string, audio stt.AudioInput, _ map[string]any) (stt.TranscriptionResult, error) {
+	f.calls++
+	f.gotModel = modelID
+	f.gotAudio = audio
+	if f.err != nil {
+		return stt.TranscriptionResult{}, f.err
+	}
+	return stt.TranscriptionResult{Text: f.text}, nil
+}
+
+type fakeSttModelResolver struct {
+	modelID string
+}
+
+func (f *fakeSttModelResolver) ResolveSttModelID(_ context.Context, _ string) (string, error) {
+	return f.modelID, nil
+}
+
+type fakeDocumentExtractor struct {
+	doc     document.Document
+	gotHash string
+	gotName string
+}
+
+func (f *fakeDocumentExtractor) ExtractAsset(_ context.Context, _, contentHash, name string) (document.Document, error) {
+	f.gotHash = contentHash
+	f.gotName = name
+	return f.doc, nil
+}
+
 type fakeStorageProvider struct {
 	objects map[string][]byte
 }
@@ -740,6 +793,88 @@ func TestChannelInboundProcessorGroupMentionTriggersReply(t *testing.T) {
 	}
 }
 
+type fakeTriggerPolicyStore struct {
+	policy trigger.Policy
+}
+
+func (f *fakeTriggerPolicyStore) Get(_ context.Context, _, _ string) (trigger.Policy, error) {
+	return f.policy, nil
+}
+
+type fakePassiveClassifier struct {
+	reply bool
+	calls int
+}
+
+func (f *fakePassiveClassifier) ClassifyPassiveMessage(_ context.Context, _ flow.ListenClassifyRequest) (bool, error) {
+	f.calls++
+	return f.reply, nil
+}
+
+func TestChannelInboundProcessorTriggerPolicy(t *testing.T) {
+	newMsg := func(id, text string) channel.InboundMessage {
+		return channel.InboundMessage{
+			BotID:        "bot-1",
+			Channel:      channel.ChannelType("feishu"),
+			Message:      channel.Message{ID: id, Text: text},
+			ReplyTarget:  "chat_id:oc_123",
+			Sender:       channel.Identity{SubjectID: "user-1"},
+			Conversation: channel.Conversation{ID: "oc_123", Type: "group"},
+		}
+	}
+	run := func(t *testing.T, policy trigger.Policy, classifier *fakePassiveClassifier, texts ...string) (int, *fakeChatService) {
+		t.Helper()
+		channelIdentitySvc := &fakeChannelIdentityService{channelIdentity: identities.ChannelIdentity{ID: "channelIdentity-7"}}
+		chatSvc := &fakeChatService{resolveResult: route.ResolveConversationResult{ChatID: "chat-7", RouteID: "route-7"}}
+		gateway := &fakeChatGateway{
+			resp: conversation.ChatResponse{
+				Messages: []conversation.ModelMessage{
+					{Role: "assistant", Content: conversation.NewTextContent("AI reply")},
+				},
+			},
+		}
+		processor := NewChannelInboundProcessor(slog.Default(), nil, chatSvc, chatSvc, gateway, channelIdentitySvc, &fakePolicyService{}, nil, "", 0)
+		processor.SetTriggerPolicies(&fakeTriggerPolicyStore{policy: policy}, classifier)
+		sender := &fakeReplySender{}
+		for i, text := range texts {
+			msg := newMsg(fmt.Sprintf("msg-%d", i), text)
+			if err := processor.HandleInbound(context.Background(), channel.ChannelConfig{ID: "cfg-1", BotID: "bot-1"}, msg, sender); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		return len(sender.sent), chatSvc
+	}
+
+	t.Run("keyword triggers until rate limited", func(t *testing.T) {
+		policy := trigger.Policy{RouteID: "route-7", Mode: trigger.ModeKeywords, Keywords: []string{"deploy"}, MaxUnsolicitedPerHour: 1}
+		replies, chatSvc := run(t, policy, nil, "please deploy now", "unrelated chatter", "deploy again")
+		if replies != 1 {
+			t.Fatalf("expected one unsolicited reply, got %d", replies)
+		}
+		if len(chatSvc.persisted) != 2 {
+			t.Fatalf("expected two passive messages, got %d", len(chatSvc.persisted))
+		}
+	})
+
+	t.Run("listen mode asks classifier", func(t *testing.T) {
+		classifier := &fakePassiveClassifier{reply: true}
+		policy := trigger.Policy{RouteID: "route-7", Mode: trigger.ModeListen, MaxUnsolicitedPerHour: 3}
+		replies, _ := run(t, policy, classifier, "how do I reset my password?")
+		if replies != 1 || classifier.calls != 1 {
+			t.Fatalf("expected classifier-approved reply, got replies=%d calls=%d", replies, classifier.calls)
+		}
+	})
+
+	t.Run("mention mode stays passive", func(t *testing.T) {
+		classifier := &fakePassiveClassifier{reply: true}
+		policy := trigger.DefaultPolicy("bot-1", "route-7")
+		replies, _ := run(t, policy, classifier, "please deploy now")
+		if replies != 0 || classifier.calls != 0 {
+			t.Fatalf("expected no reply, got replies=%d calls=%d", replies, classifier.calls)
+		}
+	})
+}
+
 type failingOpenStreamSender struct {
 	err error
 }
@@ -970,6 +1105,185 @@ func TestChannelInboundProcessorIngestsBase64Attachment(t *testing.T) {
 	}
 }
 
+func TestChannelInboundProcessorTranscribesVoiceAttachment(t *testing.T) {
+	channelIdentitySvc := &fakeChannelIdentityService{channelIdentity: identities.ChannelIdentity{ID: "channelIdentity-voice"}}
+	policySvc := &fakePolicyService{}
+	chatSvc := &fakeChatService{resolveResult: route.ResolveConversationResult{ChatID: "chat-voice", RouteID: "route-voice"}}
+	gateway := &fakeChatGateway{
+		resp: conversation.ChatResponse{
+			Messages: []conversation.ModelMessage{
+				{Role: "assistant", Content: conversation.NewTextContent("ok")},
+			},
+		},
+	}
+	processor := NewChannelInboundProcessor(slog.Default(), nil, chatSvc, chatSvc, gateway, channelIdentitySvc, policySvc, nil, "", 0)
+	mediaSvc := &fakeMediaIngestor{nextID: "asset-voice-1", nextMime: "audio/ogg"}
+	processor.SetMediaService(mediaSvc)
+	transcriber := &fakeSttTranscriber{text: " turn on the lights "}
+	processor.SetSttService(transcriber, &fakeSttModelResolver{modelID: "stt-model-1"})
+	sender := &fakeReplySender{}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte("fake-ogg-bytes"))
+	cfg := channel.ChannelConfig{ID: "cfg-voice", BotID: "bot-1", ChannelType: channel.ChannelType("web")}
+	msg := channel.InboundMessage{
+		BotID:   "bot-1",
+		Channel: channel.ChannelType("web"),
+		Message: channel.Message{
+			ID: "msg-voice-1",
+			Attachments: []channel.Attachment{
+				{
+					Type:   channel.AttachmentVoice,
+					Base64: "data:audio/ogg;base64," + encoded,
+				},
+			},
+		},
+		ReplyTarget: "web-target",
+		Sender: channel.Identity{
+			SubjectID:  "web-subject",
+			Attributes: map[string]string{"user_id": "web-user-id"},
+		},
+		Conversation: channel.Conversation{
+			ID:   "web-conv",
+			Type: channel.ConversationTypePrivate,
+		},
+	}
+
+	if err := processor.HandleInbound(context.Background(), cfg, msg, sender); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transcriber.calls != 1 {
+		t.Fatalf("expected one transcription, got %d", transcriber.calls)
+	}
+	if transcriber.gotModel != "stt-model-1" {
+		t.Fatalf("expected configured stt model, got %q", transcriber.gotModel)
+	}
+	if string(transcriber.gotAudio.Data) != "fake-ogg-bytes" {
+		t.Fatalf("unexpected audio payload: %q", string(transcriber.gotAudio.Data))
+	}
+	if got := gateway.gotReq.Query; got != "[Voice message transcript]\nturn on the lights" {
+		t.Fatalf("unexpected query: %q", got)
+	}
+	if len(gateway.gotReq.Attachments) != 1 {
+		t.Fatalf("expected one gateway attachment, got %d", len(gateway.gotReq.Attachments))
+	}
+	if got := gateway.gotReq.Attachments[0].Metadata["transcript"]; got != "turn on the lights" {
+		t.Fatalf("expected transcript in attachment metadata, got %v", got)
+	}
+}
+
+func TestBuildInboundQueryAppendsTranscriptToText(t *testing.T) {
+	t.Parallel()
+	message := channel.Message{
+		Text:        "see voice note",
+		Attachments: []channel.Attachment{{Type: channel.AttachmentVoice}},
+	}
+	attachments := []conversation.ChatAttachment{
+		{Type: "voice", Metadata: map[string]any{"transcript": "hello there"}},
+	}
+	got := buildInboundQuery(message, attachments)
+	want := "see voice note\n[Voice message transcript]\nhello there"
+	if got != want {
+		t.Fatalf("buildInboundQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestChannelInboundProcessorExtractsDocumentAttachment(t *testing.T) {
+	channelIdentitySvc := &fakeChannelIdentityService{channelIdentity: identities.ChannelIdentity{ID: "channelIdentity-doc"}}
+	policySvc := &fakePolicyService{}
+	chatSvc := &fakeChatService{resolveResult: route.ResolveConversationResult{ChatID: "chat-doc", RouteID: "route-doc"}}
+	gateway := &fakeChatGateway{
+		resp: conversation.ChatResponse{
+			Messages: []conversation.ModelMessage{
+				{Role: "assistant", Content: conversation.NewTextContent("ok")},
+			},
+		},
+	}
+	processor := NewChannelInboundProcessor(slog.Default(), nil, chatSvc, chatSvc, gateway, channelIdentitySvc, policySvc, nil, "", 0)
+	processor.SetMediaService(&fakeMediaIngestor{nextID: "asset-doc-1", nextMime: "application/pdf"})
+	extractor := &fakeDocumentExtractor{doc: document.Document{
+		Format: document.FormatPDF,
+		Title:  "Q3 report",
+		Pages:  []document.Page{{Number: 1, Text: "one"}, {Number: 2, Text: "two"}},
+	}}
+	processor.SetDocumentExtractor(extractor)
+	sender := &fakeReplySender{}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte("%PDF-1.4"))
+	cfg := channel.ChannelConfig{ID: "cfg-doc", BotID: "bot-1", ChannelType: channel.ChannelType("web")}
+	msg := channel.InboundMessage{
+		BotID:   "bot-1",
+		Channel: channel.ChannelType("web"),
+		Message: channel.Message{
+			ID:   "msg-doc-1",
+			Text: "summarize this",
+			Attachments: []channel.Attachment{
+				{
+					Type:   channel.AttachmentFile,
+					Base64: "data:application/pdf;base64," + encoded,
+					Name:   "report.pdf",
+				},
+			},
+		},
+		ReplyTarget: "web-target",
+		Sender: channel.Identity{
+			SubjectID:  "web-subject",
+			Attributes: map[string]string{"user_id": "web-user-id"},
+		},
+		Conversation: channel.Conversation{
+			ID:   "web-conv",
+			Type: channel.ConversationTypePrivate,
+		},
+	}
+
+	if err := processor.HandleInbound(context.Background(), cfg, msg, sender); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if extractor.gotHash != "asset-doc-1" || extractor.gotName != "report.pdf" {
+		t.Fatalf("unexpected extraction request: hash=%q name=%q", extractor.gotHash, extractor.gotName)
+	}
+	if len(gateway.gotReq.Attachments) != 1 {
+		t.Fatalf("expected one gateway attachment, got %d", len(gateway.gotReq.Attachments))
+	}
+	att := gateway.gotReq.Attachments[0]
+	if att.Metadata["document_format"] != "pdf" || att.Metadata["document_pages"] != 2 {
+		t.Fatalf("expected document metadata, got %v", att.Metadata)
+	}
+	want := fmt.Sprintf("summarize this\n[Documents: read with the read_document tool]\n- %s (pdf, 2 pages, title \"Q3 report\")", att.Path)
+	if got := gateway.gotReq.Query; got != want {
+		t.Fatalf("unexpected query:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestFormatInteraction(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		interaction channel.Interaction
+		want        string
+	}{
+		{channel.Interaction{Type: channel.InteractionButton, MessageID: "42", Label: "Yes", Value: "yes"}, "[Button clicked on message 42]\nYes (value: yes)"},
+		{channel.Interaction{Type: channel.InteractionButton, MessageID: "42", Label: "Yes", Value: "Yes"}, "[Button clicked on message 42]\nYes"},
+		{channel.Interaction{Type: channel.InteractionButton, Value: "/approve abc"}, "[Button clicked]\n/approve abc"},
+		{channel.Interaction{Type: channel.InteractionPoll, MessageID: "7", Options: []string{"Tea", "Coffee"}}, "[Poll vote on message 7]\nTea\nCoffee"},
+		{channel.Interaction{Type: channel.InteractionPoll, MessageID: "7"}, "[Poll vote retracted on message 7]"},
+	}
+	for _, tc := range cases {
+		if got := formatInteraction(tc.interaction); got != tc.want {
+			t.Errorf("formatInteraction(%+v) = %q, want %q", tc.interaction, got, tc.want)
+		}
+	}
+}
+
+func TestPlatformSourceMessageIDPrefersInteractionTarget(t *testing.T) {
+	msg := channel.InboundMessage{Message: channel.Message{ID: "callback:1"}}
+	if got := platformSourceMessageID(msg); got != "callback:1" {
+		t.Fatalf("expected message id, got %q", got)
+	}
+	msg.Interaction = &channel.Interaction{Type: channel.InteractionButton, MessageID: "42"}
+	if got := platformSourceMessageID(msg); got != "42" {
+		t.Fatalf("expected interaction message id, got %q", got)
+	}
+}
+
 func TestChannelInboundProcessorIngestsQQFileAttachmentKeepsOriginalExtWhenMimeGeneric(t *testing.T) {
 	channelIdentitySvc := &fakeChannelIdentityService{channelIdentity: identities.ChannelIdentity{ID: "channelIdentity-qq-file"}}
 	policySvc := &fakePolicyService{}
@@ -1319,7 +1633,7 @@ func TestDownloadInboundAttachmentURLTooLarge(t *testing.T) {
 	}))
 	defer server.Close()
 
-	_, err := openInboundAttachmentURL(context.Background(), server.URL)
+	_, err := openInboundAttachmentURL(context.Background(), netguard.Policy{AllowPrivate: true}, server.URL)
 	if err == nil {
 		t.Fatalf("expected too-large error")
 	}
@@ -1328,6 +1642,47 @@ func TestDownloadInboundAttachmentURLTooLarge(t *testing.T) {
 	}
 }
 
+func TestDownloadInboundAttachmentURLBlocksPrivateAddress(t *testing.T) {
+	t.Parallel()
+
+	var hit atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { hit.Store(true) }))
+	defer server.Close()
+
+	_, err := openInboundAttachmentURL(context.Background(), netguard.Policy{}, server.URL)
+	if !errors.Is(err, netguard.ErrBlockedAddress) {
+		t.Fatalf("expected ErrBlockedAddress, got %v", err)
+	}
+	if hit.Load() {
+		t.Fatal("blocked download reached the listener")
+	}
+}
+
+type failingFetchPolicies struct{ err error }
+
+func (f failingFetchPolicies) ResolveFetchPolicy(context.Context, string) (netguard.Policy, error) {
+	return netguard.Policy{}, f.err
+}
+
+func TestLoadInboundAttachmentFailsWhenFetchPolicyUnavailable(t *testing.T) {
+	t.Parallel()
+
+	var hit atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { hit.Store(true) }))
+	defer server.Close()
+
+	loadErr := errors.New("settings unavailable")
+	p := &ChannelInboundProcessor{fetchPolicies: failingFetchPolicies{err: loadErr}}
+	_, err := p.loadInboundAttachmentPayload(context.Background(), channel.ChannelConfig{}, channel.InboundMessage{}, "bot-1",
+		channel.Attachment{URL: server.URL, PlatformKey: "file-1"})
+	if !errors.Is(err, loadErr) {
+		t.Fatalf("expected the policy error, got %v", err)
+	}
+	if hit.Load() {
+		t.Fatal("download ran without a fetch policy")
+	}
+}
+
 func TestMapStreamChunkToChannelEvents(t *testing.T) {
 	t.Parallel()
 
@@ -1625,6 +1980,31 @@ func TestIsDataURL(t *testing.T) {
 	}
 }
 
+func TestParseApprovalCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		ok      bool
+		approve bool
+		ref     string
+		note    string
+	}{
+		{"/approve 1a2b3c4d", true, true, "1a2b3c4d", ""},
+		{"/reject@MemohBot 1a2b3c4d too risky", true, false, "1a2b3c4d", "too risky"},
+		{"/approve", true, true, "", ""},
+		{"/new", false, false, "", ""},
+		{"approve 1a2b3c4d", false, false, "", ""},
+	}
+	for _, tt := range tests {
+		approve, ref, note, ok := parseApprovalCommand(tt.input)
+		if ok != tt.ok || approve != tt.approve || ref != tt.ref || note != tt.note {
+			t.Errorf("parseApprovalCommand(%q) = (%v, %q, %q, %v), want (%v, %q, %q, %v)",
+				tt.input, approve, ref, note, ok, tt.approve, tt.ref, tt.note, tt.ok)
+		}
+	}
+}
+
 func TestExtractStorageKey(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
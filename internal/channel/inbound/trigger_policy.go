@@ -0,0 +1,96 @@
+package inbound
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/channel/trigger"
+	"github.com/memohai/memoh/internal/conversation/flow"
+)
+
+// triggerPolicyStore loads the group trigger policy of a route.
+type triggerPolicyStore interface {
+	Get(ctx context.Context, botID, routeID string) (trigger.Policy, error)
+}
+
+// passiveClassifier decides whether the bot should reply to a group message
+// it was not addressed in.
+type passiveClassifier interface {
+	ClassifyPassiveMessage(ctx context.Context, req flow.ListenClassifyRequest) (bool, error)
+}
+
+// SetTriggerPolicies configures per-route trigger policies that let the bot
+// reply in groups without being mentioned. The classifier is used by listen
+// mode and may be nil.
+func (p *ChannelInboundProcessor) SetTriggerPolicies(store triggerPolicyStore, classifier passiveClassifier) {
+	if p == nil {
+		return
+	}
+	p.triggerPolicies = store
+	p.passiveClassifier = classifier
+	if p.unsolicited == nil {
+		p.unsolicited = trigger.NewLimiter()
+	}
+}
+
+// matchTriggerPolicy reports whether the route's trigger policy wants an
+// unsolicited reply to a group message the bot was not addressed in, and
+// records the reply against the route's rate limit when it does.
+func (p *ChannelInboundProcessor) matchTriggerPolicy(ctx context.Context, ident InboundIdentity, msg channel.InboundMessage, routeID, text string) bool {
+	if p.triggerPolicies == nil || p.unsolicited == nil || strings.TrimSpace(routeID) == "" {
+		return false
+	}
+	policy, err := p.triggerPolicies.Get(ctx, ident.BotID, routeID)
+	if err != nil {
+		if p.logger != nil {
+			p.logger.Warn("load trigger policy failed", slog.String("route_id", routeID), slog.Any("error", err))
+		}
+		return false
+	}
+	if policy.Mode != trigger.ModeKeywords && policy.Mode != trigger.ModeListen {
+		return false
+	}
+	text = strings.TrimSpace(rawTextForCommand(msg, text))
+	if text == "" {
+		return false
+	}
+	reason := ""
+	if matched, ok := policy.MatchText(text); ok {
+		reason = "keyword:" + matched
+	} else if policy.Mode == trigger.ModeListen && p.passiveClassifier != nil && p.unsolicited.Allow(policy) {
+		// Check the limit first so a busy group does not cost a model call
+		// per message once the route is out of unsolicited replies.
+		reply, err := p.passiveClassifier.ClassifyPassiveMessage(ctx, flow.ListenClassifyRequest{
+			BotID:        ident.BotID,
+			ModelID:      policy.ClassifierModelID,
+			Instructions: policy.ListenInstructions,
+			SenderName:   ident.DisplayName,
+			Text:         text,
+		})
+		if err != nil {
+			if p.logger != nil {
+				p.logger.Warn("classify passive message failed", slog.String("route_id", routeID), slog.Any("error", err))
+			}
+			return false
+		}
+		if reply {
+			reason = "classifier"
+		}
+	}
+	if reason == "" || !p.unsolicited.Take(policy) {
+		return false
+	}
+	if p.logger != nil {
+		p.logger.Info(
+			"inbound triggering unsolicited reply",
+			slog.String("channel", msg.Channel.String()),
+			slog.String("bot_id", strings.TrimSpace(ident.BotID)),
+			slog.String("route_id", strings.TrimSpace(routeID)),
+			slog.String("mode", policy.Mode),
+			slog.String("reason", reason),
+		)
+	}
+	return true
+}
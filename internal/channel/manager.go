@@ -71,6 +71,7 @@ type Manager struct {
 	inboundOnce    sync.Once
 	inboundCtx     context.Context
 	inboundCancel  context.CancelFunc
+	debouncer      *inboundDebouncer
 	mu             sync.Mutex
 	refreshMu      sync.Mutex
 	connections    map[string]*connectionEntry
@@ -85,7 +86,7 @@ func NewManager(log *slog.Logger, registry *Registry, service ManagerStore, proc
 	if registry == nil {
 		registry = NewRegistry()
 	}
-	return &Manager{
+	m := &Manager{
 		registry:        registry,
 		service:         service,
 		processor:       processor,
@@ -97,6 +98,8 @@ func NewManager(log *slog.Logger, registry *Registry, service ManagerStore, proc
 		inboundQueue:    make(chan inboundTask, 256),
 		inboundWorkers:  4,
 	}
+	m.debouncer = newInboundDebouncer(m.logger, m.dispatchDebounced)
+	return m
 }
 
 // Registry returns the adapter registry used by this manager.
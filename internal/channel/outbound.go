@@ -220,9 +220,9 @@ func buildOutboundMessages(msg OutboundMessage, policy OutboundPolicy) ([]Outbou
 			if chunk == "" {
 				continue
 			}
-			actions := base.Actions
+			actions, poll := base.Actions, base.Poll
 			if len(chunks) > 1 && idx < len(chunks)-1 {
-				actions = nil
+				actions, poll = nil, nil
 			}
 			item := OutboundMessage{
 				Target: msg.Target,
@@ -233,6 +233,7 @@ func buildOutboundMessages(msg OutboundMessage, policy OutboundPolicy) ([]Outbou
 					Parts:       base.Parts,
 					Attachments: nil,
 					Actions:     actions,
+					Poll:        poll,
 					Thread:      base.Thread,
 					Reply:       base.Reply,
 					Metadata:    base.Metadata,
@@ -251,6 +252,7 @@ func buildOutboundMessages(msg OutboundMessage, policy OutboundPolicy) ([]Outbou
 		media.Text = ""
 		media.Parts = nil
 		media.Actions = nil
+		media.Poll = nil
 		media.Attachments = attachments
 		attachmentMessages = append(attachmentMessages, OutboundMessage{Target: msg.Target, Message: media})
 	}
@@ -328,6 +330,9 @@ func validateMessageCapabilities(registry *Registry, channelType ChannelType, ms
 	if len(msg.Actions) > 0 && !caps.Buttons {
 		return errors.New("channel does not support actions")
 	}
+	if msg.Poll != nil && !caps.Polls {
+		return errors.New("channel does not support polls")
+	}
 	if msg.Thread != nil && !caps.Threads {
 		return errors.New("channel does not support threads")
 	}
@@ -0,0 +1,65 @@
+package trigger
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter tracks unsolicited replies per route in memory. Counts reset when
+// the process restarts, which only makes the bot briefly more permissive.
+type Limiter struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	routes map[string][]time.Time
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{
+		now:    time.Now,
+		routes: map[string][]time.Time{},
+	}
+}
+
+// Allow reports whether the policy permits another unsolicited reply on its
+// route now, without recording one.
+func (l *Limiter) Allow(p Policy) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.allowLocked(p, l.now())
+}
+
+// Take records an unsolicited reply if the policy permits one and reports
+// whether it did.
+func (l *Limiter) Take(p Policy) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.allowLocked(p, now) {
+		return false
+	}
+	l.routes[p.RouteID] = append(l.routes[p.RouteID], now)
+	return true
+}
+
+func (l *Limiter) allowLocked(p Policy, now time.Time) bool {
+	if p.MaxUnsolicitedPerHour <= 0 {
+		return false
+	}
+	cutoff := now.Add(-time.Hour)
+	recent := l.routes[p.RouteID][:0]
+	for _, at := range l.routes[p.RouteID] {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	if len(recent) == 0 {
+		delete(l.routes, p.RouteID)
+		return true
+	}
+	l.routes[p.RouteID] = recent
+	if len(recent) >= p.MaxUnsolicitedPerHour {
+		return false
+	}
+	cooldown := time.Duration(p.CooldownSeconds) * time.Second
+	return now.Sub(recent[len(recent)-1]) >= cooldown
+}
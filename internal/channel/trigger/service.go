@@ -0,0 +1,286 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/db"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+// Service stores per-route trigger policies.
+type Service struct {
+	queries *sqlc.Queries
+	logger  *slog.Logger
+}
+
+func NewService(log *slog.Logger, queries *sqlc.Queries) *Service {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Service{
+		queries: queries,
+		logger:  log.With(slog.String("service", "channel/trigger")),
+	}
+}
+
+// Get returns the route's policy, or the default policy when none is stored.
+func (s *Service) Get(ctx context.Context, botID, routeID string) (Policy, error) {
+	pgRouteID, err := db.ParseUUID(routeID)
+	if err != nil {
+		return Policy{}, err
+	}
+	row, err := s.queries.GetRouteTriggerPolicy(ctx, pgRouteID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if err := s.requireRoute(ctx, botID, pgRouteID); err != nil {
+				return Policy{}, err
+			}
+			return DefaultPolicy(botID, routeID), nil
+		}
+		return Policy{}, fmt.Errorf("get trigger policy: %w", err)
+	}
+	if row.BotID.String() != botID {
+		return Policy{}, ErrRouteMismatch
+	}
+	return toPolicy(row), nil
+}
+
+// List returns the stored policies of a bot with their route details.
+func (s *Service) List(ctx context.Context, botID string) ([]Policy, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListRouteTriggerPoliciesByBot(ctx, pgBotID)
+	if err != nil {
+		return nil, fmt.Errorf("list trigger policies: %w", err)
+	}
+	items := make([]Policy, 0, len(rows))
+	for _, row := range rows {
+		policy := toPolicy(sqlc.BotRouteTriggerPolicy{
+			RouteID:               row.RouteID,
+			BotID:                 row.BotID,
+			Mode:                  row.Mode,
+			Keywords:              row.Keywords,
+			Patterns:              row.Patterns,
+			ListenInstructions:    row.ListenInstructions,
+			ClassifierModelID:     row.ClassifierModelID,
+			MaxUnsolicitedPerHour: row.MaxUnsolicitedPerHour,
+			CooldownSeconds:       row.CooldownSeconds,
+			UpdatedAt:             row.UpdatedAt,
+		})
+		policy.Platform = row.Platform
+		policy.ConversationID = row.ConversationID
+		policy.ConversationType = db.TextToString(row.ConversationType)
+		items = append(items, policy)
+	}
+	return items, nil
+}
+
+// Upsert validates and stores the policy of a route owned by the bot.
+func (s *Service) Upsert(ctx context.Context, botID, routeID string, req UpsertRequest) (Policy, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return Policy{}, err
+	}
+	pgRouteID, err := db.ParseUUID(routeID)
+	if err != nil {
+		return Policy{}, err
+	}
+	if err := s.requireRoute(ctx, botID, pgRouteID); err != nil {
+		return Policy{}, err
+	}
+	policy, err := normalizeRequest(req)
+	if err != nil {
+		return Policy{}, err
+	}
+	var pgModelID pgtype.UUID
+	if policy.ClassifierModelID != "" {
+		if pgModelID, err = db.ParseUUID(policy.ClassifierModelID); err != nil {
+			return Policy{}, err
+		}
+	}
+	row, err := s.queries.UpsertRouteTriggerPolicy(ctx, sqlc.UpsertRouteTriggerPolicyParams{
+		RouteID:               pgRouteID,
+		BotID:                 pgBotID,
+		Mode:                  policy.Mode,
+		Keywords:              policy.Keywords,
+		Patterns:              policy.Patterns,
+		ListenInstructions:    policy.ListenInstructions,
+		ClassifierModelID:     pgModelID,
+		MaxUnsolicitedPerHour: int32(policy.MaxUnsolicitedPerHour), //nolint:gosec // bounded by normalizeRequest
+		CooldownSeconds:       int32(policy.CooldownSeconds),       //nolint:gosec // bounded by normalizeRequest
+	})
+	if err != nil {
+		return Policy{}, fmt.Errorf("upsert trigger policy: %w", err)
+	}
+	return toPolicy(row), nil
+}
+
+// Delete removes a route's policy, restoring mention-only triggering.
+func (s *Service) Delete(ctx context.Context, botID, routeID string) error {
+	if _, err := s.Get(ctx, botID, routeID); err != nil {
+		return err
+	}
+	pgRouteID, err := db.ParseUUID(routeID)
+	if err != nil {
+		return err
+	}
+	if err := s.queries.DeleteRouteTriggerPolicy(ctx, pgRouteID); err != nil {
+		return fmt.Errorf("delete trigger policy: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) requireRoute(ctx context.Context, botID string, routeID pgtype.UUID) error {
+	route, err := s.queries.GetChatRouteByID(ctx, routeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRouteNotFound
+		}
+		return fmt.Errorf("get route: %w", err)
+	}
+	if route.BotID.String() != botID {
+		return ErrRouteMismatch
+	}
+	return nil
+}
+
+func normalizeRequest(req UpsertRequest) (Policy, error) {
+	policy := Policy{
+		Mode:                  strings.ToLower(strings.TrimSpace(req.Mode)),
+		Keywords:              compactStrings(req.Keywords),
+		Patterns:              compactStrings(req.Patterns),
+		ListenInstructions:    strings.TrimSpace(req.ListenInstructions),
+		ClassifierModelID:     strings.TrimSpace(req.ClassifierModelID),
+		MaxUnsolicitedPerHour: DefaultMaxUnsolicitedPerHour,
+		CooldownSeconds:       DefaultCooldownSeconds,
+	}
+	if policy.Mode == "" {
+		policy.Mode = ModeMention
+	}
+	switch policy.Mode {
+	case ModeMention, ModeListen:
+	case ModeKeywords:
+		if len(policy.Keywords) == 0 && len(policy.Patterns) == 0 {
+			return Policy{}, ErrNoTriggers
+		}
+	default:
+		return Policy{}, ErrInvalidMode
+	}
+	if len(policy.Keywords) > maxKeywords || len(policy.Patterns) > maxPatterns {
+		return Policy{}, ErrTooManyTriggers
+	}
+	for _, pattern := range policy.Patterns {
+		if len(pattern) > maxPatternLength {
+			return Policy{}, fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidPattern, pattern, maxPatternLength)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return Policy{}, fmt.Errorf("%w: %v", ErrInvalidPattern, err)
+		}
+	}
+	if len(policy.ListenInstructions) > maxInstructionsLen {
+		return Policy{}, ErrInstructionsTooLong
+	}
+	if req.MaxUnsolicitedPerHour != nil {
+		policy.MaxUnsolicitedPerHour = *req.MaxUnsolicitedPerHour
+	}
+	if req.CooldownSeconds != nil {
+		policy.CooldownSeconds = *req.CooldownSeconds
+	}
+	if policy.MaxUnsolicitedPerHour < 0 || policy.CooldownSeconds < 0 ||
+		policy.MaxUnsolicitedPerHour > 3600 || policy.CooldownSeconds > 86400 {
+		return Policy{}, ErrInvalidLimit
+	}
+	return policy, nil
+}
+
+// MatchText reports the first keyword or pattern found in text. Keywords
+// are case-insensitive and must match whole words unless they start or end
+// with a non-word character.
+func (p Policy) MatchText(text string) (string, bool) {
+	if strings.TrimSpace(text) == "" {
+		return "", false
+	}
+	for _, keyword := range p.Keywords {
+		if keywordPattern(keyword).MatchString(text) {
+			return keyword, true
+		}
+	}
+	for _, pattern := range p.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(text) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+func keywordPattern(keyword string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(keyword)
+	runes := []rune(keyword)
+	if isASCIIWordRune(runes[0]) {
+		expr = `\b` + expr
+	}
+	if isASCIIWordRune(runes[len(runes)-1]) {
+		expr += `\b`
+	}
+	return regexp.MustCompile(`(?i)` + expr)
+}
+
+func isASCIIWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+func compactStrings(values []string) []string {
+	out := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	return out
+}
+
+func toPolicy(row sqlc.BotRouteTriggerPolicy) Policy {
+	policy := Policy{
+		RouteID:               row.RouteID.String(),
+		BotID:                 row.BotID.String(),
+		Mode:                  row.Mode,
+		Keywords:              row.Keywords,
+		Patterns:              row.Patterns,
+		ListenInstructions:    row.ListenInstructions,
+		MaxUnsolicitedPerHour: int(row.MaxUnsolicitedPerHour),
+		CooldownSeconds:       int(row.CooldownSeconds),
+		UpdatedAt:             row.UpdatedAt.Time,
+	}
+	if row.ClassifierModelID.Valid {
+		policy.ClassifierModelID = row.ClassifierModelID.String()
+	}
+	if policy.Keywords == nil {
+		policy.Keywords = []string{}
+	}
+	if policy.Patterns == nil {
+		policy.Patterns = []string{}
+	}
+	return policy
+}
@@ -0,0 +1,104 @@
+package trigger
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNormalizeRequest(t *testing.T) {
+	zero := 0
+	negative := -1
+	tests := []struct {
+		name    string
+		req     UpsertRequest
+		wantErr error
+	}{
+		{name: "default mode", req: UpsertRequest{}},
+		{name: "listen", req: UpsertRequest{Mode: "Listen"}},
+		{name: "keywords", req: UpsertRequest{Mode: ModeKeywords, Keywords: []string{" deploy ", "deploy"}}},
+		{name: "keywords without triggers", req: UpsertRequest{Mode: ModeKeywords, Keywords: []string{" "}}, wantErr: ErrNoTriggers},
+		{name: "unknown mode", req: UpsertRequest{Mode: "always"}, wantErr: ErrInvalidMode},
+		{name: "bad pattern", req: UpsertRequest{Mode: ModeKeywords, Patterns: []string{"("}}, wantErr: ErrInvalidPattern},
+		{name: "negative limit", req: UpsertRequest{CooldownSeconds: &negative}, wantErr: ErrInvalidLimit},
+		{name: "zero limit", req: UpsertRequest{MaxUnsolicitedPerHour: &zero}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := normalizeRequest(tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("normalizeRequest() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && policy.Mode == "" {
+				t.Fatal("expected mode to be set")
+			}
+		})
+	}
+
+	policy, err := normalizeRequest(UpsertRequest{Mode: ModeKeywords, Keywords: []string{" deploy ", "deploy"}})
+	if err != nil {
+		t.Fatalf("normalizeRequest() error = %v", err)
+	}
+	if len(policy.Keywords) != 1 || policy.Keywords[0] != "deploy" {
+		t.Fatalf("keywords = %v, want [deploy]", policy.Keywords)
+	}
+	if policy.MaxUnsolicitedPerHour != DefaultMaxUnsolicitedPerHour || policy.CooldownSeconds != DefaultCooldownSeconds {
+		t.Fatalf("unexpected default limits: %+v", policy)
+	}
+}
+
+func TestPolicyMatchText(t *testing.T) {
+	policy := Policy{
+		Keywords: []string{"deploy", "c++", "部署"},
+		Patterns: []string{`(?i)\bv\d+\.\d+\b`},
+	}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{text: "Can someone DEPLOY this?", want: true},
+		{text: "the redeployment failed", want: false},
+		{text: "anyone know c++?", want: true},
+		{text: "请帮忙部署一下", want: true},
+		{text: "is V2.3 out", want: true},
+		{text: "nothing here", want: false},
+		{text: "  ", want: false},
+	}
+	for _, tt := range tests {
+		if _, got := policy.MatchText(tt.text); got != tt.want {
+			t.Errorf("MatchText(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter()
+	limiter.now = func() time.Time { return now }
+	policy := Policy{RouteID: "r1", MaxUnsolicitedPerHour: 2, CooldownSeconds: 60}
+
+	if !limiter.Take(policy) {
+		t.Fatal("expected first reply to be allowed")
+	}
+	if limiter.Allow(policy) {
+		t.Fatal("expected cooldown to block")
+	}
+	now = now.Add(time.Minute)
+	if !limiter.Take(policy) {
+		t.Fatal("expected reply after cooldown")
+	}
+	now = now.Add(10 * time.Minute)
+	if limiter.Take(policy) {
+		t.Fatal("expected hourly limit to block")
+	}
+	if !limiter.Allow(Policy{RouteID: "r2", MaxUnsolicitedPerHour: 1}) {
+		t.Fatal("expected other routes to be independent")
+	}
+	now = now.Add(time.Hour)
+	if !limiter.Allow(policy) {
+		t.Fatal("expected window to expire")
+	}
+	if limiter.Allow(Policy{RouteID: "r3"}) {
+		t.Fatal("expected zero hourly limit to disable replies")
+	}
+}
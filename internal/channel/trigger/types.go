@@ -0,0 +1,87 @@
+package trigger
+
+import (
+	"errors"
+	"time"
+)
+
+// Trigger modes. Direct messages, mentions and replies to the bot always
+// trigger; the mode decides what else does in group conversations.
+const (
+	// ModeMention replies only when mentioned or replied to.
+	ModeMention = "mention"
+	// ModeKeywords also replies when a keyword or pattern matches.
+	ModeKeywords = "keywords"
+	// ModeListen also asks a classifier model whether to reply to messages
+	// that match no keyword.
+	ModeListen = "listen"
+)
+
+const (
+	DefaultMaxUnsolicitedPerHour = 3
+	DefaultCooldownSeconds       = 300
+
+	maxKeywords        = 50
+	maxPatterns        = 20
+	maxPatternLength   = 200
+	maxInstructionsLen = 2000
+)
+
+var (
+	ErrInvalidMode         = errors.New("mode must be one of mention, keywords, listen")
+	ErrNoTriggers          = errors.New("keywords mode needs at least one keyword or pattern")
+	ErrInvalidPattern      = errors.New("invalid pattern")
+	ErrInvalidLimit        = errors.New("rate limits are out of range")
+	ErrTooManyTriggers     = errors.New("too many keywords or patterns")
+	ErrInstructionsTooLong = errors.New("listen instructions are too long")
+	ErrRouteNotFound       = errors.New("route not found")
+	ErrRouteMismatch       = errors.New("route does not belong to bot")
+)
+
+// Policy is the group trigger policy of one route. Replies it causes are
+// unsolicited and limited per route: at most MaxUnsolicitedPerHour in any
+// hour and no closer together than CooldownSeconds. A zero hourly limit
+// disables unsolicited replies.
+type Policy struct {
+	RouteID               string    `json:"route_id"`
+	BotID                 string    `json:"bot_id"`
+	Mode                  string    `json:"mode"`
+	Keywords              []string  `json:"keywords"`
+	Patterns              []string  `json:"patterns"`
+	ListenInstructions    string    `json:"listen_instructions"`
+	ClassifierModelID     string    `json:"classifier_model_id,omitempty"`
+	MaxUnsolicitedPerHour int       `json:"max_unsolicited_per_hour"`
+	CooldownSeconds       int       `json:"cooldown_seconds"`
+	Platform              string    `json:"platform,omitempty"`
+	ConversationID        string    `json:"conversation_id,omitempty"`
+	ConversationType      string    `json:"conversation_type,omitempty"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// UpsertRequest sets a route's trigger policy. Omitted limits use the
+// defaults. Without a classifier model, listen mode uses the bot's title
+// model.
+type UpsertRequest struct {
+	Mode                  string   `json:"mode"`
+	Keywords              []string `json:"keywords,omitempty"`
+	Patterns              []string `json:"patterns,omitempty"`
+	ListenInstructions    string   `json:"listen_instructions,omitempty"`
+	ClassifierModelID     string   `json:"classifier_model_id,omitempty"`
+	MaxUnsolicitedPerHour *int     `json:"max_unsolicited_per_hour,omitempty"`
+	CooldownSeconds       *int     `json:"cooldown_seconds,omitempty"`
+}
+
+type ListResponse struct {
+	Items []Policy `json:"items"`
+}
+
+// DefaultPolicy is the policy of routes without a stored one.
+func DefaultPolicy(botID, routeID string) Policy {
+	return Policy{
+		RouteID:               routeID,
+		BotID:                 botID,
+		Mode:                  ModeMention,
+		MaxUnsolicitedPerHour: DefaultMaxUnsolicitedPerHour,
+		CooldownSeconds:       DefaultCooldownSeconds,
+	}
+}
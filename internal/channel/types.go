@@ -76,7 +76,10 @@ type InboundMessage struct {
 	Conversation Conversation
 	ReceivedAt   time.Time
 	Source       string
-	Metadata     map[string]any
+	// Interaction is set when the message is a button click or poll vote
+	// on an earlier bot message.
+	Interaction *Interaction
+	Metadata    map[string]any
 }
 
 // RoutingKey returns a stable identifier used for reply routing.
@@ -285,6 +288,32 @@ type Action struct {
 	URL   string `json:"url,omitempty"`
 }
 
+// Poll describes a native poll question with its answer options.
+type Poll struct {
+	Question        string   `json:"question"`
+	Options         []string `json:"options"`
+	MultipleAnswers bool     `json:"multiple_answers,omitempty"`
+}
+
+// Interaction types reported on inbound messages.
+const (
+	InteractionButton = "button"
+	InteractionPoll   = "poll"
+)
+
+// Interaction is a user's response to an interactive message the bot sent:
+// a button click or a poll vote.
+type Interaction struct {
+	Type string `json:"type"`
+	// MessageID is the platform ID of the message carrying the buttons or poll.
+	MessageID string `json:"message_id,omitempty"`
+	// Value is the clicked button's value.
+	Value string `json:"value,omitempty"`
+	Label string `json:"label,omitempty"`
+	// Options are the chosen poll options; empty when a vote is retracted.
+	Options []string `json:"options,omitempty"`
+}
+
 // ThreadRef references a conversation thread by ID.
 type ThreadRef struct {
 	ID string `json:"id"`
@@ -304,6 +333,7 @@ type Message struct {
 	Parts       []MessagePart  `json:"parts,omitempty"`
 	Attachments []Attachment   `json:"attachments,omitempty"`
 	Actions     []Action       `json:"actions,omitempty"`
+	Poll        *Poll          `json:"poll,omitempty"`
 	Thread      *ThreadRef     `json:"thread,omitempty"`
 	Reply       *ReplyRef      `json:"reply,omitempty"`
 	Metadata    map[string]any `json:"metadata,omitempty"`
@@ -314,7 +344,8 @@ func (m Message) IsEmpty() bool {
 	return strings.TrimSpace(m.Text) == "" &&
 		len(m.Parts) == 0 &&
 		len(m.Attachments) == 0 &&
-		len(m.Actions) == 0
+		len(m.Actions) == 0 &&
+		m.Poll == nil
 }
 
 // PlainText extracts the plain text representation of the message.
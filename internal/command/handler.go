@@ -112,7 +112,9 @@ func NewHandler(
 // the regular resource-group dispatch (e.g. in the channel inbound
 // processor which has the required routing context).
 var topLevelCommands = map[string]string{
-	"new": "Start a new conversation (resets session context)",
+	"new":     "Start a new conversation (resets session context)",
+	"approve": "Approve a pending tool call: /approve <id> [note]",
+	"reject":  "Reject a pending tool call: /reject <id> [reason]",
 }
 
 // IsCommand reports whether the text contains a slash command.
@@ -52,7 +52,7 @@ func (h *Handler) buildUsageGroup() *CommandGroup {
 				switch r.SessionType {
 				case "heartbeat":
 					buckets[1].rows = append(buckets[1].rows, r)
-				case "schedule":
+				case "schedule", "trigger":
 					buckets[2].rows = append(buckets[2].rows, r)
 				default:
 					buckets[0].rows = append(buckets[0].rows, r)
@@ -103,25 +103,50 @@ func (s *Service) doCompaction(ctx context.Context, logID pgtype.UUID, sessionUU
 
 	userPrompt := buildUserPrompt(priorSummaries, entries)
 
-	model := agent.CreateModel(agent.ModelConfig{
+	candidates := append([]TriggerModel{{
+		ModelID:    cfg.ModelID,
 		ClientType: cfg.ClientType,
-		BaseURL:    cfg.BaseURL,
 		APIKey:     cfg.APIKey,
-		ModelID:    cfg.ModelID,
-	})
+		BaseURL:    cfg.BaseURL,
+	}}, cfg.Fallbacks...)
 
-	result, err := sdk.GenerateTextResult(ctx,
-		sdk.WithModel(model),
-		sdk.WithSystem(systemPrompt),
-		sdk.WithMessages([]sdk.Message{sdk.UserMessage(userPrompt)}),
+	var (
+		result  *sdk.GenerateResult
+		usedID  string
+		lastErr error
 	)
-	if err != nil {
-		return err
+	for i, candidate := range candidates {
+		model := agent.CreateModel(agent.ModelConfig{
+			ClientType: candidate.ClientType,
+			BaseURL:    candidate.BaseURL,
+			APIKey:     candidate.APIKey,
+			ModelID:    candidate.ModelID,
+		})
+		result, lastErr = sdk.GenerateTextResult(ctx,
+			sdk.WithModel(model),
+			sdk.WithSystem(systemPrompt),
+			sdk.WithMessages([]sdk.Message{sdk.UserMessage(userPrompt)}),
+		)
+		if lastErr == nil {
+			usedID = candidate.ModelID
+			break
+		}
+		if ctx.Err() != nil || i == len(candidates)-1 {
+			return lastErr
+		}
+		s.logger.Warn("compaction model failed, trying fallback",
+			slog.String("bot_id", cfg.BotID),
+			slog.String("model_id", candidate.ModelID),
+			slog.String("error", lastErr.Error()),
+		)
 	}
 
+	if cfg.OnUsage != nil {
+		cfg.OnUsage(ctx, usedID, result.Usage)
+	}
 	usageJSON, _ := json.Marshal(result.Usage)
 
-	modelUUID := db.ParseUUIDOrEmpty(cfg.ModelID)
+	modelUUID := db.ParseUUIDOrEmpty(usedID)
 
 	if err := s.queries.MarkMessagesCompacted(ctx, sqlc.MarkMessagesCompactedParams{
 		CompactID: logID,
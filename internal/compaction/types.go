@@ -1,6 +1,11 @@
 package compaction
 
-import "time"
+import (
+	"context"
+	"time"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+)
 
 // Log represents a compaction log entry.
 type Log struct {
@@ -30,4 +35,17 @@ type TriggerConfig struct {
 	ClientType string
 	APIKey     string //nolint:gosec // runtime credential, not a hardcoded secret
 	BaseURL    string
+	// Fallbacks are tried in order when the primary model fails.
+	Fallbacks []TriggerModel
+	// OnUsage, when set, receives the token usage of a successful
+	// compaction together with the ModelID of the model that produced it.
+	OnUsage func(ctx context.Context, modelID string, usage sdk.Usage)
+}
+
+// TriggerModel describes a fallback model used for compaction.
+type TriggerModel struct {
+	ModelID    string
+	ClientType string
+	APIKey     string //nolint:gosec // runtime credential, not a hardcoded secret
+	BaseURL    string
 }
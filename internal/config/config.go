@@ -29,6 +29,7 @@ const (
 	DefaultQdrantCollection = "memory"
 	DefaultRuntimeDir       = "/opt/memoh/runtime"
 	DefaultBaseImage        = "debian:bookworm-slim"
+	DefaultOpenAIToolCalls  = OpenAIToolCallsReasoning
 )
 
 type Config struct {
@@ -43,6 +44,7 @@ type Config struct {
 	Sparse         SparseConfig         `toml:"sparse"`
 	BrowserGateway BrowserGatewayConfig `toml:"browser_gateway"`
 	Registry       RegistryConfig       `toml:"registry"`
+	OpenAICompat   OpenAICompatConfig   `toml:"openai_compat"`
 }
 
 type LogConfig struct {
@@ -84,6 +86,12 @@ type WorkspaceConfig struct {
 	CNIBinaryDir string `toml:"cni_bin_dir"`
 	CNIConfigDir string `toml:"cni_conf_dir"`
 	RuntimeDir   string `toml:"runtime_dir"`
+
+	// Default resource profile for bots without their own. Zero is unlimited.
+	CPUShares     int `toml:"cpu_shares"`
+	CPUMillicores int `toml:"cpu_millicores"`
+	MemoryMB      int `toml:"memory_mb"`
+	PidsLimit     int `toml:"pids_limit"`
 }
 
 // ImageRef returns the fully qualified image reference for the base image,
@@ -171,6 +179,19 @@ func (c BrowserGatewayConfig) BaseURL() string {
 	return "http://" + host + ":" + strconv.Itoa(port)
 }
 
+// OpenAI-compatible tool call rendering modes.
+const (
+	OpenAIToolCallsReasoning = "reasoning"
+	OpenAIToolCallsOmit      = "omit"
+)
+
+// OpenAICompatConfig configures the OpenAI-compatible /v1 endpoints.
+type OpenAICompatConfig struct {
+	// ToolCalls controls how the bot's tool calls appear in completions:
+	// "reasoning" surfaces them as reasoning content, "omit" hides them.
+	ToolCalls string `toml:"tool_calls"`
+}
+
 func Load(path string) (Config, error) {
 	cfg := Config{
 		Log: LogConfig{
@@ -209,6 +230,9 @@ func Load(path string) (Config, error) {
 			Host: "127.0.0.1",
 			Port: 8083,
 		},
+		OpenAICompat: OpenAICompatConfig{
+			ToolCalls: DefaultOpenAIToolCalls,
+		},
 	}
 
 	if path == "" {
@@ -0,0 +1,119 @@
+package containerd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// applyEgressRules replaces the filter table inside the task's network
+// namespace with the rules for policy. The rules live in the container's own
+// netns, which the container cannot modify without CAP_NET_ADMIN.
+func applyEgressRules(ctx context.Context, pid uint32, policy EgressPolicy) error {
+	if pid == 0 {
+		return ErrInvalidArgument
+	}
+	procDir := filepath.Join("/proc", strconv.FormatUint(uint64(pid), 10))
+	netnsPath := filepath.Join(procDir, "ns", "net")
+	if _, err := os.Stat(netnsPath); err != nil {
+		return fmt.Errorf("netns not found: %s: %w", netnsPath, err)
+	}
+	var nameservers []netip.Addr
+	if policy.Mode == EgressModeAllowlist {
+		// Best effort: without a readable resolv.conf the container simply
+		// cannot resolve names, which does not widen the policy.
+		if data, err := os.ReadFile(filepath.Join(procDir, "root", "etc", "resolv.conf")); err == nil {
+			nameservers = parseNameservers(data)
+		}
+	}
+	for _, family := range []struct {
+		restore string
+		v6      bool
+	}{{"iptables-restore", false}, {"ip6tables-restore", true}} {
+		rules := egressRuleset(policy, nameservers, family.v6)
+		//nolint:gosec // binaries are fixed; netnsPath is derived from a numeric pid
+		cmd := exec.CommandContext(ctx, "nsenter", "--net="+netnsPath, family.restore)
+		cmd.Stdin = strings.NewReader(rules)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			// Nothing to lift when the host has no iptables and the policy
+			// is unrestricted.
+			if !policy.Restricted() && errors.Is(err, exec.ErrNotFound) {
+				return nil
+			}
+			return fmt.Errorf("%s: %w: %s", family.restore, err, strings.TrimSpace(stderr.String()))
+		}
+	}
+	return nil
+}
+
+// egressRuleset renders an iptables-restore ruleset for one address family.
+// Loopback and replies on established connections are always allowed; in
+// allowlist mode DNS to the container's nameservers and the allowed prefixes
+// are accepted too, and everything else is rejected.
+func egressRuleset(policy EgressPolicy, nameservers []netip.Addr, v6 bool) string {
+	var b strings.Builder
+	b.WriteString("*filter\n:INPUT ACCEPT [0:0]\n:FORWARD ACCEPT [0:0]\n")
+	if !policy.Restricted() {
+		b.WriteString(":OUTPUT ACCEPT [0:0]\nCOMMIT\n")
+		return b.String()
+	}
+	b.WriteString(":OUTPUT DROP [0:0]\n")
+	b.WriteString("-A OUTPUT -o lo -j ACCEPT\n")
+	b.WriteString("-A OUTPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT\n")
+	if policy.Mode == EgressModeAllowlist {
+		for _, ns := range nameservers {
+			if ns.Is6() != v6 || ns.IsLoopback() {
+				continue
+			}
+			prefix := netip.PrefixFrom(ns, ns.BitLen())
+			fmt.Fprintf(&b, "-A OUTPUT -d %s -p udp --dport 53 -j ACCEPT\n", prefix)
+			fmt.Fprintf(&b, "-A OUTPUT -d %s -p tcp --dport 53 -j ACCEPT\n", prefix)
+		}
+		for _, prefix := range policy.Allow {
+			if prefix.Addr().Is6() != v6 {
+				continue
+			}
+			fmt.Fprintf(&b, "-A OUTPUT -d %s -j ACCEPT\n", prefix.Masked())
+		}
+	}
+	b.WriteString("-A OUTPUT -p tcp -j REJECT --reject-with tcp-reset\n")
+	b.WriteString("-A OUTPUT -j REJECT\n")
+	b.WriteString("COMMIT\n")
+	return b.String()
+}
+
+func parseNameservers(data []byte) []netip.Addr {
+	var out []netip.Addr
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		addr, err := netip.ParseAddr(fields[1])
+		if err != nil {
+			continue
+		}
+		// Drop IPv6 zones; iptables cannot match on them.
+		out = append(out, addr.WithZone("").Unmap())
+	}
+	return out
+}
+
+func (s *DefaultService) ApplyEgressPolicy(ctx context.Context, containerID string, policy EgressPolicy) error {
+	task, err := s.getTask(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	return applyEgressRules(s.withNamespace(ctx), task.Pid(), policy)
+}
@@ -0,0 +1,61 @@
+package containerd
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestEgressRulesetFullAcceptsEverything(t *testing.T) {
+	rules := egressRuleset(EgressPolicy{Mode: EgressModeFull}, nil, false)
+	if !strings.Contains(rules, ":OUTPUT ACCEPT") || strings.Contains(rules, "REJECT") {
+		t.Fatalf("unexpected full ruleset:\n%s", rules)
+	}
+}
+
+func TestEgressRulesetAllowlist(t *testing.T) {
+	policy := EgressPolicy{
+		Mode: EgressModeAllowlist,
+		Allow: []netip.Prefix{
+			netip.MustParsePrefix("10.1.2.3/8"),
+			netip.MustParsePrefix("2001:db8::1/128"),
+		},
+	}
+	nameservers := []netip.Addr{netip.MustParseAddr("192.168.1.1"), netip.MustParseAddr("127.0.0.53")}
+
+	v4 := egressRuleset(policy, nameservers, false)
+	for _, want := range []string{
+		":OUTPUT DROP",
+		"-A OUTPUT -o lo -j ACCEPT",
+		"-A OUTPUT -d 192.168.1.1/32 -p udp --dport 53 -j ACCEPT",
+		"-A OUTPUT -d 10.0.0.0/8 -j ACCEPT",
+		"-A OUTPUT -j REJECT",
+	} {
+		if !strings.Contains(v4, want) {
+			t.Fatalf("v4 ruleset missing %q:\n%s", want, v4)
+		}
+	}
+	if strings.Contains(v4, "2001:db8") || strings.Contains(v4, "127.0.0.53") {
+		t.Fatalf("v4 ruleset has foreign or loopback entries:\n%s", v4)
+	}
+
+	v6 := egressRuleset(policy, nameservers, true)
+	if !strings.Contains(v6, "-A OUTPUT -d 2001:db8::1/128 -j ACCEPT") || strings.Contains(v6, "10.0.0.0") {
+		t.Fatalf("unexpected v6 ruleset:\n%s", v6)
+	}
+}
+
+func TestEgressRulesetNoneSkipsDNSAndAllowList(t *testing.T) {
+	policy := EgressPolicy{Mode: EgressModeNone, Allow: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+	rules := egressRuleset(policy, []netip.Addr{netip.MustParseAddr("8.8.8.8")}, false)
+	if strings.Contains(rules, "--dport 53") || strings.Contains(rules, "10.0.0.0") {
+		t.Fatalf("none ruleset should only allow loopback and replies:\n%s", rules)
+	}
+}
+
+func TestParseNameservers(t *testing.T) {
+	got := parseNameservers([]byte("# comment\nnameserver 1.1.1.1\nsearch local\nnameserver fe80::1%eth0\nnameserver bogus\n"))
+	if len(got) != 2 || got[0].String() != "1.1.1.1" || got[1].String() != "fe80::1" {
+		t.Fatalf("parseNameservers = %v", got)
+	}
+}
@@ -0,0 +1,169 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+
+	cgroup1stats "github.com/containerd/cgroups/v3/cgroup1/stats"
+	cgroup2stats "github.com/containerd/cgroups/v3/cgroup2/stats"
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/typeurl/v2"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// DefaultCPUPeriod is the CFS period used when a quota is set without one.
+const DefaultCPUPeriod uint64 = 100000
+
+// resourceSpecOpts sets the container's cgroup limits to exactly limits,
+// clearing any limit left at zero.
+func resourceSpecOpts(limits ResourceLimits) []oci.SpecOpts {
+	return []oci.SpecOpts{
+		func(_ context.Context, _ oci.Client, _ *containers.Container, s *oci.Spec) error {
+			if s.Linux == nil {
+				s.Linux = &specs.Linux{}
+			}
+			if s.Linux.Resources == nil {
+				s.Linux.Resources = &specs.LinuxResources{}
+			}
+			resources := s.Linux.Resources
+			resources.CPU = cpuResources(limits, false)
+			if limits.MemoryBytes > 0 {
+				limit := limits.MemoryBytes
+				resources.Memory = &specs.LinuxMemory{Limit: &limit}
+			} else {
+				resources.Memory = nil
+			}
+			if limits.PidsLimit > 0 {
+				resources.Pids = &specs.LinuxPids{Limit: &limits.PidsLimit}
+			} else {
+				resources.Pids = nil
+			}
+			return nil
+		},
+	}
+}
+
+// taskResources converts limits for a live cgroup update. Unlike the stored
+// spec, a running task needs explicit "unlimited" values to lift a limit.
+func taskResources(limits ResourceLimits) *specs.LinuxResources {
+	memory := int64(-1)
+	if limits.MemoryBytes > 0 {
+		memory = limits.MemoryBytes
+	}
+	pids := int64(-1)
+	if limits.PidsLimit > 0 {
+		pids = limits.PidsLimit
+	}
+	return &specs.LinuxResources{
+		CPU:    cpuResources(limits, true),
+		Memory: &specs.LinuxMemory{Limit: &memory},
+		Pids:   &specs.LinuxPids{Limit: &pids},
+	}
+}
+
+func cpuResources(limits ResourceLimits, explicit bool) *specs.LinuxCPU {
+	if limits.CPUShares == 0 && limits.CPUQuota <= 0 && !explicit {
+		return nil
+	}
+	cpu := &specs.LinuxCPU{}
+	if limits.CPUShares > 0 {
+		shares := limits.CPUShares
+		cpu.Shares = &shares
+	}
+	quota := int64(-1)
+	if limits.CPUQuota > 0 {
+		quota = limits.CPUQuota
+	}
+	if limits.CPUQuota > 0 || explicit {
+		period := limits.CPUPeriod
+		if period == 0 {
+			period = DefaultCPUPeriod
+		}
+		cpu.Quota = &quota
+		cpu.Period = &period
+	}
+	return cpu
+}
+
+func (s *DefaultService) UpdateContainerResources(ctx context.Context, containerID string, limits ResourceLimits) error {
+	if containerID == "" {
+		return ErrInvalidArgument
+	}
+	ctx = s.withNamespace(ctx)
+	container, err := s.client.LoadContainer(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return fmt.Errorf("load container spec: %w", err)
+	}
+	if err := container.Update(ctx, containerd.UpdateContainerOpts(containerd.WithSpec(spec, resourceSpecOpts(limits)...))); err != nil {
+		return fmt.Errorf("update container spec: %w", err)
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := task.Update(ctx, containerd.WithResources(taskResources(limits))); err != nil {
+		return fmt.Errorf("update task resources: %w", err)
+	}
+	return nil
+}
+
+func (s *DefaultService) GetContainerMetrics(ctx context.Context, containerID string) (ContainerMetrics, error) {
+	task, err := s.getTask(ctx, containerID)
+	if err != nil {
+		return ContainerMetrics{}, err
+	}
+	metric, err := task.Metrics(s.withNamespace(ctx))
+	if err != nil {
+		return ContainerMetrics{}, err
+	}
+	if metric == nil || metric.Data == nil {
+		return ContainerMetrics{}, ErrNotSupported
+	}
+	data, err := typeurl.UnmarshalAny(metric.Data)
+	if err != nil {
+		return ContainerMetrics{}, fmt.Errorf("decode task metrics: %w", err)
+	}
+	switch m := data.(type) {
+	case *cgroup2stats.Metrics:
+		return ContainerMetrics{
+			CPUUsageNanos:    m.GetCPU().GetUsageUsec() * 1000,
+			MemoryUsageBytes: m.GetMemory().GetUsage(),
+			MemoryLimitBytes: m.GetMemory().GetUsageLimit(),
+			Pids:             m.GetPids().GetCurrent(),
+			PidsLimit:        m.GetPids().GetLimit(),
+		}, nil
+	case *cgroup1stats.Metrics:
+		return ContainerMetrics{
+			CPUUsageNanos:    m.GetCPU().GetUsage().GetTotal(),
+			MemoryUsageBytes: m.GetMemory().GetUsage().GetUsage(),
+			MemoryLimitBytes: m.GetMemory().GetUsage().GetLimit(),
+			Pids:             m.GetPids().GetCurrent(),
+			PidsLimit:        m.GetPids().GetLimit(),
+		}, nil
+	default:
+		return ContainerMetrics{}, ErrNotSupported
+	}
+}
+
+func (s *DefaultService) SnapshotUsage(ctx context.Context, snapshotter, key string) (SnapshotUsage, error) {
+	if snapshotter == "" || key == "" {
+		return SnapshotUsage{}, ErrInvalidArgument
+	}
+	ctx = s.withNamespace(ctx)
+	usage, err := s.client.SnapshotService(snapshotter).Usage(ctx, key)
+	if err != nil {
+		return SnapshotUsage{}, err
+	}
+	return SnapshotUsage{SizeBytes: usage.Size, Inodes: usage.Inodes}, nil
+}
@@ -0,0 +1,42 @@
+package containerd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func TestResourceSpecOptsReplacesLimits(t *testing.T) {
+	memory := int64(1 << 30)
+	spec := &oci.Spec{Linux: &specs.Linux{Resources: &specs.LinuxResources{
+		Memory: &specs.LinuxMemory{Limit: &memory},
+	}}}
+
+	for _, opt := range resourceSpecOpts(ResourceLimits{CPUQuota: 50000, PidsLimit: 32}) {
+		if err := opt(context.Background(), nil, nil, spec); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+	resources := spec.Linux.Resources
+	if resources.Memory != nil {
+		t.Fatal("memory limit should be cleared")
+	}
+	if resources.CPU == nil || *resources.CPU.Quota != 50000 || *resources.CPU.Period != DefaultCPUPeriod {
+		t.Fatalf("unexpected cpu resources: %+v", resources.CPU)
+	}
+	if resources.Pids == nil || resources.Pids.Limit == nil || *resources.Pids.Limit != 32 {
+		t.Fatalf("unexpected pids resources: %+v", resources.Pids)
+	}
+}
+
+func TestTaskResourcesLiftsUnsetLimits(t *testing.T) {
+	resources := taskResources(ResourceLimits{MemoryBytes: 64 << 20})
+	if *resources.Memory.Limit != 64<<20 {
+		t.Fatalf("memory limit = %d", *resources.Memory.Limit)
+	}
+	if *resources.Pids.Limit != -1 || *resources.CPU.Quota != -1 {
+		t.Fatal("unset limits should be lifted on the running task")
+	}
+}
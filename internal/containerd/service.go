@@ -106,6 +106,14 @@ type Service interface {
 	PrepareSnapshot(ctx context.Context, snapshotter, key, parent string) error
 	CreateContainerFromSnapshot(ctx context.Context, req CreateContainerRequest) (ContainerInfo, error)
 	SnapshotMounts(ctx context.Context, snapshotter, key string) ([]MountInfo, error)
+	SnapshotUsage(ctx context.Context, snapshotter, key string) (SnapshotUsage, error)
+
+	// UpdateContainerResources replaces the cgroup limits stored in the
+	// container spec and applies them to the running task, if any.
+	UpdateContainerResources(ctx context.Context, containerID string, limits ResourceLimits) error
+	GetContainerMetrics(ctx context.Context, containerID string) (ContainerMetrics, error)
+	// ApplyEgressPolicy replaces the outbound firewall of the running task.
+	ApplyEgressPolicy(ctx context.Context, containerID string, policy EgressPolicy) error
 }
 
 type DefaultService struct {
@@ -248,6 +256,9 @@ func specOptsFromSpec(spec ContainerSpec) []oci.SpecOpts {
 		}
 		opts = append(opts, oci.WithMounts(mounts))
 	}
+	if spec.Resources != nil {
+		opts = append(opts, resourceSpecOpts(*spec.Resources)...)
+	}
 
 	return opts
 }
@@ -753,6 +764,11 @@ func (s *DefaultService) SetupNetwork(ctx context.Context, req NetworkSetupReque
 	if err != nil {
 		return NetworkResult{}, err
 	}
+	if req.Egress != nil {
+		if err := applyEgressRules(ctx, task.Pid(), *req.Egress); err != nil {
+			return NetworkResult{}, fmt.Errorf("apply egress policy: %w", err)
+		}
+	}
 	return NetworkResult{IP: ip}, nil
 }
 
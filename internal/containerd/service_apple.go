@@ -358,7 +358,11 @@ func (s *AppleService) ListTasks(ctx context.Context, opts *ListTasksOptions) ([
 // Network (no-op — Apple Container handles networking natively)
 // ---------------------------------------------------------------------------
 
-func (*AppleService) SetupNetwork(context.Context, NetworkSetupRequest) (NetworkResult, error) {
+func (*AppleService) SetupNetwork(_ context.Context, req NetworkSetupRequest) (NetworkResult, error) {
+	// Fail closed: Apple Container has no hook to restrict egress.
+	if req.Egress != nil && req.Egress.Restricted() {
+		return NetworkResult{}, ErrNotSupported
+	}
 	return NetworkResult{}, nil
 }
 func (*AppleService) RemoveNetwork(context.Context, NetworkSetupRequest) error { return nil }
@@ -387,6 +391,22 @@ func (*AppleService) SnapshotMounts(context.Context, string, string) ([]MountInf
 	return nil, ErrNotSupported
 }
 
+func (*AppleService) SnapshotUsage(context.Context, string, string) (SnapshotUsage, error) {
+	return SnapshotUsage{}, ErrNotSupported
+}
+
+func (*AppleService) UpdateContainerResources(context.Context, string, ResourceLimits) error {
+	return ErrNotSupported
+}
+
+func (*AppleService) GetContainerMetrics(context.Context, string) (ContainerMetrics, error) {
+	return ContainerMetrics{}, ErrNotSupported
+}
+
+func (*AppleService) ApplyEgressPolicy(context.Context, string, EgressPolicy) error {
+	return ErrNotSupported
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
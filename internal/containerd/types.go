@@ -2,6 +2,7 @@ package containerd
 
 import (
 	"errors"
+	"net/netip"
 	"time"
 )
 
@@ -85,13 +86,44 @@ type MountSpec struct {
 }
 
 type ContainerSpec struct {
-	Cmd     []string
-	Env     []string
-	WorkDir string
-	User    string
-	Mounts  []MountSpec
-	DNS     []string
-	TTY     bool
+	Cmd       []string
+	Env       []string
+	WorkDir   string
+	User      string
+	Mounts    []MountSpec
+	DNS       []string
+	TTY       bool
+	Resources *ResourceLimits
+}
+
+// ResourceLimits caps the cgroup resources of a container. Zero fields are
+// left unlimited.
+type ResourceLimits struct {
+	CPUShares   uint64
+	CPUQuota    int64 // microseconds of CPU time per CPUPeriod
+	CPUPeriod   uint64
+	MemoryBytes int64
+	PidsLimit   int64
+}
+
+// IsZero reports whether no limit is set.
+func (r ResourceLimits) IsZero() bool {
+	return r == ResourceLimits{}
+}
+
+// ContainerMetrics is a resource usage sample of a running task.
+type ContainerMetrics struct {
+	CPUUsageNanos    uint64
+	MemoryUsageBytes uint64
+	MemoryLimitBytes uint64
+	Pids             uint64
+	PidsLimit        uint64
+}
+
+// SnapshotUsage is the disk space used by a snapshot's writable layer.
+type SnapshotUsage struct {
+	SizeBytes int64
+	Inodes    int64
 }
 
 type LayerStatus struct {
@@ -109,6 +141,30 @@ type NetworkSetupRequest struct {
 	PID         uint32
 	CNIBinDir   string
 	CNIConfDir  string
+	// Egress, when set, is enforced once the network is up. Nil leaves the
+	// container's outbound traffic unrestricted.
+	Egress *EgressPolicy
+}
+
+// Egress modes of a container network.
+const (
+	EgressModeFull      = "full"
+	EgressModeAllowlist = "allowlist"
+	EgressModeNone      = "none"
+)
+
+// EgressPolicy restricts a container's outbound traffic. Loopback and replies
+// on established connections are always allowed. In allowlist mode the
+// container's nameservers and the Allow prefixes are reachable; in none mode
+// nothing else is.
+type EgressPolicy struct {
+	Mode  string
+	Allow []netip.Prefix
+}
+
+// Restricted reports whether the policy blocks any outbound traffic.
+func (p EgressPolicy) Restricted() bool {
+	return p.Mode == EgressModeAllowlist || p.Mode == EgressModeNone
 }
 
 type NetworkResult struct {
@@ -0,0 +1,26 @@
+package flow
+
+import (
+	"context"
+	"errors"
+
+	"github.com/memohai/memoh/internal/eventtrigger"
+)
+
+// EventTriggerGateway adapts event trigger calls to the chat Resolver.
+type EventTriggerGateway struct {
+	resolver *Resolver
+}
+
+// NewEventTriggerGateway creates an EventTriggerGateway backed by the given Resolver.
+func NewEventTriggerGateway(resolver *Resolver) *EventTriggerGateway {
+	return &EventTriggerGateway{resolver: resolver}
+}
+
+// TriggerEvent delegates an event trigger run to the chat Resolver.
+func (g *EventTriggerGateway) TriggerEvent(ctx context.Context, botID string, payload eventtrigger.TriggerPayload, token string) (eventtrigger.TriggerResult, error) {
+	if g == nil || g.resolver == nil {
+		return eventtrigger.TriggerResult{}, errors.New("chat resolver not configured")
+	}
+	return g.resolver.TriggerEvent(ctx, botID, payload, token)
+}
@@ -16,6 +16,7 @@ import (
 	sdk "github.com/memohai/twilight-ai/sdk"
 
 	agentpkg "github.com/memohai/memoh/internal/agent"
+	"github.com/memohai/memoh/internal/budget"
 	"github.com/memohai/memoh/internal/compaction"
 	"github.com/memohai/memoh/internal/conversation"
 	"github.com/memohai/memoh/internal/db/sqlc"
@@ -64,6 +65,7 @@ type Resolver struct {
 	settingsService   *settings.Service
 	sessionService    SessionService
 	compactionService *compaction.Service
+	budgetService     BudgetService
 	eventPublisher    messageevent.Publisher
 	skillLoader       SkillLoader
 	assetLoader       gatewayAssetLoader
@@ -128,6 +130,13 @@ type resolvedContext struct {
 	model     models.GetResponse
 	provider  sqlc.LlmProvider
 	query     string // headerified query
+
+	// fallbacks are tried in order when the primary model fails before
+	// producing output; fallbackFrom is set once a fallback has answered.
+	fallbacks        []modelCandidate
+	fallbackFrom     string
+	reasoningEnabled bool
+	reasoningEffort  string
 }
 
 func (r *Resolver) resolve(ctx context.Context, req conversation.ChatRequest) (resolvedContext, error) {
@@ -140,6 +149,9 @@ func (r *Resolver) resolve(ctx context.Context, req conversation.ChatRequest) (r
 	if strings.TrimSpace(req.ChatID) == "" {
 		return resolvedContext{}, errors.New("chat id is required")
 	}
+	if err := r.checkBudget(ctx, req); err != nil {
+		return resolvedContext{}, err
+	}
 
 	skipHistory := req.MaxContextLoadTime < 0
 
@@ -161,7 +173,6 @@ func (r *Resolver) resolve(ctx context.Context, req conversation.ChatRequest) (r
 	if err != nil {
 		return resolvedContext{}, err
 	}
-	clientType := provider.ClientType
 
 	maxCtx := coalescePositiveInt(req.MaxContextLoadTime, botSettings.MaxContextLoadTime, defaultMaxContextMinutes)
 	maxTokens := botSettings.MaxContextTokens
@@ -250,28 +261,7 @@ func (r *Resolver) resolve(ctx context.Context, req conversation.ChatRequest) (r
 		req.Query,
 	)
 
-	reasoningEffort := ""
-	if chatModel.HasCompatibility(models.CompatReasoning) && botSettings.ReasoningEnabled {
-		reasoningEffort = botSettings.ReasoningEffort
-	}
-
-	var reasoningConfig *agentpkg.ReasoningConfig
-	if reasoningEffort != "" {
-		reasoningConfig = &agentpkg.ReasoningConfig{
-			Enabled: true,
-			Effort:  reasoningEffort,
-		}
-	}
-
-	modelCfg := agentpkg.ModelConfig{
-		ModelID:         chatModel.ModelID,
-		ClientType:      clientType,
-		APIKey:          provider.ApiKey,
-		BaseURL:         provider.BaseUrl,
-		ReasoningConfig: reasoningConfig,
-	}
-
-	sdkModel := agentpkg.CreateModel(modelCfg)
+	sdkModel, reasoningEffort := buildSDKModel(chatModel, provider, botSettings.ReasoningEnabled, botSettings.ReasoningEffort)
 	sdkMessages := modelMessagesToSDKMessages(nonNilModelMessages(messages))
 
 	runCfg := agentpkg.RunConfig{
@@ -284,22 +274,37 @@ func (r *Resolver) resolve(ctx context.Context, req conversation.ChatRequest) (r
 			BotID:             req.BotID,
 			ChatID:            req.ChatID,
 			SessionID:         req.SessionID,
+			UserID:            strings.TrimSpace(req.UserID),
 			ChannelIdentityID: strings.TrimSpace(req.SourceChannelIdentityID),
 			CurrentPlatform:   req.CurrentChannel,
 			ReplyTarget:       strings.TrimSpace(req.ReplyTarget),
 			SessionToken:      req.ChatToken,
+			ConversationID:    memoryConversationID(req),
+			ConversationType:  req.ConversationType,
 		},
 		Skills:        agentSkills,
 		LoopDetection: agentpkg.LoopDetectionConfig{Enabled: loopDetectionEnabled},
 	}
 
-	return resolvedContext{runConfig: runCfg, model: chatModel, provider: provider, query: headerifiedQuery}, nil
+	return resolvedContext{
+		runConfig:        runCfg,
+		model:            chatModel,
+		provider:         provider,
+		query:            headerifiedQuery,
+		fallbacks:        r.resolveFallbackChain(ctx, chatModel.ID, botSettings.ModelFallbacks.Chat),
+		reasoningEnabled: botSettings.ReasoningEnabled,
+		reasoningEffort:  botSettings.ReasoningEffort,
+	}, nil
 }
 
 // Chat sends a synchronous chat request and stores the result.
 func (r *Resolver) Chat(ctx context.Context, req conversation.ChatRequest) (conversation.ChatResponse, error) {
 	rc, err := r.resolve(ctx, req)
 	if err != nil {
+		var exhausted *budget.ExhaustedError
+		if errors.As(err, &exhausted) {
+			return budgetExhaustedResponse(exhausted), nil
+		}
 		return conversation.ChatResponse{}, err
 	}
 	req.Query = rc.query
@@ -309,14 +314,14 @@ func (r *Resolver) Chat(ctx context.Context, req conversation.ChatRequest) (conv
 	cfg := rc.runConfig
 	cfg = r.prepareRunConfig(ctx, cfg)
 
-	result, err := r.agent.Generate(ctx, cfg)
+	result, rc, err := r.generateWithFallback(ctx, rc, cfg)
 	if err != nil {
 		return conversation.ChatResponse{}, err
 	}
 
 	outputMessages := sdkMessagesToModelMessages(result.Messages)
 	roundMessages := prependUserMessage(req.Query, outputMessages)
-	if err := r.storeRound(ctx, req, roundMessages, rc.model.ID); err != nil {
+	if err := r.storeRound(ctx, req, roundMessages, rc.roundModel()); err != nil {
 		return conversation.ChatResponse{}, err
 	}
 
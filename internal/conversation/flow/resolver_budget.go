@@ -0,0 +1,140 @@
+package flow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+
+	agentpkg "github.com/memohai/memoh/internal/agent"
+	"github.com/memohai/memoh/internal/budget"
+	"github.com/memohai/memoh/internal/conversation"
+)
+
+// BudgetService enforces token budgets and records consumption.
+type BudgetService interface {
+	Check(ctx context.Context, botID string, subject budget.Subject) error
+	Record(ctx context.Context, record budget.UsageRecord) error
+}
+
+// SetBudgetService configures token budget enforcement. When unset, turns
+// are never blocked and no usage is recorded to the budget ledger.
+func (r *Resolver) SetBudgetService(s BudgetService) {
+	r.budgetService = s
+}
+
+func budgetSubject(req conversation.ChatRequest) budget.Subject {
+	return budget.Subject{
+		UserID:            strings.TrimSpace(req.UserID),
+		ChannelIdentityID: strings.TrimSpace(req.SourceChannelIdentityID),
+	}
+}
+
+// checkBudget returns a *budget.ExhaustedError when the turn must not run.
+// Lookup failures are logged and the turn is allowed so that a budget
+// outage never takes the bot down.
+func (r *Resolver) checkBudget(ctx context.Context, req conversation.ChatRequest) error {
+	if r.budgetService == nil {
+		return nil
+	}
+	err := r.budgetService.Check(ctx, req.BotID, budgetSubject(req))
+	if err == nil {
+		return nil
+	}
+	var exhausted *budget.ExhaustedError
+	if errors.As(err, &exhausted) {
+		r.logger.Info("token budget exhausted",
+			slog.String("bot_id", req.BotID),
+			slog.String("rule_id", exhausted.Allowance.Rule.ID),
+		)
+		return exhausted
+	}
+	r.logger.Warn("token budget check failed", slog.String("bot_id", req.BotID), slog.Any("error", err))
+	return nil
+}
+
+// recordBudgetUsage adds the round's token usage to the budget ledger.
+func (r *Resolver) recordBudgetUsage(ctx context.Context, req conversation.ChatRequest, messages []conversation.ModelMessage, model roundModel) {
+	r.recordBudget(ctx, req, model.ID, sumRoundUsage(messages))
+}
+
+// recordAuxiliaryUsage adds the usage of a model call made on behalf of the
+// turn outside the agent loop, such as title generation or compaction.
+func (r *Resolver) recordAuxiliaryUsage(ctx context.Context, req conversation.ChatRequest, modelID string, usage sdk.Usage) {
+	r.recordBudget(ctx, req, modelID, budget.Usage{
+		InputTokens:  int64(usage.InputTokens),
+		OutputTokens: int64(usage.OutputTokens),
+	})
+}
+
+func (r *Resolver) recordBudget(ctx context.Context, req conversation.ChatRequest, modelID string, usage budget.Usage) {
+	if r.budgetService == nil {
+		return
+	}
+	if usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		return
+	}
+	if err := r.budgetService.Record(ctx, budget.UsageRecord{
+		BotID:             req.BotID,
+		SessionID:         req.SessionID,
+		UserID:            strings.TrimSpace(req.UserID),
+		ChannelIdentityID: strings.TrimSpace(req.SourceChannelIdentityID),
+		ModelID:           modelID,
+		Usage:             usage,
+	}); err != nil {
+		r.logger.Warn("record token usage failed", slog.String("bot_id", req.BotID), slog.Any("error", err))
+	}
+}
+
+func sumRoundUsage(messages []conversation.ModelMessage) budget.Usage {
+	var total budget.Usage
+	for _, m := range messages {
+		if len(m.Usage) == 0 {
+			continue
+		}
+		var u usageInfo
+		if json.Unmarshal(m.Usage, &u) != nil {
+			continue
+		}
+		if u.InputTokens != nil {
+			total.InputTokens += int64(*u.InputTokens)
+		}
+		if u.OutputTokens != nil {
+			total.OutputTokens += int64(*u.OutputTokens)
+		}
+	}
+	return total
+}
+
+func budgetExhaustedResponse(err *budget.ExhaustedError) conversation.ChatResponse {
+	return conversation.ChatResponse{
+		Messages: []conversation.ModelMessage{{
+			Role:    "assistant",
+			Content: conversation.NewTextContent(err.Message()),
+		}},
+	}
+}
+
+// budgetExhaustedEvents renders the exhausted reply as a complete agent
+// stream so channel adapters deliver it like a normal answer.
+func budgetExhaustedEvents(err *budget.ExhaustedError) []json.RawMessage {
+	events := []agentpkg.StreamEvent{
+		{Type: agentpkg.EventAgentStart},
+		{Type: agentpkg.EventTextStart},
+		{Type: agentpkg.EventTextDelta, Delta: err.Message()},
+		{Type: agentpkg.EventTextEnd},
+		{Type: agentpkg.EventAgentEnd},
+	}
+	out := make([]json.RawMessage, 0, len(events))
+	for _, e := range events {
+		data, marshalErr := json.Marshal(e)
+		if marshalErr != nil {
+			continue
+		}
+		out = append(out, data)
+	}
+	return out
+}
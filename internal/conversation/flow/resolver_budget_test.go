@@ -0,0 +1,94 @@
+package flow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+
+	"github.com/memohai/memoh/internal/budget"
+	"github.com/memohai/memoh/internal/conversation"
+)
+
+type fakeBudgetService struct {
+	checkErr error
+	records  []budget.UsageRecord
+}
+
+func (f *fakeBudgetService) Check(context.Context, string, budget.Subject) error {
+	return f.checkErr
+}
+
+func (f *fakeBudgetService) Record(_ context.Context, record budget.UsageRecord) error {
+	f.records = append(f.records, record)
+	return nil
+}
+
+func TestCheckBudget(t *testing.T) {
+	req := conversation.ChatRequest{BotID: "bot-1", UserID: "user-1"}
+
+	exhausted := &budget.ExhaustedError{Allowance: budget.Allowance{Rule: budget.Rule{ExhaustedMessage: "no more"}}}
+	r := &Resolver{logger: slog.Default(), budgetService: &fakeBudgetService{checkErr: exhausted}}
+	err := r.checkBudget(context.Background(), req)
+	var got *budget.ExhaustedError
+	if !errors.As(err, &got) || got.Message() != "no more" {
+		t.Fatalf("expected exhausted error, got %v", err)
+	}
+
+	r.budgetService = &fakeBudgetService{checkErr: errors.New("db down")}
+	if err := r.checkBudget(context.Background(), req); err != nil {
+		t.Fatalf("lookup failures should not block the turn, got %v", err)
+	}
+
+	resp := budgetExhaustedResponse(exhausted)
+	if len(resp.Messages) != 1 || resp.Messages[0].TextContent() != "no more" {
+		t.Fatalf("unexpected exhausted response: %+v", resp)
+	}
+}
+
+func TestRecordBudgetUsage(t *testing.T) {
+	fake := &fakeBudgetService{}
+	r := &Resolver{logger: slog.Default(), budgetService: fake}
+	req := conversation.ChatRequest{BotID: "bot-1", SessionID: "s-1", UserID: "user-1", SourceChannelIdentityID: "ci-1"}
+	messages := []conversation.ModelMessage{
+		{Role: "user", Content: conversation.NewTextContent("hi")},
+		{Role: "assistant", Content: conversation.NewTextContent("a"), Usage: json.RawMessage(`{"inputTokens":120,"outputTokens":30}`)},
+		{Role: "tool", Content: conversation.NewTextContent("t")},
+		{Role: "assistant", Content: conversation.NewTextContent("b"), Usage: json.RawMessage(`{"inputTokens":200,"outputTokens":50}`)},
+	}
+
+	r.recordBudgetUsage(context.Background(), req, messages, roundModel{ID: "model-1"})
+	if len(fake.records) != 1 {
+		t.Fatalf("expected one ledger record, got %d", len(fake.records))
+	}
+	rec := fake.records[0]
+	if rec.Usage.InputTokens != 320 || rec.Usage.OutputTokens != 80 {
+		t.Fatalf("unexpected usage: %+v", rec.Usage)
+	}
+	if rec.UserID != "user-1" || rec.ChannelIdentityID != "ci-1" || rec.ModelID != "model-1" {
+		t.Fatalf("unexpected attribution: %+v", rec)
+	}
+
+	r.recordBudgetUsage(context.Background(), req, messages[:1], roundModel{ID: "model-1"})
+	if len(fake.records) != 1 {
+		t.Fatal("rounds without usage should not be recorded")
+	}
+}
+
+func TestRecordAuxiliaryUsage(t *testing.T) {
+	fake := &fakeBudgetService{}
+	r := &Resolver{logger: slog.Default(), budgetService: fake}
+	req := conversation.ChatRequest{BotID: "bot-1", SessionID: "s-1", UserID: "user-1"}
+
+	r.recordAuxiliaryUsage(context.Background(), req, "title-model", sdk.Usage{InputTokens: 40, OutputTokens: 8})
+	if len(fake.records) != 1 {
+		t.Fatalf("expected one ledger record, got %d", len(fake.records))
+	}
+	rec := fake.records[0]
+	if rec.Usage.InputTokens != 40 || rec.Usage.OutputTokens != 8 || rec.ModelID != "title-model" || rec.UserID != "user-1" {
+		t.Fatalf("unexpected record: %+v", rec)
+	}
+}
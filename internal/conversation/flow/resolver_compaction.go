@@ -4,6 +4,8 @@ import (
 	"context"
 	"log/slog"
 
+	sdk "github.com/memohai/twilight-ai/sdk"
+
 	"github.com/memohai/memoh/internal/compaction"
 	"github.com/memohai/memoh/internal/conversation"
 	"github.com/memohai/memoh/internal/models"
@@ -24,10 +26,16 @@ func (r *Resolver) maybeCompact(ctx context.Context, req conversation.ChatReques
 	if !compaction.ShouldCompact(inputTokens, settings.CompactionThreshold) {
 		return
 	}
+	if r.checkBudget(ctx, req) != nil {
+		return
+	}
 
 	modelID := settings.CompactionModelID
+	fallbacks := rc.fallbacks
 	if modelID == "" {
 		modelID = rc.model.ID
+	} else {
+		fallbacks = r.resolveFallbackChain(ctx, modelID, settings.ModelFallbacks.Compaction)
 	}
 
 	cfg := compaction.TriggerConfig{
@@ -41,6 +49,7 @@ func (r *Resolver) maybeCompact(ctx context.Context, req conversation.ChatReques
 		return
 	}
 	cfg.ModelID = model.ModelID
+	modelIDs := map[string]string{model.ModelID: model.ID}
 
 	provider, err := models.FetchProviderByID(ctx, r.queries, model.LlmProviderID)
 	if err != nil {
@@ -50,6 +59,22 @@ func (r *Resolver) maybeCompact(ctx context.Context, req conversation.ChatReques
 	cfg.ClientType = provider.ClientType
 	cfg.APIKey = provider.ApiKey
 	cfg.BaseURL = provider.BaseUrl
+	for _, c := range fallbacks {
+		if c.model.ID == model.ID {
+			continue
+		}
+		modelIDs[c.model.ModelID] = c.model.ID
+		cfg.Fallbacks = append(cfg.Fallbacks, compaction.TriggerModel{
+			ModelID:    c.model.ModelID,
+			ClientType: c.provider.ClientType,
+			APIKey:     c.provider.ApiKey,
+			BaseURL:    c.provider.BaseUrl,
+		})
+	}
+
+	cfg.OnUsage = func(ctx context.Context, modelID string, usage sdk.Usage) {
+		r.recordAuxiliaryUsage(ctx, req, modelIDs[modelID], usage)
+	}
 
 	r.compactionService.TriggerCompaction(ctx, cfg)
 }
@@ -0,0 +1,36 @@
+package flow
+
+import (
+	"encoding/json"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+
+	"github.com/memohai/memoh/internal/models"
+)
+
+// messageCost prices a message's recorded usage. It returns nil when the
+// answering model has no pricing or the message carries no usage.
+func messageCost(usage json.RawMessage, pricing *models.ModelPricing) *float64 {
+	if pricing == nil || len(usage) == 0 {
+		return nil
+	}
+	var u sdk.Usage
+	if err := json.Unmarshal(usage, &u); err != nil {
+		return nil
+	}
+	cached := u.InputTokenDetails.CacheReadTokens
+	if cached == 0 {
+		cached = u.CachedInputTokens
+	}
+	reasoning := u.OutputTokenDetails.ReasoningTokens
+	if reasoning == 0 {
+		reasoning = u.ReasoningTokens
+	}
+	cost := pricing.Cost(models.TokenCounts{
+		Input:       u.InputTokens,
+		CachedInput: cached,
+		Output:      u.OutputTokens,
+		Reasoning:   reasoning,
+	})
+	return &cost
+}
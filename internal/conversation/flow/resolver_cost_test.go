@@ -0,0 +1,30 @@
+package flow
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/memohai/memoh/internal/models"
+)
+
+func TestMessageCost(t *testing.T) {
+	pricing := &models.ModelPricing{Input: 3, Output: 15, CachedInput: 0.3}
+	usage := json.RawMessage(`{"inputTokens":1000000,"outputTokens":100000,"inputTokenDetails":{"cacheReadTokens":500000}}`)
+
+	got := messageCost(usage, pricing)
+	if got == nil {
+		t.Fatal("expected a cost")
+	}
+	// 500k uncached at $3 + 500k cached at $0.30 + 100k output at $15.
+	if want := 1.5 + 0.15 + 1.5; math.Abs(*got-want) > 1e-9 {
+		t.Fatalf("cost = %v, want %v", *got, want)
+	}
+
+	if messageCost(usage, nil) != nil {
+		t.Fatal("unpriced models should have no cost")
+	}
+	if messageCost(nil, pricing) != nil {
+		t.Fatal("messages without usage should have no cost")
+	}
+}
@@ -0,0 +1,263 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+
+	agentpkg "github.com/memohai/memoh/internal/agent"
+	"github.com/memohai/memoh/internal/db/sqlc"
+	"github.com/memohai/memoh/internal/models"
+)
+
+// modelCandidate pairs a chat model with the provider used to call it.
+type modelCandidate struct {
+	model    models.GetResponse
+	provider sqlc.LlmProvider
+}
+
+// roundModel identifies the model that produced a stored round. FallbackFrom
+// is the primary model ID when a fallback model answered in its place, and
+// Pricing prices the round's usage when the model has pricing configured.
+type roundModel struct {
+	ID           string
+	FallbackFrom string
+	Pricing      *models.ModelPricing
+}
+
+// agentStreamFunc starts one streaming agent run.
+type agentStreamFunc func(ctx context.Context, cfg agentpkg.RunConfig) <-chan agentpkg.StreamEvent
+
+// agentGenerateFunc runs one non-streaming agent invocation.
+type agentGenerateFunc func(ctx context.Context, cfg agentpkg.RunConfig) (*agentpkg.GenerateResult, error)
+
+// modelStreamEvent is a stream event tagged with the model that produced it.
+type modelStreamEvent struct {
+	agentpkg.StreamEvent
+	Model roundModel
+}
+
+// resolveFallbackChain loads the configured fallback models in order.
+// Models that cannot be resolved, are not chat models, or repeat the
+// primary model are skipped so that a stale entry never blocks a turn.
+func (r *Resolver) resolveFallbackChain(ctx context.Context, primaryID string, fallbackIDs []string) []modelCandidate {
+	if len(fallbackIDs) == 0 || r.modelsService == nil {
+		return nil
+	}
+	seen := map[string]struct{}{strings.TrimSpace(primaryID): {}}
+	chain := make([]modelCandidate, 0, len(fallbackIDs))
+	for _, id := range fallbackIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		model, provider, err := r.fetchChatModel(ctx, id)
+		if err != nil {
+			r.logger.Warn("skip unresolved fallback model", slog.String("model_id", id), slog.Any("error", err))
+			continue
+		}
+		if _, ok := seen[model.ID]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		seen[model.ID] = struct{}{}
+		chain = append(chain, modelCandidate{model: model, provider: provider})
+	}
+	return chain
+}
+
+// candidates returns the primary model followed by its fallbacks.
+func (rc resolvedContext) candidates() []modelCandidate {
+	out := make([]modelCandidate, 0, 1+len(rc.fallbacks))
+	out = append(out, modelCandidate{model: rc.model, provider: rc.provider})
+	return append(out, rc.fallbacks...)
+}
+
+// bind returns a copy of cfg that targets the given candidate model.
+func (rc resolvedContext) bind(cfg agentpkg.RunConfig, c modelCandidate) agentpkg.RunConfig {
+	cfg.Model, cfg.ReasoningEffort = buildSDKModel(c.model, c.provider, rc.reasoningEnabled, rc.reasoningEffort)
+	cfg.SupportsImageInput = c.model.HasCompatibility(models.CompatVision)
+	return cfg
+}
+
+// withModel returns a copy of rc describing the candidate that answered.
+func (rc resolvedContext) withModel(c modelCandidate) resolvedContext {
+	if c.model.ID == rc.model.ID {
+		return rc
+	}
+	out := rc
+	out.fallbackFrom = rc.model.ID
+	out.model = c.model
+	out.provider = c.provider
+	return out
+}
+
+// answeredBy returns a copy of rc rebound to the candidate identified by m.
+func (rc resolvedContext) answeredBy(m roundModel) resolvedContext {
+	for _, c := range rc.candidates() {
+		if c.model.ID == m.ID {
+			return rc.withModel(c)
+		}
+	}
+	return rc
+}
+
+// roundModel describes which model answered for persistence.
+func (rc resolvedContext) roundModel() roundModel {
+	return roundModel{ID: rc.model.ID, FallbackFrom: rc.fallbackFrom, Pricing: rc.model.Config.Pricing}
+}
+
+// buildSDKModel creates the SDK model for a chat model and returns the
+// reasoning effort to use with it.
+func buildSDKModel(model models.GetResponse, provider sqlc.LlmProvider, reasoningEnabled bool, effort string) (*sdk.Model, string) {
+	reasoningEffort := ""
+	if model.HasCompatibility(models.CompatReasoning) && reasoningEnabled {
+		reasoningEffort = effort
+	}
+	var reasoningConfig *agentpkg.ReasoningConfig
+	if reasoningEffort != "" {
+		reasoningConfig = &agentpkg.ReasoningConfig{
+			Enabled: true,
+			Effort:  reasoningEffort,
+		}
+	}
+	return agentpkg.CreateModel(agentpkg.ModelConfig{
+		ModelID:         model.ModelID,
+		ClientType:      provider.ClientType,
+		APIKey:          provider.ApiKey,
+		BaseURL:         provider.BaseUrl,
+		ReasoningConfig: reasoningConfig,
+	}), reasoningEffort
+}
+
+// generateWithFallback runs a non-streaming generation on the primary model
+// and retries on each fallback model in turn when the call fails before
+// producing output. A failure after a tool ran or text was produced is
+// returned as is, so tool side effects are never repeated. It returns the
+// context rebound to the model that answered.
+func (r *Resolver) generateWithFallback(ctx context.Context, rc resolvedContext, cfg agentpkg.RunConfig) (*agentpkg.GenerateResult, resolvedContext, error) {
+	return generateWithFallback(ctx, r.logger, rc, cfg, r.agent.Generate)
+}
+
+func generateWithFallback(ctx context.Context, logger *slog.Logger, rc resolvedContext, cfg agentpkg.RunConfig, generate agentGenerateFunc) (*agentpkg.GenerateResult, resolvedContext, error) {
+	candidates := rc.candidates()
+	var lastErr error
+	for i, c := range candidates {
+		attemptCfg := cfg
+		if i > 0 {
+			attemptCfg = rc.bind(cfg, c)
+		}
+		result, err := generate(ctx, attemptCfg)
+		if err == nil {
+			return result, rc.withModel(c), nil
+		}
+		lastErr = err
+		if ctx.Err() != nil || errors.Is(err, agentpkg.ErrPartialOutput) || i == len(candidates)-1 {
+			break
+		}
+		logger.Warn("model failed, trying fallback",
+			slog.String("bot_id", cfg.Identity.BotID),
+			slog.String("model_id", c.model.ID),
+			slog.String("fallback_model_id", candidates[i+1].model.ID),
+			slog.Any("error", err),
+		)
+	}
+	return nil, rc, lastErr
+}
+
+// streamWithFallback runs the agent stream on the primary model. When an
+// attempt fails before emitting any output (a stream-start error or an error
+// part ahead of the first text, reasoning or tool event), its events are
+// discarded and the next fallback model is tried transparently. Once an
+// attempt has produced output, its events are forwarded unchanged.
+func (r *Resolver) streamWithFallback(ctx context.Context, rc resolvedContext, cfg agentpkg.RunConfig) <-chan modelStreamEvent {
+	return streamWithFallback(ctx, r.logger, rc, cfg, r.agent.Stream)
+}
+
+func streamWithFallback(ctx context.Context, logger *slog.Logger, rc resolvedContext, cfg agentpkg.RunConfig, stream agentStreamFunc) <-chan modelStreamEvent {
+	out := make(chan modelStreamEvent)
+	go func() {
+		defer close(out)
+		candidates := rc.candidates()
+		for i, c := range candidates {
+			attemptCfg := cfg
+			if i > 0 {
+				attemptCfg = rc.bind(cfg, c)
+			}
+			model := rc.withModel(c).roundModel()
+			send := func(event agentpkg.StreamEvent) bool {
+				select {
+				case out <- modelStreamEvent{StreamEvent: event, Model: model}:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+
+			events := stream(ctx, attemptCfg)
+			abandon := func() {
+				go func() {
+					for range events { //nolint:revive // drain so the agent goroutine can exit
+					}
+				}()
+			}
+
+			var pending []agentpkg.StreamEvent
+			committed := false
+			failure := ""
+			for event := range events {
+				if committed {
+					if !send(event) {
+						abandon()
+						return
+					}
+					continue
+				}
+				switch {
+				case event.Type == agentpkg.EventError:
+					failure = event.Error
+					pending = append(pending, event)
+				case event.Type == agentpkg.EventAgentStart || event.IsTerminal():
+					pending = append(pending, event)
+				default:
+					committed = true
+					for _, p := range pending {
+						if !send(p) {
+							abandon()
+							return
+						}
+					}
+					pending = nil
+					if !send(event) {
+						abandon()
+						return
+					}
+				}
+			}
+
+			retry := !committed && failure != "" && i < len(candidates)-1 && ctx.Err() == nil
+			if retry {
+				logger.Warn("model failed before output, trying fallback",
+					slog.String("bot_id", cfg.Identity.BotID),
+					slog.String("model_id", c.model.ID),
+					slog.String("fallback_model_id", candidates[i+1].model.ID),
+					slog.String("error", failure),
+				)
+				continue
+			}
+			for _, p := range pending {
+				if !send(p) {
+					return
+				}
+			}
+			return
+		}
+	}()
+	return out
+}
@@ -0,0 +1,174 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	agentpkg "github.com/memohai/memoh/internal/agent"
+	"github.com/memohai/memoh/internal/db/sqlc"
+	"github.com/memohai/memoh/internal/models"
+)
+
+func fallbackTestContext() resolvedContext {
+	return resolvedContext{
+		model:    models.GetResponse{ID: "primary", ModelID: "primary-model"},
+		provider: sqlc.LlmProvider{ClientType: agentpkg.ClientTypeOpenAICompletions},
+		fallbacks: []modelCandidate{{
+			model:    models.GetResponse{ID: "backup", ModelID: "backup-model"},
+			provider: sqlc.LlmProvider{ClientType: agentpkg.ClientTypeOpenAICompletions},
+		}},
+	}
+}
+
+func fakeStream(attempts [][]agentpkg.StreamEvent, calls *int) agentStreamFunc {
+	return func(_ context.Context, _ agentpkg.RunConfig) <-chan agentpkg.StreamEvent {
+		events := attempts[*calls]
+		*calls++
+		ch := make(chan agentpkg.StreamEvent, len(events))
+		for _, e := range events {
+			ch <- e
+		}
+		close(ch)
+		return ch
+	}
+}
+
+func collectStream(ch <-chan modelStreamEvent) []modelStreamEvent {
+	var out []modelStreamEvent
+	for e := range ch {
+		out = append(out, e)
+	}
+	return out
+}
+
+func TestStreamWithFallback_RetriesOnErrorBeforeOutput(t *testing.T) {
+	calls := 0
+	stream := fakeStream([][]agentpkg.StreamEvent{
+		{
+			{Type: agentpkg.EventAgentStart},
+			{Type: agentpkg.EventError, Error: "rate limited"},
+			{Type: agentpkg.EventAgentAbort},
+		},
+		{
+			{Type: agentpkg.EventAgentStart},
+			{Type: agentpkg.EventTextDelta, Delta: "hello"},
+			{Type: agentpkg.EventAgentEnd},
+		},
+	}, &calls)
+
+	events := collectStream(streamWithFallback(context.Background(), slog.Default(), fallbackTestContext(), agentpkg.RunConfig{}, stream))
+	if calls != 2 {
+		t.Fatalf("expected 2 attempts, got %d", calls)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events from fallback attempt, got %d", len(events))
+	}
+	for _, e := range events {
+		if e.Type == agentpkg.EventError {
+			t.Fatalf("error from failed attempt should be discarded")
+		}
+		if e.Model.ID != "backup" || e.Model.FallbackFrom != "primary" {
+			t.Fatalf("unexpected event model: %+v", e.Model)
+		}
+	}
+}
+
+func TestStreamWithFallback_NoRetryAfterOutput(t *testing.T) {
+	calls := 0
+	stream := fakeStream([][]agentpkg.StreamEvent{
+		{
+			{Type: agentpkg.EventAgentStart},
+			{Type: agentpkg.EventTextDelta, Delta: "partial"},
+			{Type: agentpkg.EventError, Error: "connection reset"},
+			{Type: agentpkg.EventAgentAbort},
+		},
+	}, &calls)
+
+	events := collectStream(streamWithFallback(context.Background(), slog.Default(), fallbackTestContext(), agentpkg.RunConfig{}, stream))
+	if calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls)
+	}
+	if len(events) != 4 {
+		t.Fatalf("expected all 4 events forwarded, got %d", len(events))
+	}
+	if events[2].Type != agentpkg.EventError || events[2].Model.ID != "primary" {
+		t.Fatalf("expected primary error to be forwarded, got %+v", events[2])
+	}
+}
+
+func TestStreamWithFallback_ForwardsLastFailure(t *testing.T) {
+	calls := 0
+	failed := []agentpkg.StreamEvent{
+		{Type: agentpkg.EventError, Error: "boom"},
+		{Type: agentpkg.EventAgentAbort},
+	}
+	stream := fakeStream([][]agentpkg.StreamEvent{failed, failed}, &calls)
+
+	events := collectStream(streamWithFallback(context.Background(), slog.Default(), fallbackTestContext(), agentpkg.RunConfig{}, stream))
+	if calls != 2 {
+		t.Fatalf("expected 2 attempts, got %d", calls)
+	}
+	if len(events) != 2 || events[0].Type != agentpkg.EventError || events[0].Model.ID != "backup" {
+		t.Fatalf("expected last attempt's failure to be forwarded, got %+v", events)
+	}
+}
+
+func TestGenerateWithFallback(t *testing.T) {
+	calls := 0
+	generate := func(_ context.Context, _ agentpkg.RunConfig) (*agentpkg.GenerateResult, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("upstream unavailable")
+		}
+		return &agentpkg.GenerateResult{Text: "ok"}, nil
+	}
+
+	result, rc, err := generateWithFallback(context.Background(), slog.Default(), fallbackTestContext(), agentpkg.RunConfig{}, generate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Text != "ok" {
+		t.Fatalf("unexpected result: %q", result.Text)
+	}
+	if got := rc.roundModel(); got.ID != "backup" || got.FallbackFrom != "primary" {
+		t.Fatalf("unexpected answered model: %+v", got)
+	}
+}
+
+func TestGenerateWithFallback_NoRetryAfterToolCall(t *testing.T) {
+	calls := 0
+	generate := func(_ context.Context, _ agentpkg.RunConfig) (*agentpkg.GenerateResult, error) {
+		calls++
+		// The primary model ran a tool, then the follow-up step failed.
+		return nil, fmt.Errorf("generate: %w: %w", agentpkg.ErrPartialOutput, errors.New("upstream unavailable"))
+	}
+
+	_, rc, err := generateWithFallback(context.Background(), slog.Default(), fallbackTestContext(), agentpkg.RunConfig{}, generate)
+	if !errors.Is(err, agentpkg.ErrPartialOutput) {
+		t.Fatalf("expected ErrPartialOutput, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected no fallback attempt after output, got %d calls", calls)
+	}
+	if got := rc.roundModel(); got.ID != "primary" || got.FallbackFrom != "" {
+		t.Fatalf("unexpected answered model: %+v", got)
+	}
+}
+
+func TestWithFallbackMetadata(t *testing.T) {
+	route := map[string]any{"route_id": "r1"}
+	if got := withFallbackMetadata(route, roundModel{ID: "primary"}); len(got) != 1 {
+		t.Fatalf("expected route metadata unchanged, got %v", got)
+	}
+	got := withFallbackMetadata(route, roundModel{ID: "backup", FallbackFrom: "primary"})
+	fb, ok := got["model_fallback"].(map[string]any)
+	if !ok || fb["answered_model_id"] != "backup" || fb["requested_model_id"] != "primary" {
+		t.Fatalf("unexpected fallback metadata: %v", got)
+	}
+	if _, ok := route["model_fallback"]; ok {
+		t.Fatal("route metadata should not be mutated")
+	}
+}
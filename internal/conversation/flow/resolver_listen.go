@@ -0,0 +1,89 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/memohai/twilight-ai/sdk"
+
+	agentpkg "github.com/memohai/memoh/internal/agent"
+)
+
+const (
+	listenPromptMaxInputChars = 1000
+	listenClassifyTimeout     = 15 * time.Second
+)
+
+// ListenClassifyRequest describes a group message the bot was not addressed
+// in, for deciding whether it should reply anyway.
+type ListenClassifyRequest struct {
+	BotID        string
+	ModelID      string
+	Instructions string
+	SenderName   string
+	Text         string
+}
+
+// ClassifyPassiveMessage asks a cheap model whether the bot should reply to
+// a message it was not mentioned in. It uses the requested model, falling
+// back to the bot's title model, and never replies when neither is set.
+func (r *Resolver) ClassifyPassiveMessage(ctx context.Context, req ListenClassifyRequest) (bool, error) {
+	text := truncate(strings.TrimSpace(req.Text), listenPromptMaxInputChars)
+	if text == "" {
+		return false, nil
+	}
+	modelID := strings.TrimSpace(req.ModelID)
+	if modelID == "" {
+		botSettings, err := r.loadBotSettings(ctx, req.BotID)
+		if err != nil {
+			return false, fmt.Errorf("load bot settings: %w", err)
+		}
+		modelID = strings.TrimSpace(botSettings.TitleModelID)
+	}
+	if modelID == "" {
+		return false, errors.New("no classifier or title model configured")
+	}
+	model, provider, err := r.fetchChatModel(ctx, modelID)
+	if err != nil {
+		return false, fmt.Errorf("resolve classifier model: %w", err)
+	}
+
+	instructions := strings.TrimSpace(req.Instructions)
+	if instructions == "" {
+		instructions = "Reply only when the message asks a question or requests help that the assistant can clearly answer."
+	}
+	sender := strings.TrimSpace(req.SenderName)
+	if sender == "" {
+		sender = "User"
+	}
+	prompt := "You decide whether an AI assistant in a group chat should reply to a message that was not addressed to it. " +
+		"Unsolicited replies are disruptive, so answer YES only when the guidance below clearly calls for one.\n\n" +
+		"Guidance: " + instructions + "\n\n" +
+		"Message from " + sender + ": " + text + "\n\n" +
+		"Answer with exactly YES or NO."
+
+	sdkModel := agentpkg.CreateModel(agentpkg.ModelConfig{
+		ModelID:    model.ModelID,
+		ClientType: provider.ClientType,
+		APIKey:     provider.ApiKey,
+		BaseURL:    provider.BaseUrl,
+	})
+	genCtx, cancel := context.WithTimeout(ctx, listenClassifyTimeout)
+	defer cancel()
+	answer, err := sdk.NewClient().GenerateText(genCtx,
+		sdk.WithModel(sdkModel),
+		sdk.WithMessages([]sdk.Message{sdk.UserMessage(prompt)}),
+	)
+	if err != nil {
+		return false, fmt.Errorf("classify passive message: %w", err)
+	}
+	return parseListenAnswer(answer), nil
+}
+
+func parseListenAnswer(answer string) bool {
+	answer = strings.ToUpper(strings.Trim(strings.TrimSpace(answer), "\"'`.!*"))
+	return strings.HasPrefix(answer, "YES")
+}
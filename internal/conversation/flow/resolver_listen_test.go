@@ -0,0 +1,22 @@
+package flow
+
+import "testing"
+
+func TestParseListenAnswer(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{answer: "YES", want: true},
+		{answer: " yes.", want: true},
+		{answer: "**Yes**", want: true},
+		{answer: "NO", want: false},
+		{answer: "Not sure", want: false},
+		{answer: "", want: false},
+	}
+	for _, tt := range tests {
+		if got := parseListenAnswer(tt.answer); got != tt.want {
+			t.Errorf("parseListenAnswer(%q) = %v, want %v", tt.answer, got, tt.want)
+		}
+	}
+}
@@ -5,35 +5,59 @@ import (
 	"log/slog"
 	"strings"
 
+	"github.com/memohai/memoh/internal/channel"
 	"github.com/memohai/memoh/internal/conversation"
 	memprovider "github.com/memohai/memoh/internal/memory/adapters"
 )
 
-func (r *Resolver) resolveMemoryProvider(ctx context.Context, botID string) memprovider.Provider {
+// resolveMemoryProvider returns the bot's memory provider together with its
+// memory scope policy.
+func (r *Resolver) resolveMemoryProvider(ctx context.Context, botID string) (memprovider.Provider, string) {
 	if r.memoryRegistry == nil {
-		return nil
+		return nil, ""
 	}
 	if r.settingsService == nil {
-		return nil
+		return nil, ""
 	}
 	botSettings, err := r.settingsService.GetBot(ctx, botID)
 	if err != nil {
-		return nil
+		return nil, ""
 	}
 	providerID := strings.TrimSpace(botSettings.MemoryProviderID)
 	if providerID == "" {
-		return nil
+		return nil, ""
 	}
 	p, err := r.memoryRegistry.Get(providerID)
 	if err != nil {
 		r.logger.Warn("memory provider lookup failed", slog.String("provider_id", providerID), slog.Any("error", err))
-		return nil
+		return nil, ""
+	}
+	return p, botSettings.MemoryScopePolicy
+}
+
+// memoryScope describes the speaker and conversation of a chat request for
+// memory scoping.
+func memoryScope(req conversation.ChatRequest, policy string) memprovider.Scope {
+	return memprovider.Scope{
+		Policy:            policy,
+		UserID:            strings.TrimSpace(req.UserID),
+		ChannelIdentityID: strings.TrimSpace(req.SourceChannelIdentityID),
+		ConversationID:    memoryConversationID(req),
+		Group:             !channel.IsPrivateConversationType(req.ConversationType),
+	}
+}
+
+// memoryConversationID identifies the conversation for memory scoping: the
+// channel route when the message came from a channel, otherwise the chat.
+func memoryConversationID(req conversation.ChatRequest) string {
+	if routeID := strings.TrimSpace(req.RouteID); routeID != "" {
+		return routeID
 	}
-	return p
+	return strings.TrimSpace(req.ChatID)
 }
 
 func (r *Resolver) loadMemoryContextMessage(ctx context.Context, req conversation.ChatRequest) *conversation.ModelMessage {
-	p := r.resolveMemoryProvider(ctx, req.BotID)
+	p, policy := r.resolveMemoryProvider(ctx, req.BotID)
 	if p == nil {
 		return nil
 	}
@@ -41,6 +65,7 @@ func (r *Resolver) loadMemoryContextMessage(ctx context.Context, req conversatio
 		Query:  req.Query,
 		BotID:  req.BotID,
 		ChatID: req.ChatID,
+		Scope:  memoryScope(req, policy),
 	})
 	if err != nil {
 		r.logger.Warn("memory provider OnBeforeChat failed", slog.Any("error", err))
@@ -65,7 +90,7 @@ func (r *Resolver) storeMemory(ctx context.Context, req conversation.ChatRequest
 		return
 	}
 
-	p := r.resolveMemoryProvider(ctx, botID)
+	p, policy := r.resolveMemoryProvider(ctx, botID)
 	if p == nil {
 		return
 	}
@@ -75,6 +100,7 @@ func (r *Resolver) storeMemory(ctx context.Context, req conversation.ChatRequest
 		UserID:            strings.TrimSpace(req.UserID),
 		ChannelIdentityID: strings.TrimSpace(req.SourceChannelIdentityID),
 		DisplayName:       r.resolveDisplayName(ctx, req),
+		Scope:             memoryScope(req, policy),
 	}); err != nil {
 		r.logger.Warn("memory provider OnAfterChat failed", slog.String("bot_id", botID), slog.Any("error", err))
 	}
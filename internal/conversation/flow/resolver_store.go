@@ -10,7 +10,9 @@ import (
 	messagepkg "github.com/memohai/memoh/internal/message"
 )
 
-func (r *Resolver) storeRound(ctx context.Context, req conversation.ChatRequest, messages []conversation.ModelMessage, modelID string) error {
+func (r *Resolver) storeRound(ctx context.Context, req conversation.ChatRequest, messages []conversation.ModelMessage, model roundModel) error {
+	r.recordBudgetUsage(ctx, req, messages, model)
+
 	fullRound := make([]conversation.ModelMessage, 0, len(messages))
 
 	// When the user message was already persisted by a channel adapter, skip
@@ -28,13 +30,13 @@ func (r *Resolver) storeRound(ctx context.Context, req conversation.ChatRequest,
 		return nil
 	}
 
-	r.storeMessages(ctx, req, fullRound, modelID)
+	r.storeMessages(ctx, req, fullRound, model)
 	go r.storeMemory(context.WithoutCancel(ctx), req, fullRound)
 
 	return nil
 }
 
-func (r *Resolver) storeMessages(ctx context.Context, req conversation.ChatRequest, messages []conversation.ModelMessage, modelID string) {
+func (r *Resolver) storeMessages(ctx context.Context, req conversation.ChatRequest, messages []conversation.ModelMessage, model roundModel) {
 	if r.messageService == nil {
 		return
 	}
@@ -42,6 +44,7 @@ func (r *Resolver) storeMessages(ctx context.Context, req conversation.ChatReque
 		return
 	}
 	meta := buildRouteMetadata(req)
+	replyMeta := withFallbackMetadata(meta, model)
 	senderChannelIdentityID, senderUserID := r.resolvePersistSenderIDs(ctx, req)
 
 	// Determine the last assistant message index for outbound asset attachment.
@@ -71,7 +74,9 @@ func (r *Resolver) storeMessages(ctx context.Context, req conversation.ChatReque
 		externalMessageID := ""
 		sourceReplyToMessageID := ""
 		assets := []messagepkg.AssetRef(nil)
+		msgMeta := replyMeta
 		if msg.Role == "user" {
+			msgMeta = meta
 			messageSenderChannelIdentityID = senderChannelIdentityID
 			messageSenderUserID = senderUserID
 			externalMessageID = req.ExternalMessageID
@@ -93,10 +98,11 @@ func (r *Resolver) storeMessages(ctx context.Context, req conversation.ChatReque
 			SourceReplyToMessageID:  sourceReplyToMessageID,
 			Role:                    msg.Role,
 			Content:                 content,
-			Metadata:                meta,
+			Metadata:                msgMeta,
 			Usage:                   msg.Usage,
 			Assets:                  assets,
-			ModelID:                 modelID,
+			ModelID:                 model.ID,
+			CostUSD:                 messageCost(msg.Usage, model.Pricing),
 		}); err != nil {
 			r.logger.Warn("persist message failed", slog.Any("error", err))
 		}
@@ -178,6 +184,23 @@ func buildRouteMetadata(req conversation.ChatRequest) map[string]any {
 	return meta
 }
 
+// withFallbackMetadata records on replies that a fallback model answered in
+// place of the requested one. The route metadata map is not modified.
+func withFallbackMetadata(meta map[string]any, model roundModel) map[string]any {
+	if strings.TrimSpace(model.FallbackFrom) == "" {
+		return meta
+	}
+	out := make(map[string]any, len(meta)+1)
+	for k, v := range meta {
+		out[k] = v
+	}
+	out["model_fallback"] = map[string]any{
+		"requested_model_id": model.FallbackFrom,
+		"answered_model_id":  model.ID,
+	}
+	return out
+}
+
 func (r *Resolver) resolvePersistSenderIDs(ctx context.Context, req conversation.ChatRequest) (string, string) {
 	channelIdentityID := strings.TrimSpace(req.SourceChannelIdentityID)
 	userID := strings.TrimSpace(req.UserID)
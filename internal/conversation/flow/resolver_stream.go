@@ -3,12 +3,14 @@ package flow
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	sdk "github.com/memohai/twilight-ai/sdk"
 
 	agentpkg "github.com/memohai/memoh/internal/agent"
+	"github.com/memohai/memoh/internal/budget"
 	"github.com/memohai/memoh/internal/conversation"
 )
 
@@ -30,6 +32,13 @@ func (r *Resolver) StreamChat(ctx context.Context, req conversation.ChatRequest)
 
 		streamReq := req
 		rc, err := r.resolve(ctx, streamReq)
+		var exhausted *budget.ExhaustedError
+		if errors.As(err, &exhausted) {
+			for _, data := range budgetExhaustedEvents(exhausted) {
+				chunkCh <- conversation.StreamChunk(data)
+			}
+			return
+		}
 		if err != nil {
 			r.logger.Error("agent stream resolve failed",
 				slog.String("bot_id", streamReq.BotID),
@@ -46,24 +55,24 @@ func (r *Resolver) StreamChat(ctx context.Context, req conversation.ChatRequest)
 		cfg := rc.runConfig
 		cfg = r.prepareRunConfig(ctx, cfg)
 
-		eventCh := r.agent.Stream(ctx, cfg)
+		eventCh := r.streamWithFallback(ctx, rc, cfg)
 		stored := false
 		for event := range eventCh {
 			if event.Type == agentpkg.EventError {
 				r.logger.Error("agent stream error",
 					slog.String("bot_id", streamReq.BotID),
 					slog.String("chat_id", streamReq.ChatID),
-					slog.String("model_id", rc.model.ID),
+					slog.String("model_id", event.Model.ID),
 					slog.String("error", event.Error),
 				)
 			}
 
-			data, err := json.Marshal(event)
+			data, err := json.Marshal(event.StreamEvent)
 			if err != nil {
 				continue
 			}
 			if !stored && event.IsTerminal() && len(event.Messages) > 0 {
-				if _, storeErr := r.tryStoreStream(ctx, streamReq, data, rc.model.ID, rc); storeErr != nil {
+				if _, storeErr := r.tryStoreStream(ctx, streamReq, data, rc.answeredBy(event.Model)); storeErr != nil {
 					r.logger.Error("stream persist failed", slog.Any("error", storeErr))
 				} else {
 					stored = true
@@ -84,6 +93,17 @@ func (r *Resolver) StreamChatWS(
 	abortCh <-chan struct{},
 ) error {
 	rc, err := r.resolve(ctx, req)
+	var exhausted *budget.ExhaustedError
+	if errors.As(err, &exhausted) {
+		for _, data := range budgetExhaustedEvents(exhausted) {
+			select {
+			case eventCh <- data:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("resolve: %w", err)
 	}
@@ -105,26 +125,25 @@ func (r *Resolver) StreamChatWS(
 	cfg := rc.runConfig
 	cfg = r.prepareRunConfig(streamCtx, cfg)
 
-	agentEventCh := r.agent.Stream(streamCtx, cfg)
-	modelID := rc.model.ID
+	agentEventCh := r.streamWithFallback(streamCtx, rc, cfg)
 	stored := false
 	for event := range agentEventCh {
 		if event.Type == agentpkg.EventError {
 			r.logger.Error("agent stream error",
 				slog.String("bot_id", req.BotID),
 				slog.String("chat_id", req.ChatID),
-				slog.String("model_id", modelID),
+				slog.String("model_id", event.Model.ID),
 				slog.String("error", event.Error),
 			)
 		}
 
-		data, err := json.Marshal(event)
+		data, err := json.Marshal(event.StreamEvent)
 		if err != nil {
 			continue
 		}
 
 		if !stored && event.IsTerminal() && len(event.Messages) > 0 {
-			if _, storeErr := r.tryStoreStream(ctx, req, data, modelID, rc); storeErr != nil {
+			if _, storeErr := r.tryStoreStream(ctx, req, data, rc.answeredBy(event.Model)); storeErr != nil {
 				r.logger.Error("ws persist failed", slog.Any("error", storeErr))
 			} else {
 				stored = true
@@ -141,8 +160,9 @@ func (r *Resolver) StreamChatWS(
 	return nil
 }
 
-// tryStoreStream attempts to extract final messages from a stream event and persist them.
-func (r *Resolver) tryStoreStream(ctx context.Context, req conversation.ChatRequest, data []byte, modelID string, rc resolvedContext) (bool, error) {
+// tryStoreStream attempts to extract final messages from a stream event and
+// persist them. rc must be bound to the model that produced the event.
+func (r *Resolver) tryStoreStream(ctx context.Context, req conversation.ChatRequest, data []byte, rc resolvedContext) (bool, error) {
 	var envelope struct {
 		Type     string          `json:"type"`
 		Messages json.RawMessage `json:"messages"`
@@ -162,7 +182,7 @@ func (r *Resolver) tryStoreStream(ctx context.Context, req conversation.ChatRequ
 	outputMessages := sdkMessagesToModelMessages(sdkMsgs)
 	roundMessages := prependUserMessage(req.Query, outputMessages)
 
-	if err := r.storeRound(ctx, req, roundMessages, modelID); err != nil {
+	if err := r.storeRound(ctx, req, roundMessages, rc.roundModel()); err != nil {
 		return false, err
 	}
 
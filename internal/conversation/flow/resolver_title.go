@@ -74,6 +74,12 @@ func (r *Resolver) maybeGenerateSessionTitle(ctx context.Context, req conversati
 		return
 	}
 
+	// Title generation is billed to the turn's budget; once it is used up
+	// the session keeps its empty title rather than overspending.
+	if r.checkBudget(ctx, req) != nil {
+		return
+	}
+
 	r.logger.Info("title gen: generating title", slog.String("session_id", sessionID), slog.String("title_model_id", titleModelID))
 
 	titleModel, provider, err := r.fetchChatModel(ctx, titleModelID)
@@ -82,7 +88,14 @@ func (r *Resolver) maybeGenerateSessionTitle(ctx context.Context, req conversati
 		return
 	}
 
-	title := r.generateTitle(ctx, titleModel, provider, userQuery)
+	candidates := append([]modelCandidate{{model: titleModel, provider: provider}},
+		r.resolveFallbackChain(ctx, titleModel.ID, botSettings.ModelFallbacks.Title)...)
+	title := ""
+	for _, c := range candidates {
+		if title = r.generateTitle(ctx, req, c.model, c.provider, userQuery); title != "" {
+			break
+		}
+	}
 	if title == "" {
 		return
 	}
@@ -95,7 +108,7 @@ func (r *Resolver) maybeGenerateSessionTitle(ctx context.Context, req conversati
 	}
 }
 
-func (r *Resolver) generateTitle(ctx context.Context, model models.GetResponse, provider sqlc.LlmProvider, userQuery string) string {
+func (r *Resolver) generateTitle(ctx context.Context, req conversation.ChatRequest, model models.GetResponse, provider sqlc.LlmProvider, userQuery string) string {
 	userSnippet := truncate(strings.TrimSpace(userQuery), titlePromptMaxInputChars)
 	if userSnippet == "" {
 		return ""
@@ -117,7 +130,7 @@ func (r *Resolver) generateTitle(ctx context.Context, model models.GetResponse,
 	defer cancel()
 
 	client := sdk.NewClient()
-	text, err := client.GenerateText(genCtx,
+	result, err := client.GenerateTextResult(genCtx,
 		sdk.WithModel(sdkModel),
 		sdk.WithMessages([]sdk.Message{sdk.UserMessage(prompt)}),
 	)
@@ -125,8 +138,9 @@ func (r *Resolver) generateTitle(ctx context.Context, model models.GetResponse,
 		r.logger.Warn("title gen: LLM call failed", slog.Any("error", err))
 		return ""
 	}
+	r.recordAuxiliaryUsage(ctx, req, model.ID, result.Usage)
 
-	title := strings.TrimSpace(text)
+	title := strings.TrimSpace(result.Text)
 	title = strings.Trim(title, "\"'`")
 	title = strings.TrimSpace(title)
 	return title
@@ -10,6 +10,7 @@ import (
 
 	agentpkg "github.com/memohai/memoh/internal/agent"
 	"github.com/memohai/memoh/internal/conversation"
+	"github.com/memohai/memoh/internal/eventtrigger"
 	"github.com/memohai/memoh/internal/heartbeat"
 	"github.com/memohai/memoh/internal/schedule"
 )
@@ -47,18 +48,23 @@ func (r *Resolver) TriggerSchedule(ctx context.Context, botID string, payload sc
 		Pattern:     payload.Pattern,
 		MaxCalls:    payload.MaxCalls,
 		Command:     payload.Command,
+		Timezone:    payload.Timezone,
+		RunAt:       payload.RunAt,
+
+		DeliveryPlatform: payload.DeliveryPlatform,
+		DeliveryTarget:   payload.DeliveryTarget,
 	})
 	cfg.Messages = append(cfg.Messages, sdk.UserMessage(schedulePrompt))
 	cfg = r.prepareRunConfig(ctx, cfg)
 
-	result, err := r.agent.Generate(ctx, cfg)
+	result, rc, err := r.generateWithFallback(ctx, rc, cfg)
 	if err != nil {
 		return schedule.TriggerResult{}, err
 	}
 
 	outputMessages := sdkMessagesToModelMessages(result.Messages)
 	roundMessages := prependUserMessage(req.Query, outputMessages)
-	storeErr := r.storeRound(ctx, req, roundMessages, rc.model.ID)
+	storeErr := r.storeRound(ctx, req, roundMessages, rc.roundModel())
 
 	totalUsageJSON, _ := json.Marshal(result.Usage)
 	return schedule.TriggerResult{
@@ -69,6 +75,68 @@ func (r *Resolver) TriggerSchedule(ctx context.Context, botID string, payload sc
 	}, storeErr
 }
 
+// TriggerEvent executes an event trigger's command via the internal agent.
+func (r *Resolver) TriggerEvent(ctx context.Context, botID string, payload eventtrigger.TriggerPayload, token string) (eventtrigger.TriggerResult, error) {
+	if strings.TrimSpace(botID) == "" {
+		return eventtrigger.TriggerResult{}, errors.New("bot id is required")
+	}
+	if strings.TrimSpace(payload.Command) == "" {
+		return eventtrigger.TriggerResult{}, errors.New("trigger command is required")
+	}
+
+	req := conversation.ChatRequest{
+		BotID:     botID,
+		ChatID:    botID,
+		SessionID: payload.SessionID,
+		Query:     payload.Command,
+		UserID:    payload.OwnerUserID,
+		Token:     token,
+	}
+	rc, err := r.resolve(ctx, req)
+	if err != nil {
+		return eventtrigger.TriggerResult{}, err
+	}
+
+	cfg := rc.runConfig
+	cfg.SessionType = "trigger"
+	cfg.Identity.ChannelIdentityID = strings.TrimSpace(payload.OwnerUserID)
+
+	var details string
+	if payload.Event.Data != nil {
+		if raw, err := json.MarshalIndent(payload.Event.Data, "", "  "); err == nil {
+			details = string(raw)
+		}
+	}
+	triggerPrompt := agentpkg.GenerateTriggerPrompt(agentpkg.TriggerEvent{
+		ID:          payload.ID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Source:      payload.Source,
+		Command:     payload.Command,
+		Summary:     payload.Event.Summary,
+		Details:     details,
+	})
+	cfg.Messages = append(cfg.Messages, sdk.UserMessage(triggerPrompt))
+	cfg = r.prepareRunConfig(ctx, cfg)
+
+	result, rc, err := r.generateWithFallback(ctx, rc, cfg)
+	if err != nil {
+		return eventtrigger.TriggerResult{}, err
+	}
+
+	outputMessages := sdkMessagesToModelMessages(result.Messages)
+	roundMessages := prependUserMessage(triggerPrompt, outputMessages)
+	storeErr := r.storeRound(ctx, req, roundMessages, rc.roundModel())
+
+	totalUsageJSON, _ := json.Marshal(result.Usage)
+	return eventtrigger.TriggerResult{
+		Status:     "ok",
+		Text:       strings.TrimSpace(result.Text),
+		UsageBytes: totalUsageJSON,
+		ModelID:    rc.model.ID,
+	}, storeErr
+}
+
 // TriggerHeartbeat executes a heartbeat check via the internal agent.
 func (r *Resolver) TriggerHeartbeat(ctx context.Context, botID string, payload heartbeat.TriggerPayload, token string) (heartbeat.TriggerResult, error) {
 	if strings.TrimSpace(botID) == "" {
@@ -76,8 +144,10 @@ func (r *Resolver) TriggerHeartbeat(ctx context.Context, botID string, payload h
 	}
 
 	var heartbeatModel string
+	var heartbeatFallbacks []string
 	if botSettings, err := r.loadBotSettings(ctx, botID); err == nil {
 		heartbeatModel = strings.TrimSpace(botSettings.HeartbeatModelID)
+		heartbeatFallbacks = botSettings.ModelFallbacks.Heartbeat
 	}
 
 	req := conversation.ChatRequest{
@@ -93,6 +163,10 @@ func (r *Resolver) TriggerHeartbeat(ctx context.Context, botID string, payload h
 	if err != nil {
 		return heartbeat.TriggerResult{}, err
 	}
+	if heartbeatModel != "" {
+		// A dedicated heartbeat model uses its own fallback chain.
+		rc.fallbacks = r.resolveFallbackChain(ctx, rc.model.ID, heartbeatFallbacks)
+	}
 
 	cfg := rc.runConfig
 	cfg.SessionType = "heartbeat"
@@ -103,24 +177,24 @@ func (r *Resolver) TriggerHeartbeat(ctx context.Context, botID string, payload h
 		fs := agentpkg.NewFSClient(r.agent.BridgeProvider(), botID)
 		checklist = fs.ReadTextSafe(ctx, "/data/HEARTBEAT.md")
 	}
-	heartbeatPrompt := agentpkg.GenerateHeartbeatPrompt(payload.Interval, checklist, payload.LastHeartbeatAt)
+	heartbeatPrompt := agentpkg.GenerateHeartbeatPrompt(payload.Interval, checklist, payload.LastHeartbeatAt, payload.Timezone)
 	cfg.Messages = append(cfg.Messages, sdk.UserMessage(heartbeatPrompt))
 	cfg = r.prepareRunConfig(ctx, cfg)
 
-	result, err := r.agent.Generate(ctx, cfg)
+	result, rc, err := r.generateWithFallback(ctx, rc, cfg)
 	if err != nil {
 		return heartbeat.TriggerResult{}, err
 	}
 
 	status := "alert"
-	text := strings.TrimSpace(result.Text)
+	nextInterval, text := heartbeat.ParseNextCheck(strings.TrimSpace(result.Text))
 	if isHeartbeatOK(text) {
 		status = "ok"
 	}
 
 	outputMessages := sdkMessagesToModelMessages(result.Messages)
 	roundMessages := prependUserMessage(heartbeatPrompt, outputMessages)
-	_ = r.storeRound(ctx, req, roundMessages, rc.model.ID)
+	_ = r.storeRound(ctx, req, roundMessages, rc.roundModel())
 
 	totalUsageJSON, _ := json.Marshal(result.Usage)
 	return heartbeat.TriggerResult{
@@ -130,6 +204,8 @@ func (r *Resolver) TriggerHeartbeat(ctx context.Context, botID string, payload h
 		UsageBytes: totalUsageJSON,
 		ModelID:    rc.model.ID,
 		SessionID:  payload.SessionID,
+
+		NextInterval: nextInterval,
 	}, nil
 }
 
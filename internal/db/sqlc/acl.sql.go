@@ -34,6 +34,26 @@ func (q *Queries) DeleteBotACLRuleByID(ctx context.Context, id pgtype.UUID) erro
 	return err
 }
 
+const deleteBotACLToolRule = `-- name: DeleteBotACLToolRule :execrows
+DELETE FROM bot_acl_rules
+WHERE id = $1
+  AND bot_id = $2
+  AND action = 'tool.use'
+`
+
+type DeleteBotACLToolRuleParams struct {
+	ID    pgtype.UUID `json:"id"`
+	BotID pgtype.UUID `json:"bot_id"`
+}
+
+func (q *Queries) DeleteBotACLToolRule(ctx context.Context, arg DeleteBotACLToolRuleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBotACLToolRule, arg.ID, arg.BotID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const hasBotACLChannelIdentityRule = `-- name: HasBotACLChannelIdentityRule :one
 SELECT EXISTS (
   SELECT 1
@@ -251,6 +271,107 @@ func (q *Queries) ListBotACLSubjectRulesByEffect(ctx context.Context, arg ListBo
 	return items, nil
 }
 
+const listBotACLToolRules = `-- name: ListBotACLToolRules :many
+SELECT
+  r.id,
+  r.bot_id,
+  r.action,
+  r.effect,
+  r.subject_kind,
+  r.user_id,
+  r.channel_identity_id,
+  r.tool_pattern,
+  r.created_by_user_id,
+  r.created_at,
+  r.updated_at,
+  u.username AS user_username,
+  u.display_name AS user_display_name,
+  u.avatar_url AS user_avatar_url,
+  ci.channel_type,
+  ci.channel_subject_id,
+  ci.display_name AS channel_identity_display_name,
+  ci.avatar_url AS channel_identity_avatar_url,
+  linked.id AS linked_user_id,
+  linked.username AS linked_user_username,
+  linked.display_name AS linked_user_display_name,
+  linked.avatar_url AS linked_user_avatar_url
+FROM bot_acl_rules r
+LEFT JOIN users u ON u.id = r.user_id
+LEFT JOIN channel_identities ci ON ci.id = r.channel_identity_id
+LEFT JOIN users linked ON linked.id = ci.user_id
+WHERE r.bot_id = $1
+  AND r.action = 'tool.use'
+ORDER BY r.created_at DESC
+`
+
+type ListBotACLToolRulesRow struct {
+	ID                         pgtype.UUID        `json:"id"`
+	BotID                      pgtype.UUID        `json:"bot_id"`
+	Action                     string             `json:"action"`
+	Effect                     string             `json:"effect"`
+	SubjectKind                string             `json:"subject_kind"`
+	UserID                     pgtype.UUID        `json:"user_id"`
+	ChannelIdentityID          pgtype.UUID        `json:"channel_identity_id"`
+	ToolPattern                pgtype.Text        `json:"tool_pattern"`
+	CreatedByUserID            pgtype.UUID        `json:"created_by_user_id"`
+	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
+	UserUsername               pgtype.Text        `json:"user_username"`
+	UserDisplayName            pgtype.Text        `json:"user_display_name"`
+	UserAvatarUrl              pgtype.Text        `json:"user_avatar_url"`
+	ChannelType                pgtype.Text        `json:"channel_type"`
+	ChannelSubjectID           pgtype.Text        `json:"channel_subject_id"`
+	ChannelIdentityDisplayName pgtype.Text        `json:"channel_identity_display_name"`
+	ChannelIdentityAvatarUrl   pgtype.Text        `json:"channel_identity_avatar_url"`
+	LinkedUserID               pgtype.UUID        `json:"linked_user_id"`
+	LinkedUserUsername         pgtype.Text        `json:"linked_user_username"`
+	LinkedUserDisplayName      pgtype.Text        `json:"linked_user_display_name"`
+	LinkedUserAvatarUrl        pgtype.Text        `json:"linked_user_avatar_url"`
+}
+
+func (q *Queries) ListBotACLToolRules(ctx context.Context, botID pgtype.UUID) ([]ListBotACLToolRulesRow, error) {
+	rows, err := q.db.Query(ctx, listBotACLToolRules, botID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBotACLToolRulesRow
+	for rows.Next() {
+		var i ListBotACLToolRulesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BotID,
+			&i.Action,
+			&i.Effect,
+			&i.SubjectKind,
+			&i.UserID,
+			&i.ChannelIdentityID,
+			&i.ToolPattern,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserUsername,
+			&i.UserDisplayName,
+			&i.UserAvatarUrl,
+			&i.ChannelType,
+			&i.ChannelSubjectID,
+			&i.ChannelIdentityDisplayName,
+			&i.ChannelIdentityAvatarUrl,
+			&i.LinkedUserID,
+			&i.LinkedUserUsername,
+			&i.LinkedUserDisplayName,
+			&i.LinkedUserAvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertBotACLChannelIdentityRule = `-- name: UpsertBotACLChannelIdentityRule :one
 INSERT INTO bot_acl_rules (
   bot_id, action, effect, subject_kind, channel_identity_id,
@@ -269,7 +390,7 @@ ON CONFLICT ON CONSTRAINT bot_acl_rules_unique_channel_identity
 DO UPDATE SET
   created_by_user_id = COALESCE(EXCLUDED.created_by_user_id, bot_acl_rules.created_by_user_id),
   updated_at = now()
-RETURNING id, bot_id, action, effect, subject_kind, user_id, channel_identity_id, source_channel, source_conversation_type, source_conversation_id, source_thread_id, created_by_user_id, created_at, updated_at
+RETURNING id, bot_id, action, effect, subject_kind, user_id, channel_identity_id, source_channel, source_conversation_type, source_conversation_id, source_thread_id, tool_pattern, created_by_user_id, created_at, updated_at
 `
 
 type UpsertBotACLChannelIdentityRuleParams struct {
@@ -307,6 +428,7 @@ func (q *Queries) UpsertBotACLChannelIdentityRule(ctx context.Context, arg Upser
 		&i.SourceConversationType,
 		&i.SourceConversationID,
 		&i.SourceThreadID,
+		&i.ToolPattern,
 		&i.CreatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -321,7 +443,7 @@ ON CONFLICT ON CONSTRAINT bot_acl_rules_unique_user
 DO UPDATE SET
   created_by_user_id = COALESCE(EXCLUDED.created_by_user_id, bot_acl_rules.created_by_user_id),
   updated_at = now()
-RETURNING id, bot_id, action, effect, subject_kind, user_id, channel_identity_id, source_channel, source_conversation_type, source_conversation_id, source_thread_id, created_by_user_id, created_at, updated_at
+RETURNING id, bot_id, action, effect, subject_kind, user_id, channel_identity_id, source_channel, source_conversation_type, source_conversation_id, source_thread_id, tool_pattern, created_by_user_id, created_at, updated_at
 `
 
 type UpsertBotACLGuestAllAllowRuleParams struct {
@@ -344,6 +466,103 @@ func (q *Queries) UpsertBotACLGuestAllAllowRule(ctx context.Context, arg UpsertB
 		&i.SourceConversationType,
 		&i.SourceConversationID,
 		&i.SourceThreadID,
+		&i.ToolPattern,
+		&i.CreatedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertBotACLToolChannelIdentityRule = `-- name: UpsertBotACLToolChannelIdentityRule :one
+INSERT INTO bot_acl_rules (bot_id, action, effect, subject_kind, channel_identity_id, tool_pattern, created_by_user_id)
+VALUES ($1, 'tool.use', $2, 'channel_identity', $3, $5::text, $4)
+ON CONFLICT ON CONSTRAINT bot_acl_rules_unique_channel_identity
+DO UPDATE SET
+  created_by_user_id = COALESCE(EXCLUDED.created_by_user_id, bot_acl_rules.created_by_user_id),
+  updated_at = now()
+RETURNING id, bot_id, action, effect, subject_kind, user_id, channel_identity_id, source_channel, source_conversation_type, source_conversation_id, source_thread_id, tool_pattern, created_by_user_id, created_at, updated_at
+`
+
+type UpsertBotACLToolChannelIdentityRuleParams struct {
+	BotID             pgtype.UUID `json:"bot_id"`
+	Effect            string      `json:"effect"`
+	ChannelIdentityID pgtype.UUID `json:"channel_identity_id"`
+	CreatedByUserID   pgtype.UUID `json:"created_by_user_id"`
+	ToolPattern       string      `json:"tool_pattern"`
+}
+
+func (q *Queries) UpsertBotACLToolChannelIdentityRule(ctx context.Context, arg UpsertBotACLToolChannelIdentityRuleParams) (BotAclRule, error) {
+	row := q.db.QueryRow(ctx, upsertBotACLToolChannelIdentityRule,
+		arg.BotID,
+		arg.Effect,
+		arg.ChannelIdentityID,
+		arg.CreatedByUserID,
+		arg.ToolPattern,
+	)
+	var i BotAclRule
+	err := row.Scan(
+		&i.ID,
+		&i.BotID,
+		&i.Action,
+		&i.Effect,
+		&i.SubjectKind,
+		&i.UserID,
+		&i.ChannelIdentityID,
+		&i.SourceChannel,
+		&i.SourceConversationType,
+		&i.SourceConversationID,
+		&i.SourceThreadID,
+		&i.ToolPattern,
+		&i.CreatedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertBotACLToolUserRule = `-- name: UpsertBotACLToolUserRule :one
+INSERT INTO bot_acl_rules (bot_id, action, effect, subject_kind, user_id, tool_pattern, created_by_user_id)
+VALUES ($1, 'tool.use', $2, $3, $4, $6::text, $5)
+ON CONFLICT ON CONSTRAINT bot_acl_rules_unique_user
+DO UPDATE SET
+  created_by_user_id = COALESCE(EXCLUDED.created_by_user_id, bot_acl_rules.created_by_user_id),
+  updated_at = now()
+RETURNING id, bot_id, action, effect, subject_kind, user_id, channel_identity_id, source_channel, source_conversation_type, source_conversation_id, source_thread_id, tool_pattern, created_by_user_id, created_at, updated_at
+`
+
+type UpsertBotACLToolUserRuleParams struct {
+	BotID           pgtype.UUID `json:"bot_id"`
+	Effect          string      `json:"effect"`
+	SubjectKind     string      `json:"subject_kind"`
+	UserID          pgtype.UUID `json:"user_id"`
+	CreatedByUserID pgtype.UUID `json:"created_by_user_id"`
+	ToolPattern     string      `json:"tool_pattern"`
+}
+
+func (q *Queries) UpsertBotACLToolUserRule(ctx context.Context, arg UpsertBotACLToolUserRuleParams) (BotAclRule, error) {
+	row := q.db.QueryRow(ctx, upsertBotACLToolUserRule,
+		arg.BotID,
+		arg.Effect,
+		arg.SubjectKind,
+		arg.UserID,
+		arg.CreatedByUserID,
+		arg.ToolPattern,
+	)
+	var i BotAclRule
+	err := row.Scan(
+		&i.ID,
+		&i.BotID,
+		&i.Action,
+		&i.Effect,
+		&i.SubjectKind,
+		&i.UserID,
+		&i.ChannelIdentityID,
+		&i.SourceChannel,
+		&i.SourceConversationType,
+		&i.SourceConversationID,
+		&i.SourceThreadID,
+		&i.ToolPattern,
 		&i.CreatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -369,7 +588,7 @@ ON CONFLICT ON CONSTRAINT bot_acl_rules_unique_user
 DO UPDATE SET
   created_by_user_id = COALESCE(EXCLUDED.created_by_user_id, bot_acl_rules.created_by_user_id),
   updated_at = now()
-RETURNING id, bot_id, action, effect, subject_kind, user_id, channel_identity_id, source_channel, source_conversation_type, source_conversation_id, source_thread_id, created_by_user_id, created_at, updated_at
+RETURNING id, bot_id, action, effect, subject_kind, user_id, channel_identity_id, source_channel, source_conversation_type, source_conversation_id, source_thread_id, tool_pattern, created_by_user_id, created_at, updated_at
 `
 
 type UpsertBotACLUserRuleParams struct {
@@ -407,6 +626,7 @@ func (q *Queries) UpsertBotACLUserRule(ctx context.Context, arg UpsertBotACLUser
 		&i.SourceConversationType,
 		&i.SourceConversationID,
 		&i.SourceThreadID,
+		&i.ToolPattern,
 		&i.CreatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
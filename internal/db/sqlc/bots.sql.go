@@ -156,6 +156,43 @@ func (q *Queries) GetBotByID(ctx context.Context, id pgtype.UUID) (GetBotByIDRow
 	return i, err
 }
 
+const getBotHeartbeatConfig = `-- name: GetBotHeartbeatConfig :one
+SELECT id, owner_user_id, status, heartbeat_enabled, heartbeat_interval, timezone, heartbeat_active_start, heartbeat_active_end, heartbeat_jitter, heartbeat_max_interval
+FROM bots
+WHERE id = $1
+`
+
+type GetBotHeartbeatConfigRow struct {
+	ID                   pgtype.UUID `json:"id"`
+	OwnerUserID          pgtype.UUID `json:"owner_user_id"`
+	Status               string      `json:"status"`
+	HeartbeatEnabled     bool        `json:"heartbeat_enabled"`
+	HeartbeatInterval    int32       `json:"heartbeat_interval"`
+	Timezone             string      `json:"timezone"`
+	HeartbeatActiveStart string      `json:"heartbeat_active_start"`
+	HeartbeatActiveEnd   string      `json:"heartbeat_active_end"`
+	HeartbeatJitter      int32       `json:"heartbeat_jitter"`
+	HeartbeatMaxInterval int32       `json:"heartbeat_max_interval"`
+}
+
+func (q *Queries) GetBotHeartbeatConfig(ctx context.Context, id pgtype.UUID) (GetBotHeartbeatConfigRow, error) {
+	row := q.db.QueryRow(ctx, getBotHeartbeatConfig, id)
+	var i GetBotHeartbeatConfigRow
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerUserID,
+		&i.Status,
+		&i.HeartbeatEnabled,
+		&i.HeartbeatInterval,
+		&i.Timezone,
+		&i.HeartbeatActiveStart,
+		&i.HeartbeatActiveEnd,
+		&i.HeartbeatJitter,
+		&i.HeartbeatMaxInterval,
+	)
+	return i, err
+}
+
 const listBotsByOwner = `-- name: ListBotsByOwner :many
 SELECT id, owner_user_id, display_name, avatar_url, is_active, status, max_context_load_time, max_context_tokens, language, reasoning_enabled, reasoning_effort, chat_model_id, search_provider_id, memory_provider_id, heartbeat_enabled, heartbeat_interval, heartbeat_prompt, metadata, created_at, updated_at
 FROM bots
@@ -228,17 +265,22 @@ func (q *Queries) ListBotsByOwner(ctx context.Context, ownerUserID pgtype.UUID)
 }
 
 const listHeartbeatEnabledBots = `-- name: ListHeartbeatEnabledBots :many
-SELECT id, owner_user_id, heartbeat_enabled, heartbeat_interval, heartbeat_prompt
+SELECT id, owner_user_id, heartbeat_enabled, heartbeat_interval, heartbeat_prompt, timezone, heartbeat_active_start, heartbeat_active_end, heartbeat_jitter, heartbeat_max_interval
 FROM bots
 WHERE heartbeat_enabled = true AND status = 'ready'
 `
 
 type ListHeartbeatEnabledBotsRow struct {
-	ID                pgtype.UUID `json:"id"`
-	OwnerUserID       pgtype.UUID `json:"owner_user_id"`
-	HeartbeatEnabled  bool        `json:"heartbeat_enabled"`
-	HeartbeatInterval int32       `json:"heartbeat_interval"`
-	HeartbeatPrompt   string      `json:"heartbeat_prompt"`
+	ID                   pgtype.UUID `json:"id"`
+	OwnerUserID          pgtype.UUID `json:"owner_user_id"`
+	HeartbeatEnabled     bool        `json:"heartbeat_enabled"`
+	HeartbeatInterval    int32       `json:"heartbeat_interval"`
+	HeartbeatPrompt      string      `json:"heartbeat_prompt"`
+	Timezone             string      `json:"timezone"`
+	HeartbeatActiveStart string      `json:"heartbeat_active_start"`
+	HeartbeatActiveEnd   string      `json:"heartbeat_active_end"`
+	HeartbeatJitter      int32       `json:"heartbeat_jitter"`
+	HeartbeatMaxInterval int32       `json:"heartbeat_max_interval"`
 }
 
 func (q *Queries) ListHeartbeatEnabledBots(ctx context.Context) ([]ListHeartbeatEnabledBotsRow, error) {
@@ -256,6 +298,11 @@ func (q *Queries) ListHeartbeatEnabledBots(ctx context.Context) ([]ListHeartbeat
 			&i.HeartbeatEnabled,
 			&i.HeartbeatInterval,
 			&i.HeartbeatPrompt,
+			&i.Timezone,
+			&i.HeartbeatActiveStart,
+			&i.HeartbeatActiveEnd,
+			&i.HeartbeatJitter,
+			&i.HeartbeatMaxInterval,
 		); err != nil {
 			return nil, err
 		}
@@ -11,6 +11,24 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const deleteBotNetworkPolicy = `-- name: DeleteBotNetworkPolicy :exec
+DELETE FROM bot_network_policies WHERE bot_id = $1
+`
+
+func (q *Queries) DeleteBotNetworkPolicy(ctx context.Context, botID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteBotNetworkPolicy, botID)
+	return err
+}
+
+const deleteBotResourceLimits = `-- name: DeleteBotResourceLimits :exec
+DELETE FROM bot_resource_limits WHERE bot_id = $1
+`
+
+func (q *Queries) DeleteBotResourceLimits(ctx context.Context, botID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteBotResourceLimits, botID)
+	return err
+}
+
 const deleteContainerByBotID = `-- name: DeleteContainerByBotID :exec
 DELETE FROM containers WHERE bot_id = $1
 `
@@ -20,6 +38,44 @@ func (q *Queries) DeleteContainerByBotID(ctx context.Context, botID pgtype.UUID)
 	return err
 }
 
+const getBotNetworkPolicy = `-- name: GetBotNetworkPolicy :one
+SELECT bot_id, egress_mode, allow_list, updated_at
+FROM bot_network_policies
+WHERE bot_id = $1
+`
+
+func (q *Queries) GetBotNetworkPolicy(ctx context.Context, botID pgtype.UUID) (BotNetworkPolicy, error) {
+	row := q.db.QueryRow(ctx, getBotNetworkPolicy, botID)
+	var i BotNetworkPolicy
+	err := row.Scan(
+		&i.BotID,
+		&i.EgressMode,
+		&i.AllowList,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getBotResourceLimits = `-- name: GetBotResourceLimits :one
+SELECT bot_id, cpu_shares, cpu_millicores, memory_mb, pids_limit, updated_at
+FROM bot_resource_limits
+WHERE bot_id = $1
+`
+
+func (q *Queries) GetBotResourceLimits(ctx context.Context, botID pgtype.UUID) (BotResourceLimit, error) {
+	row := q.db.QueryRow(ctx, getBotResourceLimits, botID)
+	var i BotResourceLimit
+	err := row.Scan(
+		&i.BotID,
+		&i.CpuShares,
+		&i.CpuMillicores,
+		&i.MemoryMb,
+		&i.PidsLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getContainerByBotID = `-- name: GetContainerByBotID :one
 SELECT id, bot_id, container_id, container_name, image, status, namespace, auto_start, container_path, created_at, updated_at, last_started_at, last_stopped_at FROM containers WHERE bot_id = $1 ORDER BY updated_at DESC LIMIT 1
 `
@@ -121,6 +177,80 @@ func (q *Queries) UpdateContainerStopped(ctx context.Context, botID pgtype.UUID)
 	return err
 }
 
+const upsertBotNetworkPolicy = `-- name: UpsertBotNetworkPolicy :one
+INSERT INTO bot_network_policies (bot_id, egress_mode, allow_list)
+VALUES ($1, $2, $3::text[])
+ON CONFLICT (bot_id) DO UPDATE SET
+  egress_mode = EXCLUDED.egress_mode,
+  allow_list = EXCLUDED.allow_list,
+  updated_at = now()
+RETURNING bot_id, egress_mode, allow_list, updated_at
+`
+
+type UpsertBotNetworkPolicyParams struct {
+	BotID      pgtype.UUID `json:"bot_id"`
+	EgressMode string      `json:"egress_mode"`
+	AllowList  []string    `json:"allow_list"`
+}
+
+func (q *Queries) UpsertBotNetworkPolicy(ctx context.Context, arg UpsertBotNetworkPolicyParams) (BotNetworkPolicy, error) {
+	row := q.db.QueryRow(ctx, upsertBotNetworkPolicy, arg.BotID, arg.EgressMode, arg.AllowList)
+	var i BotNetworkPolicy
+	err := row.Scan(
+		&i.BotID,
+		&i.EgressMode,
+		&i.AllowList,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertBotResourceLimits = `-- name: UpsertBotResourceLimits :one
+INSERT INTO bot_resource_limits (bot_id, cpu_shares, cpu_millicores, memory_mb, pids_limit)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5
+)
+ON CONFLICT (bot_id) DO UPDATE SET
+  cpu_shares = EXCLUDED.cpu_shares,
+  cpu_millicores = EXCLUDED.cpu_millicores,
+  memory_mb = EXCLUDED.memory_mb,
+  pids_limit = EXCLUDED.pids_limit,
+  updated_at = now()
+RETURNING bot_id, cpu_shares, cpu_millicores, memory_mb, pids_limit, updated_at
+`
+
+type UpsertBotResourceLimitsParams struct {
+	BotID         pgtype.UUID `json:"bot_id"`
+	CpuShares     int32       `json:"cpu_shares"`
+	CpuMillicores int32       `json:"cpu_millicores"`
+	MemoryMb      int32       `json:"memory_mb"`
+	PidsLimit     int32       `json:"pids_limit"`
+}
+
+func (q *Queries) UpsertBotResourceLimits(ctx context.Context, arg UpsertBotResourceLimitsParams) (BotResourceLimit, error) {
+	row := q.db.QueryRow(ctx, upsertBotResourceLimits,
+		arg.BotID,
+		arg.CpuShares,
+		arg.CpuMillicores,
+		arg.MemoryMb,
+		arg.PidsLimit,
+	)
+	var i BotResourceLimit
+	err := row.Scan(
+		&i.BotID,
+		&i.CpuShares,
+		&i.CpuMillicores,
+		&i.MemoryMb,
+		&i.PidsLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const upsertContainer = `-- name: UpsertContainer :exec
 INSERT INTO containers (
   bot_id, container_id, container_name, image, status, namespace, auto_start,
@@ -18,18 +18,26 @@ SET status = $2,
     error_message = $4,
     usage = $5,
     model_id = $6,
+    consecutive_ok = $7,
+    requested_interval = $8,
+    next_run_at = $9,
+    next_run_reason = $10,
     completed_at = now()
 WHERE id = $1
-RETURNING id, bot_id, session_id, status, result_text, error_message, usage, model_id, started_at, completed_at
+RETURNING id, bot_id, session_id, status, result_text, error_message, usage, model_id, started_at, completed_at, consecutive_ok, requested_interval, next_run_at, next_run_reason
 `
 
 type CompleteHeartbeatLogParams struct {
-	ID           pgtype.UUID `json:"id"`
-	Status       string      `json:"status"`
-	ResultText   string      `json:"result_text"`
-	ErrorMessage string      `json:"error_message"`
-	Usage        []byte      `json:"usage"`
-	ModelID      pgtype.UUID `json:"model_id"`
+	ID                pgtype.UUID        `json:"id"`
+	Status            string             `json:"status"`
+	ResultText        string             `json:"result_text"`
+	ErrorMessage      string             `json:"error_message"`
+	Usage             []byte             `json:"usage"`
+	ModelID           pgtype.UUID        `json:"model_id"`
+	ConsecutiveOk     int32              `json:"consecutive_ok"`
+	RequestedInterval pgtype.Int4        `json:"requested_interval"`
+	NextRunAt         pgtype.Timestamptz `json:"next_run_at"`
+	NextRunReason     string             `json:"next_run_reason"`
 }
 
 func (q *Queries) CompleteHeartbeatLog(ctx context.Context, arg CompleteHeartbeatLogParams) (BotHeartbeatLog, error) {
@@ -40,6 +48,10 @@ func (q *Queries) CompleteHeartbeatLog(ctx context.Context, arg CompleteHeartbea
 		arg.ErrorMessage,
 		arg.Usage,
 		arg.ModelID,
+		arg.ConsecutiveOk,
+		arg.RequestedInterval,
+		arg.NextRunAt,
+		arg.NextRunReason,
 	)
 	var i BotHeartbeatLog
 	err := row.Scan(
@@ -53,6 +65,10 @@ func (q *Queries) CompleteHeartbeatLog(ctx context.Context, arg CompleteHeartbea
 		&i.ModelID,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.ConsecutiveOk,
+		&i.RequestedInterval,
+		&i.NextRunAt,
+		&i.NextRunReason,
 	)
 	return i, err
 }
@@ -107,7 +123,7 @@ func (q *Queries) DeleteHeartbeatLogsByBot(ctx context.Context, botID pgtype.UUI
 }
 
 const listHeartbeatLogsByBot = `-- name: ListHeartbeatLogsByBot :many
-SELECT id, bot_id, session_id, status, result_text, error_message, usage, started_at, completed_at
+SELECT id, bot_id, session_id, status, result_text, error_message, usage, started_at, completed_at, consecutive_ok, requested_interval, next_run_at, next_run_reason
 FROM bot_heartbeat_logs
 WHERE bot_id = $1
   AND ($2::timestamptz IS NULL OR started_at < $2::timestamptz)
@@ -122,15 +138,19 @@ type ListHeartbeatLogsByBotParams struct {
 }
 
 type ListHeartbeatLogsByBotRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	BotID        pgtype.UUID        `json:"bot_id"`
-	SessionID    pgtype.UUID        `json:"session_id"`
-	Status       string             `json:"status"`
-	ResultText   string             `json:"result_text"`
-	ErrorMessage string             `json:"error_message"`
-	Usage        []byte             `json:"usage"`
-	StartedAt    pgtype.Timestamptz `json:"started_at"`
-	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+	ID                pgtype.UUID        `json:"id"`
+	BotID             pgtype.UUID        `json:"bot_id"`
+	SessionID         pgtype.UUID        `json:"session_id"`
+	Status            string             `json:"status"`
+	ResultText        string             `json:"result_text"`
+	ErrorMessage      string             `json:"error_message"`
+	Usage             []byte             `json:"usage"`
+	StartedAt         pgtype.Timestamptz `json:"started_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	ConsecutiveOk     int32              `json:"consecutive_ok"`
+	RequestedInterval pgtype.Int4        `json:"requested_interval"`
+	NextRunAt         pgtype.Timestamptz `json:"next_run_at"`
+	NextRunReason     string             `json:"next_run_reason"`
 }
 
 func (q *Queries) ListHeartbeatLogsByBot(ctx context.Context, arg ListHeartbeatLogsByBotParams) ([]ListHeartbeatLogsByBotRow, error) {
@@ -152,6 +172,10 @@ func (q *Queries) ListHeartbeatLogsByBot(ctx context.Context, arg ListHeartbeatL
 			&i.Usage,
 			&i.StartedAt,
 			&i.CompletedAt,
+			&i.ConsecutiveOk,
+			&i.RequestedInterval,
+			&i.NextRunAt,
+			&i.NextRunReason,
 		); err != nil {
 			return nil, err
 		}
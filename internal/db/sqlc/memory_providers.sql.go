@@ -102,6 +102,24 @@ func (q *Queries) GetMemoryProviderByID(ctx context.Context, id pgtype.UUID) (Me
 	return i, err
 }
 
+const getVectorStoreStatus = `-- name: GetVectorStoreStatus :one
+SELECT
+  EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') AS extension_available,
+  to_regclass('memory_vectors') IS NOT NULL AS table_exists
+`
+
+type GetVectorStoreStatusRow struct {
+	ExtensionAvailable bool `json:"extension_available"`
+	TableExists        bool `json:"table_exists"`
+}
+
+func (q *Queries) GetVectorStoreStatus(ctx context.Context) (GetVectorStoreStatusRow, error) {
+	row := q.db.QueryRow(ctx, getVectorStoreStatus)
+	var i GetVectorStoreStatusRow
+	err := row.Scan(&i.ExtensionAvailable, &i.TableExists)
+	return i, err
+}
+
 const listMemoryProviders = `-- name: ListMemoryProviders :many
 SELECT id, name, provider, config, is_default, created_at, updated_at FROM memory_providers ORDER BY created_at ASC
 `
@@ -23,7 +23,8 @@ INSERT INTO bot_history_messages (
   content,
   metadata,
   usage,
-  model_id
+  model_id,
+  cost_usd
 )
 VALUES (
   $1,
@@ -36,7 +37,8 @@ VALUES (
   $8,
   $9,
   $10,
-  $11::uuid
+  $11::uuid,
+  $12::double precision
 )
 RETURNING
   id,
@@ -54,17 +56,18 @@ RETURNING
 `
 
 type CreateMessageParams struct {
-	BotID                   pgtype.UUID `json:"bot_id"`
-	SessionID               pgtype.UUID `json:"session_id"`
-	SenderChannelIdentityID pgtype.UUID `json:"sender_channel_identity_id"`
-	SenderUserID            pgtype.UUID `json:"sender_user_id"`
-	ExternalMessageID       pgtype.Text `json:"external_message_id"`
-	SourceReplyToMessageID  pgtype.Text `json:"source_reply_to_message_id"`
-	Role                    string      `json:"role"`
-	Content                 []byte      `json:"content"`
-	Metadata                []byte      `json:"metadata"`
-	Usage                   []byte      `json:"usage"`
-	ModelID                 pgtype.UUID `json:"model_id"`
+	BotID                   pgtype.UUID   `json:"bot_id"`
+	SessionID               pgtype.UUID   `json:"session_id"`
+	SenderChannelIdentityID pgtype.UUID   `json:"sender_channel_identity_id"`
+	SenderUserID            pgtype.UUID   `json:"sender_user_id"`
+	ExternalMessageID       pgtype.Text   `json:"external_message_id"`
+	SourceReplyToMessageID  pgtype.Text   `json:"source_reply_to_message_id"`
+	Role                    string        `json:"role"`
+	Content                 []byte        `json:"content"`
+	Metadata                []byte        `json:"metadata"`
+	Usage                   []byte        `json:"usage"`
+	ModelID                 pgtype.UUID   `json:"model_id"`
+	CostUsd                 pgtype.Float8 `json:"cost_usd"`
 }
 
 type CreateMessageRow struct {
@@ -95,6 +98,7 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (C
 		arg.Metadata,
 		arg.Usage,
 		arg.ModelID,
+		arg.CostUsd,
 	)
 	var i CreateMessageRow
 	err := row.Scan(
@@ -9,33 +9,42 @@ import (
 )
 
 type Bot struct {
-	ID                  pgtype.UUID        `json:"id"`
-	OwnerUserID         pgtype.UUID        `json:"owner_user_id"`
-	DisplayName         pgtype.Text        `json:"display_name"`
-	AvatarUrl           pgtype.Text        `json:"avatar_url"`
-	IsActive            bool               `json:"is_active"`
-	Status              string             `json:"status"`
-	MaxContextLoadTime  int32              `json:"max_context_load_time"`
-	MaxContextTokens    int32              `json:"max_context_tokens"`
-	Language            string             `json:"language"`
-	ReasoningEnabled    bool               `json:"reasoning_enabled"`
-	ReasoningEffort     string             `json:"reasoning_effort"`
-	ChatModelID         pgtype.UUID        `json:"chat_model_id"`
-	SearchProviderID    pgtype.UUID        `json:"search_provider_id"`
-	MemoryProviderID    pgtype.UUID        `json:"memory_provider_id"`
-	HeartbeatEnabled    bool               `json:"heartbeat_enabled"`
-	HeartbeatInterval   int32              `json:"heartbeat_interval"`
-	HeartbeatPrompt     string             `json:"heartbeat_prompt"`
-	HeartbeatModelID    pgtype.UUID        `json:"heartbeat_model_id"`
-	CompactionEnabled   bool               `json:"compaction_enabled"`
-	CompactionThreshold int32              `json:"compaction_threshold"`
-	CompactionModelID   pgtype.UUID        `json:"compaction_model_id"`
-	TitleModelID        pgtype.UUID        `json:"title_model_id"`
-	TtsModelID          pgtype.UUID        `json:"tts_model_id"`
-	BrowserContextID    pgtype.UUID        `json:"browser_context_id"`
-	Metadata            []byte             `json:"metadata"`
-	CreatedAt           pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	ID                   pgtype.UUID        `json:"id"`
+	OwnerUserID          pgtype.UUID        `json:"owner_user_id"`
+	DisplayName          pgtype.Text        `json:"display_name"`
+	AvatarUrl            pgtype.Text        `json:"avatar_url"`
+	IsActive             bool               `json:"is_active"`
+	Status               string             `json:"status"`
+	MaxContextLoadTime   int32              `json:"max_context_load_time"`
+	MaxContextTokens     int32              `json:"max_context_tokens"`
+	Language             string             `json:"language"`
+	ReasoningEnabled     bool               `json:"reasoning_enabled"`
+	ReasoningEffort      string             `json:"reasoning_effort"`
+	ChatModelID          pgtype.UUID        `json:"chat_model_id"`
+	SearchProviderID     pgtype.UUID        `json:"search_provider_id"`
+	MemoryProviderID     pgtype.UUID        `json:"memory_provider_id"`
+	HeartbeatEnabled     bool               `json:"heartbeat_enabled"`
+	HeartbeatInterval    int32              `json:"heartbeat_interval"`
+	HeartbeatPrompt      string             `json:"heartbeat_prompt"`
+	HeartbeatModelID     pgtype.UUID        `json:"heartbeat_model_id"`
+	CompactionEnabled    bool               `json:"compaction_enabled"`
+	CompactionThreshold  int32              `json:"compaction_threshold"`
+	CompactionModelID    pgtype.UUID        `json:"compaction_model_id"`
+	TitleModelID         pgtype.UUID        `json:"title_model_id"`
+	TtsModelID           pgtype.UUID        `json:"tts_model_id"`
+	SttModelID           pgtype.UUID        `json:"stt_model_id"`
+	BrowserContextID     pgtype.UUID        `json:"browser_context_id"`
+	MemoryScopePolicy    string             `json:"memory_scope_policy"`
+	Timezone             string             `json:"timezone"`
+	HeartbeatActiveStart string             `json:"heartbeat_active_start"`
+	HeartbeatActiveEnd   string             `json:"heartbeat_active_end"`
+	HeartbeatJitter      int32              `json:"heartbeat_jitter"`
+	HeartbeatMaxInterval int32              `json:"heartbeat_max_interval"`
+	FetchAllowDomains    []string           `json:"fetch_allow_domains"`
+	FetchDenyDomains     []string           `json:"fetch_deny_domains"`
+	Metadata             []byte             `json:"metadata"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
 }
 
 type BotAclRule struct {
@@ -50,6 +59,7 @@ type BotAclRule struct {
 	SourceConversationType pgtype.Text        `json:"source_conversation_type"`
 	SourceConversationID   pgtype.Text        `json:"source_conversation_id"`
 	SourceThreadID         pgtype.Text        `json:"source_thread_id"`
+	ToolPattern            pgtype.Text        `json:"tool_pattern"`
 	CreatedByUserID        pgtype.UUID        `json:"created_by_user_id"`
 	CreatedAt              pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
@@ -99,16 +109,20 @@ type BotEmailBinding struct {
 }
 
 type BotHeartbeatLog struct {
-	ID           pgtype.UUID        `json:"id"`
-	BotID        pgtype.UUID        `json:"bot_id"`
-	SessionID    pgtype.UUID        `json:"session_id"`
-	Status       string             `json:"status"`
-	ResultText   string             `json:"result_text"`
-	ErrorMessage string             `json:"error_message"`
-	Usage        []byte             `json:"usage"`
-	ModelID      pgtype.UUID        `json:"model_id"`
-	StartedAt    pgtype.Timestamptz `json:"started_at"`
-	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+	ID                pgtype.UUID        `json:"id"`
+	BotID             pgtype.UUID        `json:"bot_id"`
+	SessionID         pgtype.UUID        `json:"session_id"`
+	Status            string             `json:"status"`
+	ResultText        string             `json:"result_text"`
+	ErrorMessage      string             `json:"error_message"`
+	Usage             []byte             `json:"usage"`
+	ModelID           pgtype.UUID        `json:"model_id"`
+	StartedAt         pgtype.Timestamptz `json:"started_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	ConsecutiveOk     int32              `json:"consecutive_ok"`
+	RequestedInterval pgtype.Int4        `json:"requested_interval"`
+	NextRunAt         pgtype.Timestamptz `json:"next_run_at"`
+	NextRunReason     string             `json:"next_run_reason"`
 }
 
 type BotHistoryMessage struct {
@@ -124,6 +138,7 @@ type BotHistoryMessage struct {
 	Metadata                []byte             `json:"metadata"`
 	Usage                   []byte             `json:"usage"`
 	ModelID                 pgtype.UUID        `json:"model_id"`
+	CostUsd                 pgtype.Float8      `json:"cost_usd"`
 	CompactID               pgtype.UUID        `json:"compact_id"`
 	CreatedAt               pgtype.Timestamptz `json:"created_at"`
 }
@@ -153,6 +168,44 @@ type BotHistoryMessageCompact struct {
 	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
 }
 
+type BotModelFallback struct {
+	ID        pgtype.UUID        `json:"id"`
+	BotID     pgtype.UUID        `json:"bot_id"`
+	Purpose   string             `json:"purpose"`
+	ModelID   pgtype.UUID        `json:"model_id"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type BotNetworkPolicy struct {
+	BotID      pgtype.UUID        `json:"bot_id"`
+	EgressMode string             `json:"egress_mode"`
+	AllowList  []string           `json:"allow_list"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type BotResourceLimit struct {
+	BotID         pgtype.UUID        `json:"bot_id"`
+	CpuShares     int32              `json:"cpu_shares"`
+	CpuMillicores int32              `json:"cpu_millicores"`
+	MemoryMb      int32              `json:"memory_mb"`
+	PidsLimit     int32              `json:"pids_limit"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+type BotRouteTriggerPolicy struct {
+	RouteID               pgtype.UUID        `json:"route_id"`
+	BotID                 pgtype.UUID        `json:"bot_id"`
+	Mode                  string             `json:"mode"`
+	Keywords              []string           `json:"keywords"`
+	Patterns              []string           `json:"patterns"`
+	ListenInstructions    string             `json:"listen_instructions"`
+	ClassifierModelID     pgtype.UUID        `json:"classifier_model_id"`
+	MaxUnsolicitedPerHour int32              `json:"max_unsolicited_per_hour"`
+	CooldownSeconds       int32              `json:"cooldown_seconds"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+}
+
 type BotSession struct {
 	ID              pgtype.UUID        `json:"id"`
 	BotID           pgtype.UUID        `json:"bot_id"`
@@ -176,6 +229,41 @@ type BotStorageBinding struct {
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 }
 
+type BotTokenBudget struct {
+	ID                pgtype.UUID        `json:"id"`
+	BotID             pgtype.UUID        `json:"bot_id"`
+	Scope             string             `json:"scope"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	ChannelIdentityID pgtype.UUID        `json:"channel_identity_id"`
+	Period            string             `json:"period"`
+	MaxInputTokens    int64              `json:"max_input_tokens"`
+	MaxOutputTokens   int64              `json:"max_output_tokens"`
+	MaxTotalTokens    int64              `json:"max_total_tokens"`
+	ExhaustedMessage  string             `json:"exhausted_message"`
+	Enabled           bool               `json:"enabled"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+type BotTokenUsage struct {
+	ID                pgtype.UUID        `json:"id"`
+	BotID             pgtype.UUID        `json:"bot_id"`
+	SessionID         pgtype.UUID        `json:"session_id"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	ChannelIdentityID pgtype.UUID        `json:"channel_identity_id"`
+	ModelID           pgtype.UUID        `json:"model_id"`
+	InputTokens       int64              `json:"input_tokens"`
+	OutputTokens      int64              `json:"output_tokens"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+type BotToolApprovalPolicy struct {
+	BotID          pgtype.UUID        `json:"bot_id"`
+	ToolPatterns   []string           `json:"tool_patterns"`
+	TimeoutSeconds int32              `json:"timeout_seconds"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
 type BrowserContext struct {
 	ID        pgtype.UUID        `json:"id"`
 	Name      string             `json:"name"`
@@ -270,6 +358,39 @@ type EmailProvider struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type EventTrigger struct {
+	ID              pgtype.UUID        `json:"id"`
+	BotID           pgtype.UUID        `json:"bot_id"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	Source          string             `json:"source"`
+	Config          []byte             `json:"config"`
+	Command         string             `json:"command"`
+	Enabled         bool               `json:"enabled"`
+	Secret          string             `json:"secret"`
+	CooldownSeconds int32              `json:"cooldown_seconds"`
+	CurrentCalls    int32              `json:"current_calls"`
+	LastFiredAt     pgtype.Timestamptz `json:"last_fired_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type EventTriggerLog struct {
+	ID           pgtype.UUID        `json:"id"`
+	TriggerID    pgtype.UUID        `json:"trigger_id"`
+	BotID        pgtype.UUID        `json:"bot_id"`
+	SessionID    pgtype.UUID        `json:"session_id"`
+	Source       string             `json:"source"`
+	Event        []byte             `json:"event"`
+	Status       string             `json:"status"`
+	ResultText   string             `json:"result_text"`
+	ErrorMessage string             `json:"error_message"`
+	Usage        []byte             `json:"usage"`
+	ModelID      pgtype.UUID        `json:"model_id"`
+	StartedAt    pgtype.Timestamptz `json:"started_at"`
+	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+}
+
 type LifecycleEvent struct {
 	ID          string             `json:"id"`
 	ContainerID string             `json:"container_id"`
@@ -380,17 +501,21 @@ type ModelVariant struct {
 }
 
 type Schedule struct {
-	ID           pgtype.UUID        `json:"id"`
-	Name         string             `json:"name"`
-	Description  string             `json:"description"`
-	Pattern      string             `json:"pattern"`
-	MaxCalls     pgtype.Int4        `json:"max_calls"`
-	CurrentCalls int32              `json:"current_calls"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
-	Enabled      bool               `json:"enabled"`
-	Command      string             `json:"command"`
-	BotID        pgtype.UUID        `json:"bot_id"`
+	ID               pgtype.UUID        `json:"id"`
+	Name             string             `json:"name"`
+	Description      string             `json:"description"`
+	Pattern          string             `json:"pattern"`
+	MaxCalls         pgtype.Int4        `json:"max_calls"`
+	CurrentCalls     int32              `json:"current_calls"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	Enabled          bool               `json:"enabled"`
+	Command          string             `json:"command"`
+	BotID            pgtype.UUID        `json:"bot_id"`
+	Timezone         string             `json:"timezone"`
+	RunAt            pgtype.Timestamptz `json:"run_at"`
+	DeliveryPlatform string             `json:"delivery_platform"`
+	DeliveryTarget   string             `json:"delivery_target"`
 }
 
 type ScheduleLog struct {
@@ -436,6 +561,43 @@ type StorageProvider struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type SttModel struct {
+	ID            pgtype.UUID        `json:"id"`
+	ModelID       string             `json:"model_id"`
+	Name          pgtype.Text        `json:"name"`
+	SttProviderID pgtype.UUID        `json:"stt_provider_id"`
+	Config        []byte             `json:"config"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+type SttProvider struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	Provider  string             `json:"provider"`
+	Config    []byte             `json:"config"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ToolApproval struct {
+	ID                         pgtype.UUID        `json:"id"`
+	BotID                      pgtype.UUID        `json:"bot_id"`
+	SessionID                  pgtype.UUID        `json:"session_id"`
+	RequesterChannelIdentityID pgtype.UUID        `json:"requester_channel_identity_id"`
+	ToolName                   string             `json:"tool_name"`
+	ToolCallID                 string             `json:"tool_call_id"`
+	Input                      []byte             `json:"input"`
+	Platform                   string             `json:"platform"`
+	ReplyTarget                string             `json:"reply_target"`
+	Status                     string             `json:"status"`
+	DecidedByUserID            pgtype.UUID        `json:"decided_by_user_id"`
+	DecisionNote               string             `json:"decision_note"`
+	ExpiresAt                  pgtype.Timestamptz `json:"expires_at"`
+	DecidedAt                  pgtype.Timestamptz `json:"decided_at"`
+	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
+}
+
 type TtsModel struct {
 	ID            pgtype.UUID        `json:"id"`
 	ModelID       string             `json:"model_id"`
@@ -471,6 +633,19 @@ type User struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 }
 
+type UserApiKey struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Name        string             `json:"name"`
+	TokenPrefix string             `json:"token_prefix"`
+	TokenHash   string             `json:"token_hash"`
+	Scopes      []string           `json:"scopes"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type UserChannelBinding struct {
 	ID          pgtype.UUID        `json:"id"`
 	UserID      pgtype.UUID        `json:"user_id"`
@@ -12,19 +12,23 @@ import (
 )
 
 const createSchedule = `-- name: CreateSchedule :one
-INSERT INTO schedule (name, description, pattern, max_calls, enabled, command, bot_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+INSERT INTO schedule (name, description, pattern, max_calls, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 `
 
 type CreateScheduleParams struct {
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Pattern     string      `json:"pattern"`
-	MaxCalls    pgtype.Int4 `json:"max_calls"`
-	Enabled     bool        `json:"enabled"`
-	Command     string      `json:"command"`
-	BotID       pgtype.UUID `json:"bot_id"`
+	Name             string             `json:"name"`
+	Description      string             `json:"description"`
+	Pattern          string             `json:"pattern"`
+	MaxCalls         pgtype.Int4        `json:"max_calls"`
+	Enabled          bool               `json:"enabled"`
+	Command          string             `json:"command"`
+	BotID            pgtype.UUID        `json:"bot_id"`
+	Timezone         string             `json:"timezone"`
+	RunAt            pgtype.Timestamptz `json:"run_at"`
+	DeliveryPlatform string             `json:"delivery_platform"`
+	DeliveryTarget   string             `json:"delivery_target"`
 }
 
 func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams) (Schedule, error) {
@@ -36,6 +40,10 @@ func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams)
 		arg.Enabled,
 		arg.Command,
 		arg.BotID,
+		arg.Timezone,
+		arg.RunAt,
+		arg.DeliveryPlatform,
+		arg.DeliveryTarget,
 	)
 	var i Schedule
 	err := row.Scan(
@@ -50,6 +58,10 @@ func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams)
 		&i.Enabled,
 		&i.Command,
 		&i.BotID,
+		&i.Timezone,
+		&i.RunAt,
+		&i.DeliveryPlatform,
+		&i.DeliveryTarget,
 	)
 	return i, err
 }
@@ -65,7 +77,7 @@ func (q *Queries) DeleteSchedule(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getScheduleByID = `-- name: GetScheduleByID :one
-SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 FROM schedule
 WHERE id = $1
 `
@@ -85,6 +97,10 @@ func (q *Queries) GetScheduleByID(ctx context.Context, id pgtype.UUID) (Schedule
 		&i.Enabled,
 		&i.Command,
 		&i.BotID,
+		&i.Timezone,
+		&i.RunAt,
+		&i.DeliveryPlatform,
+		&i.DeliveryTarget,
 	)
 	return i, err
 }
@@ -98,7 +114,7 @@ SET current_calls = current_calls + 1,
     END,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 `
 
 func (q *Queries) IncrementScheduleCalls(ctx context.Context, id pgtype.UUID) (Schedule, error) {
@@ -116,12 +132,16 @@ func (q *Queries) IncrementScheduleCalls(ctx context.Context, id pgtype.UUID) (S
 		&i.Enabled,
 		&i.Command,
 		&i.BotID,
+		&i.Timezone,
+		&i.RunAt,
+		&i.DeliveryPlatform,
+		&i.DeliveryTarget,
 	)
 	return i, err
 }
 
 const listEnabledSchedules = `-- name: ListEnabledSchedules :many
-SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 FROM schedule
 WHERE enabled = true
 ORDER BY created_at DESC
@@ -148,6 +168,10 @@ func (q *Queries) ListEnabledSchedules(ctx context.Context) ([]Schedule, error)
 			&i.Enabled,
 			&i.Command,
 			&i.BotID,
+			&i.Timezone,
+			&i.RunAt,
+			&i.DeliveryPlatform,
+			&i.DeliveryTarget,
 		); err != nil {
 			return nil, err
 		}
@@ -160,7 +184,7 @@ func (q *Queries) ListEnabledSchedules(ctx context.Context) ([]Schedule, error)
 }
 
 const listSchedulesByBot = `-- name: ListSchedulesByBot :many
-SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+SELECT id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 FROM schedule
 WHERE bot_id = $1
 ORDER BY created_at DESC
@@ -187,6 +211,10 @@ func (q *Queries) ListSchedulesByBot(ctx context.Context, botID pgtype.UUID) ([]
 			&i.Enabled,
 			&i.Command,
 			&i.BotID,
+			&i.Timezone,
+			&i.RunAt,
+			&i.DeliveryPlatform,
+			&i.DeliveryTarget,
 		); err != nil {
 			return nil, err
 		}
@@ -206,19 +234,27 @@ SET name = $2,
     max_calls = $5,
     enabled = $6,
     command = $7,
+    timezone = $8,
+    run_at = $9,
+    delivery_platform = $10,
+    delivery_target = $11,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id
+RETURNING id, name, description, pattern, max_calls, current_calls, created_at, updated_at, enabled, command, bot_id, timezone, run_at, delivery_platform, delivery_target
 `
 
 type UpdateScheduleParams struct {
-	ID          pgtype.UUID `json:"id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Pattern     string      `json:"pattern"`
-	MaxCalls    pgtype.Int4 `json:"max_calls"`
-	Enabled     bool        `json:"enabled"`
-	Command     string      `json:"command"`
+	ID               pgtype.UUID        `json:"id"`
+	Name             string             `json:"name"`
+	Description      string             `json:"description"`
+	Pattern          string             `json:"pattern"`
+	MaxCalls         pgtype.Int4        `json:"max_calls"`
+	Enabled          bool               `json:"enabled"`
+	Command          string             `json:"command"`
+	Timezone         string             `json:"timezone"`
+	RunAt            pgtype.Timestamptz `json:"run_at"`
+	DeliveryPlatform string             `json:"delivery_platform"`
+	DeliveryTarget   string             `json:"delivery_target"`
 }
 
 func (q *Queries) UpdateSchedule(ctx context.Context, arg UpdateScheduleParams) (Schedule, error) {
@@ -230,6 +266,10 @@ func (q *Queries) UpdateSchedule(ctx context.Context, arg UpdateScheduleParams)
 		arg.MaxCalls,
 		arg.Enabled,
 		arg.Command,
+		arg.Timezone,
+		arg.RunAt,
+		arg.DeliveryPlatform,
+		arg.DeliveryTarget,
 	)
 	var i Schedule
 	err := row.Scan(
@@ -244,6 +284,10 @@ func (q *Queries) UpdateSchedule(ctx context.Context, arg UpdateScheduleParams)
 		&i.Enabled,
 		&i.Command,
 		&i.BotID,
+		&i.Timezone,
+		&i.RunAt,
+		&i.DeliveryPlatform,
+		&i.DeliveryTarget,
 	)
 	return i, err
 }
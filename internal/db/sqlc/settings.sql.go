@@ -23,6 +23,14 @@ SET max_context_load_time = 1440,
     heartbeat_prompt = '',
     compaction_enabled = false,
     compaction_threshold = 100000,
+    memory_scope_policy = 'private',
+    timezone = '',
+    heartbeat_active_start = '',
+    heartbeat_active_end = '',
+    heartbeat_jitter = 0,
+    heartbeat_max_interval = 0,
+    fetch_allow_domains = '{}',
+    fetch_deny_domains = '{}',
     chat_model_id = NULL,
     heartbeat_model_id = NULL,
     compaction_model_id = NULL,
@@ -30,6 +38,7 @@ SET max_context_load_time = 1440,
     search_provider_id = NULL,
     memory_provider_id = NULL,
     tts_model_id = NULL,
+    stt_model_id = NULL,
     browser_context_id = NULL,
     updated_at = now()
 WHERE id = $1
@@ -53,6 +62,14 @@ SELECT
   bots.heartbeat_prompt,
   bots.compaction_enabled,
   bots.compaction_threshold,
+  bots.memory_scope_policy,
+  bots.timezone,
+  bots.heartbeat_active_start,
+  bots.heartbeat_active_end,
+  bots.heartbeat_jitter,
+  bots.heartbeat_max_interval,
+  bots.fetch_allow_domains,
+  bots.fetch_deny_domains,
   chat_models.id AS chat_model_id,
   heartbeat_models.id AS heartbeat_model_id,
   compaction_models.id AS compaction_model_id,
@@ -60,6 +77,7 @@ SELECT
   search_providers.id AS search_provider_id,
   memory_providers.id AS memory_provider_id,
   tts_models.id AS tts_model_id,
+  stt_models.id AS stt_model_id,
   browser_contexts.id AS browser_context_id
 FROM bots
 LEFT JOIN models AS chat_models ON chat_models.id = bots.chat_model_id
@@ -69,30 +87,40 @@ LEFT JOIN models AS title_models ON title_models.id = bots.title_model_id
 LEFT JOIN search_providers ON search_providers.id = bots.search_provider_id
 LEFT JOIN memory_providers ON memory_providers.id = bots.memory_provider_id
 LEFT JOIN tts_models ON tts_models.id = bots.tts_model_id
+LEFT JOIN stt_models ON stt_models.id = bots.stt_model_id
 LEFT JOIN browser_contexts ON browser_contexts.id = bots.browser_context_id
 WHERE bots.id = $1
 `
 
 type GetSettingsByBotIDRow struct {
-	BotID               pgtype.UUID `json:"bot_id"`
-	MaxContextLoadTime  int32       `json:"max_context_load_time"`
-	MaxContextTokens    int32       `json:"max_context_tokens"`
-	Language            string      `json:"language"`
-	ReasoningEnabled    bool        `json:"reasoning_enabled"`
-	ReasoningEffort     string      `json:"reasoning_effort"`
-	HeartbeatEnabled    bool        `json:"heartbeat_enabled"`
-	HeartbeatInterval   int32       `json:"heartbeat_interval"`
-	HeartbeatPrompt     string      `json:"heartbeat_prompt"`
-	CompactionEnabled   bool        `json:"compaction_enabled"`
-	CompactionThreshold int32       `json:"compaction_threshold"`
-	ChatModelID         pgtype.UUID `json:"chat_model_id"`
-	HeartbeatModelID    pgtype.UUID `json:"heartbeat_model_id"`
-	CompactionModelID   pgtype.UUID `json:"compaction_model_id"`
-	TitleModelID        pgtype.UUID `json:"title_model_id"`
-	SearchProviderID    pgtype.UUID `json:"search_provider_id"`
-	MemoryProviderID    pgtype.UUID `json:"memory_provider_id"`
-	TtsModelID          pgtype.UUID `json:"tts_model_id"`
-	BrowserContextID    pgtype.UUID `json:"browser_context_id"`
+	BotID                pgtype.UUID `json:"bot_id"`
+	MaxContextLoadTime   int32       `json:"max_context_load_time"`
+	MaxContextTokens     int32       `json:"max_context_tokens"`
+	Language             string      `json:"language"`
+	ReasoningEnabled     bool        `json:"reasoning_enabled"`
+	ReasoningEffort      string      `json:"reasoning_effort"`
+	HeartbeatEnabled     bool        `json:"heartbeat_enabled"`
+	HeartbeatInterval    int32       `json:"heartbeat_interval"`
+	HeartbeatPrompt      string      `json:"heartbeat_prompt"`
+	CompactionEnabled    bool        `json:"compaction_enabled"`
+	CompactionThreshold  int32       `json:"compaction_threshold"`
+	MemoryScopePolicy    string      `json:"memory_scope_policy"`
+	Timezone             string      `json:"timezone"`
+	HeartbeatActiveStart string      `json:"heartbeat_active_start"`
+	HeartbeatActiveEnd   string      `json:"heartbeat_active_end"`
+	HeartbeatJitter      int32       `json:"heartbeat_jitter"`
+	HeartbeatMaxInterval int32       `json:"heartbeat_max_interval"`
+	FetchAllowDomains    []string    `json:"fetch_allow_domains"`
+	FetchDenyDomains     []string    `json:"fetch_deny_domains"`
+	ChatModelID          pgtype.UUID `json:"chat_model_id"`
+	HeartbeatModelID     pgtype.UUID `json:"heartbeat_model_id"`
+	CompactionModelID    pgtype.UUID `json:"compaction_model_id"`
+	TitleModelID         pgtype.UUID `json:"title_model_id"`
+	SearchProviderID     pgtype.UUID `json:"search_provider_id"`
+	MemoryProviderID     pgtype.UUID `json:"memory_provider_id"`
+	TtsModelID           pgtype.UUID `json:"tts_model_id"`
+	SttModelID           pgtype.UUID `json:"stt_model_id"`
+	BrowserContextID     pgtype.UUID `json:"browser_context_id"`
 }
 
 func (q *Queries) GetSettingsByBotID(ctx context.Context, id pgtype.UUID) (GetSettingsByBotIDRow, error) {
@@ -110,6 +138,14 @@ func (q *Queries) GetSettingsByBotID(ctx context.Context, id pgtype.UUID) (GetSe
 		&i.HeartbeatPrompt,
 		&i.CompactionEnabled,
 		&i.CompactionThreshold,
+		&i.MemoryScopePolicy,
+		&i.Timezone,
+		&i.HeartbeatActiveStart,
+		&i.HeartbeatActiveEnd,
+		&i.HeartbeatJitter,
+		&i.HeartbeatMaxInterval,
+		&i.FetchAllowDomains,
+		&i.FetchDenyDomains,
 		&i.ChatModelID,
 		&i.HeartbeatModelID,
 		&i.CompactionModelID,
@@ -117,6 +153,7 @@ func (q *Queries) GetSettingsByBotID(ctx context.Context, id pgtype.UUID) (GetSe
 		&i.SearchProviderID,
 		&i.MemoryProviderID,
 		&i.TtsModelID,
+		&i.SttModelID,
 		&i.BrowserContextID,
 	)
 	return i, err
@@ -135,17 +172,26 @@ WITH updated AS (
       heartbeat_prompt = $8,
       compaction_enabled = $9,
       compaction_threshold = $10,
-      chat_model_id = COALESCE($11::uuid, bots.chat_model_id),
-      heartbeat_model_id = COALESCE($12::uuid, bots.heartbeat_model_id),
-      compaction_model_id = COALESCE($13::uuid, bots.compaction_model_id),
-      title_model_id = COALESCE($14::uuid, bots.title_model_id),
-      search_provider_id = COALESCE($15::uuid, bots.search_provider_id),
-      memory_provider_id = COALESCE($16::uuid, bots.memory_provider_id),
-      tts_model_id = COALESCE($17::uuid, bots.tts_model_id),
-      browser_context_id = COALESCE($18::uuid, bots.browser_context_id),
+      memory_scope_policy = $11,
+      timezone = $12,
+      heartbeat_active_start = $13,
+      heartbeat_active_end = $14,
+      heartbeat_jitter = $15,
+      heartbeat_max_interval = $16,
+      fetch_allow_domains = $17,
+      fetch_deny_domains = $18,
+      chat_model_id = COALESCE($19::uuid, bots.chat_model_id),
+      heartbeat_model_id = COALESCE($20::uuid, bots.heartbeat_model_id),
+      compaction_model_id = COALESCE($21::uuid, bots.compaction_model_id),
+      title_model_id = COALESCE($22::uuid, bots.title_model_id),
+      search_provider_id = COALESCE($23::uuid, bots.search_provider_id),
+      memory_provider_id = COALESCE($24::uuid, bots.memory_provider_id),
+      tts_model_id = COALESCE($25::uuid, bots.tts_model_id),
+      stt_model_id = COALESCE($26::uuid, bots.stt_model_id),
+      browser_context_id = COALESCE($27::uuid, bots.browser_context_id),
       updated_at = now()
-  WHERE bots.id = $19
-  RETURNING bots.id, bots.max_context_load_time, bots.max_context_tokens, bots.language, bots.reasoning_enabled, bots.reasoning_effort, bots.heartbeat_enabled, bots.heartbeat_interval, bots.heartbeat_prompt, bots.compaction_enabled, bots.compaction_threshold, bots.chat_model_id, bots.heartbeat_model_id, bots.compaction_model_id, bots.title_model_id, bots.search_provider_id, bots.memory_provider_id, bots.tts_model_id, bots.browser_context_id
+  WHERE bots.id = $28
+  RETURNING bots.id, bots.max_context_load_time, bots.max_context_tokens, bots.language, bots.reasoning_enabled, bots.reasoning_effort, bots.heartbeat_enabled, bots.heartbeat_interval, bots.heartbeat_prompt, bots.compaction_enabled, bots.compaction_threshold, bots.memory_scope_policy, bots.timezone, bots.heartbeat_active_start, bots.heartbeat_active_end, bots.heartbeat_jitter, bots.heartbeat_max_interval, bots.fetch_allow_domains, bots.fetch_deny_domains, bots.chat_model_id, bots.heartbeat_model_id, bots.compaction_model_id, bots.title_model_id, bots.search_provider_id, bots.memory_provider_id, bots.tts_model_id, bots.stt_model_id, bots.browser_context_id
 )
 SELECT
   updated.id AS bot_id,
@@ -159,6 +205,14 @@ SELECT
   updated.heartbeat_prompt,
   updated.compaction_enabled,
   updated.compaction_threshold,
+  updated.memory_scope_policy,
+  updated.timezone,
+  updated.heartbeat_active_start,
+  updated.heartbeat_active_end,
+  updated.heartbeat_jitter,
+  updated.heartbeat_max_interval,
+  updated.fetch_allow_domains,
+  updated.fetch_deny_domains,
   chat_models.id AS chat_model_id,
   heartbeat_models.id AS heartbeat_model_id,
   compaction_models.id AS compaction_model_id,
@@ -166,6 +220,7 @@ SELECT
   search_providers.id AS search_provider_id,
   memory_providers.id AS memory_provider_id,
   tts_models.id AS tts_model_id,
+  stt_models.id AS stt_model_id,
   browser_contexts.id AS browser_context_id
 FROM updated
 LEFT JOIN models AS chat_models ON chat_models.id = updated.chat_model_id
@@ -175,51 +230,70 @@ LEFT JOIN models AS title_models ON title_models.id = updated.title_model_id
 LEFT JOIN search_providers ON search_providers.id = updated.search_provider_id
 LEFT JOIN memory_providers ON memory_providers.id = updated.memory_provider_id
 LEFT JOIN tts_models ON tts_models.id = updated.tts_model_id
+LEFT JOIN stt_models ON stt_models.id = updated.stt_model_id
 LEFT JOIN browser_contexts ON browser_contexts.id = updated.browser_context_id
 `
 
 type UpsertBotSettingsParams struct {
-	MaxContextLoadTime  int32       `json:"max_context_load_time"`
-	MaxContextTokens    int32       `json:"max_context_tokens"`
-	Language            string      `json:"language"`
-	ReasoningEnabled    bool        `json:"reasoning_enabled"`
-	ReasoningEffort     string      `json:"reasoning_effort"`
-	HeartbeatEnabled    bool        `json:"heartbeat_enabled"`
-	HeartbeatInterval   int32       `json:"heartbeat_interval"`
-	HeartbeatPrompt     string      `json:"heartbeat_prompt"`
-	CompactionEnabled   bool        `json:"compaction_enabled"`
-	CompactionThreshold int32       `json:"compaction_threshold"`
-	ChatModelID         pgtype.UUID `json:"chat_model_id"`
-	HeartbeatModelID    pgtype.UUID `json:"heartbeat_model_id"`
-	CompactionModelID   pgtype.UUID `json:"compaction_model_id"`
-	TitleModelID        pgtype.UUID `json:"title_model_id"`
-	SearchProviderID    pgtype.UUID `json:"search_provider_id"`
-	MemoryProviderID    pgtype.UUID `json:"memory_provider_id"`
-	TtsModelID          pgtype.UUID `json:"tts_model_id"`
-	BrowserContextID    pgtype.UUID `json:"browser_context_id"`
-	ID                  pgtype.UUID `json:"id"`
+	MaxContextLoadTime   int32       `json:"max_context_load_time"`
+	MaxContextTokens     int32       `json:"max_context_tokens"`
+	Language             string      `json:"language"`
+	ReasoningEnabled     bool        `json:"reasoning_enabled"`
+	ReasoningEffort      string      `json:"reasoning_effort"`
+	HeartbeatEnabled     bool        `json:"heartbeat_enabled"`
+	HeartbeatInterval    int32       `json:"heartbeat_interval"`
+	HeartbeatPrompt      string      `json:"heartbeat_prompt"`
+	CompactionEnabled    bool        `json:"compaction_enabled"`
+	CompactionThreshold  int32       `json:"compaction_threshold"`
+	MemoryScopePolicy    string      `json:"memory_scope_policy"`
+	Timezone             string      `json:"timezone"`
+	HeartbeatActiveStart string      `json:"heartbeat_active_start"`
+	HeartbeatActiveEnd   string      `json:"heartbeat_active_end"`
+	HeartbeatJitter      int32       `json:"heartbeat_jitter"`
+	HeartbeatMaxInterval int32       `json:"heartbeat_max_interval"`
+	FetchAllowDomains    []string    `json:"fetch_allow_domains"`
+	FetchDenyDomains     []string    `json:"fetch_deny_domains"`
+	ChatModelID          pgtype.UUID `json:"chat_model_id"`
+	HeartbeatModelID     pgtype.UUID `json:"heartbeat_model_id"`
+	CompactionModelID    pgtype.UUID `json:"compaction_model_id"`
+	TitleModelID         pgtype.UUID `json:"title_model_id"`
+	SearchProviderID     pgtype.UUID `json:"search_provider_id"`
+	MemoryProviderID     pgtype.UUID `json:"memory_provider_id"`
+	TtsModelID           pgtype.UUID `json:"tts_model_id"`
+	SttModelID           pgtype.UUID `json:"stt_model_id"`
+	BrowserContextID     pgtype.UUID `json:"browser_context_id"`
+	ID                   pgtype.UUID `json:"id"`
 }
 
 type UpsertBotSettingsRow struct {
-	BotID               pgtype.UUID `json:"bot_id"`
-	MaxContextLoadTime  int32       `json:"max_context_load_time"`
-	MaxContextTokens    int32       `json:"max_context_tokens"`
-	Language            string      `json:"language"`
-	ReasoningEnabled    bool        `json:"reasoning_enabled"`
-	ReasoningEffort     string      `json:"reasoning_effort"`
-	HeartbeatEnabled    bool        `json:"heartbeat_enabled"`
-	HeartbeatInterval   int32       `json:"heartbeat_interval"`
-	HeartbeatPrompt     string      `json:"heartbeat_prompt"`
-	CompactionEnabled   bool        `json:"compaction_enabled"`
-	CompactionThreshold int32       `json:"compaction_threshold"`
-	ChatModelID         pgtype.UUID `json:"chat_model_id"`
-	HeartbeatModelID    pgtype.UUID `json:"heartbeat_model_id"`
-	CompactionModelID   pgtype.UUID `json:"compaction_model_id"`
-	TitleModelID        pgtype.UUID `json:"title_model_id"`
-	SearchProviderID    pgtype.UUID `json:"search_provider_id"`
-	MemoryProviderID    pgtype.UUID `json:"memory_provider_id"`
-	TtsModelID          pgtype.UUID `json:"tts_model_id"`
-	BrowserContextID    pgtype.UUID `json:"browser_context_id"`
+	BotID                pgtype.UUID `json:"bot_id"`
+	MaxContextLoadTime   int32       `json:"max_context_load_time"`
+	MaxContextTokens     int32       `json:"max_context_tokens"`
+	Language             string      `json:"language"`
+	ReasoningEnabled     bool        `json:"reasoning_enabled"`
+	ReasoningEffort      string      `json:"reasoning_effort"`
+	HeartbeatEnabled     bool        `json:"heartbeat_enabled"`
+	HeartbeatInterval    int32       `json:"heartbeat_interval"`
+	HeartbeatPrompt      string      `json:"heartbeat_prompt"`
+	CompactionEnabled    bool        `json:"compaction_enabled"`
+	CompactionThreshold  int32       `json:"compaction_threshold"`
+	MemoryScopePolicy    string      `json:"memory_scope_policy"`
+	Timezone             string      `json:"timezone"`
+	HeartbeatActiveStart string      `json:"heartbeat_active_start"`
+	HeartbeatActiveEnd   string      `json:"heartbeat_active_end"`
+	HeartbeatJitter      int32       `json:"heartbeat_jitter"`
+	HeartbeatMaxInterval int32       `json:"heartbeat_max_interval"`
+	FetchAllowDomains    []string    `json:"fetch_allow_domains"`
+	FetchDenyDomains     []string    `json:"fetch_deny_domains"`
+	ChatModelID          pgtype.UUID `json:"chat_model_id"`
+	HeartbeatModelID     pgtype.UUID `json:"heartbeat_model_id"`
+	CompactionModelID    pgtype.UUID `json:"compaction_model_id"`
+	TitleModelID         pgtype.UUID `json:"title_model_id"`
+	SearchProviderID     pgtype.UUID `json:"search_provider_id"`
+	MemoryProviderID     pgtype.UUID `json:"memory_provider_id"`
+	TtsModelID           pgtype.UUID `json:"tts_model_id"`
+	SttModelID           pgtype.UUID `json:"stt_model_id"`
+	BrowserContextID     pgtype.UUID `json:"browser_context_id"`
 }
 
 func (q *Queries) UpsertBotSettings(ctx context.Context, arg UpsertBotSettingsParams) (UpsertBotSettingsRow, error) {
@@ -234,6 +308,14 @@ func (q *Queries) UpsertBotSettings(ctx context.Context, arg UpsertBotSettingsPa
 		arg.HeartbeatPrompt,
 		arg.CompactionEnabled,
 		arg.CompactionThreshold,
+		arg.MemoryScopePolicy,
+		arg.Timezone,
+		arg.HeartbeatActiveStart,
+		arg.HeartbeatActiveEnd,
+		arg.HeartbeatJitter,
+		arg.HeartbeatMaxInterval,
+		arg.FetchAllowDomains,
+		arg.FetchDenyDomains,
 		arg.ChatModelID,
 		arg.HeartbeatModelID,
 		arg.CompactionModelID,
@@ -241,6 +323,7 @@ func (q *Queries) UpsertBotSettings(ctx context.Context, arg UpsertBotSettingsPa
 		arg.SearchProviderID,
 		arg.MemoryProviderID,
 		arg.TtsModelID,
+		arg.SttModelID,
 		arg.BrowserContextID,
 		arg.ID,
 	)
@@ -257,6 +340,14 @@ func (q *Queries) UpsertBotSettings(ctx context.Context, arg UpsertBotSettingsPa
 		&i.HeartbeatPrompt,
 		&i.CompactionEnabled,
 		&i.CompactionThreshold,
+		&i.MemoryScopePolicy,
+		&i.Timezone,
+		&i.HeartbeatActiveStart,
+		&i.HeartbeatActiveEnd,
+		&i.HeartbeatJitter,
+		&i.HeartbeatMaxInterval,
+		&i.FetchAllowDomains,
+		&i.FetchDenyDomains,
 		&i.ChatModelID,
 		&i.HeartbeatModelID,
 		&i.CompactionModelID,
@@ -264,6 +355,7 @@ func (q *Queries) UpsertBotSettings(ctx context.Context, arg UpsertBotSettingsPa
 		&i.SearchProviderID,
 		&i.MemoryProviderID,
 		&i.TtsModelID,
+		&i.SttModelID,
 		&i.BrowserContextID,
 	)
 	return i, err
@@ -22,7 +22,8 @@ SELECT
   COALESCE(SUM((m.usage->>'outputTokens')::bigint), 0)::bigint AS output_tokens,
   COALESCE(SUM((m.usage->'inputTokenDetails'->>'cacheReadTokens')::bigint), 0)::bigint AS cache_read_tokens,
   COALESCE(SUM((m.usage->'inputTokenDetails'->>'cacheWriteTokens')::bigint), 0)::bigint AS cache_write_tokens,
-  COALESCE(SUM((m.usage->'outputTokenDetails'->>'reasoningTokens')::bigint), 0)::bigint AS reasoning_tokens
+  COALESCE(SUM((m.usage->'outputTokenDetails'->>'reasoningTokens')::bigint), 0)::bigint AS reasoning_tokens,
+  COALESCE(SUM(m.cost_usd), 0)::float8 AS cost_usd
 FROM bot_history_messages m
 LEFT JOIN bot_sessions s ON s.id = m.session_id
 LEFT JOIN bot_sessions ps ON ps.id = s.parent_session_id
@@ -50,6 +51,7 @@ type GetTokenUsageByDayAndTypeRow struct {
 	CacheReadTokens  int64       `json:"cache_read_tokens"`
 	CacheWriteTokens int64       `json:"cache_write_tokens"`
 	ReasoningTokens  int64       `json:"reasoning_tokens"`
+	CostUsd          float64     `json:"cost_usd"`
 }
 
 func (q *Queries) GetTokenUsageByDayAndType(ctx context.Context, arg GetTokenUsageByDayAndTypeParams) ([]GetTokenUsageByDayAndTypeRow, error) {
@@ -74,6 +76,7 @@ func (q *Queries) GetTokenUsageByDayAndType(ctx context.Context, arg GetTokenUsa
 			&i.CacheReadTokens,
 			&i.CacheWriteTokens,
 			&i.ReasoningTokens,
+			&i.CostUsd,
 		); err != nil {
 			return nil, err
 		}
@@ -92,7 +95,8 @@ SELECT
   COALESCE(mo.name, 'Unknown') AS model_name,
   COALESCE(lp.name, 'Unknown') AS provider_name,
   COALESCE(SUM((m.usage->>'inputTokens')::bigint), 0)::bigint AS input_tokens,
-  COALESCE(SUM((m.usage->>'outputTokens')::bigint), 0)::bigint AS output_tokens
+  COALESCE(SUM((m.usage->>'outputTokens')::bigint), 0)::bigint AS output_tokens,
+  COALESCE(SUM(m.cost_usd), 0)::float8 AS cost_usd
 FROM bot_history_messages m
 LEFT JOIN models mo ON mo.id = m.model_id
 LEFT JOIN llm_providers lp ON lp.id = mo.llm_provider_id
@@ -117,6 +121,7 @@ type GetTokenUsageByModelRow struct {
 	ProviderName string      `json:"provider_name"`
 	InputTokens  int64       `json:"input_tokens"`
 	OutputTokens int64       `json:"output_tokens"`
+	CostUsd      float64     `json:"cost_usd"`
 }
 
 func (q *Queries) GetTokenUsageByModel(ctx context.Context, arg GetTokenUsageByModelParams) ([]GetTokenUsageByModelRow, error) {
@@ -135,6 +140,74 @@ func (q *Queries) GetTokenUsageByModel(ctx context.Context, arg GetTokenUsageByM
 			&i.ProviderName,
 			&i.InputTokens,
 			&i.OutputTokens,
+			&i.CostUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTokenUsageBySession = `-- name: GetTokenUsageBySession :many
+SELECT
+  m.session_id,
+  COALESCE(s.title, '') AS session_title,
+  COALESCE(s.type, 'chat') AS session_type,
+  COALESCE(SUM((m.usage->>'inputTokens')::bigint), 0)::bigint AS input_tokens,
+  COALESCE(SUM((m.usage->>'outputTokens')::bigint), 0)::bigint AS output_tokens,
+  COALESCE(SUM(m.cost_usd), 0)::float8 AS cost_usd
+FROM bot_history_messages m
+LEFT JOIN bot_sessions s ON s.id = m.session_id
+WHERE m.bot_id = $1
+  AND m.usage IS NOT NULL
+  AND m.created_at >= $2
+  AND m.created_at < $3
+  AND ($4::uuid IS NULL OR m.model_id = $4::uuid)
+GROUP BY m.session_id, s.title, s.type
+ORDER BY cost_usd DESC, input_tokens DESC
+`
+
+type GetTokenUsageBySessionParams struct {
+	BotID    pgtype.UUID        `json:"bot_id"`
+	FromTime pgtype.Timestamptz `json:"from_time"`
+	ToTime   pgtype.Timestamptz `json:"to_time"`
+	ModelID  pgtype.UUID        `json:"model_id"`
+}
+
+type GetTokenUsageBySessionRow struct {
+	SessionID    pgtype.UUID `json:"session_id"`
+	SessionTitle string      `json:"session_title"`
+	SessionType  string      `json:"session_type"`
+	InputTokens  int64       `json:"input_tokens"`
+	OutputTokens int64       `json:"output_tokens"`
+	CostUsd      float64     `json:"cost_usd"`
+}
+
+func (q *Queries) GetTokenUsageBySession(ctx context.Context, arg GetTokenUsageBySessionParams) ([]GetTokenUsageBySessionRow, error) {
+	rows, err := q.db.Query(ctx, getTokenUsageBySession,
+		arg.BotID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.ModelID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTokenUsageBySessionRow
+	for rows.Next() {
+		var i GetTokenUsageBySessionRow
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.SessionTitle,
+			&i.SessionType,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostUsd,
 		); err != nil {
 			return nil, err
 		}
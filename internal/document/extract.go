@@ -0,0 +1,190 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
+	readability "github.com/go-shiori/go-readability"
+	"github.com/ledongthuc/pdf"
+)
+
+// DetectFormat returns the document format for a MIME type, falling back to
+// the file name extension. It returns "" for unsupported files.
+func DetectFormat(mimeType, name string) Format {
+	if mt, _, err := mime.ParseMediaType(strings.TrimSpace(mimeType)); err == nil {
+		switch strings.ToLower(mt) {
+		case "application/pdf", "application/x-pdf":
+			return FormatPDF
+		case "application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+			return FormatDOCX
+		case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+			return FormatXLSX
+		case "text/html", "application/xhtml+xml":
+			return FormatHTML
+		}
+	}
+	switch strings.ToLower(path.Ext(strings.TrimSpace(name))) {
+	case ".pdf":
+		return FormatPDF
+	case ".docx":
+		return FormatDOCX
+	case ".xlsx":
+		return FormatXLSX
+	case ".html", ".htm", ".xhtml":
+		return FormatHTML
+	}
+	return ""
+}
+
+// Extract parses data as the given format.
+func Extract(data []byte, format Format) (Document, error) {
+	if int64(len(data)) > MaxInputBytes {
+		return Document{}, fmt.Errorf("%w: max %d bytes", ErrTooLarge, MaxInputBytes)
+	}
+	var (
+		doc Document
+		err error
+	)
+	switch format {
+	case FormatPDF:
+		doc, err = extractPDF(data)
+	case FormatDOCX:
+		doc, err = extractDOCX(data)
+	case FormatXLSX:
+		doc, err = extractXLSX(data)
+	case FormatHTML:
+		doc, err = extractHTML(data)
+	default:
+		return Document{}, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return Document{}, fmt.Errorf("extract %s: %w", format, err)
+	}
+	doc.Format = format
+	doc.limitText()
+	return doc, nil
+}
+
+// TotalPages returns the number of pages in the document.
+func (d Document) TotalPages() int {
+	return len(d.Pages)
+}
+
+// limitText numbers the pages and trims the text so the whole document
+// stays within maxTextBytes.
+func (d *Document) limitText() {
+	remaining := maxTextBytes
+	for i := range d.Pages {
+		d.Pages[i].Number = i + 1
+		d.Pages[i].Text = strings.TrimSpace(d.Pages[i].Text)
+		if len(d.Pages[i].Text) > remaining {
+			d.Pages[i].Text = truncateUTF8(d.Pages[i].Text, remaining)
+			d.Truncated = true
+		}
+		remaining -= len(d.Pages[i].Text)
+	}
+}
+
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func extractPDF(data []byte) (doc Document, err error) {
+	// The PDF parser panics on some malformed files.
+	defer func() {
+		if r := recover(); r != nil {
+			doc, err = Document{}, fmt.Errorf("malformed pdf: %v", r)
+		}
+	}()
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Document{}, err
+	}
+	total := reader.NumPage()
+	fonts := make(map[string]*pdf.Font)
+	size := 0
+	for i := 1; i <= total; i++ {
+		if size > maxTextBytes {
+			// Keep the page count accurate without parsing the rest.
+			doc.Pages = append(doc.Pages, Page{})
+			doc.Truncated = true
+			continue
+		}
+		text, err := pdfPageText(reader.Page(i), fonts)
+		if err != nil {
+			return Document{}, fmt.Errorf("page %d: %w", i, err)
+		}
+		size += len(text)
+		doc.Pages = append(doc.Pages, Page{Text: text})
+	}
+	doc.Title = strings.TrimSpace(reader.Trailer().Key("Info").Key("Title").Text())
+	return doc, nil
+}
+
+// pdfPageText returns a page's text with one line per text row. Rows keep
+// the reading order of most single-column layouts and table rows.
+func pdfPageText(page pdf.Page, fonts map[string]*pdf.Font) (string, error) {
+	if page.V.IsNull() {
+		return "", nil
+	}
+	rows, err := page.GetTextByRow()
+	if err != nil || len(rows) == 0 {
+		return page.GetPlainText(fonts)
+	}
+	var sb strings.Builder
+	for _, row := range rows {
+		var line strings.Builder
+		for i, word := range row.Content {
+			if i > 0 && needsSpace(row.Content[i-1], word) {
+				line.WriteByte(' ')
+			}
+			line.WriteString(word.S)
+		}
+		if text := strings.TrimSpace(line.String()); text != "" {
+			sb.WriteString(text)
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String(), nil
+}
+
+// needsSpace reports whether a gap separates two text runs on a row. Runs
+// are often single glyphs, so only a visible gap counts as a space.
+func needsSpace(prev, next pdf.Text) bool {
+	if strings.HasSuffix(prev.S, " ") || strings.HasPrefix(next.S, " ") {
+		return false
+	}
+	if prev.W <= 0 {
+		return true
+	}
+	return next.X-(prev.X+prev.W) > next.FontSize*0.2
+}
+
+func extractHTML(data []byte) (Document, error) {
+	article, err := readability.FromReader(bytes.NewReader(data), &url.URL{})
+	if err != nil || strings.TrimSpace(article.Content) == "" {
+		// Pages readability cannot handle are still converted in full.
+		text, convErr := htmltomarkdown.ConvertString(string(data))
+		if convErr != nil {
+			return Document{}, convErr
+		}
+		return Document{Pages: []Page{{Text: text}}}, nil
+	}
+	text, err := htmltomarkdown.ConvertString(article.Content)
+	if err != nil {
+		text = article.TextContent
+	}
+	return Document{Title: strings.TrimSpace(article.Title), Pages: []Page{{Text: text}}}, nil
+}
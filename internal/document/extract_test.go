@@ -0,0 +1,216 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildZip packs files into an in-memory zip archive.
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// buildPDF writes a minimal PDF with one text line per page.
+func buildPDF(title string, pages ...string) []byte {
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	)
+	for i, text := range pages {
+		content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	objects = append(objects, fmt.Sprintf("<< /Title (%s) >>", title))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, len(objects), xref)
+	return buf.Bytes()
+}
+
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		mime, name string
+		want       Format
+	}{
+		{"application/pdf", "", FormatPDF},
+		{"application/octet-stream", "Report.PDF", FormatPDF},
+		{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "", FormatDOCX},
+		{"", "budget.xlsx", FormatXLSX},
+		{"text/html; charset=utf-8", "", FormatHTML},
+		{"image/png", "photo.png", ""},
+		{"", "legacy.doc", ""},
+	}
+	for _, tc := range cases {
+		if got := DetectFormat(tc.mime, tc.name); got != tc.want {
+			t.Errorf("DetectFormat(%q, %q) = %q, want %q", tc.mime, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestExtractPDF(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Extract(buildPDF("Quarterly Report", "Hello PDF", "Second page"), FormatPDF)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if doc.Title != "Quarterly Report" {
+		t.Errorf("title = %q", doc.Title)
+	}
+	if doc.TotalPages() != 2 {
+		t.Fatalf("pages = %d, want 2", doc.TotalPages())
+	}
+	if !strings.Contains(doc.Pages[0].Text, "Hello PDF") || !strings.Contains(doc.Pages[1].Text, "Second page") {
+		t.Errorf("unexpected page text: %q / %q", doc.Pages[0].Text, doc.Pages[1].Text)
+	}
+	if doc.Pages[1].Number != 2 {
+		t.Errorf("page number = %d, want 2", doc.Pages[1].Number)
+	}
+}
+
+func TestExtractMalformedPDF(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Extract([]byte("%PDF-1.4\nnot really a pdf"), FormatPDF); err == nil {
+		t.Fatal("expected an error for a malformed pdf")
+	}
+}
+
+func TestExtractDOCX(t *testing.T) {
+	t.Parallel()
+
+	body := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading1"/><w:tabs><w:tab w:val="left" w:pos="720"/></w:tabs></w:pPr><w:r><w:t>Overview</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">Revenue grew </w:t></w:r><w:r><w:t>12%.</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/></w:numPr></w:pPr><w:r><w:t>First point</w:t></w:r></w:p>
+<w:tbl>
+<w:tr><w:tc><w:p><w:r><w:t>Region</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Sales</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p><w:r><w:t>EU|West</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>42</w:t></w:r></w:p></w:tc></w:tr>
+</w:tbl>
+<w:p><w:r><w:br w:type="page"/><w:t>Appendix</w:t></w:r></w:p>
+</w:body></w:document>`
+	data := buildZip(t, map[string]string{
+		"word/document.xml": body,
+		"docProps/core.xml": `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Annual Review</dc:title></cp:coreProperties>`,
+	})
+	doc, err := Extract(data, FormatDOCX)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if doc.Title != "Annual Review" {
+		t.Errorf("title = %q", doc.Title)
+	}
+	if doc.TotalPages() != 2 {
+		t.Fatalf("pages = %d, want 2: %#v", doc.TotalPages(), doc.Pages)
+	}
+	want := "# Overview\n\nRevenue grew 12%.\n\n- First point\n\n| Region | Sales |\n| --- | --- |\n| EU\\|West | 42 |"
+	if doc.Pages[0].Text != want {
+		t.Errorf("page 1 =\n%s\nwant\n%s", doc.Pages[0].Text, want)
+	}
+	if doc.Pages[1].Text != "Appendix" {
+		t.Errorf("page 2 = %q, want Appendix", doc.Pages[1].Text)
+	}
+}
+
+func TestExtractXLSX(t *testing.T) {
+	t.Parallel()
+
+	data := buildZip(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Summary" sheetId="1" r:id="rId1"/><sheet name="Empty" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="worksheet" Target="/xl/worksheets/sheet2.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Item</t></si><si><r><t>Co</t></r><r><t>st</t></r></si><si><t>Paper</t><rPh><t>pe-pa</t></rPh></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+<row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3" t="inlineStr"><is><t>note</t></is></c></row>
+<row r="4"><c r="B4"><v>3.5</v></c><c r="C4" t="b"><v>1</v></c></row>
+</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+	})
+	doc, err := Extract(data, FormatXLSX)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if doc.TotalPages() != 2 || doc.Pages[0].Name != "Summary" || doc.Pages[1].Name != "Empty" {
+		t.Fatalf("unexpected sheets: %#v", doc.Pages)
+	}
+	want := "| Item | Cost |  |\n| --- | --- | --- |\n| Paper |  | note |\n|  | 3.5 | TRUE |"
+	if doc.Pages[0].Text != want {
+		t.Errorf("sheet =\n%s\nwant\n%s", doc.Pages[0].Text, want)
+	}
+	if doc.Pages[1].Text != "" {
+		t.Errorf("empty sheet = %q", doc.Pages[1].Text)
+	}
+}
+
+func TestExtractHTML(t *testing.T) {
+	t.Parallel()
+
+	page := `<html><head><title>Release notes</title></head><body><article><h1>Release notes</h1>
+<p>This release adds document extraction so that agents can read attached files without a container.</p>
+<p>It also improves the scheduler and fixes several bugs reported by users during the beta period.</p>
+</article></body></html>`
+	doc, err := Extract([]byte(page), FormatHTML)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if doc.TotalPages() != 1 || !strings.Contains(doc.Pages[0].Text, "document extraction") {
+		t.Fatalf("unexpected html extraction: %#v", doc)
+	}
+}
+
+func TestExtractRejectsUnsupportedAndOversized(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Extract([]byte("x"), Format("doc")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+	if _, err := Extract(make([]byte, MaxInputBytes+1), FormatPDF); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,551 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxPartBytes bounds the uncompressed size of one part of an Office
+// archive, so a small zip cannot expand without limit.
+const maxPartBytes = 4 * MaxInputBytes
+
+func extractDOCX(data []byte) (Document, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Document{}, err
+	}
+	body, err := openPart(zr, "word/document.xml")
+	if err != nil {
+		return Document{}, err
+	}
+	defer func() { _ = body.Close() }()
+
+	w := &docxWriter{}
+	dec := xml.NewDecoder(body)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Document{}, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			w.start(t)
+		case xml.EndElement:
+			w.end(t)
+		case xml.CharData:
+			if w.inText {
+				w.para.Write(t)
+			}
+		}
+	}
+	w.pageBreak()
+	doc := Document{Pages: w.pages}
+	if title, err := docxTitle(zr); err == nil {
+		doc.Title = title
+	}
+	return doc, nil
+}
+
+// docxWriter turns the WordprocessingML token stream into Markdown.
+type docxWriter struct {
+	pages  []Page
+	page   strings.Builder
+	para   strings.Builder
+	prefix string
+	inRun  bool
+	inText bool
+	tables []*tableBuilder
+}
+
+func (w *docxWriter) start(el xml.StartElement) {
+	switch el.Name.Local {
+	case "p":
+		w.para.Reset()
+		w.prefix = ""
+	case "pStyle":
+		w.prefix = headingPrefix(attr(el, "val"), w.prefix)
+	case "numPr":
+		if w.prefix == "" {
+			w.prefix = "- "
+		}
+	case "r":
+		w.inRun = true
+	case "t":
+		w.inText = true
+	case "tab":
+		// Tab stop definitions in paragraph properties share the name.
+		if w.inRun {
+			w.para.WriteByte('\t')
+		}
+	case "br", "cr":
+		if attr(el, "type") == "page" && len(w.tables) == 0 {
+			w.flushParagraph()
+			w.pageBreak()
+			return
+		}
+		w.para.WriteByte('\n')
+	case "pageBreakBefore":
+		if len(w.tables) == 0 && attr(el, "val") != "0" && attr(el, "val") != "false" && w.page.Len() > 0 {
+			w.pageBreak()
+		}
+	case "tbl":
+		w.tables = append(w.tables, &tableBuilder{})
+	case "tr":
+		if t := w.table(); t != nil {
+			t.row = nil
+		}
+	case "tc":
+		if t := w.table(); t != nil {
+			t.cell.Reset()
+		}
+	}
+}
+
+func (w *docxWriter) end(el xml.EndElement) {
+	switch el.Name.Local {
+	case "r":
+		w.inRun = false
+	case "t":
+		w.inText = false
+	case "p":
+		w.flushParagraph()
+	case "tc":
+		if t := w.table(); t != nil {
+			t.row = append(t.row, strings.TrimSpace(t.cell.String()))
+		}
+	case "tr":
+		if t := w.table(); t != nil {
+			t.rows = append(t.rows, t.row)
+		}
+	case "tbl":
+		t := w.table()
+		if t == nil {
+			return
+		}
+		w.tables = w.tables[:len(w.tables)-1]
+		if parent := w.table(); parent != nil {
+			// Nested tables are flattened into the enclosing cell.
+			for _, row := range t.rows {
+				parent.appendCell(strings.Join(row, " "))
+			}
+			return
+		}
+		w.page.WriteString(renderTable(t.rows))
+		w.page.WriteString("\n\n")
+	}
+}
+
+func (w *docxWriter) table() *tableBuilder {
+	if len(w.tables) == 0 {
+		return nil
+	}
+	return w.tables[len(w.tables)-1]
+}
+
+func (w *docxWriter) flushParagraph() {
+	text := strings.TrimSpace(w.para.String())
+	w.para.Reset()
+	if text == "" {
+		return
+	}
+	if t := w.table(); t != nil {
+		t.appendCell(text)
+		return
+	}
+	w.page.WriteString(w.prefix)
+	w.page.WriteString(text)
+	w.page.WriteString("\n\n")
+}
+
+func (w *docxWriter) pageBreak() {
+	w.pages = append(w.pages, Page{Text: w.page.String()})
+	w.page.Reset()
+}
+
+// headingPrefix maps Word's built-in heading styles to Markdown headings.
+func headingPrefix(style, fallback string) string {
+	style = strings.ToLower(style)
+	switch {
+	case style == "title":
+		return "# "
+	case strings.HasPrefix(style, "heading"):
+		level, err := strconv.Atoi(strings.TrimPrefix(style, "heading"))
+		if err != nil || level < 1 {
+			return fallback
+		}
+		return strings.Repeat("#", min(level, 6)) + " "
+	}
+	return fallback
+}
+
+// docxTitle reads the title from the package's core properties.
+func docxTitle(zr *zip.Reader) (string, error) {
+	rc, err := openPart(zr, "docProps/core.xml")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+	var props struct {
+		Title string `xml:"title"`
+	}
+	if err := xml.NewDecoder(rc).Decode(&props); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(props.Title), nil
+}
+
+type tableBuilder struct {
+	rows [][]string
+	row  []string
+	cell strings.Builder
+}
+
+func (t *tableBuilder) appendCell(text string) {
+	if t.cell.Len() > 0 {
+		t.cell.WriteByte(' ')
+	}
+	t.cell.WriteString(text)
+}
+
+func extractXLSX(data []byte) (Document, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Document{}, err
+	}
+	sheets, err := xlsxSheets(zr)
+	if err != nil {
+		return Document{}, err
+	}
+	shared, err := xlsxSharedStrings(zr)
+	if err != nil {
+		return Document{}, err
+	}
+	var doc Document
+	for _, sheet := range sheets {
+		rows, truncated, err := xlsxSheetRows(zr, sheet.path, shared)
+		if err != nil {
+			return Document{}, fmt.Errorf("sheet %q: %w", sheet.name, err)
+		}
+		doc.Truncated = doc.Truncated || truncated
+		doc.Pages = append(doc.Pages, Page{Name: sheet.name, Text: renderTable(rows)})
+	}
+	return doc, nil
+}
+
+type xlsxSheet struct {
+	name string
+	path string
+}
+
+// xlsxSheets lists the worksheets in workbook order with their part paths.
+func xlsxSheets(zr *zip.Reader) ([]xlsxSheet, error) {
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodePart(zr, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodePart(zr, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[rel.ID] = target
+	}
+	sheets := make([]xlsxSheet, 0, len(workbook.Sheets))
+	for _, s := range workbook.Sheets {
+		if target, ok := targets[s.ID]; ok {
+			sheets = append(sheets, xlsxSheet{name: s.Name, path: target})
+		}
+	}
+	return sheets, nil
+}
+
+// xlsxSharedStrings reads the shared string table. Workbooks without
+// shared strings are valid.
+func xlsxSharedStrings(zr *zip.Reader) ([]string, error) {
+	rc, err := openPart(zr, "xl/sharedStrings.xml")
+	if errors.Is(err, errPartNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	var (
+		result  []string
+		current strings.Builder
+		inText  bool
+		// Phonetic runs repeat the text as a reading guide; skip them.
+		inPhonetic bool
+	)
+	dec := xml.NewDecoder(rc)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				current.Reset()
+			case "t":
+				inText = true
+			case "rPh":
+				inPhonetic = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "si":
+				result = append(result, current.String())
+			case "t":
+				inText = false
+			case "rPh":
+				inPhonetic = false
+			}
+		case xml.CharData:
+			if inText && !inPhonetic {
+				current.Write(t)
+			}
+		}
+	}
+}
+
+// xlsxSheetRows reads the non-empty rows of a worksheet, capped at
+// maxSheetRows by maxSheetCols.
+func xlsxSheetRows(zr *zip.Reader, name string, shared []string) ([][]string, bool, error) {
+	rc, err := openPart(zr, name)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	var (
+		rows      [][]string
+		row       []string
+		col       int
+		cellType  string
+		value     strings.Builder
+		inValue   bool
+		truncated bool
+	)
+	dec := xml.NewDecoder(rc)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return rows, truncated, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				row = nil
+				col = 0
+			case "c":
+				if ref := attr(t, "r"); ref != "" {
+					if idx, ok := columnIndex(ref); ok {
+						col = idx
+					}
+				}
+				cellType = attr(t, "t")
+				value.Reset()
+			case "v", "t":
+				inValue = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v", "t":
+				inValue = false
+			case "c":
+				if col >= maxSheetCols {
+					truncated = true
+				} else if text := cellText(cellType, value.String(), shared); text != "" {
+					for len(row) <= col {
+						row = append(row, "")
+					}
+					row[col] = text
+				}
+				col++
+			case "row":
+				if len(row) == 0 {
+					continue
+				}
+				if len(rows) >= maxSheetRows {
+					truncated = true
+					continue
+				}
+				rows = append(rows, row)
+			}
+		case xml.CharData:
+			if inValue {
+				value.Write(t)
+			}
+		}
+	}
+}
+
+func cellText(cellType, raw string, shared []string) string {
+	raw = strings.TrimSpace(raw)
+	switch cellType {
+	case "s":
+		idx, err := strconv.Atoi(raw)
+		if err != nil || idx < 0 || idx >= len(shared) {
+			return ""
+		}
+		return strings.TrimSpace(shared[idx])
+	case "b":
+		if raw == "1" {
+			return "TRUE"
+		}
+		if raw == "0" {
+			return "FALSE"
+		}
+	}
+	return raw
+}
+
+// columnIndex converts the column letters of a cell reference such as
+// "AB12" to a zero-based index.
+func columnIndex(ref string) (int, bool) {
+	idx := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		idx = idx*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 || n > 3 {
+		return 0, false
+	}
+	return idx - 1, true
+}
+
+// renderTable renders rows as a Markdown table with the first row as the
+// header. Rows are padded to the widest row.
+func renderTable(rows [][]string) string {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteByte('|')
+		for i := range width {
+			cell := ""
+			if i < len(row) {
+				cell = escapeCell(row[i])
+			}
+			sb.WriteByte(' ')
+			sb.WriteString(cell)
+			sb.WriteString(" |")
+		}
+		sb.WriteByte('\n')
+	}
+	writeRow(rows[0])
+	sb.WriteByte('|')
+	for range width {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteByte('\n')
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+var cellEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\t", " ")
+
+func escapeCell(s string) string {
+	return cellEscaper.Replace(strings.TrimSpace(s))
+}
+
+func attr(el xml.StartElement, local string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+var errPartNotFound = errors.New("part not found")
+
+// openPart opens a part of an Office archive, failing reads with
+// ErrTooLarge past maxPartBytes.
+func openPart(zr *zip.Reader, name string) (io.ReadCloser, error) {
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		return &partReader{ReadCloser: rc, remaining: maxPartBytes}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", errPartNotFound, name)
+}
+
+func decodePart(zr *zip.Reader, name string, v any) error {
+	rc, err := openPart(zr, name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+type partReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (r *partReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if int64(n) > r.remaining {
+		return int(r.remaining), fmt.Errorf("%w: archive part exceeds %d bytes", ErrTooLarge, maxPartBytes)
+	}
+	r.remaining -= int64(n)
+	return n, err
+}
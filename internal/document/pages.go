@@ -0,0 +1,124 @@
+package document
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParsePageRange parses a page selection such as "3", "2-5", "4-" or
+// "1,3,5-7" against a document of total pages. An empty spec selects every
+// page. Ends past the last page are clamped; the result is sorted and
+// free of duplicates.
+func ParsePageRange(spec string, total int) ([]int, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		pages := make([]int, total)
+		for i := range pages {
+			pages[i] = i + 1
+		}
+		return pages, nil
+	}
+	var pages []int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		startRaw, endRaw, isRange := strings.Cut(part, "-")
+		start, err := parsePageNumber(startRaw, 1)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPageRange, part)
+		}
+		end := start
+		if isRange {
+			if end, err = parsePageNumber(endRaw, total); err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidPageRange, part)
+			}
+		}
+		if start > end {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPageRange, part)
+		}
+		if start > total {
+			return nil, fmt.Errorf("%w: %q, document has %d pages", ErrInvalidPageRange, part, total)
+		}
+		for p := start; p <= min(end, total); p++ {
+			pages = append(pages, p)
+		}
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPageRange, spec)
+	}
+	slices.Sort(pages)
+	return slices.Compact(pages), nil
+}
+
+func parsePageNumber(raw string, fallback int) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, ErrInvalidPageRange
+	}
+	return n, nil
+}
+
+// Rendered is a selection of pages rendered as text.
+type Rendered struct {
+	Text string
+	// Pages lists the page numbers included in Text.
+	Pages []int
+	// NextPage is the first selected page left out to respect the size
+	// limit, or 0 when every selected page was rendered.
+	NextPage int
+	// Truncated is set when a single page was cut to fit the limit.
+	Truncated bool
+}
+
+// Render renders the selected pages under page headers, stopping before
+// the text would exceed maxChars bytes. The first page is always included,
+// cut to maxChars when it is larger.
+func (d Document) Render(pages []int, maxChars int) Rendered {
+	var (
+		sb     strings.Builder
+		result Rendered
+	)
+	for i, number := range pages {
+		if number < 1 || number > len(d.Pages) {
+			continue
+		}
+		page := d.Pages[number-1]
+		chunk := d.pageHeader(page) + page.Text + "\n\n"
+		if maxChars > 0 && sb.Len()+len(chunk) > maxChars {
+			if len(result.Pages) > 0 {
+				result.NextPage = number
+				break
+			}
+			chunk = truncateUTF8(chunk, maxChars)
+			result.Truncated = true
+			if i+1 < len(pages) {
+				result.NextPage = pages[i+1]
+			}
+			sb.WriteString(chunk)
+			result.Pages = append(result.Pages, number)
+			break
+		}
+		sb.WriteString(chunk)
+		result.Pages = append(result.Pages, number)
+	}
+	result.Text = strings.TrimSpace(sb.String())
+	return result
+}
+
+func (d Document) pageHeader(page Page) string {
+	if page.Name != "" {
+		return fmt.Sprintf("## Sheet %d: %s\n\n", page.Number, page.Name)
+	}
+	if len(d.Pages) == 1 {
+		return ""
+	}
+	return fmt.Sprintf("## Page %d\n\n", page.Number)
+}
@@ -0,0 +1,70 @@
+package document
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePageRange(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		spec string
+		want []int
+	}{
+		{"", []int{1, 2, 3, 4, 5, 6, 7, 8}},
+		{"3", []int{3}},
+		{"2-4", []int{2, 3, 4}},
+		{"7-", []int{7, 8}},
+		{"-2", []int{1, 2}},
+		{"1, 3, 5-6, 3", []int{1, 3, 5, 6}},
+		{"6-20", []int{6, 7, 8}},
+	}
+	for _, tc := range cases {
+		got, err := ParsePageRange(tc.spec, 8)
+		if err != nil {
+			t.Errorf("ParsePageRange(%q): %v", tc.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParsePageRange(%q) = %v, want %v", tc.spec, got, tc.want)
+		}
+	}
+	for _, spec := range []string{"0", "9", "4-2", "a", "1-b", ","} {
+		if _, err := ParsePageRange(spec, 8); !errors.Is(err, ErrInvalidPageRange) {
+			t.Errorf("ParsePageRange(%q): expected ErrInvalidPageRange, got %v", spec, err)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	doc := Document{Pages: []Page{
+		{Number: 1, Text: "alpha"},
+		{Number: 2, Text: "beta"},
+		{Number: 3, Text: strings.Repeat("g", 100)},
+	}}
+
+	all := doc.Render([]int{1, 2, 3}, 0)
+	if all.NextPage != 0 || len(all.Pages) != 3 || !strings.HasPrefix(all.Text, "## Page 1\n\nalpha") {
+		t.Errorf("unexpected full render: %#v", all)
+	}
+
+	limited := doc.Render([]int{1, 2, 3}, 60)
+	if !reflect.DeepEqual(limited.Pages, []int{1, 2}) || limited.NextPage != 3 || limited.Truncated {
+		t.Errorf("unexpected limited render: %#v", limited)
+	}
+
+	cut := doc.Render([]int{3}, 50)
+	if !cut.Truncated || len(cut.Text) > 50 || cut.NextPage != 0 {
+		t.Errorf("unexpected truncated render: %#v", cut)
+	}
+
+	sheets := Document{Pages: []Page{{Number: 1, Name: "Summary", Text: "| a |"}}}
+	if got := sheets.Render([]int{1}, 0).Text; got != "## Sheet 1: Summary\n\n| a |" {
+		t.Errorf("sheet render = %q", got)
+	}
+}
@@ -0,0 +1,112 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/memohai/memoh/internal/media"
+)
+
+// extractionVersion is stored with cached extractions; bump it when the
+// extractors change so stale results are rebuilt.
+const extractionVersion = 1
+
+// Store reads media assets and keeps their extractions next to them.
+type Store interface {
+	Open(ctx context.Context, botID, contentHash string) (io.ReadCloser, media.Asset, error)
+	PutExtraction(ctx context.Context, botID, contentHash string, reader io.Reader) error
+	OpenExtraction(ctx context.Context, botID, contentHash string) (io.ReadCloser, error)
+}
+
+// Service extracts documents stored as media assets and caches the result
+// alongside the asset, so each document is parsed once.
+type Service struct {
+	store  Store
+	logger *slog.Logger
+}
+
+// NewService creates a document service backed by the media store.
+func NewService(log *slog.Logger, store Store) *Service {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Service{
+		store:  store,
+		logger: log.With(slog.String("service", "document")),
+	}
+}
+
+type cachedExtraction struct {
+	Version  int      `json:"version"`
+	Document Document `json:"document"`
+}
+
+// ExtractAsset returns the extraction of a media asset, parsing and caching
+// it on first use. name is the original file name and is used to detect
+// the format when the asset's MIME type is generic.
+func (s *Service) ExtractAsset(ctx context.Context, botID, contentHash, name string) (Document, error) {
+	if s == nil || s.store == nil {
+		return Document{}, media.ErrProviderUnavailable
+	}
+	botID = strings.TrimSpace(botID)
+	contentHash = strings.TrimSpace(contentHash)
+	if doc, err := s.cached(ctx, botID, contentHash); err == nil {
+		return doc, nil
+	}
+
+	reader, asset, err := s.store.Open(ctx, botID, contentHash)
+	if err != nil {
+		return Document{}, err
+	}
+	defer func() { _ = reader.Close() }()
+	format := DetectFormat(asset.Mime, name)
+	if format == "" {
+		format = DetectFormat("", asset.StorageKey)
+	}
+	if format == "" {
+		return Document{}, fmt.Errorf("%w: %s", ErrUnsupportedFormat, asset.Mime)
+	}
+	data, err := media.ReadAllWithLimit(reader, MaxInputBytes)
+	if err != nil {
+		if errors.Is(err, media.ErrAssetTooLarge) {
+			return Document{}, fmt.Errorf("%w: max %d bytes", ErrTooLarge, MaxInputBytes)
+		}
+		return Document{}, err
+	}
+	doc, err := Extract(data, format)
+	if err != nil {
+		return Document{}, err
+	}
+
+	payload, err := json.Marshal(cachedExtraction{Version: extractionVersion, Document: doc})
+	if err == nil {
+		err = s.store.PutExtraction(ctx, botID, contentHash, bytes.NewReader(payload))
+	}
+	if err != nil {
+		s.logger.Warn("cache document extraction failed",
+			slog.String("bot_id", botID), slog.String("content_hash", contentHash), slog.Any("error", err))
+	}
+	return doc, nil
+}
+
+func (s *Service) cached(ctx context.Context, botID, contentHash string) (Document, error) {
+	reader, err := s.store.OpenExtraction(ctx, botID, contentHash)
+	if err != nil {
+		return Document{}, err
+	}
+	defer func() { _ = reader.Close() }()
+	var cached cachedExtraction
+	if err := json.NewDecoder(reader).Decode(&cached); err != nil {
+		return Document{}, err
+	}
+	if cached.Version != extractionVersion {
+		return Document{}, ErrNotExtracted
+	}
+	return cached.Document, nil
+}
@@ -0,0 +1,79 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/memohai/memoh/internal/media"
+)
+
+type fakeStore struct {
+	assets      map[string][]byte
+	mimes       map[string]string
+	extractions map[string][]byte
+	opens       int
+}
+
+func (s *fakeStore) Open(_ context.Context, botID, contentHash string) (io.ReadCloser, media.Asset, error) {
+	data, ok := s.assets[contentHash]
+	if !ok {
+		return nil, media.Asset{}, media.ErrAssetNotFound
+	}
+	s.opens++
+	return io.NopCloser(bytes.NewReader(data)), media.Asset{ContentHash: contentHash, BotID: botID, Mime: s.mimes[contentHash]}, nil
+}
+
+func (s *fakeStore) PutExtraction(_ context.Context, _, contentHash string, reader io.Reader) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	s.extractions[contentHash] = data
+	return nil
+}
+
+func (s *fakeStore) OpenExtraction(_ context.Context, _, contentHash string) (io.ReadCloser, error) {
+	data, ok := s.extractions[contentHash]
+	if !ok {
+		return nil, media.ErrAssetNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func TestServiceExtractAssetCachesResult(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeStore{
+		assets: map[string][]byte{
+			"abc": buildPDF("Cached", "Page one"),
+			"img": []byte("not a document"),
+		},
+		mimes:       map[string]string{"abc": "application/octet-stream", "img": "image/png"},
+		extractions: map[string][]byte{},
+	}
+	svc := NewService(nil, store)
+	ctx := context.Background()
+
+	for range 2 {
+		doc, err := svc.ExtractAsset(ctx, "bot-1", "abc", "report.pdf")
+		if err != nil {
+			t.Fatalf("ExtractAsset: %v", err)
+		}
+		if doc.Format != FormatPDF || doc.Title != "Cached" || doc.TotalPages() != 1 {
+			t.Fatalf("unexpected document: %#v", doc)
+		}
+	}
+	if store.opens != 1 {
+		t.Errorf("asset opened %d times, want 1", store.opens)
+	}
+
+	if _, err := svc.ExtractAsset(ctx, "bot-1", "img", "photo.png"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+	if _, err := svc.ExtractAsset(ctx, "bot-1", "missing", ""); !errors.Is(err, media.ErrAssetNotFound) {
+		t.Errorf("expected ErrAssetNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package document extracts text and table structure from common document
+// formats so models without file or vision support can read them.
+package document
+
+import "errors"
+
+// Format identifies a supported document format.
+type Format string
+
+const (
+	FormatPDF  Format = "pdf"
+	FormatDOCX Format = "docx"
+	FormatXLSX Format = "xlsx"
+	FormatHTML Format = "html"
+)
+
+const (
+	// MaxInputBytes bounds the size of a document accepted for extraction.
+	MaxInputBytes int64 = 50 * 1024 * 1024
+	// maxTextBytes bounds the extracted text kept for one document.
+	maxTextBytes = 4 * 1024 * 1024
+	// maxSheetRows and maxSheetCols bound the cells rendered per sheet.
+	maxSheetRows = 5000
+	maxSheetCols = 100
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported document format")
+	ErrTooLarge          = errors.New("document too large to extract")
+	ErrInvalidPageRange  = errors.New("invalid page range")
+	ErrNotExtracted      = errors.New("document has not been extracted")
+)
+
+// Document is the text extracted from a document, split into pages. PDFs
+// keep their pages, spreadsheets get one page per sheet, Word documents
+// split on explicit page breaks and HTML is a single page. Tables are
+// rendered as Markdown tables.
+type Document struct {
+	Format Format `json:"format"`
+	Title  string `json:"title,omitempty"`
+	Pages  []Page `json:"pages"`
+	// Truncated is set when the text was cut to stay within size limits.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Page is one page, or one sheet of a spreadsheet.
+type Page struct {
+	Number int `json:"number"`
+	// Name is the sheet name for spreadsheets.
+	Name string `json:"name,omitempty"`
+	Text string `json:"text"`
+}
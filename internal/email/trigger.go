@@ -11,12 +11,18 @@ type ChatTriggerer interface {
 	TriggerBotChat(ctx context.Context, botID, content string) error
 }
 
+// EventDispatcher fires a bot's event triggers for an inbound email.
+type EventDispatcher interface {
+	DispatchEmail(ctx context.Context, botID string, mail InboundEmail) error
+}
+
 // Trigger notifies bots when a new email arrives and immediately triggers
 // the bot's LLM to process it.
 type Trigger struct {
 	logger        *slog.Logger
 	emailService  *Service
 	chatTriggerer ChatTriggerer
+	events        EventDispatcher
 }
 
 func NewTrigger(log *slog.Logger, emailService *Service, chatTriggerer ChatTriggerer) *Trigger {
@@ -27,6 +33,12 @@ func NewTrigger(log *slog.Logger, emailService *Service, chatTriggerer ChatTrigg
 	}
 }
 
+// SetEventDispatcher lets inbound emails fire event triggers.
+// This allows breaking dependency cycles in the DI graph.
+func (t *Trigger) SetEventDispatcher(events EventDispatcher) {
+	t.events = events
+}
+
 // HandleInbound triggers a conversation for each bound bot so it can process
 // the incoming email.
 func (t *Trigger) HandleInbound(ctx context.Context, providerID string, mail InboundEmail) error {
@@ -57,6 +69,14 @@ func (t *Trigger) HandleInbound(ctx context.Context, providerID string, mail Inb
 				}
 			}(binding.BotID, content)
 		}
+
+		if t.events != nil {
+			if err := t.events.DispatchEmail(ctx, binding.BotID, mail); err != nil {
+				t.logger.Error("failed to dispatch email event triggers",
+					slog.String("bot_id", binding.BotID),
+					slog.Any("error", err))
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,705 @@
+package eventtrigger
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/auth"
+	"github.com/memohai/memoh/internal/boot"
+	"github.com/memohai/memoh/internal/db"
+	"github.com/memohai/memoh/internal/db/sqlc"
+	"github.com/memohai/memoh/internal/workspace/bridge"
+)
+
+// SessionCreator creates sessions for trigger runs.
+type SessionCreator interface {
+	CreateSession(ctx context.Context, botID, sessionType string) (string, error)
+}
+
+// NotificationWatcher streams notifications from one of a bot's MCP
+// connections. WatchNotifications blocks until ctx is done or the
+// connection drops.
+type NotificationWatcher interface {
+	WatchNotifications(ctx context.Context, botID, connectionID, resourceURI string, handle func(method string, params any)) error
+}
+
+var (
+	ErrNotFound      = errors.New("event trigger not found")
+	ErrDisabled      = errors.New("event trigger is disabled")
+	ErrInvalidSecret = errors.New("invalid webhook secret")
+	// ErrBusy is returned when an event arrives while the trigger's previous
+	// run is still going. The event is dropped so a run cannot retrigger
+	// itself through the files it writes.
+	ErrBusy = errors.New("event trigger is already running")
+)
+
+const (
+	triggerTokenTTL    = 10 * time.Minute
+	dataRoot           = "/data"
+	webhookSecretBytes = 24
+)
+
+type Service struct {
+	queries        *sqlc.Queries
+	triggerer      Triggerer
+	sessionCreator SessionCreator
+	bridges        bridge.Provider
+	notifications  NotificationWatcher
+	jwtSecret      string
+	logger         *slog.Logger
+	now            func() time.Time
+
+	mu sync.Mutex
+	// baseCtx parents every listener; it is set by Bootstrap and cancelled
+	// by Stop.
+	baseCtx   context.Context
+	stop      context.CancelFunc
+	listeners map[string]context.CancelFunc
+	running   map[string]struct{}
+}
+
+func NewService(log *slog.Logger, queries *sqlc.Queries, triggerer Triggerer, sessionCreator SessionCreator, runtimeConfig *boot.RuntimeConfig) *Service {
+	return &Service{
+		queries:        queries,
+		triggerer:      triggerer,
+		sessionCreator: sessionCreator,
+		jwtSecret:      runtimeConfig.JwtSecret,
+		logger:         log.With(slog.String("service", "event_trigger")),
+		now:            time.Now,
+		listeners:      map[string]context.CancelFunc{},
+		running:        map[string]struct{}{},
+	}
+}
+
+// SetSources configures where file and MCP events come from.
+// This allows breaking dependency cycles in the DI graph.
+func (s *Service) SetSources(bridges bridge.Provider, notifications NotificationWatcher) {
+	s.bridges = bridges
+	s.notifications = notifications
+}
+
+// Bootstrap starts the listeners of all enabled file and MCP triggers.
+func (s *Service) Bootstrap(ctx context.Context) error {
+	if s.queries == nil {
+		return errors.New("event trigger queries not configured")
+	}
+	s.mu.Lock()
+	if s.baseCtx == nil {
+		s.baseCtx, s.stop = context.WithCancel(context.WithoutCancel(ctx))
+	}
+	s.mu.Unlock()
+	for _, source := range []string{SourceFile, SourceMCP} {
+		rows, err := s.queries.ListEnabledEventTriggersBySource(ctx, source)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			s.restartListener(toTrigger(row))
+		}
+	}
+	return nil
+}
+
+// Stop cancels all listeners. Runs already in progress finish.
+func (s *Service) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		s.stop()
+	}
+	s.baseCtx, s.stop = nil, nil
+	clear(s.listeners)
+}
+
+func (s *Service) Create(ctx context.Context, botID string, req CreateRequest) (Trigger, error) {
+	if s.queries == nil {
+		return Trigger{}, errors.New("event trigger queries not configured")
+	}
+	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Command) == "" {
+		return Trigger{}, errors.New("name and command are required")
+	}
+	source := strings.TrimSpace(req.Source)
+	cfg, err := normalizeConfig(source, req.Config)
+	if err != nil {
+		return Trigger{}, err
+	}
+	cooldown, err := validateCooldown(req.CooldownSeconds)
+	if err != nil {
+		return Trigger{}, err
+	}
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return Trigger{}, err
+	}
+	secret := ""
+	if source == SourceWebhook {
+		if secret, err = generateSecret(); err != nil {
+			return Trigger{}, err
+		}
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return Trigger{}, err
+	}
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	row, err := s.queries.CreateEventTrigger(ctx, sqlc.CreateEventTriggerParams{
+		BotID:           pgBotID,
+		Name:            strings.TrimSpace(req.Name),
+		Description:     req.Description,
+		Source:          source,
+		Config:          configJSON,
+		Command:         req.Command,
+		Enabled:         enabled,
+		Secret:          secret,
+		CooldownSeconds: cooldown,
+	})
+	if err != nil {
+		return Trigger{}, err
+	}
+	item := toTrigger(row)
+	s.restartListener(item)
+	return item, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (Trigger, error) {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return Trigger{}, ErrNotFound
+	}
+	row, err := s.queries.GetEventTriggerByID(ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Trigger{}, ErrNotFound
+		}
+		return Trigger{}, err
+	}
+	return toTrigger(row), nil
+}
+
+func (s *Service) List(ctx context.Context, botID string) ([]Trigger, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListEventTriggersByBot(ctx, pgBotID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Trigger, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toTrigger(row))
+	}
+	return items, nil
+}
+
+func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (Trigger, error) {
+	existing, err := s.Get(ctx, id)
+	if err != nil {
+		return Trigger{}, err
+	}
+	name := existing.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+	}
+	command := existing.Command
+	if req.Command != nil {
+		command = *req.Command
+	}
+	if name == "" || strings.TrimSpace(command) == "" {
+		return Trigger{}, errors.New("name and command are required")
+	}
+	description := existing.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+	cfg := existing.Config
+	if req.Config != nil {
+		if cfg, err = normalizeConfig(existing.Source, *req.Config); err != nil {
+			return Trigger{}, err
+		}
+	}
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return Trigger{}, err
+	}
+	cooldown := int32(existing.CooldownSeconds) //nolint:gosec // loaded from an int32 column
+	if req.CooldownSeconds != nil {
+		if cooldown, err = validateCooldown(*req.CooldownSeconds); err != nil {
+			return Trigger{}, err
+		}
+	}
+	enabled := existing.Enabled
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	secret := existing.WebhookSecret
+	if existing.Source == SourceWebhook && (req.RotateSecret || secret == "") {
+		if secret, err = generateSecret(); err != nil {
+			return Trigger{}, err
+		}
+	}
+	row, err := s.queries.UpdateEventTrigger(ctx, sqlc.UpdateEventTriggerParams{
+		ID:              toUUID(existing.ID),
+		Name:            name,
+		Description:     description,
+		Config:          configJSON,
+		Command:         command,
+		Enabled:         enabled,
+		Secret:          secret,
+		CooldownSeconds: cooldown,
+	})
+	if err != nil {
+		return Trigger{}, err
+	}
+	item := toTrigger(row)
+	s.restartListener(item)
+	return item, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.queries.DeleteEventTrigger(ctx, pgID); err != nil {
+		return err
+	}
+	s.stopListener(id)
+	return nil
+}
+
+// Fire runs a trigger right away with a test event, ignoring its cooldown.
+func (s *Service) Fire(ctx context.Context, id string) error {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !item.Enabled {
+		return ErrDisabled
+	}
+	return s.run(ctx, item, Event{Source: item.Source, Summary: "manual test run"})
+}
+
+// FireWebhook authenticates a call to a webhook trigger's public URL and
+// starts a run with payload in the background.
+func (s *Service) FireWebhook(ctx context.Context, id, secret string, payload any) error {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if item.Source != SourceWebhook {
+		return ErrNotFound
+	}
+	if item.WebhookSecret == "" || subtle.ConstantTimeCompare([]byte(item.WebhookSecret), []byte(secret)) != 1 {
+		return ErrInvalidSecret
+	}
+	if !item.Enabled {
+		return ErrDisabled
+	}
+	go s.fire(context.WithoutCancel(ctx), item.ID, Event{
+		Source:  SourceWebhook,
+		Summary: "webhook called",
+		Data:    payload,
+	})
+	return nil
+}
+
+// fire dispatches an event and logs the outcome; it is what listeners and
+// inbound sources run in the background.
+func (s *Service) fire(ctx context.Context, id string, event Event) {
+	err := s.dispatch(ctx, id, event)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrBusy):
+		s.logger.Info("event dropped: trigger still running", slog.String("trigger_id", id), slog.String("event", event.Summary))
+	default:
+		s.logger.Error("event trigger run failed", slog.String("trigger_id", id), slog.Any("error", err))
+	}
+}
+
+// dispatch runs a trigger for an event unless it has been disabled or is
+// cooling down. The trigger is reloaded so edits and its last run time are
+// current.
+func (s *Service) dispatch(ctx context.Context, id string, event Event) error {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !item.Enabled {
+		return nil
+	}
+	if item.CooldownSeconds > 0 && item.LastFiredAt != nil {
+		if wait := item.LastFiredAt.Add(time.Duration(item.CooldownSeconds) * time.Second).Sub(s.now()); wait > 0 {
+			s.logger.Info("event dropped: trigger cooling down",
+				slog.String("trigger_id", id),
+				slog.String("event", event.Summary),
+				slog.Duration("remaining", wait))
+			return nil
+		}
+	}
+	return s.run(ctx, item, event)
+}
+
+func (s *Service) run(ctx context.Context, item Trigger, event Event) error {
+	if s.triggerer == nil {
+		return errors.New("event trigger triggerer not configured")
+	}
+	if !s.acquire(item.ID) {
+		return ErrBusy
+	}
+	defer s.release(item.ID)
+
+	if _, err := s.queries.MarkEventTriggerFired(ctx, toUUID(item.ID)); err != nil {
+		return err
+	}
+
+	ownerUserID, err := s.resolveBotOwner(ctx, item.BotID)
+	if err != nil {
+		return fmt.Errorf("resolve bot owner: %w", err)
+	}
+
+	var sessionID string
+	var pgSessionID pgtype.UUID
+	if s.sessionCreator != nil {
+		sid, err := s.sessionCreator.CreateSession(ctx, item.BotID, "trigger")
+		if err != nil {
+			s.logger.Error("create trigger session failed", slog.String("bot_id", item.BotID), slog.Any("error", err))
+		} else {
+			sessionID = sid
+			pgSessionID = db.ParseUUIDOrEmpty(sid)
+		}
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		eventJSON = []byte("{}")
+	}
+	logRow, err := s.queries.CreateEventTriggerLog(ctx, sqlc.CreateEventTriggerLogParams{
+		TriggerID: toUUID(item.ID),
+		BotID:     toUUID(item.BotID),
+		Source:    item.Source,
+		Event:     eventJSON,
+		SessionID: pgSessionID,
+	})
+	if err != nil {
+		s.logger.Error("create event trigger log failed", slog.String("trigger_id", item.ID), slog.Any("error", err))
+	}
+
+	token, err := s.generateTriggerToken(ownerUserID)
+	if err != nil {
+		s.completeLog(ctx, logRow.ID, "error", "", err.Error(), nil, pgtype.UUID{})
+		return fmt.Errorf("generate trigger token: %w", err)
+	}
+
+	result, triggerErr := s.triggerer.TriggerEvent(ctx, item.BotID, TriggerPayload{
+		ID:          item.ID,
+		Name:        item.Name,
+		Description: item.Description,
+		Source:      item.Source,
+		Command:     item.Command,
+		Event:       event,
+		OwnerUserID: ownerUserID,
+		SessionID:   sessionID,
+	}, token)
+	if triggerErr != nil {
+		s.completeLog(ctx, logRow.ID, "error", "", triggerErr.Error(), nil, pgtype.UUID{})
+		return triggerErr
+	}
+
+	modelID := db.ParseUUIDOrEmpty(result.ModelID)
+	s.completeLog(ctx, logRow.ID, result.Status, result.Text, "", result.UsageBytes, modelID)
+	s.logger.Info("event trigger completed",
+		slog.String("trigger_id", item.ID),
+		slog.String("source", item.Source),
+		slog.String("status", result.Status))
+	return nil
+}
+
+func (s *Service) acquire(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.running[id]; ok {
+		return false
+	}
+	s.running[id] = struct{}{}
+	return true
+}
+
+func (s *Service) release(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, id)
+}
+
+func (s *Service) completeLog(ctx context.Context, logID pgtype.UUID, status, resultText, errorMessage string, usageBytes []byte, modelID pgtype.UUID) {
+	if !logID.Valid {
+		return
+	}
+	_, err := s.queries.CompleteEventTriggerLog(ctx, sqlc.CompleteEventTriggerLogParams{
+		ID:           logID,
+		Status:       status,
+		ResultText:   resultText,
+		ErrorMessage: errorMessage,
+		Usage:        usageBytes,
+		ModelID:      modelID,
+	})
+	if err != nil {
+		s.logger.Error("complete event trigger log failed", slog.Any("error", err))
+	}
+}
+
+func (s *Service) ListLogs(ctx context.Context, botID string, before *time.Time, limit int) ([]Log, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	beforeTS := pgtype.Timestamptz{}
+	if before != nil {
+		beforeTS = pgtype.Timestamptz{Time: *before, Valid: true}
+	}
+	rows, err := s.queries.ListEventTriggerLogsByBot(ctx, sqlc.ListEventTriggerLogsByBotParams{
+		BotID:   pgBotID,
+		Column2: beforeTS,
+		Limit:   int32(limit), //nolint:gosec // capped to 100 above
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Log, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toLog(sqlc.ListEventTriggerLogsByTriggerRow(row)))
+	}
+	return items, nil
+}
+
+func (s *Service) ListLogsByTrigger(ctx context.Context, triggerID string, before *time.Time, limit int) ([]Log, error) {
+	pgID, err := db.ParseUUID(triggerID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	beforeTS := pgtype.Timestamptz{}
+	if before != nil {
+		beforeTS = pgtype.Timestamptz{Time: *before, Valid: true}
+	}
+	rows, err := s.queries.ListEventTriggerLogsByTrigger(ctx, sqlc.ListEventTriggerLogsByTriggerParams{
+		TriggerID: pgID,
+		Column2:   beforeTS,
+		Limit:     int32(limit), //nolint:gosec // capped to 100 above
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Log, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toLog(row))
+	}
+	return items, nil
+}
+
+func (s *Service) DeleteLogs(ctx context.Context, botID string) error {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return err
+	}
+	return s.queries.DeleteEventTriggerLogsByBot(ctx, pgBotID)
+}
+
+// resolveBotOwner returns the owner user ID for the given bot.
+func (s *Service) resolveBotOwner(ctx context.Context, botID string) (string, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return "", err
+	}
+	bot, err := s.queries.GetBotByID(ctx, pgBotID)
+	if err != nil {
+		return "", fmt.Errorf("get bot: %w", err)
+	}
+	ownerID := bot.OwnerUserID.String()
+	if ownerID == "" {
+		return "", errors.New("bot owner not found")
+	}
+	return ownerID, nil
+}
+
+// generateTriggerToken creates a short-lived JWT for trigger callbacks.
+func (s *Service) generateTriggerToken(userID string) (string, error) {
+	if strings.TrimSpace(s.jwtSecret) == "" {
+		return "", errors.New("jwt secret not configured")
+	}
+	signed, _, err := auth.GenerateToken(userID, s.jwtSecret, triggerTokenTTL)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + signed, nil
+}
+
+// normalizeConfig validates cfg for source and drops the fields of other
+// sources.
+func normalizeConfig(source string, cfg Config) (Config, error) {
+	switch source {
+	case SourceFile:
+		p, err := resolveDataPath(cfg.Path)
+		if err != nil {
+			return Config{}, err
+		}
+		out := Config{Path: p, Recursive: cfg.Recursive, Pattern: strings.TrimSpace(cfg.Pattern)}
+		if _, err := path.Match(out.Pattern, ""); err != nil {
+			return Config{}, fmt.Errorf("invalid pattern %q: %w", out.Pattern, err)
+		}
+		for _, op := range cfg.Events {
+			op = strings.ToLower(strings.TrimSpace(op))
+			switch op {
+			case FileOpCreate, FileOpModify, FileOpDelete:
+			default:
+				return Config{}, fmt.Errorf("invalid file event %q: use create, modify or delete", op)
+			}
+			if !slices.Contains(out.Events, op) {
+				out.Events = append(out.Events, op)
+			}
+		}
+		return out, nil
+	case SourceEmail:
+		return Config{From: strings.TrimSpace(cfg.From), Subject: strings.TrimSpace(cfg.Subject)}, nil
+	case SourceWebhook:
+		return Config{}, nil
+	case SourceMCP:
+		out := Config{ConnectionID: strings.TrimSpace(cfg.ConnectionID), ResourceURI: strings.TrimSpace(cfg.ResourceURI)}
+		if out.ConnectionID == "" {
+			return Config{}, errors.New("connection_id is required for mcp triggers")
+		}
+		for _, method := range cfg.Methods {
+			if method = strings.TrimSpace(method); method != "" && !slices.Contains(out.Methods, method) {
+				out.Methods = append(out.Methods, method)
+			}
+		}
+		return out, nil
+	default:
+		return Config{}, fmt.Errorf("invalid source %q: use file, email, webhook or mcp", source)
+	}
+}
+
+// resolveDataPath resolves a watched path against /data and rejects paths
+// that leave it.
+func resolveDataPath(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errors.New("path is required for file triggers")
+	}
+	if !path.IsAbs(raw) {
+		raw = path.Join(dataRoot, raw)
+	}
+	p := path.Clean(raw)
+	if p != dataRoot && !strings.HasPrefix(p, dataRoot+"/") {
+		return "", fmt.Errorf("path %q must be under %s", raw, dataRoot)
+	}
+	return p, nil
+}
+
+func validateCooldown(seconds int) (int32, error) {
+	if seconds < 0 || seconds > math.MaxInt32 {
+		return 0, fmt.Errorf("cooldown_seconds out of range: %d", seconds)
+	}
+	return int32(seconds), nil //nolint:gosec // bounds checked above
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func toTrigger(row sqlc.EventTrigger) Trigger {
+	item := Trigger{
+		ID:              row.ID.String(),
+		BotID:           row.BotID.String(),
+		Name:            row.Name,
+		Description:     row.Description,
+		Source:          row.Source,
+		Command:         row.Command,
+		Enabled:         row.Enabled,
+		CooldownSeconds: int(row.CooldownSeconds),
+		WebhookSecret:   row.Secret,
+		CurrentCalls:    int(row.CurrentCalls),
+	}
+	if len(row.Config) > 0 {
+		_ = json.Unmarshal(row.Config, &item.Config)
+	}
+	if row.LastFiredAt.Valid {
+		t := row.LastFiredAt.Time
+		item.LastFiredAt = &t
+	}
+	if row.CreatedAt.Valid {
+		item.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		item.UpdatedAt = row.UpdatedAt.Time
+	}
+	return item
+}
+
+func toLog(row sqlc.ListEventTriggerLogsByTriggerRow) Log {
+	l := Log{
+		ID:           row.ID.String(),
+		TriggerID:    row.TriggerID.String(),
+		BotID:        row.BotID.String(),
+		SessionID:    row.SessionID.String(),
+		Source:       row.Source,
+		Status:       row.Status,
+		ResultText:   row.ResultText,
+		ErrorMessage: row.ErrorMessage,
+	}
+	if row.StartedAt.Valid {
+		l.StartedAt = row.StartedAt.Time
+	}
+	if row.CompletedAt.Valid {
+		t := row.CompletedAt.Time
+		l.CompletedAt = &t
+	}
+	if row.Event != nil {
+		var event any
+		if err := json.Unmarshal(row.Event, &event); err == nil {
+			l.Event = event
+		}
+	}
+	if row.Usage != nil {
+		var usage any
+		if err := json.Unmarshal(row.Usage, &usage); err == nil {
+			l.Usage = usage
+		}
+	}
+	return l
+}
+
+func toUUID(id string) pgtype.UUID {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return pgtype.UUID{}
+	}
+	return pgID
+}
@@ -0,0 +1,309 @@
+package eventtrigger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/db"
+	"github.com/memohai/memoh/internal/db/sqlc"
+	"github.com/memohai/memoh/internal/email"
+	pb "github.com/memohai/memoh/internal/workspace/bridgepb"
+)
+
+const (
+	listenMinBackoff = 5 * time.Second
+	listenMaxBackoff = 5 * time.Minute
+	// fileDebounce collects the changes of one burst, such as a copied
+	// directory, into a single run.
+	fileDebounce = 2 * time.Second
+	// fileMaxBatch bounds the changes reported to a single run.
+	fileMaxBatch = 100
+	// emailBodyLimit bounds the email text passed to a run.
+	emailBodyLimit = 4000
+)
+
+// FileChange is one changed path reported by a file trigger.
+type FileChange struct {
+	Op      string `json:"op"`
+	Path    string `json:"path"`
+	IsDir   bool   `json:"is_dir,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	ModTime string `json:"mod_time,omitempty"`
+}
+
+// restartListener stops the trigger's listener and starts a new one if the
+// trigger is enabled and listens to a long-lived source. Listeners start
+// once Bootstrap has run.
+func (s *Service) restartListener(item Trigger) {
+	s.stopListener(item.ID)
+	if !item.Enabled || (item.Source != SourceFile && item.Source != SourceMCP) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.baseCtx == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(s.baseCtx)
+	s.listeners[item.ID] = cancel
+	go s.listen(ctx, item)
+}
+
+func (s *Service) stopListener(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cancel, ok := s.listeners[id]; ok {
+		cancel()
+		delete(s.listeners, id)
+	}
+}
+
+// listen keeps a trigger's source connected, reconnecting with backoff
+// when the bot's container or MCP server is unavailable.
+func (s *Service) listen(ctx context.Context, item Trigger) {
+	logger := s.logger.With(slog.String("trigger_id", item.ID), slog.String("source", item.Source))
+	backoff := listenMinBackoff
+	for {
+		started := s.now()
+		var err error
+		switch item.Source {
+		case SourceFile:
+			err = s.watchFiles(ctx, item)
+		case SourceMCP:
+			err = s.watchNotifications(ctx, item)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if s.now().Sub(started) > listenMaxBackoff {
+			backoff = listenMinBackoff
+		}
+		logger.Warn("event trigger listener disconnected", slog.Duration("retry_in", backoff), slog.Any("error", err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, listenMaxBackoff)
+	}
+}
+
+// watchFiles streams changes under the trigger's path from the bot's
+// container and fires one run per debounced batch.
+func (s *Service) watchFiles(ctx context.Context, item Trigger) error {
+	if s.bridges == nil {
+		return errors.New("workspace bridge not configured")
+	}
+	client, err := s.bridges.MCPClient(ctx, item.BotID)
+	if err != nil {
+		return err
+	}
+	stream, err := client.Watch(ctx, item.Config.Path, item.Config.Recursive, 0)
+	if err != nil {
+		return err
+	}
+	events := make(chan *pb.WatchEvent)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var batch []FileChange
+	var flush <-chan time.Time
+	fireBatch := func() {
+		if len(batch) > 0 {
+			go s.fire(context.WithoutCancel(ctx), item.ID, fileEvent(item.Config.Path, batch))
+		}
+		batch, flush = nil, nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errc:
+			fireBatch()
+			if errors.Is(err, io.EOF) {
+				err = errors.New("watch stream ended")
+			}
+			return err
+		case ev := <-events:
+			change, ok := matchFileEvent(item.Config, ev)
+			if !ok {
+				continue
+			}
+			batch = mergeChange(batch, change)
+			if flush == nil {
+				flush = time.After(fileDebounce)
+			}
+		case <-flush:
+			fireBatch()
+		}
+	}
+}
+
+// matchFileEvent converts a watch event and reports whether it passes the
+// trigger's event and pattern filters.
+func matchFileEvent(cfg Config, ev *pb.WatchEvent) (FileChange, bool) {
+	var op string
+	switch ev.GetOp() {
+	case pb.WatchEvent_CREATE:
+		op = FileOpCreate
+	case pb.WatchEvent_MODIFY:
+		op = FileOpModify
+	case pb.WatchEvent_DELETE:
+		op = FileOpDelete
+	default:
+		return FileChange{}, false
+	}
+	if len(cfg.Events) > 0 && !slices.Contains(cfg.Events, op) {
+		return FileChange{}, false
+	}
+	if cfg.Pattern != "" {
+		if ok, _ := path.Match(cfg.Pattern, path.Base(ev.GetPath())); !ok {
+			return FileChange{}, false
+		}
+	}
+	return FileChange{
+		Op:      op,
+		Path:    ev.GetPath(),
+		IsDir:   ev.GetIsDir(),
+		Size:    ev.GetSize(),
+		ModTime: ev.GetModTime(),
+	}, true
+}
+
+// mergeChange adds a change to a batch, keeping one entry per path. A file
+// created and then modified within the batch is still reported as created.
+func mergeChange(batch []FileChange, change FileChange) []FileChange {
+	for i, existing := range batch {
+		if existing.Path != change.Path {
+			continue
+		}
+		if existing.Op == FileOpCreate && change.Op == FileOpModify {
+			change.Op = FileOpCreate
+		}
+		batch[i] = change
+		return batch
+	}
+	if len(batch) >= fileMaxBatch {
+		return batch
+	}
+	return append(batch, change)
+}
+
+func fileEvent(root string, changes []FileChange) Event {
+	summary := fmt.Sprintf("%d file changes under %s", len(changes), root)
+	if len(changes) == 1 {
+		summary = changes[0].Op + " " + changes[0].Path
+	}
+	return Event{
+		Source:  SourceFile,
+		Summary: summary,
+		Data:    map[string]any{"changes": changes},
+	}
+}
+
+// watchNotifications forwards notifications from the trigger's MCP
+// connection.
+func (s *Service) watchNotifications(ctx context.Context, item Trigger) error {
+	if s.notifications == nil {
+		return errors.New("mcp notifications not configured")
+	}
+	return s.notifications.WatchNotifications(ctx, item.BotID, item.Config.ConnectionID, item.Config.ResourceURI, func(method string, params any) {
+		if len(item.Config.Methods) > 0 && !slices.Contains(item.Config.Methods, method) {
+			return
+		}
+		go s.fire(context.WithoutCancel(ctx), item.ID, notificationEvent(method, params))
+	})
+}
+
+func notificationEvent(method string, params any) Event {
+	// Round-trip through JSON so logs store the wire form of SDK types.
+	var data map[string]any
+	if raw, err := json.Marshal(params); err == nil {
+		_ = json.Unmarshal(raw, &data)
+	}
+	summary := method
+	if uri, ok := data["uri"].(string); ok && uri != "" {
+		summary += " " + uri
+	}
+	return Event{
+		Source:  SourceMCP,
+		Summary: summary,
+		Data:    map[string]any{"method": method, "params": data},
+	}
+}
+
+// DispatchEmail fires the bot's enabled email triggers whose filters match
+// mail. Runs happen in the background.
+func (s *Service) DispatchEmail(ctx context.Context, botID string, mail email.InboundEmail) error {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return err
+	}
+	rows, err := s.queries.ListEnabledEventTriggersByBotAndSource(ctx, sqlc.ListEnabledEventTriggersByBotAndSourceParams{
+		BotID:  pgBotID,
+		Source: SourceEmail,
+	})
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		item := toTrigger(row)
+		if !matchEmail(item.Config, mail.From, mail.Subject) {
+			continue
+		}
+		go s.fire(context.WithoutCancel(ctx), item.ID, emailEvent(mail))
+	}
+	return nil
+}
+
+// matchEmail reports whether from and subject contain the trigger's
+// filters, ignoring case.
+func matchEmail(cfg Config, from, subject string) bool {
+	if cfg.From != "" && !strings.Contains(strings.ToLower(from), strings.ToLower(cfg.From)) {
+		return false
+	}
+	if cfg.Subject != "" && !strings.Contains(strings.ToLower(subject), strings.ToLower(cfg.Subject)) {
+		return false
+	}
+	return true
+}
+
+func emailEvent(mail email.InboundEmail) Event {
+	body := strings.TrimSpace(mail.BodyText)
+	if runes := []rune(body); len(runes) > emailBodyLimit {
+		body = string(runes[:emailBodyLimit]) + "…"
+	}
+	return Event{
+		Source:  SourceEmail,
+		Summary: fmt.Sprintf("email from %s: %s", mail.From, mail.Subject),
+		Data: map[string]any{
+			"message_id":  mail.MessageID,
+			"from":        mail.From,
+			"to":          mail.To,
+			"subject":     mail.Subject,
+			"body":        body,
+			"received_at": mail.ReceivedAt,
+		},
+	}
+}
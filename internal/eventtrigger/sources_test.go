@@ -0,0 +1,102 @@
+package eventtrigger
+
+import (
+	"testing"
+
+	pb "github.com/memohai/memoh/internal/workspace/bridgepb"
+)
+
+func TestNormalizeConfigFile(t *testing.T) {
+	cfg, err := normalizeConfig(SourceFile, Config{
+		Path:    "inbox/../inbox",
+		Pattern: "*.csv",
+		Events:  []string{"Create", "create", " modify "},
+		From:    "dropped@example.com",
+	})
+	if err != nil {
+		t.Fatalf("normalizeConfig returned error: %v", err)
+	}
+	if cfg.Path != "/data/inbox" {
+		t.Errorf("path = %q, want /data/inbox", cfg.Path)
+	}
+	if len(cfg.Events) != 2 || cfg.Events[0] != FileOpCreate || cfg.Events[1] != FileOpModify {
+		t.Errorf("events = %v, want [create modify]", cfg.Events)
+	}
+	if cfg.From != "" {
+		t.Errorf("email filter should be dropped from a file trigger, got %q", cfg.From)
+	}
+}
+
+func TestNormalizeConfigRejects(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		cfg    Config
+	}{
+		{"missing path", SourceFile, Config{}},
+		{"outside data", SourceFile, Config{Path: "/etc"}},
+		{"escaping data", SourceFile, Config{Path: "../etc"}},
+		{"data prefix", SourceFile, Config{Path: "/database"}},
+		{"bad pattern", SourceFile, Config{Path: "/data", Pattern: "["}},
+		{"bad event", SourceFile, Config{Path: "/data", Events: []string{"rename"}}},
+		{"missing connection", SourceMCP, Config{}},
+		{"unknown source", "cron", Config{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := normalizeConfig(tc.source, tc.cfg); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestMatchFileEvent(t *testing.T) {
+	cfg := Config{Pattern: "*.csv", Events: []string{FileOpCreate}}
+	cases := []struct {
+		name string
+		ev   *pb.WatchEvent
+		want bool
+	}{
+		{"match", &pb.WatchEvent{Op: pb.WatchEvent_CREATE, Path: "/data/in/a.csv"}, true},
+		{"wrong op", &pb.WatchEvent{Op: pb.WatchEvent_DELETE, Path: "/data/in/a.csv"}, false},
+		{"wrong pattern", &pb.WatchEvent{Op: pb.WatchEvent_CREATE, Path: "/data/in/a.txt"}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			change, ok := matchFileEvent(cfg, tc.ev)
+			if ok != tc.want {
+				t.Fatalf("matchFileEvent = %v, want %v", ok, tc.want)
+			}
+			if ok && (change.Op != FileOpCreate || change.Path != tc.ev.GetPath()) {
+				t.Errorf("unexpected change %+v", change)
+			}
+		})
+	}
+}
+
+func TestMergeChange(t *testing.T) {
+	var batch []FileChange
+	batch = mergeChange(batch, FileChange{Op: FileOpCreate, Path: "/data/a", Size: 1})
+	batch = mergeChange(batch, FileChange{Op: FileOpModify, Path: "/data/a", Size: 2})
+	batch = mergeChange(batch, FileChange{Op: FileOpModify, Path: "/data/b"})
+	if len(batch) != 2 {
+		t.Fatalf("len(batch) = %d, want 2", len(batch))
+	}
+	if batch[0].Op != FileOpCreate || batch[0].Size != 2 {
+		t.Errorf("created then modified file = %+v, want create with latest size", batch[0])
+	}
+}
+
+func TestMatchEmail(t *testing.T) {
+	cfg := Config{From: "@Example.com", Subject: "invoice"}
+	if !matchEmail(cfg, "billing@example.com", "Your INVOICE for May") {
+		t.Error("expected case-insensitive match")
+	}
+	if matchEmail(cfg, "billing@other.com", "Invoice") {
+		t.Error("expected sender mismatch")
+	}
+	if !matchEmail(Config{}, "anyone@x.y", "") {
+		t.Error("empty filters should match every email")
+	}
+}
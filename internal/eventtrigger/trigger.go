@@ -0,0 +1,29 @@
+package eventtrigger
+
+import "context"
+
+// TriggerPayload describes the parameters passed to the chat side when an
+// event trigger fires.
+type TriggerPayload struct {
+	ID          string
+	Name        string
+	Description string
+	Source      string
+	Command     string
+	Event       Event
+	OwnerUserID string
+	SessionID   string
+}
+
+// TriggerResult carries execution metadata back from the resolver.
+type TriggerResult struct {
+	Status     string
+	Text       string
+	UsageBytes []byte
+	ModelID    string
+}
+
+// Triggerer runs the agent for a fired event trigger.
+type Triggerer interface {
+	TriggerEvent(ctx context.Context, botID string, payload TriggerPayload, token string) (TriggerResult, error)
+}
@@ -0,0 +1,124 @@
+package eventtrigger
+
+import "time"
+
+// Event sources a trigger can listen to.
+const (
+	SourceFile    = "file"
+	SourceEmail   = "email"
+	SourceWebhook = "webhook"
+	SourceMCP     = "mcp"
+)
+
+// File operations a file trigger can filter on.
+const (
+	FileOpCreate = "create"
+	FileOpModify = "modify"
+	FileOpDelete = "delete"
+)
+
+// Config holds the source-specific settings of a trigger. Only the fields
+// of the trigger's source are used.
+type Config struct {
+	// Path is the file or directory watched by a file trigger. Relative
+	// paths are resolved against /data, and the path must stay below it.
+	Path      string `json:"path,omitempty"`
+	Recursive bool   `json:"recursive,omitempty"`
+	// Pattern is a glob matched against the base name of changed files,
+	// e.g. "*.csv". Empty matches every file.
+	Pattern string `json:"pattern,omitempty"`
+	// Events limits a file trigger to create, modify and/or delete. Empty
+	// means all three.
+	Events []string `json:"events,omitempty"`
+
+	// From and Subject are case-insensitive substrings an inbound email must
+	// contain to fire an email trigger. Empty matches every email.
+	From    string `json:"from,omitempty"`
+	Subject string `json:"subject,omitempty"`
+
+	// ConnectionID is the bot's HTTP or SSE MCP connection an mcp trigger
+	// listens to.
+	ConnectionID string `json:"connection_id,omitempty"`
+	// Methods limits an mcp trigger to these notification methods, e.g.
+	// "notifications/resources/updated". Empty means all of them.
+	Methods []string `json:"methods,omitempty"`
+	// ResourceURI is subscribed to on connect so the server reports its
+	// updates.
+	ResourceURI string `json:"resource_uri,omitempty"`
+}
+
+type Trigger struct {
+	ID          string `json:"id"`
+	BotID       string `json:"bot_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Source      string `json:"source"`
+	Config      Config `json:"config"`
+	Command     string `json:"command"`
+	Enabled     bool   `json:"enabled"`
+	// CooldownSeconds is the minimum time between two runs; events arriving
+	// sooner are dropped.
+	CooldownSeconds int `json:"cooldown_seconds"`
+	// WebhookSecret authenticates calls to a webhook trigger's public URL.
+	WebhookSecret string     `json:"webhook_secret,omitempty"`
+	CurrentCalls  int        `json:"current_calls"`
+	LastFiredAt   *time.Time `json:"last_fired_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type CreateRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Source          string `json:"source"`
+	Config          Config `json:"config"`
+	Command         string `json:"command"`
+	Enabled         *bool  `json:"enabled,omitempty"`
+	CooldownSeconds int    `json:"cooldown_seconds,omitempty"`
+}
+
+// UpdateRequest changes a trigger. The source cannot change; create a new
+// trigger instead.
+type UpdateRequest struct {
+	Name            *string `json:"name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	Config          *Config `json:"config,omitempty"`
+	Command         *string `json:"command,omitempty"`
+	Enabled         *bool   `json:"enabled,omitempty"`
+	CooldownSeconds *int    `json:"cooldown_seconds,omitempty"`
+	// RotateSecret issues a new webhook secret.
+	RotateSecret bool `json:"rotate_secret,omitempty"`
+}
+
+type ListResponse struct {
+	Items []Trigger `json:"items"`
+}
+
+// Event is what happened when a trigger fires.
+type Event struct {
+	Source string `json:"source"`
+	// Summary is a one-line description shown to the bot and in logs.
+	Summary string `json:"summary"`
+	// Data is the source-specific payload, such as the changed files, the
+	// email headers or the webhook body.
+	Data any `json:"data,omitempty"`
+}
+
+type Log struct {
+	ID           string     `json:"id"`
+	TriggerID    string     `json:"trigger_id"`
+	BotID        string     `json:"bot_id"`
+	SessionID    string     `json:"session_id,omitempty"`
+	Source       string     `json:"source"`
+	Event        any        `json:"event,omitempty"`
+	Status       string     `json:"status"`
+	ResultText   string     `json:"result_text"`
+	ErrorMessage string     `json:"error_message"`
+	Usage        any        `json:"usage,omitempty"`
+	StartedAt    time.Time  `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+type ListLogsResponse struct {
+	Items []Log `json:"items"`
+}
@@ -39,6 +39,9 @@ func (h *ACLHandler) Register(e *echo.Echo) {
 	group.GET("/blacklist", h.ListBlacklist)
 	group.PUT("/blacklist", h.UpsertBlacklist)
 	group.DELETE("/blacklist/:rule_id", h.DeleteBlacklist)
+	group.GET("/tool_rules", h.ListToolRules)
+	group.PUT("/tool_rules", h.UpsertToolRule)
+	group.DELETE("/tool_rules/:rule_id", h.DeleteToolRule)
 	group.GET("/access/users", h.SearchUsers)
 	group.GET("/access/channel_identities", h.SearchChannelIdentities)
 	group.GET("/access/channel_identities/:channel_identity_id/conversations", h.ListObservedConversationsByChannelIdentity)
@@ -198,6 +201,88 @@ func (h *ACLHandler) DeleteBlacklist(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// ListToolRules godoc
+// @Summary List bot tool rules
+// @Description List allow and deny rules that control which tools the bot offers to each caller
+// @Tags bots
+// @Param bot_id path string true "Bot ID"
+// @Success 200 {object} acl.ListRulesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/tool_rules [get].
+func (h *ACLHandler) ListToolRules(c echo.Context) error {
+	botID, _, err := h.requireManageAccess(c)
+	if err != nil {
+		return err
+	}
+	items, err := h.service.ListToolRules(c.Request().Context(), botID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, acl.ListRulesResponse{Items: items})
+}
+
+// UpsertToolRule godoc
+// @Summary Upsert bot tool rule
+// @Description Allow or deny tools matching a name or glob for a user, a channel identity, or everyone. The bot owner is never restricted.
+// @Tags bots
+// @Param bot_id path string true "Bot ID"
+// @Param payload body acl.UpsertToolRuleRequest true "Tool rule payload"
+// @Success 200 {object} acl.Rule
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/tool_rules [put].
+func (h *ACLHandler) UpsertToolRule(c echo.Context) error {
+	botID, actorID, err := h.requireManageAccess(c)
+	if err != nil {
+		return err
+	}
+	var req acl.UpsertToolRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	item, err := h.service.AddToolRule(c.Request().Context(), botID, actorID, req)
+	if err != nil {
+		if errors.Is(err, acl.ErrInvalidRuleSubject) || errors.Is(err, acl.ErrInvalidToolPattern) || errors.Is(err, acl.ErrInvalidEffect) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// DeleteToolRule godoc
+// @Summary Delete bot tool rule
+// @Description Delete a tool rule of the bot by rule ID
+// @Tags bots
+// @Param bot_id path string true "Bot ID"
+// @Param rule_id path string true "Rule ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/tool_rules/{rule_id} [delete].
+func (h *ACLHandler) DeleteToolRule(c echo.Context) error {
+	botID, _, err := h.requireManageAccess(c)
+	if err != nil {
+		return err
+	}
+	ruleID := strings.TrimSpace(c.Param("rule_id"))
+	if ruleID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "rule id is required")
+	}
+	if err := h.service.DeleteToolRule(c.Request().Context(), botID, ruleID); err != nil {
+		if errors.Is(err, acl.ErrToolRuleNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
 // SearchUsers godoc
 // @Summary Search access users
 // @Description Search user candidates for bot access control
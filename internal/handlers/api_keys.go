@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/apikeys"
+	"github.com/memohai/memoh/internal/auth"
+)
+
+type APIKeysHandler struct {
+	service *apikeys.Service
+	logger  *slog.Logger
+}
+
+func NewAPIKeysHandler(log *slog.Logger, service *apikeys.Service) *APIKeysHandler {
+	return &APIKeysHandler{
+		service: service,
+		logger:  log.With(slog.String("handler", "api_keys")),
+	}
+}
+
+func (h *APIKeysHandler) Register(e *echo.Echo) {
+	group := e.Group("/users/me/api-keys")
+	group.GET("", h.List)
+	group.POST("", h.Create)
+	group.GET("/scopes", h.ListScopes)
+	group.DELETE("/:id", h.Revoke)
+}
+
+// List godoc
+// @Summary List API keys
+// @Description List the current user's personal access tokens, including revoked and expired ones. Tokens are never returned after creation.
+// @Tags api-keys
+// @Success 200 {object} apikeys.ListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me/api-keys [get].
+func (h *APIKeysHandler) List(c echo.Context) error {
+	userID, err := RequireChannelIdentityID(c)
+	if err != nil {
+		return err
+	}
+	items, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, apikeys.ListResponse{Items: items})
+}
+
+// Create godoc
+// @Summary Create API key
+// @Description Create a long-lived personal access token with the given scopes. The token is returned only in this response; send it as a Bearer token.
+// @Tags api-keys
+// @Param payload body apikeys.CreateRequest true "API key payload"
+// @Success 201 {object} apikeys.CreateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me/api-keys [post].
+func (h *APIKeysHandler) Create(c echo.Context) error {
+	userID, err := RequireChannelIdentityID(c)
+	if err != nil {
+		return err
+	}
+	var req apikeys.CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.service.Create(c.Request().Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apikeys.ErrNameRequired),
+			errors.Is(err, apikeys.ErrNoScopes),
+			errors.Is(err, apikeys.ErrInvalidScope),
+			errors.Is(err, apikeys.ErrInvalidExpiry):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+	}
+	h.logger.Info("api key created", slog.String("user_id", userID), slog.String("key_id", resp.ID))
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// ListScopes godoc
+// @Summary List API key scopes
+// @Description List the scopes that can be granted to an API key
+// @Tags api-keys
+// @Success 200 {object} apikeys.ScopesResponse
+// @Router /users/me/api-keys/scopes [get].
+func (*APIKeysHandler) ListScopes(c echo.Context) error {
+	return c.JSON(http.StatusOK, apikeys.ScopesResponse{Scopes: slices.Clone(auth.Scopes)})
+}
+
+// Revoke godoc
+// @Summary Revoke API key
+// @Description Revoke one of the current user's API keys. Requests using it are rejected immediately.
+// @Tags api-keys
+// @Param id path string true "API key ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me/api-keys/{id} [delete].
+func (h *APIKeysHandler) Revoke(c echo.Context) error {
+	userID, err := RequireChannelIdentityID(c)
+	if err != nil {
+		return err
+	}
+	if err := h.service.Revoke(c.Request().Context(), userID, c.Param("id")); err != nil {
+		if errors.Is(err, apikeys.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	h.logger.Info("api key revoked", slog.String("user_id", userID), slog.String("key_id", c.Param("id")))
+	return c.NoContent(http.StatusNoContent)
+}
@@ -97,6 +97,9 @@ type GetContainerResponse struct {
 	Legacy           bool      `json:"legacy"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
+
+	Resources *workspace.ResourceProfile `json:"resources,omitempty"`
+	Usage     *workspace.ResourceUsage   `json:"usage,omitempty"`
 }
 
 type RollbackRequest struct {
@@ -159,6 +162,11 @@ func (h *ContainerdHandler) Register(e *echo.Echo) {
 	group.DELETE("", h.DeleteContainer)
 	group.POST("/start", h.StartContainer)
 	group.POST("/stop", h.StopContainer)
+	group.GET("/resources", h.GetContainerResources)
+	group.PUT("/resources", h.UpdateContainerResources)
+	group.DELETE("/resources", h.ResetContainerResources)
+	group.GET("/network", h.GetContainerNetwork)
+	group.PUT("/network", h.UpdateContainerNetwork)
 	group.POST("/snapshots", h.CreateSnapshot)
 	group.GET("/snapshots", h.ListSnapshots)
 	group.POST("/snapshots/rollback", h.RollbackSnapshot)
@@ -362,9 +370,139 @@ func (h *ContainerdHandler) GetContainer(c echo.Context) error {
 		Legacy:           status.Legacy,
 		CreatedAt:        status.CreatedAt,
 		UpdatedAt:        status.UpdatedAt,
+		Resources:        status.Resources,
+		Usage:            status.Usage,
 	})
 }
 
+// GetContainerResources godoc
+// @Summary Get container resource profile for bot
+// @Description Get the CPU, memory, pids and disk limits applied to the bot container. Zero means unlimited; inherited profiles come from the server defaults.
+// @Tags containerd
+// @Param bot_id path string true "Bot ID"
+// @Success 200 {object} workspace.ResourceProfile
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/container/resources [get].
+func (h *ContainerdHandler) GetContainerResources(c echo.Context) error {
+	botID, err := h.requireBotAccess(c)
+	if err != nil {
+		return err
+	}
+	profile, err := h.manager.ResourceProfile(c.Request().Context(), botID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, profile)
+}
+
+// UpdateContainerResources godoc
+// @Summary Update container resource profile for bot
+// @Description Set the bot's CPU shares, CPU quota (1000 millicores = one CPU), memory, pids and disk quota. Limits are applied to the existing container immediately, including its running task.
+// @Tags containerd
+// @Param bot_id path string true "Bot ID"
+// @Param payload body workspace.ResourceProfile true "Resource profile"
+// @Success 200 {object} workspace.ResourceProfile
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/container/resources [put].
+func (h *ContainerdHandler) UpdateContainerResources(c echo.Context) error {
+	botID, err := h.requireBotAccess(c)
+	if err != nil {
+		return err
+	}
+	var req workspace.ResourceProfile
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	profile, err := h.manager.UpdateResourceProfile(c.Request().Context(), botID, req)
+	if err != nil {
+		if errors.Is(err, workspace.ErrInvalidResourceProfile) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, profile)
+}
+
+// ResetContainerResources godoc
+// @Summary Reset container resource profile for bot
+// @Description Remove the bot's own resource profile so the server defaults apply again
+// @Tags containerd
+// @Param bot_id path string true "Bot ID"
+// @Success 200 {object} workspace.ResourceProfile
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/container/resources [delete].
+func (h *ContainerdHandler) ResetContainerResources(c echo.Context) error {
+	botID, err := h.requireBotAccess(c)
+	if err != nil {
+		return err
+	}
+	profile, err := h.manager.ResetResourceProfile(c.Request().Context(), botID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, profile)
+}
+
+// GetContainerNetwork godoc
+// @Summary Get container network policy for bot
+// @Description Get the bot container's egress mode (full, allowlist or none) and allow list. auto_allow lists the MCP server and browser gateway hosts an allowlist permits implicitly.
+// @Tags containerd
+// @Param bot_id path string true "Bot ID"
+// @Success 200 {object} workspace.NetworkPolicy
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/container/network [get].
+func (h *ContainerdHandler) GetContainerNetwork(c echo.Context) error {
+	botID, err := h.requireBotAccess(c)
+	if err != nil {
+		return err
+	}
+	policy, err := h.manager.NetworkPolicy(c.Request().Context(), botID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, policy)
+}
+
+// UpdateContainerNetwork godoc
+// @Summary Update container network policy for bot
+// @Description Set the bot container's egress mode and allow list of domains, IP addresses or CIDRs. The policy is enforced in the container's network namespace and applied to the running task immediately.
+// @Tags containerd
+// @Param bot_id path string true "Bot ID"
+// @Param payload body workspace.NetworkPolicy true "Network policy"
+// @Success 200 {object} workspace.NetworkPolicy
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse
+// @Router /bots/{bot_id}/container/network [put].
+func (h *ContainerdHandler) UpdateContainerNetwork(c echo.Context) error {
+	botID, err := h.requireBotAccess(c)
+	if err != nil {
+		return err
+	}
+	var req workspace.NetworkPolicy
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	policy, err := h.manager.UpdateNetworkPolicy(c.Request().Context(), botID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, workspace.ErrInvalidNetworkPolicy):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, ctr.ErrNotSupported):
+			return echo.NewHTTPError(http.StatusNotImplemented, "network policies are not supported on this container backend")
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+	}
+	return c.JSON(http.StatusOK, policy)
+}
+
 // DeleteContainer godoc
 // @Summary Delete MCP container for bot
 // @Tags containerd
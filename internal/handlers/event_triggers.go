@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/accounts"
+	"github.com/memohai/memoh/internal/bots"
+	"github.com/memohai/memoh/internal/eventtrigger"
+)
+
+// webhookBodyLimit bounds the request body passed to a webhook trigger run.
+const webhookBodyLimit = 64 << 10
+
+type EventTriggerHandler struct {
+	service        *eventtrigger.Service
+	botService     *bots.Service
+	accountService *accounts.Service
+	logger         *slog.Logger
+}
+
+func NewEventTriggerHandler(log *slog.Logger, service *eventtrigger.Service, botService *bots.Service, accountService *accounts.Service) *EventTriggerHandler {
+	return &EventTriggerHandler{
+		service:        service,
+		botService:     botService,
+		accountService: accountService,
+		logger:         log.With(slog.String("handler", "event_trigger")),
+	}
+}
+
+func (h *EventTriggerHandler) Register(e *echo.Echo) {
+	group := e.Group("/bots/:bot_id/event-triggers")
+	group.POST("", h.Create)
+	group.GET("", h.List)
+	group.GET("/logs", h.ListLogs)
+	group.DELETE("/logs", h.DeleteLogs)
+	group.GET("/:id", h.Get)
+	group.GET("/:id/logs", h.ListLogsByTrigger)
+	group.POST("/:id/fire", h.Fire)
+	group.PUT("/:id", h.Update)
+	group.DELETE("/:id", h.Delete)
+
+	// The webhook URL is public and authenticated by the trigger's secret.
+	e.POST("/triggers/webhook/:id", h.Webhook)
+}
+
+// Create godoc
+// @Summary Create event trigger
+// @Description Create a trigger that runs the bot when a file changes, an email arrives, a webhook is called or an MCP server sends a notification
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Param payload body eventtrigger.CreateRequest true "Event trigger payload"
+// @Success 201 {object} eventtrigger.Trigger
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers [post].
+func (h *EventTriggerHandler) Create(c echo.Context) error {
+	userID, err := h.requireUserID(c)
+	if err != nil {
+		return err
+	}
+	botID := strings.TrimSpace(c.Param("bot_id"))
+	if botID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "bot id is required")
+	}
+	if _, err := h.authorizeBotAccess(c.Request().Context(), userID, botID); err != nil {
+		return err
+	}
+	var req eventtrigger.CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.service.Create(c.Request().Context(), botID, req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// List godoc
+// @Summary List event triggers
+// @Description List event triggers of a bot
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Success 200 {object} eventtrigger.ListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers [get].
+func (h *EventTriggerHandler) List(c echo.Context) error {
+	userID, err := h.requireUserID(c)
+	if err != nil {
+		return err
+	}
+	botID := strings.TrimSpace(c.Param("bot_id"))
+	if botID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "bot id is required")
+	}
+	if _, err := h.authorizeBotAccess(c.Request().Context(), userID, botID); err != nil {
+		return err
+	}
+	items, err := h.service.List(c.Request().Context(), botID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, eventtrigger.ListResponse{Items: items})
+}
+
+// Get godoc
+// @Summary Get event trigger
+// @Description Get an event trigger by ID
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Param id path string true "Event trigger ID"
+// @Success 200 {object} eventtrigger.Trigger
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers/{id} [get].
+func (h *EventTriggerHandler) Get(c echo.Context) error {
+	item, err := h.authorizeTrigger(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// Update godoc
+// @Summary Update event trigger
+// @Description Update an event trigger by ID. Its source cannot change.
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Param id path string true "Event trigger ID"
+// @Param payload body eventtrigger.UpdateRequest true "Event trigger payload"
+// @Success 200 {object} eventtrigger.Trigger
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers/{id} [put].
+func (h *EventTriggerHandler) Update(c echo.Context) error {
+	var req eventtrigger.UpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	item, err := h.authorizeTrigger(c)
+	if err != nil {
+		return err
+	}
+	resp, err := h.service.Update(c.Request().Context(), item.ID, req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Delete godoc
+// @Summary Delete event trigger
+// @Description Delete an event trigger by ID
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Param id path string true "Event trigger ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers/{id} [delete].
+func (h *EventTriggerHandler) Delete(c echo.Context) error {
+	item, err := h.authorizeTrigger(c)
+	if err != nil {
+		return err
+	}
+	if err := h.service.Delete(c.Request().Context(), item.ID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Fire godoc
+// @Summary Fire event trigger
+// @Description Run an event trigger now with a test event, ignoring its cooldown
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Param id path string true "Event trigger ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers/{id}/fire [post].
+func (h *EventTriggerHandler) Fire(c echo.Context) error {
+	item, err := h.authorizeTrigger(c)
+	if err != nil {
+		return err
+	}
+	if err := h.service.Fire(c.Request().Context(), item.ID); err != nil {
+		if errors.Is(err, eventtrigger.ErrBusy) || errors.Is(err, eventtrigger.ErrDisabled) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListLogs godoc
+// @Summary List event trigger logs
+// @Description List event trigger run logs for a bot
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Param before query string false "Before timestamp (RFC3339)"
+// @Param limit query int false "Limit" default(50)
+// @Success 200 {object} eventtrigger.ListLogsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers/logs [get].
+func (h *EventTriggerHandler) ListLogs(c echo.Context) error {
+	userID, err := h.requireUserID(c)
+	if err != nil {
+		return err
+	}
+	botID := strings.TrimSpace(c.Param("bot_id"))
+	if botID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "bot id is required")
+	}
+	if _, err := h.authorizeBotAccess(c.Request().Context(), userID, botID); err != nil {
+		return err
+	}
+
+	before, limit := parseBeforeLimit(c)
+	items, err := h.service.ListLogs(c.Request().Context(), botID, before, limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, eventtrigger.ListLogsResponse{Items: items})
+}
+
+// ListLogsByTrigger godoc
+// @Summary List event trigger logs by trigger
+// @Description List run logs for a specific event trigger
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Param id path string true "Event trigger ID"
+// @Param before query string false "Before timestamp (RFC3339)"
+// @Param limit query int false "Limit" default(50)
+// @Success 200 {object} eventtrigger.ListLogsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers/{id}/logs [get].
+func (h *EventTriggerHandler) ListLogsByTrigger(c echo.Context) error {
+	item, err := h.authorizeTrigger(c)
+	if err != nil {
+		return err
+	}
+
+	before, limit := parseBeforeLimit(c)
+	items, err := h.service.ListLogsByTrigger(c.Request().Context(), item.ID, before, limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, eventtrigger.ListLogsResponse{Items: items})
+}
+
+// DeleteLogs godoc
+// @Summary Delete event trigger logs
+// @Description Delete all event trigger run logs for a bot
+// @Tags event-triggers
+// @Param bot_id path string true "Bot ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/event-triggers/logs [delete].
+func (h *EventTriggerHandler) DeleteLogs(c echo.Context) error {
+	userID, err := h.requireUserID(c)
+	if err != nil {
+		return err
+	}
+	botID := strings.TrimSpace(c.Param("bot_id"))
+	if botID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "bot id is required")
+	}
+	if _, err := h.authorizeBotAccess(c.Request().Context(), userID, botID); err != nil {
+		return err
+	}
+	if err := h.service.DeleteLogs(c.Request().Context(), botID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Webhook godoc
+// @Summary Call webhook trigger
+// @Description Fire a webhook trigger. Authenticate with the trigger's secret in the X-Trigger-Secret header or the secret query parameter. The request body (JSON or text, up to 64 KiB) is passed to the bot. The run happens in the background.
+// @Tags event-triggers
+// @Param id path string true "Event trigger ID"
+// @Param secret query string false "Webhook secret"
+// @Success 202 "Accepted"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /triggers/webhook/{id} [post].
+func (h *EventTriggerHandler) Webhook(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	secret := strings.TrimSpace(c.Request().Header.Get("X-Trigger-Secret"))
+	if secret == "" {
+		secret = strings.TrimSpace(c.QueryParam("secret"))
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, webhookBodyLimit+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if len(body) > webhookBodyLimit {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "webhook body too large")
+	}
+	var payload any
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			payload = string(body)
+		}
+	}
+	err = h.service.FireWebhook(c.Request().Context(), id, secret, payload)
+	switch {
+	case err == nil:
+		return c.NoContent(http.StatusAccepted)
+	case errors.Is(err, eventtrigger.ErrNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, eventtrigger.ErrInvalidSecret):
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	case errors.Is(err, eventtrigger.ErrDisabled):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		h.logger.Error("webhook trigger failed", slog.String("trigger_id", id), slog.Any("error", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "webhook trigger failed")
+	}
+}
+
+// authorizeTrigger loads the trigger named in the path and checks that it
+// belongs to the path's bot and that the caller may access that bot.
+func (h *EventTriggerHandler) authorizeTrigger(c echo.Context) (eventtrigger.Trigger, error) {
+	userID, err := h.requireUserID(c)
+	if err != nil {
+		return eventtrigger.Trigger{}, err
+	}
+	botID := strings.TrimSpace(c.Param("bot_id"))
+	if botID == "" {
+		return eventtrigger.Trigger{}, echo.NewHTTPError(http.StatusBadRequest, "bot id is required")
+	}
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return eventtrigger.Trigger{}, echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	item, err := h.service.Get(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, eventtrigger.ErrNotFound) {
+			return eventtrigger.Trigger{}, echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return eventtrigger.Trigger{}, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if item.BotID != botID {
+		return eventtrigger.Trigger{}, echo.NewHTTPError(http.StatusForbidden, "bot mismatch")
+	}
+	if _, err := h.authorizeBotAccess(c.Request().Context(), userID, botID); err != nil {
+		return eventtrigger.Trigger{}, err
+	}
+	return item, nil
+}
+
+func (*EventTriggerHandler) requireUserID(c echo.Context) (string, error) {
+	return RequireChannelIdentityID(c)
+}
+
+func (h *EventTriggerHandler) authorizeBotAccess(ctx context.Context, userID, botID string) (bots.Bot, error) {
+	return AuthorizeBotAccess(ctx, h.botService, h.accountService, userID, botID)
+}
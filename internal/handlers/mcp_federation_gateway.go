@@ -42,7 +42,7 @@ func (g *MCPFederationGateway) SetOAuthService(svc *mcpgw.OAuthService) {
 }
 
 func (g *MCPFederationGateway) ListHTTPConnectionTools(ctx context.Context, connection mcpgw.Connection) ([]mcpgw.ToolDescriptor, error) {
-	session, err := g.connectStreamableSession(ctx, connection)
+	session, err := g.connectStreamableSession(ctx, connection, g.connectionHTTPClient(ctx, connection), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +55,7 @@ func (g *MCPFederationGateway) ListHTTPConnectionTools(ctx context.Context, conn
 }
 
 func (g *MCPFederationGateway) CallHTTPConnectionTool(ctx context.Context, connection mcpgw.Connection, toolName string, args map[string]any) (map[string]any, error) {
-	session, err := g.connectStreamableSession(ctx, connection)
+	session, err := g.connectStreamableSession(ctx, connection, g.connectionHTTPClient(ctx, connection), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +71,7 @@ func (g *MCPFederationGateway) CallHTTPConnectionTool(ctx context.Context, conne
 }
 
 func (g *MCPFederationGateway) ListSSEConnectionTools(ctx context.Context, connection mcpgw.Connection) ([]mcpgw.ToolDescriptor, error) {
-	session, err := g.connectSSESession(ctx, connection)
+	session, err := g.connectSSESession(ctx, connection, g.connectionHTTPClient(ctx, connection), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +84,7 @@ func (g *MCPFederationGateway) ListSSEConnectionTools(ctx context.Context, conne
 }
 
 func (g *MCPFederationGateway) CallSSEConnectionTool(ctx context.Context, connection mcpgw.Connection, toolName string, args map[string]any) (map[string]any, error) {
-	session, err := g.connectSSESession(ctx, connection)
+	session, err := g.connectSSESession(ctx, connection, g.connectionHTTPClient(ctx, connection), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +99,7 @@ func (g *MCPFederationGateway) CallSSEConnectionTool(ctx context.Context, connec
 	return wrapSDKToolResult(result)
 }
 
-func (g *MCPFederationGateway) connectStreamableSession(ctx context.Context, connection mcpgw.Connection) (*sdkmcp.ClientSession, error) {
+func (g *MCPFederationGateway) connectStreamableSession(ctx context.Context, connection mcpgw.Connection, httpClient *http.Client, opts *sdkmcp.ClientOptions) (*sdkmcp.ClientSession, error) {
 	url := strings.TrimSpace(anyToString(connection.Config["url"]))
 	if url == "" {
 		return nil, errors.New("http mcp url is required")
@@ -107,16 +107,16 @@ func (g *MCPFederationGateway) connectStreamableSession(ctx context.Context, con
 	client := sdkmcp.NewClient(&sdkmcp.Implementation{
 		Name:    "memoh-federation-client",
 		Version: "v1",
-	}, nil)
+	}, opts)
 	transport := &sdkmcp.StreamableClientTransport{
 		Endpoint:   url,
-		HTTPClient: g.connectionHTTPClient(ctx, connection),
+		HTTPClient: httpClient,
 		MaxRetries: -1,
 	}
 	return client.Connect(ctx, transport, nil)
 }
 
-func (g *MCPFederationGateway) connectSSESession(ctx context.Context, connection mcpgw.Connection) (*sdkmcp.ClientSession, error) {
+func (g *MCPFederationGateway) connectSSESession(ctx context.Context, connection mcpgw.Connection, httpClient *http.Client, opts *sdkmcp.ClientOptions) (*sdkmcp.ClientSession, error) {
 	endpoints := resolveSSEEndpointCandidates(connection.Config)
 	if len(endpoints) == 0 {
 		return nil, errors.New("sse mcp url is required")
@@ -126,10 +126,10 @@ func (g *MCPFederationGateway) connectSSESession(ctx context.Context, connection
 		client := sdkmcp.NewClient(&sdkmcp.Implementation{
 			Name:    "memoh-federation-client",
 			Version: "v1",
-		}, nil)
+		}, opts)
 		transport := &sdkmcp.SSEClientTransport{
 			Endpoint:   endpoint,
-			HTTPClient: g.connectionHTTPClient(ctx, connection),
+			HTTPClient: httpClient,
 		}
 		session, err := client.Connect(ctx, transport, nil)
 		if err == nil {
@@ -143,6 +143,71 @@ func (g *MCPFederationGateway) connectSSESession(ctx context.Context, connection
 	return nil, fmt.Errorf("connect sse mcp failed: %w", lastErr)
 }
 
+// mcpWatchKeepAlive pings watched sessions so a dead server is noticed and
+// the watcher can reconnect.
+const mcpWatchKeepAlive = time.Minute
+
+// WatchConnectionNotifications holds a session to an HTTP or SSE connection
+// open and passes each server notification to handle until ctx is done or
+// the server drops the session. A non-empty resourceURI is subscribed to
+// first so the server reports its updates.
+func (g *MCPFederationGateway) WatchConnectionNotifications(ctx context.Context, connection mcpgw.Connection, resourceURI string, handle func(method string, params any)) error {
+	opts := &sdkmcp.ClientOptions{
+		ToolListChangedHandler: func(_ context.Context, req *sdkmcp.ToolListChangedRequest) {
+			handle("notifications/tools/list_changed", req.Params)
+		},
+		PromptListChangedHandler: func(_ context.Context, req *sdkmcp.PromptListChangedRequest) {
+			handle("notifications/prompts/list_changed", req.Params)
+		},
+		ResourceListChangedHandler: func(_ context.Context, req *sdkmcp.ResourceListChangedRequest) {
+			handle("notifications/resources/list_changed", req.Params)
+		},
+		ResourceUpdatedHandler: func(_ context.Context, req *sdkmcp.ResourceUpdatedNotificationRequest) {
+			handle("notifications/resources/updated", req.Params)
+		},
+		LoggingMessageHandler: func(_ context.Context, req *sdkmcp.LoggingMessageRequest) {
+			handle("notifications/message", req.Params)
+		},
+		KeepAlive: mcpWatchKeepAlive,
+	}
+	// The session outlives any single request, so the client must not time
+	// out the long-lived event stream.
+	httpClient := *g.connectionHTTPClient(ctx, connection)
+	httpClient.Timeout = 0
+
+	var session *sdkmcp.ClientSession
+	var err error
+	switch strings.TrimSpace(connection.Type) {
+	case "http":
+		session, err = g.connectStreamableSession(ctx, connection, &httpClient, opts)
+	case "sse":
+		session, err = g.connectSSESession(ctx, connection, &httpClient, opts)
+	default:
+		return fmt.Errorf("notifications are not supported for %q connections", connection.Type)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+
+	if uri := strings.TrimSpace(resourceURI); uri != "" {
+		if err := session.Subscribe(ctx, &sdkmcp.SubscribeParams{URI: uri}); err != nil {
+			return fmt.Errorf("subscribe to %s: %w", uri, err)
+		}
+	}
+	done := make(chan error, 1)
+	go func() { done <- session.Wait() }()
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-done:
+		if err == nil {
+			err = errors.New("mcp session closed")
+		}
+		return err
+	}
+}
+
 func resolveSSEEndpointCandidates(config map[string]any) []string {
 	if config == nil {
 		return []string{}
@@ -733,8 +733,12 @@ func (r *fileMemoryRuntime) Search(ctx context.Context, req memprovider.SearchRe
 		return memprovider.SearchResponse{}, err
 	}
 	query := strings.ToLower(strings.TrimSpace(req.Query))
+	scopeKeys := memprovider.ScopeKeysFromFilters(req.Filters)
 	results := make([]memprovider.MemoryItem, 0, len(items))
 	for _, item := range items {
+		if !memprovider.ScopeKeysAllow(scopeKeys, memprovider.ScopeKeyFromMetadata(item.Metadata)) {
+			continue
+		}
 		score := runtimeScore(query, item.Memory)
 		if query != "" && score <= 0 {
 			continue
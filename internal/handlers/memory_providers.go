@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -67,6 +68,9 @@ func (h *MemoryProvidersHandler) Create(c echo.Context) error {
 	}
 	resp, err := h.service.Create(c.Request().Context(), req)
 	if err != nil {
+		if errors.Is(err, memprovider.ErrVectorStoreUnavailable) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	return c.JSON(http.StatusCreated, resp)
@@ -156,6 +160,9 @@ func (h *MemoryProvidersHandler) Update(c echo.Context) error {
 	}
 	resp, err := h.service.Update(c.Request().Context(), id, req)
 	if err != nil {
+		if errors.Is(err, memprovider.ErrVectorStoreUnavailable) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	return c.JSON(http.StatusOK, resp)
@@ -0,0 +1,657 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/accounts"
+	agentpkg "github.com/memohai/memoh/internal/agent"
+	"github.com/memohai/memoh/internal/auth"
+	"github.com/memohai/memoh/internal/bots"
+	"github.com/memohai/memoh/internal/channel"
+	"github.com/memohai/memoh/internal/config"
+	"github.com/memohai/memoh/internal/conversation"
+	"github.com/memohai/memoh/internal/conversation/flow"
+	"github.com/memohai/memoh/internal/db"
+	sessionpkg "github.com/memohai/memoh/internal/session"
+)
+
+const (
+	// openAICompatChannel is the current channel reported to the agent for
+	// turns arriving through the OpenAI-compatible endpoints.
+	openAICompatChannel = "openai"
+
+	// openAISessionHeader selects a server-side session. When present the
+	// bot's stored history is used instead of the request's message list.
+	openAISessionHeader = "X-Memoh-Session-Id"
+
+	// openAIToolCallsHeader overrides the configured tool call rendering mode.
+	openAIToolCallsHeader = "X-Memoh-Tool-Calls"
+
+	// openAICompatTokenTTL bounds the session token minted for the agent's
+	// callbacks during a completion.
+	openAICompatTokenTTL = 10 * time.Minute
+)
+
+// OpenAICompatHandler exposes bots through OpenAI-compatible chat completion
+// endpoints so existing OpenAI clients can talk to them. The request model is
+// the bot ID or display name.
+type OpenAICompatHandler struct {
+	botService     *bots.Service
+	accountService *accounts.Service
+	sessionService *sessionpkg.Service
+	resolver       *flow.Resolver
+	toolCalls      string
+	jwtSecret      string
+	logger         *slog.Logger
+}
+
+// NewOpenAICompatHandler creates an OpenAI-compatible handler.
+func NewOpenAICompatHandler(log *slog.Logger, botService *bots.Service, accountService *accounts.Service, sessionService *sessionpkg.Service, cfg config.Config) *OpenAICompatHandler {
+	return &OpenAICompatHandler{
+		botService:     botService,
+		accountService: accountService,
+		sessionService: sessionService,
+		toolCalls:      normalizeOpenAIToolCalls(cfg.OpenAICompat.ToolCalls, config.DefaultOpenAIToolCalls),
+		jwtSecret:      cfg.Auth.JWTSecret,
+		logger:         log.With(slog.String("handler", "openai_compat")),
+	}
+}
+
+// SetResolver sets the flow resolver used to run chat turns.
+func (h *OpenAICompatHandler) SetResolver(resolver *flow.Resolver) {
+	h.resolver = resolver
+}
+
+func (h *OpenAICompatHandler) Register(e *echo.Echo) {
+	group := e.Group("/v1")
+	group.GET("/models", h.ListModels)
+	group.POST("/chat/completions", h.CreateChatCompletion)
+}
+
+// OpenAIModel is one entry of the OpenAI model list. Each model is a bot.
+type OpenAIModel struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+	Name    string `json:"name,omitempty"`
+}
+
+// OpenAIModelList is the response body for GET /v1/models.
+type OpenAIModelList struct {
+	Object string        `json:"object"`
+	Data   []OpenAIModel `json:"data"`
+}
+
+// OpenAIChatMessage is one message in an OpenAI chat completion request.
+// Content is either a string or an array of content parts.
+type OpenAIChatMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content,omitempty"`
+	Name    string          `json:"name,omitempty"`
+}
+
+// OpenAIContentPart is one part of a multi-part OpenAI message content.
+type OpenAIContentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL *struct {
+		URL string `json:"url"`
+	} `json:"image_url,omitempty"`
+}
+
+// OpenAIStreamOptions holds streaming options of a chat completion request.
+type OpenAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// OpenAIChatCompletionRequest is the request body for POST /v1/chat/completions.
+// Sampling parameters are accepted for compatibility but ignored; the bot's
+// own model settings apply.
+type OpenAIChatCompletionRequest struct {
+	Model         string               `json:"model"`
+	Messages      []OpenAIChatMessage  `json:"messages"`
+	Stream        bool                 `json:"stream,omitempty"`
+	StreamOptions *OpenAIStreamOptions `json:"stream_options,omitempty"`
+}
+
+// OpenAIUsage is the token usage reported on a completion.
+type OpenAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// OpenAIResponseMessage is the assistant message of a completion choice.
+type OpenAIResponseMessage struct {
+	Role             string `json:"role"`
+	Content          string `json:"content"`
+	ReasoningContent string `json:"reasoning_content,omitempty"`
+}
+
+// OpenAIChoice is one choice of a non-streaming completion.
+type OpenAIChoice struct {
+	Index        int                   `json:"index"`
+	Message      OpenAIResponseMessage `json:"message"`
+	FinishReason string                `json:"finish_reason"`
+}
+
+// OpenAIChatCompletion is the response body of a non-streaming completion.
+type OpenAIChatCompletion struct {
+	ID      string         `json:"id"`
+	Object  string         `json:"object"`
+	Created int64          `json:"created"`
+	Model   string         `json:"model"`
+	Choices []OpenAIChoice `json:"choices"`
+	Usage   *OpenAIUsage   `json:"usage,omitempty"`
+}
+
+// OpenAIChunkDelta is the incremental message of a streamed choice.
+type OpenAIChunkDelta struct {
+	Role             string `json:"role,omitempty"`
+	Content          string `json:"content,omitempty"`
+	ReasoningContent string `json:"reasoning_content,omitempty"`
+}
+
+// OpenAIChunkChoice is one choice of a streamed completion chunk.
+type OpenAIChunkChoice struct {
+	Index        int              `json:"index"`
+	Delta        OpenAIChunkDelta `json:"delta"`
+	FinishReason *string          `json:"finish_reason"`
+}
+
+// OpenAIChatCompletionChunk is one SSE chunk of a streaming completion.
+type OpenAIChatCompletionChunk struct {
+	ID      string              `json:"id"`
+	Object  string              `json:"object"`
+	Created int64               `json:"created"`
+	Model   string              `json:"model"`
+	Choices []OpenAIChunkChoice `json:"choices"`
+	Usage   *OpenAIUsage        `json:"usage,omitempty"`
+}
+
+// ListModels godoc
+// @Summary List bots as OpenAI models
+// @Description List the bots the caller can access in the OpenAI model list format. Use a bot ID or display name as the model of a chat completion.
+// @Tags openai
+// @Produce json
+// @Success 200 {object} OpenAIModelList
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/models [get].
+func (h *OpenAICompatHandler) ListModels(c echo.Context) error {
+	userID, err := RequireChannelIdentityID(c)
+	if err != nil {
+		return err
+	}
+	items, err := h.botService.ListAccessible(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	resp := OpenAIModelList{Object: "list", Data: make([]OpenAIModel, 0, len(items))}
+	for _, bot := range items {
+		resp.Data = append(resp.Data, OpenAIModel{
+			ID:      bot.ID,
+			Object:  "model",
+			Created: bot.CreatedAt.Unix(),
+			OwnedBy: "memoh",
+			Name:    bot.DisplayName,
+		})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CreateChatCompletion godoc
+// @Summary Create an OpenAI-compatible chat completion
+// @Description Run a chat turn against a bot with its memory, tools and container. Without the X-Memoh-Session-Id header the request messages are the conversation history; with it the bot's stored session history is used and only the last user message is sent. Set stream to receive chat.completion.chunk server-sent events. Tool calls are surfaced as reasoning content or omitted, per server config or the X-Memoh-Tool-Calls header.
+// @Tags openai
+// @Accept json
+// @Produce json
+// @Produce text/event-stream
+// @Param payload body OpenAIChatCompletionRequest true "Chat completion request"
+// @Param X-Memoh-Session-Id header string false "Session ID to continue"
+// @Param X-Memoh-Tool-Calls header string false "Tool call rendering: reasoning or omit"
+// @Success 200 {object} OpenAIChatCompletion
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/chat/completions [post].
+func (h *OpenAICompatHandler) CreateChatCompletion(c echo.Context) error {
+	userID, err := RequireChannelIdentityID(c)
+	if err != nil {
+		return err
+	}
+	if h.resolver == nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "resolver not configured")
+	}
+	var payload OpenAIChatCompletionRequest
+	if err := c.Bind(&payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	ctx := c.Request().Context()
+	bot, err := h.resolveBot(ctx, userID, payload.Model)
+	if err != nil {
+		return err
+	}
+	sessionID := strings.TrimSpace(c.Request().Header.Get(openAISessionHeader))
+	if sessionID != "" {
+		if err := h.requireBotSession(ctx, bot.ID, sessionID); err != nil {
+			return err
+		}
+	}
+	history, query, attachments, err := splitOpenAIMessages(payload.Messages)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	token, err := h.sessionToken(userID)
+	if err != nil {
+		h.logger.Error("issue openai session token failed", slog.String("bot_id", bot.ID), slog.Any("error", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "issue session token failed")
+	}
+
+	req := conversation.ChatRequest{
+		BotID:                   bot.ID,
+		ChatID:                  bot.ID,
+		SessionID:               sessionID,
+		Token:                   token,
+		UserID:                  userID,
+		SourceChannelIdentityID: userID,
+		ConversationType:        channel.ConversationTypePrivate,
+		Query:                   query,
+		CurrentChannel:          openAICompatChannel,
+		Channels:                []string{openAICompatChannel},
+		Attachments:             attachments,
+	}
+	if sessionID == "" {
+		// The client owns the conversation: use its history, not ours.
+		req.Messages = history
+		req.MaxContextLoadTime = -1
+	}
+
+	toolCalls := normalizeOpenAIToolCalls(c.Request().Header.Get(openAIToolCallsHeader), h.toolCalls)
+	completionID := "chatcmpl-" + uuid.NewString()
+	model := strings.TrimSpace(payload.Model)
+	if payload.Stream {
+		includeUsage := payload.StreamOptions != nil && payload.StreamOptions.IncludeUsage
+		return h.streamCompletion(c, req, newOpenAIStreamTranslator(completionID, model, toolCalls, includeUsage))
+	}
+
+	resp, err := h.resolver.Chat(ctx, req)
+	if err != nil {
+		h.logger.Error("openai chat completion failed", slog.String("bot_id", bot.ID), slog.Any("error", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, buildOpenAICompletion(completionID, model, resp.Messages, toolCalls))
+}
+
+// sessionToken mints a short-lived JWT for the caller. The request may be
+// authenticated with an API key, which must never be handed to the agent.
+func (h *OpenAICompatHandler) sessionToken(userID string) (string, error) {
+	if strings.TrimSpace(h.jwtSecret) == "" {
+		return "", errors.New("jwt secret not configured")
+	}
+	signed, _, err := auth.GenerateToken(userID, h.jwtSecret, openAICompatTokenTTL)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + signed, nil
+}
+
+func (h *OpenAICompatHandler) streamCompletion(c echo.Context, req conversation.ChatRequest, translator *openAIStreamTranslator) error {
+	flusher, ok := c.Response().Writer.(http.Flusher)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "streaming not supported")
+	}
+	c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	c.Response().Header().Set(echo.HeaderConnection, "keep-alive")
+	c.Response().WriteHeader(http.StatusOK)
+	writer := bufio.NewWriter(c.Response().Writer)
+
+	send := func(v any) bool {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return true
+		}
+		if _, err := fmt.Fprintf(writer, "data: %s\n\n", data); err != nil {
+			return false
+		}
+		if err := writer.Flush(); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	// Detach the resolver from the request so a client disconnect doesn't
+	// cancel the run before it can finish and persist the round.
+	chunkCh, errCh := h.resolver.StreamChat(context.WithoutCancel(c.Request().Context()), req)
+	connected := true
+	for raw := range chunkCh {
+		// Keep draining after a disconnect so the resolver can finish.
+		if !connected {
+			continue
+		}
+		var event agentpkg.StreamEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			continue
+		}
+		if event.Type == agentpkg.EventError {
+			connected = send(openAIStreamError(event.Error))
+			continue
+		}
+		for _, chunk := range translator.translate(event) {
+			if connected = send(chunk); !connected {
+				break
+			}
+		}
+	}
+	if err := <-errCh; err != nil {
+		h.logger.Error("openai chat completion stream failed", slog.String("bot_id", req.BotID), slog.Any("error", err))
+		if connected {
+			connected = send(openAIStreamError(err.Error()))
+		}
+	}
+	if connected {
+		_, _ = writer.WriteString("data: [DONE]\n\n")
+		_ = writer.Flush()
+		flusher.Flush()
+	}
+	return nil
+}
+
+// resolveBot finds the bot addressed by an OpenAI model value, which is a
+// bot ID or a case-insensitive display name.
+func (h *OpenAICompatHandler) resolveBot(ctx context.Context, userID, model string) (bots.Bot, error) {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return bots.Bot{}, echo.NewHTTPError(http.StatusBadRequest, "model is required")
+	}
+	if _, err := db.ParseUUID(model); err == nil {
+		return AuthorizeBotAccess(ctx, h.botService, h.accountService, userID, model)
+	}
+	items, err := h.botService.ListAccessible(ctx, userID)
+	if err != nil {
+		return bots.Bot{}, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	for _, bot := range items {
+		if strings.EqualFold(strings.TrimSpace(bot.DisplayName), model) {
+			return bot, nil
+		}
+	}
+	return bots.Bot{}, echo.NewHTTPError(http.StatusNotFound, "model not found")
+}
+
+func (h *OpenAICompatHandler) requireBotSession(ctx context.Context, botID, sessionID string) error {
+	if h.sessionService == nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "session service not configured")
+	}
+	sess, err := h.sessionService.Get(ctx, sessionID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "session not found")
+	}
+	if sess.BotID != botID {
+		return echo.NewHTTPError(http.StatusForbidden, "session does not belong to bot")
+	}
+	return nil
+}
+
+func normalizeOpenAIToolCalls(value, fallback string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case config.OpenAIToolCallsReasoning:
+		return config.OpenAIToolCallsReasoning
+	case config.OpenAIToolCallsOmit:
+		return config.OpenAIToolCallsOmit
+	default:
+		return fallback
+	}
+}
+
+// splitOpenAIMessages separates the final user message, which becomes the
+// turn's query and attachments, from the preceding conversation. System and
+// tool messages are dropped: the bot brings its own prompt and tools.
+func splitOpenAIMessages(messages []OpenAIChatMessage) ([]conversation.ModelMessage, string, []conversation.ChatAttachment, error) {
+	last := -1
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return nil, "", nil, errors.New("messages must include a user message")
+	}
+	query, attachments := parseOpenAIContent(messages[last].Content)
+	if strings.TrimSpace(query) == "" && len(attachments) == 0 {
+		return nil, "", nil, errors.New("last user message is empty")
+	}
+	history := make([]conversation.ModelMessage, 0, last)
+	for _, m := range messages[:last] {
+		if m.Role != "user" && m.Role != "assistant" {
+			continue
+		}
+		text, _ := parseOpenAIContent(m.Content)
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		history = append(history, conversation.ModelMessage{
+			Role:    m.Role,
+			Content: conversation.NewTextContent(text),
+		})
+	}
+	return history, query, attachments, nil
+}
+
+func parseOpenAIContent(raw json.RawMessage) (string, []conversation.ChatAttachment) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil
+	}
+	var parts []OpenAIContentPart
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return "", nil
+	}
+	texts := make([]string, 0, len(parts))
+	var attachments []conversation.ChatAttachment
+	for _, p := range parts {
+		switch p.Type {
+		case "text":
+			if strings.TrimSpace(p.Text) != "" {
+				texts = append(texts, p.Text)
+			}
+		case "image_url":
+			if p.ImageURL == nil || strings.TrimSpace(p.ImageURL.URL) == "" {
+				continue
+			}
+			url := strings.TrimSpace(p.ImageURL.URL)
+			att := conversation.ChatAttachment{Type: "image"}
+			if strings.HasPrefix(url, "data:") {
+				att.Base64 = url
+			} else {
+				att.URL = url
+			}
+			attachments = append(attachments, att)
+		}
+	}
+	return strings.Join(texts, "\n"), attachments
+}
+
+func buildOpenAICompletion(id, model string, messages []conversation.ModelMessage, toolCalls string) OpenAIChatCompletion {
+	outputs := flow.ExtractAssistantOutputs(messages)
+	contents := make([]string, 0, len(outputs))
+	for _, out := range outputs {
+		if strings.TrimSpace(out.Content) != "" {
+			contents = append(contents, out.Content)
+		}
+	}
+	var reasoning []string
+	var usage OpenAIUsage
+	for _, m := range messages {
+		usage.add(m.Usage)
+		if m.Role != "assistant" {
+			continue
+		}
+		for _, p := range m.ContentParts() {
+			if p.Type == "reasoning" && strings.TrimSpace(p.Text) != "" {
+				reasoning = append(reasoning, p.Text)
+			}
+		}
+		if toolCalls == config.OpenAIToolCallsReasoning {
+			for _, call := range m.ToolCalls {
+				reasoning = append(reasoning, formatOpenAIToolCall(call.Function.Name, call.Function.Arguments))
+			}
+		}
+	}
+	completion := OpenAIChatCompletion{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   model,
+		Choices: []OpenAIChoice{{
+			Message: OpenAIResponseMessage{
+				Role:             "assistant",
+				Content:          strings.Join(contents, "\n\n"),
+				ReasoningContent: strings.Join(reasoning, "\n"),
+			},
+			FinishReason: "stop",
+		}},
+	}
+	if usage.TotalTokens > 0 {
+		completion.Usage = &usage
+	}
+	return completion
+}
+
+// add accumulates an agent usage payload into the OpenAI usage totals.
+func (u *OpenAIUsage) add(raw json.RawMessage) {
+	if len(raw) == 0 {
+		return
+	}
+	var usage struct {
+		InputTokens  int `json:"inputTokens"`
+		OutputTokens int `json:"outputTokens"`
+	}
+	if json.Unmarshal(raw, &usage) != nil {
+		return
+	}
+	u.PromptTokens += usage.InputTokens
+	u.CompletionTokens += usage.OutputTokens
+	u.TotalTokens = u.PromptTokens + u.CompletionTokens
+}
+
+func formatOpenAIToolCall(name string, input any) string {
+	args := ""
+	switch v := input.(type) {
+	case nil:
+	case string:
+		args = v
+	default:
+		if data, err := json.Marshal(v); err == nil {
+			args = string(data)
+		}
+	}
+	return fmt.Sprintf("[tool] %s(%s)", name, args)
+}
+
+func openAIStreamError(message string) map[string]any {
+	return map[string]any{"error": map[string]string{"message": message, "type": "server_error"}}
+}
+
+// openAIStreamTranslator converts agent stream events into OpenAI
+// chat.completion.chunk payloads.
+type openAIStreamTranslator struct {
+	id           string
+	model        string
+	created      int64
+	toolCalls    string
+	includeUsage bool
+	roleSent     bool
+}
+
+func newOpenAIStreamTranslator(id, model, toolCalls string, includeUsage bool) *openAIStreamTranslator {
+	return &openAIStreamTranslator{
+		id:           id,
+		model:        model,
+		created:      time.Now().Unix(),
+		toolCalls:    toolCalls,
+		includeUsage: includeUsage,
+	}
+}
+
+func (t *openAIStreamTranslator) translate(event agentpkg.StreamEvent) []OpenAIChatCompletionChunk {
+	switch event.Type {
+	case agentpkg.EventTextDelta:
+		if event.Delta == "" {
+			return nil
+		}
+		return []OpenAIChatCompletionChunk{t.delta(OpenAIChunkDelta{Content: event.Delta})}
+	case agentpkg.EventReasoningDelta:
+		if event.Delta == "" {
+			return nil
+		}
+		return []OpenAIChatCompletionChunk{t.delta(OpenAIChunkDelta{ReasoningContent: event.Delta})}
+	case agentpkg.EventToolCallStart:
+		if t.toolCalls != config.OpenAIToolCallsReasoning {
+			return nil
+		}
+		line := formatOpenAIToolCall(event.ToolName, event.Input) + "\n"
+		return []OpenAIChatCompletionChunk{t.delta(OpenAIChunkDelta{ReasoningContent: line})}
+	case agentpkg.EventAgentEnd, agentpkg.EventAgentAbort:
+		chunks := []OpenAIChatCompletionChunk{t.finish()}
+		if t.includeUsage {
+			var usage OpenAIUsage
+			usage.add(event.Usage)
+			chunks = append(chunks, t.chunk(nil, &usage))
+		}
+		return chunks
+	default:
+		return nil
+	}
+}
+
+func (t *openAIStreamTranslator) delta(delta OpenAIChunkDelta) OpenAIChatCompletionChunk {
+	if !t.roleSent {
+		delta.Role = "assistant"
+		t.roleSent = true
+	}
+	return t.chunk([]OpenAIChunkChoice{{Delta: delta}}, nil)
+}
+
+func (t *openAIStreamTranslator) finish() OpenAIChatCompletionChunk {
+	reason := "stop"
+	delta := OpenAIChunkDelta{}
+	if !t.roleSent {
+		delta.Role = "assistant"
+		t.roleSent = true
+	}
+	return t.chunk([]OpenAIChunkChoice{{Delta: delta, FinishReason: &reason}}, nil)
+}
+
+func (t *openAIStreamTranslator) chunk(choices []OpenAIChunkChoice, usage *OpenAIUsage) OpenAIChatCompletionChunk {
+	if choices == nil {
+		choices = []OpenAIChunkChoice{}
+	}
+	return OpenAIChatCompletionChunk{
+		ID:      t.id,
+		Object:  "chat.completion.chunk",
+		Created: t.created,
+		Model:   t.model,
+		Choices: choices,
+		Usage:   usage,
+	}
+}
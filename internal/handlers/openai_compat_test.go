@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	agentpkg "github.com/memohai/memoh/internal/agent"
+	"github.com/memohai/memoh/internal/config"
+	"github.com/memohai/memoh/internal/conversation"
+)
+
+func TestSplitOpenAIMessages(t *testing.T) {
+	t.Parallel()
+
+	messages := []OpenAIChatMessage{
+		{Role: "system", Content: json.RawMessage(`"be terse"`)},
+		{Role: "user", Content: json.RawMessage(`"hi"`)},
+		{Role: "assistant", Content: json.RawMessage(`"hello"`)},
+		{Role: "user", Content: json.RawMessage(`[{"type":"text","text":"what is this?"},{"type":"image_url","image_url":{"url":"data:image/png;base64,AAAA"}}]`)},
+	}
+	history, query, attachments, err := splitOpenAIMessages(messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query != "what is this?" {
+		t.Fatalf("unexpected query %q", query)
+	}
+	if len(attachments) != 1 || attachments[0].Type != "image" || attachments[0].Base64 == "" {
+		t.Fatalf("unexpected attachments: %+v", attachments)
+	}
+	if len(history) != 2 || history[0].Role != "user" || history[1].TextContent() != "hello" {
+		t.Fatalf("system message should be dropped and history kept: %+v", history)
+	}
+
+	if _, _, _, err := splitOpenAIMessages([]OpenAIChatMessage{{Role: "system", Content: json.RawMessage(`"x"`)}}); err == nil {
+		t.Fatal("expected error without a user message")
+	}
+}
+
+func TestOpenAIStreamTranslator(t *testing.T) {
+	t.Parallel()
+
+	tr := newOpenAIStreamTranslator("chatcmpl-1", "bot", config.OpenAIToolCallsReasoning, true)
+	chunks := tr.translate(agentpkg.StreamEvent{Type: agentpkg.EventTextDelta, Delta: "Hel"})
+	if len(chunks) != 1 || chunks[0].Choices[0].Delta.Role != "assistant" || chunks[0].Choices[0].Delta.Content != "Hel" {
+		t.Fatalf("unexpected first chunk: %+v", chunks)
+	}
+	chunks = tr.translate(agentpkg.StreamEvent{Type: agentpkg.EventToolCallStart, ToolName: "search", Input: map[string]any{"q": "go"}})
+	if len(chunks) != 1 || chunks[0].Choices[0].Delta.Role != "" || chunks[0].Choices[0].Delta.ReasoningContent != "[tool] search({\"q\":\"go\"})\n" {
+		t.Fatalf("unexpected tool chunk: %+v", chunks)
+	}
+	chunks = tr.translate(agentpkg.StreamEvent{Type: agentpkg.EventAgentEnd, Usage: json.RawMessage(`{"inputTokens":10,"outputTokens":5}`)})
+	if len(chunks) != 2 {
+		t.Fatalf("expected finish and usage chunks, got %d", len(chunks))
+	}
+	if reason := chunks[0].Choices[0].FinishReason; reason == nil || *reason != "stop" {
+		t.Fatalf("unexpected finish reason: %v", reason)
+	}
+	if u := chunks[1].Usage; u == nil || u.TotalTokens != 15 || len(chunks[1].Choices) != 0 {
+		t.Fatalf("unexpected usage chunk: %+v", chunks[1])
+	}
+
+	omit := newOpenAIStreamTranslator("chatcmpl-2", "bot", config.OpenAIToolCallsOmit, false)
+	if chunks := omit.translate(agentpkg.StreamEvent{Type: agentpkg.EventToolCallStart, ToolName: "search"}); len(chunks) != 0 {
+		t.Fatalf("tool calls should be omitted, got %+v", chunks)
+	}
+}
+
+func TestBuildOpenAICompletion(t *testing.T) {
+	t.Parallel()
+
+	messages := []conversation.ModelMessage{
+		{
+			Role:      "assistant",
+			ToolCalls: []conversation.ToolCall{{Type: "function", Function: conversation.ToolCallFunction{Name: "search", Arguments: `{"q":"go"}`}}},
+			Usage:     json.RawMessage(`{"inputTokens":100,"outputTokens":10}`),
+		},
+		{Role: "tool", Content: conversation.NewTextContent("result")},
+		{
+			Role:    "assistant",
+			Content: conversation.NewTextContent("Go is a language."),
+			Usage:   json.RawMessage(`{"inputTokens":150,"outputTokens":20}`),
+		},
+	}
+
+	got := buildOpenAICompletion("chatcmpl-1", "bot", messages, config.OpenAIToolCallsReasoning)
+	msg := got.Choices[0].Message
+	if msg.Content != "Go is a language." {
+		t.Fatalf("unexpected content %q", msg.Content)
+	}
+	if msg.ReasoningContent != `[tool] search({"q":"go"})` {
+		t.Fatalf("unexpected reasoning %q", msg.ReasoningContent)
+	}
+	if got.Usage == nil || got.Usage.PromptTokens != 250 || got.Usage.CompletionTokens != 30 {
+		t.Fatalf("unexpected usage: %+v", got.Usage)
+	}
+
+	got = buildOpenAICompletion("chatcmpl-2", "bot", messages, config.OpenAIToolCallsOmit)
+	if got.Choices[0].Message.ReasoningContent != "" {
+		t.Fatal("tool calls should be omitted")
+	}
+}
+
+func TestNormalizeOpenAIToolCalls(t *testing.T) {
+	t.Parallel()
+
+	if got := normalizeOpenAIToolCalls(" OMIT ", config.OpenAIToolCallsReasoning); got != config.OpenAIToolCallsOmit {
+		t.Fatalf("got %q", got)
+	}
+	if got := normalizeOpenAIToolCalls("bogus", config.OpenAIToolCallsOmit); got != config.OpenAIToolCallsOmit {
+		t.Fatalf("invalid values should fall back, got %q", got)
+	}
+}
+
+func TestOpenAICompatSessionToken(t *testing.T) {
+	h := &OpenAICompatHandler{jwtSecret: "secret"}
+	token, err := h.sessionToken("user-1")
+	if err != nil {
+		t.Fatalf("sessionToken: %v", err)
+	}
+	raw, ok := strings.CutPrefix(token, "Bearer ")
+	if !ok {
+		t.Fatalf("expected bearer token, got %q", token)
+	}
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(raw, claims, func(*jwt.Token) (any, error) { return []byte("secret"), nil }); err != nil {
+		t.Fatalf("minted token does not verify: %v", err)
+	}
+	if sub, _ := claims.GetSubject(); sub != "user-1" {
+		t.Fatalf("subject = %q, want user-1", sub)
+	}
+
+	if _, err := (&OpenAICompatHandler{}).sessionToken("user-1"); err == nil {
+		t.Fatal("expected an error without a jwt secret")
+	}
+}
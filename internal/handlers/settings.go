@@ -96,7 +96,9 @@ func (h *SettingsHandler) Upsert(c echo.Context) error {
 	}
 	resp, err := h.service.UpsertBot(c.Request().Context(), botID, req)
 	if err != nil {
-		if errors.Is(err, settings.ErrInvalidModelRef) {
+		if errors.Is(err, settings.ErrInvalidModelRef) || errors.Is(err, settings.ErrInvalidMemoryScope) ||
+			errors.Is(err, settings.ErrInvalidTimezone) || errors.Is(err, settings.ErrInvalidActiveHours) ||
+			errors.Is(err, settings.ErrInvalidFetchDomain) {
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 		if errors.Is(err, settings.ErrModelIDAmbiguous) {
@@ -105,7 +107,8 @@ func (h *SettingsHandler) Upsert(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	if req.HeartbeatEnabled != nil || req.HeartbeatInterval != nil {
+	if req.HeartbeatEnabled != nil || req.HeartbeatInterval != nil || req.HeartbeatActiveStart != nil ||
+		req.HeartbeatActiveEnd != nil || req.HeartbeatJitter != nil || req.HeartbeatMaxInterval != nil || req.Timezone != nil {
 		if err := h.heartbeatService.Reschedule(c.Request().Context(), botID); err != nil {
 			h.logger.Error("failed to reschedule heartbeat", slog.String("bot_id", botID), slog.Any("error", err))
 		}
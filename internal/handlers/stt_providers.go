@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/stt"
+)
+
+type SttProvidersHandler struct {
+	service *stt.Service
+	logger  *slog.Logger
+}
+
+func NewSttProvidersHandler(log *slog.Logger, service *stt.Service) *SttProvidersHandler {
+	return &SttProvidersHandler{
+		service: service,
+		logger:  log.With(slog.String("handler", "stt_providers")),
+	}
+}
+
+func (h *SttProvidersHandler) Register(e *echo.Echo) {
+	g := e.Group("/stt-providers")
+	g.GET("/meta", h.ListMeta)
+	g.POST("", h.Create)
+	g.GET("", h.List)
+	g.GET("/:id", h.Get)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+
+	g.GET("/:id/models", h.ListModels)
+	g.POST("/:id/import-models", h.ImportModels)
+
+	mg := e.Group("/stt-models")
+	mg.POST("", h.CreateModel)
+	mg.GET("", h.ListAllModels)
+	mg.GET("/:id", h.GetModel)
+	mg.PUT("/:id", h.UpdateModel)
+	mg.DELETE("/:id", h.DeleteModel)
+	mg.GET("/:id/capabilities", h.GetModelCapabilities)
+	mg.POST("/:id/test", h.TestModel)
+}
+
+// ListMeta godoc
+// @Summary List STT provider metadata
+// @Description List available STT provider types with their models and capabilities
+// @Tags stt-providers
+// @Success 200 {array} stt.ProviderMetaResponse
+// @Router /stt-providers/meta [get].
+func (h *SttProvidersHandler) ListMeta(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.service.ListMeta(c.Request().Context()))
+}
+
+// Create godoc
+// @Summary Create an STT provider
+// @Description Create an STT provider and auto-import its available models
+// @Tags stt-providers
+// @Accept json
+// @Produce json
+// @Param request body stt.CreateProviderRequest true "STT provider configuration"
+// @Success 201 {object} stt.ProviderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-providers [post].
+func (h *SttProvidersHandler) Create(c echo.Context) error {
+	var req stt.CreateProviderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "name is required")
+	}
+	if strings.TrimSpace(string(req.Provider)) == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "provider is required")
+	}
+	resp, err := h.service.CreateProvider(c.Request().Context(), req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// List godoc
+// @Summary List STT providers
+// @Tags stt-providers
+// @Produce json
+// @Param provider query string false "Provider type filter"
+// @Success 200 {array} stt.ProviderResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-providers [get].
+func (h *SttProvidersHandler) List(c echo.Context) error {
+	items, err := h.service.ListProviders(c.Request().Context(), c.QueryParam("provider"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// Get godoc
+// @Summary Get an STT provider
+// @Tags stt-providers
+// @Produce json
+// @Param id path string true "Provider ID"
+// @Success 200 {object} stt.ProviderResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /stt-providers/{id} [get].
+func (h *SttProvidersHandler) Get(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	resp, err := h.service.GetProvider(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Update godoc
+// @Summary Update an STT provider
+// @Tags stt-providers
+// @Accept json
+// @Produce json
+// @Param id path string true "Provider ID"
+// @Param request body stt.UpdateProviderRequest true "Updated configuration"
+// @Success 200 {object} stt.ProviderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-providers/{id} [put].
+func (h *SttProvidersHandler) Update(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	var req stt.UpdateProviderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.service.UpdateProvider(c.Request().Context(), id, req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Delete godoc
+// @Summary Delete an STT provider
+// @Tags stt-providers
+// @Param id path string true "Provider ID"
+// @Success 204 "No Content"
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-providers/{id} [delete].
+func (h *SttProvidersHandler) Delete(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	if err := h.service.DeleteProvider(c.Request().Context(), id); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListModels godoc
+// @Summary List models for an STT provider
+// @Tags stt-providers
+// @Produce json
+// @Param id path string true "Provider ID"
+// @Success 200 {array} stt.ModelResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-providers/{id}/models [get].
+func (h *SttProvidersHandler) ListModels(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	items, err := h.service.ListModelsByProvider(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// ImportModels godoc
+// @Summary Import models for an STT provider
+// @Description Discover and import available models from the STT adapter
+// @Tags stt-providers
+// @Produce json
+// @Param id path string true "Provider ID"
+// @Success 200 {array} stt.ModelResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-providers/{id}/import-models [post].
+func (h *SttProvidersHandler) ImportModels(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	items, err := h.service.ImportModels(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// CreateModel godoc
+// @Summary Create an STT model
+// @Description Manually create an STT model under a specific provider
+// @Tags stt-models
+// @Accept json
+// @Produce json
+// @Param request body stt.CreateModelRequest true "STT model configuration"
+// @Success 201 {object} stt.ModelResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-models [post].
+func (h *SttProvidersHandler) CreateModel(c echo.Context) error {
+	var req stt.CreateModelRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if strings.TrimSpace(req.ModelID) == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "model_id is required")
+	}
+	if strings.TrimSpace(req.SttProviderID) == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "stt_provider_id is required")
+	}
+	resp, err := h.service.CreateModel(c.Request().Context(), req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// ListAllModels godoc
+// @Summary List all STT models
+// @Tags stt-models
+// @Produce json
+// @Success 200 {array} stt.ModelResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-models [get].
+func (h *SttProvidersHandler) ListAllModels(c echo.Context) error {
+	items, err := h.service.ListAllModels(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// GetModel godoc
+// @Summary Get an STT model
+// @Tags stt-models
+// @Produce json
+// @Param id path string true "Model ID"
+// @Success 200 {object} stt.ModelResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /stt-models/{id} [get].
+func (h *SttProvidersHandler) GetModel(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	resp, err := h.service.GetModel(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// UpdateModel godoc
+// @Summary Update an STT model
+// @Tags stt-models
+// @Accept json
+// @Produce json
+// @Param id path string true "Model ID"
+// @Param request body stt.UpdateModelRequest true "Updated configuration"
+// @Success 200 {object} stt.ModelResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-models/{id} [put].
+func (h *SttProvidersHandler) UpdateModel(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	var req stt.UpdateModelRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.service.UpdateModel(c.Request().Context(), id, req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteModel godoc
+// @Summary Delete an STT model
+// @Tags stt-models
+// @Param id path string true "Model ID"
+// @Success 204 "No Content"
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-models/{id} [delete].
+func (h *SttProvidersHandler) DeleteModel(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	if err := h.service.DeleteModel(c.Request().Context(), id); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetModelCapabilities godoc
+// @Summary Get STT model capabilities
+// @Tags stt-models
+// @Produce json
+// @Param id path string true "Model ID"
+// @Success 200 {object} stt.ModelCapabilities
+// @Failure 404 {object} ErrorResponse
+// @Router /stt-models/{id}/capabilities [get].
+func (h *SttProvidersHandler) GetModelCapabilities(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	caps, err := h.service.GetModelCapabilities(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return c.JSON(http.StatusOK, caps)
+}
+
+// TestModel godoc
+// @Summary Test STT model transcription
+// @Description Transcribe an uploaded audio file using a specific model's config
+// @Tags stt-models
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Model ID"
+// @Param file formData file true "Audio file"
+// @Param language formData string false "Language hint (ISO-639-1)"
+// @Success 200 {object} stt.TranscriptionResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stt-models/{id}/test [post].
+func (h *SttProvidersHandler) TestModel(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "file is required")
+	}
+	if fileHeader.Size > stt.MaxAudioBytes {
+		return echo.NewHTTPError(http.StatusBadRequest, "audio file too large")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	defer func() { _ = file.Close() }()
+	data, err := io.ReadAll(io.LimitReader(file, stt.MaxAudioBytes+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	var override map[string]any
+	if lang := strings.TrimSpace(c.FormValue("language")); lang != "" {
+		override = map[string]any{"language": lang}
+	}
+	result, err := h.service.Transcribe(c.Request().Context(), id, stt.AudioInput{
+		Data:     data,
+		Filename: fileHeader.Filename,
+		Mime:     fileHeader.Header.Get("Content-Type"),
+	}, override)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/accounts"
+	"github.com/memohai/memoh/internal/bots"
+	"github.com/memohai/memoh/internal/budget"
+)
+
+type TokenBudgetHandler struct {
+	service        *budget.Service
+	botService     *bots.Service
+	accountService *accounts.Service
+	logger         *slog.Logger
+}
+
+func NewTokenBudgetHandler(log *slog.Logger, service *budget.Service, botService *bots.Service, accountService *accounts.Service) *TokenBudgetHandler {
+	return &TokenBudgetHandler{
+		service:        service,
+		botService:     botService,
+		accountService: accountService,
+		logger:         log.With(slog.String("handler", "token_budget")),
+	}
+}
+
+func (h *TokenBudgetHandler) Register(e *echo.Echo) {
+	group := e.Group("/bots/:bot_id/token-budgets")
+	group.GET("", h.List)
+	group.POST("", h.Create)
+	group.GET("/allowance", h.GetAllowance)
+	group.PUT("/:id", h.Update)
+	group.DELETE("/:id", h.Delete)
+}
+
+// List godoc
+// @Summary List token budgets
+// @Description List token budget rules configured for a bot
+// @Tags token-budgets
+// @Param bot_id path string true "Bot ID"
+// @Success 200 {object} budget.ListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/token-budgets [get].
+func (h *TokenBudgetHandler) List(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	items, err := h.service.List(c.Request().Context(), botID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, budget.ListResponse{Items: items})
+}
+
+// Create godoc
+// @Summary Create token budget
+// @Description Create a daily or monthly token budget scoped to the bot, a user, or a channel identity. Omit the subject on user or channel identity scopes to cap each subject separately.
+// @Tags token-budgets
+// @Param bot_id path string true "Bot ID"
+// @Param payload body budget.CreateRequest true "Budget payload"
+// @Success 201 {object} budget.Rule
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/token-budgets [post].
+func (h *TokenBudgetHandler) Create(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	var req budget.CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	rule, err := h.service.Create(c.Request().Context(), botID, req)
+	if err != nil {
+		return budgetHTTPError(err)
+	}
+	return c.JSON(http.StatusCreated, rule)
+}
+
+// Update godoc
+// @Summary Update token budget
+// @Description Update the period, limits, reply message, or enabled state of a token budget
+// @Tags token-budgets
+// @Param bot_id path string true "Bot ID"
+// @Param id path string true "Budget ID"
+// @Param payload body budget.UpdateRequest true "Budget payload"
+// @Success 200 {object} budget.Rule
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/token-budgets/{id} [put].
+func (h *TokenBudgetHandler) Update(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	id, err := h.requireOwnedRule(c.Request().Context(), botID, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	var req budget.UpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	rule, err := h.service.Update(c.Request().Context(), id, req)
+	if err != nil {
+		return budgetHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, rule)
+}
+
+// Delete godoc
+// @Summary Delete token budget
+// @Description Delete a token budget rule
+// @Tags token-budgets
+// @Param bot_id path string true "Bot ID"
+// @Param id path string true "Budget ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/token-budgets/{id} [delete].
+func (h *TokenBudgetHandler) Delete(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	id, err := h.requireOwnedRule(c.Request().Context(), botID, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	if err := h.service.Delete(c.Request().Context(), id); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetAllowance godoc
+// @Summary Get remaining token allowance
+// @Description Report usage and remaining allowance for every enabled budget that applies to the bot and the optional user or channel identity
+// @Tags token-budgets
+// @Param bot_id path string true "Bot ID"
+// @Param user_id query string false "User ID"
+// @Param channel_identity_id query string false "Channel identity ID"
+// @Success 200 {object} budget.AllowanceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/token-budgets/allowance [get].
+func (h *TokenBudgetHandler) GetAllowance(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	items, err := h.service.Allowances(c.Request().Context(), botID, budget.Subject{
+		UserID:            strings.TrimSpace(c.QueryParam("user_id")),
+		ChannelIdentityID: strings.TrimSpace(c.QueryParam("channel_identity_id")),
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	resp := budget.AllowanceResponse{Items: items}
+	for _, item := range items {
+		if item.Exhausted {
+			resp.Exhausted = true
+			break
+		}
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *TokenBudgetHandler) authorize(c echo.Context) (string, error) {
+	userID, err := RequireChannelIdentityID(c)
+	if err != nil {
+		return "", err
+	}
+	botID := strings.TrimSpace(c.Param("bot_id"))
+	if botID == "" {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "bot id is required")
+	}
+	if _, err := AuthorizeBotAccess(c.Request().Context(), h.botService, h.accountService, userID, botID); err != nil {
+		return "", err
+	}
+	return botID, nil
+}
+
+func (h *TokenBudgetHandler) requireOwnedRule(ctx context.Context, botID, id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "id is required")
+	}
+	rule, err := h.service.Get(ctx, id)
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if rule.BotID != botID {
+		return "", echo.NewHTTPError(http.StatusForbidden, "bot mismatch")
+	}
+	return id, nil
+}
+
+func budgetHTTPError(err error) error {
+	switch {
+	case errors.Is(err, budget.ErrInvalidScope),
+		errors.Is(err, budget.ErrInvalidPeriod),
+		errors.Is(err, budget.ErrInvalidSubject),
+		errors.Is(err, budget.ErrInvalidLimit),
+		errors.Is(err, budget.ErrNoLimit):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+}
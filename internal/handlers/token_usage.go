@@ -38,35 +38,51 @@ func (h *TokenUsageHandler) Register(e *echo.Echo) {
 
 // DailyTokenUsage represents aggregated token usage for a single day.
 type DailyTokenUsage struct {
-	Day              string `json:"day"`
-	InputTokens      int64  `json:"input_tokens"`
-	OutputTokens     int64  `json:"output_tokens"`
-	CacheReadTokens  int64  `json:"cache_read_tokens"`
-	CacheWriteTokens int64  `json:"cache_write_tokens"`
-	ReasoningTokens  int64  `json:"reasoning_tokens"`
+	Day              string  `json:"day"`
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	CacheWriteTokens int64   `json:"cache_write_tokens"`
+	ReasoningTokens  int64   `json:"reasoning_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
 }
 
 // ModelTokenUsage represents aggregated token usage for a single model.
 type ModelTokenUsage struct {
-	ModelID      string `json:"model_id"`
-	ModelSlug    string `json:"model_slug"`
-	ModelName    string `json:"model_name"`
-	ProviderName string `json:"provider_name"`
-	InputTokens  int64  `json:"input_tokens"`
-	OutputTokens int64  `json:"output_tokens"`
+	ModelID      string  `json:"model_id"`
+	ModelSlug    string  `json:"model_slug"`
+	ModelName    string  `json:"model_name"`
+	ProviderName string  `json:"provider_name"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// SessionTokenUsage represents aggregated token usage for a single session.
+type SessionTokenUsage struct {
+	SessionID    string  `json:"session_id"`
+	Title        string  `json:"title"`
+	Type         string  `json:"type"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
 }
 
 // TokenUsageResponse is the response body for GET /bots/:bot_id/token-usage.
 type TokenUsageResponse struct {
-	Chat      []DailyTokenUsage `json:"chat"`
-	Heartbeat []DailyTokenUsage `json:"heartbeat"`
-	Schedule  []DailyTokenUsage `json:"schedule"`
-	ByModel   []ModelTokenUsage `json:"by_model"`
+	Chat      []DailyTokenUsage   `json:"chat"`
+	Heartbeat []DailyTokenUsage   `json:"heartbeat"`
+	Schedule  []DailyTokenUsage   `json:"schedule"`
+	ByModel   []ModelTokenUsage   `json:"by_model"`
+	BySession []SessionTokenUsage `json:"by_session"`
+	// TotalCostUSD is the bot's cost over the range. Messages answered by
+	// models without pricing contribute tokens but no cost.
+	TotalCostUSD float64 `json:"total_cost_usd"`
 }
 
 // GetTokenUsage godoc
 // @Summary Get token usage statistics
-// @Description Get daily aggregated token usage for a bot, split by chat, heartbeat, and schedule session types, with optional model filter and per-model breakdown
+// @Description Get daily aggregated token usage for a bot, split by chat, heartbeat, and schedule session types, with optional model filter, per-model and per-session breakdowns, and USD cost for models with pricing
 // @Tags token-usage
 // @Param bot_id path string true "Bot ID"
 // @Param from query string true "Start date (YYYY-MM-DD)"
@@ -137,11 +153,21 @@ func (h *TokenUsageHandler) GetTokenUsage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to fetch token usage by model")
 	}
 
+	bySession, err := h.fetchUsageBySession(ctx, pgBotID, fromTS, toTS, pgModelID)
+	if err != nil {
+		h.logger.Error("fetch token usage by session failed", slog.Any("error", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to fetch token usage by session")
+	}
+
 	resp := TokenUsageResponse{
 		Chat:      chat,
 		Heartbeat: heartbeat,
 		Schedule:  schedule,
 		ByModel:   byModel,
+		BySession: bySession,
+	}
+	for _, s := range bySession {
+		resp.TotalCostUSD += s.CostUSD
 	}
 	return c.JSON(http.StatusOK, resp)
 }
@@ -165,12 +191,20 @@ func (h *TokenUsageHandler) fetchUsageByDay(ctx context.Context, botID pgtype.UU
 			CacheReadTokens:  r.CacheReadTokens,
 			CacheWriteTokens: r.CacheWriteTokens,
 			ReasoningTokens:  r.ReasoningTokens,
+			CostUSD:          r.CostUsd,
 		}
 		switch r.SessionType {
 		case "heartbeat":
 			heartbeat = append(heartbeat, d)
-		case "schedule":
-			schedule = append(schedule, d)
+		case "schedule", "trigger":
+			// Event trigger runs are automated like schedules and share
+			// their bucket; rows arrive ordered by day, so merge same-day
+			// entries.
+			if n := len(schedule); n > 0 && schedule[n-1].Day == d.Day {
+				schedule[n-1] = addDailyTokenUsage(schedule[n-1], d)
+			} else {
+				schedule = append(schedule, d)
+			}
 		default:
 			chat = append(chat, d)
 		}
@@ -178,6 +212,16 @@ func (h *TokenUsageHandler) fetchUsageByDay(ctx context.Context, botID pgtype.UU
 	return chat, heartbeat, schedule, nil
 }
 
+func addDailyTokenUsage(a, b DailyTokenUsage) DailyTokenUsage {
+	a.InputTokens += b.InputTokens
+	a.OutputTokens += b.OutputTokens
+	a.CacheReadTokens += b.CacheReadTokens
+	a.CacheWriteTokens += b.CacheWriteTokens
+	a.ReasoningTokens += b.ReasoningTokens
+	a.CostUSD += b.CostUSD
+	return a
+}
+
 func (h *TokenUsageHandler) fetchUsageByModel(ctx context.Context, botID pgtype.UUID, from, to pgtype.Timestamptz) ([]ModelTokenUsage, error) {
 	rows, err := h.queries.GetTokenUsageByModel(ctx, sqlc.GetTokenUsageByModelParams{
 		BotID:    botID,
@@ -197,6 +241,32 @@ func (h *TokenUsageHandler) fetchUsageByModel(ctx context.Context, botID pgtype.
 			ProviderName: r.ProviderName,
 			InputTokens:  r.InputTokens,
 			OutputTokens: r.OutputTokens,
+			CostUSD:      r.CostUsd,
+		})
+	}
+	return result, nil
+}
+
+func (h *TokenUsageHandler) fetchUsageBySession(ctx context.Context, botID pgtype.UUID, from, to pgtype.Timestamptz, modelID pgtype.UUID) ([]SessionTokenUsage, error) {
+	rows, err := h.queries.GetTokenUsageBySession(ctx, sqlc.GetTokenUsageBySessionParams{
+		BotID:    botID,
+		FromTime: from,
+		ToTime:   to,
+		ModelID:  modelID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]SessionTokenUsage, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, SessionTokenUsage{
+			SessionID:    formatOptionalUUID(r.SessionID),
+			Title:        r.SessionTitle,
+			Type:         r.SessionType,
+			InputTokens:  r.InputTokens,
+			OutputTokens: r.OutputTokens,
+			CostUSD:      r.CostUsd,
 		})
 	}
 	return result, nil
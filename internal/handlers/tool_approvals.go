@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/accounts"
+	"github.com/memohai/memoh/internal/approval"
+	"github.com/memohai/memoh/internal/bots"
+)
+
+type ToolApprovalHandler struct {
+	service        *approval.Service
+	botService     *bots.Service
+	accountService *accounts.Service
+	logger         *slog.Logger
+}
+
+func NewToolApprovalHandler(log *slog.Logger, service *approval.Service, botService *bots.Service, accountService *accounts.Service) *ToolApprovalHandler {
+	return &ToolApprovalHandler{
+		service:        service,
+		botService:     botService,
+		accountService: accountService,
+		logger:         log.With(slog.String("handler", "tool_approval")),
+	}
+}
+
+func (h *ToolApprovalHandler) Register(e *echo.Echo) {
+	group := e.Group("/bots/:bot_id/tool-approvals")
+	group.GET("", h.List)
+	group.GET("/policy", h.GetPolicy)
+	group.PUT("/policy", h.UpdatePolicy)
+	group.POST("/:id/decision", h.Decide)
+}
+
+// GetPolicy godoc
+// @Summary Get tool approval policy
+// @Description Get the tools that need owner approval before they run, and how long a call waits for a decision
+// @Tags tool-approvals
+// @Param bot_id path string true "Bot ID"
+// @Success 200 {object} approval.Policy
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/tool-approvals/policy [get].
+func (h *ToolApprovalHandler) GetPolicy(c echo.Context) error {
+	botID, _, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	policy, err := h.service.ApprovalPolicy(c.Request().Context(), botID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicy godoc
+// @Summary Update tool approval policy
+// @Description Replace the tool names or globs (e.g. "exec", "github_*") that need owner approval. Omit timeout_seconds to keep the current timeout.
+// @Tags tool-approvals
+// @Param bot_id path string true "Bot ID"
+// @Param payload body approval.UpdatePolicyRequest true "Policy payload"
+// @Success 200 {object} approval.Policy
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/tool-approvals/policy [put].
+func (h *ToolApprovalHandler) UpdatePolicy(c echo.Context) error {
+	botID, _, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	var req approval.UpdatePolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	policy, err := h.service.UpdatePolicy(c.Request().Context(), botID, req)
+	if err != nil {
+		return toolApprovalHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, policy)
+}
+
+// List godoc
+// @Summary List tool approvals
+// @Description List the approval audit log of a bot, newest first
+// @Tags tool-approvals
+// @Param bot_id path string true "Bot ID"
+// @Param status query string false "Filter by status (pending, approved, rejected, expired)"
+// @Param limit query int false "Max results (default 50, max 200)"
+// @Success 200 {object} approval.ListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/tool-approvals [get].
+func (h *ToolApprovalHandler) List(c echo.Context) error {
+	botID, _, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	items, err := h.service.List(c.Request().Context(), botID, c.QueryParam("status"), parseLimit(c.QueryParam("limit")))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, approval.ListResponse{Items: items})
+}
+
+// Decide godoc
+// @Summary Decide tool approval
+// @Description Approve or reject a pending tool call. Only the bot owner can decide.
+// @Tags tool-approvals
+// @Param bot_id path string true "Bot ID"
+// @Param id path string true "Approval ID"
+// @Param payload body approval.DecideRequest true "Decision payload"
+// @Success 200 {object} approval.Approval
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/tool-approvals/{id}/decision [post].
+func (h *ToolApprovalHandler) Decide(c echo.Context) error {
+	botID, userID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	var req approval.DecideRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	item, err := h.service.Decide(c.Request().Context(), botID, c.Param("id"), userID, req)
+	if err != nil {
+		return toolApprovalHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+func (h *ToolApprovalHandler) authorize(c echo.Context) (string, string, error) {
+	userID, err := RequireChannelIdentityID(c)
+	if err != nil {
+		return "", "", err
+	}
+	botID := strings.TrimSpace(c.Param("bot_id"))
+	if botID == "" {
+		return "", "", echo.NewHTTPError(http.StatusBadRequest, "bot id is required")
+	}
+	if _, err := AuthorizeBotAccess(c.Request().Context(), h.botService, h.accountService, userID, botID); err != nil {
+		return "", "", err
+	}
+	return botID, userID, nil
+}
+
+func toolApprovalHTTPError(err error) error {
+	switch {
+	case errors.Is(err, approval.ErrInvalidToolPattern),
+		errors.Is(err, approval.ErrInvalidTimeout),
+		errors.Is(err, approval.ErrAmbiguousID):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, approval.ErrNotOwner):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case errors.Is(err, approval.ErrNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, approval.ErrNotPending):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/memohai/memoh/internal/accounts"
+	"github.com/memohai/memoh/internal/bots"
+	"github.com/memohai/memoh/internal/channel/trigger"
+)
+
+type TriggerPolicyHandler struct {
+	service        *trigger.Service
+	botService     *bots.Service
+	accountService *accounts.Service
+	logger         *slog.Logger
+}
+
+func NewTriggerPolicyHandler(log *slog.Logger, service *trigger.Service, botService *bots.Service, accountService *accounts.Service) *TriggerPolicyHandler {
+	return &TriggerPolicyHandler{
+		service:        service,
+		botService:     botService,
+		accountService: accountService,
+		logger:         log.With(slog.String("handler", "trigger_policy")),
+	}
+}
+
+func (h *TriggerPolicyHandler) Register(e *echo.Echo) {
+	group := e.Group("/bots/:bot_id/trigger-policies")
+	group.GET("", h.List)
+	group.GET("/:route_id", h.Get)
+	group.PUT("/:route_id", h.Upsert)
+	group.DELETE("/:route_id", h.Delete)
+}
+
+// List godoc
+// @Summary List trigger policies
+// @Description List the group trigger policies stored for a bot's routes. Routes without one reply only when mentioned.
+// @Tags trigger-policies
+// @Param bot_id path string true "Bot ID"
+// @Success 200 {object} trigger.ListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/trigger-policies [get].
+func (h *TriggerPolicyHandler) List(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	items, err := h.service.List(c.Request().Context(), botID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, trigger.ListResponse{Items: items})
+}
+
+// Get godoc
+// @Summary Get trigger policy
+// @Description Get the group trigger policy of a route, or the default mention policy when none is set
+// @Tags trigger-policies
+// @Param bot_id path string true "Bot ID"
+// @Param route_id path string true "Route ID"
+// @Success 200 {object} trigger.Policy
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/trigger-policies/{route_id} [get].
+func (h *TriggerPolicyHandler) Get(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	policy, err := h.service.Get(c.Request().Context(), botID, strings.TrimSpace(c.Param("route_id")))
+	if err != nil {
+		return triggerPolicyHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, policy)
+}
+
+// Upsert godoc
+// @Summary Set trigger policy
+// @Description Set how the bot decides to reply to group messages on a route it is not mentioned in: keyword or regex matches, or a classifier model in listen mode. Unsolicited replies are rate limited per route.
+// @Tags trigger-policies
+// @Param bot_id path string true "Bot ID"
+// @Param route_id path string true "Route ID"
+// @Param payload body trigger.UpsertRequest true "Trigger policy"
+// @Success 200 {object} trigger.Policy
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/trigger-policies/{route_id} [put].
+func (h *TriggerPolicyHandler) Upsert(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	var req trigger.UpsertRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	policy, err := h.service.Upsert(c.Request().Context(), botID, strings.TrimSpace(c.Param("route_id")), req)
+	if err != nil {
+		return triggerPolicyHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, policy)
+}
+
+// Delete godoc
+// @Summary Delete trigger policy
+// @Description Delete a route's trigger policy so the bot replies in the group only when mentioned
+// @Tags trigger-policies
+// @Param bot_id path string true "Bot ID"
+// @Param route_id path string true "Route ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{bot_id}/trigger-policies/{route_id} [delete].
+func (h *TriggerPolicyHandler) Delete(c echo.Context) error {
+	botID, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+	if err := h.service.Delete(c.Request().Context(), botID, strings.TrimSpace(c.Param("route_id"))); err != nil {
+		return triggerPolicyHTTPError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *TriggerPolicyHandler) authorize(c echo.Context) (string, error) {
+	userID, err := RequireChannelIdentityID(c)
+	if err != nil {
+		return "", err
+	}
+	botID := strings.TrimSpace(c.Param("bot_id"))
+	if botID == "" {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "bot id is required")
+	}
+	if _, err := AuthorizeBotAccess(c.Request().Context(), h.botService, h.accountService, userID, botID); err != nil {
+		return "", err
+	}
+	return botID, nil
+}
+
+func triggerPolicyHTTPError(err error) error {
+	switch {
+	case errors.Is(err, trigger.ErrRouteNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, trigger.ErrRouteMismatch):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case errors.Is(err, trigger.ErrInvalidMode),
+		errors.Is(err, trigger.ErrNoTriggers),
+		errors.Is(err, trigger.ErrInvalidPattern),
+		errors.Is(err, trigger.ErrInvalidLimit),
+		errors.Is(err, trigger.ErrTooManyTriggers),
+		errors.Is(err, trigger.ErrInstructionsTooLong):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+}
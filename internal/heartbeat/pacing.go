@@ -0,0 +1,167 @@
+package heartbeat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reasons recorded in heartbeat_logs.next_run_reason.
+const (
+	NextReasonInterval    = "interval"
+	NextReasonBackoff     = "backoff"
+	NextReasonRequested   = "requested"
+	NextReasonActiveHours = "active_hours"
+)
+
+const (
+	// NextCheckDirective lets a heartbeat run ask for its next check, e.g.
+	// "HEARTBEAT_NEXT: 90" (minutes) or "HEARTBEAT_NEXT: 2h".
+	NextCheckDirective = "HEARTBEAT_NEXT:"
+	// MaxRequestedInterval caps how far ahead, in minutes, a run may push
+	// the next check.
+	MaxRequestedInterval = 24 * 60
+	// maxBackoffShift bounds the exponent so the doubling cannot overflow
+	// before the ceiling applies.
+	maxBackoffShift = 16
+)
+
+// nextRun is when the next heartbeat fires and why, along with the pacing
+// state recorded on the log of the run that produced it.
+type nextRun struct {
+	At            time.Time
+	Reason        string
+	ConsecutiveOK int
+	Requested     int
+}
+
+// plan computes the next heartbeat after now. consecutiveOK counts the
+// HEARTBEAT_OK runs in a row ending with the current one, and requested is
+// the interval in minutes the run asked for (0 for none). jitter returns a
+// random duration in [0, max].
+func (cfg Config) plan(now time.Time, consecutiveOK, requested int, jitter func(time.Duration) time.Duration) nextRun {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	next := nextRun{ConsecutiveOK: consecutiveOK, Requested: requested, Reason: NextReasonInterval}
+	delay := time.Duration(interval) * time.Minute
+	switch {
+	case requested > 0:
+		delay = time.Duration(min(requested, MaxRequestedInterval)) * time.Minute
+		next.Reason = NextReasonRequested
+	case cfg.MaxInterval > interval && consecutiveOK > 1:
+		// The first quiet run keeps the base interval; each one after that
+		// doubles it up to the ceiling.
+		backoff := interval << min(consecutiveOK-1, maxBackoffShift)
+		delay = time.Duration(min(backoff, cfg.MaxInterval)) * time.Minute
+		next.Reason = NextReasonBackoff
+	}
+
+	spread := time.Duration(max(cfg.Jitter, 0)) * time.Minute
+	if requested > 0 {
+		// An explicit request is honored as asked.
+		spread = 0
+	}
+	next.At = now.Add(delay + jitter(spread))
+
+	window, err := cfg.activeWindow()
+	if err == nil && !window.contains(next.At) {
+		next.At = window.nextStart(next.At).Add(jitter(time.Duration(max(cfg.Jitter, 0)) * time.Minute))
+		next.Reason = NextReasonActiveHours
+	}
+	return next
+}
+
+// activeWindow is a daily wall-clock window in a location. A window whose
+// start equals its end is always active; a start after the end wraps
+// midnight.
+type activeWindow struct {
+	start, end int // minutes after midnight
+	loc        *time.Location
+}
+
+func (cfg Config) activeWindow() (activeWindow, error) {
+	w := activeWindow{loc: time.UTC}
+	if tz := strings.TrimSpace(cfg.Timezone); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return w, err
+		}
+		w.loc = loc
+	}
+	if strings.TrimSpace(cfg.ActiveStart) == "" && strings.TrimSpace(cfg.ActiveEnd) == "" {
+		return w, nil
+	}
+	var err error
+	if w.start, err = parseClock(cfg.ActiveStart); err != nil {
+		return w, err
+	}
+	if w.end, err = parseClock(cfg.ActiveEnd); err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+func (w activeWindow) contains(t time.Time) bool {
+	if w.start == w.end {
+		return true
+	}
+	local := t.In(w.loc)
+	m := local.Hour()*60 + local.Minute()
+	if w.start < w.end {
+		return m >= w.start && m < w.end
+	}
+	return m >= w.start || m < w.end
+}
+
+// nextStart returns the first window start after t.
+func (w activeWindow) nextStart(t time.Time) time.Time {
+	local := t.In(w.loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), w.start/60, w.start%60, 0, 0, w.loc)
+	if !start.After(local) {
+		start = time.Date(local.Year(), local.Month(), local.Day()+1, w.start/60, w.start%60, 0, 0, w.loc)
+	}
+	return start
+}
+
+// parseClock parses "HH:MM" into minutes after midnight.
+func parseClock(raw string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: use HH:MM", raw)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ParseNextCheck extracts a NextCheckDirective from a heartbeat's final
+// text. It returns the requested interval in minutes (0 when absent or
+// invalid) and the text with the directive line removed.
+func ParseNextCheck(text string) (int, string) {
+	lines := strings.Split(text, "\n")
+	minutes := 0
+	kept := lines[:0]
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, NextCheckDirective) {
+			kept = append(kept, line)
+			continue
+		}
+		if m := parseNextCheckValue(strings.TrimSpace(strings.TrimPrefix(trimmed, NextCheckDirective))); m > 0 {
+			minutes = m
+		}
+	}
+	return minutes, strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+func parseNextCheckValue(raw string) int {
+	if n, err := strconv.Atoi(raw); err == nil {
+		return max(n, 0)
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return max(int(d.Round(time.Minute)/time.Minute), 1)
+}
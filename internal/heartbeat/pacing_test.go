@@ -0,0 +1,107 @@
+package heartbeat
+
+import (
+	"testing"
+	"time"
+)
+
+func noJitter(time.Duration) time.Duration { return 0 }
+
+func fullJitter(limit time.Duration) time.Duration { return limit }
+
+func TestPlanBackoff(t *testing.T) {
+	now := time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC)
+	cfg := Config{Interval: 30, MaxInterval: 180}
+
+	cases := []struct {
+		consecutiveOK int
+		want          time.Duration
+		reason        string
+	}{
+		{0, 30 * time.Minute, NextReasonInterval},
+		{1, 30 * time.Minute, NextReasonInterval},
+		{2, 60 * time.Minute, NextReasonBackoff},
+		{3, 120 * time.Minute, NextReasonBackoff},
+		{4, 180 * time.Minute, NextReasonBackoff},
+		{40, 180 * time.Minute, NextReasonBackoff},
+	}
+	for _, tc := range cases {
+		next := cfg.plan(now, tc.consecutiveOK, 0, noJitter)
+		if got := next.At.Sub(now); got != tc.want || next.Reason != tc.reason {
+			t.Errorf("consecutive_ok=%d: got %s (%s), want %s (%s)", tc.consecutiveOK, got, next.Reason, tc.want, tc.reason)
+		}
+	}
+
+	noBackoff := Config{Interval: 30}
+	if got := noBackoff.plan(now, 5, 0, noJitter).At.Sub(now); got != 30*time.Minute {
+		t.Fatalf("expected backoff disabled without max interval, got %s", got)
+	}
+}
+
+func TestPlanRequestedIntervalSkipsJitter(t *testing.T) {
+	now := time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC)
+	cfg := Config{Interval: 30, MaxInterval: 180, Jitter: 10}
+
+	next := cfg.plan(now, 6, 45, fullJitter)
+	if got := next.At.Sub(now); got != 45*time.Minute || next.Reason != NextReasonRequested {
+		t.Fatalf("expected requested 45m without jitter, got %s (%s)", got, next.Reason)
+	}
+	if capped := cfg.plan(now, 0, 10_000, noJitter); capped.At.Sub(now) != MaxRequestedInterval*time.Minute {
+		t.Fatalf("expected requested interval to be capped, got %s", capped.At.Sub(now))
+	}
+	if jittered := cfg.plan(now, 0, 0, fullJitter); jittered.At.Sub(now) != 40*time.Minute {
+		t.Fatalf("expected interval plus full jitter, got %s", jittered.At.Sub(now))
+	}
+}
+
+func TestPlanActiveHours(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Interval: 60, Timezone: "Asia/Shanghai", ActiveStart: "08:00", ActiveEnd: "22:00", Jitter: 5}
+
+	// 21:30 local: the next slot at 22:30 is outside the window, so the run
+	// moves to 08:00 the next morning plus jitter.
+	now := time.Date(2026, 3, 13, 21, 30, 0, 0, shanghai)
+	next := cfg.plan(now, 0, 0, fullJitter)
+	want := time.Date(2026, 3, 14, 8, 5, 0, 0, shanghai)
+	if !next.At.Equal(want) || next.Reason != NextReasonActiveHours {
+		t.Fatalf("expected %s (active_hours), got %s (%s)", want, next.At.In(shanghai), next.Reason)
+	}
+
+	inside := cfg.plan(time.Date(2026, 3, 13, 10, 0, 0, 0, shanghai), 0, 0, noJitter)
+	if inside.Reason != NextReasonInterval {
+		t.Fatalf("expected a run inside the window to keep its interval, got %s", inside.Reason)
+	}
+
+	overnight := Config{Interval: 60, ActiveStart: "22:00", ActiveEnd: "06:00"}
+	late := overnight.plan(time.Date(2026, 3, 13, 23, 0, 0, 0, time.UTC), 0, 0, noJitter)
+	if late.Reason != NextReasonInterval {
+		t.Fatalf("expected midnight to be inside an overnight window, got %s", late.Reason)
+	}
+	morning := overnight.plan(time.Date(2026, 3, 13, 5, 30, 0, 0, time.UTC), 0, 0, noJitter)
+	if want := time.Date(2026, 3, 13, 22, 0, 0, 0, time.UTC); !morning.At.Equal(want) {
+		t.Fatalf("expected the window to reopen at %s, got %s", want, morning.At)
+	}
+}
+
+func TestParseNextCheck(t *testing.T) {
+	cases := []struct {
+		text    string
+		minutes int
+		rest    string
+	}{
+		{"HEARTBEAT_OK", 0, "HEARTBEAT_OK"},
+		{"HEARTBEAT_OK\nHEARTBEAT_NEXT: 120", 120, "HEARTBEAT_OK"},
+		{"Sent a reminder.\n  HEARTBEAT_NEXT: 2h  ", 120, "Sent a reminder."},
+		{"HEARTBEAT_NEXT: 90s\nHEARTBEAT_OK", 2, "HEARTBEAT_OK"},
+		{"HEARTBEAT_NEXT: soon\nHEARTBEAT_OK", 0, "HEARTBEAT_OK"},
+	}
+	for _, tc := range cases {
+		minutes, rest := ParseNextCheck(tc.text)
+		if minutes != tc.minutes || rest != tc.rest {
+			t.Errorf("ParseNextCheck(%q) = %d, %q; want %d, %q", tc.text, minutes, rest, tc.minutes, tc.rest)
+		}
+	}
+}
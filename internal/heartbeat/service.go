@@ -6,12 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"math/rand/v2"
 	"strings"
 	"sync"
 	"time"
+	_ "time/tzdata" // active hours name IANA zones; slim images may lack zoneinfo
 
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/robfig/cron/v3"
 
 	"github.com/memohai/memoh/internal/auth"
 	"github.com/memohai/memoh/internal/boot"
@@ -26,30 +28,36 @@ type SessionCreator interface {
 	CreateSession(ctx context.Context, botID, sessionType string) (string, error)
 }
 
+// job is one bot's chain of heartbeat timers. Each run arms the next timer
+// only while its job is still registered, so Stop and Reschedule win over
+// a run that is in flight.
+type job struct {
+	timer *time.Timer
+}
+
 type Service struct {
 	queries        *sqlc.Queries
-	cron           *cron.Cron
 	triggerer      Triggerer
 	sessionCreator SessionCreator
 	jwtSecret      string
 	logger         *slog.Logger
+	now            func() time.Time
+	jitter         func(time.Duration) time.Duration
 	mu             sync.Mutex
-	jobs           map[string]cron.EntryID
+	jobs           map[string]*job
 }
 
 func NewService(log *slog.Logger, queries *sqlc.Queries, triggerer Triggerer, sessionCreator SessionCreator, runtimeConfig *boot.RuntimeConfig) *Service {
-	c := cron.New()
-	service := &Service{
+	return &Service{
 		queries:        queries,
-		cron:           c,
 		triggerer:      triggerer,
 		sessionCreator: sessionCreator,
 		jwtSecret:      runtimeConfig.JwtSecret,
 		logger:         log.With(slog.String("service", "heartbeat")),
-		jobs:           map[string]cron.EntryID{},
+		now:            time.Now,
+		jitter:         randomJitter,
+		jobs:           map[string]*job{},
 	}
-	c.Start()
-	return service
 }
 
 func (s *Service) Bootstrap(ctx context.Context) error {
@@ -67,10 +75,19 @@ func (s *Service) Bootstrap(ctx context.Context) error {
 			BotID:       botID,
 			OwnerUserID: ownerUserID,
 			Interval:    int(row.HeartbeatInterval),
+			Timezone:    row.Timezone,
+			ActiveStart: row.HeartbeatActiveStart,
+			ActiveEnd:   row.HeartbeatActiveEnd,
+			Jitter:      int(row.HeartbeatJitter),
+			MaxInterval: int(row.HeartbeatMaxInterval),
 		}
-		if err := s.scheduleJob(ctx, cfg); err != nil {
-			s.logger.Error("failed to schedule heartbeat", slog.String("bot_id", botID), slog.Any("error", err))
+		// Resume the pace the last run chose, so restarts neither reset
+		// backoff nor drop a requested check.
+		next, ok := s.pendingRun(ctx, row.ID)
+		if !ok {
+			next = s.firstRun(ctx, cfg)
 		}
+		s.scheduleJob(ctx, cfg, next)
 	}
 	s.logger.Info("heartbeat bootstrap complete", slog.Int("count", len(rows)))
 	return nil
@@ -83,7 +100,7 @@ func (s *Service) Reschedule(ctx context.Context, botID string) error {
 	if err != nil {
 		return err
 	}
-	bot, err := s.queries.GetBotByID(ctx, pgID)
+	bot, err := s.queries.GetBotHeartbeatConfig(ctx, pgID)
 	if err != nil {
 		return fmt.Errorf("get bot: %w", err)
 	}
@@ -94,24 +111,32 @@ func (s *Service) Reschedule(ctx context.Context, botID string) error {
 		BotID:       botID,
 		OwnerUserID: bot.OwnerUserID.String(),
 		Interval:    int(bot.HeartbeatInterval),
+		Timezone:    bot.Timezone,
+		ActiveStart: bot.HeartbeatActiveStart,
+		ActiveEnd:   bot.HeartbeatActiveEnd,
+		Jitter:      int(bot.HeartbeatJitter),
+		MaxInterval: int(bot.HeartbeatMaxInterval),
 	}
-	return s.scheduleJob(ctx, cfg)
+	s.scheduleJob(ctx, cfg, s.firstRun(ctx, cfg))
+	return nil
 }
 
 func (s *Service) Stop(botID string) {
 	s.removeJob(botID)
 }
 
-func (s *Service) runHeartbeat(ctx context.Context, cfg Config) {
+// runHeartbeat runs one heartbeat and returns when the next one is due.
+func (s *Service) runHeartbeat(ctx context.Context, cfg Config) time.Time {
+	fallback := cfg.plan(s.now(), 0, 0, s.jitter)
 	if s.triggerer == nil {
 		s.logger.Error("heartbeat triggerer not configured")
-		return
+		return fallback.At
 	}
 
 	pgBotID, err := db.ParseUUID(cfg.BotID)
 	if err != nil {
 		s.logger.Error("invalid bot id", slog.String("bot_id", cfg.BotID), slog.Any("error", err))
-		return
+		return fallback.At
 	}
 
 	var sessionID string
@@ -127,11 +152,13 @@ func (s *Service) runHeartbeat(ctx context.Context, cfg Config) {
 	}
 
 	var lastHeartbeatAt string
+	prevConsecutiveOK := 0
 	if prevLogs, listErr := s.queries.ListHeartbeatLogsByBot(ctx, sqlc.ListHeartbeatLogsByBotParams{
 		BotID: pgBotID,
 		Limit: 1,
 	}); listErr == nil && len(prevLogs) > 0 {
 		lastHeartbeatAt = prevLogs[0].StartedAt.Time.UTC().Format("2006-01-02T15:04:05Z")
+		prevConsecutiveOK = int(prevLogs[0].ConsecutiveOk)
 	}
 
 	logRow, err := s.queries.CreateHeartbeatLog(ctx, sqlc.CreateHeartbeatLogParams{
@@ -140,14 +167,14 @@ func (s *Service) runHeartbeat(ctx context.Context, cfg Config) {
 	})
 	if err != nil {
 		s.logger.Error("create heartbeat log failed", slog.String("bot_id", cfg.BotID), slog.Any("error", err))
-		return
+		return fallback.At
 	}
 
 	token, err := s.generateTriggerToken(cfg.OwnerUserID)
 	if err != nil {
-		s.completeLog(ctx, logRow.ID, "error", "", err.Error(), nil, pgtype.UUID{})
+		s.completeLog(ctx, logRow.ID, "error", "", err.Error(), nil, pgtype.UUID{}, fallback)
 		s.logger.Error("generate trigger token failed", slog.String("bot_id", cfg.BotID), slog.Any("error", err))
-		return
+		return fallback.At
 	}
 
 	result, err := s.triggerer.TriggerHeartbeat(ctx, cfg.BotID, TriggerPayload{
@@ -156,26 +183,46 @@ func (s *Service) runHeartbeat(ctx context.Context, cfg Config) {
 		OwnerUserID:     cfg.OwnerUserID,
 		SessionID:       sessionID,
 		LastHeartbeatAt: lastHeartbeatAt,
+		Timezone:        cfg.Timezone,
 	}, token)
 	if err != nil {
-		s.completeLog(ctx, logRow.ID, "error", "", err.Error(), nil, pgtype.UUID{})
+		s.completeLog(ctx, logRow.ID, "error", "", err.Error(), nil, pgtype.UUID{}, fallback)
 		s.logger.Error("heartbeat trigger failed", slog.String("bot_id", cfg.BotID), slog.Any("error", err))
-		return
+		return fallback.At
 	}
 
+	consecutiveOK := 0
+	if result.Status == "ok" {
+		consecutiveOK = prevConsecutiveOK + 1
+	}
+	next := cfg.plan(s.now(), consecutiveOK, result.NextInterval, s.jitter)
 	modelID := db.ParseUUIDOrEmpty(result.ModelID)
-	s.completeLog(ctx, logRow.ID, result.Status, result.Text, "", result.UsageBytes, modelID)
-	s.logger.Info("heartbeat completed", slog.String("bot_id", cfg.BotID), slog.String("status", result.Status))
+	s.completeLog(ctx, logRow.ID, result.Status, result.Text, "", result.UsageBytes, modelID, next)
+	s.logger.Info("heartbeat completed",
+		slog.String("bot_id", cfg.BotID),
+		slog.String("status", result.Status),
+		slog.Time("next_run_at", next.At),
+		slog.String("next_run_reason", next.Reason),
+	)
+	return next.At
 }
 
-func (s *Service) completeLog(ctx context.Context, logID pgtype.UUID, status, resultText, errorMessage string, usageBytes []byte, modelID pgtype.UUID) {
+func (s *Service) completeLog(ctx context.Context, logID pgtype.UUID, status, resultText, errorMessage string, usageBytes []byte, modelID pgtype.UUID, next nextRun) {
+	requested := pgtype.Int4{}
+	if next.Requested > 0 {
+		requested = pgtype.Int4{Int32: int32(min(next.Requested, MaxRequestedInterval)), Valid: true} //nolint:gosec // capped above
+	}
 	_, err := s.queries.CompleteHeartbeatLog(ctx, sqlc.CompleteHeartbeatLogParams{
-		ID:           logID,
-		Status:       status,
-		ResultText:   resultText,
-		ErrorMessage: errorMessage,
-		Usage:        usageBytes,
-		ModelID:      modelID,
+		ID:                logID,
+		Status:            status,
+		ResultText:        resultText,
+		ErrorMessage:      errorMessage,
+		Usage:             usageBytes,
+		ModelID:           modelID,
+		ConsecutiveOk:     int32(min(next.ConsecutiveOK, math.MaxInt32)), //nolint:gosec // capped above
+		RequestedInterval: requested,
+		NextRunAt:         pgtype.Timestamptz{Time: next.At, Valid: !next.At.IsZero()},
+		NextRunReason:     next.Reason,
 	})
 	if err != nil {
 		s.logger.Error("complete heartbeat log failed", slog.Any("error", err))
@@ -228,35 +275,90 @@ func (s *Service) generateTriggerToken(userID string) (string, error) {
 	return "Bearer " + signed, nil
 }
 
-func (s *Service) scheduleJob(ctx context.Context, cfg Config) error {
-	if cfg.Interval <= 0 {
-		cfg.Interval = 30
-	}
-	spec := fmt.Sprintf("@every %dm", cfg.Interval)
-	job := func() {
-		s.runHeartbeat(context.WithoutCancel(ctx), cfg)
+// firstRun plans the first heartbeat after (re)scheduling, carrying over
+// the backoff streak of the last run.
+func (s *Service) firstRun(ctx context.Context, cfg Config) time.Time {
+	consecutiveOK := 0
+	if pgBotID, err := db.ParseUUID(cfg.BotID); err == nil {
+		if logs, err := s.queries.ListHeartbeatLogsByBot(ctx, sqlc.ListHeartbeatLogsByBotParams{
+			BotID: pgBotID,
+			Limit: 1,
+		}); err == nil && len(logs) > 0 {
+			consecutiveOK = int(logs[0].ConsecutiveOk)
+		}
 	}
-	entryID, err := s.cron.AddFunc(spec, job)
-	if err != nil {
-		return fmt.Errorf("add heartbeat cron job: %w", err)
+	return cfg.plan(s.now(), consecutiveOK, 0, s.jitter).At
+}
+
+// pendingRun returns the next run the last heartbeat scheduled, if it is
+// still in the future.
+func (s *Service) pendingRun(ctx context.Context, botID pgtype.UUID) (time.Time, bool) {
+	logs, err := s.queries.ListHeartbeatLogsByBot(ctx, sqlc.ListHeartbeatLogsByBotParams{
+		BotID: botID,
+		Limit: 1,
+	})
+	if err != nil || len(logs) == 0 || !logs[0].NextRunAt.Valid {
+		return time.Time{}, false
 	}
+	next := logs[0].NextRunAt.Time
+	return next, next.After(s.now())
+}
+
+func (s *Service) scheduleJob(ctx context.Context, cfg Config, at time.Time) {
+	j := &job{}
 	s.mu.Lock()
-	s.jobs[cfg.BotID] = entryID
+	if prev, ok := s.jobs[cfg.BotID]; ok && prev.timer != nil {
+		prev.timer.Stop()
+	}
+	s.jobs[cfg.BotID] = j
 	s.mu.Unlock()
-	s.logger.Info("heartbeat scheduled", slog.String("bot_id", cfg.BotID), slog.Int("interval_minutes", cfg.Interval))
-	return nil
+	s.arm(context.WithoutCancel(ctx), cfg, j, at)
+	s.logger.Info("heartbeat scheduled",
+		slog.String("bot_id", cfg.BotID),
+		slog.Int("interval_minutes", cfg.Interval),
+		slog.Time("next_run_at", at),
+	)
+}
+
+// arm sets j's timer for at unless j has been replaced or removed.
+func (s *Service) arm(ctx context.Context, cfg Config, j *job, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jobs[cfg.BotID] != j {
+		return
+	}
+	j.timer = time.AfterFunc(max(at.Sub(s.now()), 0), func() {
+		s.mu.Lock()
+		current := s.jobs[cfg.BotID] == j
+		s.mu.Unlock()
+		if !current {
+			return
+		}
+		s.arm(ctx, cfg, j, s.runHeartbeat(ctx, cfg))
+	})
 }
 
 func (s *Service) removeJob(botID string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	entryID, ok := s.jobs[botID]
-	if ok {
-		s.cron.Remove(entryID)
+	if j, ok := s.jobs[botID]; ok {
+		if j.timer != nil {
+			j.timer.Stop()
+		}
 		delete(s.jobs, botID)
 	}
 }
 
+// randomJitter returns a uniformly random duration in [0, limit], at
+// second granularity.
+func randomJitter(limit time.Duration) time.Duration {
+	seconds := int64(limit / time.Second)
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(seconds+1)) * time.Second //nolint:gosec // scheduling jitter, not security sensitive
+}
+
 func toLog(row sqlc.ListHeartbeatLogsByBotRow) Log {
 	l := Log{
 		ID:           row.ID.String(),
@@ -273,6 +375,16 @@ func toLog(row sqlc.ListHeartbeatLogsByBotRow) Log {
 		t := row.CompletedAt.Time
 		l.CompletedAt = &t
 	}
+	l.ConsecutiveOK = int(row.ConsecutiveOk)
+	if row.RequestedInterval.Valid {
+		requested := int(row.RequestedInterval.Int32)
+		l.RequestedInterval = &requested
+	}
+	if row.NextRunAt.Valid {
+		t := row.NextRunAt.Time
+		l.NextRunAt = &t
+	}
+	l.NextRunReason = row.NextRunReason
 	if row.Usage != nil {
 		var usage any
 		if err := json.Unmarshal(row.Usage, &usage); err == nil {
@@ -8,6 +8,7 @@ type TriggerPayload struct {
 	OwnerUserID     string
 	SessionID       string
 	LastHeartbeatAt string // ISO 8601; empty on first heartbeat
+	Timezone        string
 }
 
 type TriggerResult struct {
@@ -17,6 +18,9 @@ type TriggerResult struct {
 	UsageBytes []byte
 	ModelID    string
 	SessionID  string
+	// NextInterval is the next-check interval in minutes the run requested
+	// with NextCheckDirective; 0 when it did not.
+	NextInterval int
 }
 
 type Triggerer interface {
@@ -2,10 +2,24 @@ package heartbeat
 
 import "time"
 
+// DefaultInterval is the heartbeat interval in minutes when none is set.
+const DefaultInterval = 30
+
 type Config struct {
 	BotID       string
 	OwnerUserID string
 	Interval    int
+	// Timezone is the bot's IANA zone for active hours; empty means UTC.
+	Timezone string
+	// ActiveStart and ActiveEnd bound the daily "HH:MM" window heartbeats
+	// may fire in. Both empty means always active.
+	ActiveStart string
+	ActiveEnd   string
+	// Jitter is the maximum random delay in minutes added to each run.
+	Jitter int
+	// MaxInterval is the backoff ceiling in minutes for quiet runs. Values
+	// not above Interval disable backoff.
+	MaxInterval int
 }
 
 type Log struct {
@@ -18,6 +32,15 @@ type Log struct {
 	Usage        any        `json:"usage,omitempty"`
 	StartedAt    time.Time  `json:"started_at"`
 	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	// ConsecutiveOK counts HEARTBEAT_OK runs in a row ending with this one.
+	ConsecutiveOK int `json:"consecutive_ok"`
+	// RequestedInterval is the next-check interval in minutes the run asked
+	// for, if any.
+	RequestedInterval *int       `json:"requested_interval,omitempty"`
+	NextRunAt         *time.Time `json:"next_run_at,omitempty"`
+	// NextRunReason explains NextRunAt: interval, backoff, requested or
+	// active_hours.
+	NextRunReason string `json:"next_run_reason,omitempty"`
 }
 
 type ListLogsResponse struct {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"strings"
 	"time"
 
@@ -127,6 +128,28 @@ func (s *ConnectionService) ListActiveByBot(ctx context.Context, botID string) (
 	return active, nil
 }
 
+// EgressHosts returns the hosts of the bot's active remote MCP servers, which
+// its container keeps reaching under a restrictive network policy.
+func (s *ConnectionService) EgressHosts(ctx context.Context, botID string) ([]string, error) {
+	items, err := s.ListActiveByBot(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(items))
+	for _, item := range items {
+		raw, _ := item.Config["url"].(string)
+		if raw == "" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		hosts = append(hosts, u.Hostname())
+	}
+	return hosts, nil
+}
+
 // Get returns a specific MCP connection for a bot.
 func (s *ConnectionService) Get(ctx context.Context, botID, id string) (Connection, error) {
 	if s.queries == nil {
@@ -18,6 +18,11 @@ type ToolSessionContext struct {
 	CurrentPlatform   string
 	ReplyTarget       string
 	IsSubagent        bool
+	// Speaker and conversation details used to scope memory recall.
+	UserID            string
+	ConversationID    string
+	GroupConversation bool
+	MemoryScopePolicy string
 }
 
 // ToolDescriptor is the MCP tools/list item shape used by the gateway.
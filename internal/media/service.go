@@ -16,7 +16,8 @@ import (
 )
 
 // Service provides content-addressed media asset persistence.
-// All metadata is derived from the filesystem — no database, no sidecar files.
+// All metadata is derived from the filesystem — no database. The only
+// sidecar files are document extractions, kept under extractionDir.
 type Service struct {
 	provider storage.Provider
 	logger   *slog.Logger
@@ -160,6 +161,54 @@ func (s *Service) IngestContainerFile(ctx context.Context, botID, containerPath
 	return s.Ingest(ctx, IngestInput{BotID: botID, Mime: mime, Reader: f, OriginalExt: ext})
 }
 
+// extractionDir holds derived document extractions, keyed like assets but
+// outside the asset prefixes so hash lookups never return them.
+const extractionDir = "extracted"
+
+// PutExtraction stores the extracted text of an asset next to it.
+func (s *Service) PutExtraction(ctx context.Context, botID, contentHash string, reader io.Reader) error {
+	if s.provider == nil {
+		return ErrProviderUnavailable
+	}
+	key, err := extractionKey(botID, contentHash)
+	if err != nil {
+		return err
+	}
+	if err := s.provider.Put(ctx, key, reader); err != nil {
+		return fmt.Errorf("store extraction: %w", err)
+	}
+	return nil
+}
+
+// OpenExtraction returns a reader for the stored extraction of an asset,
+// or ErrAssetNotFound when the asset has not been extracted.
+func (s *Service) OpenExtraction(ctx context.Context, botID, contentHash string) (io.ReadCloser, error) {
+	if s.provider == nil {
+		return nil, ErrProviderUnavailable
+	}
+	key, err := extractionKey(botID, contentHash)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := s.provider.Open(ctx, key)
+	if err != nil {
+		return nil, ErrAssetNotFound
+	}
+	return reader, nil
+}
+
+func extractionKey(botID, contentHash string) (string, error) {
+	botID = strings.TrimSpace(botID)
+	contentHash = strings.TrimSpace(contentHash)
+	if botID == "" || len(contentHash) < 2 {
+		return "", ErrAssetNotFound
+	}
+	if strings.ContainsAny(botID+contentHash, "/\\") || strings.Contains(botID+contentHash, "..") {
+		return "", ErrPathTraversal
+	}
+	return path.Join(botID, extractionDir, contentHash[:2], contentHash+".json"), nil
+}
+
 // resolveByContentHash scans hash-prefix directory by extension to find the file.
 // It first tries known extensions (fast path), then falls back to a directory
 // listing if the provider supports it, so arbitrary file types are found.
@@ -85,11 +85,11 @@ func (p *BuiltinProvider) OnBeforeChat(ctx context.Context, req adapters.BeforeC
 		Query: req.Query,
 		BotID: req.BotID,
 		Limit: memoryContextLimitPerScope,
-		Filters: map[string]any{
+		Filters: req.Scope.ApplyFilters(map[string]any{
 			"namespace": sharedMemoryNamespace,
 			"scopeId":   req.BotID,
 			"bot_id":    req.BotID,
-		},
+		}),
 		NoStats: true,
 	})
 	if err != nil {
@@ -165,7 +165,7 @@ func (p *BuiltinProvider) OnAfterChat(ctx context.Context, req adapters.AfterCha
 		"scopeId":   botID,
 		"bot_id":    botID,
 	}
-	metadata := adapters.BuildProfileMetadata(req.UserID, req.ChannelIdentityID, req.DisplayName)
+	metadata := req.Scope.ApplyMetadata(adapters.BuildProfileMetadata(req.UserID, req.ChannelIdentityID, req.DisplayName))
 	if _, err := p.service.Add(ctx, adapters.AddRequest{
 		Messages: req.Messages,
 		BotID:    botID,
@@ -266,11 +266,11 @@ func (p *BuiltinProvider) CallTool(ctx context.Context, session mcp.ToolSessionC
 		Query: query,
 		BotID: botID,
 		Limit: limit,
-		Filters: map[string]any{
+		Filters: adapters.ScopeFromSession(session).ApplyFilters(map[string]any{
 			"namespace": sharedMemoryNamespace,
 			"scopeId":   botID,
 			"bot_id":    botID,
-		},
+		}),
 		NoStats: true,
 	})
 	if err != nil {
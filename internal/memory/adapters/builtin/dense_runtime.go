@@ -88,19 +88,23 @@ func newDenseRuntime(providerConfig map[string]any, queries *dbsqlc.Queries, cfg
 	}
 
 	return &denseRuntime{
-		qdrant: qClient,
-		store:  store,
-		embedder: &denseEmbeddingClient{
-			baseURL:    strings.TrimRight(modelSpec.baseURL, "/"),
-			apiKey:     modelSpec.apiKey,
-			modelID:    modelSpec.modelID,
-			dimensions: modelSpec.dimensions,
-			httpClient: &http.Client{Timeout: 30 * time.Second},
-		},
+		qdrant:     qClient,
+		store:      store,
+		embedder:   newDenseEmbeddingClient(modelSpec),
 		collection: collection,
 	}, nil
 }
 
+func newDenseEmbeddingClient(spec denseModelSpec) *denseEmbeddingClient {
+	return &denseEmbeddingClient{
+		baseURL:    strings.TrimRight(spec.baseURL, "/"),
+		apiKey:     spec.apiKey,
+		modelID:    spec.modelID,
+		dimensions: spec.dimensions,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
 func (r *denseRuntime) Add(ctx context.Context, req adapters.AddRequest) (adapters.SearchResponse, error) {
 	botID, err := sparseRuntimeBotID(req.BotID, req.Filters)
 	if err != nil {
@@ -145,7 +149,7 @@ func (r *denseRuntime) Search(ctx context.Context, req adapters.SearchRequest) (
 	if err != nil {
 		return adapters.SearchResponse{}, fmt.Errorf("dense embed query: %w", err)
 	}
-	results, err := r.qdrant.SearchDense(ctx, qdrantclient.DenseVector{Values: vec}, botID, limit)
+	results, err := r.qdrant.SearchDense(ctx, qdrantclient.DenseVector{Values: vec}, botID, adapters.ScopeKeysFromFilters(req.Filters), limit)
 	if err != nil {
 		return adapters.SearchResponse{}, err
 	}
@@ -344,6 +348,7 @@ func (r *denseRuntime) Status(ctx context.Context, botID string) (adapters.Memor
 	status := adapters.MemoryStatusResponse{
 		ProviderType:      BuiltinType,
 		MemoryMode:        string(ModeDense),
+		VectorStore:       string(VectorStoreQdrant),
 		CanManualSync:     true,
 		SourceDir:         path.Join(config.DefaultDataMount, "memory"),
 		OverviewPath:      path.Join(config.DefaultDataMount, "MEMORY.md"),
@@ -675,6 +680,9 @@ func densePayload(botID string, item storefs.MemoryItem) map[string]string {
 	if item.UpdatedAt != "" {
 		payload["updated_at"] = item.UpdatedAt
 	}
+	if key := adapters.ScopeKeyFromMetadata(item.Metadata); key != "" {
+		payload[adapters.MetadataScopeKey] = key
+	}
 	return payload
 }
 
@@ -732,6 +740,9 @@ func denseResultToItem(r qdrantclient.SearchResult) adapters.MemoryItem {
 		item.BotID = r.Payload["bot_id"]
 		item.CreatedAt = r.Payload["created_at"]
 		item.UpdatedAt = r.Payload["updated_at"]
+		if key := strings.TrimSpace(r.Payload[adapters.MetadataScopeKey]); key != "" {
+			item.Metadata = map[string]any{adapters.MetadataScopeKey: key}
+		}
 	}
 	return item
 }
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"log/slog"
 	"strconv"
 	"strings"
@@ -20,9 +21,18 @@ const (
 	ModeDense  BuiltinMemoryMode = "dense"
 )
 
+// VectorStore selects where dense mode stores its embeddings.
+type VectorStore string
+
+const (
+	VectorStoreQdrant   VectorStore = "qdrant"
+	VectorStorePgvector VectorStore = "pgvector"
+)
+
 // NewBuiltinRuntimeFromConfig returns the appropriate memoryRuntime based on the
-// provider's persisted config (memory_mode field). Falls back to the file runtime for "off" or unknown.
-func NewBuiltinRuntimeFromConfig(log *slog.Logger, providerConfig map[string]any, fileRuntime any, store *storefs.Service, queries *dbsqlc.Queries, cfg config.Config) (any, error) {
+// provider's persisted config (memory_mode field, plus vector_store in dense
+// mode). Falls back to the file runtime for "off" or unknown.
+func NewBuiltinRuntimeFromConfig(log *slog.Logger, providerConfig map[string]any, fileRuntime any, store *storefs.Service, queries *dbsqlc.Queries, conn dbsqlc.DBTX, cfg config.Config) (any, error) {
 	mode := BuiltinMemoryMode(strings.TrimSpace(adapters.StringFromConfig(providerConfig, "memory_mode")))
 
 	switch mode {
@@ -55,6 +65,21 @@ func NewBuiltinRuntimeFromConfig(log *slog.Logger, providerConfig map[string]any
 		return rt, nil
 
 	case ModeDense:
+		if DenseVectorStore(providerConfig) == VectorStorePgvector {
+			rt, err := newPgvectorRuntime(providerConfig, queries, conn, store)
+			if err != nil {
+				if log != nil {
+					log.Warn("pgvector runtime init failed, falling back to file runtime", slog.Any("error", err))
+				}
+				return fileRuntime, nil
+			}
+			go func() {
+				if err := rt.buildIndex(context.Background()); err != nil && log != nil {
+					log.Warn("pgvector index build failed", slog.Any("error", err))
+				}
+			}()
+			return rt, nil
+		}
 		rt, err := newDenseRuntime(providerConfig, queries, cfg, store)
 		if err != nil {
 			if log != nil {
@@ -69,6 +94,15 @@ func NewBuiltinRuntimeFromConfig(log *slog.Logger, providerConfig map[string]any
 	}
 }
 
+// DenseVectorStore returns the vector store configured for dense mode,
+// defaulting to Qdrant.
+func DenseVectorStore(providerConfig map[string]any) VectorStore {
+	if VectorStore(strings.TrimSpace(adapters.StringFromConfig(providerConfig, "vector_store"))) == VectorStorePgvector {
+		return VectorStorePgvector
+	}
+	return VectorStoreQdrant
+}
+
 // parseQdrantHostPort extracts host and gRPC port from a Qdrant base URL.
 // Qdrant base URLs are typically HTTP (port 6333), but the gRPC port is 6334.
 func parseQdrantHostPort(baseURL string) (string, int) {
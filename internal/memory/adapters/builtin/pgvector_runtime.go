@@ -0,0 +1,595 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/config"
+	dbsqlc "github.com/memohai/memoh/internal/db/sqlc"
+	adapters "github.com/memohai/memoh/internal/memory/adapters"
+	pgvectorclient "github.com/memohai/memoh/internal/memory/pgvector"
+	storefs "github.com/memohai/memoh/internal/memory/storefs"
+)
+
+const (
+	// pgvectorCandidateFactor widens each retriever's result list before
+	// fusion so items ranked well by only one of them can still surface.
+	pgvectorCandidateFactor = 4
+	// pgvectorRRFK is the reciprocal rank fusion constant.
+	pgvectorRRFK = 60
+	// pgvectorModelPayloadKey is the synthetic payload field Scroll uses to
+	// report the model that produced a stored vector.
+	pgvectorModelPayloadKey = "embedding_model"
+	// pgvectorScrollPageSize is the number of stored vectors read per page
+	// when diffing the index against the memory files.
+	pgvectorScrollPageSize = 1000
+)
+
+type denseEmbedder interface {
+	EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error)
+	EmbedQuery(ctx context.Context, text string) ([]float32, error)
+	Health(ctx context.Context) error
+}
+
+type pgvectorIndex interface {
+	TableName() string
+	TableExists(ctx context.Context) (bool, error)
+	EnsureTable(ctx context.Context) error
+	EnsureIndex(ctx context.Context) error
+	Upsert(ctx context.Context, id, botID string, vec []float32, payload map[string]string) error
+	SearchDense(ctx context.Context, vec []float32, botID string, scopeKeys []string, limit int) ([]pgvectorclient.SearchResult, error)
+	SearchText(ctx context.Context, query, botID string, scopeKeys []string, limit int) ([]pgvectorclient.SearchResult, error)
+	Scroll(ctx context.Context, botID, after string, limit int) ([]pgvectorclient.SearchResult, error)
+	Count(ctx context.Context, botID string) (int, error)
+	DeleteByIDs(ctx context.Context, ids []string) error
+	DeleteByBotID(ctx context.Context, botID string) error
+}
+
+// pgvectorRuntime implements memoryRuntime with markdown files as the source
+// of truth and a pgvector table in Postgres as the derived dense index.
+// Retrieval fuses cosine similarity with full-text ranking.
+type pgvectorRuntime struct {
+	index    pgvectorIndex
+	store    sparseMemoryStore
+	embedder denseEmbedder
+	model    string
+}
+
+func newPgvectorRuntime(providerConfig map[string]any, queries *dbsqlc.Queries, conn dbsqlc.DBTX, store *storefs.Service) (*pgvectorRuntime, error) {
+	if queries == nil || conn == nil {
+		return nil, errors.New("pgvector runtime: database is required")
+	}
+	if store == nil {
+		return nil, errors.New("pgvector runtime: memory store is required")
+	}
+	modelRef := strings.TrimSpace(adapters.StringFromConfig(providerConfig, "embedding_model_id"))
+	if modelRef == "" {
+		return nil, errors.New("pgvector runtime: embedding_model_id is required")
+	}
+	modelSpec, err := resolveDenseEmbeddingModel(context.Background(), queries, modelRef)
+	if err != nil {
+		return nil, err
+	}
+	index, err := pgvectorclient.NewClient(conn, modelSpec.modelID, modelSpec.dimensions)
+	if err != nil {
+		return nil, err
+	}
+	return &pgvectorRuntime{
+		index:    index,
+		store:    store,
+		embedder: newDenseEmbeddingClient(modelSpec),
+		model:    modelSpec.modelID,
+	}, nil
+}
+
+// buildIndex adds the HNSW index for the runtime's embedding size. It runs in
+// the background when the runtime is created; searches fall back to a
+// sequential scan until the index is ready.
+func (r *pgvectorRuntime) buildIndex(ctx context.Context) error {
+	if err := r.index.EnsureTable(ctx); err != nil {
+		return err
+	}
+	return r.index.EnsureIndex(ctx)
+}
+
+func (*pgvectorRuntime) Mode() string {
+	return string(ModeDense)
+}
+
+func (r *pgvectorRuntime) Add(ctx context.Context, req adapters.AddRequest) (adapters.SearchResponse, error) {
+	botID, err := sparseRuntimeBotID(req.BotID, req.Filters)
+	if err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	text := sparseRuntimeText(req.Message, req.Messages)
+	if text == "" {
+		return adapters.SearchResponse{}, errors.New("pgvector runtime: message is required")
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	item := adapters.MemoryItem{
+		ID:        sparseRuntimeMemoryID(botID, time.Now().UTC()),
+		Memory:    text,
+		Hash:      denseRuntimeHash(text),
+		Metadata:  req.Metadata,
+		BotID:     botID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := r.store.PersistMemories(ctx, botID, []storefs.MemoryItem{denseStoreItemFromMemoryItem(item)}, req.Filters); err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	if err := r.upsertSourceItems(ctx, botID, []storefs.MemoryItem{denseStoreItemFromMemoryItem(item)}); err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	return adapters.SearchResponse{Results: []adapters.MemoryItem{item}}, nil
+}
+
+func (r *pgvectorRuntime) Search(ctx context.Context, req adapters.SearchRequest) (adapters.SearchResponse, error) {
+	botID, err := sparseRuntimeBotID(req.BotID, req.Filters)
+	if err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	if err := r.index.EnsureTable(ctx); err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	scopeKeys := adapters.ScopeKeysFromFilters(req.Filters)
+	candidates := limit * pgvectorCandidateFactor
+
+	vec, err := r.embedder.EmbedQuery(ctx, req.Query)
+	if err != nil {
+		return adapters.SearchResponse{}, fmt.Errorf("dense embed query: %w", err)
+	}
+	dense, err := r.index.SearchDense(ctx, vec, botID, scopeKeys, candidates)
+	if err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	text, err := r.index.SearchText(ctx, req.Query, botID, scopeKeys, candidates)
+	if err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	fused := fusePgvectorResults(limit, dense, text)
+	items := make([]adapters.MemoryItem, 0, len(fused))
+	for _, result := range fused {
+		items = append(items, pgvectorResultToItem(result))
+	}
+	return adapters.SearchResponse{Results: items}, nil
+}
+
+func (r *pgvectorRuntime) GetAll(ctx context.Context, req adapters.GetAllRequest) (adapters.SearchResponse, error) {
+	botID, err := sparseRuntimeBotID(req.BotID, req.Filters)
+	if err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	items, err := r.store.ReadAllMemoryFiles(ctx, botID)
+	if err != nil {
+		return adapters.SearchResponse{}, err
+	}
+	result := make([]adapters.MemoryItem, 0, len(items))
+	for _, item := range items {
+		mem := denseMemoryItemFromStore(item)
+		mem.BotID = botID
+		result = append(result, mem)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UpdatedAt > result[j].UpdatedAt })
+	if req.Limit > 0 && len(result) > req.Limit {
+		result = result[:req.Limit]
+	}
+	return adapters.SearchResponse{Results: result}, nil
+}
+
+func (r *pgvectorRuntime) Update(ctx context.Context, req adapters.UpdateRequest) (adapters.MemoryItem, error) {
+	memoryID := strings.TrimSpace(req.MemoryID)
+	if memoryID == "" {
+		return adapters.MemoryItem{}, errors.New("pgvector runtime: memory_id is required")
+	}
+	text := strings.TrimSpace(req.Memory)
+	if text == "" {
+		return adapters.MemoryItem{}, errors.New("pgvector runtime: memory is required")
+	}
+	botID := sparseRuntimeBotIDFromMemoryID(memoryID)
+	if botID == "" {
+		return adapters.MemoryItem{}, errors.New("pgvector runtime: invalid memory_id")
+	}
+	items, err := r.store.ReadAllMemoryFiles(ctx, botID)
+	if err != nil {
+		return adapters.MemoryItem{}, err
+	}
+	var existing *storefs.MemoryItem
+	for i := range items {
+		if strings.TrimSpace(items[i].ID) == memoryID {
+			item := items[i]
+			existing = &item
+			break
+		}
+	}
+	if existing == nil {
+		return adapters.MemoryItem{}, errors.New("pgvector runtime: memory not found")
+	}
+	existing.Memory = text
+	existing.Hash = denseRuntimeHash(text)
+	existing.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := r.store.PersistMemories(ctx, botID, []storefs.MemoryItem{*existing}, nil); err != nil {
+		return adapters.MemoryItem{}, err
+	}
+	if err := r.upsertSourceItems(ctx, botID, []storefs.MemoryItem{*existing}); err != nil {
+		return adapters.MemoryItem{}, err
+	}
+	item := denseMemoryItemFromStore(*existing)
+	item.BotID = botID
+	return item, nil
+}
+
+func (r *pgvectorRuntime) Delete(ctx context.Context, memoryID string) (adapters.DeleteResponse, error) {
+	return r.DeleteBatch(ctx, []string{memoryID})
+}
+
+func (r *pgvectorRuntime) DeleteBatch(ctx context.Context, memoryIDs []string) (adapters.DeleteResponse, error) {
+	grouped := map[string][]string{}
+	pointIDs := make([]string, 0, len(memoryIDs))
+	for _, rawID := range memoryIDs {
+		memoryID := strings.TrimSpace(rawID)
+		if memoryID == "" {
+			continue
+		}
+		botID := sparseRuntimeBotIDFromMemoryID(memoryID)
+		if botID == "" {
+			continue
+		}
+		grouped[botID] = append(grouped[botID], memoryID)
+		pointIDs = append(pointIDs, sparsePointID(botID, memoryID))
+	}
+	for botID, ids := range grouped {
+		if err := r.store.RemoveMemories(ctx, botID, ids); err != nil {
+			return adapters.DeleteResponse{}, err
+		}
+	}
+	if err := r.index.EnsureTable(ctx); err != nil {
+		return adapters.DeleteResponse{}, err
+	}
+	if err := r.index.DeleteByIDs(ctx, pointIDs); err != nil {
+		return adapters.DeleteResponse{}, err
+	}
+	return adapters.DeleteResponse{Message: "Memories deleted successfully!"}, nil
+}
+
+func (r *pgvectorRuntime) DeleteAll(ctx context.Context, req adapters.DeleteAllRequest) (adapters.DeleteResponse, error) {
+	botID, err := sparseRuntimeBotID(req.BotID, req.Filters)
+	if err != nil {
+		return adapters.DeleteResponse{}, err
+	}
+	if err := r.store.RemoveAllMemories(ctx, botID); err != nil {
+		return adapters.DeleteResponse{}, err
+	}
+	if err := r.index.EnsureTable(ctx); err != nil {
+		return adapters.DeleteResponse{}, err
+	}
+	if err := r.index.DeleteByBotID(ctx, botID); err != nil {
+		return adapters.DeleteResponse{}, err
+	}
+	return adapters.DeleteResponse{Message: "All memories deleted successfully!"}, nil
+}
+
+func (r *pgvectorRuntime) Compact(ctx context.Context, filters map[string]any, ratio float64, _ int) (adapters.CompactResult, error) {
+	botID, err := sparseRuntimeBotID("", filters)
+	if err != nil {
+		return adapters.CompactResult{}, err
+	}
+	if ratio <= 0 || ratio > 1 {
+		return adapters.CompactResult{}, errors.New("ratio must be in range (0, 1]")
+	}
+	items, err := r.store.ReadAllMemoryFiles(ctx, botID)
+	if err != nil {
+		return adapters.CompactResult{}, err
+	}
+	before := len(items)
+	if before == 0 {
+		return adapters.CompactResult{BeforeCount: 0, AfterCount: 0, Ratio: ratio, Results: []adapters.MemoryItem{}}, nil
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].UpdatedAt > items[j].UpdatedAt })
+	target := int(float64(before) * ratio)
+	if target < 1 {
+		target = 1
+	}
+	if target > before {
+		target = before
+	}
+	keptStore := append([]storefs.MemoryItem(nil), items[:target]...)
+	if err := r.store.RebuildFiles(ctx, botID, keptStore, filters); err != nil {
+		return adapters.CompactResult{}, err
+	}
+	if _, err := r.Rebuild(ctx, botID); err != nil {
+		return adapters.CompactResult{}, err
+	}
+	kept := make([]adapters.MemoryItem, 0, len(keptStore))
+	for _, item := range keptStore {
+		kept = append(kept, denseMemoryItemFromStore(item))
+	}
+	return adapters.CompactResult{
+		BeforeCount: before,
+		AfterCount:  len(kept),
+		Ratio:       ratio,
+		Results:     kept,
+	}, nil
+}
+
+func (r *pgvectorRuntime) Usage(ctx context.Context, filters map[string]any) (adapters.UsageResponse, error) {
+	botID, err := sparseRuntimeBotID("", filters)
+	if err != nil {
+		return adapters.UsageResponse{}, err
+	}
+	items, err := r.store.ReadAllMemoryFiles(ctx, botID)
+	if err != nil {
+		return adapters.UsageResponse{}, err
+	}
+	var usage adapters.UsageResponse
+	usage.Count = len(items)
+	for _, item := range items {
+		usage.TotalTextBytes += int64(len(item.Memory))
+	}
+	if usage.Count > 0 {
+		usage.AvgTextBytes = usage.TotalTextBytes / int64(usage.Count)
+	}
+	usage.EstimatedStorageBytes = usage.TotalTextBytes
+	return usage, nil
+}
+
+func (r *pgvectorRuntime) Status(ctx context.Context, botID string) (adapters.MemoryStatusResponse, error) {
+	fileCount, err := r.store.CountMemoryFiles(ctx, botID)
+	if err != nil {
+		return adapters.MemoryStatusResponse{}, err
+	}
+	items, err := r.store.ReadAllMemoryFiles(ctx, botID)
+	if err != nil {
+		return adapters.MemoryStatusResponse{}, err
+	}
+	status := adapters.MemoryStatusResponse{
+		ProviderType:      BuiltinType,
+		MemoryMode:        string(ModeDense),
+		VectorStore:       string(VectorStorePgvector),
+		CanManualSync:     true,
+		SourceDir:         path.Join(config.DefaultDataMount, "memory"),
+		OverviewPath:      path.Join(config.DefaultDataMount, "MEMORY.md"),
+		MarkdownFileCount: fileCount,
+		SourceCount:       len(items),
+		PgvectorTable:     r.index.TableName(),
+	}
+	if err := r.embedder.Health(ctx); err != nil {
+		status.Encoder.Error = err.Error()
+	} else {
+		status.Encoder.OK = true
+	}
+	exists, err := r.index.TableExists(ctx)
+	if err != nil {
+		status.Pgvector.Error = err.Error()
+		return status, nil
+	}
+	status.Pgvector.OK = true
+	if exists {
+		count, err := r.index.Count(ctx, botID)
+		if err != nil {
+			status.Pgvector.OK = false
+			status.Pgvector.Error = err.Error()
+			return status, nil
+		}
+		status.IndexedCount = count
+	}
+	return status, nil
+}
+
+func (r *pgvectorRuntime) Rebuild(ctx context.Context, botID string) (adapters.RebuildResult, error) {
+	items, err := r.store.ReadAllMemoryFiles(ctx, botID)
+	if err != nil {
+		return adapters.RebuildResult{}, err
+	}
+	if err := r.store.SyncOverview(ctx, botID); err != nil {
+		return adapters.RebuildResult{}, err
+	}
+	return r.syncSourceItems(ctx, botID, items)
+}
+
+// --- helpers ---
+
+// pgvectorScrollAll reads every stored vector for a bot, one keyset page at
+// a time.
+func pgvectorScrollAll(ctx context.Context, index pgvectorIndex, botID string, pageSize int) ([]pgvectorclient.SearchResult, error) {
+	var (
+		all   []pgvectorclient.SearchResult
+		after string
+	)
+	for {
+		page, err := index.Scroll(ctx, botID, after, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < pageSize {
+			return all, nil
+		}
+		after = page[len(page)-1].ID
+	}
+}
+
+func (r *pgvectorRuntime) syncSourceItems(ctx context.Context, botID string, items []storefs.MemoryItem) (adapters.RebuildResult, error) {
+	if err := r.index.EnsureTable(ctx); err != nil {
+		return adapters.RebuildResult{}, err
+	}
+	existing, err := pgvectorScrollAll(ctx, r.index, botID, pgvectorScrollPageSize)
+	if err != nil {
+		return adapters.RebuildResult{}, err
+	}
+	existingBySource := make(map[string]pgvectorclient.SearchResult, len(existing))
+	for _, item := range existing {
+		sourceID := pgvectorSourceID(item)
+		if sourceID != "" {
+			existingBySource[sourceID] = item
+		}
+	}
+	sourceIDs := make(map[string]struct{}, len(items))
+	toUpsert := make([]storefs.MemoryItem, 0, len(items))
+	missingCount := 0
+	restoredCount := 0
+	for _, item := range items {
+		item = denseCanonicalStoreItem(item)
+		if item.ID == "" || item.Memory == "" {
+			continue
+		}
+		sourceIDs[item.ID] = struct{}{}
+		existingItem, ok := existingBySource[item.ID]
+		if !ok {
+			missingCount++
+			restoredCount++
+			toUpsert = append(toUpsert, item)
+			continue
+		}
+		// Vectors from a previous embedding model are re-embedded too.
+		expected := densePayload(botID, item)
+		expected[pgvectorModelPayloadKey] = r.model
+		if !densePayloadMatches(existingItem.Payload, expected) {
+			restoredCount++
+			toUpsert = append(toUpsert, item)
+		}
+	}
+	stale := make([]string, 0)
+	for _, item := range existing {
+		if _, ok := sourceIDs[pgvectorSourceID(item)]; ok {
+			continue
+		}
+		if strings.TrimSpace(item.ID) != "" {
+			stale = append(stale, item.ID)
+		}
+	}
+	if err := r.index.DeleteByIDs(ctx, stale); err != nil {
+		return adapters.RebuildResult{}, err
+	}
+	if err := r.upsertSourceItems(ctx, botID, toUpsert); err != nil {
+		return adapters.RebuildResult{}, err
+	}
+	count, err := r.index.Count(ctx, botID)
+	if err != nil {
+		return adapters.RebuildResult{}, err
+	}
+	return adapters.RebuildResult{
+		FsCount:       len(items),
+		StorageCount:  count,
+		MissingCount:  missingCount,
+		RestoredCount: restoredCount,
+	}, nil
+}
+
+func (r *pgvectorRuntime) upsertSourceItems(ctx context.Context, botID string, items []storefs.MemoryItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if err := r.index.EnsureTable(ctx); err != nil {
+		return err
+	}
+	canonical := make([]storefs.MemoryItem, 0, len(items))
+	texts := make([]string, 0, len(items))
+	for _, item := range items {
+		item = denseCanonicalStoreItem(item)
+		if item.ID == "" || item.Memory == "" {
+			continue
+		}
+		canonical = append(canonical, item)
+		texts = append(texts, item.Memory)
+	}
+	if len(canonical) == 0 {
+		return nil
+	}
+	vectors, err := r.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("dense embed documents: %w", err)
+	}
+	if len(vectors) != len(canonical) {
+		return fmt.Errorf("dense embed documents: expected %d vectors, got %d", len(canonical), len(vectors))
+	}
+	for i, item := range canonical {
+		if err := r.index.Upsert(ctx, sparsePointID(botID, item.ID), botID, vectors[i], densePayload(botID, item)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fusePgvectorResults merges ranked result lists with reciprocal rank fusion
+// and returns the top limit results. Scores are normalized so a result
+// ranked first by every list scores 1.
+func fusePgvectorResults(limit int, lists ...[]pgvectorclient.SearchResult) []pgvectorclient.SearchResult {
+	if len(lists) == 0 {
+		return nil
+	}
+	type fusedResult struct {
+		result pgvectorclient.SearchResult
+		score  float64
+		order  int
+	}
+	byID := map[string]*fusedResult{}
+	for _, list := range lists {
+		for rank, result := range list {
+			entry, ok := byID[result.ID]
+			if !ok {
+				entry = &fusedResult{result: result, order: len(byID)}
+				byID[result.ID] = entry
+			}
+			entry.score += 1 / float64(pgvectorRRFK+rank+1)
+		}
+	}
+	fused := make([]*fusedResult, 0, len(byID))
+	for _, entry := range byID {
+		fused = append(fused, entry)
+	}
+	sort.Slice(fused, func(i, j int) bool {
+		if fused[i].score != fused[j].score {
+			return fused[i].score > fused[j].score
+		}
+		return fused[i].order < fused[j].order
+	})
+	if limit > 0 && len(fused) > limit {
+		fused = fused[:limit]
+	}
+	maxScore := float64(len(lists)) / float64(pgvectorRRFK+1)
+	out := make([]pgvectorclient.SearchResult, 0, len(fused))
+	for _, entry := range fused {
+		result := entry.result
+		result.Score = entry.score / maxScore
+		out = append(out, result)
+	}
+	return out
+}
+
+func pgvectorSourceID(result pgvectorclient.SearchResult) string {
+	if sourceID := strings.TrimSpace(result.Payload["source_entry_id"]); sourceID != "" {
+		return sourceID
+	}
+	return strings.TrimSpace(result.ID)
+}
+
+func pgvectorResultToItem(r pgvectorclient.SearchResult) adapters.MemoryItem {
+	item := adapters.MemoryItem{
+		ID:    r.ID,
+		Score: r.Score,
+	}
+	if r.Payload != nil {
+		if sourceID := strings.TrimSpace(r.Payload["source_entry_id"]); sourceID != "" {
+			item.ID = sourceID
+		}
+		item.Memory = r.Payload["memory"]
+		item.Hash = r.Payload["hash"]
+		item.BotID = r.Payload["bot_id"]
+		item.CreatedAt = r.Payload["created_at"]
+		item.UpdatedAt = r.Payload["updated_at"]
+		if key := strings.TrimSpace(r.Payload[adapters.MetadataScopeKey]); key != "" {
+			item.Metadata = map[string]any{adapters.MetadataScopeKey: key}
+		}
+	}
+	return item
+}
@@ -0,0 +1,354 @@
+package builtin
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+
+	adapters "github.com/memohai/memoh/internal/memory/adapters"
+	pgvectorclient "github.com/memohai/memoh/internal/memory/pgvector"
+	storefs "github.com/memohai/memoh/internal/memory/storefs"
+)
+
+// fakeDenseEmbedder embeds text as keyword indicators so cosine similarity
+// is predictable in tests.
+type fakeDenseEmbedder struct {
+	keywords []string
+	embedded int
+}
+
+func (e *fakeDenseEmbedder) embed(text string) []float32 {
+	text = strings.ToLower(text)
+	vec := make([]float32, len(e.keywords))
+	for i, keyword := range e.keywords {
+		if strings.Contains(text, keyword) {
+			vec[i] = 1
+		}
+	}
+	return vec
+}
+
+func (e *fakeDenseEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		out = append(out, e.embed(text))
+	}
+	e.embedded += len(texts)
+	return out, nil
+}
+
+func (e *fakeDenseEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return e.embed(text), nil
+}
+
+func (*fakeDenseEmbedder) Health(context.Context) error { return nil }
+
+type fakePgvectorRow struct {
+	botID   string
+	model   string
+	vec     []float32
+	payload map[string]string
+}
+
+type fakePgvectorIndex struct {
+	model  string
+	exists bool
+	rows   map[string]fakePgvectorRow
+}
+
+func newFakePgvectorIndex(model string) *fakePgvectorIndex {
+	return &fakePgvectorIndex{model: model, rows: map[string]fakePgvectorRow{}}
+}
+
+func (*fakePgvectorIndex) TableName() string { return pgvectorclient.TableName }
+
+func (i *fakePgvectorIndex) TableExists(context.Context) (bool, error) { return i.exists, nil }
+
+func (i *fakePgvectorIndex) EnsureTable(context.Context) error {
+	i.exists = true
+	return nil
+}
+
+func (*fakePgvectorIndex) EnsureIndex(context.Context) error { return nil }
+
+func (i *fakePgvectorIndex) Upsert(_ context.Context, id, botID string, vec []float32, payload map[string]string) error {
+	i.rows[id] = fakePgvectorRow{botID: botID, model: i.model, vec: vec, payload: payload}
+	return nil
+}
+
+func (i *fakePgvectorIndex) search(botID string, scopeKeys []string, limit int, score func(fakePgvectorRow) float64) []pgvectorclient.SearchResult {
+	results := make([]pgvectorclient.SearchResult, 0, len(i.rows))
+	for id, row := range i.rows {
+		if row.botID != botID || row.model != i.model {
+			continue
+		}
+		if !adapters.ScopeKeysAllow(scopeKeys, row.payload[adapters.MetadataScopeKey]) {
+			continue
+		}
+		s := score(row)
+		if s <= 0 {
+			continue
+		}
+		results = append(results, pgvectorclient.SearchResult{ID: id, Score: s, Payload: row.payload})
+	}
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].ID < results[b].ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func (i *fakePgvectorIndex) SearchDense(_ context.Context, vec []float32, botID string, scopeKeys []string, limit int) ([]pgvectorclient.SearchResult, error) {
+	return i.search(botID, scopeKeys, limit, func(row fakePgvectorRow) float64 {
+		var dot, a, b float64
+		for k := range vec {
+			dot += float64(vec[k] * row.vec[k])
+			a += float64(vec[k] * vec[k])
+			b += float64(row.vec[k] * row.vec[k])
+		}
+		if a == 0 || b == 0 {
+			return 0
+		}
+		return dot / math.Sqrt(a*b)
+	}), nil
+}
+
+func (i *fakePgvectorIndex) SearchText(_ context.Context, query, botID string, scopeKeys []string, limit int) ([]pgvectorclient.SearchResult, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	return i.search(botID, scopeKeys, limit, func(row fakePgvectorRow) float64 {
+		text := strings.ToLower(row.payload["memory"])
+		matched := 0
+		for _, term := range terms {
+			if strings.Contains(text, term) {
+				matched++
+			}
+		}
+		return float64(matched)
+	}), nil
+}
+
+func (i *fakePgvectorIndex) Scroll(_ context.Context, botID, after string, limit int) ([]pgvectorclient.SearchResult, error) {
+	results := make([]pgvectorclient.SearchResult, 0, len(i.rows))
+	for id, row := range i.rows {
+		if row.botID != botID || id <= after {
+			continue
+		}
+		payload := map[string]string{pgvectorModelPayloadKey: row.model}
+		for k, v := range row.payload {
+			payload[k] = v
+		}
+		results = append(results, pgvectorclient.SearchResult{ID: id, Payload: payload})
+	}
+	sort.Slice(results, func(a, b int) bool { return results[a].ID < results[b].ID })
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func (i *fakePgvectorIndex) Count(_ context.Context, botID string) (int, error) {
+	count := 0
+	for _, row := range i.rows {
+		if row.botID == botID && row.model == i.model {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (i *fakePgvectorIndex) DeleteByIDs(_ context.Context, ids []string) error {
+	for _, id := range ids {
+		delete(i.rows, id)
+	}
+	return nil
+}
+
+func (i *fakePgvectorIndex) DeleteByBotID(_ context.Context, botID string) error {
+	for id, row := range i.rows {
+		if row.botID == botID {
+			delete(i.rows, id)
+		}
+	}
+	return nil
+}
+
+func TestFusePgvectorResults(t *testing.T) {
+	t.Parallel()
+
+	dense := []pgvectorclient.SearchResult{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	text := []pgvectorclient.SearchResult{{ID: "b"}, {ID: "d"}}
+
+	fused := fusePgvectorResults(3, dense, text)
+	if len(fused) != 3 {
+		t.Fatalf("expected 3 fused results, got %d", len(fused))
+	}
+	if fused[0].ID != "b" {
+		t.Fatalf("expected the result found by both retrievers first, got %q", fused[0].ID)
+	}
+	if fused[1].ID != "a" {
+		t.Fatalf("expected the top dense result second, got %q", fused[1].ID)
+	}
+	if fused[0].Score <= fused[1].Score || fused[0].Score > 1 {
+		t.Fatalf("unexpected fused scores: %v, %v", fused[0].Score, fused[1].Score)
+	}
+
+	top := fusePgvectorResults(1, []pgvectorclient.SearchResult{{ID: "x"}}, []pgvectorclient.SearchResult{{ID: "x"}})
+	if len(top) != 1 || top[0].Score != 1 {
+		t.Fatalf("expected a unanimous top result to score 1, got %#v", top)
+	}
+}
+
+func TestPgvectorRuntimeSearchFusesDenseAndKeywordResults(t *testing.T) {
+	t.Parallel()
+
+	embedder := &fakeDenseEmbedder{keywords: []string{"tea", "berlin"}}
+	index := newFakePgvectorIndex("embed-small")
+	runtime := &pgvectorRuntime{
+		index:    index,
+		store:    newFakeSparseStore(),
+		embedder: embedder,
+		model:    "embed-small",
+	}
+
+	alice := adapters.Scope{Policy: adapters.ScopePolicyPerUser, UserID: "alice"}
+	bob := adapters.Scope{Policy: adapters.ScopePolicyPerUser, UserID: "bob"}
+	for _, add := range []struct {
+		scope adapters.Scope
+		text  string
+	}{
+		{alice, "Alice drinks oolong tea"},
+		{bob, "Bob drinks green tea"},
+		{adapters.Scope{}, "The office is in Berlin"},
+		{alice, "Alice prefers the oolong from Taiwan"},
+	} {
+		if _, err := runtime.Add(context.Background(), adapters.AddRequest{
+			BotID:    "bot-1",
+			Message:  add.text,
+			Metadata: add.scope.ApplyMetadata(nil),
+		}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if len(index.rows) != 4 {
+		t.Fatalf("expected 4 indexed rows, got %d", len(index.rows))
+	}
+
+	resp, err := runtime.Search(context.Background(), adapters.SearchRequest{
+		BotID:   "bot-1",
+		Query:   "oolong tea",
+		Limit:   5,
+		Filters: alice.ApplyFilters(nil),
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("expected alice's two oolong memories, got %#v", resp.Results)
+	}
+	if resp.Results[0].Memory != "Alice drinks oolong tea" {
+		t.Fatalf("expected the dense and keyword match first, got %q", resp.Results[0].Memory)
+	}
+	// Only the keyword retriever finds this one; fusion still surfaces it.
+	if resp.Results[1].Memory != "Alice prefers the oolong from Taiwan" {
+		t.Fatalf("expected the keyword-only match second, got %q", resp.Results[1].Memory)
+	}
+	for _, item := range resp.Results {
+		if strings.Contains(item.Memory, "Bob") {
+			t.Fatalf("alice must not recall bob's memory: %q", item.Memory)
+		}
+	}
+}
+
+func TestPgvectorRuntimeRebuildReembedsAndRemovesStaleRows(t *testing.T) {
+	t.Parallel()
+
+	embedder := &fakeDenseEmbedder{keywords: []string{"tea", "berlin"}}
+	index := newFakePgvectorIndex("embed-small")
+	store := newFakeSparseStore(
+		storefs.MemoryItem{
+			ID:        "bot-1:mem_1",
+			Memory:    "Ran likes tea",
+			CreatedAt: "2026-03-13T09:00:00Z",
+			UpdatedAt: "2026-03-13T09:00:00Z",
+		},
+		storefs.MemoryItem{
+			ID:        "bot-1:mem_2",
+			Memory:    "Ran works in Berlin",
+			CreatedAt: "2026-03-13T10:00:00Z",
+			UpdatedAt: "2026-03-13T10:00:00Z",
+		},
+		storefs.MemoryItem{
+			ID:        "bot-1:mem_3",
+			Memory:    "Ran owns a bike",
+			CreatedAt: "2026-03-13T11:00:00Z",
+			UpdatedAt: "2026-03-13T11:00:00Z",
+		},
+	)
+	runtime := &pgvectorRuntime{
+		index:    index,
+		store:    store,
+		embedder: embedder,
+		model:    "embed-small",
+	}
+
+	current := denseCanonicalStoreItem(store.items["bot-1:mem_1"])
+	index.rows[sparsePointID("bot-1", current.ID)] = fakePgvectorRow{
+		botID: "bot-1", model: "embed-small", vec: []float32{1, 0}, payload: densePayload("bot-1", current),
+	}
+	// Embedded by a model the provider no longer uses.
+	previous := denseCanonicalStoreItem(store.items["bot-1:mem_2"])
+	index.rows[sparsePointID("bot-1", previous.ID)] = fakePgvectorRow{
+		botID: "bot-1", model: "embed-old", vec: []float32{0, 1}, payload: densePayload("bot-1", previous),
+	}
+	index.rows[sparsePointID("bot-1", "bot-1:stale")] = fakePgvectorRow{
+		botID: "bot-1", model: "embed-small", vec: []float32{1, 1},
+		payload: map[string]string{"bot_id": "bot-1", "memory": "stale", "source_entry_id": "bot-1:stale"},
+	}
+
+	result, err := runtime.Rebuild(context.Background(), "bot-1")
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if result.FsCount != 3 || result.StorageCount != 3 {
+		t.Fatalf("expected fs_count=3 and storage_count=3, got %#v", result)
+	}
+	if result.MissingCount != 1 || result.RestoredCount != 2 {
+		t.Fatalf("expected missing_count=1 and restored_count=2, got %#v", result)
+	}
+	if embedder.embedded != 2 {
+		t.Fatalf("expected only the missing and outdated memories to be embedded, got %d", embedder.embedded)
+	}
+	if row := index.rows[sparsePointID("bot-1", previous.ID)]; row.model != "embed-small" {
+		t.Fatalf("expected outdated vector to be re-embedded, got model %q", row.model)
+	}
+	if _, ok := index.rows[sparsePointID("bot-1", "bot-1:stale")]; ok {
+		t.Fatal("expected stale row to be removed")
+	}
+}
+
+func TestPgvectorScrollAllPagesThroughEveryRow(t *testing.T) {
+	t.Parallel()
+
+	index := newFakePgvectorIndex("embed-small")
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		index.rows[id] = fakePgvectorRow{botID: "bot-1", model: "embed-small"}
+	}
+	index.rows["x"] = fakePgvectorRow{botID: "bot-2", model: "embed-small"}
+
+	for _, pageSize := range []int{1, 2, 5, 10} {
+		rows, err := pgvectorScrollAll(context.Background(), index, "bot-1", pageSize)
+		if err != nil {
+			t.Fatalf("pgvectorScrollAll(%d) error = %v", pageSize, err)
+		}
+		if len(rows) != 5 {
+			t.Fatalf("page size %d: expected 5 rows, got %d", pageSize, len(rows))
+		}
+	}
+}
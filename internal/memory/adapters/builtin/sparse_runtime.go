@@ -33,7 +33,7 @@ type sparseIndex interface {
 	CollectionExists(ctx context.Context) (bool, error)
 	EnsureCollection(ctx context.Context) error
 	Upsert(ctx context.Context, id string, vec qdrantclient.SparseVector, payload map[string]string) error
-	Search(ctx context.Context, vec qdrantclient.SparseVector, botID string, limit int) ([]qdrantclient.SearchResult, error)
+	Search(ctx context.Context, vec qdrantclient.SparseVector, botID string, scopeKeys []string, limit int) ([]qdrantclient.SearchResult, error)
 	Scroll(ctx context.Context, botID string, limit int) ([]qdrantclient.SearchResult, error)
 	Count(ctx context.Context, botID string) (int, error)
 	DeleteByIDs(ctx context.Context, ids []string) error
@@ -141,7 +141,7 @@ func (r *sparseRuntime) Search(ctx context.Context, req adapters.SearchRequest)
 	results, err := r.qdrant.Search(ctx, qdrantclient.SparseVector{
 		Indices: vec.Indices,
 		Values:  vec.Values,
-	}, botID, limit)
+	}, botID, adapters.ScopeKeysFromFilters(req.Filters), limit)
 	if err != nil {
 		return adapters.SearchResponse{}, err
 	}
@@ -516,6 +516,9 @@ func sparseResultToItem(r qdrantclient.SearchResult) adapters.MemoryItem {
 		item.BotID = r.Payload["bot_id"]
 		item.CreatedAt = r.Payload["created_at"]
 		item.UpdatedAt = r.Payload["updated_at"]
+		if key := strings.TrimSpace(r.Payload[adapters.MetadataScopeKey]); key != "" {
+			item.Metadata = map[string]any{adapters.MetadataScopeKey: key}
+		}
 	}
 	return item
 }
@@ -632,6 +635,9 @@ func sparsePayload(botID string, item storefs.MemoryItem) map[string]string {
 	if item.UpdatedAt != "" {
 		payload["updated_at"] = item.UpdatedAt
 	}
+	if key := adapters.ScopeKeyFromMetadata(item.Metadata); key != "" {
+		payload[adapters.MetadataScopeKey] = key
+	}
 	return payload
 }
 
@@ -128,13 +128,16 @@ func (i *fakeSparseIndex) Upsert(_ context.Context, id string, _ qdrantclient.Sp
 	return nil
 }
 
-func (i *fakeSparseIndex) Search(_ context.Context, _ qdrantclient.SparseVector, botID string, limit int) ([]qdrantclient.SearchResult, error) {
+func (i *fakeSparseIndex) Search(_ context.Context, _ qdrantclient.SparseVector, botID string, scopeKeys []string, limit int) ([]qdrantclient.SearchResult, error) {
 	query := strings.ToLower(strings.TrimSpace(i.encoder.lastQuery))
 	results := make([]qdrantclient.SearchResult, 0, len(i.points))
 	for _, point := range i.points {
 		if strings.TrimSpace(point.Payload["bot_id"]) != strings.TrimSpace(botID) {
 			continue
 		}
+		if !adapters.ScopeKeysAllow(scopeKeys, point.Payload[adapters.MetadataScopeKey]) {
+			continue
+		}
 		text := strings.ToLower(point.Payload["memory"])
 		if query != "" && !strings.Contains(text, query) {
 			continue
@@ -249,6 +252,63 @@ func TestSparseRuntimeAddWritesSourceAndSupportsRecall(t *testing.T) {
 	}
 }
 
+func TestSparseRuntimeSearchHonorsMemoryScope(t *testing.T) {
+	t.Parallel()
+
+	encoder := &fakeSparseEncoder{}
+	index := newFakeSparseIndex(encoder)
+	store := newFakeSparseStore()
+	runtime := &sparseRuntime{
+		qdrant:  index,
+		encoder: encoder,
+		store:   store,
+	}
+
+	alice := adapters.Scope{Policy: adapters.ScopePolicyPerUser, UserID: "alice"}
+	bob := adapters.Scope{Policy: adapters.ScopePolicyPerUser, UserID: "bob"}
+	for _, add := range []struct {
+		scope adapters.Scope
+		text  string
+	}{
+		{alice, "Alice keeps her tea preference private"},
+		{bob, "Bob keeps his tea preference private"},
+		{adapters.Scope{}, "The team tea budget is shared"},
+	} {
+		if _, err := runtime.Add(context.Background(), adapters.AddRequest{
+			BotID:    "bot-1",
+			Message:  add.text,
+			Metadata: add.scope.ApplyMetadata(nil),
+		}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	resp, err := runtime.Search(context.Background(), adapters.SearchRequest{
+		BotID:   "bot-1",
+		Query:   "tea",
+		Filters: alice.ApplyFilters(nil),
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("expected alice to recall her memory and the shared one, got %#v", resp.Results)
+	}
+	for _, item := range resp.Results {
+		if strings.Contains(item.Memory, "Bob") {
+			t.Fatalf("alice must not recall bob's memory: %q", item.Memory)
+		}
+	}
+
+	resp, err = runtime.Search(context.Background(), adapters.SearchRequest{BotID: "bot-1", Query: "tea"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected unscoped search to see all memories, got %d", len(resp.Results))
+	}
+}
+
 func TestSparseRuntimeRebuildSyncsSourceAndRemovesStalePoints(t *testing.T) {
 	t.Parallel()
 
@@ -62,7 +62,7 @@ func (p *Mem0Provider) OnBeforeChat(ctx context.Context, req adapters.BeforeChat
 	if query == "" || botID == "" {
 		return nil, nil
 	}
-	memories, err := p.searchMemories(ctx, query, botID, mem0ContextMaxItems)
+	memories, err := p.searchMemories(ctx, query, botID, req.Scope.ReadKeys(), mem0ContextMaxItems)
 	if err != nil {
 		p.logger.Warn("mem0 search for context failed", slog.Any("error", err))
 		return nil, nil
@@ -97,6 +97,7 @@ func (p *Mem0Provider) OnAfterChat(ctx context.Context, req adapters.AfterChatRe
 	_, err := p.client.Add(ctx, mem0AddRequest{
 		Messages: req.Messages,
 		AgentID:  botID,
+		Metadata: req.Scope.ApplyMetadata(nil),
 	})
 	if err != nil {
 		p.logger.Warn("mem0 store memory failed", slog.String("bot_id", botID), slog.Any("error", err))
@@ -154,7 +155,7 @@ func (p *Mem0Provider) CallTool(ctx context.Context, session mcp.ToolSessionCont
 		limit = mem0MaxLimit
 	}
 
-	memories, err := p.searchMemories(ctx, query, botID, limit)
+	memories, err := p.searchMemories(ctx, query, botID, adapters.ScopeFromSession(session).ReadKeys(), limit)
 	if err != nil {
 		return mcp.BuildToolErrorResult("memory search failed"), nil
 	}
@@ -438,10 +439,12 @@ func (p *Mem0Provider) syncSourceItems(ctx context.Context, botID string, items
 	}, nil
 }
 
-func (p *Mem0Provider) searchMemories(ctx context.Context, query, agentID string, limit int) ([]adapters.MemoryItem, error) {
+// searchMemories searches an agent's memories and keeps those within
+// scopeKeys (nil keeps everything).
+func (p *Mem0Provider) searchMemories(ctx context.Context, query, agentID string, scopeKeys []string, limit int) ([]adapters.MemoryItem, error) {
 	memories, err := p.client.Search(ctx, mem0SearchRequest{
 		Query:   query,
-		TopK:    limit,
+		TopK:    adapters.ScopedFetchLimit(scopeKeys, limit),
 		Filters: mem0AgentFilter(agentID, ""),
 	})
 	if err != nil {
@@ -449,7 +452,7 @@ func (p *Mem0Provider) searchMemories(ctx context.Context, query, agentID string
 	}
 	items := mem0ToItems(memories)
 	sort.Slice(items, func(i, j int) bool { return items[i].Score > items[j].Score })
-	return adapters.DeduplicateItems(items), nil
+	return adapters.FilterItemsByScope(adapters.DeduplicateItems(items), scopeKeys, limit), nil
 }
 
 func mem0ScopeID(botID, agentID string) string {
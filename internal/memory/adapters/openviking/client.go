@@ -46,8 +46,9 @@ type ovMemory struct {
 }
 
 type ovAddRequest struct {
-	AgentID string `json:"agent_id"`
-	Content string `json:"content"`
+	AgentID  string         `json:"agent_id"`
+	Content  string         `json:"content"`
+	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 type ovSearchRequest struct {
@@ -60,11 +61,12 @@ type ovUpdateRequest struct {
 	Content string `json:"content"`
 }
 
-func (c *openVikingClient) Add(ctx context.Context, agentID, content string) (*ovMemory, error) {
+func (c *openVikingClient) Add(ctx context.Context, agentID, content string, metadata map[string]any) (*ovMemory, error) {
 	var result ovMemory
 	if err := c.doJSON(ctx, http.MethodPost, "/memories", ovAddRequest{
-		AgentID: agentID,
-		Content: content,
+		AgentID:  agentID,
+		Content:  content,
+		Metadata: metadata,
 	}, &result); err != nil {
 		return nil, fmt.Errorf("openviking add: %w", err)
 	}
@@ -51,7 +51,7 @@ func (p *OpenVikingProvider) OnBeforeChat(ctx context.Context, req adapters.Befo
 	if query == "" || botID == "" {
 		return nil, nil
 	}
-	memories, err := p.client.Search(ctx, botID, query, ovContextMaxItems)
+	memories, err := p.searchMemories(ctx, botID, query, req.Scope.ReadKeys(), ovContextMaxItems)
 	if err != nil {
 		p.logger.Warn("openviking search for context failed", slog.Any("error", err))
 		return nil, nil
@@ -98,7 +98,7 @@ func (p *OpenVikingProvider) OnAfterChat(ctx context.Context, req adapters.After
 	if len(parts) == 0 {
 		return nil
 	}
-	_, err := p.client.Add(ctx, botID, strings.Join(parts, "\n"))
+	_, err := p.client.Add(ctx, botID, strings.Join(parts, "\n"), req.Scope.ApplyMetadata(nil))
 	if err != nil {
 		p.logger.Warn("openviking store memory failed", slog.String("bot_id", botID), slog.Any("error", err))
 	}
@@ -154,7 +154,7 @@ func (p *OpenVikingProvider) CallTool(ctx context.Context, session mcp.ToolSessi
 	if limit > ovMaxLimit {
 		limit = ovMaxLimit
 	}
-	memories, err := p.client.Search(ctx, botID, query, limit)
+	memories, err := p.searchMemories(ctx, botID, query, adapters.ScopeFromSession(session).ReadKeys(), limit)
 	if err != nil {
 		return mcp.BuildToolErrorResult("memory search failed"), nil
 	}
@@ -199,7 +199,7 @@ func (p *OpenVikingProvider) Add(ctx context.Context, req adapters.AddRequest) (
 	if text == "" {
 		return adapters.SearchResponse{}, errors.New("message is required")
 	}
-	mem, err := p.client.Add(ctx, botID, text)
+	mem, err := p.client.Add(ctx, botID, text, req.Metadata)
 	if err != nil {
 		return adapters.SearchResponse{}, err
 	}
@@ -289,6 +289,26 @@ func (*OpenVikingProvider) Usage(_ context.Context, _ map[string]any) (adapters.
 
 // --- helpers ---
 
+// searchMemories searches a bot's memories and keeps those within scopeKeys
+// (nil keeps everything).
+func (p *OpenVikingProvider) searchMemories(ctx context.Context, botID, query string, scopeKeys []string, limit int) ([]ovMemory, error) {
+	memories, err := p.client.Search(ctx, botID, query, adapters.ScopedFetchLimit(scopeKeys, limit))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ovMemory, 0, len(memories))
+	for _, mem := range memories {
+		if !adapters.ScopeKeysAllow(scopeKeys, adapters.ScopeKeyFromMetadata(mem.Metadata)) {
+			continue
+		}
+		out = append(out, mem)
+		if len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
 func ovToItems(memories []ovMemory) []adapters.MemoryItem {
 	items := make([]adapters.MemoryItem, 0, len(memories))
 	for _, m := range memories {
@@ -0,0 +1,197 @@
+package adapters
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/memohai/memoh/internal/mcp"
+)
+
+// Memory scope policies, mirrored from the bot settings.
+const (
+	ScopePolicyShared  = "shared"
+	ScopePolicyPerUser = "per_user"
+	ScopePolicyPrivate = "private"
+)
+
+const (
+	// MetadataScopeKey is the memory metadata (and index payload) field that
+	// records who a memory belongs to.
+	MetadataScopeKey = "memory_scope_key"
+	// FilterScopeKeys is the search filter holding the scope keys a caller
+	// may recall, as a []string. Absent means no restriction.
+	FilterScopeKeys = "memory_scope_keys"
+
+	// ScopeKeyBot marks bot-global memories visible to everyone.
+	ScopeKeyBot = "bot"
+
+	// scopeOverfetchFactor widens searches against backends that can only be
+	// filtered after retrieval, so scoped results still fill the limit.
+	scopeOverfetchFactor = 3
+)
+
+// Scope describes who is talking to the bot and under which privacy policy.
+// It decides where a new memory is filed and which memories may be
+// recalled. The zero value is unrestricted, which is what owner-facing
+// management APIs use.
+type Scope struct {
+	Policy            string
+	UserID            string
+	ChannelIdentityID string
+	ConversationID    string
+	// Group is set for group chats and threads; direct chats leave it false.
+	Group bool
+}
+
+// ScopeFromSession builds the scope of a memory tool call.
+func ScopeFromSession(session mcp.ToolSessionContext) Scope {
+	return Scope{
+		Policy:            session.MemoryScopePolicy,
+		UserID:            session.UserID,
+		ChannelIdentityID: session.ChannelIdentityID,
+		ConversationID:    session.ConversationID,
+		Group:             session.GroupConversation,
+	}
+}
+
+// Restricted reports whether the scope limits recall to a subset of memories.
+func (s Scope) Restricted() bool {
+	switch strings.TrimSpace(s.Policy) {
+	case ScopePolicyPerUser, ScopePolicyPrivate:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s Scope) speakerKey() string {
+	if id := strings.TrimSpace(s.UserID); id != "" {
+		return "user:" + id
+	}
+	if id := strings.TrimSpace(s.ChannelIdentityID); id != "" {
+		return "identity:" + id
+	}
+	return ""
+}
+
+func (s Scope) conversationKey() string {
+	if id := strings.TrimSpace(s.ConversationID); id != "" {
+		return "conversation:" + id
+	}
+	return ""
+}
+
+// WriteKey returns the scope key a memory extracted from this conversation
+// is filed under.
+func (s Scope) WriteKey() string {
+	if !s.Restricted() {
+		return ScopeKeyBot
+	}
+	keys := []string{s.speakerKey(), s.conversationKey()}
+	if s.Policy == ScopePolicyPrivate && s.Group {
+		keys[0], keys[1] = keys[1], keys[0]
+	}
+	for _, key := range keys {
+		if key != "" {
+			return key
+		}
+	}
+	return ScopeKeyBot
+}
+
+// ReadKeys returns the scope keys that may be recalled, or nil when recall
+// is unrestricted. Bot-global memories are always included.
+func (s Scope) ReadKeys() []string {
+	if !s.Restricted() {
+		return nil
+	}
+	keys := []string{ScopeKeyBot}
+	if key := s.WriteKey(); key != ScopeKeyBot {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// ApplyFilters adds the scope restriction to search filters.
+func (s Scope) ApplyFilters(filters map[string]any) map[string]any {
+	keys := s.ReadKeys()
+	if keys == nil {
+		return filters
+	}
+	return MergeMetadata(filters, map[string]any{FilterScopeKeys: keys})
+}
+
+// ApplyMetadata records the write key in memory metadata.
+func (s Scope) ApplyMetadata(metadata map[string]any) map[string]any {
+	return MergeMetadata(metadata, map[string]any{MetadataScopeKey: s.WriteKey()})
+}
+
+// ScopeKeysFromFilters reads the allowed scope keys from search filters. It
+// returns nil when the filters do not restrict recall.
+func ScopeKeysFromFilters(filters map[string]any) []string {
+	switch v := filters[FilterScopeKeys].(type) {
+	case []string:
+		return v
+	case []any:
+		keys := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				keys = append(keys, s)
+			}
+		}
+		return keys
+	default:
+		return nil
+	}
+}
+
+// ScopeKeysAllow reports whether a memory scope key is within the allowed
+// keys. A nil list allows everything. Memories stored before scoping existed
+// carry no key and count as bot-global.
+func ScopeKeysAllow(allowed []string, key string) bool {
+	if allowed == nil {
+		return true
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		key = ScopeKeyBot
+	}
+	return slices.Contains(allowed, key)
+}
+
+// ScopeKeyFromMetadata returns the scope key recorded on a memory.
+func ScopeKeyFromMetadata(metadata map[string]any) string {
+	key, _ := metadata[MetadataScopeKey].(string)
+	return strings.TrimSpace(key)
+}
+
+// FilterItemsByScope drops memories outside the allowed scope keys and
+// trims the rest to limit (when positive).
+func FilterItemsByScope(items []MemoryItem, allowed []string, limit int) []MemoryItem {
+	if allowed == nil {
+		if limit > 0 && len(items) > limit {
+			return items[:limit]
+		}
+		return items
+	}
+	out := make([]MemoryItem, 0, len(items))
+	for _, item := range items {
+		if !ScopeKeysAllow(allowed, ScopeKeyFromMetadata(item.Metadata)) {
+			continue
+		}
+		out = append(out, item)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out
+}
+
+// ScopedFetchLimit widens limit for backends that filter scope after
+// retrieval.
+func ScopedFetchLimit(allowed []string, limit int) int {
+	if allowed == nil || limit <= 0 {
+		return limit
+	}
+	return limit * scopeOverfetchFactor
+}
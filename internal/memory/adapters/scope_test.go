@@ -0,0 +1,96 @@
+package adapters
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScopeKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		scope     Scope
+		writeKey  string
+		readKeys  []string
+		forbidden string
+	}{
+		{
+			name:     "shared keeps everything global",
+			scope:    Scope{Policy: ScopePolicyShared, UserID: "alice", ConversationID: "route-1", Group: true},
+			writeKey: ScopeKeyBot,
+		},
+		{
+			name:     "unset policy is unrestricted",
+			scope:    Scope{UserID: "alice"},
+			writeKey: ScopeKeyBot,
+		},
+		{
+			name:      "per user follows the speaker into groups",
+			scope:     Scope{Policy: ScopePolicyPerUser, UserID: "alice", ConversationID: "route-1", Group: true},
+			writeKey:  "user:alice",
+			readKeys:  []string{ScopeKeyBot, "user:alice"},
+			forbidden: "conversation:route-1",
+		},
+		{
+			name:      "private direct chat is per speaker",
+			scope:     Scope{Policy: ScopePolicyPrivate, ChannelIdentityID: "ci-1", ConversationID: "route-2"},
+			writeKey:  "identity:ci-1",
+			readKeys:  []string{ScopeKeyBot, "identity:ci-1"},
+			forbidden: "user:alice",
+		},
+		{
+			name:      "private group stays in the conversation",
+			scope:     Scope{Policy: ScopePolicyPrivate, UserID: "alice", ConversationID: "route-1", Group: true},
+			writeKey:  "conversation:route-1",
+			readKeys:  []string{ScopeKeyBot, "conversation:route-1"},
+			forbidden: "user:alice",
+		},
+		{
+			name:     "unknown speaker falls back to bot",
+			scope:    Scope{Policy: ScopePolicyPerUser},
+			writeKey: ScopeKeyBot,
+			readKeys: []string{ScopeKeyBot},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.scope.WriteKey(); got != tt.writeKey {
+				t.Fatalf("WriteKey() = %q, want %q", got, tt.writeKey)
+			}
+			if got := tt.scope.ReadKeys(); !slices.Equal(got, tt.readKeys) {
+				t.Fatalf("ReadKeys() = %v, want %v", got, tt.readKeys)
+			}
+			keys := tt.scope.ReadKeys()
+			if !ScopeKeysAllow(keys, "") {
+				t.Fatal("expected memories without a scope key to stay visible")
+			}
+			if tt.forbidden != "" && ScopeKeysAllow(keys, tt.forbidden) {
+				t.Fatalf("expected %q to be hidden", tt.forbidden)
+			}
+		})
+	}
+}
+
+func TestFilterItemsByScope(t *testing.T) {
+	t.Parallel()
+
+	items := []MemoryItem{
+		{ID: "1", Metadata: map[string]any{MetadataScopeKey: "user:bob"}},
+		{ID: "2", Metadata: map[string]any{MetadataScopeKey: "user:alice"}},
+		{ID: "3"},
+		{ID: "4", Metadata: map[string]any{MetadataScopeKey: ScopeKeyBot}},
+	}
+	scope := Scope{Policy: ScopePolicyPerUser, UserID: "alice"}
+	got := FilterItemsByScope(items, scope.ReadKeys(), 2)
+	if len(got) != 2 || got[0].ID != "2" || got[1].ID != "3" {
+		t.Fatalf("unexpected filtered items: %#v", got)
+	}
+	if len(FilterItemsByScope(items, nil, 0)) != len(items) {
+		t.Fatal("expected nil scope keys to keep every item")
+	}
+	if keys := ScopeKeysFromFilters(scope.ApplyFilters(map[string]any{"bot_id": "bot-1"})); !slices.Equal(keys, scope.ReadKeys()) {
+		t.Fatalf("expected filters to carry scope keys, got %v", keys)
+	}
+}
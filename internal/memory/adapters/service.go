@@ -3,6 +3,7 @@ package adapters
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
@@ -14,6 +15,10 @@ import (
 	qdrantclient "github.com/memohai/memoh/internal/memory/qdrant"
 )
 
+// ErrVectorStoreUnavailable is returned when a provider selects the pgvector
+// store but the database cannot serve it.
+var ErrVectorStoreUnavailable = errors.New("pgvector vector store unavailable")
+
 type Service struct {
 	queries  *sqlc.Queries
 	registry *Registry
@@ -45,7 +50,7 @@ func (*Service) ListMeta(_ context.Context) []ProviderMeta {
 					"memory_mode": {
 						Type:        "select",
 						Title:       "Memory Mode",
-						Description: "off = file-based, sparse = Qdrant sparse vectors, dense = embedding API + Qdrant dense vectors",
+						Description: "off = file-based, sparse = Qdrant sparse vectors, dense = embedding API + Qdrant or pgvector dense vectors",
 						Required:    false,
 					},
 					"embedding_model_id": {
@@ -54,6 +59,13 @@ func (*Service) ListMeta(_ context.Context) []ProviderMeta {
 						Description: "Embedding model for dense vector search (dense mode only)",
 						Required:    false,
 					},
+					"vector_store": {
+						Type:        "select",
+						Title:       "Vector Store",
+						Description: "Where dense mode stores embeddings: qdrant (default) or pgvector (the Postgres database, requires the vector extension)",
+						Required:    false,
+						Example:     "qdrant",
+					},
 					"qdrant_collection": {
 						Type:        "string",
 						Title:       "Qdrant Collection",
@@ -124,6 +136,9 @@ func (s *Service) Create(ctx context.Context, req ProviderCreateRequest) (Provid
 	if !isValidProviderType(req.Provider) {
 		return ProviderGetResponse{}, fmt.Errorf("invalid provider type: %s", req.Provider)
 	}
+	if err := s.validateVectorStore(ctx, req.Provider, req.Config); err != nil {
+		return ProviderGetResponse{}, err
+	}
 	configJSON, err := json.Marshal(req.Config)
 	if err != nil {
 		return ProviderGetResponse{}, fmt.Errorf("marshal config: %w", err)
@@ -167,6 +182,12 @@ func (s *Service) Status(ctx context.Context, id string) (ProviderStatusResponse
 	}
 	status.MemoryMode = StringFromConfig(resp.Config, "memory_mode")
 	status.EmbeddingModelID = StringFromConfig(resp.Config, "embedding_model_id")
+	if status.MemoryMode == "dense" {
+		status.VectorStore = StringFromConfig(resp.Config, "vector_store")
+		if status.VectorStore == "" {
+			status.VectorStore = "qdrant"
+		}
+	}
 	collections := []string{"memory_sparse", "memory_dense"}
 	status.Collections = make([]ProviderCollectionStatus, 0, len(collections))
 	for _, collection := range collections {
@@ -227,6 +248,9 @@ func (s *Service) Update(ctx context.Context, id string, req ProviderUpdateReque
 	}
 	config := current.Config
 	if req.Config != nil {
+		if err := s.validateVectorStore(ctx, ProviderType(current.Provider), req.Config); err != nil {
+			return ProviderGetResponse{}, err
+		}
 		configJSON, marshalErr := json.Marshal(req.Config)
 		if marshalErr != nil {
 			return ProviderGetResponse{}, fmt.Errorf("marshal config: %w", marshalErr)
@@ -315,6 +339,26 @@ func (s *Service) tryEvictAndReinstantiate(id, providerType string, config map[s
 	s.tryInstantiate(id, providerType, config)
 }
 
+// validateVectorStore rejects a built-in provider that selects the pgvector
+// store when the database lacks the vector extension or the table that
+// migrations create with it.
+func (s *Service) validateVectorStore(ctx context.Context, provider ProviderType, config map[string]any) error {
+	if provider != ProviderBuiltin || strings.TrimSpace(StringFromConfig(config, "vector_store")) != "pgvector" {
+		return nil
+	}
+	status, err := s.queries.GetVectorStoreStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("check vector store: %w", err)
+	}
+	if !status.ExtensionAvailable {
+		return fmt.Errorf("%w: the database does not have the vector extension; use a Postgres image that ships pgvector", ErrVectorStoreUnavailable)
+	}
+	if !status.TableExists {
+		return fmt.Errorf("%w: the memory_vectors table does not exist; create the vector extension and re-run migration 0055_memory_vectors", ErrVectorStoreUnavailable)
+	}
+	return nil
+}
+
 func isValidProviderType(t ProviderType) bool {
 	switch t {
 	case ProviderBuiltin, ProviderMem0, ProviderOpenViking:
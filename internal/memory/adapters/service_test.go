@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/memohai/memoh/internal/config"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+type vectorStatusDB struct {
+	available, tableExists bool
+	queries                int
+}
+
+func (*vectorStatusDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.New("unexpected exec")
+}
+
+func (*vectorStatusDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (d *vectorStatusDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	d.queries++
+	return vectorStatusRow{d.available, d.tableExists}
+}
+
+type vectorStatusRow [2]bool
+
+func (r vectorStatusRow) Scan(dest ...any) error {
+	*dest[0].(*bool) = r[0]
+	*dest[1].(*bool) = r[1]
+	return nil
+}
+
+func TestValidateVectorStore(t *testing.T) {
+	t.Parallel()
+
+	pgvectorConfig := map[string]any{"memory_mode": "dense", "vector_store": "pgvector"}
+	tests := []struct {
+		name        string
+		provider    ProviderType
+		config      map[string]any
+		db          vectorStatusDB
+		wantErr     bool
+		wantQueries int
+	}{
+		{name: "qdrant store is not checked", provider: ProviderBuiltin, config: map[string]any{"vector_store": "qdrant"}},
+		{name: "other providers are not checked", provider: ProviderMem0, config: pgvectorConfig},
+		{name: "extension unavailable", provider: ProviderBuiltin, config: pgvectorConfig, wantErr: true, wantQueries: 1},
+		{name: "table missing", provider: ProviderBuiltin, config: pgvectorConfig, db: vectorStatusDB{available: true}, wantErr: true, wantQueries: 1},
+		{name: "ready", provider: ProviderBuiltin, config: pgvectorConfig, db: vectorStatusDB{available: true, tableExists: true}, wantQueries: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			db := tt.db
+			svc := NewService(slog.Default(), sqlc.New(&db), config.Config{})
+			err := svc.validateVectorStore(context.Background(), tt.provider, tt.config)
+			if tt.wantErr != errors.Is(err, ErrVectorStoreUnavailable) {
+				t.Fatalf("validateVectorStore() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if db.queries != tt.wantQueries {
+				t.Fatalf("queries = %d, want %d", db.queries, tt.wantQueries)
+			}
+		})
+	}
+}
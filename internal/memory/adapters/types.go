@@ -10,6 +10,8 @@ type BeforeChatRequest struct {
 	Query  string
 	BotID  string
 	ChatID string
+	// Scope limits recall to memories the current speaker may see.
+	Scope Scope
 }
 
 // BeforeChatResult contains memory context to inject into the conversation.
@@ -24,6 +26,8 @@ type AfterChatRequest struct {
 	UserID            string
 	ChannelIdentityID string
 	DisplayName       string
+	// Scope decides who the extracted memories belong to.
+	Scope Scope
 }
 
 // LLM is the interface for LLM operations needed by memory service.
@@ -228,8 +232,11 @@ type MemoryStatusResponse struct {
 	SourceCount       int          `json:"source_count,omitempty"`
 	IndexedCount      int          `json:"indexed_count,omitempty"`
 	QdrantCollection  string       `json:"qdrant_collection,omitempty"`
+	VectorStore       string       `json:"vector_store,omitempty"`
+	PgvectorTable     string       `json:"pgvector_table,omitempty"`
 	Encoder           HealthStatus `json:"encoder"`
 	Qdrant            HealthStatus `json:"qdrant"`
+	Pgvector          HealthStatus `json:"pgvector"`
 }
 
 // Memory provider admin types.
@@ -292,5 +299,6 @@ type ProviderStatusResponse struct {
 	ProviderType     string                     `json:"provider_type"`
 	MemoryMode       string                     `json:"memory_mode,omitempty"`
 	EmbeddingModelID string                     `json:"embedding_model_id,omitempty"`
+	VectorStore      string                     `json:"vector_store,omitempty"`
 	Collections      []ProviderCollectionStatus `json:"collections,omitempty"`
 }
@@ -0,0 +1,317 @@
+// Package pgvector stores dense memory embeddings in Postgres using the
+// pgvector extension, mirroring the facade of the qdrant package so the
+// built-in memory runtime can run without a Qdrant deployment.
+package pgvector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+
+	dbsqlc "github.com/memohai/memoh/internal/db/sqlc"
+)
+
+const (
+	// TableName is the table holding dense memory vectors.
+	TableName = "memory_vectors"
+	// scopeKeyField is the payload field holding a memory's scope key.
+	scopeKeyField = "memory_scope_key"
+	// maxHNSWDimensions is the largest vector pgvector can index with HNSW.
+	// Larger embeddings are still stored and searched, just without an index.
+	maxHNSWDimensions = 2000
+	// textSearchConfig is the Postgres text search configuration used for
+	// keyword ranking. "simple" avoids language-specific stemming so mixed
+	// language memories rank consistently. It must match the search_text
+	// column created by migration 0055_memory_vectors.
+	textSearchConfig = "simple"
+)
+
+// Client reads and writes dense vectors for a single embedding model.
+type Client struct {
+	db         dbsqlc.DBTX
+	model      string
+	dimensions int
+
+	mu      sync.Mutex
+	ensured bool
+}
+
+// SearchResult is one result from a vector search, keyword search or scroll.
+type SearchResult struct {
+	ID      string
+	Score   float64
+	Payload map[string]string
+}
+
+// NewClient creates a client for vectors produced by model with the given
+// dimensions.
+func NewClient(db dbsqlc.DBTX, model string, dimensions int) (*Client, error) {
+	if db == nil {
+		return nil, errors.New("pgvector: database connection is required")
+	}
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return nil, errors.New("pgvector: embedding model is required")
+	}
+	if dimensions <= 0 {
+		return nil, errors.New("pgvector: dimensions must be positive")
+	}
+	return &Client{db: db, model: model, dimensions: dimensions}, nil
+}
+
+// TableName returns the table the client writes to.
+func (*Client) TableName() string {
+	return TableName
+}
+
+// TableExists reports whether the vector table has been created.
+func (c *Client) TableExists(ctx context.Context) (bool, error) {
+	var exists bool
+	if err := c.db.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, TableName).Scan(&exists); err != nil {
+		return false, fmt.Errorf("pgvector: check table: %w", err)
+	}
+	return exists, nil
+}
+
+// ErrTableMissing is returned when the memory_vectors table does not exist.
+// Migrations only create it when the vector extension can be installed.
+var ErrTableMissing = errors.New("pgvector: table " + TableName + " does not exist; install the vector extension and re-run migration 0055_memory_vectors")
+
+// EnsureTable checks that migrations created the vector table.
+func (c *Client) EnsureTable(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ensured {
+		return nil
+	}
+	exists, err := c.TableExists(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrTableMissing
+	}
+	c.ensured = true
+	return nil
+}
+
+// EnsureIndex adds the HNSW index for the client's dimensions, which cannot
+// be declared up front. It builds the index concurrently so writes are not
+// blocked, and is meant to run in the background rather than on a request.
+func (c *Client) EnsureIndex(ctx context.Context) error {
+	// HNSW needs a fixed dimension, so each embedding size gets its own
+	// partial expression index over the untyped column.
+	if c.dimensions > maxHNSWDimensions {
+		return nil
+	}
+	name := fmt.Sprintf("idx_%s_hnsw_%d", TableName, c.dimensions)
+	var valid bool
+	err := c.db.QueryRow(ctx, `
+SELECT i.indisvalid FROM pg_index i
+JOIN pg_class c ON c.oid = i.indexrelid
+WHERE c.relname = $1`, name).Scan(&valid)
+	switch {
+	case err == nil && valid:
+		return nil
+	case err == nil:
+		// A failed concurrent build leaves an invalid index that
+		// IF NOT EXISTS would keep skipping.
+		if _, err := c.db.Exec(ctx, `DROP INDEX CONCURRENTLY IF EXISTS `+name); err != nil {
+			return fmt.Errorf("pgvector: drop invalid index: %w", err)
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return fmt.Errorf("pgvector: check index: %w", err)
+	}
+	stmt := fmt.Sprintf(
+		`CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s USING hnsw ((embedding::vector(%d)) vector_cosine_ops) WHERE dimensions = %d`,
+		name, TableName, c.dimensions, c.dimensions,
+	)
+	if _, err := c.db.Exec(ctx, stmt); err != nil {
+		return fmt.Errorf("pgvector: create index: %w", err)
+	}
+	return nil
+}
+
+// Upsert inserts or updates one vector with its payload.
+func (c *Client) Upsert(ctx context.Context, id, botID string, vec []float32, payload map[string]string) error {
+	if len(vec) != c.dimensions {
+		return fmt.Errorf("pgvector: expected %d dimensions, got %d", c.dimensions, len(vec))
+	}
+	_, err := c.db.Exec(ctx, `
+INSERT INTO `+TableName+` (id, bot_id, model, dimensions, embedding, payload, updated_at)
+VALUES ($1, $2, $3, $4, $5::vector, $6, now())
+ON CONFLICT (id) DO UPDATE SET
+  bot_id = EXCLUDED.bot_id,
+  model = EXCLUDED.model,
+  dimensions = EXCLUDED.dimensions,
+  embedding = EXCLUDED.embedding,
+  payload = EXCLUDED.payload,
+  updated_at = now()`,
+		id, botID, c.model, c.dimensions, vectorLiteral(vec), payload,
+	)
+	if err != nil {
+		return fmt.Errorf("pgvector: upsert: %w", err)
+	}
+	return nil
+}
+
+// SearchDense returns the nearest vectors by cosine distance, filtered by
+// bot_id and, when scopeKeys is non-nil, by memory scope. Score is the
+// cosine similarity.
+func (c *Client) SearchDense(ctx context.Context, vec []float32, botID string, scopeKeys []string, limit int) ([]SearchResult, error) {
+	if len(vec) != c.dimensions {
+		return nil, fmt.Errorf("pgvector: expected %d dimensions, got %d", c.dimensions, len(vec))
+	}
+	distance := fmt.Sprintf("(embedding::vector(%d)) <=> $1::vector(%d)", c.dimensions, c.dimensions)
+	rows, err := c.db.Query(ctx, `
+SELECT id::text, payload, 1 - (`+distance+`) AS score
+FROM `+TableName+`
+WHERE dimensions = `+strconv.Itoa(c.dimensions)+`
+  AND model = $2
+  AND bot_id = $3
+  AND `+scopeCondition("$4")+`
+ORDER BY `+distance+`
+LIMIT $5`,
+		vectorLiteral(vec), c.model, botID, scopeKeys, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pgvector: search dense: %w", err)
+	}
+	return collectResults(rows)
+}
+
+// SearchText ranks memories by Postgres full-text relevance against query,
+// matching any of its terms. Score is the ts_rank_cd value.
+func (c *Client) SearchText(ctx context.Context, query, botID string, scopeKeys []string, limit int) ([]SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+	rows, err := c.db.Query(ctx, `
+WITH q AS (
+  SELECT to_tsquery('`+textSearchConfig+`', replace(plainto_tsquery('`+textSearchConfig+`', $1)::text, ' & ', ' | ')) AS query
+)
+SELECT v.id::text, v.payload, ts_rank_cd(v.search_text, q.query) AS score
+FROM `+TableName+` v, q
+WHERE v.dimensions = $2
+  AND v.model = $3
+  AND v.bot_id = $4
+  AND v.search_text @@ q.query
+  AND `+scopeCondition("$5")+`
+ORDER BY score DESC
+LIMIT $6`,
+		query, c.dimensions, c.model, botID, scopeKeys, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pgvector: search text: %w", err)
+	}
+	return collectResults(rows)
+}
+
+// Scroll returns up to limit stored vectors for a bot with IDs after the
+// given cursor, regardless of the model that produced them. Pass an empty
+// cursor for the first page and the last returned ID for the next one. The
+// payload carries the model under "embedding_model" so callers can detect
+// vectors that need re-embedding.
+func (c *Client) Scroll(ctx context.Context, botID, after string, limit int) ([]SearchResult, error) {
+	var cursor any
+	if after != "" {
+		cursor = after
+	}
+	rows, err := c.db.Query(ctx, `
+SELECT id::text, payload || jsonb_build_object('embedding_model', model), 0::float8
+FROM `+TableName+`
+WHERE bot_id = $1 AND ($2::uuid IS NULL OR id > $2::uuid)
+ORDER BY id
+LIMIT $3`,
+		botID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pgvector: scroll: %w", err)
+	}
+	return collectResults(rows)
+}
+
+// Count returns the number of vectors stored for a bot by the client's model.
+func (c *Client) Count(ctx context.Context, botID string) (int, error) {
+	var count int64
+	err := c.db.QueryRow(ctx, `
+SELECT count(*) FROM `+TableName+`
+WHERE bot_id = $1 AND model = $2 AND dimensions = $3`,
+		botID, c.model, c.dimensions,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("pgvector: count: %w", err)
+	}
+	return int(count), nil
+}
+
+// DeleteByIDs removes specific vectors by their UUID strings.
+func (c *Client) DeleteByIDs(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := c.db.Exec(ctx, `DELETE FROM `+TableName+` WHERE id = ANY($1::uuid[])`, ids); err != nil {
+		return fmt.Errorf("pgvector: delete: %w", err)
+	}
+	return nil
+}
+
+// DeleteByBotID removes all vectors for a given bot_id.
+func (c *Client) DeleteByBotID(ctx context.Context, botID string) error {
+	if _, err := c.db.Exec(ctx, `DELETE FROM `+TableName+` WHERE bot_id = $1`, botID); err != nil {
+		return fmt.Errorf("pgvector: delete by bot: %w", err)
+	}
+	return nil
+}
+
+// --- helpers ---
+
+// scopeCondition restricts rows to the scope keys bound at param. A NULL
+// array means unrestricted; rows without a key count as bot-global.
+func scopeCondition(param string) string {
+	return fmt.Sprintf(
+		"(%s::text[] IS NULL OR coalesce(nullif(payload->>'%s', ''), 'bot') = ANY(%s::text[]))",
+		param, scopeKeyField, param,
+	)
+}
+
+func collectResults(rows pgx.Rows) ([]SearchResult, error) {
+	defer rows.Close()
+	var results []SearchResult
+	for rows.Next() {
+		var (
+			result  SearchResult
+			payload map[string]string
+		)
+		if err := rows.Scan(&result.ID, &payload, &result.Score); err != nil {
+			return nil, fmt.Errorf("pgvector: scan: %w", err)
+		}
+		result.Payload = payload
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgvector: rows: %w", err)
+	}
+	return results, nil
+}
+
+// vectorLiteral renders vec in pgvector's text input format.
+func vectorLiteral(vec []float32) string {
+	var b strings.Builder
+	b.Grow(len(vec)*8 + 2)
+	b.WriteByte('[')
+	for i, v := range vec {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
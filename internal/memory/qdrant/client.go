@@ -14,6 +14,8 @@ import (
 
 const (
 	sparseVectorName = "sparse"
+	// scopeKeyField is the payload field holding a memory's scope key.
+	scopeKeyField = "memory_scope_key"
 )
 
 // Client wraps the official Qdrant gRPC client with sparse-memory-specific helpers.
@@ -176,8 +178,9 @@ func (c *Client) UpsertDense(ctx context.Context, id string, vec DenseVector, pa
 	return nil
 }
 
-// Search performs a sparse-vector query against the collection, filtered by bot_id.
-func (c *Client) Search(ctx context.Context, vec SparseVector, botID string, limit int) ([]SearchResult, error) {
+// Search performs a sparse-vector query against the collection, filtered by
+// bot_id and, when scopeKeys is non-nil, by memory scope.
+func (c *Client) Search(ctx context.Context, vec SparseVector, botID string, scopeKeys []string, limit int) ([]SearchResult, error) {
 	if limit <= 0 {
 		limit = 10
 	}
@@ -189,7 +192,7 @@ func (c *Client) Search(ctx context.Context, vec SparseVector, botID string, lim
 		CollectionName: c.collection,
 		Query:          pb.NewQuerySparse(vec.Indices, vec.Values),
 		Using:          strPtr(sparseVectorName),
-		Filter:         botFilter(botID),
+		Filter:         scopedBotFilter(botID, scopeKeys),
 		Limit:          uint64Ptr(queryLimit),
 		WithPayload:    pb.NewWithPayload(true),
 	})
@@ -199,8 +202,9 @@ func (c *Client) Search(ctx context.Context, vec SparseVector, botID string, lim
 	return scoredPointsToResults(scored), nil
 }
 
-// SearchDense performs a dense-vector query against the collection, filtered by bot_id.
-func (c *Client) SearchDense(ctx context.Context, vec DenseVector, botID string, limit int) ([]SearchResult, error) {
+// SearchDense performs a dense-vector query against the collection, filtered
+// by bot_id and, when scopeKeys is non-nil, by memory scope.
+func (c *Client) SearchDense(ctx context.Context, vec DenseVector, botID string, scopeKeys []string, limit int) ([]SearchResult, error) {
 	if limit <= 0 {
 		limit = 10
 	}
@@ -211,7 +215,7 @@ func (c *Client) SearchDense(ctx context.Context, vec DenseVector, botID string,
 	scored, err := c.inner.Query(ctx, &pb.QueryPoints{
 		CollectionName: c.collection,
 		Query:          pb.NewQueryDense(vec.Values),
-		Filter:         botFilter(botID),
+		Filter:         scopedBotFilter(botID, scopeKeys),
 		Limit:          uint64Ptr(queryLimit),
 		WithPayload:    pb.NewWithPayload(true),
 	})
@@ -354,6 +358,20 @@ func botFilter(botID string) *pb.Filter {
 	}
 }
 
+// scopedBotFilter narrows botFilter to points whose memory_scope_key is one
+// of scopeKeys. Points indexed before scoping have no key and stay visible.
+func scopedBotFilter(botID string, scopeKeys []string) *pb.Filter {
+	filter := botFilter(botID)
+	if scopeKeys == nil {
+		return filter
+	}
+	filter.Should = []*pb.Condition{
+		pb.NewMatchKeywords(scopeKeyField, scopeKeys...),
+		pb.NewIsEmpty(scopeKeyField),
+	}
+	return filter
+}
+
 func stringPayloadToValueMap(payload map[string]string) map[string]*pb.Value {
 	m := make(map[string]*pb.Value, len(payload))
 	for k, v := range payload {
@@ -85,6 +85,7 @@ func (s *DBService) Persist(ctx context.Context, input PersistInput) (Message, e
 		Metadata:                metaBytes,
 		Usage:                   input.Usage,
 		ModelID:                 pgModelID,
+		CostUsd:                 toPgFloat8(input.CostUSD),
 	})
 	if err != nil {
 		return Message{}, err
@@ -741,6 +742,13 @@ func toPgText(value string) pgtype.Text {
 	return pgtype.Text{String: value, Valid: true}
 }
 
+func toPgFloat8(value *float64) pgtype.Float8 {
+	if value == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *value, Valid: true}
+}
+
 func nonNilMap(m map[string]any) map[string]any {
 	if m == nil {
 		return map[string]any{}
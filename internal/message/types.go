@@ -67,6 +67,8 @@ type PersistInput struct {
 	Usage                   json.RawMessage
 	Assets                  []AssetRef
 	ModelID                 string
+	// CostUSD is the priced cost of Usage; nil when the model has no pricing.
+	CostUSD *float64
 }
 
 // Writer defines write behavior needed by the inbound router.
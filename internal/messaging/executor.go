@@ -53,6 +53,7 @@ type Executor struct {
 	Sender        Sender
 	Reactor       Reactor
 	Resolver      ChannelTypeResolver
+	Capabilities  CapabilityResolver
 	AssetResolver AssetResolver
 	Logger        *slog.Logger
 }
@@ -0,0 +1,200 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+// Interactive message kinds accepted by SendInteractive.
+const (
+	InteractiveButtons = "buttons"
+	InteractivePoll    = "poll"
+)
+
+// How an interactive message was rendered on the platform.
+const (
+	RenderedButtons = "buttons"
+	RenderedPoll    = "poll"
+	RenderedText    = "text"
+)
+
+const (
+	maxInteractiveOptions = 10
+	// maxInteractiveValueBytes fits Telegram's 64-byte callback data.
+	maxInteractiveValueBytes = 64
+)
+
+// CapabilityResolver reports the feature matrix of a channel type.
+type CapabilityResolver interface {
+	GetCapabilities(channelType channel.ChannelType) (channel.ChannelCapabilities, bool)
+}
+
+// InteractiveOption is one button or poll answer.
+type InteractiveOption struct {
+	Label string
+	Value string
+	URL   string
+}
+
+// InteractiveResult is the success payload returned after sending an
+// interactive message.
+type InteractiveResult struct {
+	BotID    string
+	Platform string
+	Target   string
+	Rendered string // one of the Rendered* values
+}
+
+// SendInteractive sends buttons or a poll. Polls fall back to buttons on
+// platforms without native polls, and both fall back to a numbered text list
+// on platforms without buttons. Unlike Send it may target the current
+// conversation.
+func (e *Executor) SendInteractive(ctx context.Context, session SessionContext, args map[string]any) (*InteractiveResult, error) {
+	if e.Sender == nil || e.Resolver == nil {
+		return nil, errors.New("message service not available")
+	}
+	botID, err := e.resolveBotID(args, session)
+	if err != nil {
+		return nil, err
+	}
+	channelType, err := e.resolvePlatform(args, session)
+	if err != nil {
+		return nil, err
+	}
+	target := firstStringArg(args, "target")
+	if target == "" {
+		target = strings.TrimSpace(session.ReplyTarget)
+	}
+	if target == "" {
+		return nil, errors.New("target is required")
+	}
+	text := firstStringArg(args, "text", "question")
+	if text == "" {
+		return nil, errors.New("text is required")
+	}
+	kind := strings.ToLower(firstStringArg(args, "kind"))
+	if kind == "" {
+		kind = InteractiveButtons
+	}
+	if kind != InteractiveButtons && kind != InteractivePoll {
+		return nil, fmt.Errorf("kind must be %q or %q", InteractiveButtons, InteractivePoll)
+	}
+	options, err := ParseInteractiveOptions(args["options"])
+	if err != nil {
+		return nil, err
+	}
+	if kind == InteractivePoll && len(options) < 2 {
+		return nil, errors.New("a poll needs at least 2 options")
+	}
+	multiple, _, _ := boolArg(args, "multiple")
+
+	var caps channel.ChannelCapabilities
+	if e.Capabilities != nil {
+		caps, _ = e.Capabilities.GetCapabilities(channelType)
+	}
+	msg, rendered := BuildInteractiveMessage(text, kind, options, multiple, caps)
+	if replyTo := firstStringArg(args, "reply_to"); replyTo != "" && caps.Reply {
+		msg.Reply = &channel.ReplyRef{MessageID: replyTo}
+	}
+	if err := e.Sender.Send(ctx, botID, channelType, channel.SendRequest{Target: target, Message: msg}); err != nil {
+		if e.Logger != nil {
+			e.Logger.Warn("send interactive failed", slog.Any("error", err), slog.String("bot_id", botID), slog.String("platform", string(channelType)))
+		}
+		return nil, err
+	}
+	return &InteractiveResult{BotID: botID, Platform: channelType.String(), Target: target, Rendered: rendered}, nil
+}
+
+// BuildInteractiveMessage renders text and options the best way the channel
+// supports, reporting which rendering was used.
+func BuildInteractiveMessage(text, kind string, options []InteractiveOption, multiple bool, caps channel.ChannelCapabilities) (channel.Message, string) {
+	if kind == InteractivePoll && caps.Polls {
+		poll := &channel.Poll{Question: text, MultipleAnswers: multiple}
+		for _, option := range options {
+			poll.Options = append(poll.Options, option.Label)
+		}
+		return channel.Message{Poll: poll}, RenderedPoll
+	}
+	if caps.Buttons {
+		actions := make([]channel.Action, 0, len(options))
+		for _, option := range options {
+			actions = append(actions, channel.Action{Type: "button", Label: option.Label, Value: option.Value, URL: option.URL})
+		}
+		return channel.Message{Text: text, Actions: actions}, RenderedButtons
+	}
+	var b strings.Builder
+	b.WriteString(text)
+	for i, option := range options {
+		fmt.Fprintf(&b, "\n%d. %s", i+1, option.Label)
+		if option.URL != "" {
+			fmt.Fprintf(&b, " (%s)", option.URL)
+		}
+	}
+	if kind == InteractivePoll && multiple {
+		b.WriteString("\nReply with the numbers of your choices.")
+	} else {
+		b.WriteString("\nReply with the number of your choice.")
+	}
+	return channel.Message{Text: b.String()}, RenderedText
+}
+
+// ParseInteractiveOptions reads options given as strings or as objects with
+// label, value and url. A value defaults to the label.
+func ParseInteractiveOptions(raw any) ([]InteractiveOption, error) {
+	items, ok := raw.([]any)
+	if !ok {
+		if list, isList := raw.([]string); isList {
+			for _, item := range list {
+				items = append(items, item)
+			}
+		}
+	}
+	if len(items) == 0 {
+		return nil, errors.New("options are required")
+	}
+	if len(items) > maxInteractiveOptions {
+		return nil, fmt.Errorf("at most %d options are allowed", maxInteractiveOptions)
+	}
+	options := make([]InteractiveOption, 0, len(items))
+	seen := make(map[string]struct{}, len(items))
+	for i, item := range items {
+		var option InteractiveOption
+		switch v := item.(type) {
+		case string:
+			option.Label = strings.TrimSpace(v)
+		case map[string]any:
+			option.Label = firstStringArg(v, "label", "text")
+			option.Value = firstStringArg(v, "value")
+			option.URL = firstStringArg(v, "url")
+		default:
+			return nil, fmt.Errorf("option %d must be a string or object", i+1)
+		}
+		if option.Label == "" {
+			return nil, fmt.Errorf("option %d needs a label", i+1)
+		}
+		if option.URL != "" {
+			if !strings.HasPrefix(option.URL, "https://") && !strings.HasPrefix(option.URL, "http://") {
+				return nil, fmt.Errorf("option %d url must be http or https", i+1)
+			}
+			option.Value = ""
+		} else {
+			if option.Value == "" {
+				option.Value = option.Label
+			}
+			if len(option.Value) > maxInteractiveValueBytes {
+				return nil, fmt.Errorf("option %d value is longer than %d bytes; set a shorter value", i+1, maxInteractiveValueBytes)
+			}
+			if _, dup := seen[option.Value]; dup {
+				return nil, fmt.Errorf("option %d repeats value %q", i+1, option.Value)
+			}
+			seen[option.Value] = struct{}{}
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
@@ -0,0 +1,103 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/memohai/memoh/internal/channel"
+)
+
+type fakeSender struct {
+	channelType channel.ChannelType
+	req         channel.SendRequest
+}
+
+func (f *fakeSender) Send(_ context.Context, _ string, channelType channel.ChannelType, req channel.SendRequest) error {
+	f.channelType, f.req = channelType, req
+	return nil
+}
+
+type fakeRegistry map[channel.ChannelType]channel.ChannelCapabilities
+
+func (r fakeRegistry) ParseChannelType(raw string) (channel.ChannelType, error) {
+	return channel.ChannelType(raw), nil
+}
+
+func (r fakeRegistry) GetCapabilities(channelType channel.ChannelType) (channel.ChannelCapabilities, bool) {
+	caps, ok := r[channelType]
+	return caps, ok
+}
+
+func TestSendInteractiveRendersByCapability(t *testing.T) {
+	registry := fakeRegistry{
+		"telegram": {Text: true, Buttons: true, Polls: true},
+		"slack":    {Text: true, Buttons: true},
+		"irc":      {Text: true},
+	}
+	args := func(platform string) map[string]any {
+		return map[string]any{
+			"platform": platform,
+			"text":     "Lunch?",
+			"kind":     "poll",
+			"options":  []any{"Pizza", map[string]any{"label": "Sushi", "value": "sushi"}},
+		}
+	}
+	session := SessionContext{BotID: "bot-1", CurrentPlatform: "telegram", ReplyTarget: "123"}
+
+	sender := &fakeSender{}
+	exec := &Executor{Sender: sender, Resolver: registry, Capabilities: registry}
+	result, err := exec.SendInteractive(context.Background(), session, args("telegram"))
+	if err != nil {
+		t.Fatalf("SendInteractive returned error: %v", err)
+	}
+	poll := sender.req.Message.Poll
+	if result.Rendered != RenderedPoll || result.Target != "123" || poll == nil || poll.Question != "Lunch?" || strings.Join(poll.Options, ",") != "Pizza,Sushi" {
+		t.Fatalf("unexpected poll send: %+v %+v", result, sender.req)
+	}
+
+	result, err = exec.SendInteractive(context.Background(), session, map[string]any{
+		"platform": "slack", "target": "C1", "text": "Lunch?", "kind": "poll",
+		"options": []any{"Pizza", map[string]any{"label": "Sushi", "value": "sushi"}},
+	})
+	if err != nil {
+		t.Fatalf("SendInteractive returned error: %v", err)
+	}
+	actions := sender.req.Message.Actions
+	if result.Rendered != RenderedButtons || len(actions) != 2 || actions[0].Value != "Pizza" || actions[1].Value != "sushi" || sender.req.Message.Text != "Lunch?" {
+		t.Fatalf("unexpected button send: %+v %+v", result, sender.req)
+	}
+
+	result, err = exec.SendInteractive(context.Background(), session, map[string]any{
+		"platform": "irc", "target": "#memoh", "text": "Lunch?",
+		"options": []any{"Pizza", "Sushi"},
+	})
+	if err != nil {
+		t.Fatalf("SendInteractive returned error: %v", err)
+	}
+	if result.Rendered != RenderedText || sender.req.Message.Text != "Lunch?\n1. Pizza\n2. Sushi\nReply with the number of your choice." {
+		t.Fatalf("unexpected text fallback: %+v %q", result, sender.req.Message.Text)
+	}
+}
+
+func TestParseInteractiveOptions(t *testing.T) {
+	options, err := ParseInteractiveOptions([]any{"Yes", map[string]any{"label": "Docs", "url": "https://example.com"}})
+	if err != nil {
+		t.Fatalf("ParseInteractiveOptions returned error: %v", err)
+	}
+	if options[0].Value != "Yes" || options[1].Value != "" || options[1].URL != "https://example.com" {
+		t.Fatalf("unexpected options: %+v", options)
+	}
+	for name, raw := range map[string]any{
+		"empty":     []any{},
+		"duplicate": []any{"Yes", map[string]any{"label": "Sure", "value": "Yes"}},
+		"long":      []any{strings.Repeat("x", 65)},
+		"bad url":   []any{map[string]any{"label": "Docs", "url": "javascript:alert(1)"}},
+		"no label":  []any{map[string]any{"value": "x"}},
+		"too many":  []any{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"},
+	} {
+		if _, err := ParseInteractiveOptions(raw); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -119,6 +119,18 @@ func TestModel_Validate(t *testing.T) {
 	}
 }
 
+func TestModel_ValidatePricing(t *testing.T) {
+	m := models.Model{
+		ModelID:       "gpt-4o",
+		LlmProviderID: "11111111-1111-1111-1111-111111111111",
+		Type:          models.ModelTypeChat,
+		Config:        models.ModelConfig{Pricing: &models.ModelPricing{Input: 2.5, Output: 10}},
+	}
+	assert.NoError(t, m.Validate())
+	m.Config.Pricing.CachedInput = -1
+	assert.Error(t, m.Validate())
+}
+
 func TestModel_HasCompatibility(t *testing.T) {
 	m := models.Model{
 		Config: models.ModelConfig{
@@ -131,6 +143,22 @@ func TestModel_HasCompatibility(t *testing.T) {
 	assert.False(t, m.HasCompatibility("image-output"))
 }
 
+func TestModelPricing_Cost(t *testing.T) {
+	p := models.ModelPricing{Input: 3, Output: 15, CachedInput: 0.3}
+
+	// 1M uncached input + 1M output.
+	assert.InDelta(t, 18.0, p.Cost(models.TokenCounts{Input: 1_000_000, Output: 1_000_000}), 1e-9)
+
+	// Half the input served from cache; reasoning billed at the output price.
+	got := p.Cost(models.TokenCounts{Input: 1_000_000, CachedInput: 500_000, Output: 1_000_000, Reasoning: 400_000})
+	assert.InDelta(t, 1.5+0.15+15, got, 1e-9)
+
+	// Separate reasoning price and cached input falling back to the input price.
+	p = models.ModelPricing{Input: 2, Output: 8, Reasoning: 10}
+	got = p.Cost(models.TokenCounts{Input: 1_000_000, CachedInput: 1_000_000, Output: 1_000_000, Reasoning: 500_000})
+	assert.InDelta(t, 2+4+5, got, 1e-9)
+}
+
 func TestModelTypes(t *testing.T) {
 	t.Run("ModelType constants", func(t *testing.T) {
 		assert.Equal(t, models.ModelTypeChat, models.ModelType("chat"))
@@ -36,9 +36,48 @@ var validCompatibilities = map[string]struct{}{
 
 // ModelConfig holds the JSONB config stored per model.
 type ModelConfig struct {
-	Dimensions      *int     `json:"dimensions,omitempty"`
-	Compatibilities []string `json:"compatibilities,omitempty"`
-	ContextWindow   *int     `json:"context_window,omitempty"`
+	Dimensions      *int          `json:"dimensions,omitempty"`
+	Compatibilities []string      `json:"compatibilities,omitempty"`
+	ContextWindow   *int          `json:"context_window,omitempty"`
+	Pricing         *ModelPricing `json:"pricing,omitempty"`
+}
+
+// ModelPricing holds model prices in USD per million tokens. Cached input
+// and reasoning tokens are billed at the input and output prices when their
+// own price is zero.
+type ModelPricing struct {
+	Input       float64 `json:"input"`
+	Output      float64 `json:"output"`
+	CachedInput float64 `json:"cached_input,omitempty"`
+	Reasoning   float64 `json:"reasoning,omitempty"`
+}
+
+// TokenCounts is the token usage a cost is computed from. Input includes
+// CachedInput and Output includes Reasoning.
+type TokenCounts struct {
+	Input       int
+	CachedInput int
+	Output      int
+	Reasoning   int
+}
+
+// Cost returns the USD cost of the given token usage.
+func (p ModelPricing) Cost(c TokenCounts) float64 {
+	cached := min(max(c.CachedInput, 0), max(c.Input, 0))
+	reasoning := min(max(c.Reasoning, 0), max(c.Output, 0))
+	cachedPrice := p.CachedInput
+	if cachedPrice == 0 {
+		cachedPrice = p.Input
+	}
+	reasoningPrice := p.Reasoning
+	if reasoningPrice == 0 {
+		reasoningPrice = p.Output
+	}
+	total := float64(max(c.Input, 0)-cached)*p.Input +
+		float64(cached)*cachedPrice +
+		float64(max(c.Output, 0)-reasoning)*p.Output +
+		float64(reasoning)*reasoningPrice
+	return total / 1_000_000
 }
 
 type Model struct {
@@ -72,6 +111,11 @@ func (m *Model) Validate() error {
 			return errors.New("invalid compatibility: " + c)
 		}
 	}
+	if p := m.Config.Pricing; p != nil {
+		if p.Input < 0 || p.Output < 0 || p.CachedInput < 0 || p.Reasoning < 0 {
+			return errors.New("pricing must not be negative")
+		}
+	}
 	return nil
 }
 
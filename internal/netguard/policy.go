@@ -0,0 +1,262 @@
+// Package netguard enforces the outbound HTTP policy for requests whose
+// destination comes from a model, a message or a bot setting rather than
+// from the operator: private destinations are refused after DNS
+// resolution, redirects are re-checked and response bodies are capped.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultMaxRedirects bounds the redirects followed when a policy does
+	// not set its own limit.
+	DefaultMaxRedirects = 5
+	defaultDialTimeout  = 10 * time.Second
+)
+
+var (
+	ErrBlockedScheme     = errors.New("only http and https urls are allowed")
+	ErrBlockedAddress    = errors.New("destination address is not allowed")
+	ErrBlockedDomain     = errors.New("destination domain is not allowed")
+	ErrTooManyRedirects  = errors.New("too many redirects")
+	ErrBodyTooLarge      = errors.New("response body too large")
+	ErrInvalidDomainRule = errors.New("domain rules must be host names such as example.com")
+)
+
+// blockedPrefixes are non-public ranges not covered by the netip.Addr
+// predicates used in IsPublicAddr.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // TEST-NET-1
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // TEST-NET-2
+	netip.MustParsePrefix("203.0.113.0/24"),  // TEST-NET-3
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, may map to private IPv4
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+}
+
+// Policy describes where an outbound request may go and how much it may
+// read. The zero value denies private destinations and places no limit on
+// domains or body size.
+type Policy struct {
+	// AllowPrivate permits loopback, private and link-local destinations.
+	// Only set it for endpoints the operator configured, such as a
+	// self-hosted search engine.
+	AllowPrivate bool
+	// AllowDomains, when non-empty, restricts requests to these domains
+	// and their subdomains.
+	AllowDomains []string
+	// DenyDomains refuses these domains and their subdomains. Deny rules
+	// win over allow rules.
+	DenyDomains []string
+	// MaxBodyBytes caps the response body; 0 means unlimited.
+	MaxBodyBytes int64
+	// MaxRedirects caps followed redirects; 0 means DefaultMaxRedirects.
+	MaxRedirects int
+	// Timeout bounds the whole request, including reading the body.
+	Timeout time.Duration
+}
+
+// Client returns an HTTP client that enforces the policy on the first
+// request, on every redirect and on every dialed address.
+func (p Policy) Client() *http.Client {
+	dialer := &net.Dialer{Timeout: defaultDialTimeout, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !p.AllowPrivate {
+		// The dialer sees the resolved address, so names that resolve (or
+		// rebind) to private ranges are caught here. A proxy would hide
+		// the real destination from this check.
+		dialer.Control = controlPublicOnly
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+	maxRedirects := p.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+	return &http.Client{
+		Timeout:   p.Timeout,
+		Transport: &guardedTransport{policy: p, base: transport},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("%w: max %d", ErrTooManyRedirects, maxRedirects)
+			}
+			return p.CheckURL(req.URL)
+		},
+	}
+}
+
+// CheckURL reports whether u may be requested under the policy. Host names
+// are checked against the domain rules; literal IP addresses are also
+// checked against the private ranges. Resolved addresses are checked when
+// dialing.
+func (p Policy) CheckURL(u *url.URL) error {
+	if u == nil {
+		return ErrBlockedScheme
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+	default:
+		return fmt.Errorf("%w: %s", ErrBlockedScheme, u.Scheme)
+	}
+	host := normalizeHost(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("%w: missing host", ErrBlockedDomain)
+	}
+	if matchDomain(p.DenyDomains, host) {
+		return fmt.Errorf("%w: %s", ErrBlockedDomain, host)
+	}
+	if len(p.AllowDomains) > 0 && !matchDomain(p.AllowDomains, host) {
+		return fmt.Errorf("%w: %s is not in the allow list", ErrBlockedDomain, host)
+	}
+	if !p.AllowPrivate {
+		if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+			return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+		}
+	}
+	return nil
+}
+
+// IsPublicAddr reports whether addr is a globally routable unicast address.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeDomains cleans user-supplied domain rules: it lowercases them,
+// drops empty entries, duplicates and leading "*." wildcards, and rejects
+// entries that are not bare host names.
+func NormalizeDomains(rules []string) ([]string, error) {
+	result := make([]string, 0, len(rules))
+	seen := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		host := normalizeHost(strings.TrimPrefix(strings.TrimSpace(rule), "*."))
+		if host == "" {
+			continue
+		}
+		if strings.ContainsAny(host, "/:@?#* ") && !isIPv6(host) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDomainRule, rule)
+		}
+		if _, ok := seen[host]; ok {
+			continue
+		}
+		seen[host] = struct{}{}
+		result = append(result, host)
+	}
+	return result, nil
+}
+
+func controlPublicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !IsPublicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	return nil
+}
+
+// matchDomain reports whether host equals a rule or is a subdomain of one.
+func matchDomain(rules []string, host string) bool {
+	for _, rule := range rules {
+		rule = normalizeHost(strings.TrimPrefix(strings.TrimSpace(rule), "*."))
+		if rule == "" {
+			continue
+		}
+		if host == rule || strings.HasSuffix(host, "."+rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	host = strings.TrimPrefix(strings.TrimSuffix(host, "]"), "[")
+	return strings.TrimSuffix(host, ".")
+}
+
+func isIPv6(host string) bool {
+	addr, err := netip.ParseAddr(host)
+	return err == nil && addr.Is6()
+}
+
+// guardedTransport checks the first request of a client and caps response
+// bodies. Redirects are checked by the client's CheckRedirect.
+type guardedTransport struct {
+	policy Policy
+	base   http.RoundTripper
+}
+
+func (t *guardedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.policy.CheckURL(req.URL); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || t.policy.MaxBodyBytes <= 0 {
+		return resp, err
+	}
+	if resp.ContentLength > t.policy.MaxBodyBytes {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: max %d bytes", ErrBodyTooLarge, t.policy.MaxBodyBytes)
+	}
+	resp.Body = LimitBody(resp.Body, t.policy.MaxBodyBytes)
+	return resp, nil
+}
+
+// LimitBody wraps body so reads fail with ErrBodyTooLarge once more than
+// maxBytes have been read.
+func LimitBody(body io.ReadCloser, maxBytes int64) io.ReadCloser {
+	return &limitedBody{ReadCloser: body, limit: maxBytes, remaining: maxBytes}
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	limit     int64
+	remaining int64
+	exceeded  bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, fmt.Errorf("%w: max %d bytes", ErrBodyTooLarge, b.limit)
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		b.exceeded = true
+		return n, fmt.Errorf("%w: max %d bytes", ErrBodyTooLarge, b.limit)
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,205 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func get(t *testing.T, client *http.Client, rawURL string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, rawURL, nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	return client.Do(req)
+}
+
+func TestClientBlocksLoopbackListener(t *testing.T) {
+	t.Parallel()
+
+	var hit atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { hit.Store(true) }))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	client := Policy{}.Client()
+	for _, rawURL := range []string{server.URL, "http://localhost:" + port} {
+		resp, err := get(t, client, rawURL)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		if !errors.Is(err, ErrBlockedAddress) {
+			t.Errorf("GET %s: expected ErrBlockedAddress, got %v", rawURL, err)
+		}
+	}
+	if hit.Load() {
+		t.Error("blocked request reached the listener")
+	}
+}
+
+func TestClientAllowPrivate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	resp, err := get(t, Policy{AllowPrivate: true}.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "ok" {
+		t.Fatalf("body = %q, want ok", body)
+	}
+}
+
+func TestClientRechecksRedirects(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/denied":
+			http.Redirect(w, r, "http://evil.example/", http.StatusFound)
+		case "/scheme":
+			http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+		default:
+			http.Redirect(w, r, r.URL.Path, http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	client := Policy{AllowPrivate: true, DenyDomains: []string{"example"}, MaxRedirects: 2}.Client()
+	cases := map[string]error{
+		"/denied": ErrBlockedDomain,
+		"/scheme": ErrBlockedScheme,
+		"/loop":   ErrTooManyRedirects,
+	}
+	for path, want := range cases {
+		resp, err := get(t, client, server.URL+path)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		if !errors.Is(err, want) {
+			t.Errorf("GET %s: expected %v, got %v", path, want, err)
+		}
+	}
+
+	// A public first hop redirecting to a metadata address is refused
+	// before anything is dialed.
+	target, _ := url.Parse("http://169.254.169.254/latest/meta-data/")
+	redirect := &http.Request{URL: target}
+	if err := (Policy{}).Client().CheckRedirect(redirect, []*http.Request{{}}); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("redirect to metadata address: expected ErrBlockedAddress, got %v", err)
+	}
+}
+
+func TestClientLimitsBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/declared" {
+			w.Header().Set("Content-Length", "999999")
+			_, _ = w.Write([]byte("x"))
+			return
+		}
+		// Chunked, so the size is only known while reading.
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(strings.Repeat("x", 64)))
+	}))
+	defer server.Close()
+
+	client := Policy{AllowPrivate: true, MaxBodyBytes: 16}.Client()
+	if _, err := get(t, client, server.URL+"/declared"); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("declared length: expected ErrBodyTooLarge, got %v", err)
+	}
+	resp, err := get(t, client, server.URL+"/streamed")
+	if err != nil {
+		t.Fatalf("GET streamed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("streamed: expected ErrBodyTooLarge, got %v", err)
+	}
+	if len(body) != 16 {
+		t.Errorf("read %d bytes before failing, want 16", len(body))
+	}
+}
+
+func TestCheckURLDomainRules(t *testing.T) {
+	t.Parallel()
+
+	policy := Policy{AllowDomains: []string{"*.example.com", "docs.go.dev"}, DenyDomains: []string{"secret.example.com"}}
+	cases := []struct {
+		url  string
+		want error
+	}{
+		{"https://example.com/a", nil},
+		{"https://API.Example.com./a", nil},
+		{"https://docs.go.dev", nil},
+		{"https://go.dev", ErrBlockedDomain},
+		{"https://notexample.com", ErrBlockedDomain},
+		{"https://x.secret.example.com", ErrBlockedDomain},
+		{"ftp://example.com", ErrBlockedScheme},
+	}
+	for _, tc := range cases {
+		u, _ := url.Parse(tc.url)
+		err := policy.CheckURL(u)
+		if tc.want == nil && err != nil {
+			t.Errorf("%s: unexpected error %v", tc.url, err)
+		}
+		if tc.want != nil && !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.url, tc.want, err)
+		}
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	t.Parallel()
+
+	blocked := []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"100.64.0.1", "0.0.0.0", "255.255.255.255", "::1", "fe80::1", "fd00::1",
+		"::ffff:127.0.0.1", "64:ff9b::a00:1",
+	}
+	for _, raw := range blocked {
+		if IsPublicAddr(netip.MustParseAddr(raw)) {
+			t.Errorf("%s should not be public", raw)
+		}
+	}
+	for _, raw := range []string{"1.1.1.1", "93.184.216.34", "2606:4700:4700::1111"} {
+		if !IsPublicAddr(netip.MustParseAddr(raw)) {
+			t.Errorf("%s should be public", raw)
+		}
+	}
+}
+
+func TestNormalizeDomains(t *testing.T) {
+	t.Parallel()
+
+	got, err := NormalizeDomains([]string{" *.Example.com ", "example.com", "", "intranet.", "::1"})
+	if err != nil {
+		t.Fatalf("NormalizeDomains: %v", err)
+	}
+	want := []string{"example.com", "intranet", "::1"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for _, bad := range []string{"https://example.com", "example.com/path", "user@example.com"} {
+		if _, err := NormalizeDomains([]string{bad}); !errors.Is(err, ErrInvalidDomainRule) {
+			t.Errorf("%q: expected ErrInvalidDomainRule, got %v", bad, err)
+		}
+	}
+}
@@ -19,7 +19,16 @@ type ModelDefinition struct {
 
 // ModelConfig mirrors the JSONB config stored per model.
 type ModelConfig struct {
-	Dimensions      *int     `yaml:"dimensions,omitempty"      json:"dimensions,omitempty"`
-	Compatibilities []string `yaml:"compatibilities,omitempty" json:"compatibilities,omitempty"`
-	ContextWindow   *int     `yaml:"context_window,omitempty"  json:"context_window,omitempty"`
+	Dimensions      *int          `yaml:"dimensions,omitempty"      json:"dimensions,omitempty"`
+	Compatibilities []string      `yaml:"compatibilities,omitempty" json:"compatibilities,omitempty"`
+	ContextWindow   *int          `yaml:"context_window,omitempty"  json:"context_window,omitempty"`
+	Pricing         *ModelPricing `yaml:"pricing,omitempty"         json:"pricing,omitempty"`
+}
+
+// ModelPricing mirrors models.ModelPricing: USD per million tokens.
+type ModelPricing struct {
+	Input       float64 `yaml:"input"                  json:"input"`
+	Output      float64 `yaml:"output"                 json:"output"`
+	CachedInput float64 `yaml:"cached_input,omitempty" json:"cached_input,omitempty"`
+	Reasoning   float64 `yaml:"reasoning,omitempty"    json:"reasoning,omitempty"`
 }
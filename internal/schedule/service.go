@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	_ "time/tzdata" // schedules name IANA zones; slim images may lack zoneinfo
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/memohai/memoh/internal/auth"
 	"github.com/memohai/memoh/internal/boot"
+	"github.com/memohai/memoh/internal/channel"
 	"github.com/memohai/memoh/internal/db"
 	"github.com/memohai/memoh/internal/db/sqlc"
 )
@@ -26,16 +28,34 @@ type SessionCreator interface {
 	CreateSession(ctx context.Context, botID, sessionType string) (string, error)
 }
 
+// Sender delivers a run's final answer through a channel adapter.
+type Sender interface {
+	Send(ctx context.Context, botID string, channelType channel.ChannelType, req channel.SendRequest) error
+}
+
+// ChannelResolver maps platform names to channel types.
+type ChannelResolver interface {
+	ParseChannelType(raw string) (channel.ChannelType, error)
+}
+
+// oneShotMaxCalls caps one-shot schedules so the call counter disables them
+// after they fire.
+const oneShotMaxCalls = 1
+
 type Service struct {
 	queries        *sqlc.Queries
 	cron           *cron.Cron
 	parser         cron.Parser
 	triggerer      Triggerer
 	sessionCreator SessionCreator
+	sender         Sender
+	channels       ChannelResolver
 	jwtSecret      string
 	logger         *slog.Logger
+	now            func() time.Time
 	mu             sync.Mutex
 	jobs           map[string]cron.EntryID
+	timers         map[string]*time.Timer
 }
 
 func NewService(log *slog.Logger, queries *sqlc.Queries, triggerer Triggerer, sessionCreator SessionCreator, runtimeConfig *boot.RuntimeConfig) *Service {
@@ -49,12 +69,21 @@ func NewService(log *slog.Logger, queries *sqlc.Queries, triggerer Triggerer, se
 		sessionCreator: sessionCreator,
 		jwtSecret:      runtimeConfig.JwtSecret,
 		logger:         log.With(slog.String("service", "schedule")),
+		now:            time.Now,
 		jobs:           map[string]cron.EntryID{},
+		timers:         map[string]*time.Timer{},
 	}
 	c.Start()
 	return service
 }
 
+// SetDelivery configures how final answers reach delivery targets.
+// This allows breaking dependency cycles in the DI graph.
+func (s *Service) SetDelivery(sender Sender, channels ChannelResolver) {
+	s.sender = sender
+	s.channels = channels
+}
+
 func (s *Service) Bootstrap(ctx context.Context) error {
 	if s.queries == nil {
 		return errors.New("schedule queries not configured")
@@ -75,11 +104,16 @@ func (s *Service) Create(ctx context.Context, botID string, req CreateRequest) (
 	if s.queries == nil {
 		return Schedule{}, errors.New("schedule queries not configured")
 	}
-	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Description) == "" || strings.TrimSpace(req.Pattern) == "" || strings.TrimSpace(req.Command) == "" {
-		return Schedule{}, errors.New("name, description, pattern, command are required")
+	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Description) == "" || strings.TrimSpace(req.Command) == "" {
+		return Schedule{}, errors.New("name, description, command are required")
 	}
-	if _, err := s.parser.Parse(req.Pattern); err != nil {
-		return Schedule{}, fmt.Errorf("invalid cron pattern: %w", err)
+	timing, err := s.resolveTiming(req.Pattern, req.Timezone, req.RunAt, req.In)
+	if err != nil {
+		return Schedule{}, err
+	}
+	deliveryPlatform, deliveryTarget, err := s.resolveDelivery(req.DeliveryPlatform, req.DeliveryTarget)
+	if err != nil {
+		return Schedule{}, err
 	}
 	pgBotID, err := db.ParseUUID(botID)
 	if err != nil {
@@ -92,18 +126,25 @@ func (s *Service) Create(ctx context.Context, botID string, req CreateRequest) (
 		}
 		maxCalls = pgtype.Int4{Int32: int32(*req.MaxCalls.Value), Valid: true} //nolint:gosec // bounds checked above
 	}
+	if timing.runAt.Valid {
+		maxCalls = pgtype.Int4{Int32: oneShotMaxCalls, Valid: true}
+	}
 	enabled := true
 	if req.Enabled != nil {
 		enabled = *req.Enabled
 	}
 	row, err := s.queries.CreateSchedule(ctx, sqlc.CreateScheduleParams{
-		Name:        req.Name,
-		Description: req.Description,
-		Pattern:     req.Pattern,
-		MaxCalls:    maxCalls,
-		Enabled:     enabled,
-		Command:     req.Command,
-		BotID:       pgBotID,
+		Name:             req.Name,
+		Description:      req.Description,
+		Pattern:          timing.pattern,
+		MaxCalls:         maxCalls,
+		Enabled:          enabled,
+		Command:          req.Command,
+		BotID:            pgBotID,
+		Timezone:         timing.timezone,
+		RunAt:            timing.runAt,
+		DeliveryPlatform: deliveryPlatform,
+		DeliveryTarget:   deliveryTarget,
 	})
 	if err != nil {
 		return Schedule{}, err
@@ -164,18 +205,30 @@ func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (Sch
 	if req.Description != nil {
 		description = *req.Description
 	}
-	pattern := existing.Pattern
-	if req.Pattern != nil {
-		if _, err := s.parser.Parse(*req.Pattern); err != nil {
-			return Schedule{}, fmt.Errorf("invalid cron pattern: %w", err)
-		}
-		pattern = *req.Pattern
+	timing, err := s.resolveUpdatedTiming(existing, req)
+	if err != nil {
+		return Schedule{}, err
+	}
+	deliveryPlatform, deliveryTarget := existing.DeliveryPlatform, existing.DeliveryTarget
+	if req.DeliveryPlatform != nil {
+		deliveryPlatform = *req.DeliveryPlatform
+	}
+	if req.DeliveryTarget != nil {
+		deliveryTarget = *req.DeliveryTarget
+	}
+	deliveryPlatform, deliveryTarget, err = s.resolveDelivery(deliveryPlatform, deliveryTarget)
+	if err != nil {
+		return Schedule{}, err
 	}
 	command := existing.Command
 	if req.Command != nil {
 		command = *req.Command
 	}
 	maxCalls := existing.MaxCalls
+	if existing.RunAt.Valid && !timing.runAt.Valid {
+		// A one-shot turned recurring drops its implicit single-call cap.
+		maxCalls = pgtype.Int4{Valid: false}
+	}
 	if req.MaxCalls.Set {
 		if req.MaxCalls.Value == nil {
 			maxCalls = pgtype.Int4{Valid: false}
@@ -186,18 +239,25 @@ func (s *Service) Update(ctx context.Context, id string, req UpdateRequest) (Sch
 			maxCalls = pgtype.Int4{Int32: int32(*req.MaxCalls.Value), Valid: true} //nolint:gosec // bounds checked above
 		}
 	}
+	if timing.runAt.Valid {
+		maxCalls = pgtype.Int4{Int32: oneShotMaxCalls, Valid: true}
+	}
 	enabled := existing.Enabled
 	if req.Enabled != nil {
 		enabled = *req.Enabled
 	}
 	updated, err := s.queries.UpdateSchedule(ctx, sqlc.UpdateScheduleParams{
-		ID:          pgID,
-		Name:        name,
-		Description: description,
-		Pattern:     pattern,
-		MaxCalls:    maxCalls,
-		Enabled:     enabled,
-		Command:     command,
+		ID:               pgID,
+		Name:             name,
+		Description:      description,
+		Pattern:          timing.pattern,
+		MaxCalls:         maxCalls,
+		Enabled:          enabled,
+		Command:          command,
+		Timezone:         timing.timezone,
+		RunAt:            timing.runAt,
+		DeliveryPlatform: deliveryPlatform,
+		DeliveryTarget:   deliveryTarget,
 	})
 	if err != nil {
 		return Schedule{}, err
@@ -292,6 +352,11 @@ func (s *Service) runSchedule(ctx context.Context, sched Schedule) error {
 		Command:     sched.Command,
 		OwnerUserID: ownerUserID,
 		SessionID:   sessionID,
+		Timezone:    sched.Timezone,
+		RunAt:       sched.RunAt,
+
+		DeliveryPlatform: sched.DeliveryPlatform,
+		DeliveryTarget:   sched.DeliveryTarget,
 	}, token)
 	if triggerErr != nil {
 		s.completeLog(ctx, logRow.ID, "error", "", triggerErr.Error(), nil, pgtype.UUID{})
@@ -300,6 +365,7 @@ func (s *Service) runSchedule(ctx context.Context, sched Schedule) error {
 
 	modelID := db.ParseUUIDOrEmpty(result.ModelID)
 	s.completeLog(ctx, logRow.ID, result.Status, result.Text, "", result.UsageBytes, modelID)
+	s.deliver(ctx, sched, result.Text)
 	s.logger.Info("schedule completed", slog.String("schedule_id", sched.ID), slog.String("status", result.Status))
 	return nil
 }
@@ -474,7 +540,15 @@ func (s *Service) scheduleJob(ctx context.Context, schedule sqlc.Schedule) error
 			s.logger.Error("scheduled job failed", slog.String("schedule_id", schedule.ID.String()), slog.Any("error", err))
 		}
 	}
-	entryID, err := s.cron.AddFunc(schedule.Pattern, job)
+	if schedule.RunAt.Valid {
+		// One-shots missed while the server was down fire right away.
+		timer := time.AfterFunc(max(schedule.RunAt.Time.Sub(s.now()), 0), job)
+		s.mu.Lock()
+		s.timers[id] = timer
+		s.mu.Unlock()
+		return nil
+	}
+	entryID, err := s.cron.AddFunc(cronSpec(schedule.Pattern, schedule.Timezone), job)
 	if err != nil {
 		return err
 	}
@@ -504,6 +578,156 @@ func (s *Service) removeJob(id string) {
 		s.cron.Remove(entryID)
 		delete(s.jobs, id)
 	}
+	if timer, ok := s.timers[id]; ok {
+		timer.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// timing is the validated part of a schedule that decides when it runs.
+type timing struct {
+	pattern  string
+	timezone string
+	runAt    pgtype.Timestamptz
+}
+
+// resolveTiming validates a recurring pattern or a one-shot run time given
+// as an absolute runAt or a duration from now.
+func (s *Service) resolveTiming(pattern, timezone string, runAt *time.Time, in string) (timing, error) {
+	t := timing{pattern: strings.TrimSpace(pattern), timezone: strings.TrimSpace(timezone)}
+	if err := validateTimezone(t.timezone); err != nil {
+		return timing{}, err
+	}
+	in = strings.TrimSpace(in)
+	oneShot := runAt != nil || in != ""
+	switch {
+	case oneShot && t.pattern != "":
+		return timing{}, errors.New("pattern cannot be combined with run_at or in")
+	case runAt != nil && in != "":
+		return timing{}, errors.New("run_at and in are mutually exclusive")
+	case !oneShot && t.pattern == "":
+		return timing{}, errors.New("one of pattern, run_at or in is required")
+	}
+	if !oneShot {
+		if _, err := s.parser.Parse(cronSpec(t.pattern, t.timezone)); err != nil {
+			return timing{}, fmt.Errorf("invalid cron pattern: %w", err)
+		}
+		return t, nil
+	}
+	now := s.now()
+	at := now
+	if runAt != nil {
+		at = *runAt
+	} else {
+		d, err := time.ParseDuration(in)
+		if err != nil || d <= 0 {
+			return timing{}, fmt.Errorf("invalid in %q: use a positive duration such as 30m or 2h", in)
+		}
+		at = now.Add(d)
+	}
+	if !at.After(now) {
+		return timing{}, errors.New("run_at must be in the future")
+	}
+	t.runAt = pgtype.Timestamptz{Time: at.UTC(), Valid: true}
+	return t, nil
+}
+
+// resolveUpdatedTiming applies an update's timing fields to an existing
+// schedule. An unchanged one-shot keeps its run time even if it has passed.
+func (s *Service) resolveUpdatedTiming(existing sqlc.Schedule, req UpdateRequest) (timing, error) {
+	timezone := existing.Timezone
+	if req.Timezone != nil {
+		timezone = *req.Timezone
+	}
+	in := ""
+	if req.In != nil {
+		in = *req.In
+	}
+	switch {
+	case req.Pattern != nil:
+		return s.resolveTiming(*req.Pattern, timezone, nil, "")
+	case req.RunAt != nil || strings.TrimSpace(in) != "":
+		return s.resolveTiming("", timezone, req.RunAt, in)
+	case existing.RunAt.Valid:
+		timezone = strings.TrimSpace(timezone)
+		if err := validateTimezone(timezone); err != nil {
+			return timing{}, err
+		}
+		return timing{timezone: timezone, runAt: existing.RunAt}, nil
+	default:
+		return s.resolveTiming(existing.Pattern, timezone, nil, "")
+	}
+}
+
+// resolveDelivery validates an optional delivery target. Platform and
+// target must be given together.
+func (s *Service) resolveDelivery(platform, target string) (string, string, error) {
+	platform, target = strings.TrimSpace(platform), strings.TrimSpace(target)
+	if platform == "" && target == "" {
+		return "", "", nil
+	}
+	if platform == "" || target == "" {
+		return "", "", errors.New("delivery_platform and delivery_target must be set together")
+	}
+	if s.channels != nil {
+		channelType, err := s.channels.ParseChannelType(platform)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid delivery_platform: %w", err)
+		}
+		platform = channelType.String()
+	}
+	return platform, target, nil
+}
+
+// deliver sends a run's final answer to the schedule's delivery target.
+// Failures are logged; the run itself has already completed.
+func (s *Service) deliver(ctx context.Context, sched Schedule, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" || sched.DeliveryPlatform == "" || sched.DeliveryTarget == "" {
+		return
+	}
+	if s.sender == nil || s.channels == nil {
+		s.logger.Warn("schedule delivery skipped: sender not configured", slog.String("schedule_id", sched.ID))
+		return
+	}
+	channelType, err := s.channels.ParseChannelType(sched.DeliveryPlatform)
+	if err != nil {
+		s.logger.Warn("schedule delivery skipped", slog.String("schedule_id", sched.ID), slog.String("platform", sched.DeliveryPlatform), slog.Any("error", err))
+		return
+	}
+	msg := channel.Message{Text: text}
+	if channel.ContainsMarkdown(text) {
+		msg.Format = channel.MessageFormatMarkdown
+	}
+	if err := s.sender.Send(ctx, sched.BotID, channelType, channel.SendRequest{
+		Target:  sched.DeliveryTarget,
+		Message: msg,
+	}); err != nil {
+		s.logger.Warn("schedule delivery failed",
+			slog.String("schedule_id", sched.ID),
+			slog.String("platform", sched.DeliveryPlatform),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func validateTimezone(timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	return nil
+}
+
+// cronSpec evaluates pattern in timezone using the CRON_TZ prefix the cron
+// parser understands. An empty timezone keeps the server's local zone.
+func cronSpec(pattern, timezone string) string {
+	if timezone == "" {
+		return pattern
+	}
+	return "CRON_TZ=" + timezone + " " + pattern
 }
 
 func toSchedule(row sqlc.Schedule) Schedule {
@@ -516,6 +740,14 @@ func toSchedule(row sqlc.Schedule) Schedule {
 		Enabled:      row.Enabled,
 		Command:      row.Command,
 		BotID:        row.BotID.String(),
+		Timezone:     row.Timezone,
+
+		DeliveryPlatform: row.DeliveryPlatform,
+		DeliveryTarget:   row.DeliveryTarget,
+	}
+	if row.RunAt.Valid {
+		runAt := row.RunAt.Time
+		item.RunAt = &runAt
 	}
 	if row.MaxCalls.Valid {
 		maxCalls := int(row.MaxCalls.Int32)
@@ -537,3 +769,33 @@ func toUUID(id string) pgtype.UUID {
 	}
 	return pgID
 }
+
+// runAtLayouts are the wall-clock formats ParseRunAt accepts besides RFC 3339.
+var runAtLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// ParseRunAt parses a one-shot run time. RFC 3339 values carry their own
+// offset; wall-clock values are read in timezone, or UTC when it is empty.
+func ParseRunAt(raw, timezone string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	loc := time.UTC
+	if timezone = strings.TrimSpace(timezone); timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+	}
+	for _, layout := range runAtLayouts {
+		if t, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid run_at %q: use RFC 3339 or \"YYYY-MM-DD HH:MM\"", raw)
+}
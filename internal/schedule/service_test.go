@@ -7,6 +7,10 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/robfig/cron/v3"
+
+	"github.com/memohai/memoh/internal/db/sqlc"
 )
 
 func TestGenerateTriggerToken(t *testing.T) {
@@ -73,3 +77,126 @@ func TestGenerateTriggerToken_EmptyUserID(t *testing.T) {
 		t.Fatal("expected error for empty user ID")
 	}
 }
+
+func newTimingTestService(now time.Time) *Service {
+	return &Service{
+		parser: cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor),
+		logger: slog.Default(),
+		now:    func() time.Time { return now },
+	}
+}
+
+func TestResolveTiming(t *testing.T) {
+	now := time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+	svc := newTimingTestService(now)
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	recurring, err := svc.resolveTiming("0 9 * * *", "Asia/Shanghai", nil, "")
+	if err != nil {
+		t.Fatalf("recurring: %v", err)
+	}
+	if recurring.runAt.Valid || recurring.timezone != "Asia/Shanghai" {
+		t.Fatalf("unexpected recurring timing: %#v", recurring)
+	}
+
+	in, err := svc.resolveTiming("", "", nil, "2h")
+	if err != nil {
+		t.Fatalf("in: %v", err)
+	}
+	if !in.runAt.Valid || !in.runAt.Time.Equal(now.Add(2*time.Hour)) {
+		t.Fatalf("expected run_at two hours from now, got %#v", in.runAt)
+	}
+
+	cases := map[string]struct {
+		pattern, timezone, in string
+		runAt                 *time.Time
+	}{
+		"missing timing":     {},
+		"bad timezone":       {pattern: "0 9 * * *", timezone: "Mars/Olympus"},
+		"bad pattern":        {pattern: "not a cron"},
+		"pattern and run_at": {pattern: "0 9 * * *", runAt: &future},
+		"run_at and in":      {runAt: &future, in: "1h"},
+		"past run_at":        {runAt: &past},
+		"negative in":        {in: "-5m"},
+		"bad in":             {in: "tomorrow"},
+	}
+	for name, tc := range cases {
+		if _, err := svc.resolveTiming(tc.pattern, tc.timezone, tc.runAt, tc.in); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestResolveUpdatedTiming(t *testing.T) {
+	now := time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+	svc := newTimingTestService(now)
+	oneShot := sqlc.Schedule{RunAt: pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true}}
+
+	kept, err := svc.resolveUpdatedTiming(oneShot, UpdateRequest{})
+	if err != nil {
+		t.Fatalf("unchanged one-shot: %v", err)
+	}
+	if !kept.runAt.Valid || !kept.runAt.Time.Equal(oneShot.RunAt.Time) {
+		t.Fatalf("expected run_at to be kept, got %#v", kept.runAt)
+	}
+
+	pattern := "*/5 * * * *"
+	recurring, err := svc.resolveUpdatedTiming(oneShot, UpdateRequest{Pattern: &pattern})
+	if err != nil {
+		t.Fatalf("switch to pattern: %v", err)
+	}
+	if recurring.runAt.Valid || recurring.pattern != pattern {
+		t.Fatalf("expected recurring timing, got %#v", recurring)
+	}
+
+	timezone := "Europe/Berlin"
+	existing := sqlc.Schedule{Pattern: pattern}
+	rezoned, err := svc.resolveUpdatedTiming(existing, UpdateRequest{Timezone: &timezone})
+	if err != nil {
+		t.Fatalf("change timezone: %v", err)
+	}
+	if rezoned.pattern != pattern || rezoned.timezone != timezone {
+		t.Fatalf("unexpected timing: %#v", rezoned)
+	}
+}
+
+func TestCronSpecUsesTimezone(t *testing.T) {
+	svc := newTimingTestService(time.Now())
+	sched, err := svc.parser.Parse(cronSpec("0 9 * * *", "Asia/Shanghai"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	next := sched.Next(time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2026, 3, 13, 1, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("expected 09:00 Shanghai (%s), got %s", want, next.UTC())
+	}
+	if cronSpec("0 9 * * *", "") != "0 9 * * *" {
+		t.Fatal("expected empty timezone to leave the pattern unchanged")
+	}
+}
+
+func TestParseRunAt(t *testing.T) {
+	got, err := ParseRunAt("2026-03-13 18:30", "Asia/Shanghai")
+	if err != nil {
+		t.Fatalf("wall clock: %v", err)
+	}
+	if want := time.Date(2026, 3, 13, 10, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("expected %s, got %s", want, got.UTC())
+	}
+
+	got, err = ParseRunAt("2026-03-13T18:30:00+02:00", "Asia/Shanghai")
+	if err != nil {
+		t.Fatalf("rfc3339: %v", err)
+	}
+	if want := time.Date(2026, 3, 13, 16, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("expected explicit offset to win, got %s", got.UTC())
+	}
+
+	if _, err := ParseRunAt("next tuesday", ""); err == nil {
+		t.Fatal("expected error for unparseable run_at")
+	}
+	if _, err := ParseRunAt("2026-03-13 18:30", "Nowhere/Town"); err == nil {
+		t.Fatal("expected error for unknown timezone")
+	}
+}
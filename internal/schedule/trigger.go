@@ -1,6 +1,9 @@
 package schedule
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // TriggerPayload describes the parameters passed to the chat side when a schedule triggers.
 type TriggerPayload struct {
@@ -12,6 +15,12 @@ type TriggerPayload struct {
 	Command     string
 	OwnerUserID string
 	SessionID   string
+	Timezone    string
+	RunAt       *time.Time
+	// DeliveryPlatform and DeliveryTarget are set when the final answer is
+	// delivered to a channel automatically.
+	DeliveryPlatform string
+	DeliveryTarget   string
 }
 
 // TriggerResult carries execution metadata back from the resolver.
@@ -17,6 +17,17 @@ type Schedule struct {
 	Enabled      bool      `json:"enabled"`
 	Command      string    `json:"command"`
 	BotID        string    `json:"bot_id"`
+	// Timezone is the IANA zone the pattern is evaluated in. Empty means the
+	// server's local zone.
+	Timezone string `json:"timezone,omitempty"`
+	// RunAt is set for one-shot schedules, which have no pattern and disable
+	// themselves after firing once.
+	RunAt *time.Time `json:"run_at,omitempty"`
+	// DeliveryPlatform and DeliveryTarget name the channel conversation that
+	// receives each run's final answer. Both are empty when the run only
+	// delivers what it sends itself.
+	DeliveryPlatform string `json:"delivery_platform,omitempty"`
+	DeliveryTarget   string `json:"delivery_target,omitempty"`
 }
 
 type NullableInt struct {
@@ -49,22 +60,38 @@ func (n *NullableInt) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// CreateRequest creates a recurring schedule (Pattern) or a one-shot
+// schedule (RunAt, or In relative to now). Exactly one of them is required.
 type CreateRequest struct {
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
-	Pattern     string      `json:"pattern"`
+	Pattern     string      `json:"pattern,omitempty"`
 	MaxCalls    NullableInt `json:"max_calls,omitempty"`
 	Command     string      `json:"command"`
 	Enabled     *bool       `json:"enabled,omitempty"`
+	Timezone    string      `json:"timezone,omitempty"`
+	RunAt       *time.Time  `json:"run_at,omitempty"`
+	// In is a Go duration such as "2h" or "90m" after which a one-shot
+	// schedule fires.
+	In               string `json:"in,omitempty"`
+	DeliveryPlatform string `json:"delivery_platform,omitempty"`
+	DeliveryTarget   string `json:"delivery_target,omitempty"`
 }
 
+// UpdateRequest changes a schedule. Setting Pattern turns it into a
+// recurring schedule; setting RunAt or In turns it into a one-shot one.
 type UpdateRequest struct {
-	Name        *string     `json:"name,omitempty"`
-	Description *string     `json:"description,omitempty"`
-	Pattern     *string     `json:"pattern,omitempty"`
-	MaxCalls    NullableInt `json:"max_calls,omitempty"`
-	Command     *string     `json:"command,omitempty"`
-	Enabled     *bool       `json:"enabled,omitempty"`
+	Name             *string     `json:"name,omitempty"`
+	Description      *string     `json:"description,omitempty"`
+	Pattern          *string     `json:"pattern,omitempty"`
+	MaxCalls         NullableInt `json:"max_calls,omitempty"`
+	Command          *string     `json:"command,omitempty"`
+	Enabled          *bool       `json:"enabled,omitempty"`
+	Timezone         *string     `json:"timezone,omitempty"`
+	RunAt            *time.Time  `json:"run_at,omitempty"`
+	In               *string     `json:"in,omitempty"`
+	DeliveryPlatform *string     `json:"delivery_platform,omitempty"`
+	DeliveryTarget   *string     `json:"delivery_target,omitempty"`
 }
 
 type ListResponse struct {
@@ -21,7 +21,7 @@ type Handler interface {
 	Register(e *echo.Echo)
 }
 
-func NewServer(log *slog.Logger, addr string, jwtSecret string,
+func NewServer(log *slog.Logger, addr string, jwtSecret string, apiKeys auth.APIKeyAuthenticator,
 	handlers ...Handler,
 ) *Server {
 	if addr == "" {
@@ -46,9 +46,9 @@ func NewServer(log *slog.Logger, addr string, jwtSecret string,
 			return nil
 		},
 	}))
-	e.Use(auth.JWTMiddleware(jwtSecret, func(c echo.Context) bool {
+	e.Use(auth.Middleware(jwtSecret, func(c echo.Context) bool {
 		return shouldSkipJWT(c.Request().URL.Path)
-	}))
+	}, apiKeys))
 
 	for _, h := range handlers {
 		if h != nil {
@@ -84,11 +84,17 @@ func shouldSkipJWT(path string) bool {
 	if strings.HasPrefix(path, "/channels/feishu/webhook/") {
 		return true
 	}
+	if strings.HasPrefix(path, "/channels/webhook/") {
+		return true
+	}
 	if strings.HasPrefix(path, "/email/mailgun/webhook/") {
 		return true
 	}
 	if strings.HasPrefix(path, "/email/oauth/callback") {
 		return true
 	}
+	if strings.HasPrefix(path, "/triggers/webhook/") {
+		return true
+	}
 	return false
 }
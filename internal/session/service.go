@@ -35,6 +35,7 @@ const (
 	TypeHeartbeat = "heartbeat"
 	TypeSchedule  = "schedule"
 	TypeSubagent  = "subagent"
+	TypeTrigger   = "trigger"
 )
 
 // CreateInput holds input for creating a new session.
@@ -7,6 +7,8 @@ import (
 	"log/slog"
 	"math"
 	"strings"
+	"time"
+	_ "time/tzdata" // bot timezones name IANA zones; slim images may lack zoneinfo
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -15,6 +17,7 @@ import (
 	"github.com/memohai/memoh/internal/acl"
 	"github.com/memohai/memoh/internal/db"
 	"github.com/memohai/memoh/internal/db/sqlc"
+	"github.com/memohai/memoh/internal/netguard"
 )
 
 type Service struct {
@@ -24,10 +27,17 @@ type Service struct {
 }
 
 var (
-	ErrModelIDAmbiguous = errors.New("model_id is ambiguous across providers")
-	ErrInvalidModelRef  = errors.New("invalid model reference")
+	ErrModelIDAmbiguous   = errors.New("model_id is ambiguous across providers")
+	ErrInvalidModelRef    = errors.New("invalid model reference")
+	ErrInvalidMemoryScope = errors.New("memory_scope_policy must be shared, per_user or private")
+	ErrInvalidTimezone    = errors.New("timezone must be an IANA timezone such as Europe/Berlin")
+	ErrInvalidActiveHours = errors.New("heartbeat_active_start and heartbeat_active_end must both be HH:MM or both be empty")
+	ErrInvalidFetchDomain = errors.New("fetch_allow_domains and fetch_deny_domains must list host names such as example.com")
 )
 
+// maxHeartbeatPacing bounds heartbeat jitter and backoff, in minutes.
+const maxHeartbeatPacing = 7 * 24 * 60
+
 func NewService(log *slog.Logger, queries *sqlc.Queries, aclService *acl.Service) *Service {
 	return &Service{
 		queries: queries,
@@ -51,6 +61,11 @@ func (s *Service) GetBot(ctx context.Context, botID string) (Settings, error) {
 		return Settings{}, err
 	}
 	settings.AllowGuest = allowGuest
+	fallbacks, err := s.loadModelFallbacks(ctx, pgID)
+	if err != nil {
+		return Settings{}, err
+	}
+	settings.ModelFallbacks = fallbacks
 	return settings, nil
 }
 
@@ -70,7 +85,7 @@ func (s *Service) UpsertBot(ctx context.Context, botID string, req UpsertRequest
 	if err != nil {
 		return Settings{}, err
 	}
-	current := normalizeBotSetting(botRow.MaxContextLoadTime, botRow.MaxContextTokens, botRow.Language, allowGuest, botRow.ReasoningEnabled, botRow.ReasoningEffort, botRow.HeartbeatEnabled, botRow.HeartbeatInterval, botRow.CompactionEnabled, botRow.CompactionThreshold)
+	current := normalizeBotSetting(botRow.MaxContextLoadTime, botRow.MaxContextTokens, botRow.Language, allowGuest, botRow.ReasoningEnabled, botRow.ReasoningEffort, botRow.HeartbeatEnabled, botRow.HeartbeatInterval, botRow.CompactionEnabled, botRow.CompactionThreshold, "")
 	if req.MaxContextLoadTime != nil && *req.MaxContextLoadTime > 0 {
 		current.MaxContextLoadTime = *req.MaxContextLoadTime
 	}
@@ -101,6 +116,64 @@ func (s *Service) UpsertBot(ctx context.Context, botID string, req UpsertRequest
 	if req.CompactionThreshold != nil && *req.CompactionThreshold >= 0 {
 		current.CompactionThreshold = *req.CompactionThreshold
 	}
+	storedRow, err := s.queries.GetSettingsByBotID(ctx, pgID)
+	if err != nil {
+		return Settings{}, err
+	}
+	stored := normalizeBotSettingsReadRow(storedRow)
+	current.MemoryScopePolicy = stored.MemoryScopePolicy
+	if req.MemoryScopePolicy != nil {
+		policy := strings.TrimSpace(*req.MemoryScopePolicy)
+		if !isValidMemoryScopePolicy(policy) {
+			return Settings{}, ErrInvalidMemoryScope
+		}
+		current.MemoryScopePolicy = policy
+	}
+	current.Timezone = stored.Timezone
+	if req.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Timezone)
+		if timezone != "" {
+			if _, err := time.LoadLocation(timezone); err != nil {
+				return Settings{}, ErrInvalidTimezone
+			}
+		}
+		current.Timezone = timezone
+	}
+	current.HeartbeatActiveStart = stored.HeartbeatActiveStart
+	current.HeartbeatActiveEnd = stored.HeartbeatActiveEnd
+	if req.HeartbeatActiveStart != nil {
+		current.HeartbeatActiveStart = strings.TrimSpace(*req.HeartbeatActiveStart)
+	}
+	if req.HeartbeatActiveEnd != nil {
+		current.HeartbeatActiveEnd = strings.TrimSpace(*req.HeartbeatActiveEnd)
+	}
+	if !isValidActiveHours(current.HeartbeatActiveStart, current.HeartbeatActiveEnd) {
+		return Settings{}, ErrInvalidActiveHours
+	}
+	current.HeartbeatJitter = stored.HeartbeatJitter
+	if req.HeartbeatJitter != nil && *req.HeartbeatJitter >= 0 {
+		current.HeartbeatJitter = min(*req.HeartbeatJitter, maxHeartbeatPacing)
+	}
+	current.HeartbeatMaxInterval = stored.HeartbeatMaxInterval
+	if req.HeartbeatMaxInterval != nil && *req.HeartbeatMaxInterval >= 0 {
+		current.HeartbeatMaxInterval = min(*req.HeartbeatMaxInterval, maxHeartbeatPacing)
+	}
+	current.FetchAllowDomains = stored.FetchAllowDomains
+	if req.FetchAllowDomains != nil {
+		domains, err := netguard.NormalizeDomains(req.FetchAllowDomains)
+		if err != nil {
+			return Settings{}, ErrInvalidFetchDomain
+		}
+		current.FetchAllowDomains = domains
+	}
+	current.FetchDenyDomains = stored.FetchDenyDomains
+	if req.FetchDenyDomains != nil {
+		domains, err := netguard.NormalizeDomains(req.FetchDenyDomains)
+		if err != nil {
+			return Settings{}, ErrInvalidFetchDomain
+		}
+		current.FetchDenyDomains = domains
+	}
 	chatModelUUID := pgtype.UUID{}
 	if value := strings.TrimSpace(req.ChatModelID); value != "" {
 		modelID, err := s.resolveModelUUID(ctx, value)
@@ -159,6 +232,14 @@ func (s *Service) UpsertBot(ctx context.Context, botID string, req UpsertRequest
 		}
 		ttsModelUUID = modelID
 	}
+	sttModelUUID := pgtype.UUID{}
+	if value := strings.TrimSpace(req.SttModelID); value != "" {
+		modelID, err := db.ParseUUID(value)
+		if err != nil {
+			return Settings{}, err
+		}
+		sttModelUUID = modelID
+	}
 	browserContextUUID := pgtype.UUID{}
 	if value := strings.TrimSpace(req.BrowserContextID); value != "" {
 		ctxID, err := db.ParseUUID(value)
@@ -167,6 +248,13 @@ func (s *Service) UpsertBot(ctx context.Context, botID string, req UpsertRequest
 		}
 		browserContextUUID = ctxID
 	}
+	var fallbackUpdates map[string][]pgtype.UUID
+	if req.ModelFallbacks != nil {
+		fallbackUpdates, err = s.resolveModelFallbacks(ctx, *req.ModelFallbacks)
+		if err != nil {
+			return Settings{}, err
+		}
+	}
 	if current.MaxContextLoadTime < math.MinInt32 || current.MaxContextLoadTime > math.MaxInt32 ||
 		current.MaxContextTokens < math.MinInt32 || current.MaxContextTokens > math.MaxInt32 ||
 		current.HeartbeatInterval < math.MinInt32 || current.HeartbeatInterval > math.MaxInt32 ||
@@ -186,14 +274,24 @@ func (s *Service) UpsertBot(ctx context.Context, botID string, req UpsertRequest
 		HeartbeatPrompt:     "",
 		CompactionEnabled:   current.CompactionEnabled,
 		CompactionThreshold: int32(current.CompactionThreshold), //nolint:gosec // range validated above
-		ChatModelID:         chatModelUUID,
-		HeartbeatModelID:    heartbeatModelUUID,
-		CompactionModelID:   compactionModelUUID,
-		TitleModelID:        titleModelUUID,
-		SearchProviderID:    searchProviderUUID,
-		MemoryProviderID:    memoryProviderUUID,
-		TtsModelID:          ttsModelUUID,
-		BrowserContextID:    browserContextUUID,
+		MemoryScopePolicy:   current.MemoryScopePolicy,
+
+		Timezone:             current.Timezone,
+		HeartbeatActiveStart: current.HeartbeatActiveStart,
+		HeartbeatActiveEnd:   current.HeartbeatActiveEnd,
+		HeartbeatJitter:      int32(current.HeartbeatJitter),      //nolint:gosec // capped to maxHeartbeatPacing
+		HeartbeatMaxInterval: int32(current.HeartbeatMaxInterval), //nolint:gosec // capped to maxHeartbeatPacing
+		FetchAllowDomains:    current.FetchAllowDomains,
+		FetchDenyDomains:     current.FetchDenyDomains,
+		ChatModelID:          chatModelUUID,
+		HeartbeatModelID:     heartbeatModelUUID,
+		CompactionModelID:    compactionModelUUID,
+		TitleModelID:         titleModelUUID,
+		SearchProviderID:     searchProviderUUID,
+		MemoryProviderID:     memoryProviderUUID,
+		TtsModelID:           ttsModelUUID,
+		SttModelID:           sttModelUUID,
+		BrowserContextID:     browserContextUUID,
 	})
 	if err != nil {
 		return Settings{}, err
@@ -205,8 +303,16 @@ func (s *Service) UpsertBot(ctx context.Context, botID string, req UpsertRequest
 	if err := s.setAllowGuest(ctx, botID, createdByUserID, current.AllowGuest); err != nil {
 		return Settings{}, err
 	}
+	if err := s.replaceModelFallbacks(ctx, pgID, fallbackUpdates); err != nil {
+		return Settings{}, err
+	}
 	settings := normalizeBotSettingsWriteRow(updated)
 	settings.AllowGuest = current.AllowGuest
+	fallbacks, err := s.loadModelFallbacks(ctx, pgID)
+	if err != nil {
+		return Settings{}, err
+	}
+	settings.ModelFallbacks = fallbacks
 	return settings, nil
 }
 
@@ -221,10 +327,93 @@ func (s *Service) Delete(ctx context.Context, botID string) error {
 	if err := s.queries.DeleteSettingsByBotID(ctx, pgID); err != nil {
 		return err
 	}
+	if err := s.queries.DeleteBotModelFallbacks(ctx, pgID); err != nil {
+		return err
+	}
 	return s.setAllowGuest(ctx, botID, "", false)
 }
 
-func normalizeBotSetting(maxContextLoadTime int32, maxContextTokens int32, language string, allowGuest bool, reasoningEnabled bool, reasoningEffort string, heartbeatEnabled bool, heartbeatInterval int32, compactionEnabled bool, compactionThreshold int32) Settings {
+func (s *Service) loadModelFallbacks(ctx context.Context, botID pgtype.UUID) (ModelFallbacks, error) {
+	rows, err := s.queries.ListBotModelFallbacks(ctx, botID)
+	if err != nil {
+		return ModelFallbacks{}, fmt.Errorf("list model fallbacks: %w", err)
+	}
+	fallbacks := ModelFallbacks{}
+	for _, row := range rows {
+		modelID := uuid.UUID(row.ModelID.Bytes).String()
+		switch row.Purpose {
+		case ModelPurposeChat:
+			fallbacks.Chat = append(fallbacks.Chat, modelID)
+		case ModelPurposeHeartbeat:
+			fallbacks.Heartbeat = append(fallbacks.Heartbeat, modelID)
+		case ModelPurposeTitle:
+			fallbacks.Title = append(fallbacks.Title, modelID)
+		case ModelPurposeCompaction:
+			fallbacks.Compaction = append(fallbacks.Compaction, modelID)
+		}
+	}
+	return fallbacks, nil
+}
+
+// resolveModelFallbacks validates the requested fallback chains and resolves
+// each model reference to its UUID. Only purposes with a non-nil list are
+// returned, so callers can tell "unchanged" apart from "cleared".
+func (s *Service) resolveModelFallbacks(ctx context.Context, req ModelFallbacks) (map[string][]pgtype.UUID, error) {
+	lists := map[string][]string{
+		ModelPurposeChat:       req.Chat,
+		ModelPurposeHeartbeat:  req.Heartbeat,
+		ModelPurposeTitle:      req.Title,
+		ModelPurposeCompaction: req.Compaction,
+	}
+	result := make(map[string][]pgtype.UUID, len(lists))
+	for purpose, refs := range lists {
+		if refs == nil {
+			continue
+		}
+		seen := make(map[pgtype.UUID]struct{}, len(refs))
+		ids := make([]pgtype.UUID, 0, len(refs))
+		for _, ref := range refs {
+			if strings.TrimSpace(ref) == "" {
+				continue
+			}
+			modelID, err := s.resolveModelUUID(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[modelID]; ok {
+				continue
+			}
+			seen[modelID] = struct{}{}
+			ids = append(ids, modelID)
+		}
+		result[purpose] = ids
+	}
+	return result, nil
+}
+
+func (s *Service) replaceModelFallbacks(ctx context.Context, botID pgtype.UUID, updates map[string][]pgtype.UUID) error {
+	for purpose, ids := range updates {
+		if err := s.queries.DeleteBotModelFallbacksByPurpose(ctx, sqlc.DeleteBotModelFallbacksByPurposeParams{
+			BotID:   botID,
+			Purpose: purpose,
+		}); err != nil {
+			return fmt.Errorf("clear %s model fallbacks: %w", purpose, err)
+		}
+		for i, modelID := range ids {
+			if _, err := s.queries.CreateBotModelFallback(ctx, sqlc.CreateBotModelFallbackParams{
+				BotID:    botID,
+				Purpose:  purpose,
+				ModelID:  modelID,
+				Position: int32(i), //nolint:gosec // bounded by request size
+			}); err != nil {
+				return fmt.Errorf("create %s model fallback: %w", purpose, err)
+			}
+		}
+	}
+	return nil
+}
+
+func normalizeBotSetting(maxContextLoadTime int32, maxContextTokens int32, language string, allowGuest bool, reasoningEnabled bool, reasoningEffort string, heartbeatEnabled bool, heartbeatInterval int32, compactionEnabled bool, compactionThreshold int32, memoryScopePolicy string) Settings {
 	settings := Settings{
 		MaxContextLoadTime:  int(maxContextLoadTime),
 		MaxContextTokens:    int(maxContextTokens),
@@ -236,6 +425,7 @@ func normalizeBotSetting(maxContextLoadTime int32, maxContextTokens int32, langu
 		HeartbeatInterval:   int(heartbeatInterval),
 		CompactionEnabled:   compactionEnabled,
 		CompactionThreshold: int(compactionThreshold),
+		MemoryScopePolicy:   strings.TrimSpace(memoryScopePolicy),
 	}
 	if settings.MaxContextLoadTime <= 0 {
 		settings.MaxContextLoadTime = DefaultMaxContextLoadTime
@@ -255,9 +445,35 @@ func normalizeBotSetting(maxContextLoadTime int32, maxContextTokens int32, langu
 	if settings.CompactionThreshold < 0 {
 		settings.CompactionThreshold = 0
 	}
+	if !isValidMemoryScopePolicy(settings.MemoryScopePolicy) {
+		settings.MemoryScopePolicy = DefaultMemoryScopePolicy
+	}
 	return settings
 }
 
+func isValidMemoryScopePolicy(policy string) bool {
+	switch policy {
+	case MemoryScopeShared, MemoryScopePerUser, MemoryScopePrivate:
+		return true
+	default:
+		return false
+	}
+}
+
+// isValidActiveHours reports whether start and end are both empty or both
+// HH:MM times of day.
+func isValidActiveHours(start, end string) bool {
+	if start == "" && end == "" {
+		return true
+	}
+	for _, v := range []string{start, end} {
+		if _, err := time.Parse("15:04", v); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 func isValidReasoningEffort(effort string) bool {
 	switch effort {
 	case "low", "medium", "high":
@@ -268,7 +484,7 @@ func isValidReasoningEffort(effort string) bool {
 }
 
 func normalizeBotSettingsReadRow(row sqlc.GetSettingsByBotIDRow) Settings {
-	return normalizeBotSettingsFields(
+	settings := normalizeBotSettingsFields(
 		row.MaxContextLoadTime,
 		row.MaxContextTokens,
 		row.Language,
@@ -278,6 +494,7 @@ func normalizeBotSettingsReadRow(row sqlc.GetSettingsByBotIDRow) Settings {
 		row.HeartbeatInterval,
 		row.CompactionEnabled,
 		row.CompactionThreshold,
+		row.MemoryScopePolicy,
 		row.ChatModelID,
 		row.HeartbeatModelID,
 		row.CompactionModelID,
@@ -285,12 +502,16 @@ func normalizeBotSettingsReadRow(row sqlc.GetSettingsByBotIDRow) Settings {
 		row.SearchProviderID,
 		row.MemoryProviderID,
 		row.TtsModelID,
+		row.SttModelID,
 		row.BrowserContextID,
 	)
+	applyHeartbeatPacing(&settings, row.Timezone, row.HeartbeatActiveStart, row.HeartbeatActiveEnd, row.HeartbeatJitter, row.HeartbeatMaxInterval)
+	applyFetchDomains(&settings, row.FetchAllowDomains, row.FetchDenyDomains)
+	return settings
 }
 
 func normalizeBotSettingsWriteRow(row sqlc.UpsertBotSettingsRow) Settings {
-	return normalizeBotSettingsFields(
+	settings := normalizeBotSettingsFields(
 		row.MaxContextLoadTime,
 		row.MaxContextTokens,
 		row.Language,
@@ -300,6 +521,7 @@ func normalizeBotSettingsWriteRow(row sqlc.UpsertBotSettingsRow) Settings {
 		row.HeartbeatInterval,
 		row.CompactionEnabled,
 		row.CompactionThreshold,
+		row.MemoryScopePolicy,
 		row.ChatModelID,
 		row.HeartbeatModelID,
 		row.CompactionModelID,
@@ -307,8 +529,27 @@ func normalizeBotSettingsWriteRow(row sqlc.UpsertBotSettingsRow) Settings {
 		row.SearchProviderID,
 		row.MemoryProviderID,
 		row.TtsModelID,
+		row.SttModelID,
 		row.BrowserContextID,
 	)
+	applyHeartbeatPacing(&settings, row.Timezone, row.HeartbeatActiveStart, row.HeartbeatActiveEnd, row.HeartbeatJitter, row.HeartbeatMaxInterval)
+	applyFetchDomains(&settings, row.FetchAllowDomains, row.FetchDenyDomains)
+	return settings
+}
+
+func applyHeartbeatPacing(settings *Settings, timezone, activeStart, activeEnd string, jitter, maxInterval int32) {
+	settings.Timezone = strings.TrimSpace(timezone)
+	settings.HeartbeatActiveStart = strings.TrimSpace(activeStart)
+	settings.HeartbeatActiveEnd = strings.TrimSpace(activeEnd)
+	settings.HeartbeatJitter = max(int(jitter), 0)
+	settings.HeartbeatMaxInterval = max(int(maxInterval), 0)
+}
+
+// applyFetchDomains copies the fetch domain lists, keeping them non-nil so
+// they serialize as empty arrays and satisfy the NOT NULL columns.
+func applyFetchDomains(settings *Settings, allow, deny []string) {
+	settings.FetchAllowDomains = append([]string{}, allow...)
+	settings.FetchDenyDomains = append([]string{}, deny...)
 }
 
 func normalizeBotSettingsFields(
@@ -321,6 +562,7 @@ func normalizeBotSettingsFields(
 	heartbeatInterval int32,
 	compactionEnabled bool,
 	compactionThreshold int32,
+	memoryScopePolicy string,
 	chatModelID pgtype.UUID,
 	heartbeatModelID pgtype.UUID,
 	compactionModelID pgtype.UUID,
@@ -328,9 +570,10 @@ func normalizeBotSettingsFields(
 	searchProviderID pgtype.UUID,
 	memoryProviderID pgtype.UUID,
 	ttsModelID pgtype.UUID,
+	sttModelID pgtype.UUID,
 	browserContextID pgtype.UUID,
 ) Settings {
-	settings := normalizeBotSetting(maxContextLoadTime, maxContextTokens, language, false, reasoningEnabled, reasoningEffort, heartbeatEnabled, heartbeatInterval, compactionEnabled, compactionThreshold)
+	settings := normalizeBotSetting(maxContextLoadTime, maxContextTokens, language, false, reasoningEnabled, reasoningEffort, heartbeatEnabled, heartbeatInterval, compactionEnabled, compactionThreshold, memoryScopePolicy)
 	if chatModelID.Valid {
 		settings.ChatModelID = uuid.UUID(chatModelID.Bytes).String()
 	}
@@ -352,6 +595,9 @@ func normalizeBotSettingsFields(
 	if ttsModelID.Valid {
 		settings.TtsModelID = uuid.UUID(ttsModelID.Bytes).String()
 	}
+	if sttModelID.Valid {
+		settings.SttModelID = uuid.UUID(sttModelID.Bytes).String()
+	}
 	if browserContextID.Valid {
 		settings.BrowserContextID = uuid.UUID(browserContextID.Bytes).String()
 	}
@@ -1,50 +1,127 @@
 package settings
 
+import "github.com/memohai/memoh/internal/netguard"
+
 const (
 	DefaultMaxContextLoadTime = 24 * 60
 	DefaultLanguage           = "auto"
 	DefaultReasoningEffort    = "medium"
 	DefaultHeartbeatInterval  = 30
+	DefaultMemoryScopePolicy  = MemoryScopePrivate
+)
+
+// Memory scope policies decide who can recall what the bot remembers.
+const (
+	// MemoryScopeShared keeps every memory bot-global, visible to everyone
+	// who talks to the bot.
+	MemoryScopeShared = "shared"
+	// MemoryScopePerUser files memories under the person who said them and
+	// recalls only the speaker's memories plus bot-global ones.
+	MemoryScopePerUser = "per_user"
+	// MemoryScopePrivate is per_user in direct chats; in groups memories stay
+	// within the conversation they came from.
+	MemoryScopePrivate = "private"
 )
 
+// Model purposes that support fallback chains.
+const (
+	ModelPurposeChat       = "chat"
+	ModelPurposeHeartbeat  = "heartbeat"
+	ModelPurposeTitle      = "title"
+	ModelPurposeCompaction = "compaction"
+)
+
+// ModelFallbacks holds ordered fallback model IDs per purpose. When the
+// primary model fails before producing any output, the next model in the
+// list is tried. In an UpsertRequest a nil list leaves the stored chain
+// unchanged and an empty list clears it.
+type ModelFallbacks struct {
+	Chat       []string `json:"chat"`
+	Heartbeat  []string `json:"heartbeat"`
+	Title      []string `json:"title"`
+	Compaction []string `json:"compaction"`
+}
+
 type Settings struct {
-	ChatModelID         string `json:"chat_model_id"`
-	SearchProviderID    string `json:"search_provider_id"`
-	MemoryProviderID    string `json:"memory_provider_id"`
-	TtsModelID          string `json:"tts_model_id"`
-	BrowserContextID    string `json:"browser_context_id"`
-	MaxContextLoadTime  int    `json:"max_context_load_time"`
-	MaxContextTokens    int    `json:"max_context_tokens"`
-	Language            string `json:"language"`
-	AllowGuest          bool   `json:"allow_guest"`
-	ReasoningEnabled    bool   `json:"reasoning_enabled"`
-	ReasoningEffort     string `json:"reasoning_effort"`
-	HeartbeatEnabled    bool   `json:"heartbeat_enabled"`
-	HeartbeatInterval   int    `json:"heartbeat_interval"`
-	HeartbeatModelID    string `json:"heartbeat_model_id"`
-	TitleModelID        string `json:"title_model_id"`
-	CompactionEnabled   bool   `json:"compaction_enabled"`
-	CompactionThreshold int    `json:"compaction_threshold"`
-	CompactionModelID   string `json:"compaction_model_id,omitempty"`
+	ChatModelID         string         `json:"chat_model_id"`
+	SearchProviderID    string         `json:"search_provider_id"`
+	MemoryProviderID    string         `json:"memory_provider_id"`
+	TtsModelID          string         `json:"tts_model_id"`
+	SttModelID          string         `json:"stt_model_id"`
+	BrowserContextID    string         `json:"browser_context_id"`
+	MaxContextLoadTime  int            `json:"max_context_load_time"`
+	MaxContextTokens    int            `json:"max_context_tokens"`
+	Language            string         `json:"language"`
+	AllowGuest          bool           `json:"allow_guest"`
+	ReasoningEnabled    bool           `json:"reasoning_enabled"`
+	ReasoningEffort     string         `json:"reasoning_effort"`
+	HeartbeatEnabled    bool           `json:"heartbeat_enabled"`
+	HeartbeatInterval   int            `json:"heartbeat_interval"`
+	HeartbeatModelID    string         `json:"heartbeat_model_id"`
+	TitleModelID        string         `json:"title_model_id"`
+	CompactionEnabled   bool           `json:"compaction_enabled"`
+	CompactionThreshold int            `json:"compaction_threshold"`
+	CompactionModelID   string         `json:"compaction_model_id,omitempty"`
+	MemoryScopePolicy   string         `json:"memory_scope_policy"`
+	ModelFallbacks      ModelFallbacks `json:"model_fallbacks"`
+	// Timezone is the bot's IANA timezone; empty means UTC.
+	Timezone string `json:"timezone"`
+	// HeartbeatActiveStart and HeartbeatActiveEnd bound the daily "HH:MM"
+	// window, in Timezone, that heartbeats run in. Both empty means always.
+	HeartbeatActiveStart string `json:"heartbeat_active_start"`
+	HeartbeatActiveEnd   string `json:"heartbeat_active_end"`
+	// HeartbeatJitter is the maximum random delay in minutes added to each
+	// heartbeat so bots do not fire in lockstep.
+	HeartbeatJitter int `json:"heartbeat_jitter"`
+	// HeartbeatMaxInterval is the ceiling in minutes for backing off after
+	// consecutive HEARTBEAT_OK runs; 0 disables backoff.
+	HeartbeatMaxInterval int `json:"heartbeat_max_interval"`
+	// FetchAllowDomains, when non-empty, limits web_fetch and attachment
+	// downloads to these domains and their subdomains.
+	FetchAllowDomains []string `json:"fetch_allow_domains"`
+	// FetchDenyDomains blocks these domains and their subdomains for
+	// web_fetch and attachment downloads.
+	FetchDenyDomains []string `json:"fetch_deny_domains"`
+}
+
+// FetchPolicy returns the outbound fetch policy for the bot's domain lists.
+// Callers set body and time limits for their own use.
+func (s Settings) FetchPolicy() netguard.Policy {
+	return netguard.Policy{
+		AllowDomains: s.FetchAllowDomains,
+		DenyDomains:  s.FetchDenyDomains,
+	}
 }
 
 type UpsertRequest struct {
-	ChatModelID         string  `json:"chat_model_id,omitempty"`
-	SearchProviderID    string  `json:"search_provider_id,omitempty"`
-	MemoryProviderID    string  `json:"memory_provider_id,omitempty"`
-	TtsModelID          string  `json:"tts_model_id,omitempty"`
-	BrowserContextID    string  `json:"browser_context_id,omitempty"`
-	MaxContextLoadTime  *int    `json:"max_context_load_time,omitempty"`
-	MaxContextTokens    *int    `json:"max_context_tokens,omitempty"`
-	Language            string  `json:"language,omitempty"`
-	AllowGuest          *bool   `json:"allow_guest,omitempty"`
-	ReasoningEnabled    *bool   `json:"reasoning_enabled,omitempty"`
-	ReasoningEffort     *string `json:"reasoning_effort,omitempty"`
-	HeartbeatEnabled    *bool   `json:"heartbeat_enabled,omitempty"`
-	HeartbeatInterval   *int    `json:"heartbeat_interval,omitempty"`
-	HeartbeatModelID    string  `json:"heartbeat_model_id,omitempty"`
-	TitleModelID        string  `json:"title_model_id,omitempty"`
-	CompactionEnabled   *bool   `json:"compaction_enabled,omitempty"`
-	CompactionThreshold *int    `json:"compaction_threshold,omitempty"`
-	CompactionModelID   *string `json:"compaction_model_id,omitempty"`
+	ChatModelID          string          `json:"chat_model_id,omitempty"`
+	SearchProviderID     string          `json:"search_provider_id,omitempty"`
+	MemoryProviderID     string          `json:"memory_provider_id,omitempty"`
+	TtsModelID           string          `json:"tts_model_id,omitempty"`
+	SttModelID           string          `json:"stt_model_id,omitempty"`
+	BrowserContextID     string          `json:"browser_context_id,omitempty"`
+	MaxContextLoadTime   *int            `json:"max_context_load_time,omitempty"`
+	MaxContextTokens     *int            `json:"max_context_tokens,omitempty"`
+	Language             string          `json:"language,omitempty"`
+	AllowGuest           *bool           `json:"allow_guest,omitempty"`
+	ReasoningEnabled     *bool           `json:"reasoning_enabled,omitempty"`
+	ReasoningEffort      *string         `json:"reasoning_effort,omitempty"`
+	HeartbeatEnabled     *bool           `json:"heartbeat_enabled,omitempty"`
+	HeartbeatInterval    *int            `json:"heartbeat_interval,omitempty"`
+	HeartbeatModelID     string          `json:"heartbeat_model_id,omitempty"`
+	TitleModelID         string          `json:"title_model_id,omitempty"`
+	CompactionEnabled    *bool           `json:"compaction_enabled,omitempty"`
+	CompactionThreshold  *int            `json:"compaction_threshold,omitempty"`
+	CompactionModelID    *string         `json:"compaction_model_id,omitempty"`
+	MemoryScopePolicy    *string         `json:"memory_scope_policy,omitempty"`
+	ModelFallbacks       *ModelFallbacks `json:"model_fallbacks,omitempty"`
+	Timezone             *string         `json:"timezone,omitempty"`
+	HeartbeatActiveStart *string         `json:"heartbeat_active_start,omitempty"`
+	HeartbeatActiveEnd   *string         `json:"heartbeat_active_end,omitempty"`
+	HeartbeatJitter      *int            `json:"heartbeat_jitter,omitempty"`
+	HeartbeatMaxInterval *int            `json:"heartbeat_max_interval,omitempty"`
+	// FetchAllowDomains and FetchDenyDomains replace the stored lists when
+	// non-nil; an empty list clears them.
+	FetchAllowDomains []string `json:"fetch_allow_domains,omitempty"`
+	FetchDenyDomains  []string `json:"fetch_deny_domains,omitempty"`
 }
@@ -0,0 +1,19 @@
+package stt
+
+import "context"
+
+type SttType string
+
+type SttMeta struct {
+	Provider    string
+	Description string
+}
+
+type SttAdapter interface {
+	Type() SttType
+	Meta() SttMeta
+	DefaultModel() string
+	Models() []ModelInfo
+	ResolveModel(model string) (string, error)
+	Transcribe(ctx context.Context, provider ProviderConfig, model string, audio AudioInput, config TranscribeConfig) (TranscriptionResult, error)
+}
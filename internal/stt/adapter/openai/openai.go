@@ -0,0 +1,202 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/memohai/memoh/internal/stt"
+)
+
+const SttTypeOpenAI stt.SttType = "openai"
+
+const (
+	defaultBaseURL  = "https://api.openai.com/v1"
+	modelWhisper1   = "whisper-1"
+	requestTimeout  = 2 * time.Minute
+	maxErrorBodyLen = 512
+)
+
+// OpenAIAdapter transcribes audio through the OpenAI /audio/transcriptions
+// endpoint. Any server exposing the same API (e.g. a self-hosted whisper)
+// can be used by setting base_url on the provider.
+type OpenAIAdapter struct {
+	logger *slog.Logger
+	client *http.Client
+}
+
+func NewOpenAIAdapter(log *slog.Logger) *OpenAIAdapter {
+	return &OpenAIAdapter{
+		logger: log.With(slog.String("adapter", "openai")),
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// NewOpenAIAdapterWithClient for testing: inject a custom HTTP client.
+func NewOpenAIAdapterWithClient(log *slog.Logger, client *http.Client) *OpenAIAdapter {
+	return &OpenAIAdapter{
+		logger: log.With(slog.String("adapter", "openai")),
+		client: client,
+	}
+}
+
+func (*OpenAIAdapter) Type() stt.SttType {
+	return SttTypeOpenAI
+}
+
+func (*OpenAIAdapter) Meta() stt.SttMeta {
+	return stt.SttMeta{
+		Provider:    "OpenAI",
+		Description: "OpenAI-compatible speech-to-text",
+	}
+}
+
+func (*OpenAIAdapter) DefaultModel() string {
+	return modelWhisper1
+}
+
+var openaiFormats = []string{"flac", "mp3", "mp4", "mpeg", "mpga", "m4a", "ogg", "wav", "webm"}
+
+func (*OpenAIAdapter) Models() []stt.ModelInfo {
+	return []stt.ModelInfo{
+		{
+			ID:          modelWhisper1,
+			Name:        "Whisper",
+			Description: "General-purpose multilingual speech recognition",
+			Capabilities: stt.ModelCapabilities{
+				Formats:     openaiFormats,
+				Prompt:      true,
+				Temperature: true,
+			},
+		},
+		{
+			ID:          "gpt-4o-transcribe",
+			Name:        "GPT-4o Transcribe",
+			Description: "Higher accuracy transcription backed by GPT-4o",
+			Capabilities: stt.ModelCapabilities{
+				Formats:     openaiFormats,
+				Prompt:      true,
+				Temperature: true,
+			},
+		},
+		{
+			ID:          "gpt-4o-mini-transcribe",
+			Name:        "GPT-4o mini Transcribe",
+			Description: "Fast, low cost transcription backed by GPT-4o mini",
+			Capabilities: stt.ModelCapabilities{
+				Formats:     openaiFormats,
+				Prompt:      true,
+				Temperature: true,
+			},
+		},
+	}
+}
+
+// ResolveModel accepts any non-empty model name so that OpenAI-compatible
+// servers with their own model identifiers keep working.
+func (*OpenAIAdapter) ResolveModel(model string) (string, error) {
+	trimmed := strings.TrimSpace(model)
+	if trimmed == "" {
+		return modelWhisper1, nil
+	}
+	return trimmed, nil
+}
+
+type transcriptionResponse struct {
+	Text     string  `json:"text"`
+	Language string  `json:"language"`
+	Duration float64 `json:"duration"`
+}
+
+func (a *OpenAIAdapter) Transcribe(ctx context.Context, provider stt.ProviderConfig, model string, audio stt.AudioInput, config stt.TranscribeConfig) (stt.TranscriptionResult, error) {
+	if err := config.Validate(); err != nil {
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: invalid config: %w", err)
+	}
+	if len(audio.Data) == 0 {
+		return stt.TranscriptionResult{}, errors.New("openai stt: audio is empty")
+	}
+	model, _ = a.ResolveModel(model)
+	filename := strings.TrimSpace(audio.Filename)
+	if filename == "" {
+		filename = stt.FilenameForMime(audio.Mime)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: create form file: %w", err)
+	}
+	if _, err := part.Write(audio.Data); err != nil {
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: write audio: %w", err)
+	}
+	fields := map[string]string{
+		"model":           model,
+		"response_format": "json",
+	}
+	if config.Language != "" {
+		fields["language"] = config.Language
+	}
+	if config.Prompt != "" {
+		fields["prompt"] = config.Prompt
+	}
+	if config.Temperature > 0 {
+		fields["temperature"] = strconv.FormatFloat(config.Temperature, 'f', -1, 64)
+	}
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return stt.TranscriptionResult{}, fmt.Errorf("openai stt: write field %s: %w", key, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: close form: %w", err)
+	}
+
+	baseURL := strings.TrimRight(strings.TrimSpace(provider.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/audio/transcriptions", &body)
+	if err != nil {
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if key := strings.TrimSpace(provider.APIKey); key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(raw))
+		if len(msg) > maxErrorBodyLen {
+			msg = msg[:maxErrorBodyLen]
+		}
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: status %d: %s", resp.StatusCode, msg)
+	}
+	var parsed transcriptionResponse
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return stt.TranscriptionResult{}, fmt.Errorf("openai stt: decode response: %w", err)
+	}
+	return stt.TranscriptionResult{
+		Text:     strings.TrimSpace(parsed.Text),
+		Language: parsed.Language,
+		Duration: parsed.Duration,
+	}, nil
+}
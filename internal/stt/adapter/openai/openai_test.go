@@ -0,0 +1,107 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/memohai/memoh/internal/stt"
+)
+
+func TestOpenAIAdapter_TypeAndMeta(t *testing.T) {
+	t.Parallel()
+	adapter := NewOpenAIAdapter(slog.Default())
+	if adapter.Type() != SttTypeOpenAI {
+		t.Errorf("Type() = %q, want %q", adapter.Type(), SttTypeOpenAI)
+	}
+	if adapter.Meta().Provider != "OpenAI" {
+		t.Errorf("Meta().Provider = %q, want %q", adapter.Meta().Provider, "OpenAI")
+	}
+	if adapter.DefaultModel() != modelWhisper1 {
+		t.Errorf("DefaultModel() = %q, want %q", adapter.DefaultModel(), modelWhisper1)
+	}
+}
+
+func TestOpenAIAdapter_Transcribe_WithMockServer(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/transcriptions" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		if got := r.FormValue("model"); got != "whisper-1" {
+			t.Errorf("model = %q", got)
+		}
+		if got := r.FormValue("language"); got != "en" {
+			t.Errorf("language = %q", got)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		defer func() { _ = file.Close() }()
+		if header.Filename != "audio.ogg" {
+			t.Errorf("filename = %q", header.Filename)
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != "fake-ogg" {
+			t.Errorf("file data = %q", string(data))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"text":" hello world ","language":"english","duration":1.5}`))
+	}))
+	defer srv.Close()
+
+	adapter := NewOpenAIAdapterWithClient(slog.Default(), srv.Client())
+	result, err := adapter.Transcribe(context.Background(),
+		stt.ProviderConfig{BaseURL: srv.URL + "/v1/", APIKey: "sk-test"},
+		"",
+		stt.AudioInput{Data: []byte("fake-ogg"), Mime: "audio/ogg; codecs=opus"},
+		stt.TranscribeConfig{Language: "en"},
+	)
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	if result.Text != "hello world" {
+		t.Errorf("Text = %q", result.Text)
+	}
+	if result.Language != "english" || result.Duration != 1.5 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestOpenAIAdapter_Transcribe_ErrorStatus(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":"bad audio"}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	adapter := NewOpenAIAdapterWithClient(slog.Default(), srv.Client())
+	_, err := adapter.Transcribe(context.Background(),
+		stt.ProviderConfig{BaseURL: srv.URL},
+		modelWhisper1,
+		stt.AudioInput{Data: []byte("x"), Filename: "a.mp3"},
+		stt.TranscribeConfig{},
+	)
+	if err == nil {
+		t.Fatal("expected error for non-2xx status")
+	}
+}
+
+func TestOpenAIAdapter_Transcribe_InvalidConfig(t *testing.T) {
+	t.Parallel()
+	adapter := NewOpenAIAdapter(slog.Default())
+	_, err := adapter.Transcribe(context.Background(), stt.ProviderConfig{}, "", stt.AudioInput{Data: []byte("x")}, stt.TranscribeConfig{Temperature: 2})
+	if err == nil {
+		t.Fatal("expected invalid config error")
+	}
+}
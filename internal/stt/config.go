@@ -0,0 +1,92 @@
+package stt
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// MaxAudioBytes is the largest audio payload forwarded to a transcription
+// backend. It matches the upload limit of the OpenAI transcription API.
+const MaxAudioBytes = 25 * 1024 * 1024
+
+// ProviderConfig is the connection configuration stored on an STT provider.
+// BaseURL may point at any OpenAI-compatible server, e.g. a local whisper.
+type ProviderConfig struct {
+	BaseURL string `json:"base_url"`
+	APIKey  string `json:"api_key"`
+}
+
+// TranscribeConfig is the user-facing configuration for an STT request.
+// All fields are optional; adapters fall back to their own defaults.
+type TranscribeConfig struct {
+	Language    string  `json:"language"    validate:"omitempty"`
+	Prompt      string  `json:"prompt"      validate:"omitempty"`
+	Temperature float64 `json:"temperature" validate:"omitempty,min=0,max=1"`
+}
+
+func (c TranscribeConfig) Validate() error {
+	return validate.Struct(c)
+}
+
+// AudioInput carries the raw audio to transcribe. Filename is used by
+// backends to detect the container format, so it should carry an extension.
+type AudioInput struct {
+	Data     []byte
+	Filename string
+	Mime     string
+}
+
+// TranscriptionResult is the normalized output of a transcription request.
+type TranscriptionResult struct {
+	Text     string  `json:"text"`
+	Language string  `json:"language,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+}
+
+// ModelCapabilities describes what a specific STT model supports.
+type ModelCapabilities struct {
+	Formats     []string `json:"formats"`
+	Prompt      bool     `json:"prompt"`
+	Temperature bool     `json:"temperature"`
+}
+
+// ModelInfo describes a single model exposed by an STT adapter.
+type ModelInfo struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description,omitempty"`
+	Capabilities ModelCapabilities `json:"capabilities"`
+}
+
+// FilenameForMime returns a generic file name whose extension matches the
+// given audio MIME type. Voice notes from chat platforms often arrive without
+// a name, while transcription backends sniff the format from the extension.
+func FilenameForMime(mime string) string {
+	mime = strings.ToLower(strings.TrimSpace(mime))
+	if idx := strings.Index(mime, ";"); idx >= 0 {
+		mime = strings.TrimSpace(mime[:idx])
+	}
+	switch mime {
+	case "audio/ogg", "audio/opus", "application/ogg":
+		return "audio.ogg"
+	case "audio/mpeg", "audio/mp3":
+		return "audio.mp3"
+	case "audio/mp4", "audio/m4a", "audio/x-m4a", "audio/aac":
+		return "audio.m4a"
+	case "audio/wav", "audio/x-wav", "audio/wave":
+		return "audio.wav"
+	case "audio/webm", "video/webm":
+		return "audio.webm"
+	case "audio/flac", "audio/x-flac":
+		return "audio.flac"
+	case "audio/amr":
+		return "audio.amr"
+	case "audio/silk":
+		return "audio.silk"
+	default:
+		return "audio.ogg"
+	}
+}
@@ -0,0 +1,518 @@
+package stt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/memohai/memoh/internal/db"
+	"github.com/memohai/memoh/internal/db/sqlc"
+)
+
+type Service struct {
+	queries  *sqlc.Queries
+	logger   *slog.Logger
+	registry *Registry
+}
+
+func NewService(log *slog.Logger, queries *sqlc.Queries, registry *Registry) *Service {
+	return &Service{
+		queries:  queries,
+		logger:   log.With(slog.String("service", "stt")),
+		registry: registry,
+	}
+}
+
+func (s *Service) Registry() *Registry { return s.registry }
+
+func (s *Service) ListMeta(_ context.Context) []ProviderMetaResponse {
+	return s.registry.ListMeta()
+}
+
+// ---------------------------------------------------------------------------
+// Provider CRUD
+// ---------------------------------------------------------------------------
+
+func (s *Service) CreateProvider(ctx context.Context, req CreateProviderRequest) (ProviderResponse, error) {
+	adapter, err := s.registry.Get(req.Provider)
+	if err != nil {
+		return ProviderResponse{}, fmt.Errorf("unsupported provider: %s", req.Provider)
+	}
+	cfgJSON := []byte("{}")
+	if req.Config != nil {
+		cfgJSON, err = json.Marshal(req.Config)
+		if err != nil {
+			return ProviderResponse{}, fmt.Errorf("marshal config: %w", err)
+		}
+	}
+	row, err := s.queries.CreateSttProvider(ctx, sqlc.CreateSttProviderParams{
+		Name:     strings.TrimSpace(req.Name),
+		Provider: string(req.Provider),
+		Config:   cfgJSON,
+	})
+	if err != nil {
+		return ProviderResponse{}, fmt.Errorf("create stt provider: %w", err)
+	}
+
+	if importErr := s.importModelsForProvider(ctx, row.ID, adapter); importErr != nil {
+		s.logger.Warn("auto-import models failed", slog.String("provider_id", row.ID.String()), slog.Any("error", importErr))
+	}
+
+	return s.toProviderResponse(row), nil
+}
+
+func (s *Service) GetProvider(ctx context.Context, id string) (ProviderResponse, error) {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return ProviderResponse{}, err
+	}
+	row, err := s.queries.GetSttProviderByID(ctx, pgID)
+	if err != nil {
+		return ProviderResponse{}, fmt.Errorf("get stt provider: %w", err)
+	}
+	return s.toProviderResponse(row), nil
+}
+
+func (s *Service) ListProviders(ctx context.Context, provider string) ([]ProviderResponse, error) {
+	provider = strings.TrimSpace(provider)
+	var (
+		rows []sqlc.SttProvider
+		err  error
+	)
+	if provider == "" {
+		rows, err = s.queries.ListSttProviders(ctx)
+	} else {
+		rows, err = s.queries.ListSttProvidersByProvider(ctx, provider)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list stt providers: %w", err)
+	}
+	items := make([]ProviderResponse, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, s.toProviderResponse(row))
+	}
+	return items, nil
+}
+
+func (s *Service) UpdateProvider(ctx context.Context, id string, req UpdateProviderRequest) (ProviderResponse, error) {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return ProviderResponse{}, err
+	}
+	current, err := s.queries.GetSttProviderByID(ctx, pgID)
+	if err != nil {
+		return ProviderResponse{}, fmt.Errorf("get stt provider: %w", err)
+	}
+	name := current.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+	}
+	config := current.Config
+	if req.Config != nil {
+		existing := decodeConfig(current.Config)
+		if key, ok := req.Config["api_key"].(string); ok {
+			if existingKey, _ := existing["api_key"].(string); existingKey != "" && key == maskAPIKey(existingKey) {
+				req.Config["api_key"] = existingKey
+			}
+		}
+		configJSON, marshalErr := json.Marshal(req.Config)
+		if marshalErr != nil {
+			return ProviderResponse{}, fmt.Errorf("marshal config: %w", marshalErr)
+		}
+		config = configJSON
+	}
+	updated, err := s.queries.UpdateSttProvider(ctx, sqlc.UpdateSttProviderParams{
+		ID:       pgID,
+		Name:     name,
+		Provider: current.Provider,
+		Config:   config,
+	})
+	if err != nil {
+		return ProviderResponse{}, fmt.Errorf("update stt provider: %w", err)
+	}
+	return s.toProviderResponse(updated), nil
+}
+
+func (s *Service) DeleteProvider(ctx context.Context, id string) error {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return err
+	}
+	return s.queries.DeleteSttProvider(ctx, pgID)
+}
+
+// ---------------------------------------------------------------------------
+// Model CRUD
+// ---------------------------------------------------------------------------
+
+func (s *Service) CreateModel(ctx context.Context, req CreateModelRequest) (ModelResponse, error) {
+	modelID := strings.TrimSpace(req.ModelID)
+	if modelID == "" {
+		return ModelResponse{}, errors.New("model_id is required")
+	}
+	providerPgID, err := db.ParseUUID(req.SttProviderID)
+	if err != nil {
+		return ModelResponse{}, fmt.Errorf("invalid stt_provider_id: %w", err)
+	}
+	provider, err := s.queries.GetSttProviderByID(ctx, providerPgID)
+	if err != nil {
+		return ModelResponse{}, fmt.Errorf("get stt provider: %w", err)
+	}
+	cfgJSON := []byte("{}")
+	if req.Config != nil {
+		cfgJSON, err = json.Marshal(req.Config)
+		if err != nil {
+			return ModelResponse{}, fmt.Errorf("marshal config: %w", err)
+		}
+	}
+	name := pgtype.Text{}
+	if n := strings.TrimSpace(req.Name); n != "" {
+		name = pgtype.Text{String: n, Valid: true}
+	}
+	row, err := s.queries.CreateSttModel(ctx, sqlc.CreateSttModelParams{
+		ModelID:       modelID,
+		Name:          name,
+		SttProviderID: providerPgID,
+		Config:        cfgJSON,
+	})
+	if err != nil {
+		return ModelResponse{}, fmt.Errorf("create stt model: %w", err)
+	}
+	return s.toModelResponse(row, provider.Provider), nil
+}
+
+func (s *Service) ListModelsByProvider(ctx context.Context, providerID string) ([]ModelResponse, error) {
+	pgID, err := db.ParseUUID(providerID)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := s.queries.GetSttProviderByID(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("get stt provider: %w", err)
+	}
+	rows, err := s.queries.ListSttModelsByProviderID(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("list stt models: %w", err)
+	}
+	items := make([]ModelResponse, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, s.toModelResponse(row, provider.Provider))
+	}
+	return items, nil
+}
+
+func (s *Service) ListAllModels(ctx context.Context) ([]ModelResponse, error) {
+	rows, err := s.queries.ListSttModels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list stt models: %w", err)
+	}
+	providerCache := make(map[string]string)
+	items := make([]ModelResponse, 0, len(rows))
+	for _, row := range rows {
+		providerType, ok := providerCache[row.SttProviderID.String()]
+		if !ok {
+			p, pErr := s.queries.GetSttProviderByID(ctx, row.SttProviderID)
+			if pErr != nil {
+				providerType = ""
+			} else {
+				providerType = p.Provider
+			}
+			providerCache[row.SttProviderID.String()] = providerType
+		}
+		items = append(items, s.toModelResponse(row, providerType))
+	}
+	return items, nil
+}
+
+func (s *Service) GetModel(ctx context.Context, id string) (ModelResponse, error) {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return ModelResponse{}, err
+	}
+	row, err := s.queries.GetSttModelWithProvider(ctx, pgID)
+	if err != nil {
+		return ModelResponse{}, fmt.Errorf("get stt model: %w", err)
+	}
+	return s.toModelWithProviderResponse(row), nil
+}
+
+func (s *Service) UpdateModel(ctx context.Context, id string, req UpdateModelRequest) (ModelResponse, error) {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return ModelResponse{}, err
+	}
+	current, err := s.queries.GetSttModelByID(ctx, pgID)
+	if err != nil {
+		return ModelResponse{}, fmt.Errorf("get stt model: %w", err)
+	}
+	name := current.Name
+	if req.Name != nil {
+		name = pgtype.Text{String: strings.TrimSpace(*req.Name), Valid: true}
+	}
+	config := current.Config
+	if req.Config != nil {
+		configJSON, marshalErr := json.Marshal(req.Config)
+		if marshalErr != nil {
+			return ModelResponse{}, fmt.Errorf("marshal config: %w", marshalErr)
+		}
+		config = configJSON
+	}
+	updated, err := s.queries.UpdateSttModel(ctx, sqlc.UpdateSttModelParams{
+		ID:     pgID,
+		Name:   name,
+		Config: config,
+	})
+	if err != nil {
+		return ModelResponse{}, fmt.Errorf("update stt model: %w", err)
+	}
+	provider, _ := s.queries.GetSttProviderByID(ctx, updated.SttProviderID)
+	return s.toModelResponse(updated, provider.Provider), nil
+}
+
+func (s *Service) DeleteModel(ctx context.Context, id string) error {
+	pgID, err := db.ParseUUID(id)
+	if err != nil {
+		return err
+	}
+	return s.queries.DeleteSttModel(ctx, pgID)
+}
+
+// ImportModels discovers models from the adapter and upserts them into the database.
+func (s *Service) ImportModels(ctx context.Context, providerID string) ([]ModelResponse, error) {
+	pgID, err := db.ParseUUID(providerID)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := s.queries.GetSttProviderByID(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("get stt provider: %w", err)
+	}
+	adapter, err := s.registry.Get(SttType(provider.Provider))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported provider: %s", provider.Provider)
+	}
+	if importErr := s.importModelsForProvider(ctx, pgID, adapter); importErr != nil {
+		return nil, importErr
+	}
+	return s.ListModelsByProvider(ctx, providerID)
+}
+
+func (s *Service) importModelsForProvider(ctx context.Context, providerID pgtype.UUID, adapter SttAdapter) error {
+	models := adapter.Models()
+	for _, m := range models {
+		existing, err := s.queries.GetSttModelByProviderAndModelID(ctx, sqlc.GetSttModelByProviderAndModelIDParams{
+			SttProviderID: providerID,
+			ModelID:       m.ID,
+		})
+		name := pgtype.Text{String: m.Name, Valid: m.Name != ""}
+		if err == nil {
+			_, updateErr := s.queries.UpdateSttModel(ctx, sqlc.UpdateSttModelParams{
+				ID:     existing.ID,
+				Name:   name,
+				Config: existing.Config,
+			})
+			if updateErr != nil {
+				return fmt.Errorf("update stt model %s: %w", m.ID, updateErr)
+			}
+		} else {
+			_, createErr := s.queries.CreateSttModel(ctx, sqlc.CreateSttModelParams{
+				ModelID:       m.ID,
+				Name:          name,
+				SttProviderID: providerID,
+				Config:        []byte("{}"),
+			})
+			if createErr != nil {
+				return fmt.Errorf("create stt model %s: %w", m.ID, createErr)
+			}
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Transcription
+// ---------------------------------------------------------------------------
+
+// Transcribe runs speech-to-text using the saved model config, optionally
+// overridden by fields in overrideCfg.
+func (s *Service) Transcribe(ctx context.Context, modelID string, audio AudioInput, overrideCfg map[string]any) (TranscriptionResult, error) {
+	if len(audio.Data) == 0 {
+		return TranscriptionResult{}, errors.New("audio is empty")
+	}
+	if len(audio.Data) > MaxAudioBytes {
+		return TranscriptionResult{}, fmt.Errorf("audio exceeds %d bytes", MaxAudioBytes)
+	}
+	pgID, err := db.ParseUUID(modelID)
+	if err != nil {
+		return TranscriptionResult{}, err
+	}
+	modelRow, err := s.queries.GetSttModelWithProvider(ctx, pgID)
+	if err != nil {
+		return TranscriptionResult{}, fmt.Errorf("get stt model: %w", err)
+	}
+	adapter, err := s.registry.Get(SttType(modelRow.ProviderType))
+	if err != nil {
+		return TranscriptionResult{}, fmt.Errorf("unsupported provider: %s", modelRow.ProviderType)
+	}
+
+	savedCfg := decodeConfig(modelRow.Config)
+	for k, v := range overrideCfg {
+		savedCfg[k] = v
+	}
+	transcribeCfg := buildTranscribeConfig(savedCfg)
+	if err := transcribeCfg.Validate(); err != nil {
+		return TranscriptionResult{}, fmt.Errorf("invalid transcribe config: %w", err)
+	}
+
+	providerCfg := buildProviderConfig(decodeConfig(modelRow.ProviderConfig))
+	if strings.TrimSpace(audio.Filename) == "" {
+		audio.Filename = FilenameForMime(audio.Mime)
+	}
+
+	resolvedModel, _ := adapter.ResolveModel(modelRow.ModelID)
+	result, err := adapter.Transcribe(ctx, providerCfg, resolvedModel, audio, transcribeCfg)
+	if err != nil {
+		return TranscriptionResult{}, fmt.Errorf("transcribe: %w", err)
+	}
+	result.Text = strings.TrimSpace(result.Text)
+	return result, nil
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// GetModelCapabilities returns the adapter-level capabilities for a stored model.
+func (s *Service) GetModelCapabilities(ctx context.Context, modelID string) (*ModelCapabilities, error) {
+	pgID, err := db.ParseUUID(modelID)
+	if err != nil {
+		return nil, err
+	}
+	modelRow, err := s.queries.GetSttModelWithProvider(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("get stt model: %w", err)
+	}
+	adapter, err := s.registry.Get(SttType(modelRow.ProviderType))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported provider: %s", modelRow.ProviderType)
+	}
+	for _, m := range adapter.Models() {
+		if m.ID == modelRow.ModelID {
+			return &m.Capabilities, nil
+		}
+	}
+	return nil, fmt.Errorf("model %s not found in adapter", modelRow.ModelID)
+}
+
+func decodeConfig(raw []byte) map[string]any {
+	var cfg map[string]any
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &cfg)
+	}
+	if cfg == nil {
+		cfg = make(map[string]any)
+	}
+	return cfg
+}
+
+func buildProviderConfig(cfg map[string]any) ProviderConfig {
+	pc := ProviderConfig{}
+	if v, ok := cfg["base_url"].(string); ok {
+		pc.BaseURL = strings.TrimSpace(v)
+	}
+	if v, ok := cfg["api_key"].(string); ok {
+		pc.APIKey = strings.TrimSpace(v)
+	}
+	return pc
+}
+
+func buildTranscribeConfig(cfg map[string]any) TranscribeConfig {
+	tc := TranscribeConfig{}
+	if v, ok := cfg["language"].(string); ok {
+		tc.Language = strings.TrimSpace(v)
+	}
+	if v, ok := cfg["prompt"].(string); ok {
+		tc.Prompt = v
+	}
+	if v, ok := cfg["temperature"].(float64); ok {
+		tc.Temperature = v
+	}
+	return tc
+}
+
+// maskAPIKey masks an API key for security.
+func maskAPIKey(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	if len(apiKey) <= 8 {
+		return strings.Repeat("*", len(apiKey))
+	}
+	return apiKey[:8] + strings.Repeat("*", len(apiKey)-8)
+}
+
+func (*Service) toProviderResponse(row sqlc.SttProvider) ProviderResponse {
+	cfg := decodeConfig(row.Config)
+	if key, ok := cfg["api_key"].(string); ok {
+		cfg["api_key"] = maskAPIKey(key)
+	}
+	return ProviderResponse{
+		ID:        row.ID.String(),
+		Name:      row.Name,
+		Provider:  row.Provider,
+		Config:    cfg,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+func (s *Service) toModelResponse(row sqlc.SttModel, providerType string) ModelResponse {
+	var cfg map[string]any
+	if len(row.Config) > 0 {
+		if err := json.Unmarshal(row.Config, &cfg); err != nil {
+			s.logger.Warn("stt model config unmarshal failed", slog.String("id", row.ID.String()), slog.Any("error", err))
+		}
+	}
+	name := ""
+	if row.Name.Valid {
+		name = row.Name.String
+	}
+	return ModelResponse{
+		ID:            row.ID.String(),
+		ModelID:       row.ModelID,
+		Name:          name,
+		SttProviderID: row.SttProviderID.String(),
+		ProviderType:  providerType,
+		Config:        cfg,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
+
+func (s *Service) toModelWithProviderResponse(row sqlc.GetSttModelWithProviderRow) ModelResponse {
+	var cfg map[string]any
+	if len(row.Config) > 0 {
+		if err := json.Unmarshal(row.Config, &cfg); err != nil {
+			s.logger.Warn("stt model config unmarshal failed", slog.String("id", row.ID.String()), slog.Any("error", err))
+		}
+	}
+	name := ""
+	if row.Name.Valid {
+		name = row.Name.String
+	}
+	return ModelResponse{
+		ID:            row.ID.String(),
+		ModelID:       row.ModelID,
+		Name:          name,
+		SttProviderID: row.SttProviderID.String(),
+		ProviderType:  row.ProviderType,
+		Config:        cfg,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"sync"
 	"time"
 
@@ -289,6 +290,20 @@ func (c *Client) DeleteFile(ctx context.Context, path string, recursive bool) er
 	return mapError(err)
 }
 
+// Watch streams changes under path, polled every interval (0 uses the
+// bridge default), until ctx is cancelled. Callers loop on Recv.
+func (c *Client) Watch(ctx context.Context, path string, recursive bool, interval time.Duration) (pb.ContainerService_WatchClient, error) {
+	stream, err := c.svc.Watch(ctx, &pb.WatchRequest{
+		Path:       path,
+		Recursive:  recursive,
+		IntervalMs: int32(min(interval.Milliseconds(), math.MaxInt32)), //nolint:gosec // clamped to MaxInt32
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return stream, nil
+}
+
 // streamReader adapts a gRPC server stream into an io.ReadCloser.
 type streamReader struct {
 	stream pb.ContainerService_ReadRawClient
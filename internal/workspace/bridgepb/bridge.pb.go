@@ -71,6 +71,55 @@ func (ExecOutput_Stream) EnumDescriptor() ([]byte, []int) {
 	return file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDescGZIP(), []int{9, 0}
 }
 
+type WatchEvent_Op int32
+
+const (
+	WatchEvent_CREATE WatchEvent_Op = 0
+	WatchEvent_MODIFY WatchEvent_Op = 1
+	WatchEvent_DELETE WatchEvent_Op = 2
+)
+
+// Enum value maps for WatchEvent_Op.
+var (
+	WatchEvent_Op_name = map[int32]string{
+		0: "CREATE",
+		1: "MODIFY",
+		2: "DELETE",
+	}
+	WatchEvent_Op_value = map[string]int32{
+		"CREATE": 0,
+		"MODIFY": 1,
+		"DELETE": 2,
+	}
+)
+
+func (x WatchEvent_Op) Enum() *WatchEvent_Op {
+	p := new(WatchEvent_Op)
+	*p = x
+	return p
+}
+
+func (x WatchEvent_Op) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchEvent_Op) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_mcp_mcpcontainer_mcpcontainer_proto_enumTypes[1].Descriptor()
+}
+
+func (WatchEvent_Op) Type() protoreflect.EnumType {
+	return &file_internal_mcp_mcpcontainer_mcpcontainer_proto_enumTypes[1]
+}
+
+func (x WatchEvent_Op) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchEvent_Op.Descriptor instead.
+func (WatchEvent_Op) EnumDescriptor() ([]byte, []int) {
+	return file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDescGZIP(), []int{23, 0}
+}
+
 type ReadFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
@@ -1183,6 +1232,142 @@ func (*RenameResponse) Descriptor() ([]byte, []int) {
 	return file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDescGZIP(), []int{21}
 }
 
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Recursive     bool                   `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	IntervalMs    int32                  `protobuf:"varint,3,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_internal_mcp_mcpcontainer_mcpcontainer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_mcp_mcpcontainer_mcpcontainer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+func (x *WatchRequest) GetIntervalMs() int32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+type WatchEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            WatchEvent_Op          `protobuf:"varint,1,opt,name=op,proto3,enum=mcpcontainer.WatchEvent_Op" json:"op,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	IsDir         bool                   `protobuf:"varint,3,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	ModTime       string                 `protobuf:"bytes,5,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	mi := &file_internal_mcp_mcpcontainer_mcpcontainer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_mcp_mcpcontainer_mcpcontainer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WatchEvent) GetOp() WatchEvent_Op {
+	if x != nil {
+		return x.Op
+	}
+	return WatchEvent_CREATE
+}
+
+func (x *WatchEvent) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *WatchEvent) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *WatchEvent) GetModTime() string {
+	if x != nil {
+		return x.ModTime
+	}
+	return ""
+}
+
 var File_internal_mcp_mcpcontainer_mcpcontainer_proto protoreflect.FileDescriptor
 
 const file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDesc = "" +
@@ -1259,7 +1444,26 @@ const file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDesc = "" +
 	"\rRenameRequest\x12\x19\n" +
 	"\bold_path\x18\x01 \x01(\tR\aoldPath\x12\x19\n" +
 	"\bnew_path\x18\x02 \x01(\tR\anewPath\"\x10\n" +
-	"\x0eRenameResponse2\xd8\x05\n" +
+	"\x0eRenameResponse\"a\n" +
+	"\fWatchRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\x12\x1f\n" +
+	"\vinterval_ms\x18\x03 \x01(\x05R\n" +
+	"intervalMs\"\xbd\x01\n" +
+	"\n" +
+	"WatchEvent\x12+\n" +
+	"\x02op\x18\x01 \x01(\x0e2\x1b.mcpcontainer.WatchEvent.OpR\x02op\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x15\n" +
+	"\x06is_dir\x18\x03 \x01(\bR\x05isDir\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x19\n" +
+	"\bmod_time\x18\x05 \x01(\tR\amodTime\"(\n" +
+	"\x02Op\x12\n" +
+	"\n" +
+	"\x06CREATE\x10\x00\x12\n" +
+	"\n" +
+	"\x06MODIFY\x10\x01\x12\n" +
+	"\n" +
+	"\x06DELETE\x10\x022\x99\x06\n" +
 	"\x10ContainerService\x12I\n" +
 	"\bReadFile\x12\x1d.mcpcontainer.ReadFileRequest\x1a\x1e.mcpcontainer.ReadFileResponse\x12L\n" +
 	"\tWriteFile\x12\x1e.mcpcontainer.WriteFileRequest\x1a\x1f.mcpcontainer.WriteFileResponse\x12F\n" +
@@ -1271,7 +1475,8 @@ const file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDesc = "" +
 	"\aReadRaw\x12\x1c.mcpcontainer.ReadRawRequest\x1a\x17.mcpcontainer.DataChunk0\x01\x12I\n" +
 	"\bWriteRaw\x12\x1b.mcpcontainer.WriteRawChunk\x1a\x1e.mcpcontainer.WriteRawResponse(\x01\x12O\n" +
 	"\n" +
-	"DeleteFile\x12\x1f.mcpcontainer.DeleteFileRequest\x1a .mcpcontainer.DeleteFileResponseB4Z2github.com/memohai/memoh/internal/mcp/mcpcontainerb\x06proto3"
+	"DeleteFile\x12\x1f.mcpcontainer.DeleteFileRequest\x1a .mcpcontainer.DeleteFileResponse\x12?\n" +
+	"\x05Watch\x12\x1a.mcpcontainer.WatchRequest\x1a\x18.mcpcontainer.WatchEvent0\x01B4Z2github.com/memohai/memoh/internal/mcp/mcpcontainerb\x06proto3"
 
 var (
 	file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDescOnce sync.Once
@@ -1285,63 +1490,69 @@ func file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDescGZIP() []byte {
 	return file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDescData
 }
 
-var file_internal_mcp_mcpcontainer_mcpcontainer_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_mcp_mcpcontainer_mcpcontainer_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_internal_mcp_mcpcontainer_mcpcontainer_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_internal_mcp_mcpcontainer_mcpcontainer_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_internal_mcp_mcpcontainer_mcpcontainer_proto_goTypes = []any{
 	(ExecOutput_Stream)(0),     // 0: mcpcontainer.ExecOutput.Stream
-	(*ReadFileRequest)(nil),    // 1: mcpcontainer.ReadFileRequest
-	(*ReadFileResponse)(nil),   // 2: mcpcontainer.ReadFileResponse
-	(*WriteFileRequest)(nil),   // 3: mcpcontainer.WriteFileRequest
-	(*WriteFileResponse)(nil),  // 4: mcpcontainer.WriteFileResponse
-	(*ListDirRequest)(nil),     // 5: mcpcontainer.ListDirRequest
-	(*FileEntry)(nil),          // 6: mcpcontainer.FileEntry
-	(*ListDirResponse)(nil),    // 7: mcpcontainer.ListDirResponse
-	(*ExecInput)(nil),          // 8: mcpcontainer.ExecInput
-	(*TerminalResize)(nil),     // 9: mcpcontainer.TerminalResize
-	(*ExecOutput)(nil),         // 10: mcpcontainer.ExecOutput
-	(*ReadRawRequest)(nil),     // 11: mcpcontainer.ReadRawRequest
-	(*DataChunk)(nil),          // 12: mcpcontainer.DataChunk
-	(*WriteRawChunk)(nil),      // 13: mcpcontainer.WriteRawChunk
-	(*WriteRawResponse)(nil),   // 14: mcpcontainer.WriteRawResponse
-	(*DeleteFileRequest)(nil),  // 15: mcpcontainer.DeleteFileRequest
-	(*DeleteFileResponse)(nil), // 16: mcpcontainer.DeleteFileResponse
-	(*StatRequest)(nil),        // 17: mcpcontainer.StatRequest
-	(*StatResponse)(nil),       // 18: mcpcontainer.StatResponse
-	(*MkdirRequest)(nil),       // 19: mcpcontainer.MkdirRequest
-	(*MkdirResponse)(nil),      // 20: mcpcontainer.MkdirResponse
-	(*RenameRequest)(nil),      // 21: mcpcontainer.RenameRequest
-	(*RenameResponse)(nil),     // 22: mcpcontainer.RenameResponse
+	(WatchEvent_Op)(0),         // 1: mcpcontainer.WatchEvent.Op
+	(*ReadFileRequest)(nil),    // 2: mcpcontainer.ReadFileRequest
+	(*ReadFileResponse)(nil),   // 3: mcpcontainer.ReadFileResponse
+	(*WriteFileRequest)(nil),   // 4: mcpcontainer.WriteFileRequest
+	(*WriteFileResponse)(nil),  // 5: mcpcontainer.WriteFileResponse
+	(*ListDirRequest)(nil),     // 6: mcpcontainer.ListDirRequest
+	(*FileEntry)(nil),          // 7: mcpcontainer.FileEntry
+	(*ListDirResponse)(nil),    // 8: mcpcontainer.ListDirResponse
+	(*ExecInput)(nil),          // 9: mcpcontainer.ExecInput
+	(*TerminalResize)(nil),     // 10: mcpcontainer.TerminalResize
+	(*ExecOutput)(nil),         // 11: mcpcontainer.ExecOutput
+	(*ReadRawRequest)(nil),     // 12: mcpcontainer.ReadRawRequest
+	(*DataChunk)(nil),          // 13: mcpcontainer.DataChunk
+	(*WriteRawChunk)(nil),      // 14: mcpcontainer.WriteRawChunk
+	(*WriteRawResponse)(nil),   // 15: mcpcontainer.WriteRawResponse
+	(*DeleteFileRequest)(nil),  // 16: mcpcontainer.DeleteFileRequest
+	(*DeleteFileResponse)(nil), // 17: mcpcontainer.DeleteFileResponse
+	(*StatRequest)(nil),        // 18: mcpcontainer.StatRequest
+	(*StatResponse)(nil),       // 19: mcpcontainer.StatResponse
+	(*MkdirRequest)(nil),       // 20: mcpcontainer.MkdirRequest
+	(*MkdirResponse)(nil),      // 21: mcpcontainer.MkdirResponse
+	(*RenameRequest)(nil),      // 22: mcpcontainer.RenameRequest
+	(*RenameResponse)(nil),     // 23: mcpcontainer.RenameResponse
+	(*WatchRequest)(nil),       // 24: mcpcontainer.WatchRequest
+	(*WatchEvent)(nil),         // 25: mcpcontainer.WatchEvent
 }
 var file_internal_mcp_mcpcontainer_mcpcontainer_proto_depIdxs = []int32{
-	6,  // 0: mcpcontainer.ListDirResponse.entries:type_name -> mcpcontainer.FileEntry
-	9,  // 1: mcpcontainer.ExecInput.resize:type_name -> mcpcontainer.TerminalResize
+	7,  // 0: mcpcontainer.ListDirResponse.entries:type_name -> mcpcontainer.FileEntry
+	10, // 1: mcpcontainer.ExecInput.resize:type_name -> mcpcontainer.TerminalResize
 	0,  // 2: mcpcontainer.ExecOutput.stream:type_name -> mcpcontainer.ExecOutput.Stream
-	6,  // 3: mcpcontainer.StatResponse.entry:type_name -> mcpcontainer.FileEntry
-	1,  // 4: mcpcontainer.ContainerService.ReadFile:input_type -> mcpcontainer.ReadFileRequest
-	3,  // 5: mcpcontainer.ContainerService.WriteFile:input_type -> mcpcontainer.WriteFileRequest
-	5,  // 6: mcpcontainer.ContainerService.ListDir:input_type -> mcpcontainer.ListDirRequest
-	17, // 7: mcpcontainer.ContainerService.Stat:input_type -> mcpcontainer.StatRequest
-	19, // 8: mcpcontainer.ContainerService.Mkdir:input_type -> mcpcontainer.MkdirRequest
-	21, // 9: mcpcontainer.ContainerService.Rename:input_type -> mcpcontainer.RenameRequest
-	8,  // 10: mcpcontainer.ContainerService.Exec:input_type -> mcpcontainer.ExecInput
-	11, // 11: mcpcontainer.ContainerService.ReadRaw:input_type -> mcpcontainer.ReadRawRequest
-	13, // 12: mcpcontainer.ContainerService.WriteRaw:input_type -> mcpcontainer.WriteRawChunk
-	15, // 13: mcpcontainer.ContainerService.DeleteFile:input_type -> mcpcontainer.DeleteFileRequest
-	2,  // 14: mcpcontainer.ContainerService.ReadFile:output_type -> mcpcontainer.ReadFileResponse
-	4,  // 15: mcpcontainer.ContainerService.WriteFile:output_type -> mcpcontainer.WriteFileResponse
-	7,  // 16: mcpcontainer.ContainerService.ListDir:output_type -> mcpcontainer.ListDirResponse
-	18, // 17: mcpcontainer.ContainerService.Stat:output_type -> mcpcontainer.StatResponse
-	20, // 18: mcpcontainer.ContainerService.Mkdir:output_type -> mcpcontainer.MkdirResponse
-	22, // 19: mcpcontainer.ContainerService.Rename:output_type -> mcpcontainer.RenameResponse
-	10, // 20: mcpcontainer.ContainerService.Exec:output_type -> mcpcontainer.ExecOutput
-	12, // 21: mcpcontainer.ContainerService.ReadRaw:output_type -> mcpcontainer.DataChunk
-	14, // 22: mcpcontainer.ContainerService.WriteRaw:output_type -> mcpcontainer.WriteRawResponse
-	16, // 23: mcpcontainer.ContainerService.DeleteFile:output_type -> mcpcontainer.DeleteFileResponse
-	14, // [14:24] is the sub-list for method output_type
-	4,  // [4:14] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	7,  // 3: mcpcontainer.StatResponse.entry:type_name -> mcpcontainer.FileEntry
+	1,  // 4: mcpcontainer.WatchEvent.op:type_name -> mcpcontainer.WatchEvent.Op
+	2,  // 5: mcpcontainer.ContainerService.ReadFile:input_type -> mcpcontainer.ReadFileRequest
+	4,  // 6: mcpcontainer.ContainerService.WriteFile:input_type -> mcpcontainer.WriteFileRequest
+	6,  // 7: mcpcontainer.ContainerService.ListDir:input_type -> mcpcontainer.ListDirRequest
+	18, // 8: mcpcontainer.ContainerService.Stat:input_type -> mcpcontainer.StatRequest
+	20, // 9: mcpcontainer.ContainerService.Mkdir:input_type -> mcpcontainer.MkdirRequest
+	22, // 10: mcpcontainer.ContainerService.Rename:input_type -> mcpcontainer.RenameRequest
+	9,  // 11: mcpcontainer.ContainerService.Exec:input_type -> mcpcontainer.ExecInput
+	12, // 12: mcpcontainer.ContainerService.ReadRaw:input_type -> mcpcontainer.ReadRawRequest
+	14, // 13: mcpcontainer.ContainerService.WriteRaw:input_type -> mcpcontainer.WriteRawChunk
+	16, // 14: mcpcontainer.ContainerService.DeleteFile:input_type -> mcpcontainer.DeleteFileRequest
+	24, // 15: mcpcontainer.ContainerService.Watch:input_type -> mcpcontainer.WatchRequest
+	3,  // 16: mcpcontainer.ContainerService.ReadFile:output_type -> mcpcontainer.ReadFileResponse
+	5,  // 17: mcpcontainer.ContainerService.WriteFile:output_type -> mcpcontainer.WriteFileResponse
+	8,  // 18: mcpcontainer.ContainerService.ListDir:output_type -> mcpcontainer.ListDirResponse
+	19, // 19: mcpcontainer.ContainerService.Stat:output_type -> mcpcontainer.StatResponse
+	21, // 20: mcpcontainer.ContainerService.Mkdir:output_type -> mcpcontainer.MkdirResponse
+	23, // 21: mcpcontainer.ContainerService.Rename:output_type -> mcpcontainer.RenameResponse
+	11, // 22: mcpcontainer.ContainerService.Exec:output_type -> mcpcontainer.ExecOutput
+	13, // 23: mcpcontainer.ContainerService.ReadRaw:output_type -> mcpcontainer.DataChunk
+	15, // 24: mcpcontainer.ContainerService.WriteRaw:output_type -> mcpcontainer.WriteRawResponse
+	17, // 25: mcpcontainer.ContainerService.DeleteFile:output_type -> mcpcontainer.DeleteFileResponse
+	25, // 26: mcpcontainer.ContainerService.Watch:output_type -> mcpcontainer.WatchEvent
+	16, // [16:27] is the sub-list for method output_type
+	5,  // [5:16] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_internal_mcp_mcpcontainer_mcpcontainer_proto_init() }
@@ -1354,8 +1565,8 @@ func file_internal_mcp_mcpcontainer_mcpcontainer_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDesc), len(file_internal_mcp_mcpcontainer_mcpcontainer_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   22,
+			NumEnums:      2,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
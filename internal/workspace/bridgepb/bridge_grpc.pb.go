@@ -30,6 +30,7 @@ const (
 	ContainerService_ReadRaw_FullMethodName    = "/mcpcontainer.ContainerService/ReadRaw"
 	ContainerService_WriteRaw_FullMethodName   = "/mcpcontainer.ContainerService/WriteRaw"
 	ContainerService_DeleteFile_FullMethodName = "/mcpcontainer.ContainerService/DeleteFile"
+	ContainerService_Watch_FullMethodName      = "/mcpcontainer.ContainerService/Watch"
 )
 
 // ContainerServiceClient is the client API for ContainerService service.
@@ -46,6 +47,7 @@ type ContainerServiceClient interface {
 	ReadRaw(ctx context.Context, in *ReadRawRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChunk], error)
 	WriteRaw(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[WriteRawChunk, WriteRawResponse], error)
 	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error)
 }
 
 type containerServiceClient struct {
@@ -171,6 +173,25 @@ func (c *containerServiceClient) DeleteFile(ctx context.Context, in *DeleteFileR
 	return out, nil
 }
 
+func (c *containerServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ContainerService_ServiceDesc.Streams[3], ContainerService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContainerService_WatchClient = grpc.ServerStreamingClient[WatchEvent]
+
 // ContainerServiceServer is the server API for ContainerService service.
 // All implementations must embed UnimplementedContainerServiceServer
 // for forward compatibility.
@@ -185,6 +206,7 @@ type ContainerServiceServer interface {
 	ReadRaw(*ReadRawRequest, grpc.ServerStreamingServer[DataChunk]) error
 	WriteRaw(grpc.ClientStreamingServer[WriteRawChunk, WriteRawResponse]) error
 	DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error)
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error
 	mustEmbedUnimplementedContainerServiceServer()
 }
 
@@ -225,6 +247,9 @@ func (UnimplementedContainerServiceServer) WriteRaw(grpc.ClientStreamingServer[W
 func (UnimplementedContainerServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteFile not implemented")
 }
+func (UnimplementedContainerServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error {
+	return status.Error(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedContainerServiceServer) mustEmbedUnimplementedContainerServiceServer() {}
 func (UnimplementedContainerServiceServer) testEmbeddedByValue()                          {}
 
@@ -397,6 +422,17 @@ func _ContainerService_DeleteFile_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContainerServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContainerService_WatchServer = grpc.ServerStreamingServer[WatchEvent]
+
 // ContainerService_ServiceDesc is the grpc.ServiceDesc for ContainerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -450,6 +486,11 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ContainerService_WriteRaw_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _ContainerService_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "internal/workspace/bridgepb/bridge.proto",
 }
@@ -374,6 +374,7 @@ func (m *Manager) restartContainer(ctx context.Context, botID, containerID strin
 		ContainerID: containerID,
 		CNIBinDir:   m.cfg.CNIBinaryDir,
 		CNIConfDir:  m.cfg.CNIConfigDir,
+		Egress:      m.containerEgress(ctx, botID),
 	}); err != nil {
 		m.logger.Error("network setup after restart failed",
 			slog.String("container_id", containerID), slog.Any("error", err))
@@ -46,6 +46,9 @@ type ContainerStatus struct {
 	Legacy           bool      `json:"legacy"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
+
+	Resources *ResourceProfile `json:"resources,omitempty"`
+	Usage     *ResourceUsage   `json:"usage,omitempty"`
 }
 
 type Manager struct {
@@ -60,6 +63,7 @@ type Manager struct {
 	grpcPool        *bridge.Pool
 	legacyMu        sync.RWMutex
 	legacyIPs       map[string]string // botID → IP for pre-bridge containers
+	egressSources   []EgressHostSource
 }
 
 func NewManager(log *slog.Logger, service ctr.Service, cfg config.WorkspaceConfig, namespace string, conn *pgxpool.Pool) *Manager {
@@ -238,9 +242,10 @@ func (m *Manager) ensureBotWithImage(ctx context.Context, botID, image string) e
 			WorkspaceLabelKey: WorkspaceLabelValue,
 		},
 		Spec: ctr.ContainerSpec{
-			Cmd:    []string{"/opt/memoh/bridge"},
-			Mounts: mounts,
-			Env:    env,
+			Cmd:       []string{"/opt/memoh/bridge"},
+			Mounts:    mounts,
+			Env:       env,
+			Resources: m.containerResources(ctx, botID),
 		},
 	})
 	if err == nil {
@@ -334,6 +339,7 @@ func (m *Manager) startWithResolvedImage(ctx context.Context, botID, image strin
 		ContainerID: containerID,
 		CNIBinDir:   m.cfg.CNIBinaryDir,
 		CNIConfDir:  m.cfg.CNIConfigDir,
+		Egress:      m.containerEgress(ctx, botID),
 	}); err != nil {
 		if stopErr := m.service.StopContainer(ctx, containerID, &ctr.StopTaskOptions{Force: true}); stopErr != nil {
 			m.logger.Warn("cleanup: stop task failed", slog.String("container_id", containerID), slog.Any("error", stopErr))
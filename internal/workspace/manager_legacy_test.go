@@ -152,6 +152,22 @@ func (*legacyRouteTestService) SnapshotMounts(context.Context, string, string) (
 	return nil, ctr.ErrNotSupported
 }
 
+func (*legacyRouteTestService) SnapshotUsage(context.Context, string, string) (ctr.SnapshotUsage, error) {
+	return ctr.SnapshotUsage{}, ctr.ErrNotSupported
+}
+
+func (*legacyRouteTestService) UpdateContainerResources(context.Context, string, ctr.ResourceLimits) error {
+	return nil
+}
+
+func (*legacyRouteTestService) GetContainerMetrics(context.Context, string) (ctr.ContainerMetrics, error) {
+	return ctr.ContainerMetrics{}, ctr.ErrNotSupported
+}
+
+func (*legacyRouteTestService) ApplyEgressPolicy(context.Context, string, ctr.EgressPolicy) error {
+	return nil
+}
+
 func newLegacyRouteTestManager(t *testing.T, svc ctr.Service, cfg config.WorkspaceConfig) *Manager {
 	t.Helper()
 	logger := slog.New(slog.DiscardHandler)
@@ -235,7 +251,7 @@ func TestSetupNetworkAndGetIPRejectsEmptyIP(t *testing.T) {
 		CNIConfigDir: "/etc/cni/net.d",
 	})
 
-	ip, err := m.setupNetworkAndGetIP(context.Background(), "workspace-bot")
+	ip, err := m.setupNetworkAndGetIP(context.Background(), "bot", "workspace-bot")
 	if err != nil {
 		t.Fatalf("setupNetworkAndGetIP failed: %v", err)
 	}
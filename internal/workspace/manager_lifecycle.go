@@ -88,13 +88,15 @@ func (m *Manager) isTaskRunning(ctx context.Context, containerID string) bool {
 	return err == nil && len(tasks) > 0 && tasks[0].Status == ctr.TaskStatusRunning
 }
 
-func (m *Manager) setupNetworkAndGetIP(ctx context.Context, containerID string) (string, error) {
+func (m *Manager) setupNetworkAndGetIP(ctx context.Context, botID, containerID string) (string, error) {
 	var lastErr error
+	egress := m.containerEgress(ctx, botID)
 	for attempt := range 2 {
 		result, err := m.service.SetupNetwork(ctx, ctr.NetworkSetupRequest{
 			ContainerID: containerID,
 			CNIBinDir:   m.cfg.CNIBinaryDir,
 			CNIConfDir:  m.cfg.CNIConfigDir,
+			Egress:      egress,
 		})
 		if err != nil {
 			lastErr = err
@@ -114,7 +116,7 @@ func (m *Manager) setupNetworkAndGetIP(ctx context.Context, containerID string)
 }
 
 func (m *Manager) setupNetworkOrFail(ctx context.Context, containerID, botID string) error {
-	ip, err := m.setupNetworkAndGetIP(ctx, containerID)
+	ip, err := m.setupNetworkAndGetIP(ctx, botID, containerID)
 	if err != nil {
 		return err
 	}
@@ -215,7 +217,7 @@ func (m *Manager) GetContainerInfo(ctx context.Context, botID string) (*Containe
 				if row.UpdatedAt.Valid {
 					updatedAt = row.UpdatedAt.Time
 				}
-				return &ContainerStatus{
+				return m.withResourceStatus(ctx, botID, &ContainerStatus{
 					ContainerID:      row.ContainerID,
 					Image:            row.Image,
 					Status:           row.Status,
@@ -226,7 +228,7 @@ func (m *Manager) GetContainerInfo(ctx context.Context, botID string) (*Containe
 					Legacy:           m.IsLegacyContainer(ctx, row.ContainerID),
 					CreatedAt:        createdAt,
 					UpdatedAt:        updatedAt,
-				}, nil
+				}), nil
 			}
 		}
 	}
@@ -242,7 +244,7 @@ func (m *Manager) GetContainerInfo(ctx context.Context, botID string) (*Containe
 		}
 		return nil, err
 	}
-	return &ContainerStatus{
+	return m.withResourceStatus(ctx, botID, &ContainerStatus{
 		ContainerID:      info.ID,
 		Image:            info.Image,
 		Status:           "unknown",
@@ -252,7 +254,22 @@ func (m *Manager) GetContainerInfo(ctx context.Context, botID string) (*Containe
 		Legacy:           m.IsLegacyContainer(ctx, containerID),
 		CreatedAt:        info.CreatedAt,
 		UpdatedAt:        info.UpdatedAt,
-	}, nil
+	}), nil
+}
+
+// withResourceStatus attaches the bot's resource profile and current usage.
+// Both are best effort; a failure leaves them unset.
+func (m *Manager) withResourceStatus(ctx context.Context, botID string, status *ContainerStatus) *ContainerStatus {
+	profile, err := m.ResourceProfile(ctx, botID)
+	if err != nil {
+		m.logger.Warn("load resource profile failed", slog.String("bot_id", botID), slog.Any("error", err))
+		return status
+	}
+	status.Resources = &profile
+	if usage, err := m.ResourceUsage(ctx, botID); err == nil {
+		status.Usage = usage
+	}
+	return status
 }
 
 // PullImage pulls a container image. This is exposed so the HTTP layer can
@@ -372,7 +389,7 @@ func (m *Manager) ReconcileContainers(ctx context.Context) {
 					continue
 				}
 			}
-			if ip, netErr := m.setupNetworkAndGetIP(ctx, containerID); netErr != nil {
+			if ip, netErr := m.setupNetworkAndGetIP(ctx, botID, containerID); netErr != nil {
 				m.logger.Error("reconcile: network setup failed for legacy container",
 					slog.String("bot_id", botID), slog.Any("error", netErr))
 			} else {
@@ -0,0 +1,272 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/jackc/pgx/v5"
+
+	ctr "github.com/memohai/memoh/internal/containerd"
+	"github.com/memohai/memoh/internal/db"
+	dbsqlc "github.com/memohai/memoh/internal/db/sqlc"
+)
+
+const (
+	maxNetworkAllowEntries = 256
+	egressResolveTimeout   = 5 * time.Second
+)
+
+var ErrInvalidNetworkPolicy = errors.New("invalid network policy")
+
+// NetworkPolicy is the egress policy of a bot's container. Allow entries are
+// domains, IP addresses or CIDRs; domains are resolved whenever the network
+// is set up or the policy changes. In allowlist mode the bot's MCP servers
+// and the browser gateway stay reachable without being listed.
+type NetworkPolicy struct {
+	Mode  string   `json:"mode"`
+	Allow []string `json:"allow"`
+	// AutoAllow lists the hosts allowed on top of Allow in allowlist mode.
+	AutoAllow []string `json:"auto_allow,omitempty"`
+}
+
+// EgressHostSource lists hosts a bot's container must reach whatever its
+// allow list says, e.g. its MCP servers.
+type EgressHostSource interface {
+	EgressHosts(ctx context.Context, botID string) ([]string, error)
+}
+
+// StaticEgressHosts is an EgressHostSource with the same hosts for every bot.
+type StaticEgressHosts []string
+
+func (h StaticEgressHosts) EgressHosts(context.Context, string) ([]string, error) {
+	return h, nil
+}
+
+// SetEgressHostSources sets the sources of hosts that allowlist policies
+// permit implicitly.
+func (m *Manager) SetEgressHostSources(sources ...EgressHostSource) {
+	m.egressSources = sources
+}
+
+func (p *NetworkPolicy) normalize() error {
+	p.Mode = strings.ToLower(strings.TrimSpace(p.Mode))
+	if p.Mode == "" {
+		p.Mode = ctr.EgressModeFull
+	}
+	switch p.Mode {
+	case ctr.EgressModeFull, ctr.EgressModeAllowlist, ctr.EgressModeNone:
+	default:
+		return fmt.Errorf("%w: mode must be full, allowlist or none", ErrInvalidNetworkPolicy)
+	}
+	allow := make([]string, 0, len(p.Allow))
+	for _, raw := range p.Allow {
+		entry := strings.ToLower(strings.TrimSpace(raw))
+		if entry == "" || slices.Contains(allow, entry) {
+			continue
+		}
+		if !validAllowEntry(entry) {
+			return fmt.Errorf("%w: %q is not a domain, IP address or CIDR", ErrInvalidNetworkPolicy, raw)
+		}
+		allow = append(allow, entry)
+	}
+	if len(allow) > maxNetworkAllowEntries {
+		return fmt.Errorf("%w: at most %d allow entries", ErrInvalidNetworkPolicy, maxNetworkAllowEntries)
+	}
+	p.Allow = allow
+	return nil
+}
+
+func validAllowEntry(entry string) bool {
+	if _, err := netip.ParsePrefix(entry); err == nil {
+		return true
+	}
+	if _, err := netip.ParseAddr(entry); err == nil {
+		return true
+	}
+	return validHostname(entry)
+}
+
+func validHostname(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// NetworkPolicy returns the bot's egress policy; bots without one have full
+// network access.
+func (m *Manager) NetworkPolicy(ctx context.Context, botID string) (NetworkPolicy, error) {
+	policy := NetworkPolicy{Mode: ctr.EgressModeFull, Allow: []string{}}
+	if m.queries == nil {
+		return policy, nil
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return NetworkPolicy{}, err
+	}
+	row, err := m.queries.GetBotNetworkPolicy(ctx, pgBotID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return policy, nil
+		}
+		return NetworkPolicy{}, err
+	}
+	policy = networkPolicyFromRow(row)
+	if policy.Mode == ctr.EgressModeAllowlist {
+		policy.AutoAllow = m.autoAllowHosts(ctx, botID)
+	}
+	return policy, nil
+}
+
+// UpdateNetworkPolicy stores the bot's egress policy and applies it to the
+// running container right away.
+func (m *Manager) UpdateNetworkPolicy(ctx context.Context, botID string, policy NetworkPolicy) (NetworkPolicy, error) {
+	if err := policy.normalize(); err != nil {
+		return NetworkPolicy{}, err
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return NetworkPolicy{}, err
+	}
+	row, err := m.queries.UpsertBotNetworkPolicy(ctx, dbsqlc.UpsertBotNetworkPolicyParams{
+		BotID:      pgBotID,
+		EgressMode: policy.Mode,
+		AllowList:  policy.Allow,
+	})
+	if err != nil {
+		return NetworkPolicy{}, err
+	}
+	stored := networkPolicyFromRow(row)
+	if stored.Mode == ctr.EgressModeAllowlist {
+		stored.AutoAllow = m.autoAllowHosts(ctx, botID)
+	}
+	if err := m.applyNetworkPolicy(ctx, botID, stored); err != nil {
+		return NetworkPolicy{}, err
+	}
+	return stored, nil
+}
+
+func (m *Manager) applyNetworkPolicy(ctx context.Context, botID string, policy NetworkPolicy) error {
+	containerID, err := m.ContainerID(ctx, botID)
+	if err != nil {
+		if errors.Is(err, ErrContainerNotFound) {
+			return nil
+		}
+		return err
+	}
+	egress := m.resolveEgress(ctx, botID, policy)
+	if err := m.service.ApplyEgressPolicy(ctx, containerID, egress); err != nil {
+		if errdefs.IsNotFound(err) || (errors.Is(err, ctr.ErrNotSupported) && !egress.Restricted()) {
+			return nil
+		}
+		return fmt.Errorf("apply network policy: %w", err)
+	}
+	return nil
+}
+
+// containerEgress returns the egress policy to set up the bot's network
+// with, or nil for full access. A lookup failure cuts the container off
+// rather than silently lifting a restriction.
+func (m *Manager) containerEgress(ctx context.Context, botID string) *ctr.EgressPolicy {
+	policy, err := m.NetworkPolicy(ctx, botID)
+	if err != nil {
+		m.logger.Warn("load network policy failed, blocking egress",
+			slog.String("bot_id", botID), slog.Any("error", err))
+		return &ctr.EgressPolicy{Mode: ctr.EgressModeNone}
+	}
+	if policy.Mode == ctr.EgressModeFull {
+		return nil
+	}
+	egress := m.resolveEgress(ctx, botID, policy)
+	return &egress
+}
+
+// resolveEgress turns a policy into prefixes. Names that fail to resolve are
+// skipped, which only ever narrows the policy.
+func (m *Manager) resolveEgress(ctx context.Context, botID string, policy NetworkPolicy) ctr.EgressPolicy {
+	egress := ctr.EgressPolicy{Mode: policy.Mode}
+	if policy.Mode != ctr.EgressModeAllowlist {
+		return egress
+	}
+	ctx, cancel := context.WithTimeout(ctx, egressResolveTimeout)
+	defer cancel()
+	for _, entry := range slices.Concat(policy.Allow, policy.AutoAllow) {
+		prefixes, err := resolveAllowEntry(ctx, entry)
+		if err != nil {
+			m.logger.Warn("resolve egress allow entry failed",
+				slog.String("bot_id", botID), slog.String("entry", entry), slog.Any("error", err))
+			continue
+		}
+		for _, prefix := range prefixes {
+			if !slices.Contains(egress.Allow, prefix) {
+				egress.Allow = append(egress.Allow, prefix)
+			}
+		}
+	}
+	return egress
+}
+
+func (m *Manager) autoAllowHosts(ctx context.Context, botID string) []string {
+	var hosts []string
+	for _, source := range m.egressSources {
+		items, err := source.EgressHosts(ctx, botID)
+		if err != nil {
+			m.logger.Warn("list egress hosts failed", slog.String("bot_id", botID), slog.Any("error", err))
+			continue
+		}
+		for _, host := range items {
+			host = strings.ToLower(strings.TrimSpace(host))
+			if host != "" && !slices.Contains(hosts, host) {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	return hosts
+}
+
+func resolveAllowEntry(ctx context.Context, entry string) ([]netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(entry); err == nil {
+		return []netip.Prefix{prefix.Masked()}, nil
+	}
+	if addr, err := netip.ParseAddr(entry); err == nil {
+		addr = addr.WithZone("").Unmap()
+		return []netip.Prefix{netip.PrefixFrom(addr, addr.BitLen())}, nil
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", entry)
+	if err != nil {
+		return nil, err
+	}
+	prefixes := make([]netip.Prefix, 0, len(addrs))
+	for _, addr := range addrs {
+		addr = addr.WithZone("").Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+func networkPolicyFromRow(row dbsqlc.BotNetworkPolicy) NetworkPolicy {
+	allow := row.AllowList
+	if allow == nil {
+		allow = []string{}
+	}
+	return NetworkPolicy{Mode: row.EgressMode, Allow: allow}
+}
@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"testing"
+
+	ctr "github.com/memohai/memoh/internal/containerd"
+)
+
+func TestNetworkPolicyNormalize(t *testing.T) {
+	t.Parallel()
+
+	p := NetworkPolicy{Mode: " AllowList ", Allow: []string{"API.example.com", "api.example.com", " ", "10.0.0.0/8", "2001:db8::1"}}
+	if err := p.normalize(); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if p.Mode != ctr.EgressModeAllowlist {
+		t.Fatalf("mode = %q", p.Mode)
+	}
+	if want := []string{"api.example.com", "10.0.0.0/8", "2001:db8::1"}; !slices.Equal(p.Allow, want) {
+		t.Fatalf("allow = %v, want %v", p.Allow, want)
+	}
+
+	empty := NetworkPolicy{}
+	if err := empty.normalize(); err != nil || empty.Mode != ctr.EgressModeFull {
+		t.Fatalf("empty policy = %+v, %v", empty, err)
+	}
+
+	for _, bad := range []NetworkPolicy{
+		{Mode: "open"},
+		{Mode: ctr.EgressModeAllowlist, Allow: []string{"*.example.com"}},
+		{Mode: ctr.EgressModeAllowlist, Allow: []string{"http://example.com"}},
+		{Mode: ctr.EgressModeAllowlist, Allow: []string{"-bad.example.com"}},
+	} {
+		if err := bad.normalize(); !errors.Is(err, ErrInvalidNetworkPolicy) {
+			t.Errorf("normalize(%+v) = %v, want ErrInvalidNetworkPolicy", bad, err)
+		}
+	}
+}
+
+func TestResolveEgressIncludesAutoAllowHosts(t *testing.T) {
+	t.Parallel()
+
+	m := &Manager{logger: slog.New(slog.DiscardHandler)}
+	m.SetEgressHostSources(StaticEgressHosts{"192.0.2.10", " 192.0.2.10 "}, StaticEgressHosts{"2001:db8::5"})
+	policy := NetworkPolicy{Mode: ctr.EgressModeAllowlist, Allow: []string{"10.1.0.0/16", "198.51.100.7"}}
+	policy.AutoAllow = m.autoAllowHosts(context.Background(), "bot")
+
+	egress := m.resolveEgress(context.Background(), "bot", policy)
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.1.0.0/16"),
+		netip.MustParsePrefix("198.51.100.7/32"),
+		netip.MustParsePrefix("192.0.2.10/32"),
+		netip.MustParsePrefix("2001:db8::5/128"),
+	}
+	if egress.Mode != ctr.EgressModeAllowlist || !slices.Equal(egress.Allow, want) {
+		t.Fatalf("egress = %+v, want allow %v", egress, want)
+	}
+
+	none := m.resolveEgress(context.Background(), "bot", NetworkPolicy{Mode: ctr.EgressModeNone, Allow: []string{"10.0.0.0/8"}})
+	if len(none.Allow) != 0 {
+		t.Fatalf("none mode should not allow anything, got %v", none.Allow)
+	}
+}
+
+func TestContainerEgressFullIsUnrestricted(t *testing.T) {
+	t.Parallel()
+
+	m := &Manager{logger: slog.New(slog.DiscardHandler)}
+	if egress := m.containerEgress(context.Background(), "bot"); egress != nil {
+		t.Fatalf("bots without a policy should keep full access, got %+v", egress)
+	}
+}
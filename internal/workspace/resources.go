@@ -0,0 +1,227 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/containerd/errdefs"
+	"github.com/jackc/pgx/v5"
+
+	ctr "github.com/memohai/memoh/internal/containerd"
+	"github.com/memohai/memoh/internal/db"
+	dbsqlc "github.com/memohai/memoh/internal/db/sqlc"
+)
+
+const (
+	maxCPUShares   = 262144
+	minMemoryMB    = 64
+	maxProfileUnit = 1 << 30
+)
+
+var ErrInvalidResourceProfile = errors.New("invalid resource profile")
+
+// ResourceProfile holds the container resource limits of a bot. Zero fields
+// are unlimited. There is no disk limit: overlay snapshots cannot enforce a
+// quota, so disk usage is only reported through ResourceUsage.
+type ResourceProfile struct {
+	CPUShares     int `json:"cpu_shares"`
+	CPUMillicores int `json:"cpu_millicores"`
+	MemoryMB      int `json:"memory_mb"`
+	PidsLimit     int `json:"pids_limit"`
+	// Inherited is true when the bot has no profile of its own and the
+	// server defaults apply.
+	Inherited bool `json:"inherited"`
+}
+
+// ResourceUsage is a live usage sample of a bot's container.
+type ResourceUsage struct {
+	CPUUsageSeconds  float64 `json:"cpu_usage_seconds"`
+	MemoryBytes      uint64  `json:"memory_bytes"`
+	MemoryLimitBytes uint64  `json:"memory_limit_bytes,omitempty"`
+	Pids             uint64  `json:"pids"`
+	PidsLimit        uint64  `json:"pids_limit,omitempty"`
+	DiskBytes        int64   `json:"disk_bytes"`
+}
+
+func (p ResourceProfile) validate() error {
+	for _, v := range []int{p.CPUShares, p.CPUMillicores, p.MemoryMB, p.PidsLimit} {
+		if v < 0 || v > maxProfileUnit {
+			return fmt.Errorf("%w: limits must be between 0 and %d", ErrInvalidResourceProfile, maxProfileUnit)
+		}
+	}
+	if p.CPUShares != 0 && (p.CPUShares < 2 || p.CPUShares > maxCPUShares) {
+		return fmt.Errorf("%w: cpu_shares must be between 2 and %d", ErrInvalidResourceProfile, maxCPUShares)
+	}
+	if p.MemoryMB != 0 && p.MemoryMB < minMemoryMB {
+		return fmt.Errorf("%w: memory_mb must be at least %d", ErrInvalidResourceProfile, minMemoryMB)
+	}
+	return nil
+}
+
+// limits converts the profile to cgroup limits. One CPU is 1000 millicores,
+// i.e. a full CFS period of quota.
+func (p ResourceProfile) limits() ctr.ResourceLimits {
+	limits := ctr.ResourceLimits{
+		CPUShares:   uint64(p.CPUShares), //nolint:gosec // validated non-negative
+		MemoryBytes: int64(p.MemoryMB) << 20,
+		PidsLimit:   int64(p.PidsLimit),
+	}
+	if p.CPUMillicores > 0 {
+		limits.CPUPeriod = ctr.DefaultCPUPeriod
+		limits.CPUQuota = int64(p.CPUMillicores) * int64(ctr.DefaultCPUPeriod) / 1000 //nolint:gosec // DefaultCPUPeriod is a small constant
+	}
+	return limits
+}
+
+func (m *Manager) defaultResourceProfile() ResourceProfile {
+	return ResourceProfile{
+		CPUShares:     m.cfg.CPUShares,
+		CPUMillicores: m.cfg.CPUMillicores,
+		MemoryMB:      m.cfg.MemoryMB,
+		PidsLimit:     m.cfg.PidsLimit,
+		Inherited:     true,
+	}
+}
+
+// ResourceProfile returns the bot's resource profile, falling back to the
+// server defaults when the bot has none.
+func (m *Manager) ResourceProfile(ctx context.Context, botID string) (ResourceProfile, error) {
+	if m.queries == nil {
+		return m.defaultResourceProfile(), nil
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return ResourceProfile{}, err
+	}
+	row, err := m.queries.GetBotResourceLimits(ctx, pgBotID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return m.defaultResourceProfile(), nil
+		}
+		return ResourceProfile{}, err
+	}
+	return resourceProfileFromRow(row), nil
+}
+
+// UpdateResourceProfile stores the bot's resource profile and applies it to
+// the existing container, including its running task.
+func (m *Manager) UpdateResourceProfile(ctx context.Context, botID string, profile ResourceProfile) (ResourceProfile, error) {
+	if err := profile.validate(); err != nil {
+		return ResourceProfile{}, err
+	}
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return ResourceProfile{}, err
+	}
+	row, err := m.queries.UpsertBotResourceLimits(ctx, dbsqlc.UpsertBotResourceLimitsParams{
+		BotID:         pgBotID,
+		CpuShares:     int32(profile.CPUShares),     //nolint:gosec // validated range
+		CpuMillicores: int32(profile.CPUMillicores), //nolint:gosec // validated range
+		MemoryMb:      int32(profile.MemoryMB),      //nolint:gosec // validated range
+		PidsLimit:     int32(profile.PidsLimit),     //nolint:gosec // validated range
+	})
+	if err != nil {
+		return ResourceProfile{}, err
+	}
+	stored := resourceProfileFromRow(row)
+	if err := m.applyResourceProfile(ctx, botID, stored); err != nil {
+		return ResourceProfile{}, err
+	}
+	return stored, nil
+}
+
+// ResetResourceProfile removes the bot's own profile so the server defaults
+// apply again.
+func (m *Manager) ResetResourceProfile(ctx context.Context, botID string) (ResourceProfile, error) {
+	pgBotID, err := db.ParseUUID(botID)
+	if err != nil {
+		return ResourceProfile{}, err
+	}
+	if err := m.queries.DeleteBotResourceLimits(ctx, pgBotID); err != nil {
+		return ResourceProfile{}, err
+	}
+	profile := m.defaultResourceProfile()
+	if err := m.applyResourceProfile(ctx, botID, profile); err != nil {
+		return ResourceProfile{}, err
+	}
+	return profile, nil
+}
+
+func (m *Manager) applyResourceProfile(ctx context.Context, botID string, profile ResourceProfile) error {
+	containerID, err := m.ContainerID(ctx, botID)
+	if err != nil {
+		if errors.Is(err, ErrContainerNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err := m.service.UpdateContainerResources(ctx, containerID, profile.limits()); err != nil {
+		switch {
+		case errdefs.IsNotFound(err):
+			return nil
+		case errors.Is(err, ctr.ErrNotSupported):
+			m.logger.Warn("container backend does not support resource limits", slog.String("bot_id", botID))
+			return nil
+		default:
+			return fmt.Errorf("apply resource limits: %w", err)
+		}
+	}
+	return nil
+}
+
+// containerResources returns the limits to create the bot's container with.
+// Lookup failures fall back to the server defaults so that a transient
+// database error never blocks container creation.
+func (m *Manager) containerResources(ctx context.Context, botID string) *ctr.ResourceLimits {
+	profile, err := m.ResourceProfile(ctx, botID)
+	if err != nil {
+		m.logger.Warn("load resource profile failed, using defaults",
+			slog.String("bot_id", botID), slog.Any("error", err))
+		profile = m.defaultResourceProfile()
+	}
+	limits := profile.limits()
+	if limits.IsZero() {
+		return nil
+	}
+	return &limits
+}
+
+// ResourceUsage samples the bot container's CPU, memory, pids and snapshot
+// disk usage. Metrics the backend cannot report are left at zero.
+func (m *Manager) ResourceUsage(ctx context.Context, botID string) (*ResourceUsage, error) {
+	containerID, err := m.ContainerID(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	usage := &ResourceUsage{}
+	if metrics, err := m.service.GetContainerMetrics(ctx, containerID); err == nil {
+		usage.CPUUsageSeconds = float64(metrics.CPUUsageNanos) / 1e9
+		usage.MemoryBytes = metrics.MemoryUsageBytes
+		usage.MemoryLimitBytes = metrics.MemoryLimitBytes
+		usage.Pids = metrics.Pids
+		usage.PidsLimit = metrics.PidsLimit
+	} else if !errdefs.IsNotFound(err) && !errors.Is(err, ctr.ErrNotSupported) {
+		m.logger.Debug("container metrics unavailable", slog.String("bot_id", botID), slog.Any("error", err))
+	}
+	info, err := m.service.GetContainer(ctx, containerID)
+	if err != nil {
+		return usage, nil
+	}
+	if info.Snapshotter != "" && info.SnapshotKey != "" {
+		if disk, err := m.service.SnapshotUsage(ctx, info.Snapshotter, info.SnapshotKey); err == nil {
+			usage.DiskBytes = disk.SizeBytes
+		}
+	}
+	return usage, nil
+}
+
+func resourceProfileFromRow(row dbsqlc.BotResourceLimit) ResourceProfile {
+	return ResourceProfile{
+		CPUShares:     int(row.CpuShares),
+		CPUMillicores: int(row.CpuMillicores),
+		MemoryMB:      int(row.MemoryMb),
+		PidsLimit:     int(row.PidsLimit),
+	}
+}
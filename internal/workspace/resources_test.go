@@ -0,0 +1,48 @@
+package workspace
+
+import (
+	"errors"
+	"testing"
+
+	ctr "github.com/memohai/memoh/internal/containerd"
+)
+
+func TestResourceProfileLimits(t *testing.T) {
+	t.Parallel()
+
+	limits := ResourceProfile{CPUShares: 512, CPUMillicores: 1500, MemoryMB: 256, PidsLimit: 128}.limits()
+	want := ctr.ResourceLimits{
+		CPUShares:   512,
+		CPUQuota:    150000,
+		CPUPeriod:   ctr.DefaultCPUPeriod,
+		MemoryBytes: 256 << 20,
+		PidsLimit:   128,
+	}
+	if limits != want {
+		t.Fatalf("limits = %+v, want %+v", limits, want)
+	}
+}
+
+func TestResourceProfileValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := []ResourceProfile{
+		{},
+		{CPUShares: 1024, CPUMillicores: 500, MemoryMB: 512, PidsLimit: 64},
+	}
+	for _, p := range valid {
+		if err := p.validate(); err != nil {
+			t.Errorf("validate(%+v) = %v", p, err)
+		}
+	}
+	invalid := []ResourceProfile{
+		{MemoryMB: -1},
+		{CPUShares: 1},
+		{MemoryMB: 16},
+	}
+	for _, p := range invalid {
+		if err := p.validate(); !errors.Is(err, ErrInvalidResourceProfile) {
+			t.Errorf("validate(%+v) = %v, want ErrInvalidResourceProfile", p, err)
+		}
+	}
+}
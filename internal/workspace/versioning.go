@@ -374,6 +374,7 @@ func (m *Manager) replaceContainerSnapshot(ctx context.Context, botID, container
 	if err != nil {
 		return err
 	}
+	spec.Resources = m.containerResources(ctx, botID)
 	if _, err := m.service.CreateContainerFromSnapshot(ctx, ctr.CreateContainerRequest{
 		ID:          containerID,
 		ImageRef:    info.Image,
@@ -396,6 +397,7 @@ func (m *Manager) replaceContainerSnapshot(ctx context.Context, botID, container
 		ContainerID: containerID,
 		CNIBinDir:   m.cfg.CNIBinaryDir,
 		CNIConfDir:  m.cfg.CNIConfigDir,
+		Egress:      m.containerEgress(ctx, botID),
 	}); err != nil {
 		return fmt.Errorf("network setup after snapshot replace: %w", err)
 	}
@@ -186,11 +186,14 @@ export type AdaptersMemoryStatusResponse = {
     markdown_file_count?: number;
     memory_mode?: string;
     overview_path?: string;
+    pgvector?: AdaptersHealthStatus;
+    pgvector_table?: string;
     provider_type?: string;
     qdrant?: AdaptersHealthStatus;
     qdrant_collection?: string;
     source_count?: number;
     source_dir?: string;
+    vector_store?: string;
 };
 
 export type AdaptersMessage = {
@@ -251,6 +254,7 @@ export type AdaptersProviderStatusResponse = {
     embedding_model_id?: string;
     memory_mode?: string;
     provider_type?: string;
+    vector_store?: string;
 };
 
 export type AdaptersProviderType = 'builtin' | 'mem0' | 'openviking';
@@ -671,6 +675,116 @@ export type EmailUpdateProviderRequest = {
     provider?: string;
 };
 
+export type EventtriggerConfig = {
+    /**
+     * ConnectionID is the bot's HTTP or SSE MCP connection an mcp trigger
+     * listens to.
+     */
+    connection_id?: string;
+    /**
+     * Events limits a file trigger to create, modify and/or delete. Empty
+     * means all three.
+     */
+    events?: Array<string>;
+    /**
+     * From and Subject are case-insensitive substrings an inbound email must
+     * contain to fire an email trigger. Empty matches every email.
+     */
+    from?: string;
+    /**
+     * Methods limits an mcp trigger to these notification methods, e.g.
+     * "notifications/resources/updated". Empty means all of them.
+     */
+    methods?: Array<string>;
+    /**
+     * Path is the file or directory watched by a file trigger. Relative
+     * paths are resolved against /data, and the path must stay below it.
+     */
+    path?: string;
+    /**
+     * Pattern is a glob matched against the base name of changed files,
+     * e.g. "*.csv". Empty matches every file.
+     */
+    pattern?: string;
+    recursive?: boolean;
+    /**
+     * ResourceURI is subscribed to on connect so the server reports its
+     * updates.
+     */
+    resource_uri?: string;
+    subject?: string;
+};
+
+export type EventtriggerCreateRequest = {
+    command?: string;
+    config?: EventtriggerConfig;
+    cooldown_seconds?: number;
+    description?: string;
+    enabled?: boolean;
+    name?: string;
+    source?: string;
+};
+
+export type EventtriggerListLogsResponse = {
+    items?: Array<EventtriggerLog>;
+};
+
+export type EventtriggerListResponse = {
+    items?: Array<EventtriggerTrigger>;
+};
+
+export type EventtriggerLog = {
+    bot_id?: string;
+    completed_at?: string;
+    error_message?: string;
+    event?: unknown;
+    id?: string;
+    result_text?: string;
+    session_id?: string;
+    source?: string;
+    started_at?: string;
+    status?: string;
+    trigger_id?: string;
+    usage?: unknown;
+};
+
+export type EventtriggerTrigger = {
+    bot_id?: string;
+    command?: string;
+    config?: EventtriggerConfig;
+    /**
+     * CooldownSeconds is the minimum time between two runs; events arriving
+     * sooner are dropped.
+     */
+    cooldown_seconds?: number;
+    created_at?: string;
+    current_calls?: number;
+    description?: string;
+    enabled?: boolean;
+    id?: string;
+    last_fired_at?: string;
+    name?: string;
+    source?: string;
+    updated_at?: string;
+    /**
+     * WebhookSecret authenticates calls to a webhook trigger's public URL.
+     */
+    webhook_secret?: string;
+};
+
+export type EventtriggerUpdateRequest = {
+    command?: string;
+    config?: EventtriggerConfig;
+    cooldown_seconds?: number;
+    description?: string;
+    enabled?: boolean;
+    name?: string;
+    /**
+     * RotateSecret issues a new webhook secret.
+     */
+    rotate_secret?: boolean;
+};
+
 export type GithubComMemohaiMemohInternalMcpConnection = {
     auth_type?: string;
     bot_id?: string;
@@ -1034,8 +1148,23 @@ export type HeartbeatListLogsResponse = {
 export type HeartbeatLog = {
     bot_id?: string;
     completed_at?: string;
+    /**
+     * ConsecutiveOK counts HEARTBEAT_OK runs in a row ending with this one.
+     */
+    consecutive_ok?: number;
     error_message?: string;
     id?: string;
+    next_run_at?: string;
+    /**
+     * NextRunReason explains NextRunAt: interval, backoff, requested or
+     * active_hours.
+     */
+    next_run_reason?: string;
+    /**
+     * RequestedInterval is the next-check interval in minutes the run asked
+     * for, if any.
+     */
+    requested_interval?: number;
     result_text?: string;
     session_id?: string;
     started_at?: string;
@@ -1278,11 +1407,20 @@ export type ProvidersUpdateRequest = {
 
 export type ScheduleCreateRequest = {
     command?: string;
+    delivery_platform?: string;
+    delivery_target?: string;
     description?: string;
     enabled?: boolean;
+    /**
+     * In is a Go duration such as "2h" or "90m" after which a one-shot
+     * schedule fires.
+     */
+    in?: string;
     max_calls?: ScheduleNullableInt;
     name?: string;
     pattern?: string;
+    run_at?: string;
+    timezone?: string;
 };
 
 export type ScheduleListLogsResponse = {
@@ -1316,22 +1454,44 @@ export type ScheduleSchedule = {
     command?: string;
     created_at?: string;
     current_calls?: number;
+    /**
+     * DeliveryPlatform and DeliveryTarget name the channel conversation that
+     * receives each run's final answer. Both are empty when the run only
+     * delivers what it sends itself.
+     */
+    delivery_platform?: string;
+    delivery_target?: string;
     description?: string;
     enabled?: boolean;
     id?: string;
     max_calls?: number;
     name?: string;
     pattern?: string;
+    /**
+     * RunAt is set for one-shot schedules, which have no pattern and disable
+     * themselves after firing once.
+     */
+    run_at?: string;
+    /**
+     * Timezone is the IANA zone the pattern is evaluated in. Empty means the
+     * server's local zone.
+     */
+    timezone?: string;
     updated_at?: string;
 };
 
 export type ScheduleUpdateRequest = {
     command?: string;
+    delivery_platform?: string;
+    delivery_target?: string;
     description?: string;
     enabled?: boolean;
+    in?: string;
     max_calls?: ScheduleNullableInt;
     name?: string;
     pattern?: string;
+    run_at?: string;
+    timezone?: string;
 };
 
 export type SearchprovidersCreateRequest = {
@@ -1410,16 +1570,47 @@ export type SettingsSettings = {
     compaction_enabled?: boolean;
     compaction_model_id?: string;
     compaction_threshold?: number;
+    /**
+     * FetchAllowDomains, when non-empty, limits web_fetch and attachment
+     * downloads to these domains and their subdomains.
+     */
+    fetch_allow_domains?: Array<string>;
+    /**
+     * FetchDenyDomains blocks these domains and their subdomains for
+     * web_fetch and attachment downloads.
+     */
+    fetch_deny_domains?: Array<string>;
+    heartbeat_active_end?: string;
+    /**
+     * HeartbeatActiveStart and HeartbeatActiveEnd bound the daily "HH:MM"
+     * window, in Timezone, that heartbeats run in. Both empty means always.
+     */
+    heartbeat_active_start?: string;
     heartbeat_enabled?: boolean;
     heartbeat_interval?: number;
+    /**
+     * HeartbeatJitter is the maximum random delay in minutes added to each
+     * heartbeat so bots do not fire in lockstep.
+     */
+    heartbeat_jitter?: number;
+    /**
+     * HeartbeatMaxInterval is the ceiling in minutes for backing off after
+     * consecutive HEARTBEAT_OK runs; 0 disables backoff.
+     */
+    heartbeat_max_interval?: number;
     heartbeat_model_id?: string;
     language?: string;
     max_context_load_time?: number;
     max_context_tokens?: number;
     memory_provider_id?: string;
+    memory_scope_policy?: string;
     reasoning_effort?: string;
     reasoning_enabled?: boolean;
     search_provider_id?: string;
+    /**
+     * Timezone is the bot's IANA timezone; empty means UTC.
+     */
+    timezone?: string;
     title_model_id?: string;
     tts_model_id?: string;
 };
@@ -1431,16 +1622,28 @@ export type SettingsUpsertRequest = {
     compaction_enabled?: boolean;
     compaction_model_id?: string;
     compaction_threshold?: number;
+    /**
+     * FetchAllowDomains and FetchDenyDomains replace the stored lists when
+     * non-nil; an empty list clears them.
+     */
+    fetch_allow_domains?: Array<string>;
+    fetch_deny_domains?: Array<string>;
+    heartbeat_active_end?: string;
+    heartbeat_active_start?: string;
     heartbeat_enabled?: boolean;
     heartbeat_interval?: number;
+    heartbeat_jitter?: number;
+    heartbeat_max_interval?: number;
     heartbeat_model_id?: string;
     language?: string;
     max_context_load_time?: number;
     max_context_tokens?: number;
     memory_provider_id?: string;
+    memory_scope_policy?: string;
     reasoning_effort?: string;
     reasoning_enabled?: boolean;
     search_provider_id?: string;
+    timezone?: string;
     title_model_id?: string;
     tts_model_id?: string;
 };
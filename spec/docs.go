@@ -63,11 +63,6 @@ const docTemplate = `{
         },
         "/auth/refresh": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
                 "description": "Issue a new JWT using the existing claims with updated expiration",
                 "tags": [
                     "auth"
@@ -92,7 +87,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/bots": {
@@ -1460,6 +1460,226 @@ const docTemplate = `{
                 }
             }
         },
+        "/bots/{bot_id}/container/network": {
+            "get": {
+                "description": "Get the bot container's egress mode (full, allowlist or none) and allow list. auto_allow lists the MCP server and browser gateway hosts an allowlist permits implicitly.",
+                "tags": [
+                    "containerd"
+                ],
+                "summary": "Get container network policy for bot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workspace.NetworkPolicy"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Set the bot container's egress mode and allow list of domains, IP addresses or CIDRs. The policy is enforced in the container's network namespace and applied to the running task immediately.",
+                "tags": [
+                    "containerd"
+                ],
+                "summary": "Update container network policy for bot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Network policy",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/workspace.NetworkPolicy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workspace.NetworkPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bots/{bot_id}/container/resources": {
+            "get": {
+                "description": "Get the CPU, memory, pids and disk limits applied to the bot container. Zero means unlimited; inherited profiles come from the server defaults.",
+                "tags": [
+                    "containerd"
+                ],
+                "summary": "Get container resource profile for bot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workspace.ResourceProfile"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Set the bot's CPU shares, CPU quota (1000 millicores = one CPU), memory, pids and disk quota. Limits are applied to the existing container immediately, including its running task.",
+                "tags": [
+                    "containerd"
+                ],
+                "summary": "Update container resource profile for bot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resource profile",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/workspace.ResourceProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workspace.ResourceProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the bot's own resource profile so the server defaults apply again",
+                "tags": [
+                    "containerd"
+                ],
+                "summary": "Reset container resource profile for bot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workspace.ResourceProfile"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/bots/{bot_id}/container/skills": {
             "get": {
                 "tags": [
@@ -2164,13 +2384,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/heartbeat/logs": {
+        "/bots/{bot_id}/event-triggers": {
             "get": {
-                "description": "List heartbeat execution logs for a bot",
+                "description": "List event triggers of a bot",
                 "tags": [
-                    "heartbeat"
+                    "event-triggers"
                 ],
-                "summary": "List heartbeat logs",
+                "summary": "List event triggers",
                 "parameters": [
                     {
                         "type": "string",
@@ -2178,26 +2398,13 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Before timestamp (RFC3339)",
-                        "name": "before",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/heartbeat.ListLogsResponse"
+                            "$ref": "#/definitions/eventtrigger.ListResponse"
                         }
                     },
                     "400": {
@@ -2214,12 +2421,12 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete all heartbeat execution logs for a bot",
+            "post": {
+                "description": "Create a trigger that runs the bot when a file changes, an email arrives, a webhook is called or an MCP server sends a notification",
                 "tags": [
-                    "heartbeat"
+                    "event-triggers"
                 ],
-                "summary": "Delete heartbeat logs",
+                "summary": "Create event trigger",
                 "parameters": [
                     {
                         "type": "string",
@@ -2227,11 +2434,23 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Event trigger payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/eventtrigger.CreateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/eventtrigger.Trigger"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2248,68 +2467,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/mcp": {
+        "/bots/{bot_id}/event-triggers/logs": {
             "get": {
-                "description": "List MCP connections for a bot",
+                "description": "List event trigger run logs for a bot",
                 "tags": [
-                    "mcp"
+                    "event-triggers"
                 ],
-                "summary": "List MCP connections",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/mcp.ListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
+                "summary": "List event trigger logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Before timestamp (RFC3339)",
+                        "name": "before",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a MCP connection for a bot",
-                "tags": [
-                    "mcp"
-                ],
-                "summary": "Create MCP connection",
-                "parameters": [
                     {
-                        "description": "MCP payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcp.UpsertRequest"
-                        }
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_memohai_memoh_internal_mcp.Connection"
+                            "$ref": "#/definitions/eventtrigger.ListLogsResponse"
                         }
                     },
                     "400": {
@@ -2318,18 +2509,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2337,24 +2516,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/mcp-ops/batch-delete": {
-            "post": {
-                "description": "Delete multiple MCP connections by IDs.",
+            },
+            "delete": {
+                "description": "Delete all event trigger run logs for a bot",
                 "tags": [
-                    "mcp"
+                    "event-triggers"
                 ],
-                "summary": "Batch delete MCP connections",
+                "summary": "Delete event trigger logs",
                 "parameters": [
                     {
-                        "description": "IDs to delete",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.BatchDeleteRequest"
-                        }
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2367,12 +2542,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2382,13 +2551,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/mcp-stdio": {
-            "post": {
-                "description": "Start a stdio MCP process in the bot container and expose it as MCP HTTP endpoint.",
+        "/bots/{bot_id}/event-triggers/{id}": {
+            "get": {
+                "description": "Get an event trigger by ID",
                 "tags": [
-                    "containerd"
+                    "event-triggers"
                 ],
-                "summary": "Create MCP stdio proxy",
+                "summary": "Get event trigger",
                 "parameters": [
                     {
                         "type": "string",
@@ -2398,20 +2567,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Stdio MCP payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.MCPStdioRequest"
-                        }
+                        "type": "string",
+                        "description": "Event trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MCPStdioResponse"
+                            "$ref": "#/definitions/eventtrigger.Trigger"
                         }
                     },
                     "400": {
@@ -2433,15 +2600,13 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/mcp-stdio/{connection_id}": {
-            "post": {
-                "description": "Proxies MCP JSON-RPC requests to a stdio MCP process in the container.",
+            },
+            "put": {
+                "description": "Update an event trigger by ID. Its source cannot change.",
                 "tags": [
-                    "containerd"
+                    "event-triggers"
                 ],
-                "summary": "MCP stdio proxy (JSON-RPC)",
+                "summary": "Update event trigger",
                 "parameters": [
                     {
                         "type": "string",
@@ -2452,26 +2617,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Connection ID",
-                        "name": "connection_id",
+                        "description": "Event trigger ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "JSON-RPC request",
+                        "description": "Event trigger payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/eventtrigger.UpdateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JSON-RPC response: {jsonrpc,id,result|error}",
+                        "description": "OK",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/eventtrigger.Trigger"
                         }
                     },
                     "400": {
@@ -2493,21 +2658,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/mcp/export": {
-            "get": {
-                "description": "Export all MCP connections for a bot in standard mcpServers format.",
+            },
+            "delete": {
+                "description": "Delete an event trigger by ID",
                 "tags": [
-                    "mcp"
+                    "event-triggers"
+                ],
+                "summary": "Delete event trigger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Export MCP connections",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/mcp.ExportResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2515,8 +2691,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -2530,30 +2706,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/mcp/import": {
-            "put": {
-                "description": "Batch import MCP connections from standard mcpServers format. Existing connections (matched by name) get config updated with is_active preserved. New connections are created as active.",
+        "/bots/{bot_id}/event-triggers/{id}/fire": {
+            "post": {
+                "description": "Run an event trigger now with a test event, ignoring its cooldown",
                 "tags": [
-                    "mcp"
+                    "event-triggers"
                 ],
-                "summary": "Import MCP connections",
+                "summary": "Fire event trigger",
                 "parameters": [
                     {
-                        "description": "mcpServers dict",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcp.ImportRequest"
-                        }
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/mcp.ListResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2561,8 +2739,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -2576,27 +2760,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/mcp/{id}": {
+        "/bots/{bot_id}/event-triggers/{id}/logs": {
             "get": {
-                "description": "Get a MCP connection by ID",
+                "description": "List run logs for a specific event trigger",
                 "tags": [
-                    "mcp"
+                    "event-triggers"
                 ],
-                "summary": "Get MCP connection",
+                "summary": "List event trigger logs by trigger",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MCP ID",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event trigger ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Before timestamp (RFC3339)",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_memohai_memoh_internal_mcp.Connection"
+                            "$ref": "#/definitions/eventtrigger.ListLogsResponse"
                         }
                     },
                     "400": {
@@ -2605,12 +2809,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2624,36 +2822,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update a MCP connection by ID",
+            }
+        },
+        "/bots/{bot_id}/heartbeat/logs": {
+            "get": {
+                "description": "List heartbeat execution logs for a bot",
                 "tags": [
-                    "mcp"
+                    "heartbeat"
                 ],
-                "summary": "Update MCP connection",
+                "summary": "List heartbeat logs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MCP ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "MCP payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcp.UpsertRequest"
-                        }
+                        "type": "string",
+                        "description": "Before timestamp (RFC3339)",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_memohai_memoh_internal_mcp.Connection"
+                            "$ref": "#/definitions/heartbeat.ListLogsResponse"
                         }
                     },
                     "400": {
@@ -2662,18 +2866,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2683,16 +2875,16 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a MCP connection by ID",
+                "description": "Delete all heartbeat execution logs for a bot",
                 "tags": [
-                    "mcp"
+                    "heartbeat"
                 ],
-                "summary": "Delete MCP connection",
+                "summary": "Delete heartbeat logs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MCP ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     }
@@ -2707,18 +2899,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2728,35 +2908,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/mcp/{id}/oauth/authorize": {
-            "post": {
-                "description": "Generate PKCE and return authorization URL for the user to authorize",
+        "/bots/{bot_id}/mcp": {
+            "get": {
+                "description": "List MCP connections for a bot",
                 "tags": [
                     "mcp"
                 ],
-                "summary": "Start OAuth authorization flow",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "MCP connection ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Optional client_id",
-                        "name": "payload",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.oauthAuthorizeRequest"
-                        }
-                    }
-                ],
+                "summary": "List MCP connections",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcp.AuthorizeResult"
+                            "$ref": "#/definitions/mcp.ListResponse"
                         }
                     },
                     "400": {
@@ -2765,44 +2928,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/mcp/{id}/oauth/discover": {
+            },
             "post": {
-                "description": "Probe MCP server URL for OAuth requirements and discover authorization server metadata",
+                "description": "Create a MCP connection for a bot",
                 "tags": [
                     "mcp"
                 ],
-                "summary": "Discover OAuth configuration for MCP server",
+                "summary": "Create MCP connection",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "MCP connection ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Optional URL override",
+                        "description": "MCP payload",
                         "name": "payload",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.oauthDiscoverRequest"
+                            "$ref": "#/definitions/mcp.UpsertRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/mcp.DiscoveryResult"
+                            "$ref": "#/definitions/github_com_memohai_memoh_internal_mcp.Connection"
                         }
                     },
                     "400": {
@@ -2811,73 +2978,100 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/bots/{bot_id}/mcp/{id}/oauth/exchange": {
+        "/bots/{bot_id}/mcp-ops/batch-delete": {
             "post": {
-                "description": "Frontend callback page calls this to exchange the authorization code for access/refresh tokens",
+                "description": "Delete multiple MCP connections by IDs.",
                 "tags": [
                     "mcp"
                 ],
-                "summary": "Exchange OAuth authorization code for tokens",
+                "summary": "Batch delete MCP connections",
                 "parameters": [
                     {
-                        "description": "Authorization code and state",
+                        "description": "IDs to delete",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.oauthExchangeRequest"
+                            "$ref": "#/definitions/handlers.BatchDeleteRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "boolean"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/bots/{bot_id}/mcp/{id}/oauth/status": {
-            "get": {
-                "description": "Returns the current OAuth status including whether tokens are available",
+        "/bots/{bot_id}/mcp-stdio": {
+            "post": {
+                "description": "Start a stdio MCP process in the bot container and expose it as MCP HTTP endpoint.",
                 "tags": [
-                    "mcp"
+                    "containerd"
                 ],
-                "summary": "Get OAuth status for MCP connection",
+                "summary": "Create MCP stdio proxy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MCP connection ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Stdio MCP payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MCPStdioRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcp.OAuthStatus"
+                            "$ref": "#/definitions/handlers.MCPStdioResponse"
                         }
                     },
                     "400": {
@@ -2891,32 +3085,9 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/bots/{bot_id}/mcp/{id}/oauth/token": {
-            "delete": {
-                "description": "Clears stored OAuth tokens",
-                "tags": [
-                    "mcp"
-                ],
-                "summary": "Revoke OAuth tokens for MCP connection",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "MCP connection ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -2924,27 +3095,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/mcp/{id}/probe": {
+        "/bots/{bot_id}/mcp-stdio/{connection_id}": {
             "post": {
-                "description": "Probe a MCP connection to discover tools and verify connectivity",
+                "description": "Proxies MCP JSON-RPC requests to a stdio MCP process in the container.",
                 "tags": [
-                    "mcp"
+                    "containerd"
                 ],
-                "summary": "Probe MCP connection",
+                "summary": "MCP stdio proxy (JSON-RPC)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MCP connection ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Connection ID",
+                        "name": "connection_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "JSON-RPC request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "JSON-RPC response: {jsonrpc,id,result|error}",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ProbeResponse"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -2953,12 +3140,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2974,36 +3155,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/memory": {
+        "/bots/{bot_id}/mcp/export": {
             "get": {
-                "description": "List all memories in the bot-shared namespace",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Export all MCP connections for a bot in standard mcpServers format.",
                 "tags": [
-                    "memory"
-                ],
-                "summary": "Get all memories",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Skip optional stats in memory search response",
-                        "name": "no_stats",
-                        "in": "query"
-                    }
+                    "mcp"
                 ],
+                "summary": "Export MCP connections",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.SearchResponse"
+                            "$ref": "#/definitions/mcp.ExportResponse"
                         }
                     },
                     "400": {
@@ -3023,42 +3186,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
-            },
-            "post": {
-                "description": "Add memory into the bot-shared namespace",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/bots/{bot_id}/mcp/import": {
+            "put": {
+                "description": "Batch import MCP connections from standard mcpServers format. Existing connections (matched by name) get config updated with is_active preserved. New connections are created as active.",
                 "tags": [
-                    "memory"
+                    "mcp"
                 ],
-                "summary": "Add memory",
+                "summary": "Import MCP connections",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Memory add payload",
+                        "description": "mcpServers dict",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.memoryAddPayload"
+                            "$ref": "#/definitions/mcp.ImportRequest"
                         }
                     }
                 ],
@@ -3066,7 +3212,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.SearchResponse"
+                            "$ref": "#/definitions/mcp.ListResponse"
                         }
                     },
                     "400": {
@@ -3086,49 +3232,31 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete specific memories by IDs, or delete all memories if no IDs are provided",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/bots/{bot_id}/mcp/{id}": {
+            "get": {
+                "description": "Get a MCP connection by ID",
                 "tags": [
-                    "memory"
+                    "mcp"
                 ],
-                "summary": "Delete memories",
+                "summary": "Get MCP connection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
+                        "description": "MCP ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Optional: specify memory_ids to delete; if omitted, deletes all",
-                        "name": "payload",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.memoryDeletePayload"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.DeleteResponse"
+                            "$ref": "#/definitions/github_com_memohai_memoh_internal_mcp.Connection"
                         }
                     },
                     "400": {
@@ -3143,49 +3271,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/memory/compact": {
-            "post": {
-                "description": "Consolidate memories by merging similar/redundant entries using LLM.\n\n**ratio** (required, range (0,1]):\n- 0.8 = light compression, mostly dedup, keep ~80% of entries\n- 0.5 = moderate compression, merge similar facts, keep ~50%\n- 0.3 = aggressive compression, heavily consolidate, keep ~30%\n\n**decay_days** (optional): enable time decay — memories older than N days are treated as low priority and more likely to be merged/dropped.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "put": {
+                "description": "Update a MCP connection by ID",
                 "tags": [
-                    "memory"
+                    "mcp"
                 ],
-                "summary": "Compact memories",
+                "summary": "Update MCP connection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
+                        "description": "MCP ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "ratio (0,1] required; decay_days optional",
+                        "description": "MCP payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.memoryCompactPayload"
+                            "$ref": "#/definitions/mcp.UpsertRequest"
                         }
                     }
                 ],
@@ -3193,7 +3313,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.CompactResult"
+                            "$ref": "#/definitions/github_com_memohai_memoh_internal_mcp.Connection"
                         }
                     },
                     "400": {
@@ -3208,46 +3328,38 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/memory/rebuild": {
-            "post": {
-                "description": "Read memory files from the container filesystem (source of truth) and restore missing entries to memory storage",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Delete a MCP connection by ID",
                 "tags": [
-                    "memory"
+                    "mcp"
                 ],
-                "summary": "Rebuild memories from filesystem",
+                "summary": "Delete MCP connection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
+                        "description": "MCP ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/adapters.RebuildResult"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3261,8 +3373,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -3272,44 +3384,31 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/bots/{bot_id}/memory/search": {
+        "/bots/{bot_id}/mcp/{id}/oauth/authorize": {
             "post": {
-                "description": "Search memory in the bot-shared namespace",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Generate PKCE and return authorization URL for the user to authorize",
                 "tags": [
-                    "memory"
+                    "mcp"
                 ],
-                "summary": "Search memory",
+                "summary": "Start OAuth authorization flow",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
+                        "description": "MCP connection ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Memory search payload",
+                        "description": "Optional client_id",
                         "name": "payload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.memorySearchPayload"
+                            "$ref": "#/definitions/handlers.oauthAuthorizeRequest"
                         }
                     }
                 ],
@@ -3317,7 +3416,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.SearchResponse"
+                            "$ref": "#/definitions/mcp.AuthorizeResult"
                         }
                     },
                     "400": {
@@ -3326,57 +3425,44 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/bots/{bot_id}/memory/status": {
-            "get": {
-                "description": "Get the resolved memory runtime status for a bot, including index health and source counts",
-                "produces": [
-                    "application/json"
-                ],
+        "/bots/{bot_id}/mcp/{id}/oauth/discover": {
+            "post": {
+                "description": "Probe MCP server URL for OAuth requirements and discover authorization server metadata",
                 "tags": [
-                    "memory"
+                    "mcp"
                 ],
-                "summary": "Get memory runtime status",
+                "summary": "Discover OAuth configuration for MCP server",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
+                        "description": "MCP connection ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Optional URL override",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.oauthDiscoverRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.MemoryStatusResponse"
+                            "$ref": "#/definitions/mcp.DiscoveryResult"
                         }
                     },
                     "400": {
@@ -3385,26 +3471,45 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "409": {
-                        "description": "Conflict",
+                    }
+                }
+            }
+        },
+        "/bots/{bot_id}/mcp/{id}/oauth/exchange": {
+            "post": {
+                "description": "Frontend callback page calls this to exchange the authorization code for access/refresh tokens",
+                "tags": [
+                    "mcp"
+                ],
+                "summary": "Exchange OAuth authorization code for tokens",
+                "parameters": [
+                    {
+                        "description": "Authorization code and state",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.oauthExchangeRequest"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -3412,21 +3517,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/memory/usage": {
+        "/bots/{bot_id}/mcp/{id}/oauth/status": {
             "get": {
-                "description": "Query the estimated storage usage of current memories",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Returns the current OAuth status including whether tokens are available",
                 "tags": [
-                    "memory"
+                    "mcp"
                 ],
-                "summary": "Get memory usage",
+                "summary": "Get OAuth status for MCP connection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
+                        "description": "MCP connection ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -3435,7 +3537,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.UsageResponse"
+                            "$ref": "#/definitions/mcp.OAuthStatus"
                         }
                     },
                     "400": {
@@ -3444,20 +3546,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -3465,27 +3555,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/memory/{id}": {
+        "/bots/{bot_id}/mcp/{id}/oauth/token": {
             "delete": {
-                "description": "Delete a single memory by its ID",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Clears stored OAuth tokens",
                 "tags": [
-                    "memory"
+                    "mcp"
                 ],
-                "summary": "Delete a single memory",
+                "summary": "Revoke OAuth tokens for MCP connection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
+                        "description": "MCP connection ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bots/{bot_id}/mcp/{id}/probe": {
+            "post": {
+                "description": "Probe a MCP connection to discover tools and verify connectivity",
+                "tags": [
+                    "mcp"
+                ],
+                "summary": "Probe MCP connection",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Memory ID",
+                        "description": "MCP connection ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3495,7 +3604,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.DeleteResponse"
+                            "$ref": "#/definitions/handlers.ProbeResponse"
                         }
                     },
                     "400": {
@@ -3510,14 +3619,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -3525,16 +3634,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/messages": {
+        "/bots/{bot_id}/memory": {
             "get": {
-                "description": "List messages for a bot history with optional pagination",
+                "description": "List all memories in the bot-shared namespace",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "memory"
                 ],
-                "summary": "List bot history messages",
+                "summary": "Get all memories",
                 "parameters": [
                     {
                         "type": "string",
@@ -3544,15 +3653,9 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Before",
-                        "name": "before",
+                        "type": "boolean",
+                        "description": "Skip optional stats in memory search response",
+                        "name": "no_stats",
                         "in": "query"
                     }
                 ],
@@ -3560,13 +3663,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/message.Message"
-                                }
-                            }
+                            "$ref": "#/definitions/adapters.SearchResponse"
                         }
                     },
                     "400": {
@@ -3586,18 +3683,27 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             },
-            "delete": {
-                "description": "Clear all persisted bot-level history messages",
+            "post": {
+                "description": "Add memory into the bot-shared namespace",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "memory"
                 ],
-                "summary": "Delete all bot history messages",
+                "summary": "Add memory",
                 "parameters": [
                     {
                         "type": "string",
@@ -3605,11 +3711,23 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Memory add payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.memoryAddPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/adapters.SearchResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3628,60 +3746,49 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/bots/{bot_id}/schedule": {
-            "get": {
-                "description": "List schedules for current user",
-                "tags": [
-                    "schedule"
-                ],
-                "summary": "List schedules",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/schedule.ListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a schedule for current user",
+            "delete": {
+                "description": "Delete specific memories by IDs, or delete all memories if no IDs are provided",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "schedule"
+                    "memory"
                 ],
-                "summary": "Create schedule",
+                "summary": "Delete memories",
                 "parameters": [
                     {
-                        "description": "Schedule payload",
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional: specify memory_ids to delete; if omitted, deletes all",
                         "name": "payload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schedule.CreateRequest"
+                            "$ref": "#/definitions/handlers.memoryDeletePayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schedule.Schedule"
+                            "$ref": "#/definitions/adapters.DeleteResponse"
                         }
                     },
                     "400": {
@@ -3690,22 +3797,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/bots/{bot_id}/schedule/logs": {
-            "get": {
-                "description": "List schedule execution logs for a bot",
+        "/bots/{bot_id}/memory/compact": {
+            "post": {
+                "description": "Consolidate memories by merging similar/redundant entries using LLM.\n\n**ratio** (required, range (0,1]):\n- 0.8 = light compression, mostly dedup, keep ~80% of entries\n- 0.5 = moderate compression, merge similar facts, keep ~50%\n- 0.3 = aggressive compression, heavily consolidate, keep ~30%\n\n**decay_days** (optional): enable time decay — memories older than N days are treated as low priority and more likely to be merged/dropped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "schedule"
+                    "memory"
                 ],
-                "summary": "List schedule logs",
+                "summary": "Compact memories",
                 "parameters": [
                     {
                         "type": "string",
@@ -3715,24 +3840,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Before timestamp (RFC3339)",
-                        "name": "before",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "ratio (0,1] required; decay_days optional",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.memoryCompactPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schedule.ListLogsResponse"
+                            "$ref": "#/definitions/adapters.CompactResult"
                         }
                     },
                     "400": {
@@ -3741,20 +3862,37 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete all schedule execution logs for a bot",
+            }
+        },
+        "/bots/{bot_id}/memory/rebuild": {
+            "post": {
+                "description": "Read memory files from the container filesystem (source of truth) and restore missing entries to memory storage",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "schedule"
+                    "memory"
                 ],
-                "summary": "Delete schedule logs",
+                "summary": "Rebuild memories from filesystem",
                 "parameters": [
                     {
                         "type": "string",
@@ -3765,8 +3903,11 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/adapters.RebuildResult"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3774,36 +3915,69 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/bots/{bot_id}/schedule/{id}": {
-            "get": {
-                "description": "Get a schedule by ID",
+        "/bots/{bot_id}/memory/search": {
+            "post": {
+                "description": "Search memory in the bot-shared namespace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "schedule"
+                    "memory"
                 ],
-                "summary": "Get schedule",
+                "summary": "Search memory",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Schedule ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Memory search payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.memorySearchPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schedule.Schedule"
+                            "$ref": "#/definitions/adapters.SearchResponse"
                         }
                     },
                     "400": {
@@ -3812,6 +3986,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3823,38 +4003,40 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Update a schedule by ID",
+            }
+        },
+        "/bots/{bot_id}/memory/status": {
+            "get": {
+                "description": "Get the resolved memory runtime status for a bot, including index health and source counts",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "schedule"
+                    "memory"
                 ],
-                "summary": "Update schedule",
+                "summary": "Get memory runtime status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Schedule ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Schedule payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schedule.UpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schedule.Schedule"
+                            "$ref": "#/definitions/adapters.MemoryStatusResponse"
                         }
                     },
                     "400": {
@@ -3863,32 +4045,58 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a schedule by ID",
+            }
+        },
+        "/bots/{bot_id}/memory/usage": {
+            "get": {
+                "description": "Query the estimated storage usage of current memories",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "schedule"
+                    "memory"
                 ],
-                "summary": "Delete schedule",
+                "summary": "Get memory usage",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Schedule ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/adapters.UsageResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3896,22 +4104,37 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/bots/{bot_id}/schedule/{id}/logs": {
-            "get": {
-                "description": "List execution logs for a specific schedule",
+        "/bots/{bot_id}/memory/{id}": {
+            "delete": {
+                "description": "Delete a single memory by its ID",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "schedule"
+                    "memory"
                 ],
-                "summary": "List schedule logs by schedule",
+                "summary": "Delete a single memory",
                 "parameters": [
                     {
                         "type": "string",
@@ -3922,30 +4145,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Schedule ID",
+                        "description": "Memory ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Before timestamp (RFC3339)",
-                        "name": "before",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schedule.ListLogsResponse"
+                            "$ref": "#/definitions/adapters.DeleteResponse"
                         }
                     },
                     "400": {
@@ -3954,21 +4164,37 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/bots/{bot_id}/sessions": {
+        "/bots/{bot_id}/messages": {
             "get": {
+                "description": "List messages for a bot history with optional pagination",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "sessions"
+                    "messages"
                 ],
-                "summary": "List bot sessions",
+                "summary": "List bot history messages",
                 "parameters": [
                     {
                         "type": "string",
@@ -3976,6 +4202,18 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Before",
+                        "name": "before",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3986,7 +4224,7 @@ const docTemplate = `{
                             "additionalProperties": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/session.Session"
+                                    "$ref": "#/definitions/message.Message"
                                 }
                             }
                         }
@@ -4002,14 +4240,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             },
-            "post": {
+            "delete": {
+                "description": "Clear all persisted bot-level history messages",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "sessions"
+                    "messages"
                 ],
-                "summary": "Create a new chat session",
+                "summary": "Delete all bot history messages",
                 "parameters": [
                     {
                         "type": "string",
@@ -4017,14 +4265,75 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bots/{bot_id}/schedule": {
+            "get": {
+                "description": "List schedules for current user",
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "List schedules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schedule.ListResponse"
+                        }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a schedule for current user",
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Create schedule",
+                "parameters": [
                     {
-                        "description": "Session data",
-                        "name": "body",
+                        "description": "Schedule payload",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.createSessionRequest"
+                            "$ref": "#/definitions/schedule.CreateRequest"
                         }
                     }
                 ],
@@ -4032,7 +4341,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/session.Session"
+                            "$ref": "#/definitions/schedule.Schedule"
                         }
                     },
                     "400": {
@@ -4041,8 +4350,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -4050,12 +4359,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/sessions/{session_id}": {
+        "/bots/{bot_id}/schedule/logs": {
             "get": {
+                "description": "List schedule execution logs for a bot",
                 "tags": [
-                    "sessions"
+                    "schedule"
                 ],
-                "summary": "Get a session by ID",
+                "summary": "List schedule logs",
                 "parameters": [
                     {
                         "type": "string",
@@ -4066,17 +4376,23 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Session ID",
-                        "name": "session_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Before timestamp (RFC3339)",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/session.Session"
+                            "$ref": "#/definitions/schedule.ListLogsResponse"
                         }
                     },
                     "400": {
@@ -4085,14 +4401,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -4100,10 +4410,11 @@ const docTemplate = `{
                 }
             },
             "delete": {
+                "description": "Delete all schedule execution logs for a bot",
                 "tags": [
-                    "sessions"
+                    "schedule"
                 ],
-                "summary": "Soft-delete a session",
+                "summary": "Delete schedule logs",
                 "parameters": [
                     {
                         "type": "string",
@@ -4111,13 +4422,6 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Session ID",
-                        "name": "session_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -4130,49 +4434,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/bots/{bot_id}/schedule/{id}": {
+            "get": {
+                "description": "Get a schedule by ID",
                 "tags": [
-                    "sessions"
+                    "schedule"
                 ],
-                "summary": "Update a session",
+                "summary": "Get schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Session ID",
-                        "name": "session_id",
+                        "description": "Schedule ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Fields to update",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.updateSessionRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/session.Session"
+                            "$ref": "#/definitions/schedule.Schedule"
                         }
                     },
                     "400": {
@@ -4181,40 +4472,11 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/bots/{bot_id}/settings": {
-            "get": {
-                "description": "Get agent settings for current user",
-                "tags": [
-                    "settings"
-                ],
-                "summary": "Get user settings",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/settings.Settings"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -4225,19 +4487,26 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update or create agent settings for current user",
+                "description": "Update a schedule by ID",
                 "tags": [
-                    "settings"
+                    "schedule"
                 ],
-                "summary": "Update user settings",
+                "summary": "Update schedule",
                 "parameters": [
                     {
-                        "description": "Settings payload",
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/settings.UpsertRequest"
+                            "$ref": "#/definitions/schedule.UpdateRequest"
                         }
                     }
                 ],
@@ -4245,7 +4514,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/settings.Settings"
+                            "$ref": "#/definitions/schedule.Schedule"
                         }
                     },
                     "400": {
@@ -4262,50 +4531,21 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Update or create agent settings for current user",
+            "delete": {
+                "description": "Delete a schedule by ID",
                 "tags": [
-                    "settings"
+                    "schedule"
                 ],
-                "summary": "Update user settings",
+                "summary": "Delete schedule",
                 "parameters": [
                     {
-                        "description": "Settings payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/settings.UpsertRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/settings.Settings"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
-                }
-            },
-            "delete": {
-                "description": "Remove agent settings for current user",
-                "tags": [
-                    "settings"
                 ],
-                "summary": "Delete user settings",
                 "responses": {
                     "204": {
                         "description": "No Content"
@@ -4325,13 +4565,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/token-usage": {
+        "/bots/{bot_id}/schedule/{id}/logs": {
             "get": {
-                "description": "Get daily aggregated token usage for a bot, split by chat, heartbeat, and schedule session types, with optional model filter and per-model breakdown",
+                "description": "List execution logs for a specific schedule",
                 "tags": [
-                    "token-usage"
+                    "schedule"
                 ],
-                "summary": "Get token usage statistics",
+                "summary": "List schedule logs by schedule",
                 "parameters": [
                     {
                         "type": "string",
@@ -4342,22 +4582,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "from",
-                        "in": "query",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End date exclusive (YYYY-MM-DD)",
-                        "name": "to",
-                        "in": "query",
-                        "required": true
+                        "description": "Before timestamp (RFC3339)",
+                        "name": "before",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Optional model UUID to filter by",
-                        "name": "model_id",
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -4365,7 +4605,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.TokenUsageResponse"
+                            "$ref": "#/definitions/schedule.ListLogsResponse"
                         }
                     },
                     "400": {
@@ -4374,12 +4614,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4389,13 +4623,12 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/tools": {
-            "post": {
-                "description": "MCP endpoint for tool discovery and invocation.",
+        "/bots/{bot_id}/sessions": {
+            "get": {
                 "tags": [
-                    "containerd"
+                    "sessions"
                 ],
-                "summary": "Unified MCP tools gateway",
+                "summary": "List bot sessions",
                 "parameters": [
                     {
                         "type": "string",
@@ -4403,22 +4636,19 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "JSON-RPC request",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JSON-RPC response: {jsonrpc,id,result|error}",
+                        "description": "OK",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/session.Session"
+                                }
+                            }
                         }
                     },
                     "400": {
@@ -4427,34 +4657,19 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/tts/synthesize": {
+            },
             "post": {
-                "description": "Stream-synthesize text using the bot's configured TTS model, write to temp file",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "bots"
+                    "sessions"
                 ],
-                "summary": "Synthesize speech for a bot",
+                "summary": "Create a new chat session",
                 "parameters": [
                     {
                         "type": "string",
@@ -4464,20 +4679,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Text to synthesize",
-                        "name": "request",
+                        "description": "Session data",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.synthesizeRequest"
+                            "$ref": "#/definitions/handlers.createSessionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handlers.synthesizeResponse"
+                            "$ref": "#/definitions/session.Session"
                         }
                     },
                     "400": {
@@ -4486,8 +4701,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -4495,19 +4710,12 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/web/messages": {
-            "post": {
-                "description": "Post a user message (with optional attachments) through the local channel pipeline.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/bots/{bot_id}/sessions/{session_id}": {
+            "get": {
                 "tags": [
-                    "local-channel"
+                    "sessions"
                 ],
-                "summary": "Send a message to a local channel",
+                "summary": "Get a session by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -4517,23 +4725,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Message payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LocalChannelMessageRequest"
-                        }
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/session.Session"
                         }
                     },
                     "400": {
@@ -4548,25 +4751,19 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/web/stream": {
-            "get": {
-                "description": "Open a persistent SSE connection to receive real-time stream events for the given bot.",
-                "produces": [
-                    "text/event-stream"
-                ],
+            },
+            "delete": {
                 "tags": [
-                    "local-channel"
+                    "sessions"
                 ],
-                "summary": "Subscribe to local channel events via SSE",
+                "summary": "Soft-delete a session",
                 "parameters": [
                     {
                         "type": "string",
@@ -4574,14 +4771,18 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "SSE stream",
-                        "schema": {
-                            "type": "string"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4594,23 +4795,14 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/web/ws": {
-            "get": {
-                "description": "Upgrade to WebSocket for bidirectional chat streaming with abort support.",
+            },
+            "patch": {
                 "tags": [
-                    "local-channel"
+                    "sessions"
                 ],
-                "summary": "WebSocket chat endpoint",
+                "summary": "Update a session",
                 "parameters": [
                     {
                         "type": "string",
@@ -4618,13 +4810,29 @@ const docTemplate = `{
                         "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateSessionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/session.Session"
                         }
                     },
                     "400": {
@@ -4639,8 +4847,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -4648,27 +4856,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{bot_id}/whitelist": {
+        "/bots/{bot_id}/settings": {
             "get": {
-                "description": "List guest allow rules for chat trigger",
+                "description": "Get agent settings for current user",
                 "tags": [
-                    "bots"
-                ],
-                "summary": "List bot whitelist",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "settings"
                 ],
+                "summary": "Get user settings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/acl.ListRulesResponse"
+                            "$ref": "#/definitions/settings.Settings"
                         }
                     },
                     "400": {
@@ -4677,12 +4876,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4692,26 +4885,19 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Add a guest allow rule for chat trigger",
+                "description": "Update or create agent settings for current user",
                 "tags": [
-                    "bots"
+                    "settings"
                 ],
-                "summary": "Upsert bot whitelist entry",
+                "summary": "Update user settings",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Whitelist payload",
+                        "description": "Settings payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/acl.UpsertRuleRequest"
+                            "$ref": "#/definitions/settings.UpsertRequest"
                         }
                     }
                 ],
@@ -4719,7 +4905,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/acl.Rule"
+                            "$ref": "#/definitions/settings.Settings"
                         }
                     },
                     "400": {
@@ -4728,12 +4914,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4741,34 +4921,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/bots/{bot_id}/whitelist/{rule_id}": {
-            "delete": {
-                "description": "Delete a guest allow rule by rule ID",
+            },
+            "post": {
+                "description": "Update or create agent settings for current user",
                 "tags": [
-                    "bots"
+                    "settings"
                 ],
-                "summary": "Delete bot whitelist entry",
+                "summary": "Update user settings",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bot ID",
-                        "name": "bot_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Rule ID",
-                        "name": "rule_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Settings payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/settings.UpsertRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/settings.Settings"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4776,8 +4952,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove agent settings for current user",
+                "tags": [
+                    "settings"
+                ],
+                "summary": "Delete user settings",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -4791,18 +4985,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{id}": {
+        "/bots/{bot_id}/token-budgets": {
             "get": {
-                "description": "Get a bot by ID (owner/admin only)",
+                "description": "List token budget rules configured for a bot",
                 "tags": [
-                    "bots"
+                    "token-budgets"
                 ],
-                "summary": "Get bot details",
+                "summary": "List token budgets",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     }
@@ -4811,7 +5005,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/bots.Bot"
+                            "$ref": "#/definitions/budget.ListResponse"
                         }
                     },
                     "400": {
@@ -4826,12 +5020,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4840,35 +5028,35 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update bot profile (owner/admin only)",
+            "post": {
+                "description": "Create a daily or monthly token budget scoped to the bot, a user, or a channel identity. Omit the subject on user or channel identity scopes to cap each subject separately.",
                 "tags": [
-                    "bots"
+                    "token-budgets"
                 ],
-                "summary": "Update bot details",
+                "summary": "Create token budget",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Bot update payload",
+                        "description": "Budget payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/bots.UpdateBotRequest"
+                            "$ref": "#/definitions/budget.CreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/bots.Bot"
+                            "$ref": "#/definitions/budget.Rule"
                         }
                     },
                     "400": {
@@ -4883,12 +5071,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4896,30 +5078,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a bot user (owner/admin only)",
+            }
+        },
+        "/bots/{bot_id}/token-budgets/allowance": {
+            "get": {
+                "description": "Report usage and remaining allowance for every enabled budget that applies to the bot and the optional user or channel identity",
                 "tags": [
-                    "bots"
+                    "token-budgets"
                 ],
-                "summary": "Delete bot",
+                "summary": "Get remaining token allowance",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel identity ID",
+                        "name": "channel_identity_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/budget.AllowanceResponse"
                         }
                     },
                     "400": {
@@ -4934,12 +5127,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4949,34 +5136,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{id}/channel/{platform}": {
-            "get": {
-                "description": "Get bot channel configuration",
+        "/bots/{bot_id}/token-budgets/{id}": {
+            "put": {
+                "description": "Update the period, limits, reply message, or enabled state of a token budget",
                 "tags": [
-                    "bots"
+                    "token-budgets"
                 ],
-                "summary": "Get bot channel config",
+                "summary": "Update token budget",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
+                        "description": "Budget ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Budget payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/budget.UpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/channel.ChannelConfig"
+                            "$ref": "#/definitions/budget.Rule"
                         }
                     },
                     "400": {
@@ -5005,43 +5201,31 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update bot channel configuration",
+            "delete": {
+                "description": "Delete a token budget rule",
                 "tags": [
-                    "bots"
+                    "token-budgets"
                 ],
-                "summary": "Update bot channel config",
+                "summary": "Delete token budget",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
+                        "description": "Budget ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Channel config payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/channel.UpsertConfigRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/channel.ChannelConfig"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -5068,32 +5252,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove bot channel configuration",
+            }
+        },
+        "/bots/{bot_id}/token-usage": {
+            "get": {
+                "description": "Get daily aggregated token usage for a bot, split by chat, heartbeat, and schedule session types, with optional model filter, per-model and per-session breakdowns, and USD cost for models with pricing",
                 "tags": [
-                    "bots"
+                    "token-usage"
                 ],
-                "summary": "Delete bot channel config",
+                "summary": "Get token usage statistics",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
-                        "in": "path",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date exclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional model UUID to filter by",
+                        "name": "model_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenUsageResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -5116,46 +5318,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{id}/channel/{platform}/send": {
-            "post": {
-                "description": "Send a message using bot channel configuration",
+        "/bots/{bot_id}/tool-approvals": {
+            "get": {
+                "description": "List the approval audit log of a bot, newest first",
                 "tags": [
-                    "bots"
+                    "tool-approvals"
                 ],
-                "summary": "Send message via bot channel",
+                "summary": "List tool approvals",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by status (pending, approved, rejected, expired)",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "description": "Send payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/channel.SendRequest"
-                        }
+                        "type": "integer",
+                        "description": "Max results (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/approval.ListResponse"
                         }
                     },
                     "400": {
@@ -5170,12 +5365,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5185,35 +5374,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{id}/channel/{platform}/send_chat": {
-            "post": {
-                "description": "Send a message using a session-scoped token (reply only)",
+        "/bots/{bot_id}/tool-approvals/policy": {
+            "get": {
+                "description": "Get the tools that need owner approval before they run, and how long a call waits for a decision",
                 "tags": [
-                    "bots"
+                    "tool-approvals"
                 ],
-                "summary": "Send message via bot channel session token",
+                "summary": "Get tool approval policy",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/approval.Policy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the tool names or globs (e.g. \"exec\", \"github_*\") that need owner approval. Omit timeout_seconds to keep the current timeout.",
+                "tags": [
+                    "tool-approvals"
+                ],
+                "summary": "Update tool approval policy",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Send payload",
+                        "description": "Policy payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/channel.SendRequest"
+                            "$ref": "#/definitions/approval.UpdatePolicyRequest"
                         }
                     }
                 ],
@@ -5221,10 +5445,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/approval.Policy"
                         }
                     },
                     "400": {
@@ -5233,12 +5454,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -5254,35 +5469,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{id}/channel/{platform}/status": {
-            "patch": {
-                "description": "Update bot channel enabled/disabled status",
+        "/bots/{bot_id}/tool-approvals/{id}/decision": {
+            "post": {
+                "description": "Approve or reject a pending tool call. Only the bot owner can decide.",
                 "tags": [
-                    "bots"
+                    "tool-approvals"
                 ],
-                "summary": "Update bot channel status",
+                "summary": "Decide tool approval",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
+                        "description": "Approval ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Channel status payload",
+                        "description": "Decision payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/channel.UpdateChannelStatusRequest"
+                            "$ref": "#/definitions/approval.DecideRequest"
                         }
                     }
                 ],
@@ -5290,7 +5505,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/channel.ChannelConfig"
+                            "$ref": "#/definitions/approval.Approval"
                         }
                     },
                     "400": {
@@ -5311,6 +5526,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5320,18 +5541,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/bots/{id}/checks": {
+        "/bots/{bot_id}/tool_rules": {
             "get": {
-                "description": "Evaluate bot attached resource checks in runtime",
+                "description": "List allow and deny rules that control which tools the bot offers to each caller",
                 "tags": [
                     "bots"
                 ],
-                "summary": "List bot runtime checks",
+                "summary": "List bot tool rules",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     }
@@ -5340,7 +5561,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/bots.ListChecksResponse"
+                            "$ref": "#/definitions/acl.ListRulesResponse"
                         }
                     },
                     "400": {
@@ -5355,12 +5576,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5368,30 +5583,28 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/bots/{id}/owner": {
+            },
             "put": {
-                "description": "Transfer bot ownership to another human user",
+                "description": "Allow or deny tools matching a name or glob for a user, a channel identity, or everyone. The bot owner is never restricted.",
                 "tags": [
                     "bots"
                 ],
-                "summary": "Transfer bot owner (admin only)",
+                "summary": "Upsert bot tool rule",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bot ID",
-                        "name": "id",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Transfer payload",
+                        "description": "Tool rule payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/bots.TransferBotRequest"
+                            "$ref": "#/definitions/acl.UpsertToolRuleRequest"
                         }
                     }
                 ],
@@ -5399,7 +5612,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/bots.Bot"
+                            "$ref": "#/definitions/acl.Rule"
                         }
                     },
                     "400": {
@@ -5414,12 +5627,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5429,24 +5636,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/browser-contexts": {
-            "get": {
-                "description": "List all browser context configurations",
-                "produces": [
-                    "application/json"
-                ],
+        "/bots/{bot_id}/tool_rules/{rule_id}": {
+            "delete": {
+                "description": "Delete a tool rule of the bot by rule ID",
                 "tags": [
-                    "browser-contexts"
+                    "bots"
+                ],
+                "summary": "Delete bot tool rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List browser contexts",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/browsercontexts.BrowserContext"
-                            }
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
@@ -5456,35 +5688,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/bots/{bot_id}/tools": {
             "post": {
-                "description": "Create a browser context configuration",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "MCP endpoint for tool discovery and invocation.",
                 "tags": [
-                    "browser-contexts"
+                    "containerd"
                 ],
-                "summary": "Create a browser context",
+                "summary": "Unified MCP tools gateway",
                 "parameters": [
                     {
-                        "description": "Browser context configuration",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON-RPC request",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/browsercontexts.CreateRequest"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "JSON-RPC response: {jsonrpc,id,result|error}",
                         "schema": {
-                            "$ref": "#/definitions/browsercontexts.BrowserContext"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -5493,6 +5728,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5502,47 +5743,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/browser-contexts/cores": {
+        "/bots/{bot_id}/trigger-policies": {
             "get": {
-                "description": "Get the list of browser cores available in the Browser Gateway container",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "List the group trigger policies stored for a bot's routes. Routes without one reply only when mentioned.",
                 "tags": [
-                    "browser-contexts"
+                    "trigger-policies"
+                ],
+                "summary": "List trigger policies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get available browser cores",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.BrowserCoresResponse"
+                            "$ref": "#/definitions/trigger.ListResponse"
                         }
                     },
-                    "502": {
-                        "description": "Bad Gateway",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/browser-contexts/{id}": {
-            "get": {
-                "description": "Get browser context by ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "browser-contexts"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bots/{bot_id}/trigger-policies/{route_id}": {
+            "get": {
+                "description": "Get the group trigger policy of a route, or the default mention policy when none is set",
+                "tags": [
+                    "trigger-policies"
                 ],
-                "summary": "Get a browser context",
+                "summary": "Get trigger policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Browser Context ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Route ID",
+                        "name": "route_id",
                         "in": "path",
                         "required": true
                     }
@@ -5551,7 +5814,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/browsercontexts.BrowserContext"
+                            "$ref": "#/definitions/trigger.Policy"
                         }
                     },
                     "400": {
@@ -5560,41 +5823,54 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             },
             "put": {
-                "description": "Update browser context by ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Set how the bot decides to reply to group messages on a route it is not mentioned in: keyword or regex matches, or a classifier model in listen mode. Unsolicited replies are rate limited per route.",
                 "tags": [
-                    "browser-contexts"
+                    "trigger-policies"
                 ],
-                "summary": "Update a browser context",
+                "summary": "Set trigger policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Browser Context ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated configuration",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Route ID",
+                        "name": "route_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trigger policy",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/browsercontexts.UpdateRequest"
+                            "$ref": "#/definitions/trigger.UpsertRequest"
                         }
                     }
                 ],
@@ -5602,7 +5878,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/browsercontexts.BrowserContext"
+                            "$ref": "#/definitions/trigger.Policy"
                         }
                     },
                     "400": {
@@ -5611,6 +5887,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5620,16 +5908,23 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete browser context by ID",
+                "description": "Delete a route's trigger policy so the bot replies in the group only when mentioned",
                 "tags": [
-                    "browser-contexts"
+                    "trigger-policies"
                 ],
-                "summary": "Delete a browser context",
+                "summary": "Delete trigger policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Browser Context ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Route ID",
+                        "name": "route_id",
                         "in": "path",
                         "required": true
                     }
@@ -5644,30 +5939,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/channels": {
-            "get": {
-                "description": "List channel meta information including capabilities and schemas",
-                "tags": [
-                    "channel"
-                ],
-                "summary": "List channel capabilities and schemas",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.ChannelMeta"
-                            }
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
@@ -5679,27 +5960,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/channels/{platform}": {
-            "get": {
-                "description": "Get channel meta information including capabilities and schemas",
+        "/bots/{bot_id}/tts/synthesize": {
+            "post": {
+                "description": "Stream-synthesize text using the bot's configured TTS model, write to temp file",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "channel"
+                    "bots"
                 ],
-                "summary": "Get channel capabilities and schemas",
+                "summary": "Synthesize speech for a bot",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Text to synthesize",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.synthesizeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ChannelMeta"
+                            "$ref": "#/definitions/handlers.synthesizeResponse"
                         }
                     },
                     "400": {
@@ -5708,8 +6004,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -5717,33 +6013,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/email-providers": {
-            "get": {
+        "/bots/{bot_id}/web/messages": {
+            "post": {
+                "description": "Post a user message (with optional attachments) through the local channel pipeline.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "email-providers"
+                    "local-channel"
                 ],
-                "summary": "List email providers",
+                "summary": "Send a message to a local channel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider type filter",
-                        "name": "provider",
-                        "in": "query"
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocalChannelMessageRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/email.ProviderResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5751,34 +6073,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/bots/{bot_id}/web/stream": {
+            "get": {
+                "description": "Open a persistent SSE connection to receive real-time stream events for the given bot.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "email-providers"
+                    "local-channel"
                 ],
-                "summary": "Create an email provider",
+                "summary": "Subscribe to local channel events via SSE",
                 "parameters": [
                     {
-                        "description": "Email provider configuration",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/email.CreateProviderRequest"
-                        }
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "SSE stream",
                         "schema": {
-                            "$ref": "#/definitions/email.ProviderResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -5787,6 +6107,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5796,40 +6122,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/email-providers/meta": {
+        "/bots/{bot_id}/web/ws": {
             "get": {
-                "description": "List available email provider types and config schemas",
+                "description": "Upgrade to WebSocket for bidirectional chat streaming with abort support.",
                 "tags": [
-                    "email-providers"
+                    "local-channel"
+                ],
+                "summary": "WebSocket chat endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List email provider metadata",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/email.ProviderMeta"
-                            }
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/email-providers/{id}": {
+        "/bots/{bot_id}/whitelist": {
             "get": {
-                "produces": [
-                    "application/json"
-                ],
+                "description": "List guest allow rules for chat trigger",
                 "tags": [
-                    "email-providers"
+                    "bots"
                 ],
-                "summary": "Get an email provider",
+                "summary": "List bot whitelist",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     }
@@ -5838,11 +6186,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/email.ProviderResponse"
+                            "$ref": "#/definitions/acl.ListRulesResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -5850,31 +6210,26 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Add a guest allow rule for chat trigger",
                 "tags": [
-                    "email-providers"
+                    "bots"
                 ],
-                "summary": "Update an email provider",
+                "summary": "Upsert bot whitelist entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated configuration",
-                        "name": "request",
+                        "description": "Whitelist payload",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/email.UpdateProviderRequest"
+                            "$ref": "#/definitions/acl.UpsertRuleRequest"
                         }
                     }
                 ],
@@ -5882,7 +6237,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/email.ProviderResponse"
+                            "$ref": "#/definitions/acl.Rule"
                         }
                     },
                     "400": {
@@ -5891,6 +6246,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5898,17 +6259,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/bots/{bot_id}/whitelist/{rule_id}": {
             "delete": {
+                "description": "Delete a guest allow rule by rule ID",
                 "tags": [
-                    "email-providers"
+                    "bots"
                 ],
-                "summary": "Delete an email provider",
+                "summary": "Delete bot whitelist entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
+                        "description": "Bot ID",
+                        "name": "bot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "rule_id",
                         "in": "path",
                         "required": true
                     }
@@ -5917,6 +6288,18 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5926,17 +6309,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/email-providers/{id}/oauth/authorize": {
+        "/bots/{id}": {
             "get": {
-                "description": "Returns the authorization URL to redirect the user to",
+                "description": "Get a bot by ID (owner/admin only)",
                 "tags": [
-                    "email-oauth"
+                    "bots"
                 ],
-                "summary": "Start OAuth2 authorization for an email provider",
+                "summary": "Get bot details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email provider ID",
+                        "description": "Bot ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5946,10 +6329,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/bots.Bot"
                         }
                     },
                     "400": {
@@ -5958,35 +6338,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/email-providers/{id}/oauth/status": {
-            "get": {
+            },
+            "put": {
+                "description": "Update bot profile (owner/admin only)",
                 "tags": [
-                    "email-oauth"
+                    "bots"
                 ],
-                "summary": "Get OAuth2 status for an email provider",
+                "summary": "Update bot details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email provider ID",
+                        "description": "Bot ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Bot update payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bots.UpdateBotRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.emailOAuthStatusResponse"
+                            "$ref": "#/definitions/bots.Bot"
                         }
                     },
                     "400": {
@@ -5995,68 +6395,44 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/email-providers/{id}/oauth/token": {
-            "delete": {
-                "tags": [
-                    "email-oauth"
-                ],
-                "summary": "Revoke stored OAuth2 tokens for an email provider",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Email provider ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/email/mailgun/webhook/{config_id}": {
-            "post": {
-                "description": "Receives inbound emails from Mailgun",
+            },
+            "delete": {
+                "description": "Delete a bot user (owner/admin only)",
                 "tags": [
-                    "email-webhook"
+                    "bots"
                 ],
-                "summary": "Mailgun inbound email webhook",
+                "summary": "Delete bot",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email provider config ID",
-                        "name": "config_id",
+                        "description": "Bot ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -6076,6 +6452,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -6085,26 +6467,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/email/oauth/callback": {
+        "/bots/{id}/channel/{platform}": {
             "get": {
-                "description": "Handles the OAuth2 callback, exchanges the code for tokens",
+                "description": "Get bot channel configuration",
                 "tags": [
-                    "email-oauth"
+                    "bots"
                 ],
-                "summary": "OAuth2 callback for email providers",
+                "summary": "Get bot channel config",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization code",
-                        "name": "code",
-                        "in": "query",
+                        "description": "Bot ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "State parameter",
-                        "name": "state",
-                        "in": "query",
+                        "description": "Channel platform",
+                        "name": "platform",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -6112,10 +6494,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/channel.ChannelConfig"
                         }
                     },
                     "400": {
@@ -6124,33 +6503,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/memory-providers": {
-            "get": {
-                "description": "List configured memory providers",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "memory-providers"
-                ],
-                "summary": "List memory providers",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/adapters.ProviderGetResponse"
-                            }
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
@@ -6161,34 +6523,42 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create a memory provider configuration",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            "put": {
+                "description": "Update bot channel configuration",
                 "tags": [
-                    "memory-providers"
+                    "bots"
                 ],
-                "summary": "Create a memory provider",
+                "summary": "Update bot channel config",
                 "parameters": [
                     {
-                        "description": "Memory provider configuration",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Bot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel platform",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Channel config payload",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/adapters.ProviderCreateRequest"
+                            "$ref": "#/definitions/channel.UpsertConfigRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.ProviderGetResponse"
+                            "$ref": "#/definitions/channel.ChannelConfig"
                         }
                     },
                     "400": {
@@ -6197,60 +6567,51 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/memory-providers/meta": {
-            "get": {
-                "description": "List available memory provider types and config schemas",
-                "tags": [
-                    "memory-providers"
-                ],
-                "summary": "List memory provider metadata",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/adapters.ProviderMeta"
-                            }
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/memory-providers/{id}": {
-            "get": {
-                "description": "Get memory provider by ID",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Remove bot channel configuration",
                 "tags": [
-                    "memory-providers"
+                    "bots"
                 ],
-                "summary": "Get a memory provider",
+                "summary": "Delete bot channel config",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
+                        "description": "Bot ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel platform",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/adapters.ProviderGetResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -6258,41 +6619,50 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update memory provider by ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/bots/{id}/channel/{platform}/send": {
+            "post": {
+                "description": "Send a message using bot channel configuration",
                 "tags": [
-                    "memory-providers"
+                    "bots"
                 ],
-                "summary": "Update a memory provider",
+                "summary": "Send message via bot channel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
+                        "description": "Bot ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated configuration",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Channel platform",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Send payload",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/adapters.ProviderUpdateRequest"
+                            "$ref": "#/definitions/channel.SendRequest"
                         }
                     }
                 ],
@@ -6300,7 +6670,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.ProviderGetResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -6309,35 +6682,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete memory provider by ID",
-                "tags": [
-                    "memory-providers"
-                ],
-                "summary": "Delete a memory provider",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -6351,30 +6703,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/memory-providers/{id}/status": {
-            "get": {
-                "description": "Get runtime status data for a memory provider",
-                "produces": [
-                    "application/json"
-                ],
+        "/bots/{id}/channel/{platform}/send_chat": {
+            "post": {
+                "description": "Send a message using a session-scoped token (reply only)",
                 "tags": [
-                    "memory-providers"
+                    "bots"
                 ],
-                "summary": "Get memory provider status",
+                "summary": "Send message via bot channel session token",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
+                        "description": "Bot ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel platform",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Send payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/channel.SendRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/adapters.ProviderStatusResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -6383,8 +6751,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -6398,73 +6772,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/models": {
-            "get": {
-                "description": "Get a list of all configured models, optionally filtered by type or provider client type",
+        "/bots/{id}/channel/{platform}/status": {
+            "patch": {
+                "description": "Update bot channel enabled/disabled status",
                 "tags": [
-                    "models"
+                    "bots"
                 ],
-                "summary": "List all models",
+                "summary": "Update bot channel status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model type (chat, embedding)",
-                        "name": "type",
-                        "in": "query"
+                        "description": "Bot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Provider client type (openai-responses, openai-completions, anthropic-messages, google-generative-ai)",
-                        "name": "client_type",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.GetResponse"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
+                        "description": "Channel platform",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a new model configuration",
-                "tags": [
-                    "models"
-                ],
-                "summary": "Create a new model",
-                "parameters": [
                     {
-                        "description": "Model configuration",
+                        "description": "Channel status payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddRequest"
+                            "$ref": "#/definitions/channel.UpdateChannelStatusRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AddResponse"
+                            "$ref": "#/definitions/channel.ChannelConfig"
                         }
                     },
                     "400": {
@@ -6473,39 +6817,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/models/count": {
-            "get": {
-                "description": "Get the total count of models, optionally filtered by type",
-                "tags": [
-                    "models"
-                ],
-                "summary": "Get model count",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Model type (chat, embedding)",
-                        "name": "type",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.CountResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -6519,18 +6838,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/models/model/{modelId}": {
+        "/bots/{id}/checks": {
             "get": {
-                "description": "Get a model configuration by its model_id field (e.g., gpt-4)",
+                "description": "Evaluate bot attached resource checks in runtime",
                 "tags": [
-                    "models"
+                    "bots"
                 ],
-                "summary": "Get model by model ID",
+                "summary": "List bot runtime checks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model ID (e.g., gpt-4)",
-                        "name": "modelId",
+                        "description": "Bot ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -6539,7 +6858,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetResponse"
+                            "$ref": "#/definitions/bots.ListChecksResponse"
                         }
                     },
                     "400": {
@@ -6548,6 +6867,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -6561,28 +6886,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/bots/{id}/owner": {
             "put": {
-                "description": "Update a model configuration by its model_id field (e.g., gpt-4)",
+                "description": "Transfer bot ownership to another human user",
                 "tags": [
-                    "models"
+                    "bots"
                 ],
-                "summary": "Update model by model ID",
+                "summary": "Transfer bot owner (admin only)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model ID (e.g., gpt-4)",
-                        "name": "modelId",
+                        "description": "Bot ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated model configuration",
+                        "description": "Transfer payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateRequest"
+                            "$ref": "#/definitions/bots.TransferBotRequest"
                         }
                     }
                 ],
@@ -6590,7 +6917,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetResponse"
+                            "$ref": "#/definitions/bots.Bot"
                         }
                     },
                     "400": {
@@ -6599,6 +6926,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -6612,25 +6945,65 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/browser-contexts": {
+            "get": {
+                "description": "List all browser context configurations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "browser-contexts"
+                ],
+                "summary": "List browser contexts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/browsercontexts.BrowserContext"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
             },
-            "delete": {
-                "description": "Delete a model configuration by its model_id field (e.g., gpt-4)",
+            "post": {
+                "description": "Create a browser context configuration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "models"
+                    "browser-contexts"
                 ],
-                "summary": "Delete model by model ID",
+                "summary": "Create a browser context",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Model ID (e.g., gpt-4)",
-                        "name": "modelId",
-                        "in": "path",
-                        "required": true
+                        "description": "Browser context configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/browsercontexts.CreateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/browsercontexts.BrowserContext"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -6638,14 +7011,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/browser-contexts/cores": {
+            "get": {
+                "description": "Get the list of browser cores available in the Browser Gateway container",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "browser-contexts"
+                ],
+                "summary": "Get available browser cores",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BrowserCoresResponse"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "502": {
+                        "description": "Bad Gateway",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -6653,17 +7046,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/models/{id}": {
+        "/browser-contexts/{id}": {
             "get": {
-                "description": "Get a model configuration by its internal UUID",
+                "description": "Get browser context by ID",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "models"
+                    "browser-contexts"
                 ],
-                "summary": "Get model by internal ID",
+                "summary": "Get a browser context",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model internal ID (UUID)",
+                        "description": "Browser Context ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -6673,7 +7069,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetResponse"
+                            "$ref": "#/definitions/browsercontexts.BrowserContext"
                         }
                     },
                     "400": {
@@ -6687,36 +7083,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
             },
             "put": {
-                "description": "Update a model configuration by its internal UUID",
-                "tags": [
-                    "models"
+                "description": "Update browser context by ID",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "Update model by internal ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "browser-contexts"
+                ],
+                "summary": "Update a browser context",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model internal ID (UUID)",
+                        "description": "Browser Context ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated model configuration",
-                        "name": "payload",
+                        "description": "Updated configuration",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateRequest"
+                            "$ref": "#/definitions/browsercontexts.UpdateRequest"
                         }
                     }
                 ],
@@ -6724,7 +7120,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetResponse"
+                            "$ref": "#/definitions/browsercontexts.BrowserContext"
                         }
                     },
                     "400": {
@@ -6733,12 +7129,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -6748,15 +7138,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a model configuration by its internal UUID",
+                "description": "Delete browser context by ID",
                 "tags": [
-                    "models"
+                    "browser-contexts"
                 ],
-                "summary": "Delete model by internal ID",
+                "summary": "Delete a browser context",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model internal ID (UUID)",
+                        "description": "Browser Context ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -6772,11 +7162,31 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/channels": {
+            "get": {
+                "description": "List channel meta information including capabilities and schemas",
+                "tags": [
+                    "channel"
+                ],
+                "summary": "List channel capabilities and schemas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ChannelMeta"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -6787,24 +7197,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/models/{id}/test": {
-            "post": {
-                "description": "Probe a model's provider endpoint using the model's real model_id and client_type to verify configuration",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/channels/{platform}": {
+            "get": {
+                "description": "Get channel meta information including capabilities and schemas",
                 "tags": [
-                    "models"
+                    "channel"
                 ],
-                "summary": "Test model connectivity",
+                "summary": "Get channel capabilities and schemas",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model internal ID (UUID)",
-                        "name": "id",
+                        "description": "Channel platform",
+                        "name": "platform",
                         "in": "path",
                         "required": true
                     }
@@ -6813,7 +7217,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TestResponse"
+                            "$ref": "#/definitions/handlers.ChannelMeta"
                         }
                     },
                     "400": {
@@ -6827,52 +7231,34 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/ping": {
-            "get": {
-                "tags": [
-                    "system"
-                ],
-                "summary": "Health check with server capabilities",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.PingResponse"
-                        }
                     }
                 }
             }
         },
-        "/providers": {
+        "/email-providers": {
             "get": {
-                "description": "Get a list of all configured LLM providers",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "providers"
+                    "email-providers"
+                ],
+                "summary": "List email providers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider type filter",
+                        "name": "provider",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all LLM providers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/providers.GetResponse"
+                                "$ref": "#/definitions/email.ProviderResponse"
                             }
                         }
                     },
@@ -6885,7 +7271,6 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new LLM provider configuration",
                 "consumes": [
                     "application/json"
                 ],
@@ -6893,17 +7278,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "providers"
+                    "email-providers"
                 ],
-                "summary": "Create a new LLM provider",
+                "summary": "Create an email provider",
                 "parameters": [
                     {
-                        "description": "Provider configuration",
+                        "description": "Email provider configuration",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/providers.CreateRequest"
+                            "$ref": "#/definitions/email.CreateProviderRequest"
                         }
                     }
                 ],
@@ -6911,7 +7296,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/providers.GetResponse"
+                            "$ref": "#/definitions/email.ProviderResponse"
                         }
                     },
                     "400": {
@@ -6929,53 +7314,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/providers/count": {
+        "/email-providers/meta": {
             "get": {
-                "description": "Get the total count of providers",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "List available email provider types and config schemas",
                 "tags": [
-                    "providers"
+                    "email-providers"
                 ],
-                "summary": "Count providers",
+                "summary": "List email provider metadata",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/providers.CountResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/email.ProviderMeta"
+                            }
                         }
                     }
                 }
             }
         },
-        "/providers/name/{name}": {
+        "/email-providers/{id}": {
             "get": {
-                "description": "Get a provider configuration by its name",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "providers"
+                    "email-providers"
                 ],
-                "summary": "Get provider by name",
+                "summary": "Get an email provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider name",
-                        "name": "name",
+                        "description": "Provider ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -6984,13 +7356,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/providers.GetResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/email.ProviderResponse"
                         }
                     },
                     "404": {
@@ -6998,19 +7364,10 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/providers/{id}": {
-            "get": {
-                "description": "Get a provider configuration by its ID",
+            },
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -7018,23 +7375,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "providers"
+                    "email-providers"
                 ],
-                "summary": "Get provider by ID",
+                "summary": "Update an email provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID (UUID)",
+                        "description": "Provider ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/email.UpdateProviderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/providers.GetResponse"
+                            "$ref": "#/definitions/email.ProviderResponse"
                         }
                     },
                     "400": {
@@ -7043,12 +7409,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7057,87 +7417,58 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing provider configuration",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            "delete": {
                 "tags": [
-                    "providers"
+                    "email-providers"
                 ],
-                "summary": "Update provider",
+                "summary": "Delete an email provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID (UUID)",
+                        "description": "Provider ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated provider configuration",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/providers.UpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/providers.GetResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a provider configuration",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/email-providers/{id}/oauth/authorize": {
+            "get": {
+                "description": "Returns the authorization URL to redirect the user to",
                 "tags": [
-                    "providers"
+                    "email-oauth"
                 ],
-                "summary": "Delete provider",
+                "summary": "Start OAuth2 authorization for an email provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID (UUID)",
+                        "description": "Email provider ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -7150,33 +7481,20 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/providers/{id}/import-models": {
-            "post": {
-                "description": "Fetch models from provider's /v1/models endpoint and import them",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/email-providers/{id}/oauth/status": {
+            "get": {
                 "tags": [
-                    "providers"
+                    "email-oauth"
                 ],
-                "summary": "Import models from provider",
+                "summary": "Get OAuth2 status for an email provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID (UUID)",
+                        "description": "Email provider ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -7186,7 +7504,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/providers.ImportModelsResponse"
+                            "$ref": "#/definitions/handlers.emailOAuthStatusResponse"
                         }
                     },
                     "400": {
@@ -7200,45 +7518,67 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/providers/{id}/models": {
-            "get": {
-                "description": "Get models for a provider by id, optionally filtered by type",
+        "/email-providers/{id}/oauth/token": {
+            "delete": {
                 "tags": [
-                    "providers"
+                    "email-oauth"
                 ],
-                "summary": "List provider models",
+                "summary": "Revoke stored OAuth2 tokens for an email provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID (UUID)",
+                        "description": "Email provider ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/email/mailgun/webhook/{config_id}": {
+            "post": {
+                "description": "Receives inbound emails from Mailgun",
+                "tags": [
+                    "email-webhook"
+                ],
+                "summary": "Mailgun inbound email webhook",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Model type (chat, embedding)",
-                        "name": "type",
-                        "in": "query"
+                        "description": "Email provider config ID",
+                        "name": "config_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.GetResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -7248,8 +7588,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -7263,25 +7603,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/providers/{id}/test": {
-            "post": {
-                "description": "Probe a provider's base URL to check reachability, supported client types, and embedding support",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/email/oauth/callback": {
+            "get": {
+                "description": "Handles the OAuth2 callback, exchanges the code for tokens",
                 "tags": [
-                    "providers"
+                    "email-oauth"
                 ],
-                "summary": "Test provider connectivity",
+                "summary": "OAuth2 callback for email providers",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID (UUID)",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State parameter",
+                        "name": "state",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -7289,7 +7630,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/providers.TestResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -7298,12 +7642,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7313,34 +7651,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/search-providers": {
+        "/memory-providers": {
             "get": {
-                "description": "List configured search providers",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List configured memory providers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "search-providers"
-                ],
-                "summary": "List search providers",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Provider filter (brave)",
-                        "name": "provider",
-                        "in": "query"
-                    }
+                    "memory-providers"
                 ],
+                "summary": "List memory providers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/searchproviders.GetResponse"
+                                "$ref": "#/definitions/adapters.ProviderGetResponse"
                             }
                         }
                     },
@@ -7353,7 +7680,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a search provider configuration",
+                "description": "Create a memory provider configuration",
                 "consumes": [
                     "application/json"
                 ],
@@ -7361,17 +7688,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "search-providers"
+                    "memory-providers"
                 ],
-                "summary": "Create a search provider",
+                "summary": "Create a memory provider",
                 "parameters": [
                     {
-                        "description": "Search provider configuration",
+                        "description": "Memory provider configuration",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/searchproviders.CreateRequest"
+                            "$ref": "#/definitions/adapters.ProviderCreateRequest"
                         }
                     }
                 ],
@@ -7379,7 +7706,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/searchproviders.GetResponse"
+                            "$ref": "#/definitions/adapters.ProviderGetResponse"
                         }
                     },
                     "400": {
@@ -7397,39 +7724,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/search-providers/meta": {
+        "/memory-providers/meta": {
             "get": {
-                "description": "List available search provider types and config schemas",
+                "description": "List available memory provider types and config schemas",
                 "tags": [
-                    "search-providers"
+                    "memory-providers"
                 ],
-                "summary": "List search provider metadata",
+                "summary": "List memory provider metadata",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/searchproviders.ProviderMeta"
+                                "$ref": "#/definitions/adapters.ProviderMeta"
                             }
                         }
                     }
                 }
             }
         },
-        "/search-providers/{id}": {
+        "/memory-providers/{id}": {
             "get": {
-                "description": "Get search provider by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get memory provider by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "search-providers"
+                    "memory-providers"
                 ],
-                "summary": "Get a search provider",
+                "summary": "Get a memory provider",
                 "parameters": [
                     {
                         "type": "string",
@@ -7443,7 +7767,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/searchproviders.GetResponse"
+                            "$ref": "#/definitions/adapters.ProviderGetResponse"
                         }
                     },
                     "400": {
@@ -7461,7 +7785,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update search provider by ID",
+                "description": "Update memory provider by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -7469,9 +7793,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "search-providers"
+                    "memory-providers"
                 ],
-                "summary": "Update a search provider",
+                "summary": "Update a memory provider",
                 "parameters": [
                     {
                         "type": "string",
@@ -7486,7 +7810,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/searchproviders.UpdateRequest"
+                            "$ref": "#/definitions/adapters.ProviderUpdateRequest"
                         }
                     }
                 ],
@@ -7494,7 +7818,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/searchproviders.GetResponse"
+                            "$ref": "#/definitions/adapters.ProviderGetResponse"
                         }
                     },
                     "400": {
@@ -7512,17 +7836,11 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete search provider by ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Delete memory provider by ID",
                 "tags": [
-                    "search-providers"
+                    "memory-providers"
                 ],
-                "summary": "Delete a search provider",
+                "summary": "Delete a memory provider",
                 "parameters": [
                     {
                         "type": "string",
@@ -7551,25 +7869,90 @@ const docTemplate = `{
                 }
             }
         },
-        "/tts-models": {
+        "/memory-providers/{id}/status": {
             "get": {
+                "description": "Get runtime status data for a memory provider",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tts-models"
+                    "memory-providers"
                 ],
-                "summary": "List all TTS models",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/tts.ModelResponse"
+                "summary": "Get memory provider status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/adapters.ProviderStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/models": {
+            "get": {
+                "description": "Get a list of all configured models, optionally filtered by type or provider client type",
+                "tags": [
+                    "models"
+                ],
+                "summary": "List all models",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model type (chat, embedding)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider client type (openai-responses, openai-completions, anthropic-messages, google-generative-ai)",
+                        "name": "client_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GetResponse"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7579,25 +7962,19 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Manually create a TTS model under a specific provider",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Create a new model configuration",
                 "tags": [
-                    "tts-models"
+                    "models"
                 ],
-                "summary": "Create a TTS model",
+                "summary": "Create a new model",
                 "parameters": [
                     {
-                        "description": "TTS model configuration",
-                        "name": "request",
+                        "description": "Model configuration",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/tts.CreateModelRequest"
+                            "$ref": "#/definitions/models.AddRequest"
                         }
                     }
                 ],
@@ -7605,7 +7982,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/tts.ModelResponse"
+                            "$ref": "#/definitions/models.AddResponse"
                         }
                     },
                     "400": {
@@ -7623,20 +8000,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/tts-models/{id}": {
+        "/models/count": {
             "get": {
-                "produces": [
-                    "application/json"
+                "description": "Get the total count of models, optionally filtered by type",
+                "tags": [
+                    "models"
+                ],
+                "summary": "Get model count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model type (chat, embedding)",
+                        "name": "type",
+                        "in": "query"
+                    }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/model/{modelId}": {
+            "get": {
+                "description": "Get a model configuration by its model_id field (e.g., gpt-4)",
                 "tags": [
-                    "tts-models"
+                    "models"
                 ],
-                "summary": "Get a TTS model",
+                "summary": "Get model by model ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model ID",
-                        "name": "id",
+                        "description": "Model ID (e.g., gpt-4)",
+                        "name": "modelId",
                         "in": "path",
                         "required": true
                     }
@@ -7645,7 +8057,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tts.ModelResponse"
+                            "$ref": "#/definitions/models.GetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
@@ -7653,35 +8071,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             },
             "put": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Update a model configuration by its model_id field (e.g., gpt-4)",
                 "tags": [
-                    "tts-models"
+                    "models"
                 ],
-                "summary": "Update a TTS model",
+                "summary": "Update model by model ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model ID",
-                        "name": "id",
+                        "description": "Model ID (e.g., gpt-4)",
+                        "name": "modelId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated configuration",
-                        "name": "request",
+                        "description": "Updated model configuration",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/tts.UpdateModelRequest"
+                            "$ref": "#/definitions/models.UpdateRequest"
                         }
                     }
                 ],
@@ -7689,7 +8108,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tts.ModelResponse"
+                            "$ref": "#/definitions/models.GetResponse"
                         }
                     },
                     "400": {
@@ -7698,6 +8117,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7707,15 +8132,16 @@ const docTemplate = `{
                 }
             },
             "delete": {
+                "description": "Delete a model configuration by its model_id field (e.g., gpt-4)",
                 "tags": [
-                    "tts-models"
+                    "models"
                 ],
-                "summary": "Delete a TTS model",
+                "summary": "Delete model by model ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model ID",
-                        "name": "id",
+                        "description": "Model ID (e.g., gpt-4)",
+                        "name": "modelId",
                         "in": "path",
                         "required": true
                     }
@@ -7724,6 +8150,18 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7733,19 +8171,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/tts-models/{id}/capabilities": {
+        "/models/{id}": {
             "get": {
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Get a model configuration by its internal UUID",
                 "tags": [
-                    "tts-models"
+                    "models"
                 ],
-                "summary": "Get TTS model capabilities",
+                "summary": "Get model by internal ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model ID",
+                        "description": "Model internal ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -7755,7 +8191,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tts.ModelCapabilities"
+                            "$ref": "#/definitions/models.GetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
@@ -7763,46 +8205,44 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/tts-models/{id}/test": {
-            "post": {
-                "description": "Synthesize text using a specific model's config and return audio",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/octet-stream"
-                ],
+            },
+            "put": {
+                "description": "Update a model configuration by its internal UUID",
                 "tags": [
-                    "tts-models"
+                    "models"
                 ],
-                "summary": "Test TTS model synthesis",
+                "summary": "Update model by internal ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model ID",
+                        "description": "Model internal ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Text to synthesize",
-                        "name": "request",
+                        "description": "Updated model configuration",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/tts.TestSynthesizeRequest"
+                            "$ref": "#/definitions/models.UpdateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Audio data",
+                        "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/models.GetResponse"
                         }
                     },
                     "400": {
@@ -7811,6 +8251,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7818,33 +8264,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tts-providers": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Delete a model configuration by its internal UUID",
                 "tags": [
-                    "tts-providers"
+                    "models"
                 ],
-                "summary": "List TTS providers",
+                "summary": "Delete model by internal ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider type filter",
-                        "name": "provider",
-                        "in": "query"
+                        "description": "Model internal ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/tts.ProviderResponse"
-                            }
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
@@ -7854,9 +8303,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/models/{id}/test": {
             "post": {
-                "description": "Create a TTS provider and auto-import its available models",
+                "description": "Probe a model's provider endpoint using the model's real model_id and client_type to verify configuration",
                 "consumes": [
                     "application/json"
                 ],
@@ -7864,25 +8315,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tts-providers"
+                    "models"
                 ],
-                "summary": "Create a TTS provider",
+                "summary": "Test model connectivity",
                 "parameters": [
                     {
-                        "description": "TTS provider configuration",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/tts.CreateProviderRequest"
-                        }
+                        "type": "string",
+                        "description": "Model internal ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tts.ProviderResponse"
+                            "$ref": "#/definitions/models.TestResponse"
                         }
                     },
                     "400": {
@@ -7891,6 +8340,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7900,60 +8355,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/tts-providers/meta": {
+        "/ping": {
             "get": {
-                "description": "List available TTS provider types with their models and capabilities",
                 "tags": [
-                    "tts-providers"
+                    "system"
                 ],
-                "summary": "List TTS provider metadata",
+                "summary": "Health check with server capabilities",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/tts.ProviderMetaResponse"
-                            }
+                            "$ref": "#/definitions/handlers.PingResponse"
                         }
                     }
                 }
             }
         },
-        "/tts-providers/{id}": {
+        "/providers": {
             "get": {
+                "description": "Get a list of all configured LLM providers",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tts-providers"
-                ],
-                "summary": "Get a TTS provider",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "providers"
                 ],
+                "summary": "List all LLM providers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tts.ProviderResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/providers.GetResponse"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
+                "description": "Create a new LLM provider configuration",
                 "consumes": [
                     "application/json"
                 ],
@@ -7961,32 +8411,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tts-providers"
+                    "providers"
                 ],
-                "summary": "Update a TTS provider",
+                "summary": "Create a new LLM provider",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated configuration",
+                        "description": "Provider configuration",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/tts.UpdateProviderRequest"
+                            "$ref": "#/definitions/providers.CreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/tts.ProviderResponse"
+                            "$ref": "#/definitions/providers.GetResponse"
                         }
                     },
                     "400": {
@@ -8002,24 +8445,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "tags": [
-                    "tts-providers"
+            }
+        },
+        "/providers/count": {
+            "get": {
+                "description": "Get the total count of providers",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "Delete a TTS provider",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "providers"
                 ],
+                "summary": "Count providers",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/providers.CountResponse"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -8030,21 +8476,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/tts-providers/{id}/import-models": {
-            "post": {
-                "description": "Discover and import available models from the TTS adapter",
+        "/providers/name/{name}": {
+            "get": {
+                "description": "Get a provider configuration by its name",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tts-providers"
+                    "providers"
                 ],
-                "summary": "Import models for a TTS provider",
+                "summary": "Get provider by name",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
+                        "description": "Provider name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -8053,10 +8502,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/tts.ModelResponse"
-                            }
+                            "$ref": "#/definitions/providers.GetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
@@ -8068,19 +8526,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/tts-providers/{id}/models": {
+        "/providers/{id}": {
             "get": {
+                "description": "Get a provider configuration by its ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tts-providers"
+                    "providers"
                 ],
-                "summary": "List models for a TTS provider",
+                "summary": "Get provider by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider ID",
+                        "description": "Provider ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -8090,10 +8552,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/tts.ModelResponse"
-                            }
+                            "$ref": "#/definitions/providers.GetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
@@ -8103,20 +8574,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users": {
-            "get": {
-                "description": "List users",
-                "tags": [
-                    "users"
-                ],
-                "summary": "List users (admin only)",
-                "responses": {
-                    "200": {
+            },
+            "put": {
+                "description": "Update an existing provider configuration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "providers"
+                ],
+                "summary": "Update provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated provider configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/providers.UpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/accounts.ListAccountsResponse"
+                            "$ref": "#/definitions/providers.GetResponse"
                         }
                     },
                     "400": {
@@ -8125,8 +8618,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -8139,29 +8632,30 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create a new human user account",
+            "delete": {
+                "description": "Delete a provider configuration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "providers"
                 ],
-                "summary": "Create human user (admin only)",
+                "summary": "Delete provider",
                 "parameters": [
                     {
-                        "description": "User payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/accounts.CreateAccountRequest"
-                        }
+                        "type": "string",
+                        "description": "Provider ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/accounts.Account"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -8169,8 +8663,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -8184,18 +8678,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
-            "get": {
-                "description": "Get current user profile",
+        "/providers/{id}/import-models": {
+            "post": {
+                "description": "Fetch models from provider's /v1/models endpoint and import them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "providers"
+                ],
+                "summary": "Import models from provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get current user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/accounts.Account"
+                            "$ref": "#/definitions/providers.ImportModelsResponse"
                         }
                     },
                     "400": {
@@ -8204,6 +8713,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -8211,29 +8726,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update current user display name or avatar",
+            }
+        },
+        "/providers/{id}/models": {
+            "get": {
+                "description": "Get models for a provider by id, optionally filtered by type",
                 "tags": [
-                    "users"
+                    "providers"
                 ],
-                "summary": "Update current user profile",
+                "summary": "List provider models",
                 "parameters": [
                     {
-                        "description": "Profile payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/accounts.UpdateProfileRequest"
-                        }
+                        "type": "string",
+                        "description": "Provider ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model type (chat, embedding)",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/accounts.Account"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GetResponse"
+                            }
                         }
                     },
                     "400": {
@@ -8242,6 +8766,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -8251,18 +8781,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/channels/{platform}": {
-            "get": {
-                "description": "Get channel binding configuration for current user",
+        "/providers/{id}/test": {
+            "post": {
+                "description": "Probe a provider's base URL to check reachability, supported client types, and embedding support",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "channel"
+                    "providers"
                 ],
-                "summary": "Get channel user config",
+                "summary": "Test provider connectivity",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
+                        "description": "Provider ID (UUID)",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -8271,7 +8807,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/channel.ChannelIdentityBinding"
+                            "$ref": "#/definitions/providers.TestResponse"
                         }
                     },
                     "400": {
@@ -8293,36 +8829,75 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update channel binding configuration for current user",
+            }
+        },
+        "/search-providers": {
+            "get": {
+                "description": "List configured search providers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "channel"
+                    "search-providers"
                 ],
-                "summary": "Update channel user config",
+                "summary": "List search providers",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel platform",
-                        "name": "platform",
-                        "in": "path",
-                        "required": true
+                        "description": "Provider filter (brave)",
+                        "name": "provider",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/searchproviders.GetResponse"
+                            }
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a search provider configuration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search-providers"
+                ],
+                "summary": "Create a search provider",
+                "parameters": [
                     {
-                        "description": "Channel user config payload",
-                        "name": "payload",
+                        "description": "Search provider configuration",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/channel.UpsertChannelIdentityConfigRequest"
+                            "$ref": "#/definitions/searchproviders.CreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/channel.ChannelIdentityBinding"
+                            "$ref": "#/definitions/searchproviders.GetResponse"
                         }
                     },
                     "400": {
@@ -8340,62 +8915,105 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/identities": {
+        "/search-providers/meta": {
             "get": {
-                "description": "List all channel identities linked to current user",
+                "description": "List available search provider types and config schemas",
                 "tags": [
-                    "users"
+                    "search-providers"
                 ],
-                "summary": "List current user's channel identities",
+                "summary": "List search provider metadata",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.listMyIdentitiesResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/searchproviders.ProviderMeta"
+                            }
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    }
+                }
+            }
+        },
+        "/search-providers/{id}": {
+            "get": {
+                "description": "Get search provider by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search-providers"
+                ],
+                "summary": "Get a search provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/searchproviders.GetResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/users/me/password": {
+            },
             "put": {
-                "description": "Update current user password with current password check",
+                "description": "Update search provider by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "search-providers"
                 ],
-                "summary": "Update current user password",
+                "summary": "Update a search provider",
                 "parameters": [
                     {
-                        "description": "Password payload",
-                        "name": "payload",
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated configuration",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/accounts.UpdatePasswordRequest"
+                            "$ref": "#/definitions/searchproviders.UpdateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searchproviders.GetResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -8410,30 +9028,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
-                "description": "Get user details (self or admin only)",
+            },
+            "delete": {
+                "description": "Delete search provider by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "search-providers"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Delete a search provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Provider ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/accounts.Account"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -8441,16 +9060,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    }
+                }
+            }
+        },
+        "/stt-models": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-models"
+                ],
+                "summary": "List all STT models",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stt.ModelResponse"
+                            }
                         }
                     },
                     "500": {
@@ -8461,35 +9096,34 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update user profile and status",
+            "post": {
+                "description": "Manually create an STT model under a specific provider",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "stt-models"
                 ],
-                "summary": "Update user (admin only)",
+                "summary": "Create an STT model",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User update payload",
-                        "name": "payload",
+                        "description": "STT model configuration",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/accounts.UpdateAccountRequest"
+                            "$ref": "#/definitions/stt.CreateModelRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/accounts.Account"
+                            "$ref": "#/definitions/stt.ModelResponse"
                         }
                     },
                     "400": {
@@ -8498,73 +9132,115 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    }
+                }
+            }
+        },
+        "/stt-models/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-models"
+                ],
+                "summary": "Get an STT model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/stt.ModelResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}/password": {
+            },
             "put": {
-                "description": "Reset a user password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "stt-models"
                 ],
-                "summary": "Reset user password (admin only)",
+                "summary": "Update an STT model",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Model ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Password payload",
-                        "name": "payload",
+                        "description": "Updated configuration",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/accounts.ResetPasswordRequest"
+                            "$ref": "#/definitions/stt.UpdateModelRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/stt.ModelResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "stt-models"
+                ],
+                "summary": "Delete an STT model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -8574,20 +9250,1701 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "accounts.Account": {
-            "type": "object",
-            "properties": {
-                "avatar_url": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "display_name": {
-                    "type": "string"
+        },
+        "/stt-models/{id}/capabilities": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-models"
+                ],
+                "summary": "Get STT model capabilities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stt.ModelCapabilities"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stt-models/{id}/test": {
+            "post": {
+                "description": "Transcribe an uploaded audio file using a specific model's config",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-models"
+                ],
+                "summary": "Test STT model transcription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Audio file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language hint (ISO-639-1)",
+                        "name": "language",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stt.TranscriptionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stt-providers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-providers"
+                ],
+                "summary": "List STT providers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider type filter",
+                        "name": "provider",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stt.ProviderResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an STT provider and auto-import its available models",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-providers"
+                ],
+                "summary": "Create an STT provider",
+                "parameters": [
+                    {
+                        "description": "STT provider configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stt.CreateProviderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stt.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stt-providers/meta": {
+            "get": {
+                "description": "List available STT provider types with their models and capabilities",
+                "tags": [
+                    "stt-providers"
+                ],
+                "summary": "List STT provider metadata",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stt.ProviderMetaResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/stt-providers/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-providers"
+                ],
+                "summary": "Get an STT provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stt.ProviderResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-providers"
+                ],
+                "summary": "Update an STT provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stt.UpdateProviderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stt.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "stt-providers"
+                ],
+                "summary": "Delete an STT provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stt-providers/{id}/import-models": {
+            "post": {
+                "description": "Discover and import available models from the STT adapter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-providers"
+                ],
+                "summary": "Import models for an STT provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stt.ModelResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stt-providers/{id}/models": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stt-providers"
+                ],
+                "summary": "List models for an STT provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stt.ModelResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/triggers/webhook/{id}": {
+            "post": {
+                "description": "Fire a webhook trigger. Authenticate with the trigger's secret in the X-Trigger-Secret header or the secret query parameter. The request body (JSON or text, up to 64 KiB) is passed to the bot. The run happens in the background.",
+                "tags": [
+                    "event-triggers"
+                ],
+                "summary": "Call webhook trigger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook secret",
+                        "name": "secret",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-models": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-models"
+                ],
+                "summary": "List all TTS models",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tts.ModelResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Manually create a TTS model under a specific provider",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-models"
+                ],
+                "summary": "Create a TTS model",
+                "parameters": [
+                    {
+                        "description": "TTS model configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tts.CreateModelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/tts.ModelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-models/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-models"
+                ],
+                "summary": "Get a TTS model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tts.ModelResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-models"
+                ],
+                "summary": "Update a TTS model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tts.UpdateModelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tts.ModelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "tts-models"
+                ],
+                "summary": "Delete a TTS model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-models/{id}/capabilities": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-models"
+                ],
+                "summary": "Get TTS model capabilities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tts.ModelCapabilities"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-models/{id}/test": {
+            "post": {
+                "description": "Synthesize text using a specific model's config and return audio",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "tts-models"
+                ],
+                "summary": "Test TTS model synthesis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Text to synthesize",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tts.TestSynthesizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audio data",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-providers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-providers"
+                ],
+                "summary": "List TTS providers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider type filter",
+                        "name": "provider",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tts.ProviderResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a TTS provider and auto-import its available models",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-providers"
+                ],
+                "summary": "Create a TTS provider",
+                "parameters": [
+                    {
+                        "description": "TTS provider configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tts.CreateProviderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/tts.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-providers/meta": {
+            "get": {
+                "description": "List available TTS provider types with their models and capabilities",
+                "tags": [
+                    "tts-providers"
+                ],
+                "summary": "List TTS provider metadata",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tts.ProviderMetaResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-providers/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-providers"
+                ],
+                "summary": "Get a TTS provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tts.ProviderResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-providers"
+                ],
+                "summary": "Update a TTS provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tts.UpdateProviderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tts.ProviderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "tts-providers"
+                ],
+                "summary": "Delete a TTS provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-providers/{id}/import-models": {
+            "post": {
+                "description": "Discover and import available models from the TTS adapter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-providers"
+                ],
+                "summary": "Import models for a TTS provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tts.ModelResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tts-providers/{id}/models": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tts-providers"
+                ],
+                "summary": "List models for a TTS provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tts.ModelResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "List users",
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users (admin only)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/accounts.ListAccountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new human user account",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create human user (admin only)",
+                "parameters": [
+                    {
+                        "description": "User payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/accounts.CreateAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/accounts.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "description": "Get current user profile",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/accounts.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update current user display name or avatar",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update current user profile",
+                "parameters": [
+                    {
+                        "description": "Profile payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/accounts.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/accounts.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/api-keys": {
+            "get": {
+                "description": "List the current user's personal access tokens, including revoked and expired ones. Tokens are never returned after creation.",
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apikeys.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a long-lived personal access token with the given scopes. The token is returned only in this response; send it as a Bearer token.",
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Create API key",
+                "parameters": [
+                    {
+                        "description": "API key payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apikeys.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/apikeys.CreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/api-keys/scopes": {
+            "get": {
+                "description": "List the scopes that can be granted to an API key",
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API key scopes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apikeys.ScopesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/api-keys/{id}": {
+            "delete": {
+                "description": "Revoke one of the current user's API keys. Requests using it are rejected immediately.",
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/channels/{platform}": {
+            "get": {
+                "description": "Get channel binding configuration for current user",
+                "tags": [
+                    "channel"
+                ],
+                "summary": "Get channel user config",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel platform",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/channel.ChannelIdentityBinding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update channel binding configuration for current user",
+                "tags": [
+                    "channel"
+                ],
+                "summary": "Update channel user config",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel platform",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Channel user config payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/channel.UpsertChannelIdentityConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/channel.ChannelIdentityBinding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/identities": {
+            "get": {
+                "description": "List all channel identities linked to current user",
+                "tags": [
+                    "users"
+                ],
+                "summary": "List current user's channel identities",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.listMyIdentitiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "put": {
+                "description": "Update current user password with current password check",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update current user password",
+                "parameters": [
+                    {
+                        "description": "Password payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/accounts.UpdatePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get user details (self or admin only)",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/accounts.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update user profile and status",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/accounts.UpdateAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/accounts.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/password": {
+            "put": {
+                "description": "Reset a user password",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reset user password (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Password payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/accounts.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/chat/completions": {
+            "post": {
+                "description": "Run a chat turn against a bot with its memory, tools and container. Without the X-Memoh-Session-Id header the request messages are the conversation history; with it the bot's stored session history is used and only the last user message is sent. Set stream to receive chat.completion.chunk server-sent events. Tool calls are surfaced as reasoning content or omitted, per server config or the X-Memoh-Tool-Calls header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "openai"
+                ],
+                "summary": "Create an OpenAI-compatible chat completion",
+                "parameters": [
+                    {
+                        "description": "Chat completion request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenAIChatCompletionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID to continue",
+                        "name": "X-Memoh-Session-Id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool call rendering: reasoning or omit",
+                        "name": "X-Memoh-Tool-Calls",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenAIChatCompletion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/models": {
+            "get": {
+                "description": "List the bots the caller can access in the OpenAI model list format. Use a bot ID or display name as the model of a chat completion.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "openai"
+                ],
+                "summary": "List bots as OpenAI models",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenAIModelList"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "accounts.Account": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
                 },
                 "email": {
                     "type": "string"
@@ -8838,6 +11195,9 @@ const docTemplate = `{
                 "subject_kind": {
                     "type": "string"
                 },
+                "tool_pattern": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -8886,6 +11246,23 @@ const docTemplate = `{
                 }
             }
         },
+        "acl.UpsertToolRuleRequest": {
+            "type": "object",
+            "properties": {
+                "channel_identity_id": {
+                    "type": "string"
+                },
+                "effect": {
+                    "type": "string"
+                },
+                "tool_pattern": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "acl.UserCandidate": {
             "type": "object",
             "properties": {
@@ -9038,6 +11415,12 @@ const docTemplate = `{
                 "overview_path": {
                     "type": "string"
                 },
+                "pgvector": {
+                    "$ref": "#/definitions/adapters.HealthStatus"
+                },
+                "pgvector_table": {
+                    "type": "string"
+                },
                 "provider_type": {
                     "type": "string"
                 },
@@ -9052,6 +11435,9 @@ const docTemplate = `{
                 },
                 "source_dir": {
                     "type": "string"
+                },
+                "vector_store": {
+                    "type": "string"
                 }
             }
         },
@@ -9130,151 +11516,367 @@ const docTemplate = `{
                 }
             }
         },
-        "adapters.ProviderGetResponse": {
+        "adapters.ProviderGetResponse": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "adapters.ProviderMeta": {
+            "type": "object",
+            "properties": {
+                "config_schema": {
+                    "$ref": "#/definitions/adapters.ProviderConfigSchema"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "adapters.ProviderStatusResponse": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/adapters.ProviderCollectionStatus"
+                    }
+                },
+                "embedding_model_id": {
+                    "type": "string"
+                },
+                "memory_mode": {
+                    "type": "string"
+                },
+                "provider_type": {
+                    "type": "string"
+                },
+                "vector_store": {
+                    "type": "string"
+                }
+            }
+        },
+        "adapters.ProviderType": {
+            "type": "string",
+            "enum": [
+                "builtin",
+                "mem0",
+                "openviking"
+            ],
+            "x-enum-varnames": [
+                "ProviderBuiltin",
+                "ProviderMem0",
+                "ProviderOpenViking"
+            ]
+        },
+        "adapters.ProviderUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "adapters.RebuildResult": {
+            "type": "object",
+            "properties": {
+                "fs_count": {
+                    "type": "integer"
+                },
+                "missing_count": {
+                    "type": "integer"
+                },
+                "restored_count": {
+                    "type": "integer"
+                },
+                "storage_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "adapters.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "relations": {
+                    "type": "array",
+                    "items": {}
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/adapters.MemoryItem"
+                    }
+                }
+            }
+        },
+        "adapters.TopKBucket": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "description": "sparse dimension index (term hash)",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "weight (term frequency)",
+                    "type": "number"
+                }
+            }
+        },
+        "adapters.UsageResponse": {
+            "type": "object",
+            "properties": {
+                "avg_text_bytes": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "estimated_storage_bytes": {
+                    "type": "integer"
+                },
+                "total_text_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apikeys.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apikeys.CreateRequest": {
+            "type": "object",
+            "properties": {
+                "expires_in_days": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apikeys.CreateResponse": {
             "type": "object",
             "properties": {
-                "config": {
-                    "type": "object",
-                    "additionalProperties": {}
-                },
                 "created_at": {
                     "type": "string"
                 },
+                "expires_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "is_default": {
-                    "type": "boolean"
+                "last_used_at": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "provider": {
+                "prefix": {
                     "type": "string"
                 },
-                "updated_at": {
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "adapters.ProviderMeta": {
+        "apikeys.ListResponse": {
             "type": "object",
             "properties": {
-                "config_schema": {
-                    "$ref": "#/definitions/adapters.ProviderConfigSchema"
-                },
-                "display_name": {
-                    "type": "string"
-                },
-                "provider": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apikeys.APIKey"
+                    }
                 }
             }
         },
-        "adapters.ProviderStatusResponse": {
+        "apikeys.ScopesResponse": {
             "type": "object",
             "properties": {
-                "collections": {
+                "scopes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/adapters.ProviderCollectionStatus"
+                        "type": "string"
                     }
+                }
+            }
+        },
+        "approval.Approval": {
+            "type": "object",
+            "properties": {
+                "bot_id": {
+                    "type": "string"
                 },
-                "embedding_model_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "memory_mode": {
+                "decided_at": {
                     "type": "string"
                 },
-                "provider_type": {
+                "decided_by_user_id": {
                     "type": "string"
-                }
-            }
-        },
-        "adapters.ProviderType": {
-            "type": "string",
-            "enum": [
-                "builtin",
-                "mem0",
-                "openviking"
-            ],
-            "x-enum-varnames": [
-                "ProviderBuiltin",
-                "ProviderMem0",
-                "ProviderOpenViking"
-            ]
-        },
-        "adapters.ProviderUpdateRequest": {
-            "type": "object",
-            "properties": {
-                "config": {
+                },
+                "decision_note": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "input": {
                     "type": "object",
                     "additionalProperties": {}
                 },
-                "name": {
+                "platform": {
+                    "type": "string"
+                },
+                "reply_target": {
+                    "type": "string"
+                },
+                "requester_channel_identity_id": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tool_call_id": {
+                    "type": "string"
+                },
+                "tool_name": {
                     "type": "string"
                 }
             }
         },
-        "adapters.RebuildResult": {
+        "approval.DecideRequest": {
             "type": "object",
             "properties": {
-                "fs_count": {
-                    "type": "integer"
-                },
-                "missing_count": {
-                    "type": "integer"
-                },
-                "restored_count": {
-                    "type": "integer"
+                "approve": {
+                    "type": "boolean"
                 },
-                "storage_count": {
-                    "type": "integer"
+                "note": {
+                    "type": "string"
                 }
             }
         },
-        "adapters.SearchResponse": {
+        "approval.ListResponse": {
             "type": "object",
             "properties": {
-                "relations": {
-                    "type": "array",
-                    "items": {}
-                },
-                "results": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/adapters.MemoryItem"
+                        "$ref": "#/definitions/approval.Approval"
                     }
                 }
             }
         },
-        "adapters.TopKBucket": {
+        "approval.Policy": {
             "type": "object",
             "properties": {
-                "index": {
-                    "description": "sparse dimension index (term hash)",
+                "timeout_seconds": {
                     "type": "integer"
                 },
-                "value": {
-                    "description": "weight (term frequency)",
-                    "type": "number"
+                "tool_patterns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "adapters.UsageResponse": {
+        "approval.UpdatePolicyRequest": {
             "type": "object",
             "properties": {
-                "avg_text_bytes": {
-                    "type": "integer"
-                },
-                "count": {
-                    "type": "integer"
-                },
-                "estimated_storage_bytes": {
+                "timeout_seconds": {
                     "type": "integer"
                 },
-                "total_text_bytes": {
-                    "type": "integer"
+                "tool_patterns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -9428,39 +12030,203 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "browsercontexts.CreateRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "browsercontexts.UpdateRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "budget.Allowance": {
+            "type": "object",
+            "properties": {
+                "exhausted": {
+                    "type": "boolean"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "remaining_input_tokens": {
+                    "type": "integer"
+                },
+                "remaining_output_tokens": {
+                    "type": "integer"
+                },
+                "remaining_total_tokens": {
+                    "type": "integer"
+                },
+                "resets_at": {
+                    "type": "string"
+                },
+                "rule": {
+                    "$ref": "#/definitions/budget.Rule"
+                },
+                "used": {
+                    "$ref": "#/definitions/budget.Usage"
+                }
+            }
+        },
+        "budget.AllowanceResponse": {
+            "type": "object",
+            "properties": {
+                "exhausted": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/budget.Allowance"
+                    }
+                }
+            }
+        },
+        "budget.CreateRequest": {
+            "type": "object",
+            "properties": {
+                "channel_identity_id": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exhausted_message": {
+                    "type": "string"
+                },
+                "max_input_tokens": {
+                    "type": "integer"
+                },
+                "max_output_tokens": {
+                    "type": "integer"
+                },
+                "max_total_tokens": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "budget.ListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/budget.Rule"
+                    }
+                }
+            }
+        },
+        "budget.Rule": {
+            "type": "object",
+            "properties": {
+                "bot_id": {
+                    "type": "string"
+                },
+                "channel_identity_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exhausted_message": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_input_tokens": {
+                    "type": "integer"
+                },
+                "max_output_tokens": {
+                    "type": "integer"
+                },
+                "max_total_tokens": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "scope": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "browsercontexts.CreateRequest": {
+        "budget.UpdateRequest": {
             "type": "object",
             "properties": {
-                "config": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "enabled": {
+                    "type": "boolean"
                 },
-                "name": {
+                "exhausted_message": {
+                    "type": "string"
+                },
+                "max_input_tokens": {
+                    "type": "integer"
+                },
+                "max_output_tokens": {
+                    "type": "integer"
+                },
+                "max_total_tokens": {
+                    "type": "integer"
+                },
+                "period": {
                     "type": "string"
                 }
             }
         },
-        "browsercontexts.UpdateRequest": {
+        "budget.Usage": {
             "type": "object",
             "properties": {
-                "config": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "input_tokens": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "output_tokens": {
+                    "type": "integer"
                 }
             }
         },
@@ -9759,6 +12525,9 @@ const docTemplate = `{
                         "$ref": "#/definitions/channel.MessagePart"
                     }
                 },
+                "poll": {
+                    "$ref": "#/definitions/channel.Poll"
+                },
                 "reply": {
                     "$ref": "#/definitions/channel.ReplyRef"
                 },
@@ -9848,6 +12617,23 @@ const docTemplate = `{
                 "MessageStyleCode"
             ]
         },
+        "channel.Poll": {
+            "type": "object",
+            "properties": {
+                "multiple_answers": {
+                    "type": "boolean"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "question": {
+                    "type": "string"
+                }
+            }
+        },
         "channel.ReplyRef": {
             "type": "object",
             "properties": {
@@ -10112,128 +12898,334 @@ const docTemplate = `{
                 }
             }
         },
-        "email.OutboxItemResponse": {
+        "email.OutboxItemResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {}
+                },
+                "body_html": {
+                    "type": "string"
+                },
+                "body_text": {
+                    "type": "string"
+                },
+                "bot_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "email.ProviderMeta": {
+            "type": "object",
+            "properties": {
+                "config_schema": {
+                    "$ref": "#/definitions/email.ConfigSchema"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "email.ProviderResponse": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "email.UpdateBindingRequest": {
+            "type": "object",
+            "properties": {
+                "can_delete": {
+                    "type": "boolean"
+                },
+                "can_read": {
+                    "type": "boolean"
+                },
+                "can_write": {
+                    "type": "boolean"
+                },
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "email_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "email.UpdateProviderRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "eventtrigger.Config": {
+            "type": "object",
+            "properties": {
+                "connection_id": {
+                    "description": "ConnectionID is the bot's HTTP or SSE MCP connection an mcp trigger\nlistens to.",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Events limits a file trigger to create, modify and/or delete. Empty\nmeans all three.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from": {
+                    "description": "From and Subject are case-insensitive substrings an inbound email must\ncontain to fire an email trigger. Empty matches every email.",
+                    "type": "string"
+                },
+                "methods": {
+                    "description": "Methods limits an mcp trigger to these notification methods, e.g.\n\"notifications/resources/updated\". Empty means all of them.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "path": {
+                    "description": "Path is the file or directory watched by a file trigger. Relative\npaths are resolved against /data, and the path must stay below it.",
+                    "type": "string"
+                },
+                "pattern": {
+                    "description": "Pattern is a glob matched against the base name of changed files,\ne.g. \"*.csv\". Empty matches every file.",
+                    "type": "string"
+                },
+                "recursive": {
+                    "type": "boolean"
+                },
+                "resource_uri": {
+                    "description": "ResourceURI is subscribed to on connect so the server reports its\nupdates.",
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "eventtrigger.CreateRequest": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "config": {
+                    "$ref": "#/definitions/eventtrigger.Config"
+                },
+                "cooldown_seconds": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "eventtrigger.ListLogsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/eventtrigger.Log"
+                    }
+                }
+            }
+        },
+        "eventtrigger.ListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/eventtrigger.Trigger"
+                    }
+                }
+            }
+        },
+        "eventtrigger.Log": {
             "type": "object",
             "properties": {
-                "attachments": {
-                    "type": "array",
-                    "items": {}
-                },
-                "body_html": {
-                    "type": "string"
-                },
-                "body_text": {
-                    "type": "string"
-                },
                 "bot_id": {
                     "type": "string"
                 },
-                "created_at": {
+                "completed_at": {
                     "type": "string"
                 },
-                "error": {
+                "error_message": {
                     "type": "string"
                 },
-                "from": {
+                "event": {},
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "result_text": {
                     "type": "string"
                 },
-                "message_id": {
+                "session_id": {
                     "type": "string"
                 },
-                "provider_id": {
+                "source": {
                     "type": "string"
                 },
-                "sent_at": {
+                "started_at": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
                 },
-                "subject": {
+                "trigger_id": {
                     "type": "string"
                 },
-                "to": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                }
+                "usage": {}
             }
         },
-        "email.ProviderMeta": {
+        "eventtrigger.Trigger": {
             "type": "object",
             "properties": {
-                "config_schema": {
-                    "$ref": "#/definitions/email.ConfigSchema"
-                },
-                "display_name": {
+                "bot_id": {
                     "type": "string"
                 },
-                "provider": {
+                "command": {
                     "type": "string"
-                }
-            }
-        },
-        "email.ProviderResponse": {
-            "type": "object",
-            "properties": {
+                },
                 "config": {
-                    "type": "object",
-                    "additionalProperties": {}
+                    "$ref": "#/definitions/eventtrigger.Config"
+                },
+                "cooldown_seconds": {
+                    "description": "CooldownSeconds is the minimum time between two runs; events arriving\nsooner are dropped.",
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "current_calls": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
+                "last_fired_at": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
-                "provider": {
+                "source": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "webhook_secret": {
+                    "description": "WebhookSecret authenticates calls to a webhook trigger's public URL.",
+                    "type": "string"
                 }
             }
         },
-        "email.UpdateBindingRequest": {
+        "eventtrigger.UpdateRequest": {
             "type": "object",
             "properties": {
-                "can_delete": {
-                    "type": "boolean"
-                },
-                "can_read": {
-                    "type": "boolean"
-                },
-                "can_write": {
-                    "type": "boolean"
+                "command": {
+                    "type": "string"
                 },
                 "config": {
-                    "type": "object",
-                    "additionalProperties": {}
+                    "$ref": "#/definitions/eventtrigger.Config"
                 },
-                "email_address": {
+                "cooldown_seconds": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
-                }
-            }
-        },
-        "email.UpdateProviderRequest": {
-            "type": "object",
-            "properties": {
-                "config": {
-                    "type": "object",
-                    "additionalProperties": {}
+                },
+                "enabled": {
+                    "type": "boolean"
                 },
                 "name": {
                     "type": "string"
                 },
-                "provider": {
-                    "type": "string"
+                "rotate_secret": {
+                    "description": "RotateSecret issues a new webhook secret.",
+                    "type": "boolean"
                 }
             }
         },
@@ -10413,6 +13405,9 @@ const docTemplate = `{
                 "cache_write_tokens": {
                     "type": "integer"
                 },
+                "cost_usd": {
+                    "type": "number"
+                },
                 "day": {
                     "type": "string"
                 },
@@ -10562,6 +13557,9 @@ const docTemplate = `{
                 "namespace": {
                     "type": "string"
                 },
+                "resources": {
+                    "$ref": "#/definitions/workspace.ResourceProfile"
+                },
                 "status": {
                     "type": "string"
                 },
@@ -10570,6 +13568,9 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/workspace.ResourceUsage"
                 }
             }
         },
@@ -10678,6 +13679,9 @@ const docTemplate = `{
         "handlers.ModelTokenUsage": {
             "type": "object",
             "properties": {
+                "cost_usd": {
+                    "type": "number"
+                },
                 "input_tokens": {
                     "type": "integer"
                 },
@@ -10698,6 +13702,153 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.OpenAIChatCompletion": {
+            "type": "object",
+            "properties": {
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OpenAIChoice"
+                    }
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/handlers.OpenAIUsage"
+                }
+            }
+        },
+        "handlers.OpenAIChatCompletionRequest": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OpenAIChatMessage"
+                    }
+                },
+                "model": {
+                    "type": "string"
+                },
+                "stream": {
+                    "type": "boolean"
+                },
+                "stream_options": {
+                    "$ref": "#/definitions/handlers.OpenAIStreamOptions"
+                }
+            }
+        },
+        "handlers.OpenAIChatMessage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OpenAIChoice": {
+            "type": "object",
+            "properties": {
+                "finish_reason": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "message": {
+                    "$ref": "#/definitions/handlers.OpenAIResponseMessage"
+                }
+            }
+        },
+        "handlers.OpenAIModel": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "owned_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OpenAIModelList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OpenAIModel"
+                    }
+                },
+                "object": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OpenAIResponseMessage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "reasoning_content": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OpenAIStreamOptions": {
+            "type": "object",
+            "properties": {
+                "include_usage": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.OpenAIUsage": {
+            "type": "object",
+            "properties": {
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
         "handlers.PingResponse": {
             "type": "object",
             "properties": {
@@ -10738,22 +13889,45 @@ const docTemplate = `{
                 "access_token": {
                     "type": "string"
                 },
-                "expires_at": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RollbackRequest": {
+            "type": "object",
+            "properties": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.SessionTokenUsage": {
+            "type": "object",
+            "properties": {
+                "cost_usd": {
+                    "type": "number"
+                },
+                "input_tokens": {
+                    "type": "integer"
+                },
+                "output_tokens": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 },
-                "token_type": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "handlers.RollbackRequest": {
-            "type": "object",
-            "properties": {
-                "version": {
-                    "type": "integer"
-                }
-            }
-        },
         "handlers.SkillItem": {
             "type": "object",
             "properties": {
@@ -10861,6 +14035,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/handlers.ModelTokenUsage"
                     }
                 },
+                "by_session": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SessionTokenUsage"
+                    }
+                },
                 "chat": {
                     "type": "array",
                     "items": {
@@ -10878,6 +14058,10 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/handlers.DailyTokenUsage"
                     }
+                },
+                "total_cost_usd": {
+                    "description": "TotalCostUSD is the bot's cost over the range. Messages answered by\nmodels without pricing contribute tokens but no cost.",
+                    "type": "number"
                 }
             }
         },
@@ -11133,12 +14317,27 @@ const docTemplate = `{
                 "completed_at": {
                     "type": "string"
                 },
+                "consecutive_ok": {
+                    "description": "ConsecutiveOK counts HEARTBEAT_OK runs in a row ending with this one.",
+                    "type": "integer"
+                },
                 "error_message": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "next_run_reason": {
+                    "description": "NextRunReason explains NextRunAt: interval, backoff, requested or\nactive_hours.",
+                    "type": "string"
+                },
+                "requested_interval": {
+                    "description": "RequestedInterval is the next-check interval in minutes the run asked\nfor, if any.",
+                    "type": "integer"
+                },
                 "result_text": {
                     "type": "string"
                 },
@@ -11549,6 +14748,26 @@ const docTemplate = `{
                 },
                 "dimensions": {
                     "type": "integer"
+                },
+                "pricing": {
+                    "$ref": "#/definitions/models.ModelPricing"
+                }
+            }
+        },
+        "models.ModelPricing": {
+            "type": "object",
+            "properties": {
+                "cached_input": {
+                    "type": "number"
+                },
+                "input": {
+                    "type": "number"
+                },
+                "output": {
+                    "type": "number"
+                },
+                "reasoning": {
+                    "type": "number"
                 }
             }
         },
@@ -11601,421 +14820,769 @@ const docTemplate = `{
                 "config": {
                     "$ref": "#/definitions/models.ModelConfig"
                 },
-                "llm_provider_id": {
+                "llm_provider_id": {
+                    "type": "string"
+                },
+                "model_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.ModelType"
+                }
+            }
+        },
+        "providers.CountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "providers.CreateRequest": {
+            "type": "object",
+            "required": [
+                "base_url",
+                "client_type",
+                "name"
+            ],
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "client_type": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "providers.GetResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "client_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enable": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "providers.ImportModelsResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "providers.TestResponse": {
+            "type": "object",
+            "properties": {
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reachable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "providers.UpdateRequest": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "client_type": {
+                    "type": "string"
+                },
+                "enable": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schedule.CreateRequest": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "delivery_platform": {
+                    "type": "string"
+                },
+                "delivery_target": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "in": {
+                    "description": "In is a Go duration such as \"2h\" or \"90m\" after which a one-shot\nschedule fires.",
+                    "type": "string"
+                },
+                "max_calls": {
+                    "$ref": "#/definitions/schedule.NullableInt"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "schedule.ListLogsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schedule.Log"
+                    }
+                }
+            }
+        },
+        "schedule.ListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schedule.Schedule"
+                    }
+                }
+            }
+        },
+        "schedule.Log": {
+            "type": "object",
+            "properties": {
+                "bot_id": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "result_text": {
+                    "type": "string"
+                },
+                "schedule_id": {
                     "type": "string"
                 },
-                "model_id": {
+                "session_id": {
                     "type": "string"
                 },
-                "name": {
+                "started_at": {
                     "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/models.ModelType"
-                }
+                "status": {
+                    "type": "string"
+                },
+                "usage": {}
             }
         },
-        "providers.CountResponse": {
+        "schedule.NullableInt": {
             "type": "object",
             "properties": {
-                "count": {
+                "set": {
+                    "type": "boolean"
+                },
+                "value": {
                     "type": "integer"
                 }
             }
         },
-        "providers.CreateRequest": {
+        "schedule.Schedule": {
             "type": "object",
-            "required": [
-                "base_url",
-                "client_type",
-                "name"
-            ],
             "properties": {
-                "api_key": {
+                "bot_id": {
                     "type": "string"
                 },
-                "base_url": {
+                "command": {
                     "type": "string"
                 },
-                "client_type": {
+                "created_at": {
                     "type": "string"
                 },
-                "icon": {
+                "current_calls": {
+                    "type": "integer"
+                },
+                "delivery_platform": {
+                    "description": "DeliveryPlatform and DeliveryTarget name the channel conversation that\nreceives each run's final answer. Both are empty when the run only\ndelivers what it sends itself.",
                     "type": "string"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "delivery_target": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_calls": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "run_at": {
+                    "description": "RunAt is set for one-shot schedules, which have no pattern and disable\nthemselves after firing once.",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA zone the pattern is evaluated in. Empty means the\nserver's local zone.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "providers.GetResponse": {
+        "schedule.UpdateRequest": {
             "type": "object",
             "properties": {
-                "api_key": {
+                "command": {
                     "type": "string"
                 },
-                "base_url": {
+                "delivery_platform": {
                     "type": "string"
                 },
-                "client_type": {
+                "delivery_target": {
                     "type": "string"
                 },
-                "created_at": {
+                "description": {
                     "type": "string"
                 },
-                "enable": {
+                "enabled": {
                     "type": "boolean"
                 },
-                "icon": {
+                "in": {
                     "type": "string"
                 },
-                "id": {
+                "max_calls": {
+                    "$ref": "#/definitions/schedule.NullableInt"
+                },
+                "name": {
                     "type": "string"
                 },
-                "metadata": {
+                "pattern": {
+                    "type": "string"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "searchproviders.CreateRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "$ref": "#/definitions/searchproviders.ProviderName"
+                }
+            }
+        },
+        "searchproviders.GetResponse": {
+            "type": "object",
+            "properties": {
+                "config": {
                     "type": "object",
                     "additionalProperties": {}
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
+                "provider": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "providers.ImportModelsResponse": {
+        "searchproviders.ProviderConfigSchema": {
             "type": "object",
             "properties": {
-                "created": {
-                    "type": "integer"
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/searchproviders.ProviderFieldSchema"
+                    }
+                }
+            }
+        },
+        "searchproviders.ProviderFieldSchema": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
                 },
-                "models": {
+                "enum": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "skipped": {
-                    "type": "integer"
+                "example": {},
+                "required": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "providers.TestResponse": {
+        "searchproviders.ProviderMeta": {
             "type": "object",
             "properties": {
-                "latency_ms": {
-                    "type": "integer"
+                "config_schema": {
+                    "$ref": "#/definitions/searchproviders.ProviderConfigSchema"
                 },
-                "message": {
+                "display_name": {
                     "type": "string"
                 },
-                "reachable": {
-                    "type": "boolean"
+                "provider": {
+                    "type": "string"
                 }
             }
         },
-        "providers.UpdateRequest": {
+        "searchproviders.ProviderName": {
+            "type": "string",
+            "enum": [
+                "brave",
+                "bing",
+                "google",
+                "tavily",
+                "sogou",
+                "serper",
+                "searxng",
+                "jina",
+                "exa",
+                "bocha",
+                "duckduckgo",
+                "yandex"
+            ],
+            "x-enum-varnames": [
+                "ProviderBrave",
+                "ProviderBing",
+                "ProviderGoogle",
+                "ProviderTavily",
+                "ProviderSogou",
+                "ProviderSerper",
+                "ProviderSearXNG",
+                "ProviderJina",
+                "ProviderExa",
+                "ProviderBocha",
+                "ProviderDuckDuckGo",
+                "ProviderYandex"
+            ]
+        },
+        "searchproviders.UpdateRequest": {
             "type": "object",
             "properties": {
-                "api_key": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
                     "type": "string"
                 },
-                "base_url": {
+                "provider": {
+                    "$ref": "#/definitions/searchproviders.ProviderName"
+                }
+            }
+        },
+        "session.Session": {
+            "type": "object",
+            "properties": {
+                "bot_id": {
                     "type": "string"
                 },
-                "client_type": {
+                "channel_type": {
                     "type": "string"
                 },
-                "enable": {
-                    "type": "boolean"
+                "created_at": {
+                    "type": "string"
                 },
-                "icon": {
+                "id": {
                     "type": "string"
                 },
                 "metadata": {
                     "type": "object",
                     "additionalProperties": {}
                 },
-                "name": {
+                "parent_session_id": {
                     "type": "string"
-                }
-            }
-        },
-        "schedule.CreateRequest": {
-            "type": "object",
-            "properties": {
-                "command": {
+                },
+                "route_conversation_type": {
                     "type": "string"
                 },
-                "description": {
+                "route_id": {
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
+                "route_metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
                 },
-                "max_calls": {
-                    "$ref": "#/definitions/schedule.NullableInt"
+                "title": {
+                    "type": "string"
                 },
-                "name": {
+                "type": {
                     "type": "string"
                 },
-                "pattern": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "schedule.ListLogsResponse": {
+        "settings.ModelFallbacks": {
             "type": "object",
             "properties": {
-                "items": {
+                "chat": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "compaction": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "heartbeat": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schedule.Log"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "schedule.ListResponse": {
-            "type": "object",
-            "properties": {
-                "items": {
+                },
+                "title": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schedule.Schedule"
+                        "type": "string"
                     }
                 }
             }
         },
-        "schedule.Log": {
+        "settings.Settings": {
             "type": "object",
             "properties": {
-                "bot_id": {
-                    "type": "string"
+                "allow_guest": {
+                    "type": "boolean"
                 },
-                "completed_at": {
+                "browser_context_id": {
                     "type": "string"
                 },
-                "error_message": {
+                "chat_model_id": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "compaction_enabled": {
+                    "type": "boolean"
                 },
-                "result_text": {
+                "compaction_model_id": {
                     "type": "string"
                 },
-                "schedule_id": {
-                    "type": "string"
+                "compaction_threshold": {
+                    "type": "integer"
                 },
-                "session_id": {
-                    "type": "string"
+                "fetch_allow_domains": {
+                    "description": "FetchAllowDomains, when non-empty, limits web_fetch and attachment\ndownloads to these domains and their subdomains.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "started_at": {
+                "fetch_deny_domains": {
+                    "description": "FetchDenyDomains blocks these domains and their subdomains for\nweb_fetch and attachment downloads.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "heartbeat_active_end": {
                     "type": "string"
                 },
-                "status": {
+                "heartbeat_active_start": {
+                    "description": "HeartbeatActiveStart and HeartbeatActiveEnd bound the daily \"HH:MM\"\nwindow, in Timezone, that heartbeats run in. Both empty means always.",
                     "type": "string"
                 },
-                "usage": {}
-            }
-        },
-        "schedule.NullableInt": {
-            "type": "object",
-            "properties": {
-                "set": {
+                "heartbeat_enabled": {
                     "type": "boolean"
                 },
-                "value": {
+                "heartbeat_interval": {
                     "type": "integer"
-                }
-            }
-        },
-        "schedule.Schedule": {
-            "type": "object",
-            "properties": {
-                "bot_id": {
-                    "type": "string"
                 },
-                "command": {
+                "heartbeat_jitter": {
+                    "description": "HeartbeatJitter is the maximum random delay in minutes added to each\nheartbeat so bots do not fire in lockstep.",
+                    "type": "integer"
+                },
+                "heartbeat_max_interval": {
+                    "description": "HeartbeatMaxInterval is the ceiling in minutes for backing off after\nconsecutive HEARTBEAT_OK runs; 0 disables backoff.",
+                    "type": "integer"
+                },
+                "heartbeat_model_id": {
                     "type": "string"
                 },
-                "created_at": {
+                "language": {
                     "type": "string"
                 },
-                "current_calls": {
+                "max_context_load_time": {
                     "type": "integer"
                 },
-                "description": {
+                "max_context_tokens": {
+                    "type": "integer"
+                },
+                "memory_provider_id": {
                     "type": "string"
                 },
-                "enabled": {
+                "memory_scope_policy": {
+                    "type": "string"
+                },
+                "model_fallbacks": {
+                    "$ref": "#/definitions/settings.ModelFallbacks"
+                },
+                "reasoning_effort": {
+                    "type": "string"
+                },
+                "reasoning_enabled": {
                     "type": "boolean"
                 },
-                "id": {
+                "search_provider_id": {
                     "type": "string"
                 },
-                "max_calls": {
-                    "type": "integer"
+                "stt_model_id": {
+                    "type": "string"
                 },
-                "name": {
+                "timezone": {
+                    "description": "Timezone is the bot's IANA timezone; empty means UTC.",
                     "type": "string"
                 },
-                "pattern": {
+                "title_model_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "tts_model_id": {
                     "type": "string"
                 }
             }
         },
-        "schedule.UpdateRequest": {
+        "settings.UpsertRequest": {
             "type": "object",
             "properties": {
-                "command": {
+                "allow_guest": {
+                    "type": "boolean"
+                },
+                "browser_context_id": {
                     "type": "string"
                 },
-                "description": {
+                "chat_model_id": {
                     "type": "string"
                 },
-                "enabled": {
+                "compaction_enabled": {
                     "type": "boolean"
                 },
-                "max_calls": {
-                    "$ref": "#/definitions/schedule.NullableInt"
-                },
-                "name": {
+                "compaction_model_id": {
                     "type": "string"
                 },
-                "pattern": {
+                "compaction_threshold": {
+                    "type": "integer"
+                },
+                "fetch_allow_domains": {
+                    "description": "FetchAllowDomains and FetchDenyDomains replace the stored lists when\nnon-nil; an empty list clears them.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "fetch_deny_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "heartbeat_active_end": {
                     "type": "string"
-                }
-            }
-        },
-        "searchproviders.CreateRequest": {
-            "type": "object",
-            "properties": {
-                "config": {
-                    "type": "object",
-                    "additionalProperties": {}
                 },
-                "name": {
+                "heartbeat_active_start": {
                     "type": "string"
                 },
-                "provider": {
-                    "$ref": "#/definitions/searchproviders.ProviderName"
-                }
-            }
-        },
-        "searchproviders.GetResponse": {
-            "type": "object",
-            "properties": {
-                "config": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "heartbeat_enabled": {
+                    "type": "boolean"
                 },
-                "created_at": {
+                "heartbeat_interval": {
+                    "type": "integer"
+                },
+                "heartbeat_jitter": {
+                    "type": "integer"
+                },
+                "heartbeat_max_interval": {
+                    "type": "integer"
+                },
+                "heartbeat_model_id": {
                     "type": "string"
                 },
-                "id": {
+                "language": {
                     "type": "string"
                 },
-                "name": {
+                "max_context_load_time": {
+                    "type": "integer"
+                },
+                "max_context_tokens": {
+                    "type": "integer"
+                },
+                "memory_provider_id": {
                     "type": "string"
                 },
-                "provider": {
+                "memory_scope_policy": {
                     "type": "string"
                 },
-                "updated_at": {
+                "model_fallbacks": {
+                    "$ref": "#/definitions/settings.ModelFallbacks"
+                },
+                "reasoning_effort": {
                     "type": "string"
-                }
-            }
-        },
-        "searchproviders.ProviderConfigSchema": {
-            "type": "object",
-            "properties": {
-                "fields": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/searchproviders.ProviderFieldSchema"
-                    }
-                }
-            }
-        },
-        "searchproviders.ProviderFieldSchema": {
-            "type": "object",
-            "properties": {
-                "description": {
+                },
+                "reasoning_enabled": {
+                    "type": "boolean"
+                },
+                "search_provider_id": {
                     "type": "string"
                 },
-                "enum": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "stt_model_id": {
+                    "type": "string"
                 },
-                "example": {},
-                "required": {
-                    "type": "boolean"
+                "timezone": {
+                    "type": "string"
                 },
-                "title": {
+                "title_model_id": {
                     "type": "string"
                 },
-                "type": {
+                "tts_model_id": {
                     "type": "string"
                 }
             }
         },
-        "searchproviders.ProviderMeta": {
+        "stt.CreateModelRequest": {
             "type": "object",
             "properties": {
-                "config_schema": {
-                    "$ref": "#/definitions/searchproviders.ProviderConfigSchema"
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
                 },
-                "display_name": {
+                "model_id": {
                     "type": "string"
                 },
-                "provider": {
-                    "type": "string"
-                }
-            }
-        },
-        "searchproviders.ProviderName": {
-            "type": "string",
-            "enum": [
-                "brave",
-                "bing",
-                "google",
-                "tavily",
-                "sogou",
-                "serper",
-                "searxng",
-                "jina",
-                "exa",
-                "bocha",
-                "duckduckgo",
-                "yandex"
-            ],
-            "x-enum-varnames": [
-                "ProviderBrave",
-                "ProviderBing",
-                "ProviderGoogle",
-                "ProviderTavily",
-                "ProviderSogou",
-                "ProviderSerper",
-                "ProviderSearXNG",
-                "ProviderJina",
-                "ProviderExa",
-                "ProviderBocha",
-                "ProviderDuckDuckGo",
-                "ProviderYandex"
-            ]
+                "name": {
+                    "type": "string"
+                },
+                "stt_provider_id": {
+                    "type": "string"
+                }
+            }
         },
-        "searchproviders.UpdateRequest": {
+        "stt.CreateProviderRequest": {
             "type": "object",
             "properties": {
                 "config": {
@@ -12026,46 +15593,67 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "provider": {
-                    "$ref": "#/definitions/searchproviders.ProviderName"
+                    "type": "string"
                 }
             }
         },
-        "session.Session": {
+        "stt.ModelCapabilities": {
             "type": "object",
             "properties": {
-                "bot_id": {
-                    "type": "string"
+                "formats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "channel_type": {
-                    "type": "string"
+                "prompt": {
+                    "type": "boolean"
                 },
-                "created_at": {
+                "temperature": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "stt.ModelInfo": {
+            "type": "object",
+            "properties": {
+                "capabilities": {
+                    "$ref": "#/definitions/stt.ModelCapabilities"
+                },
+                "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "metadata": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "stt.ModelResponse": {
+            "type": "object",
+            "properties": {
+                "config": {
                     "type": "object",
                     "additionalProperties": {}
                 },
-                "parent_session_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "route_conversation_type": {
+                "id": {
                     "type": "string"
                 },
-                "route_id": {
+                "model_id": {
                     "type": "string"
                 },
-                "route_metadata": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "name": {
+                    "type": "string"
                 },
-                "title": {
+                "provider_type": {
                     "type": "string"
                 },
-                "type": {
+                "stt_provider_id": {
                     "type": "string"
                 },
                 "updated_at": {
@@ -12073,121 +15661,181 @@ const docTemplate = `{
                 }
             }
         },
-        "settings.Settings": {
+        "stt.ProviderMetaResponse": {
             "type": "object",
             "properties": {
-                "allow_guest": {
-                    "type": "boolean"
-                },
-                "browser_context_id": {
+                "default_model": {
                     "type": "string"
                 },
-                "chat_model_id": {
+                "description": {
                     "type": "string"
                 },
-                "compaction_enabled": {
-                    "type": "boolean"
-                },
-                "compaction_model_id": {
+                "display_name": {
                     "type": "string"
                 },
-                "compaction_threshold": {
-                    "type": "integer"
-                },
-                "heartbeat_enabled": {
-                    "type": "boolean"
-                },
-                "heartbeat_interval": {
-                    "type": "integer"
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stt.ModelInfo"
+                    }
                 },
-                "heartbeat_model_id": {
+                "provider": {
                     "type": "string"
+                }
+            }
+        },
+        "stt.ProviderResponse": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
                 },
-                "language": {
+                "created_at": {
                     "type": "string"
                 },
-                "max_context_load_time": {
-                    "type": "integer"
-                },
-                "max_context_tokens": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "memory_provider_id": {
+                "name": {
                     "type": "string"
                 },
-                "reasoning_effort": {
+                "provider": {
                     "type": "string"
                 },
-                "reasoning_enabled": {
-                    "type": "boolean"
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "stt.TranscriptionResult": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "number"
                 },
-                "search_provider_id": {
+                "language": {
                     "type": "string"
                 },
-                "title_model_id": {
+                "text": {
                     "type": "string"
+                }
+            }
+        },
+        "stt.UpdateModelRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
                 },
-                "tts_model_id": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "settings.UpsertRequest": {
+        "stt.UpdateProviderRequest": {
             "type": "object",
             "properties": {
-                "allow_guest": {
-                    "type": "boolean"
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
                 },
-                "browser_context_id": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "trigger.ListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/trigger.Policy"
+                    }
+                }
+            }
+        },
+        "trigger.Policy": {
+            "type": "object",
+            "properties": {
+                "bot_id": {
                     "type": "string"
                 },
-                "chat_model_id": {
+                "classifier_model_id": {
                     "type": "string"
                 },
-                "compaction_enabled": {
-                    "type": "boolean"
+                "conversation_id": {
+                    "type": "string"
                 },
-                "compaction_model_id": {
+                "conversation_type": {
                     "type": "string"
                 },
-                "compaction_threshold": {
+                "cooldown_seconds": {
                     "type": "integer"
                 },
-                "heartbeat_enabled": {
-                    "type": "boolean"
-                },
-                "heartbeat_interval": {
-                    "type": "integer"
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "heartbeat_model_id": {
+                "listen_instructions": {
                     "type": "string"
                 },
-                "language": {
+                "max_unsolicited_per_hour": {
+                    "type": "integer"
+                },
+                "mode": {
                     "type": "string"
                 },
-                "max_context_load_time": {
-                    "type": "integer"
+                "patterns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "max_context_tokens": {
-                    "type": "integer"
+                "platform": {
+                    "type": "string"
                 },
-                "memory_provider_id": {
+                "route_id": {
                     "type": "string"
                 },
-                "reasoning_effort": {
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "trigger.UpsertRequest": {
+            "type": "object",
+            "properties": {
+                "classifier_model_id": {
                     "type": "string"
                 },
-                "reasoning_enabled": {
-                    "type": "boolean"
+                "cooldown_seconds": {
+                    "type": "integer"
                 },
-                "search_provider_id": {
-                    "type": "string"
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "title_model_id": {
+                "listen_instructions": {
                     "type": "string"
                 },
-                "tts_model_id": {
+                "max_unsolicited_per_hour": {
+                    "type": "integer"
+                },
+                "mode": {
                     "type": "string"
+                },
+                "patterns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -12398,6 +16046,71 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "workspace.NetworkPolicy": {
+            "type": "object",
+            "properties": {
+                "allow": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "auto_allow": {
+                    "description": "AutoAllow lists the hosts allowed on top of Allow in allowlist mode.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspace.ResourceProfile": {
+            "type": "object",
+            "properties": {
+                "cpu_millicores": {
+                    "type": "integer"
+                },
+                "cpu_shares": {
+                    "type": "integer"
+                },
+                "inherited": {
+                    "description": "Inherited is true when the bot has no profile of its own and the\nserver defaults apply.",
+                    "type": "boolean"
+                },
+                "memory_mb": {
+                    "type": "integer"
+                },
+                "pids_limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "workspace.ResourceUsage": {
+            "type": "object",
+            "properties": {
+                "cpu_usage_seconds": {
+                    "type": "number"
+                },
+                "disk_bytes": {
+                    "type": "integer"
+                },
+                "memory_bytes": {
+                    "type": "integer"
+                },
+                "memory_limit_bytes": {
+                    "type": "integer"
+                },
+                "pids": {
+                    "type": "integer"
+                },
+                "pids_limit": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`